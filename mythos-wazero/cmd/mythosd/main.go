@@ -10,6 +10,7 @@ import (
 
 	"github.com/loredanacirstea/wasmx/app"
 	cmd "github.com/loredanacirstea/wasmx/cmdutils"
+	_ "github.com/loredanacirstea/wasmx/x/vmsql/drivers"
 )
 
 func main() {
@@ -0,0 +1,136 @@
+//go:build wasmedge
+
+// The conformance tests need a WasmEdge installation (cgo):
+// go test --tags wasmedge ./conformance/...
+package conformance_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	wt "github.com/loredanacirstea/wasmx/testutil/wasmx"
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/vm/determinism"
+	"github.com/loredanacirstea/wasmx/x/wasmx/vm/determinism/testutils"
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
+
+	wasmedge "github.com/loredanacirstea/wasmx-wasmedge"
+	wazero "github.com/loredanacirstea/wasmx-wazero"
+
+	tinygo "github.com/loredanacirstea/mythos-tests/testdata/tinygo"
+	wasmxtest "github.com/loredanacirstea/mythos-tests/testdata/wasmx"
+	ut "github.com/loredanacirstea/mythos-tests/utils"
+)
+
+// GasTestSuite runs the same contract calls on one wasm runtime and records the gas used
+type GasTestSuite struct {
+	wt.KeeperTestSuite
+	wasmRuntime string
+	gasUsed     map[string]int64
+}
+
+func (suite *GasTestSuite) SetupSuite() {
+	suite.MaxBlockGas = 100_000_000_000
+	suite.SystemContractsModify = ut.SystemContractsModify(suite.wasmRuntime)
+	mydir, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+
+	switch suite.wasmRuntime {
+	case "wasmedge":
+		suite.WasmVmMeta = &wasmedge.WasmEdgeVmMeta{}
+	default:
+		suite.WasmVmMeta = &wazero.WazeroVmMeta{}
+	}
+	suite.CompiledCacheDir = ut.GetCompiledCacheDir(mydir, suite.wasmRuntime)
+	suite.SetupChains()
+}
+
+func (suite *GasTestSuite) TearDownSuite() {
+	suite.TearDownChains()
+}
+
+func (suite *GasTestSuite) TestContractsGasUsed() {
+	sender := suite.GetRandomAccount()
+	appA := suite.AppContext()
+	appA.Faucet.Fund(appA.Context(), appA.BytesToAccAddressPrefixed(sender.Address), sdk.NewCoin(appA.Chain.Config.BaseDenom, sdkmath.NewInt(wt.DEFAULT_BALANCE)))
+
+	codeId := appA.StoreCode(sender, wasmxtest.WasmxSimpleStorage, nil)
+	contractAddress := appA.InstantiateCode(sender, codeId, types.WasmxExecutionMessage{Data: []byte{}}, "simpleStorage", nil)
+	res := appA.ExecuteContract(sender, contractAddress, types.WasmxExecutionMessage{Data: []byte(`{"set":{"key":"hello","value":"sammy"}}`)}, nil, nil)
+	suite.gasUsed["assemblyscript_set"] = res.GasUsed
+
+	codeId = appA.StoreCode(sender, tinygo.TinyGoSimpleStorage, nil)
+	contractAddress = appA.InstantiateCode(sender, codeId, types.WasmxExecutionMessage{Data: []byte("hello")}, "tinygoSimpleStorage", nil)
+	res = appA.ExecuteContract(sender, contractAddress, types.WasmxExecutionMessage{Data: []byte(`{"store":{"key":"storagekey","value":"goodbye"}}`)}, nil, nil)
+	suite.gasUsed["tinygo_store"] = res.GasUsed
+
+	codeId = appA.StoreCode(sender, tinygo.TinyGoAdd, nil)
+	contractAddress = appA.InstantiateCode(sender, codeId, types.WasmxExecutionMessage{Data: []byte{}}, "tinygoAdd", nil)
+	res = appA.ExecuteContract(sender, contractAddress, types.WasmxExecutionMessage{Data: []byte{}}, nil, nil)
+	suite.gasUsed["tinygo_add"] = res.GasUsed
+}
+
+// TestGasParity asserts that wazero and WasmEdge charge the same gas for the same calls
+// of stored contracts, which have injected gas metering
+func TestGasParity(t *testing.T) {
+	results := map[string]map[string]int64{}
+	for _, wasmRuntime := range []string{"wazero", "wasmedge"} {
+		s := &GasTestSuite{wasmRuntime: wasmRuntime, gasUsed: map[string]int64{}}
+		suite.Run(t, s)
+		results[wasmRuntime] = s.gasUsed
+	}
+	require.NotEmpty(t, results["wazero"])
+	require.Equal(t, results["wazero"], results["wasmedge"])
+}
+
+type loopResult struct {
+	gasUsed  uint64
+	outOfGas bool
+}
+
+func runLoop(t *testing.T, meta memc.IWasmVmMeta, aot bool, wasm []byte, n int32, gasLimit uint64) (res loopResult) {
+	ctx := sdk.Context{}.WithContext(context.Background())
+	vm := meta.NewWasmVm(ctx, aot)
+	defer vm.Cleanup()
+	require.NoError(t, vm.InstantiateWasm("", "", wasm))
+	gasMeter := wazero.NewGasMeter(gasLimit, 0, nil)
+	defer func() {
+		if r := recover(); r != nil {
+			require.Equal(t, wazero.ErrOutOfGas, r)
+			res.outOfGas = true
+		}
+	}()
+	_, err := vm.Call("run", []interface{}{n}, gasMeter)
+	require.NoError(t, err)
+	return loopResult{gasUsed: gasMeter.GasConsumed()}
+}
+
+// TestInstructionGasParity asserts that all engines charge the same gas for a module
+// with injected gas metering and run out of gas at the same point
+func TestInstructionGasParity(t *testing.T) {
+	metered, err := determinism.InjectGasMetering(testutils.Loop)
+	require.NoError(t, err)
+	metas := []memc.IWasmVmMeta{&wazero.WazeroVmMeta{}, &wasmedge.WasmEdgeVmMeta{}}
+	for _, meta := range metas {
+		meta.InitWasmRuntime(context.Background(), memc.WasmRuntimeConfig{})
+	}
+	gasLimits := []uint64{1_000_000, testutils.LoopGas(100), testutils.LoopGas(100) - 1, testutils.LoopGas(50)}
+	for _, gasLimit := range gasLimits {
+		expected := runLoop(t, metas[0], false, metered, 100, gasLimit)
+		for _, meta := range metas {
+			for _, aot := range []bool{false, true} {
+				require.Equal(t, expected, runLoop(t, meta, aot, metered, 100, gasLimit), "%s aot=%v gas limit %d", meta.LibVersion(), aot, gasLimit)
+			}
+		}
+	}
+}
@@ -1,22 +1,37 @@
 package keeper_test
 
 import (
+	"context"
 	_ "embed"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"strings"
+	"time"
 
 	sdkmath "cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	simulation "github.com/cosmos/cosmos-sdk/types/simulation"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 
+	cmtconfig "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 	cmttypes "github.com/cometbft/cometbft/types"
+	dbm "github.com/cosmos/cosmos-db"
 
+	"github.com/loredanacirstea/wasmx/app"
 	mcfg "github.com/loredanacirstea/wasmx/config"
+	multichain "github.com/loredanacirstea/wasmx/multichain"
 
 	testdata "github.com/loredanacirstea/mythos-tests/network/testdata/wasmx"
 	ut "github.com/loredanacirstea/wasmx/testutil/wasmx"
+	networkkeeper "github.com/loredanacirstea/wasmx/x/network/keeper"
 	networkserver "github.com/loredanacirstea/wasmx/x/network/server"
+	"github.com/loredanacirstea/wasmx/x/network/vmp2p"
 	wasmxtypes "github.com/loredanacirstea/wasmx/x/wasmx/types"
 )
 
@@ -74,6 +89,293 @@ func (suite *KeeperTestSuite) TestBlockHeader() {
 	suite.Require().NoError(err)
 }
 
+func (suite *KeeperTestSuite) TestBlockStore() {
+	wasmbin := testdata.WasmxSimpleStorage
+	chainId := mcfg.MYTHOS_CHAIN_ID_TEST
+	suite.SetCurrentChain(chainId)
+	chain := suite.GetChain(chainId)
+
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+	sender := simulation.Account{
+		PrivKey: chain.SenderPrivKey,
+		PubKey:  chain.SenderAccount.GetPubKey(),
+		Address: chain.SenderAccount.GetAddress(),
+	}
+
+	appA := s.GetAppContext(chain)
+	senderPrefixed := appA.BytesToAccAddressPrefixed(sender.Address)
+	appA.Faucet.Fund(appA.Context(), senderPrefixed, sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
+
+	codeId := appA.StoreCode(sender, wasmbin, nil)
+	appA.InstantiateCode(sender, codeId, wasmxtypes.WasmxExecutionMessage{Data: []byte(fmt.Sprintf(`{"crosschain_contract":"%s"}`, wasmxtypes.ROLE_MULTICHAIN_REGISTRY))}, "wasmbin", nil)
+
+	abcicli := appA.ABCIClient()
+	lastHeight, err := abcicli.LatestBlockHeight(appA.Context())
+	suite.Require().NoError(err)
+
+	// blocks are saved asynchronously, after commit
+	store := appA.App.GetBlockStore()
+	suite.Require().NotNil(store)
+	suite.Require().Eventually(func() bool {
+		return store.Height() >= lastHeight
+	}, 10*time.Second, 100*time.Millisecond)
+
+	height := lastHeight - 1
+	block, err := abcicli.Block(appA.Context(), &height)
+	suite.Require().NoError(err)
+
+	storedBlock := store.LoadBlock(height)
+	suite.Require().NotNil(storedBlock)
+	suite.Require().Equal(block.Block.Hash(), storedBlock.Hash())
+	suite.Require().Equal(storedBlock.Hash(), store.LoadBlockByHash(storedBlock.Hash()).Hash())
+	// blocks are indexed by the hash the rpc BlockIDs report
+	suite.Require().Equal(block.BlockID.Hash, storedBlock.Hash())
+	suite.Require().Equal(height, store.LoadBlockMetaByHash(block.BlockID.Hash).Header.Height)
+
+	// canonical commit, from the next block
+	commit := store.LoadBlockCommit(height)
+	suite.Require().NotNil(commit)
+	suite.Require().Equal(height, commit.Height)
+
+	// seen commit for the latest block
+	seenCommit := store.LoadSeenCommit(lastHeight)
+	suite.Require().NotNil(seenCommit)
+	suite.Require().Equal(lastHeight, seenCommit.Height)
+
+	blockByHash, err := abcicli.BlockByHash(appA.Context(), storedBlock.Hash())
+	suite.Require().NoError(err)
+	suite.Require().Equal(height, blockByHash.Block.Height)
+}
+
+func (suite *KeeperTestSuite) TestBlockHeaderAndSearch() {
+	wasmbin := testdata.WasmxSimpleStorage
+	chainId := mcfg.MYTHOS_CHAIN_ID_TEST
+	suite.SetCurrentChain(chainId)
+	chain := suite.GetChain(chainId)
+
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+	sender := simulation.Account{
+		PrivKey: chain.SenderPrivKey,
+		PubKey:  chain.SenderAccount.GetPubKey(),
+		Address: chain.SenderAccount.GetAddress(),
+	}
+
+	appA := s.GetAppContext(chain)
+	senderPrefixed := appA.BytesToAccAddressPrefixed(sender.Address)
+	appA.Faucet.Fund(appA.Context(), senderPrefixed, sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
+	appA.StoreCode(sender, wasmbin, nil)
+
+	abcicli := appA.ABCIClient()
+	lastHeight, err := abcicli.LatestBlockHeight(appA.Context())
+	suite.Require().NoError(err)
+
+	// blocks are saved and indexed asynchronously, after commit
+	store := appA.App.GetBlockStore()
+	suite.Require().NotNil(store)
+	suite.Require().Eventually(func() bool {
+		return store.Height() >= lastHeight
+	}, 10*time.Second, 100*time.Millisecond)
+
+	block, err := abcicli.Block(appA.Context(), &lastHeight)
+	suite.Require().NoError(err)
+
+	header, err := abcicli.Header(appA.Context(), &lastHeight)
+	suite.Require().NoError(err)
+	suite.Require().Equal(block.Block.Header.Hash(), header.Header.Hash())
+
+	storedBlock := store.LoadBlock(lastHeight)
+	suite.Require().NotNil(storedBlock)
+	headerByHash, err := abcicli.HeaderByHash(appA.Context(), storedBlock.Hash())
+	suite.Require().NoError(err)
+	suite.Require().Equal(lastHeight, headerByHash.Header.Height)
+
+	headerByHash, err = abcicli.HeaderByHash(appA.Context(), []byte{1, 2, 3})
+	suite.Require().NoError(err)
+	suite.Require().Nil(headerByHash.Header)
+
+	// the block is indexed after it is saved
+	indexer := appA.App.GetBlockIndexer()
+	suite.Require().NotNil(indexer)
+	suite.Require().Eventually(func() bool {
+		indexed, err := indexer.Has(lastHeight)
+		return err == nil && indexed
+	}, 10*time.Second, 100*time.Millisecond)
+
+	query := fmt.Sprintf("block.height > %d", lastHeight-2)
+	res, err := abcicli.BlockSearch(appA.Context(), query, nil, nil, "asc")
+	suite.Require().NoError(err)
+	suite.Require().Equal(2, res.TotalCount)
+	suite.Require().Len(res.Blocks, 2)
+	suite.Require().Equal(lastHeight-1, res.Blocks[0].Block.Height)
+	suite.Require().Equal(lastHeight, res.Blocks[1].Block.Height)
+
+	page := 2
+	perPage := 1
+	res, err = abcicli.BlockSearch(appA.Context(), query, &page, &perPage, "")
+	suite.Require().NoError(err)
+	suite.Require().Equal(2, res.TotalCount)
+	suite.Require().Len(res.Blocks, 1)
+	suite.Require().Equal(lastHeight-1, res.Blocks[0].Block.Height)
+
+	_, err = abcicli.BlockSearch(appA.Context(), query, nil, nil, "random")
+	suite.Require().ErrorContains(err, "expected order_by")
+}
+
+func (suite *KeeperTestSuite) TestUnconfirmedTxs() {
+	chainId := mcfg.MYTHOS_CHAIN_ID_TEST
+	suite.SetCurrentChain(chainId)
+	chain := suite.GetChain(chainId)
+	appA := s.GetAppContext(chain)
+	abcicli := appA.ABCIClient()
+
+	// test blocks are finalized with their transactions, so the mempool is empty
+	res, err := abcicli.UnconfirmedTxs(appA.Context(), nil)
+	suite.Require().NoError(err)
+	suite.Require().Equal(0, res.Count)
+	suite.Require().Equal(0, res.Total)
+	suite.Require().Len(res.Txs, 0)
+
+	num, err := abcicli.NumUnconfirmedTxs(appA.Context())
+	suite.Require().NoError(err)
+	suite.Require().Equal(0, num.Total)
+	suite.Require().Equal(int64(0), num.TotalBytes)
+
+	// a broadcasted transaction stays in the mempool until the next block
+	sender := simulation.Account{
+		PrivKey: chain.SenderPrivKey,
+		PubKey:  chain.SenderAccount.GetPubKey(),
+		Address: chain.SenderAccount.GetAddress(),
+	}
+	senderPrefixed := appA.BytesToAccAddressPrefixed(sender.Address)
+	appA.Faucet.Fund(appA.Context(), senderPrefixed, sdk.NewCoin(appA.Chain.Config.BaseDenom, sdkmath.NewInt(ut.DEFAULT_BALANCE)))
+	msg := &banktypes.MsgSend{
+		FromAddress: senderPrefixed.String(),
+		ToAddress:   senderPrefixed.String(),
+		Amount:      sdk.NewCoins(sdk.NewCoin(appA.Chain.Config.BaseDenom, sdkmath.NewInt(1))),
+	}
+	txbz := appA.PrepareCosmosTx(sender, []sdk.Msg{msg}, nil, nil, "")
+	_, err = abcicli.BroadcastTxAsync(appA.Context(), txbz)
+	suite.Require().NoError(err)
+
+	res, err = abcicli.UnconfirmedTxs(appA.Context(), nil)
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, res.Count)
+	suite.Require().Equal(1, res.Total)
+	suite.Require().Equal(int64(len(txbz)), res.TotalBytes)
+	suite.Require().Len(res.Txs, 1)
+	suite.Require().Equal(cmttypes.Tx(txbz), res.Txs[0])
+
+	num, err = abcicli.NumUnconfirmedTxs(appA.Context())
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, num.Total)
+	suite.Require().Equal(int64(len(txbz)), num.TotalBytes)
+
+	commitres, err := appA.Chain.CommitBlock()
+	suite.Require().NoError(err)
+	suite.Require().Len(commitres.TxResults, 1)
+	suite.Require().True(commitres.TxResults[0].IsOK(), commitres.TxResults[0].Log)
+
+	num, err = abcicli.NumUnconfirmedTxs(appA.Context())
+	suite.Require().NoError(err)
+	suite.Require().Equal(0, num.Total)
+}
+
+func (suite *KeeperTestSuite) TestBlockSync() {
+	wasmbin := testdata.WasmxSimpleStorage
+	chainId := mcfg.MYTHOS_CHAIN_ID_TEST
+	suite.SetCurrentChain(chainId)
+	chain := suite.GetChain(chainId)
+
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+	sender := simulation.Account{
+		PrivKey: chain.SenderPrivKey,
+		PubKey:  chain.SenderAccount.GetPubKey(),
+		Address: chain.SenderAccount.GetAddress(),
+	}
+
+	appA := s.GetAppContext(chain)
+	senderPrefixed := appA.BytesToAccAddressPrefixed(sender.Address)
+	appA.Faucet.Fund(appA.Context(), senderPrefixed, sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
+	codeId := appA.StoreCode(sender, wasmbin, nil)
+
+	// the second node stops at this height
+	syncHeight := appA.App.LastBlockHeight()
+	dbB := copyDB(suite, appA.App.Db())
+
+	appA.InstantiateCode(sender, codeId, wasmxtypes.WasmxExecutionMessage{Data: []byte{}}, "simpleStorage", nil)
+	appA.InstantiateCode(sender, codeId, wasmxtypes.WasmxExecutionMessage{Data: []byte{}}, "simpleStorage", nil)
+
+	lastHeight, err := appA.ABCIClient().LatestBlockHeight(appA.Context())
+	suite.Require().NoError(err)
+	suite.Require().Greater(lastHeight, syncHeight+1)
+
+	// serve blocks from this node
+	port := "5999"
+	protocolId := mcfg.GetBlockSyncProtocolId(chainId)
+	goctx := appA.App.GetGoContextParent()
+	err = vmp2p.InitializeBlockSyncProvider(goctx, appA.App.Logger(), appA.App, protocolId, ed25519.GenPrivKey().Bytes(), port)
+	suite.Require().NoError(err)
+	p2pctx, err := vmp2p.GetP2PContext(goctx)
+	suite.Require().NoError(err)
+	peeraddress := fmt.Sprintf("/ip4/127.0.0.1/tcp/%s/p2p/%s", port, (*p2pctx.Node).ID().String())
+
+	client, err := vmp2p.NewBlockSyncClient(protocolId, peeraddress)
+	suite.Require().NoError(err)
+	defer client.Close()
+
+	startHeight := lastHeight - 3
+	resp, err := client.Request(startHeight, lastHeight)
+	suite.Require().NoError(err)
+	suite.Require().GreaterOrEqual(resp.LastHeight, lastHeight)
+	suite.Require().Equal(4, len(resp.Blocks))
+
+	cdc := appA.App.GetNetworkKeeper().Codec()
+	verifier := vmp2p.NewClientVerification(appA.App, appA.App.Logger(), nil)
+	trusted, err := vmp2p.DecodeBlockSyncEntry(cdc, chainId, resp.Blocks[0])
+	suite.Require().NoError(err)
+	suite.Require().Equal(startHeight, trusted.Block.Height)
+
+	// a block must extend the trusted block
+	b, err := vmp2p.DecodeBlockSyncEntry(cdc, chainId, resp.Blocks[2])
+	suite.Require().NoError(err)
+	err = vmp2p.VerifyBlockSyncBlock(verifier, chainId, trusted.Anchor(), b)
+	suite.Require().ErrorContains(err, "block height mismatch")
+
+	forged := trusted.Anchor()
+	forged.Height = b.Block.Height - 1
+	err = vmp2p.VerifyBlockSyncBlock(verifier, chainId, forged, b)
+	suite.Require().ErrorContains(err, "last_block_id mismatch")
+
+	// the second node replays the blocks it missed
+	_, appCreator := multichain.CreateMockAppCreator(suite.WasmVmMeta, app.NewAppCreator, suite.T().TempDir(), func(dbpath string) dbm.DB { return dbB })
+	appB := appCreator(chainId, &chain.Config)
+	defer appB.Teardown()
+	suite.Require().Equal(syncHeight, appB.GetBaseApp().LastBlockHeight())
+
+	replayed, err := vmp2p.StartBlockSync(appB.GetGoContextParent(), appA.App.Logger(), appB, protocolId, peeraddress)
+	suite.Require().NoError(err)
+	suite.Require().Equal(lastHeight-syncHeight, replayed)
+	suite.Require().Equal(lastHeight, appB.GetBaseApp().LastBlockHeight())
+	suite.Require().Equal(appA.App.LastCommitID().Hash, appB.GetBaseApp().LastCommitID().Hash)
+
+	// the provider has no newer blocks
+	resp, err = client.Request(lastHeight+1, lastHeight+1)
+	suite.Require().NoError(err)
+	suite.Require().Equal(0, len(resp.Blocks))
+}
+
+// copyDB copies the node's database, so a second node can start from the same state
+func copyDB(suite *KeeperTestSuite, src dbm.DB) dbm.DB {
+	dst := dbm.NewMemDB()
+	iter, err := src.Iterator(nil, nil)
+	suite.Require().NoError(err)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		suite.Require().NoError(dst.Set(iter.Key(), iter.Value()))
+	}
+	return dst
+}
+
 func (suite *KeeperTestSuite) TestStateSyncBootstrap() {
 	statestr := `{"Version":{"consensus":{"block":11},"software":"0.38.6"},"ChainID":"mythos_7000-14","InitialHeight":1,"LastBlockHeight":10,"LastBlockID":{"hash":"F3EDBE8D0B34156827D2693A73E809C7A9C783DB06409060B173139F6EBDAA59","parts":{"total":1,"hash":"F3EDBE8D0B34156827D2693A73E809C7A9C783DB06409060B173139F6EBDAA59"}},"LastBlockTime":"2024-07-29T14:37:43.302Z","NextValidators":"eyJ2YWxpZGF0b3JzIjpbeyJhZGRyZXNzIjoiNmFkMDlmYTZjOThiZDNmZTYyMzM2MTg2NjU2Yjg2MWZmMzkzNzE0NiIsInB1Yl9rZXkiOnsidHlwZV91cmwiOiIvY29zbW9zLmNyeXB0by5lZDI1NTE5LlB1YktleSIsInZhbHVlIjoiZXlKclpYa2lPaUo2Y0cxUk5VTm9WeXRFYWtKSWRrMXlhVGRKY2pKdllrbE5aMjlZVTBsVldsRnliRFo0VFdaMWFWSnZQU0o5In0sInZvdGluZ19wb3dlciI6IjEwMDAwMDAwMDAwMDAwMCIsInByb3Bvc2VyX3ByaW9yaXR5IjoiMCJ9XSwicHJvcG9zZXIiOnsiYWRkcmVzcyI6IjZhZDA5ZmE2Yzk4YmQzZmU2MjMzNjE4NjY1NmI4NjFmZjM5MzcxNDYiLCJwdWJfa2V5Ijp7InR5cGVfdXJsIjoiL2Nvc21vcy5jcnlwdG8uZWQyNTUxOS5QdWJLZXkiLCJ2YWx1ZSI6ImV5SnJaWGtpT2lKNmNHMVJOVU5vVnl0RWFrSklkazF5YVRkSmNqSnZZa2xOWjI5WVUwbFZXbEZ5YkRaNFRXWjFhVkp2UFNKOSJ9LCJ2b3RpbmdfcG93ZXIiOiIxMDAwMDAwMDAwMDAwMDAiLCJwcm9wb3Nlcl9wcmlvcml0eSI6IjAifX0=","Validators":"eyJ2YWxpZGF0b3JzIjpbeyJhZGRyZXNzIjoiNmFkMDlmYTZjOThiZDNmZTYyMzM2MTg2NjU2Yjg2MWZmMzkzNzE0NiIsInB1Yl9rZXkiOnsidHlwZV91cmwiOiIvY29zbW9zLmNyeXB0by5lZDI1NTE5LlB1YktleSIsInZhbHVlIjoiZXlKclpYa2lPaUo2Y0cxUk5VTm9WeXRFYWtKSWRrMXlhVGRKY2pKdllrbE5aMjlZVTBsVldsRnliRFo0VFdaMWFWSnZQU0o5In0sInZvdGluZ19wb3dlciI6IjEwMDAwMDAwMDAwMDAwMCIsInByb3Bvc2VyX3ByaW9yaXR5IjoiMCJ9XSwicHJvcG9zZXIiOnsiYWRkcmVzcyI6IjZhZDA5ZmE2Yzk4YmQzZmU2MjMzNjE4NjY1NmI4NjFmZjM5MzcxNDYiLCJwdWJfa2V5Ijp7InR5cGVfdXJsIjoiL2Nvc21vcy5jcnlwdG8uZWQyNTUxOS5QdWJLZXkiLCJ2YWx1ZSI6ImV5SnJaWGtpT2lKNmNHMVJOVU5vVnl0RWFrSklkazF5YVRkSmNqSnZZa2xOWjI5WVUwbFZXbEZ5YkRaNFRXWjFhVkp2UFNKOSJ9LCJ2b3RpbmdfcG93ZXIiOiIxMDAwMDAwMDAwMDAwMDAiLCJwcm9wb3Nlcl9wcmlvcml0eSI6IjAifX0=","LastValidators":"eyJ2YWxpZGF0b3JzIjpbeyJhZGRyZXNzIjoiNmFkMDlmYTZjOThiZDNmZTYyMzM2MTg2NjU2Yjg2MWZmMzkzNzE0NiIsInB1Yl9rZXkiOnsidHlwZV91cmwiOiIvY29zbW9zLmNyeXB0by5lZDI1NTE5LlB1YktleSIsInZhbHVlIjoiZXlKclpYa2lPaUo2Y0cxUk5VTm9WeXRFYWtKSWRrMXlhVGRKY2pKdllrbE5aMjlZVTBsVldsRnliRFo0VFdaMWFWSnZQU0o5In0sInZvdGluZ19wb3dlciI6IjEwMDAwMDAwMDAwMDAwMCIsInByb3Bvc2VyX3ByaW9yaXR5IjoiMCJ9XSwicHJvcG9zZXIiOnsiYWRkcmVzcyI6IjZhZDA5ZmE2Yzk4YmQzZmU2MjMzNjE4NjY1NmI4NjFmZjM5MzcxNDYiLCJwdWJfa2V5Ijp7InR5cGVfdXJsIjoiL2Nvc21vcy5jcnlwdG8uZWQyNTUxOS5QdWJLZXkiLCJ2YWx1ZSI6ImV5SnJaWGtpT2lKNmNHMVJOVU5vVnl0RWFrSklkazF5YVRkSmNqSnZZa2xOWjI5WVUwbFZXbEZ5YkRaNFRXWjFhVkp2UFNKOSJ9LCJ2b3RpbmdfcG93ZXIiOiIxMDAwMDAwMDAwMDAwMDAiLCJwcm9wb3Nlcl9wcmlvcml0eSI6IjAifX0=","LastHeightValidatorsChanged":12,"ConsensusParams":{"block":{"max_bytes":22020096,"max_gas":-1},"evidence":{"max_age_num_blocks":100000,"max_age_duration":172800000000000,"max_bytes":1048576},"validator":{"pub_key_types":["ed25519"]},"version":{"app":0},"abci":{"vote_extensions_enable_height":0}},"LastHeightConsensusParamsChanged":11,"LastResultsHash":"47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=","AppHash":"1i83m5cD/4e8D5zzYHKRsi47lIwd1G9msitiP8gV03I="}`
 
@@ -87,3 +389,231 @@ func (suite *KeeperTestSuite) TestStateSyncBootstrap() {
 	err := networkserver.ConsensusTx(appA.App, s.App().Logger(), appA.App.GetNetworkKeeper(), msg)
 	s.Require().NoError(err)
 }
+
+func (suite *KeeperTestSuite) TestBlockEventsPublish() {
+	wasmbin := testdata.WasmxSimpleStorage
+	chainId := mcfg.MYTHOS_CHAIN_ID_TEST
+	suite.SetCurrentChain(chainId)
+	chain := suite.GetChain(chainId)
+
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+	sender := simulation.Account{
+		PrivKey: chain.SenderPrivKey,
+		PubKey:  chain.SenderAccount.GetPubKey(),
+		Address: chain.SenderAccount.GetAddress(),
+	}
+
+	appA := s.GetAppContext(chain)
+	denom := appA.Chain.Config.BaseDenom
+	senderPrefixedLevel0 := appA.BytesToAccAddressPrefixed(sender.Address)
+	appA.Faucet.Fund(appA.Context(), senderPrefixedLevel0, sdk.NewCoin(denom, initBalance))
+
+	eventBus := appA.App.GetEventBus()
+	suite.Require().True(eventBus.IsRunning())
+
+	ctx := context.Background()
+	subscriber := "test-subscriber"
+	blockSub, err := eventBus.Subscribe(ctx, subscriber, cmttypes.EventQueryNewBlock, 10)
+	suite.Require().NoError(err)
+	txSub, err := eventBus.Subscribe(ctx, subscriber, cmttypes.EventQueryTx, 10)
+	suite.Require().NoError(err)
+	defer func() {
+		err := eventBus.UnsubscribeAll(ctx, subscriber)
+		suite.Require().NoError(err)
+	}()
+
+	publisher := networkkeeper.NewBlockEventPublisher(appA.App, eventBus, appA.App.Logger())
+	// first publish only records the current height
+	err = publisher.Publish(appA.Context())
+	suite.Require().NoError(err)
+	drainEvents(blockSub.Out())
+	drainEvents(txSub.Out())
+
+	appA.StoreCode(sender, wasmbin, nil)
+	lastHeight, err := appA.ABCIClient().LatestBlockHeight(appA.Context())
+	suite.Require().NoError(err)
+
+	err = publisher.Publish(appA.Context())
+	suite.Require().NoError(err)
+
+	var lastBlockHeight int64
+	foundTx := false
+	timeout := time.After(time.Second * 5)
+	for lastBlockHeight < lastHeight || !foundTx {
+		select {
+		case msg := <-blockSub.Out():
+			data, ok := msg.Data().(cmttypes.EventDataNewBlock)
+			suite.Require().True(ok)
+			suite.Require().Equal(data.Block.Hash(), data.BlockID.Hash)
+			lastBlockHeight = data.Block.Height
+		case msg := <-txSub.Out():
+			data, ok := msg.Data().(cmttypes.EventDataTx)
+			suite.Require().True(ok)
+			suite.Require().True(data.Result.IsOK())
+			suite.Require().Contains(msg.Events(), cmttypes.TxHashKey)
+			foundTx = true
+		case <-timeout:
+			suite.Require().Fail("timed out waiting for block events", "last block height %d, found tx: %v", lastBlockHeight, foundTx)
+		}
+	}
+	suite.Require().Equal(lastHeight, lastBlockHeight)
+}
+
+// testWSConn collects the responses written to a websocket subscription
+type testWSConn struct {
+	responses chan rpctypes.RPCResponse
+}
+
+func (c *testWSConn) GetRemoteAddr() string { return "test-ws-client" }
+func (c *testWSConn) WriteRPCResponse(_ context.Context, resp rpctypes.RPCResponse) error {
+	c.responses <- resp
+	return nil
+}
+func (c *testWSConn) TryWriteRPCResponse(resp rpctypes.RPCResponse) bool {
+	select {
+	case c.responses <- resp:
+		return true
+	default:
+		return false
+	}
+}
+func (c *testWSConn) Context() context.Context { return context.Background() }
+
+func (suite *KeeperTestSuite) TestBlockEventsSubscribe() {
+	wasmbin := testdata.WasmxSimpleStorage
+	chainId := mcfg.MYTHOS_CHAIN_ID_TEST
+	suite.SetCurrentChain(chainId)
+	chain := suite.GetChain(chainId)
+
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+	sender := simulation.Account{
+		PrivKey: chain.SenderPrivKey,
+		PubKey:  chain.SenderAccount.GetPubKey(),
+		Address: chain.SenderAccount.GetAddress(),
+	}
+
+	appA := s.GetAppContext(chain)
+	denom := appA.Chain.Config.BaseDenom
+	senderPrefixedLevel0 := appA.BytesToAccAddressPrefixed(sender.Address)
+	appA.Faucet.Fund(appA.Context(), senderPrefixedLevel0, sdk.NewCoin(denom, initBalance))
+
+	eventBus := appA.App.GetEventBus()
+	rpcConfig := cmtconfig.DefaultConfig()
+	rpcConfig.RPC.MaxSubscriptionsPerClient = 1
+	env := networkkeeper.NewEnvironment(appA.App, appA.ABCIClient(), rpcConfig, nil, eventBus, appA.App.Logger())
+
+	lastHeight, err := appA.ABCIClient().LatestBlockHeight(appA.Context())
+	suite.Require().NoError(err)
+
+	wsConn := &testWSConn{responses: make(chan rpctypes.RPCResponse, 10)}
+	rpcCtx := &rpctypes.Context{JSONReq: &rpctypes.RPCRequest{ID: rpctypes.JSONRPCIntID(1)}, WSConn: wsConn}
+	query := fmt.Sprintf("tm.event='Tx' AND tx.height>%d", lastHeight)
+	_, err = env.Subscribe(rpcCtx, query)
+	suite.Require().NoError(err)
+	defer func() {
+		_, err := env.UnsubscribeAll(rpcCtx)
+		suite.Require().NoError(err)
+	}()
+
+	// subscription limits
+	_, err = env.Subscribe(rpcCtx, "tm.event='NewBlock'")
+	suite.Require().ErrorContains(err, "max_subscriptions_per_client 1 reached")
+	_, err = env.Subscribe(rpcCtx, "tm.event='Tx' AND tx.hash='"+strings.Repeat("A", 512)+"'")
+	suite.Require().ErrorContains(err, "maximum query length exceeded")
+
+	// the events are published by the app after each commit
+	appA.StoreCode(sender, wasmbin, nil)
+
+	select {
+	case resp := <-wsConn.responses:
+		suite.Require().Nil(resp.Error)
+		var result ctypes.ResultEvent
+		err = cmtjson.Unmarshal(resp.Result, &result)
+		suite.Require().NoError(err)
+		suite.Require().Equal(query, result.Query)
+		data, ok := result.Data.(cmttypes.EventDataTx)
+		suite.Require().True(ok)
+		suite.Require().Greater(data.Height, lastHeight)
+		suite.Require().True(data.Result.IsOK())
+		suite.Require().Contains(result.Events, cmttypes.TxHashKey)
+	case <-time.After(time.Second * 5):
+		suite.Require().Fail("timed out waiting for the subscribed tx event")
+	}
+}
+
+func (suite *KeeperTestSuite) TestBlockResultsAndEventsClient() {
+	wasmbin := testdata.WasmxSimpleStorage
+	chainId := mcfg.MYTHOS_CHAIN_ID_TEST
+	suite.SetCurrentChain(chainId)
+	chain := suite.GetChain(chainId)
+
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+	sender := simulation.Account{
+		PrivKey: chain.SenderPrivKey,
+		PubKey:  chain.SenderAccount.GetPubKey(),
+		Address: chain.SenderAccount.GetAddress(),
+	}
+
+	appA := s.GetAppContext(chain)
+	denom := appA.Chain.Config.BaseDenom
+	senderPrefixedLevel0 := appA.BytesToAccAddressPrefixed(sender.Address)
+	appA.Faucet.Fund(appA.Context(), senderPrefixedLevel0, sdk.NewCoin(denom, initBalance))
+
+	abcicli := appA.ABCIClient()
+	ctx := context.Background()
+	subscriber := "test-events-client"
+	blockCh, err := abcicli.Subscribe(ctx, subscriber, cmttypes.EventQueryNewBlock.String(), 10)
+	suite.Require().NoError(err)
+
+	publisher := networkkeeper.NewBlockEventPublisher(appA.App, appA.App.GetEventBus(), appA.App.Logger())
+	err = publisher.Publish(appA.Context())
+	suite.Require().NoError(err)
+
+	appA.StoreCode(sender, wasmbin, nil)
+	lastHeight, err := abcicli.LatestBlockHeight(appA.Context())
+	suite.Require().NoError(err)
+
+	blockRes, err := abcicli.BlockResults(appA.Context(), &lastHeight)
+	suite.Require().NoError(err)
+	suite.Require().Equal(lastHeight, blockRes.Height)
+	suite.Require().Len(blockRes.TxsResults, 1)
+	suite.Require().True(blockRes.TxsResults[0].IsOK())
+
+	err = publisher.Publish(appA.Context())
+	suite.Require().NoError(err)
+
+	timeout := time.After(time.Second * 5)
+	var lastBlockHeight int64
+	for lastBlockHeight < lastHeight {
+		select {
+		case ev := <-blockCh:
+			data, ok := ev.Data.(cmttypes.EventDataNewBlock)
+			suite.Require().True(ok)
+			lastBlockHeight = data.Block.Height
+		case <-timeout:
+			suite.Require().Fail("timed out waiting for new block events", "last block height %d", lastBlockHeight)
+		}
+	}
+
+	err = abcicli.UnsubscribeAll(ctx, subscriber)
+	suite.Require().NoError(err)
+	// the events channel is closed after unsubscribing
+	suite.Require().Eventually(func() bool {
+		select {
+		case _, ok := <-blockCh:
+			return !ok
+		default:
+			return false
+		}
+	}, time.Second*5, time.Millisecond*50)
+}
+
+func drainEvents(out <-chan cmtpubsub.Message) {
+	for {
+		select {
+		case <-out:
+		default:
+			return
+		}
+	}
+}
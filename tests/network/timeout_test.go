@@ -0,0 +1,140 @@
+package keeper_test
+
+import (
+	"time"
+
+	simulation "github.com/cosmos/cosmos-sdk/types/simulation"
+
+	mcfg "github.com/loredanacirstea/wasmx/config"
+	mctx "github.com/loredanacirstea/wasmx/context"
+	networkkeeper "github.com/loredanacirstea/wasmx/x/network/keeper"
+	"github.com/loredanacirstea/wasmx/x/network/types"
+)
+
+func (suite *KeeperTestSuite) TestPersistedTimeouts() {
+	chainId := mcfg.MYTHOS_CHAIN_ID_TEST
+	suite.SetCurrentChain(chainId)
+	chain := suite.GetChain(chainId)
+	appA := s.GetAppContext(chain)
+
+	sender := simulation.Account{
+		PrivKey: chain.SenderPrivKey,
+		PubKey:  chain.SenderAccount.GetPubKey(),
+		Address: chain.SenderAccount.GetAddress(),
+	}
+	contract := appA.BytesToAccAddressPrefixed(sender.Address).String()
+	keeper := &suite.App().NetworkKeeper
+
+	start := time.Now()
+	_, err := keeper.StartTimeout(appA.Context(), &types.MsgStartTimeoutRequest{
+		Sender:   contract,
+		Contract: contract,
+		Delay:    3600000,
+		Args:     []byte(`{"delay":"roundTimeout"}`),
+		Id:       "1",
+	})
+	suite.Require().NoError(err)
+
+	res, err := keeper.PendingTimeouts(appA.Context(), &types.QueryPendingTimeoutsRequest{Contract: contract})
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Timeouts, 1)
+	suite.Require().Equal("1", res.Timeouts[0].Id)
+	suite.Require().Equal(contract, res.Timeouts[0].Contract)
+	suite.Require().Equal([]byte(`{"delay":"roundTimeout"}`), res.Timeouts[0].Args)
+	suite.Require().GreaterOrEqual(res.Timeouts[0].Deadline, start.Add(time.Hour).UnixMilli())
+
+	// already armed timeouts are not started twice
+	err = keeper.RestoreTimeouts(appA.Context())
+	suite.Require().NoError(err)
+
+	_, err = keeper.CancelTimeout(appA.Context(), &types.MsgCancelTimeoutRequest{Sender: contract, Id: "1"})
+	suite.Require().NoError(err)
+
+	res, err = keeper.PendingTimeouts(appA.Context(), &types.QueryPendingTimeoutsRequest{Contract: contract})
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Timeouts, 0)
+}
+
+func (suite *KeeperTestSuite) TestTimeoutIdReused() {
+	chainId := mcfg.MYTHOS_CHAIN_ID_TEST
+	suite.SetCurrentChain(chainId)
+	chain := suite.GetChain(chainId)
+	appA := s.GetAppContext(chain)
+
+	contract := appA.BytesToAccAddressPrefixed(chain.SenderAccount.GetAddress()).String()
+	keeper := &suite.App().NetworkKeeper
+
+	_, err := keeper.StartTimeout(appA.Context(), &types.MsgStartTimeoutRequest{
+		Sender:   contract,
+		Contract: contract,
+		Delay:    200,
+		Args:     []byte(`{"delay":"first"}`),
+		Id:       "2",
+	})
+	suite.Require().NoError(err)
+	_, err = keeper.StartTimeout(appA.Context(), &types.MsgStartTimeoutRequest{
+		Sender:   contract,
+		Contract: contract,
+		Delay:    3600000,
+		Args:     []byte(`{"delay":"second"}`),
+		Id:       "2",
+	})
+	suite.Require().NoError(err)
+
+	// the replaced timeout does not fire and does not remove the new one
+	time.Sleep(time.Second)
+	res, err := keeper.PendingTimeouts(appA.Context(), &types.QueryPendingTimeoutsRequest{Contract: contract})
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Timeouts, 1)
+	suite.Require().Equal([]byte(`{"delay":"second"}`), res.Timeouts[0].Args)
+	cancelfn, err := mctx.GetTimeoutGoroutine(suite.App().GetGoContextParent(), networkkeeper.TimeoutKey(chainId, contract, "2"))
+	suite.Require().NoError(err)
+	suite.Require().NotNil(cancelfn)
+
+	_, err = keeper.CancelTimeout(appA.Context(), &types.MsgCancelTimeoutRequest{Sender: contract, Id: "2"})
+	suite.Require().NoError(err)
+}
+
+func (suite *KeeperTestSuite) TestRestoredTimeoutFires() {
+	chainId := mcfg.MYTHOS_CHAIN_ID_TEST
+	suite.SetCurrentChain(chainId)
+	chain := suite.GetChain(chainId)
+	appA := s.GetAppContext(chain)
+
+	contract := appA.BytesToAccAddressPrefixed(chain.SenderAccount.GetAddress()).String()
+	keeper := &suite.App().NetworkKeeper
+	goContextParent := suite.App().GetGoContextParent()
+	timeoutKey := networkkeeper.TimeoutKey(chainId, contract, "3")
+
+	_, err := keeper.StartTimeout(appA.Context(), &types.MsgStartTimeoutRequest{
+		Sender:   contract,
+		Contract: contract,
+		Delay:    1000,
+		Args:     []byte(`{"delay":"roundTimeout"}`),
+		Id:       "3",
+	})
+	suite.Require().NoError(err)
+
+	// a node restart loses the goroutine, but not the persisted timeout
+	cancelfn, err := mctx.GetTimeoutGoroutine(goContextParent, timeoutKey)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(cancelfn)
+	cancelfn()
+	suite.Require().NoError(mctx.RemoveTimeoutGoroutine(goContextParent, timeoutKey))
+
+	res, err := keeper.PendingTimeouts(appA.Context(), &types.QueryPendingTimeoutsRequest{Contract: contract})
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Timeouts, 1)
+
+	err = keeper.RestoreTimeouts(appA.Context())
+	suite.Require().NoError(err)
+	cancelfn, err = mctx.GetTimeoutGoroutine(goContextParent, timeoutKey)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(cancelfn)
+
+	// the restored timeout fires at its deadline and unregisters itself
+	suite.Require().Eventually(func() bool {
+		cancelfn, err := mctx.GetTimeoutGoroutine(goContextParent, timeoutKey)
+		return err == nil && cancelfn == nil
+	}, 10*time.Second, 100*time.Millisecond)
+}
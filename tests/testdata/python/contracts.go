@@ -19,4 +19,10 @@ var (
 
 	//go:embed demo1.py
 	PyDemo []byte
+
+	//go:embed wasi_clock.py
+	PyWasiClock []byte
+
+	//go:embed wasi_fs.py
+	PyWasiFs []byte
 )
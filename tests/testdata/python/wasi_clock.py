@@ -0,0 +1,13 @@
+import json
+import os
+import time
+
+def instantiate(dataObj):
+    pass
+
+def main(dataObj):
+    if "time" in dataObj:
+        return json.dumps({"time": time.time_ns(), "monotonic": time.monotonic_ns()}).encode()
+    if "random" in dataObj:
+        return os.urandom(*dataObj["random"])
+    raise ValueError('Invalid function')
@@ -0,0 +1,35 @@
+import os
+
+def instantiate(dataObj):
+    os.makedirs("/data/notes", exist_ok=True)
+
+def main(dataObj):
+    if "write" in dataObj:
+        return write(*dataObj["write"])
+    if "append" in dataObj:
+        return append(*dataObj["append"])
+    if "read" in dataObj:
+        return read(*dataObj["read"])
+    if "writeAndFail" in dataObj:
+        write(*dataObj["writeAndFail"])
+        raise ValueError('failed after write')
+    if "remove" in dataObj:
+        return remove(*dataObj["remove"])
+    if "list" in dataObj:
+        return ",".join(sorted(os.listdir("/data/notes"))).encode()
+    raise ValueError('Invalid function')
+
+def write(name: str, content: str):
+    with open("/data/notes/" + name, "w") as f:
+        f.write(content)
+
+def append(name: str, content: str):
+    with open("/data/notes/" + name, "a") as f:
+        f.write(content)
+
+def read(name: str) -> bytes:
+    with open("/data/notes/" + name, "r") as f:
+        return f.read().encode()
+
+def remove(name: str):
+    os.remove("/data/notes/" + name)
@@ -0,0 +1,9 @@
+module github.com/loredanacirstea/wasmx-env-kv
+
+go 1.24
+
+toolchain go1.24.4
+
+require github.com/loredanacirstea/wasmx-env-utils v0.0.0
+
+replace github.com/loredanacirstea/wasmx-env-utils => ../wasmx-env-utils
@@ -0,0 +1,170 @@
+package kv
+
+// #include <stdlib.h>
+import "C"
+
+import (
+	"encoding/json"
+
+	utils "github.com/loredanacirstea/wasmx-env-utils"
+)
+
+//go:wasm-module kvdb
+//export wasmx_kvdb_i64_1
+func wasmx_kvdb_i64_1() {}
+
+// Host function imports
+//
+//go:wasmimport kvdb Connect
+func Connect_(reqPtr int64) int64
+
+//go:wasmimport kvdb Close
+func Close_(reqPtr int64) int64
+
+//go:wasmimport kvdb Get
+func Get_(reqPtr int64) int64
+
+//go:wasmimport kvdb Has
+func Has_(reqPtr int64) int64
+
+//go:wasmimport kvdb Set
+func Set_(reqPtr int64) int64
+
+//go:wasmimport kvdb Delete
+func Delete_(reqPtr int64) int64
+
+//go:wasmimport kvdb Iterator
+func Iterator_(reqPtr int64) int64
+
+//go:wasmimport kvdb BatchAtomic
+func BatchAtomic_(reqPtr int64) int64
+
+//go:wasmimport kvdb Stats
+func Stats_(reqPtr int64) int64
+
+// SDK function wrappers
+func Connect(req *KvConnectionRequest) KvConnectionResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := Connect_(utils.BytesToPackedPtr(bz))
+	resp := KvConnectionResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func Close(req *KvCloseRequest) KvCloseResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := Close_(utils.BytesToPackedPtr(bz))
+	resp := KvCloseResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func Get(req *KvGetRequest) KvGetResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := Get_(utils.BytesToPackedPtr(bz))
+	resp := KvGetResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func Has(req *KvHasRequest) KvHasResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := Has_(utils.BytesToPackedPtr(bz))
+	resp := KvHasResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func Set(req *KvSetRequest) KvSetResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := Set_(utils.BytesToPackedPtr(bz))
+	resp := KvSetResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func Delete(req *KvDeleteRequest) KvDeleteResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := Delete_(utils.BytesToPackedPtr(bz))
+	resp := KvDeleteResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func Iterator(req *KvIteratorRequest) KvIteratorResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := Iterator_(utils.BytesToPackedPtr(bz))
+	resp := KvIteratorResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func BatchAtomic(req *KvBatchAtomicRequest) KvBatchAtomicResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := BatchAtomic_(utils.BytesToPackedPtr(bz))
+	resp := KvBatchAtomicResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func Stats(req *KvStatsRequest) KvStatsResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := Stats_(utils.BytesToPackedPtr(bz))
+	resp := KvStatsResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
@@ -0,0 +1,107 @@
+package kv
+
+type KvConnectionRequest struct {
+	// cosmos-db backend: goleveldb, memdb, pebbledb, ...
+	Driver string `json:"driver"`
+	Dir    string `json:"dir"`
+	Name   string `json:"name"`
+	Id     string `json:"id"`
+}
+
+type KvConnectionResponse struct {
+	Error string `json:"error"`
+}
+
+type KvCloseRequest struct {
+	Id string `json:"id"`
+}
+
+type KvCloseResponse struct {
+	Error string `json:"error"`
+}
+
+type KvGetRequest struct {
+	Id  string `json:"id"`
+	Key []byte `json:"key"`
+}
+
+type KvGetResponse struct {
+	Error string `json:"error"`
+	Value []byte `json:"value"`
+}
+
+type KvHasRequest struct {
+	Id  string `json:"id"`
+	Key []byte `json:"key"`
+}
+
+type KvHasResponse struct {
+	Error string `json:"error"`
+	Found bool   `json:"found"`
+}
+
+type KvSetRequest struct {
+	Id    string `json:"id"`
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+type KvSetResponse struct {
+	Error string `json:"error"`
+}
+
+type KvDeleteRequest struct {
+	Id  string `json:"id"`
+	Key []byte `json:"key"`
+}
+
+type KvDeleteResponse struct {
+	Error string `json:"error"`
+}
+
+type KvIteratorRequest struct {
+	Id    string `json:"id"`
+	Start []byte `json:"start"`
+	// exclusive
+	End     []byte `json:"end"`
+	Reverse bool   `json:"reverse"`
+	// max number of pairs returned, 0 returns up to 100 pairs; max 1000
+	Limit uint32 `json:"limit"`
+}
+
+type KvPair struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+type KvIteratorResponse struct {
+	Error string   `json:"error"`
+	Pairs []KvPair `json:"pairs"`
+	// next page bound, empty when there are no more pairs:
+	// use it as start for forward iterators and as end for reverse iterators
+	NextKey []byte `json:"next_key"`
+}
+
+type KvBatchOperation struct {
+	Key    []byte `json:"key"`
+	Value  []byte `json:"value"`
+	Delete bool   `json:"delete"`
+}
+
+type KvBatchAtomicRequest struct {
+	Id         string             `json:"id"`
+	Operations []KvBatchOperation `json:"operations"`
+}
+
+type KvBatchAtomicResponse struct {
+	Error string `json:"error"`
+}
+
+type KvStatsRequest struct {
+	Id string `json:"id"`
+}
+
+type KvStatsResponse struct {
+	Error string            `json:"error"`
+	Stats map[string]string `json:"stats"`
+}
@@ -33,6 +33,30 @@ func BatchAtomic_(reqPtr int64) int64
 //go:wasmimport sql Query
 func Query_(reqPtr int64) int64
 
+//go:wasmimport sql Prepare
+func Prepare_(reqPtr int64) int64
+
+//go:wasmimport sql ExecutePrepared
+func ExecutePrepared_(reqPtr int64) int64
+
+//go:wasmimport sql ClosePrepared
+func ClosePrepared_(reqPtr int64) int64
+
+//go:wasmimport sql QueryOpen
+func QueryOpen_(reqPtr int64) int64
+
+//go:wasmimport sql QueryNext
+func QueryNext_(reqPtr int64) int64
+
+//go:wasmimport sql QueryClose
+func QueryClose_(reqPtr int64) int64
+
+//go:wasmimport sql Migrate
+func Migrate_(reqPtr int64) int64
+
+//go:wasmimport sql SchemaVersion
+func SchemaVersion_(reqPtr int64) int64
+
 // SDK function wrappers
 func Connect(req *SqlConnectionRequest) SqlConnectionResponse {
 	bz, err := json.Marshal(req)
@@ -117,3 +141,115 @@ func Query(req *SqlQueryRequest) SqlQueryResponse {
 	}
 	return resp
 }
+
+func Prepare(req *SqlPrepareRequest) SqlPrepareResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := Prepare_(utils.BytesToPackedPtr(bz))
+	resp := SqlPrepareResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func ExecutePrepared(req *SqlExecutePreparedRequest) SqlExecuteResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := ExecutePrepared_(utils.BytesToPackedPtr(bz))
+	resp := SqlExecuteResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func ClosePrepared(req *SqlClosePreparedRequest) SqlClosePreparedResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := ClosePrepared_(utils.BytesToPackedPtr(bz))
+	resp := SqlClosePreparedResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func QueryOpen(req *SqlQueryOpenRequest) SqlQueryOpenResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := QueryOpen_(utils.BytesToPackedPtr(bz))
+	resp := SqlQueryOpenResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func QueryNext(req *SqlQueryNextRequest) SqlQueryNextResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := QueryNext_(utils.BytesToPackedPtr(bz))
+	resp := SqlQueryNextResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func QueryClose(req *SqlQueryCloseRequest) SqlQueryCloseResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := QueryClose_(utils.BytesToPackedPtr(bz))
+	resp := SqlQueryCloseResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func Migrate(req *SqlMigrateRequest) SqlMigrateResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := Migrate_(utils.BytesToPackedPtr(bz))
+	resp := SqlMigrateResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
+
+func SchemaVersion(req *SqlSchemaVersionRequest) SqlSchemaVersionResponse {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	ptr := SchemaVersion_(utils.BytesToPackedPtr(bz))
+	resp := SqlSchemaVersionResponse{}
+	err = json.Unmarshal(utils.PackedPtrToBytes(ptr), &resp)
+	if err != nil {
+		panic(err)
+	}
+	return resp
+}
@@ -113,3 +113,128 @@ type SqlQueryRowRequest struct {
 type SqlQueryRowResponse struct {
 	Error string `json:"error"`
 }
+
+type SqlPrepareRequest struct {
+	Id          string `json:"id"`
+	StatementId string `json:"statement_id"`
+	Query       string `json:"query"`
+}
+
+type SqlPrepareResponse struct {
+	Error string `json:"error"`
+}
+
+type SqlExecutePreparedRequest struct {
+	Id          string `json:"id"`
+	StatementId string `json:"statement_id"`
+	Params      Params `json:"params"`
+}
+
+type SqlClosePreparedRequest struct {
+	Id          string `json:"id"`
+	StatementId string `json:"statement_id"`
+}
+
+type SqlClosePreparedResponse struct {
+	Error string `json:"error"`
+}
+
+// SqlQueryOpenRequest opens a cursor over the results of Query
+// or, if StatementId is set, of the prepared statement.
+// The rows are read when the cursor is opened; queries returning
+// more than 10000 rows fail and should be paged with LIMIT instead.
+type SqlQueryOpenRequest struct {
+	Id          string `json:"id"`
+	CursorId    string `json:"cursor_id"`
+	Query       string `json:"query"`
+	StatementId string `json:"statement_id"`
+	Params      Params `json:"params"`
+}
+
+type SqlColumn struct {
+	Name         string `json:"name"`
+	DatabaseType string `json:"database_type"`
+}
+
+type SqlQueryOpenResponse struct {
+	Error   string      `json:"error"`
+	Columns []SqlColumn `json:"columns"`
+}
+
+type SqlQueryNextRequest struct {
+	Id       string `json:"id"`
+	CursorId string `json:"cursor_id"`
+	// Count is the max number of rows returned, 100 if 0
+	Count int `json:"count"`
+}
+
+type SqlQueryNextResponse struct {
+	Error string       `json:"error"`
+	Rows  [][]SqlValue `json:"rows"`
+	// Done is true when there are no more rows
+	Done bool `json:"done"`
+}
+
+type SqlQueryCloseRequest struct {
+	Id       string `json:"id"`
+	CursorId string `json:"cursor_id"`
+}
+
+type SqlQueryCloseResponse struct {
+	Error string `json:"error"`
+}
+
+const (
+	SqlValueNull  = "null"
+	SqlValueInt   = "int64"
+	SqlValueFloat = "float"
+	SqlValueText  = "text"
+	SqlValueBlob  = "blob"
+	SqlValueTime  = "time"
+)
+
+// SqlValue is a typed column value; only the field of its type is set
+type SqlValue struct {
+	Type  string  `json:"type"`
+	Int   int64   `json:"int,omitempty"`
+	Float float64 `json:"float,omitempty"`
+	Text  string  `json:"text,omitempty"`
+	Blob  []byte  `json:"blob,omitempty"`
+	// Time is in UTC, RFC3339 with nanoseconds
+	Time string `json:"time,omitempty"`
+}
+
+// SqlMigration is a versioned schema change; Down reverts Up
+type SqlMigration struct {
+	Version int64               `json:"version"`
+	Up      []SqlExecuteCommand `json:"up"`
+	Down    []SqlExecuteCommand `json:"down"`
+}
+
+// SqlMigrateRequest brings the schema of Table to TargetVersion, reverting
+// applied migrations above it. If TargetVersion is not set, the pending
+// migrations are applied and nothing is reverted.
+type SqlMigrateRequest struct {
+	Id            string         `json:"id"`
+	Table         string         `json:"table"`
+	Migrations    []SqlMigration `json:"migrations"`
+	TargetVersion *int64         `json:"target_version,omitempty"`
+}
+
+type SqlMigrateResponse struct {
+	Error string `json:"error"`
+	// Version is the schema version after migrating
+	Version  int64   `json:"version"`
+	Applied  []int64 `json:"applied"`
+	Reverted []int64 `json:"reverted"`
+}
+
+type SqlSchemaVersionRequest struct {
+	Id    string `json:"id"`
+	Table string `json:"table"`
+}
+
+type SqlSchemaVersionResponse struct {
+	Error   string `json:"error"`
+	Version int64  `json:"version"`
+}
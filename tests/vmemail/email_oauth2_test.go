@@ -34,10 +34,10 @@ func (suite *KeeperTestSuite) TestEmailOauth2() {
 	suite.Require().NoError(err)
 
 	wasmbin := precompiles.GetPrecompileByLabel(appA.AccBech32Codec(), types.EMAIL_v001)
-	codeId := appA.StoreCode(sender, wasmbin, nil)
+	codeId := appA.StoreCodeByAuthority(sender, wasmbin, nil)
 
 	wasmbinR := precompiles.GetPrecompileByLabel(appA.AccBech32Codec(), types.HTTPSERVER_REGISTRY_v001)
-	codeIdR := appA.StoreCode(sender, wasmbinR, nil)
+	codeIdR := appA.StoreCodeByAuthority(sender, wasmbinR, nil)
 	contractAddressR := appA.InstantiateCode(sender, codeIdR, types.WasmxExecutionMessage{Data: []byte{}}, "httpserver_registry", nil)
 
 	// set a role to have access to protected APIs
@@ -121,7 +121,7 @@ func (suite *KeeperTestSuite) TestEmail() {
 	suite.Require().NoError(err)
 
 	wasmbin := precompiles.GetPrecompileByLabel(appA.AccBech32Codec(), types.EMAIL_v001)
-	codeId := appA.StoreCode(sender, wasmbin, nil)
+	codeId := appA.StoreCodeByAuthority(sender, wasmbin, nil)
 
 	msginit := &MsgInitializeRequest{
 		Config: Config{
@@ -184,7 +184,7 @@ func (suite *KeeperTestSuite) TestEmailListen() {
 	suite.Require().NoError(err)
 
 	wasmbin := precompiles.GetPrecompileByLabel(appA.AccBech32Codec(), types.EMAIL_v001)
-	codeId := appA.StoreCode(sender, wasmbin, nil)
+	codeId := appA.StoreCodeByAuthority(sender, wasmbin, nil)
 
 	msginit := &MsgInitializeRequest{
 		Providers: []Provider{
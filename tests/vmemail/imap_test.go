@@ -37,7 +37,7 @@ func (suite *KeeperTestSuite) TestImap() {
 	appA := s.AppContext()
 	appA.Faucet.Fund(appA.Context(), appA.BytesToAccAddressPrefixed(sender.Address), sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
 
-	codeId := appA.StoreCode(sender, wasmbin, nil)
+	codeId := appA.StoreCodeByAuthority(sender, wasmbin, nil)
 	contractAddress := appA.InstantiateCode(sender, codeId, types.WasmxExecutionMessage{Data: []byte{}}, "imaptest", nil)
 
 	// set a role to have access to protected APIs
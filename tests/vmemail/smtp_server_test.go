@@ -39,7 +39,7 @@ func (suite *KeeperTestSuite) TestIncomingEmail() {
 	appA.Faucet.Fund(appA.Context(), appA.BytesToAccAddressPrefixed(sender.Address), sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
 
 	// Store the emailchain contract and instantiate it
-	codeId := appA.StoreCode(sender, wasmbin, nil)
+	codeId := appA.StoreCodeByAuthority(sender, wasmbin, nil)
 	contractAddress := appA.InstantiateCode(sender, codeId, types.WasmxExecutionMessage{Data: []byte{}}, "emailchain", nil)
 
 	// set a role to have access to protected APIs
@@ -72,7 +72,7 @@ func (suite *KeeperTestSuite) TestEmailSmtpServer() {
 	appA.Faucet.Fund(appA.Context(), appA.BytesToAccAddressPrefixed(sender.Address), sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
 
 	// Store the emailchain contract and instantiate it
-	codeId := appA.StoreCode(sender, wasmbin, nil)
+	codeId := appA.StoreCodeByAuthority(sender, wasmbin, nil)
 	contractAddress := appA.InstantiateCode(sender, codeId, types.WasmxExecutionMessage{Data: []byte{}}, "emailchain", nil)
 
 	// set a role to have access to protected APIs
@@ -36,14 +36,14 @@ func (suite *KeeperTestSuite) TestHttpServer() {
 	appA := s.AppContext()
 	appA.Faucet.Fund(appA.Context(), appA.BytesToAccAddressPrefixed(sender.Address), sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
 
-	codeId := appA.StoreCode(sender, wasmbin, nil)
+	codeId := appA.StoreCodeByAuthority(sender, wasmbin, nil)
 	contractAddress := appA.InstantiateCode(sender, codeId, types.WasmxExecutionMessage{Data: []byte{}}, "httpserver", nil)
 
 	// set a role to have access to protected APIs
 	utils.RegisterRole(suite, appA, "httpserver", contractAddress, sender)
 
 	wasmbinR := precompiles.GetPrecompileByLabel(appA.AccBech32Codec(), types.HTTPSERVER_REGISTRY_v001)
-	codeIdR := appA.StoreCode(sender, wasmbinR, nil)
+	codeIdR := appA.StoreCodeByAuthority(sender, wasmbinR, nil)
 	contractAddressR := appA.InstantiateCode(sender, codeIdR, types.WasmxExecutionMessage{Data: []byte{}}, "httpserver_registry", nil)
 
 	// set a role to have access to protected APIs
@@ -144,14 +144,14 @@ func (suite *KeeperTestSuite) TestHttpServerRegistry() {
 	_, err := utils.DeployDType(suite, appA, sender)
 	suite.Require().NoError(err)
 
-	codeId := appA.StoreCode(sender, wasmbin, nil)
+	codeId := appA.StoreCodeByAuthority(sender, wasmbin, nil)
 	contractAddress := appA.InstantiateCode(sender, codeId, types.WasmxExecutionMessage{Data: []byte{}}, "httpserver", nil)
 
 	// set a role to have access to protected APIs
 	// utils.RegisterRole(suite, appA, "httpserver", contractAddress, sender)
 
 	wasmbinR := precompiles.GetPrecompileByLabel(appA.AccBech32Codec(), types.HTTPSERVER_REGISTRY_v001)
-	codeIdR := appA.StoreCode(sender, wasmbinR, nil)
+	codeIdR := appA.StoreCodeByAuthority(sender, wasmbinR, nil)
 	contractAddressR := appA.InstantiateCode(sender, codeIdR, types.WasmxExecutionMessage{Data: []byte{}}, "httpserver_registry", nil)
 
 	// set a role to have access to protected APIs
@@ -353,7 +353,7 @@ func (suite *KeeperTestSuite) TestDTypeErc20() {
 	appA.Faucet.Fund(appA.Context(), spenderPrefixed, sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
 
 	wasmbin := testdata.WasmxErc20DType
-	codeId := appA.StoreCode(sender, wasmbin, nil)
+	codeId := appA.StoreCodeByAuthority(sender, wasmbin, nil)
 
 	utils.DeployDType(suite, appA, sender)
 
@@ -28,7 +28,7 @@ func (suite *KeeperTestSuite) TestPriviledgedAPI() {
 	appA.Faucet.Fund(appA.Context(), senderPrefixed, sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
 
 	// test that a contract with a priviledged API, without the proper role is executed using the Mocked API
-	codeId := appA.StoreCode(sender, wasmbin, nil)
+	codeId := appA.StoreCodeByAuthority(sender, wasmbin, nil)
 	contractAddress := appA.InstantiateCode(sender, codeId, types.WasmxExecutionMessage{Data: []byte{}}, "sqltest", nil)
 
 	cmdQuery := &Calldata{Query: &vmsql.SqlQueryRequest{
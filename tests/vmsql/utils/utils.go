@@ -33,7 +33,7 @@ type KeeperTestSuiteInterface interface {
 
 func DeployDType(suite KeeperTestSuiteInterface, appA wt.AppContext, sender simulation.Account) (mcodec.AccAddressPrefixed, error) {
 	wasmbin := precompiles.GetPrecompileByLabel(appA.AddressCodec(), types.DTYPE_v001)
-	codeId := appA.StoreCode(sender, wasmbin, nil)
+	codeId := appA.StoreCodeByAuthority(sender, wasmbin, nil)
 	cmdi := &vmsql.InstantiateDType{Dir: "", Driver: "sqlite3"}
 	data, err := json.Marshal(cmdi)
 	if err != nil {
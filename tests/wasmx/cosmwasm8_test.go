@@ -261,6 +261,66 @@ func (suite *KeeperTestSuite) TestWasmxCW20() {
 	suite.Require().Equal(`{"balance":"8000000000000000"}`, string(qres))
 }
 
+func (suite *KeeperTestSuite) TestWasmxCW20Migrate() {
+	wasmbin := cw8.Cw20BaseAarch64Wasm
+	sender := suite.GetRandomAccount()
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+
+	appA := s.AppContext()
+	senderPrefixed := appA.BytesToAccAddressPrefixed(sender.Address)
+	appA.Faucet.Fund(appA.Context(), senderPrefixed, sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
+
+	codeId := appA.StoreCode(sender, wasmbin, nil)
+	instantiateMsg := CW20InstantiateMsg{
+		Name:     "cw20",
+		Symbol:   "TKN",
+		Decimals: 18,
+		InitialBalances: []Cw20Coin{
+			{Address: senderPrefixed.String(), Amount: "10000000000000000"},
+		},
+	}
+	calld, err := json.Marshal(instantiateMsg)
+	s.Require().NoError(err)
+	msgbz, err := json.Marshal(types.WasmxExecutionMessage{Data: calld})
+	s.Require().NoError(err)
+	res, err := appA.DeliverTxWithOpts(sender, &types.MsgInstantiateContract{
+		Sender: senderPrefixed.String(),
+		Admin:  senderPrefixed.String(),
+		CodeId: codeId,
+		Label:  "cw20",
+		Msg:    msgbz,
+	}, "", ut.DEFAULT_GAS_LIMIT, nil)
+	s.Require().NoError(err)
+	s.Require().True(res.IsOK(), res.GetLog())
+	appA.S.Commit()
+	contractAddress, err := appA.AddressStringToAccAddressPrefixed(appA.GetContractAddressFromEvents(res.GetEvents()))
+	s.Require().NoError(err)
+
+	// the same code stored again, so the contract version check of the migrate entry point passes
+	newCodeId := appA.StoreCode(sender, wasmbin, nil)
+	s.Require().NotEqual(codeId, newCodeId)
+
+	migratebz, err := json.Marshal(types.WasmxExecutionMessage{Data: []byte(`{}`)})
+	s.Require().NoError(err)
+	res, err = appA.DeliverTxWithOpts(sender, &types.MsgMigrateContract{
+		Sender:   senderPrefixed.String(),
+		Contract: contractAddress.String(),
+		CodeId:   newCodeId,
+		Msg:      migratebz,
+	}, "", ut.DEFAULT_GAS_LIMIT, nil)
+	s.Require().NoError(err)
+	s.Require().True(res.IsOK(), res.GetLog())
+	appA.S.Commit()
+
+	contractInfo, err := appA.App.WasmxKeeper.GetContractInfo(appA.Context(), contractAddress)
+	s.Require().NoError(err)
+	s.Require().Equal(newCodeId, contractInfo.CodeId)
+
+	calld = []byte(fmt.Sprintf(`{"balance":{"address":"%s"}}`, senderPrefixed.String()))
+	qres := appA.WasmxQueryRaw(sender, contractAddress, types.WasmxExecutionMessage{Data: calld}, nil, nil)
+	suite.Require().Equal(`{"balance":"10000000000000000"}`, string(qres))
+}
+
 func (suite *KeeperTestSuite) TestWasmxCW20ByEthereumTx() {
 	wasmbin := cw8.Cw20BaseAarch64Wasm
 	deployer := suite.GetRandomAccount()
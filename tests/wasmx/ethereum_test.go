@@ -3,6 +3,7 @@ package keeper_test
 import (
 	_ "embed"
 	"encoding/hex"
+	"encoding/json"
 	"math/big"
 	"strings"
 
@@ -237,3 +238,70 @@ func (suite *KeeperTestSuite) TestAliasedAccount() {
 	// s.Require().Equal(receiverEthBalance.GetBalance().Amount.BigInt(), big.NewInt(0))
 
 }
+
+func (suite *KeeperTestSuite) TestTraceEthTx() {
+	priv, err := ethsecp256k1.GenerateKey()
+	s.Require().NoError(err)
+	sender := sdk.AccAddress(priv.PubKey().Address().Bytes())
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+	setHex := `60fe47b1`
+
+	appA := s.AppContext()
+	appA.Faucet.Fund(appA.Context(), appA.BytesToAccAddressPrefixed(sender), sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
+
+	evmcode, err := hex.DecodeString(testdata.SimpleStorage)
+	s.Require().NoError(err)
+	initvalue := "0000000000000000000000000000000000000000000000000000000000000009"
+	databz := append(evmcode, appA.Hex2bz(initvalue)...)
+	res := appA.SendEthTx(priv, nil, databz, nil, ut.DEFAULT_GAS_LIMIT, big.NewInt(10000), nil)
+	contractAddressStr := appA.GetContractAddressFromEvents(res.GetEvents())
+	contractAddress, err := appA.AddressStringToAccAddressPrefixed(contractAddressStr)
+	s.Require().NoError(err)
+
+	newvalue := "0000000000000000000000000000000000000000000000000000000000000006"
+	databz = appA.Hex2bz(setHex + newvalue)
+	to := types.EvmAddressFromAcc(contractAddress.Bytes())
+	appA.SendEthTx(priv, &to, databz, nil, ut.DEFAULT_GAS_LIMIT, big.NewInt(10000), nil)
+
+	abcicli := appA.ABCIClient()
+	height, err := abcicli.LatestBlockHeight(appA.Context())
+	s.Require().NoError(err)
+	block, err := abcicli.Block(appA.Context(), &height)
+	s.Require().NoError(err)
+	s.Require().Len(block.Block.Txs, 1)
+	txhash := block.Block.Txs[0].Hash()
+
+	trace, err := abcicli.TraceTx(appA.Context(), txhash, types.TraceConfig{Tracer: "callTracer"})
+	s.Require().NoError(err)
+	var frame struct {
+		Type  string         `json:"type"`
+		From  common.Address `json:"from"`
+		To    common.Address `json:"to"`
+		Input string         `json:"input"`
+		Error string         `json:"error"`
+	}
+	s.Require().NoError(json.Unmarshal(trace, &frame))
+	s.Require().Equal(types.CALL_TYPE_CALL, frame.Type)
+	s.Require().Equal(types.EvmAddressFromAcc(sender), frame.From)
+	s.Require().Equal(to, frame.To)
+	s.Require().Equal("0x"+setHex+newvalue, frame.Input)
+	s.Require().Equal("", frame.Error)
+
+	trace, err = abcicli.TraceTx(appA.Context(), txhash, types.TraceConfig{
+		Tracer:       "prestateTracer",
+		TracerConfig: json.RawMessage(`{"diffMode":true}`),
+	})
+	s.Require().NoError(err)
+	var diff struct {
+		Pre  map[common.Address]struct{ Storage map[string]string } `json:"pre"`
+		Post map[common.Address]struct{ Storage map[string]string } `json:"post"`
+	}
+	s.Require().NoError(json.Unmarshal(trace, &diff))
+	slot := "0x" + strings.Repeat("00", 32)
+	s.Require().Equal("0x"+initvalue, diff.Pre[to].Storage[slot])
+	s.Require().Equal("0x"+newvalue, diff.Post[to].Storage[slot])
+
+	// the state is not changed by tracing
+	queryres := appA.App.WasmxKeeper.QueryRaw(appA.Context(), contractAddress, appA.Hex2bz(strings.Repeat("00", 32)))
+	suite.Require().Equal(newvalue, hex.EncodeToString(queryres))
+}
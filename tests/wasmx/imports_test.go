@@ -0,0 +1,92 @@
+package keeper_test
+
+import (
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ut "github.com/loredanacirstea/wasmx/testutil/wasmx"
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+)
+
+type testImport struct {
+	module  string
+	name    string
+	params  []byte
+	results []byte
+}
+
+const (
+	wasmI32 = byte(0x7f)
+	wasmI64 = byte(0x7e)
+)
+
+// builds a minimal wasm module with the given function imports
+// and one exported function named after the host interface version
+func wasmWithImports(version string, imports []testImport) []byte {
+	// type 0 is the exported function type
+	fntypes := [][]byte{{0x60, 0x00, 0x00}}
+	entries := make([][]byte, len(imports))
+	for i, imp := range imports {
+		fntype := append([]byte{0x60, byte(len(imp.params))}, imp.params...)
+		fntype = append(fntype, byte(len(imp.results)))
+		fntype = append(fntype, imp.results...)
+		fntypes = append(fntypes, fntype)
+
+		entry := append(wasmName(imp.module), wasmName(imp.name)...)
+		entries[i] = append(entry, 0x00, byte(i+1))
+	}
+
+	wasmbin := append([]byte{}, wasmHeader...)
+	wasmbin = append(wasmbin, wasmSection(1, wasmVec(fntypes...))...)
+	wasmbin = append(wasmbin, wasmSection(2, wasmVec(entries...))...)
+	wasmbin = append(wasmbin, wasmSection(3, wasmVec([]byte{0x00}))...)
+	export := append(wasmName(version), 0x00, byte(len(imports)))
+	wasmbin = append(wasmbin, wasmSection(7, wasmVec(export))...)
+	wasmbin = append(wasmbin, wasmSection(10, wasmVec(wasmBody(0x00, 0x0b)))...)
+	return wasmbin
+}
+
+func (suite *KeeperTestSuite) TestStoreCodeVerifiesImports() {
+	sender := suite.GetRandomAccount()
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+
+	appA := s.AppContext()
+	appA.Faucet.Fund(appA.Context(), appA.BytesToAccAddressPrefixed(sender.Address), sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
+	senderstr := appA.BytesToAccAddressPrefixed(sender.Address).String()
+
+	wasmbin := wasmWithImports(types.WASMX_ENVi32_2, []testImport{
+		{module: "wasmx", name: "storageStore", params: []byte{wasmI32, wasmI32}},
+		{module: "wasmx", name: "getCallData", results: []byte{wasmI32}},
+	})
+	appA.StoreCode(sender, wasmbin, nil)
+
+	wasmbin = wasmWithImports(types.WASMX_ENVi32_2, []testImport{
+		{module: "wasmx", name: "storageStore", params: []byte{wasmI32, wasmI32}},
+		{module: "wasmx", name: "getCallData", results: []byte{wasmI64}},
+		{module: "wasmx", name: "notAHostFunction"},
+		{module: "consensus", name: "CheckTx", params: []byte{wasmI32}, results: []byte{wasmI32}},
+		{module: "notamodule", name: "fn"},
+	})
+	res, err := appA.DeliverTxWithOpts(sender, &types.MsgStoreCode{Sender: senderstr, ByteCode: wasmbin}, "", 5000000, nil)
+	s.Require().NoError(err)
+	s.Require().True(res.IsErr(), res.GetLog())
+	s.Require().Contains(res.GetLog(), "wasmx.getCallData: wrong type () -> (i64), expected () -> (i32)")
+	s.Require().Contains(res.GetLog(), "wasmx.notAHostFunction: unknown function")
+	s.Require().Contains(res.GetLog(), "consensus.CheckTx: host API not declared")
+	s.Require().Contains(res.GetLog(), "notamodule.fn: unknown module")
+	s.Require().NotContains(res.GetLog(), "storageStore")
+
+	// only the authority and contracts with a role can declare protected interfaces
+	wasmbin = wasmWithImports(types.WASMX_ENVi32_2, []testImport{
+		{module: "consensus", name: "CheckTx", params: []byte{wasmI32}, results: []byte{wasmI32}},
+	})
+	res, err = appA.DeliverTxWithOpts(sender, &types.MsgStoreCode{Sender: senderstr, ByteCode: wasmbin, Deps: []string{types.WASMX_CONSENSUS_JSON_i32_1}}, "", 5000000, nil)
+	s.Require().NoError(err)
+	s.Require().True(res.IsErr(), res.GetLog())
+	s.Require().Contains(res.GetLog(), "protected host API "+types.WASMX_CONSENSUS_JSON_i32_1)
+
+	authority, err := appA.AddressStringToAccAddressPrefixed(appA.App.WasmxKeeper.GetAuthority())
+	s.Require().NoError(err)
+	_, _, err = appA.App.WasmxKeeper.Create(appA.Context(), authority, wasmbin, []string{types.WASMX_CONSENSUS_JSON_i32_1}, types.CodeMetadata{}, false, false, nil)
+	s.Require().NoError(err)
+}
@@ -0,0 +1,89 @@
+package keeper_test
+
+import (
+	"encoding/hex"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	ut "github.com/loredanacirstea/wasmx/testutil/wasmx"
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+
+	testdata "github.com/loredanacirstea/mythos-tests/testdata/classic"
+	interfacesTestdata "github.com/loredanacirstea/mythos-tests/testdata/interfaces"
+)
+
+// builds a wasm module whose main function grows the memory
+// one page at a time until memory.grow fails at the max_memory_pages limit, then traps
+func growBombWasm(version string) []byte {
+	wasmbin := append([]byte{}, wasmHeader...)
+	wasmbin = append(wasmbin, wasmSection(1, wasmVec([]byte{0x60, 0x00, 0x00}))...)
+	wasmbin = append(wasmbin, wasmSection(3, wasmVec([]byte{0x00}, []byte{0x00}))...)
+	// 1 page, no max
+	wasmbin = append(wasmbin, wasmSection(5, wasmVec([]byte{0x00, 0x01}))...)
+	wasmbin = append(wasmbin, wasmSection(7, wasmVec(
+		append(wasmName("memory"), 0x02, 0x00),
+		append(wasmName(version), 0x00, 0x00),
+		append(wasmName("instantiate"), 0x00, 0x00),
+		append(wasmName("main"), 0x00, 0x01),
+	))...)
+	wasmbin = append(wasmbin, wasmSection(10, wasmVec(
+		wasmBody(0x00, 0x0b),
+		// loop (br_if 0 (i32.ne (memory.grow (i32.const 1)) (i32.const -1))) unreachable
+		wasmBody(0x00, 0x03, 0x40, 0x41, 0x01, 0x40, 0x00, 0x41, 0x7f, 0x47, 0x0d, 0x00, 0x0b, 0x00, 0x0b),
+	))...)
+	return wasmbin
+}
+
+func (suite *KeeperTestSuite) TestMemoryLimitGrowBomb() {
+	sender := suite.GetRandomAccount()
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+
+	appA := s.AppContext()
+	appA.Faucet.Fund(appA.Context(), appA.BytesToAccAddressPrefixed(sender.Address), sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
+
+	codeId := appA.StoreCode(sender, growBombWasm(types.WASMX_ENVi32_2), nil)
+	contractAddress := appA.InstantiateCode(sender, codeId, types.WasmxExecutionMessage{Data: []byte{}}, "growbomb", nil)
+
+	res, err := appA.ExecuteContractNoCheck(sender, contractAddress, types.WasmxExecutionMessage{Data: []byte{}}, nil, nil, 5000000, nil)
+	s.Require().NoError(err)
+	s.Require().True(res.IsErr(), res.GetLog())
+	s.Require().Contains(res.GetLog(), types.ErrMemoryLimit.Error())
+
+	abcires, err := appA.WasmxQueryRawNoCheck(sender, contractAddress, types.WasmxExecutionMessage{Data: []byte{}}, nil, nil)
+	s.Require().NoError(err)
+	s.Require().True(abcires.IsErr())
+	s.Require().Contains(abcires.Log, types.ErrMemoryLimit.Error())
+}
+
+func (suite *KeeperTestSuite) TestMaxCallDepth() {
+	sender := suite.GetRandomAccount()
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+
+	appA := s.AppContext()
+	appA.Faucet.Fund(appA.Context(), appA.BytesToAccAddressPrefixed(sender.Address), sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
+
+	params := appA.App.WasmxKeeper.GetParams(appA.Context())
+	defer appA.App.WasmxKeeper.SetParams(appA.Context(), params)
+	limited := params
+	limited.MaxCallDepth = 2
+	appA.App.WasmxKeeper.SetParams(appA.Context(), limited)
+
+	evmcode, err := hex.DecodeString(testdata.ForwardContract)
+	s.Require().NoError(err)
+	_, contractAddress := appA.DeployEvm(sender, evmcode, types.WasmxExecutionMessage{Data: []byte{}}, nil, "ForwardContract", nil)
+	self := types.EvmAddressFromAcc(contractAddress.Bytes())
+
+	// the contract calls itself once for each address
+	calld, err := interfacesTestdata.ForwardEvmAbi.Pack("forward", "depth: ", []common.Address{self, self})
+	s.Require().NoError(err)
+	appA.ExecuteContract(sender, contractAddress, types.WasmxExecutionMessage{Data: calld}, nil, nil)
+
+	calld, err = interfacesTestdata.ForwardEvmAbi.Pack("forward", "depth: ", []common.Address{self, self, self})
+	s.Require().NoError(err)
+	res, err := appA.ExecuteContractNoCheck(sender, contractAddress, types.WasmxExecutionMessage{Data: calld}, nil, nil, 5000000, nil)
+	s.Require().NoError(err)
+	s.Require().True(res.IsErr(), res.GetLog())
+}
@@ -259,3 +259,84 @@ func (suite *KeeperTestSuite) TestWasiInterpreterPythonDemo1() {
 	s.Require().NoError(err)
 	s.Require().NotNil(contractInfo)
 }
+
+func (suite *KeeperTestSuite) TestWasiInterpreterPythonClockRandom() {
+	sender := suite.GetRandomAccount()
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+
+	appA := s.AppContext()
+	appA.Faucet.Fund(appA.Context(), appA.BytesToAccAddressPrefixed(sender.Address), sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
+
+	deps := []string{types.INTERPRETER_PYTHON}
+	codeId := appA.StoreCode(sender, py.PyWasiClock, deps)
+	contractAddress := appA.InstantiateCode(sender, codeId, types.WasmxExecutionMessage{Data: []byte(`{}`)}, "wasiClockPy", nil)
+
+	// clocks follow the block time
+	data := []byte(`{"time":[]}`)
+	resp := appA.WasmxQueryRaw(sender, contractAddress, types.WasmxExecutionMessage{Data: data}, nil, nil)
+	var clocks struct {
+		Time      uint64 `json:"time"`
+		Monotonic uint64 `json:"monotonic"`
+	}
+	err := json.Unmarshal(resp, &clocks)
+	s.Require().NoError(err)
+	blockTime := uint64(appA.Context().BlockTime().UnixNano())
+	s.Require().Equal(blockTime, clocks.Time)
+	s.Require().Equal(blockTime, clocks.Monotonic)
+
+	// random bytes are derived from the chain, block, tx and call
+	data = []byte(`{"random":[32]}`)
+	resp = appA.WasmxQueryRaw(sender, contractAddress, types.WasmxExecutionMessage{Data: data}, nil, nil)
+	s.Require().Equal(32, len(resp))
+	s.Require().NotEqual(make([]byte, 32), resp)
+	resp2 := appA.WasmxQueryRaw(sender, contractAddress, types.WasmxExecutionMessage{Data: data}, nil, nil)
+	s.Require().Equal(resp, resp2)
+}
+
+func (suite *KeeperTestSuite) TestWasiInterpreterPythonFilesystem() {
+	sender := suite.GetRandomAccount()
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+
+	appA := s.AppContext()
+	appA.Faucet.Fund(appA.Context(), appA.BytesToAccAddressPrefixed(sender.Address), sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
+
+	deps := []string{types.INTERPRETER_PYTHON}
+	codeId := appA.StoreCode(sender, py.PyWasiFs, deps)
+	contractAddress := appA.InstantiateCode(sender, codeId, types.WasmxExecutionMessage{Data: []byte(`{}`)}, "wasiFsPy", nil)
+
+	data := []byte(`{"write":["a.txt","hello"]}`)
+	appA.ExecuteContract(sender, contractAddress, types.WasmxExecutionMessage{Data: data}, nil, nil)
+
+	// files written in a tx are readable in the next calls
+	data = []byte(`{"read":["a.txt"]}`)
+	resp := appA.WasmxQueryRaw(sender, contractAddress, types.WasmxExecutionMessage{Data: data}, nil, nil)
+	s.Require().Equal("hello", string(resp))
+
+	data = []byte(`{"append":["a.txt"," world"]}`)
+	appA.ExecuteContract(sender, contractAddress, types.WasmxExecutionMessage{Data: data}, nil, nil)
+	data = []byte(`{"write":["b.txt","second"]}`)
+	appA.ExecuteContract(sender, contractAddress, types.WasmxExecutionMessage{Data: data}, nil, nil)
+
+	data = []byte(`{"read":["a.txt"]}`)
+	resp = appA.WasmxQueryRaw(sender, contractAddress, types.WasmxExecutionMessage{Data: data}, nil, nil)
+	s.Require().Equal("hello world", string(resp))
+
+	data = []byte(`{"list":[]}`)
+	resp = appA.WasmxQueryRaw(sender, contractAddress, types.WasmxExecutionMessage{Data: data}, nil, nil)
+	s.Require().Equal("a.txt,b.txt", string(resp))
+
+	data = []byte(`{"remove":["a.txt"]}`)
+	appA.ExecuteContract(sender, contractAddress, types.WasmxExecutionMessage{Data: data}, nil, nil)
+	data = []byte(`{"list":[]}`)
+	resp = appA.WasmxQueryRaw(sender, contractAddress, types.WasmxExecutionMessage{Data: data}, nil, nil)
+	s.Require().Equal("b.txt", string(resp))
+
+	// writes done in a failed tx are reverted
+	data = []byte(`{"writeAndFail":["c.txt","reverted"]}`)
+	res, err := appA.ExecuteContractNoCheck(sender, contractAddress, types.WasmxExecutionMessage{Data: data}, nil, nil, 1500000, nil)
+	s.Require().NoError(err)
+	s.Require().True(res.IsErr(), res.GetLog())
+	data = []byte(`{"list":[]}`)
+	resp = appA.WasmxQueryRaw(sender, contractAddress, types.WasmxExecutionMessage{Data: data}, nil, nil)
+	s.Require().Equal("b.txt", string(resp))
+}
@@ -0,0 +1,79 @@
+package keeper_test
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	sdkerr "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ut "github.com/loredanacirstea/wasmx/testutil/wasmx"
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+)
+
+// builds a wasm module whose main function never returns
+func infiniteLoopWasm(version string) []byte {
+	wasmbin := append([]byte{}, wasmHeader...)
+	wasmbin = append(wasmbin, wasmSection(1, wasmVec([]byte{0x60, 0x00, 0x00}))...)
+	wasmbin = append(wasmbin, wasmSection(3, wasmVec([]byte{0x00}, []byte{0x00}))...)
+	wasmbin = append(wasmbin, wasmSection(5, wasmVec([]byte{0x00, 0x01}))...)
+	wasmbin = append(wasmbin, wasmSection(7, wasmVec(
+		append(wasmName("memory"), 0x02, 0x00),
+		append(wasmName(version), 0x00, 0x00),
+		append(wasmName("instantiate"), 0x00, 0x00),
+		append(wasmName("main"), 0x00, 0x01),
+	))...)
+	wasmbin = append(wasmbin, wasmSection(10, wasmVec(
+		wasmBody(0x00, 0x0b),
+		// loop (br 0)
+		wasmBody(0x00, 0x03, 0x40, 0x0c, 0x00, 0x0b, 0x0b),
+	))...)
+	return wasmbin
+}
+
+func (suite *KeeperTestSuite) TestQueryTimeout() {
+	sender := suite.GetRandomAccount()
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+
+	appA := s.AppContext()
+	appA.Faucet.Fund(appA.Context(), appA.BytesToAccAddressPrefixed(sender.Address), sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
+
+	codeId := appA.StoreCode(sender, infiniteLoopWasm(types.WASMX_ENVi32_2), nil)
+	contractAddress := appA.InstantiateCode(sender, codeId, types.WasmxExecutionMessage{Data: []byte{}}, "infiniteloop", nil)
+	senderstr, err := appA.AddressCodec().BytesToString(sender.Address)
+	s.Require().NoError(err)
+	msgbz, err := json.Marshal(types.WasmxExecutionMessage{Data: []byte{}})
+	s.Require().NoError(err)
+
+	// the caller deadline is shorter than the query timeout, and bounds the query
+	requireTimeout := func(call func(ctx sdk.Context) error) {
+		goCtx, cancel := context.WithTimeout(appA.Context().Context(), 500*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err := call(appA.Context().WithContext(goCtx))
+		s.Require().Less(time.Since(start), 10*time.Second)
+		s.Require().ErrorIs(err, types.ErrExecutionTimeout)
+		codespace, code, _ := sdkerr.ABCIInfo(err, false)
+		s.Require().Equal(types.DefaultCodespace, codespace)
+		s.Require().Equal(uint32(34), code)
+	}
+
+	requireTimeout(func(ctx sdk.Context) error {
+		_, err := appA.App.WasmxKeeper.SmartContractCall(ctx, &types.QuerySmartContractCallRequest{
+			Sender:    senderstr,
+			Address:   contractAddress.String(),
+			QueryData: msgbz,
+		})
+		return err
+	})
+	requireTimeout(func(ctx sdk.Context) error {
+		_, err := appA.App.WasmxKeeper.CallEth(ctx, &types.QueryCallEthRequest{
+			Sender:    senderstr,
+			Address:   contractAddress.String(),
+			QueryData: msgbz,
+		})
+		return err
+	})
+}
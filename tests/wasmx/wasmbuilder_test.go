@@ -0,0 +1,27 @@
+package keeper_test
+
+// helpers for building minimal wasm modules byte by byte.
+// Lengths are encoded in one byte, so the modules must stay small.
+
+var wasmHeader = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+func wasmVec(items ...[]byte) []byte {
+	out := []byte{byte(len(items))}
+	for _, item := range items {
+		out = append(out, item...)
+	}
+	return out
+}
+
+func wasmName(v string) []byte {
+	return append([]byte{byte(len(v))}, []byte(v)...)
+}
+
+func wasmSection(id byte, content []byte) []byte {
+	return append([]byte{id, byte(len(content))}, content...)
+}
+
+// wasmBody is a function body, with its local declarations
+func wasmBody(code ...byte) []byte {
+	return append([]byte{byte(len(code))}, code...)
+}
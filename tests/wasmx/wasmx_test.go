@@ -164,6 +164,183 @@ func (suite *KeeperTestSuite) TestWasmxSameCode() {
 	s.Require().Equal(codeId+1, codeId2)
 }
 
+func (suite *KeeperTestSuite) TestWasmxContractAdmin() {
+	wasmbin := wasmxtest.WasmxSimpleStorage
+	sender := suite.GetRandomAccount()
+	newAdmin := suite.GetRandomAccount()
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+
+	appA := s.AppContext()
+	appA.Faucet.Fund(appA.Context(), appA.BytesToAccAddressPrefixed(sender.Address), sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
+	appA.Faucet.Fund(appA.Context(), appA.BytesToAccAddressPrefixed(newAdmin.Address), sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
+	senderStr := appA.BytesToAccAddressPrefixed(sender.Address).String()
+	newAdminStr := appA.BytesToAccAddressPrefixed(newAdmin.Address).String()
+
+	codeId := appA.StoreCode(sender, wasmbin, nil)
+	msgbz, err := json.Marshal(types.WasmxExecutionMessage{Data: []byte{}})
+	s.Require().NoError(err)
+	res, err := appA.DeliverTxWithOpts(sender, &types.MsgInstantiateContract{
+		Sender: senderStr,
+		Admin:  senderStr,
+		CodeId: codeId,
+		Label:  "simpleStorage",
+		Msg:    msgbz,
+	}, "", ut.DEFAULT_GAS_LIMIT, nil)
+	s.Require().NoError(err)
+	s.Require().True(res.IsOK(), res.GetLog())
+	appA.S.Commit()
+	contractAddress, err := appA.AddressStringToAccAddressPrefixed(appA.GetContractAddressFromEvents(res.GetEvents()))
+	s.Require().NoError(err)
+
+	contractInfo, err := appA.App.WasmxKeeper.GetContractInfo(appA.Context(), contractAddress)
+	s.Require().NoError(err)
+	s.Require().Equal(senderStr, contractInfo.Admin)
+
+	// only the admin can change the admin
+	res, err = appA.DeliverTxWithOpts(newAdmin, &types.MsgUpdateAdmin{
+		Sender:   newAdminStr,
+		NewAdmin: newAdminStr,
+		Contract: contractAddress.String(),
+	}, "", ut.DEFAULT_GAS_LIMIT, nil)
+	s.Require().NoError(err)
+	s.Require().True(res.IsErr(), res.GetLog())
+	s.Require().Contains(res.GetLog(), "can not modify contract")
+	appA.S.Commit()
+
+	res, err = appA.DeliverTxWithOpts(sender, &types.MsgUpdateAdmin{
+		Sender:   senderStr,
+		NewAdmin: newAdminStr,
+		Contract: contractAddress.String(),
+	}, "", ut.DEFAULT_GAS_LIMIT, nil)
+	s.Require().NoError(err)
+	s.Require().True(res.IsOK(), res.GetLog())
+	appA.S.Commit()
+
+	contractInfo, err = appA.App.WasmxKeeper.GetContractInfo(appA.Context(), contractAddress)
+	s.Require().NoError(err)
+	s.Require().Equal(newAdminStr, contractInfo.Admin)
+
+	// the old admin cannot migrate
+	res, err = appA.DeliverTxWithOpts(sender, &types.MsgMigrateContract{
+		Sender:   senderStr,
+		Contract: contractAddress.String(),
+		CodeId:   codeId,
+		Msg:      msgbz,
+	}, "", ut.DEFAULT_GAS_LIMIT, nil)
+	s.Require().NoError(err)
+	s.Require().True(res.IsErr(), res.GetLog())
+	s.Require().Contains(res.GetLog(), "can not migrate")
+	appA.S.Commit()
+
+	res, err = appA.DeliverTxWithOpts(newAdmin, &types.MsgClearAdmin{
+		Sender:   newAdminStr,
+		Contract: contractAddress.String(),
+	}, "", ut.DEFAULT_GAS_LIMIT, nil)
+	s.Require().NoError(err)
+	s.Require().True(res.IsOK(), res.GetLog())
+	appA.S.Commit()
+
+	contractInfo, err = appA.App.WasmxKeeper.GetContractInfo(appA.Context(), contractAddress)
+	s.Require().NoError(err)
+	s.Require().Equal("", contractInfo.Admin)
+
+	// the contract can still be executed
+	data := []byte(`{"set":{"key":"hello","value":"sammy"}}`)
+	appA.ExecuteContract(sender, contractAddress, types.WasmxExecutionMessage{Data: data}, nil, nil)
+	queryres := appA.App.WasmxKeeper.QueryRaw(appA.Context(), contractAddress, []byte("hello"))
+	s.Require().Equal("sammy", string(queryres))
+}
+
+// builds a wasm module with empty instantiate, main and migrate entry points
+func migratableWasm(version string) []byte {
+	wasmbin := append([]byte{}, wasmHeader...)
+	wasmbin = append(wasmbin, wasmSection(1, wasmVec([]byte{0x60, 0x00, 0x00}))...)
+	wasmbin = append(wasmbin, wasmSection(3, wasmVec([]byte{0x00}, []byte{0x00}, []byte{0x00}))...)
+	wasmbin = append(wasmbin, wasmSection(5, wasmVec([]byte{0x00, 0x01}))...)
+	wasmbin = append(wasmbin, wasmSection(7, wasmVec(
+		append(wasmName("memory"), 0x02, 0x00),
+		append(wasmName(version), 0x00, 0x00),
+		append(wasmName("instantiate"), 0x00, 0x00),
+		append(wasmName("main"), 0x00, 0x01),
+		append(wasmName("migrate"), 0x00, 0x02),
+	))...)
+	wasmbin = append(wasmbin, wasmSection(10, wasmVec(
+		wasmBody(0x00, 0x0b),
+		wasmBody(0x00, 0x0b),
+		wasmBody(0x00, 0x0b),
+	))...)
+	return wasmbin
+}
+
+func (suite *KeeperTestSuite) TestWasmxContractMigrate() {
+	wasmbin := wasmxtest.WasmxSimpleStorage
+	sender := suite.GetRandomAccount()
+	initBalance := sdkmath.NewInt(ut.DEFAULT_BALANCE)
+
+	appA := s.AppContext()
+	appA.Faucet.Fund(appA.Context(), appA.BytesToAccAddressPrefixed(sender.Address), sdk.NewCoin(appA.Chain.Config.BaseDenom, initBalance))
+	senderStr := appA.BytesToAccAddressPrefixed(sender.Address).String()
+
+	codeId := appA.StoreCode(sender, wasmbin, nil)
+	msgbz, err := json.Marshal(types.WasmxExecutionMessage{Data: []byte{}})
+	s.Require().NoError(err)
+	res, err := appA.DeliverTxWithOpts(sender, &types.MsgInstantiateContract{
+		Sender: senderStr,
+		Admin:  senderStr,
+		CodeId: codeId,
+		Label:  "simpleStorage",
+		Msg:    msgbz,
+	}, "", ut.DEFAULT_GAS_LIMIT, nil)
+	s.Require().NoError(err)
+	s.Require().True(res.IsOK(), res.GetLog())
+	appA.S.Commit()
+	contractAddress, err := appA.AddressStringToAccAddressPrefixed(appA.GetContractAddressFromEvents(res.GetEvents()))
+	s.Require().NoError(err)
+
+	data := []byte(`{"set":{"key":"hello","value":"sammy"}}`)
+	appA.ExecuteContract(sender, contractAddress, types.WasmxExecutionMessage{Data: data}, nil, nil)
+
+	newCodeId := appA.StoreCode(sender, migratableWasm(types.WASMX_ENVi32_2), nil)
+	s.Require().NotEqual(codeId, newCodeId)
+
+	res, err = appA.DeliverTxWithOpts(sender, &types.MsgMigrateContract{
+		Sender:   senderStr,
+		Contract: contractAddress.String(),
+		CodeId:   newCodeId,
+		Msg:      msgbz,
+	}, "", ut.DEFAULT_GAS_LIMIT, nil)
+	s.Require().NoError(err)
+	s.Require().True(res.IsOK(), res.GetLog())
+	appA.S.Commit()
+
+	migrateEvs := appA.GetEventsByAttribute(res.GetEvents(), types.AttributeKeyCodeID, fmt.Sprint(newCodeId))
+	s.Require().Len(migrateEvs, 1)
+	s.Require().Equal(types.EventTypeMigrate, migrateEvs[0].Type)
+
+	// the contract runs the new code, with its address, admin and storage kept
+	contractInfo, err := appA.App.WasmxKeeper.GetContractInfo(appA.Context(), contractAddress)
+	s.Require().NoError(err)
+	s.Require().Equal(newCodeId, contractInfo.CodeId)
+	s.Require().Equal(senderStr, contractInfo.Admin)
+	queryres := appA.App.WasmxKeeper.QueryRaw(appA.Context(), contractAddress, []byte("hello"))
+	s.Require().Equal("sammy", string(queryres))
+
+	// code without a migrate entry point cannot be migrated to
+	res, err = appA.DeliverTxWithOpts(sender, &types.MsgMigrateContract{
+		Sender:   senderStr,
+		Contract: contractAddress.String(),
+		CodeId:   codeId,
+		Msg:      msgbz,
+	}, "", ut.DEFAULT_GAS_LIMIT, nil)
+	s.Require().NoError(err)
+	s.Require().True(res.IsErr(), res.GetLog())
+	appA.S.Commit()
+
+	contractInfo, err = appA.App.WasmxKeeper.GetContractInfo(appA.Context(), contractAddress)
+	s.Require().NoError(err)
+	s.Require().Equal(newCodeId, contractInfo.CodeId)
+}
+
 func (suite *KeeperTestSuite) TestWasmxTime() {
 	SkipFixmeTests(suite.T(), "TestWasmxTime")
 	SkipCIExpensiveTests(suite.T(), "TestWasmxTime")
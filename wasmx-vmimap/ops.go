@@ -10,6 +10,7 @@ import (
 	"github.com/emersion/go-imap/v2/imapclient"
 
 	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
+	vmutils "github.com/loredanacirstea/wasmx/x/wasmx/vm/utils"
 )
 
 func Connect(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
@@ -36,7 +37,9 @@ func Connect(_context interface{}, rnh memc.RuntimeHandler, params []interface{}
 	conn, found := vctx.GetConnection(connId)
 	if found {
 		if conn.Info.ImapServerUrl == req.ImapServerUrl {
-			err := conn.Client.Noop().Wait()
+			err := vmutils.RunWithContext(ctx.Ctx, func() error {
+				return conn.Client.Noop().Wait()
+			})
 			if err == nil {
 				return prepareResponse(rnh, response)
 			} else {
@@ -68,7 +71,7 @@ func connectCommon(
 	connId string,
 	info ImapConnectionRequest,
 ) ([]interface{}, error) {
-	client, err := getClient(nil)
+	client, err := dialWithContext(ctx, getClient)
 	if err != nil {
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
@@ -215,7 +218,7 @@ func Count(_context interface{}, rnh memc.RuntimeHandler, params []interface{})
 		return prepareResponse(rnh, response)
 	}
 
-	folder, err := conn.Client.Select(req.Folder, nil).Wait()
+	folder, err := selectFolder(ctx, conn.Client, req.Folder)
 	if err != nil {
 		response.Error = "failed to select email folder"
 		return prepareResponse(rnh, response)
@@ -251,7 +254,7 @@ func UIDSearch(_context interface{}, rnh memc.RuntimeHandler, params []interface
 		return prepareResponse(rnh, response)
 	}
 
-	folder, err := conn.Client.Select(req.Folder, nil).Wait()
+	folder, err := selectFolder(ctx, conn.Client, req.Folder)
 	if err != nil {
 		response.Error = "failed to select email folder"
 		return prepareResponse(rnh, response)
@@ -262,7 +265,12 @@ func UIDSearch(_context interface{}, rnh memc.RuntimeHandler, params []interface
 		return prepareResponse(rnh, response)
 	}
 
-	numset, count, err := fetchEmailIds(conn.Client, folder, conn.Info.Auth.Username, *req.FetchFilter)
+	var numset imap.NumSet
+	var count uint32
+	err = vmutils.RunWithContext(ctx.Ctx, func() (err error) {
+		numset, count, err = fetchEmailIds(conn.Client, folder, conn.Info.Auth.Username, *req.FetchFilter)
+		return err
+	})
 	if err != nil {
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
@@ -318,14 +326,14 @@ func Fetch(_context interface{}, rnh memc.RuntimeHandler, params []interface{})
 		}
 	}
 
-	folder, err := conn.Client.Select(req.Folder, nil).Wait()
+	folder, err := selectFolder(ctx, conn.Client, req.Folder)
 	if err != nil {
 		return nil, fmt.Errorf("failed to select email folder: %v", err.Error())
 	}
 	response.Count = int64(folder.NumMessages)
 	emails := []Email{}
 	if len(req.SeqSet) > 0 {
-		e, err := imapFetch(conn.Client, ctx.Ctx.Logger(), req.SeqSet, options, bodySection)
+		e, err := fetchWithContext(ctx, conn.Client, req.SeqSet, options, bodySection)
 		if err != nil {
 			response.Error = err.Error()
 			return prepareResponse(rnh, response)
@@ -333,7 +341,7 @@ func Fetch(_context interface{}, rnh memc.RuntimeHandler, params []interface{})
 		emails = e
 	}
 	if len(req.UidSet) > 0 {
-		e, err := imapFetch(conn.Client, ctx.Ctx.Logger(), req.UidSet, options, bodySection)
+		e, err := fetchWithContext(ctx, conn.Client, req.UidSet, options, bodySection)
 		if err != nil {
 			response.Error = err.Error()
 			return prepareResponse(rnh, response)
@@ -341,13 +349,18 @@ func Fetch(_context interface{}, rnh memc.RuntimeHandler, params []interface{})
 		emails = append(emails, e...)
 	}
 	if req.FetchFilter != nil {
-		numset, count, err := fetchEmailIds(conn.Client, folder, conn.Info.Auth.Username, *req.FetchFilter)
+		var numset imap.NumSet
+		var count uint32
+		err = vmutils.RunWithContext(ctx.Ctx, func() (err error) {
+			numset, count, err = fetchEmailIds(conn.Client, folder, conn.Info.Auth.Username, *req.FetchFilter)
+			return err
+		})
 		if err != nil {
 			response.Error = err.Error()
 			return prepareResponse(rnh, response)
 		}
 		if count > 0 {
-			e, err := imapFetch(conn.Client, ctx.Ctx.Logger(), numset, options, bodySection)
+			e, err := fetchWithContext(ctx, conn.Client, numset, options, bodySection)
 			if err != nil {
 				response.Error = err.Error()
 				return prepareResponse(rnh, response)
@@ -390,13 +403,16 @@ func ListMailboxes(_context interface{}, rnh memc.RuntimeHandler, params []inter
 	}
 
 	// List all mailboxes (use the empty string for the reference and "*" for the mailbox pattern)
-	cmd := conn.Client.List("", "*", nil)
-	defer cmd.Close()
-	for {
-		d := cmd.Next()
-		if d == nil {
-			break
-		}
+	var mailboxes []*imap.ListData
+	err = vmutils.RunWithContext(ctx.Ctx, func() (err error) {
+		mailboxes, err = conn.Client.List("", "*", nil).Collect()
+		return err
+	})
+	if err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+	for _, d := range mailboxes {
 		response.Mailboxes = append(response.Mailboxes, d.Mailbox)
 	}
 	return prepareResponse(rnh, response)
@@ -429,7 +445,9 @@ func CreateFolder(_context interface{}, rnh memc.RuntimeHandler, params []interf
 		return prepareResponse(rnh, response)
 	}
 
-	err = conn.Client.Create(req.Path, req.Options).Wait()
+	err = vmutils.RunWithContext(ctx.Ctx, func() error {
+		return conn.Client.Create(req.Path, req.Options).Wait()
+	})
 	if err != nil {
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
@@ -547,6 +565,52 @@ func callbackMailboxChange(ctx *Context, folder string, sessionId string, sessio
 	}
 }
 
+// dialWithContext connects a client, returning early if the execution deadline expires.
+// A client connected after the deadline is closed.
+func dialWithContext(ctx *Context, getClient func(opts *imapclient.Options) (*imapclient.Client, error)) (*imapclient.Client, error) {
+	type result struct {
+		client *imapclient.Client
+		err    error
+	}
+	if err := ctx.Ctx.Context().Err(); err != nil {
+		return nil, err
+	}
+	done := make(chan result, 1)
+	go func() {
+		client, err := getClient(nil)
+		done <- result{client, err}
+	}()
+	select {
+	case res := <-done:
+		return res.client, res.err
+	case <-ctx.Ctx.Context().Done():
+		go func() {
+			if res := <-done; res.client != nil {
+				res.client.Close()
+			}
+		}()
+		return nil, ctx.Ctx.Context().Err()
+	}
+}
+
+// selectFolder selects a mailbox, returning early if the execution deadline expires
+func selectFolder(ctx *Context, c *imapclient.Client, name string) (folder *imap.SelectData, err error) {
+	err = vmutils.RunWithContext(ctx.Ctx, func() (err error) {
+		folder, err = c.Select(name, nil).Wait()
+		return err
+	})
+	return folder, err
+}
+
+// fetchWithContext fetches emails, returning early if the execution deadline expires
+func fetchWithContext(ctx *Context, c *imapclient.Client, numSet imap.NumSet, options *imap.FetchOptions, bodySection *imap.FetchItemBodySection) (emails []Email, err error) {
+	err = vmutils.RunWithContext(ctx.Ctx, func() (err error) {
+		emails, err = imapFetch(c, ctx.Ctx.Logger(), numSet, options, bodySection)
+		return err
+	})
+	return emails, err
+}
+
 func prepareResponse(rnh memc.RuntimeHandler, response interface{}) ([]interface{}, error) {
 	responsebz, err := json.Marshal(response)
 	if err != nil {
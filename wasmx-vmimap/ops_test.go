@@ -0,0 +1,89 @@
+package vmimap_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	mcodec "github.com/loredanacirstea/wasmx/codec"
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+	vmtypes "github.com/loredanacirstea/wasmx/x/wasmx/vm"
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
+
+	vmimap "github.com/loredanacirstea/wasmx-vmimap"
+)
+
+// testRuntime passes requests and responses as byte slices instead of wasm memory pointers
+type testRuntime struct{}
+
+func (testRuntime) GetVm() memc.IVm                               { return nil }
+func (testRuntime) GetMemory() (memc.IMemory, error)              { return nil, nil }
+func (testRuntime) PtrParamsLength() int                          { return 1 }
+func (testRuntime) ReadStringFromPtr(interface{}) (string, error) { return "", nil }
+func (testRuntime) ReadJsString(arr []byte) string                { return string(arr) }
+func (testRuntime) ReadMemFromPtr(pointer []interface{}) ([]byte, error) {
+	return pointer[0].([]byte), nil
+}
+func (testRuntime) AllocateWriteMem(data []byte) ([]interface{}, error) {
+	return []interface{}{data}, nil
+}
+
+func TestConnectDeadline(t *testing.T) {
+	// the server accepts connections, but never completes the TLS handshake
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	goctx, cancel := context.WithCancel(context.Background())
+	goctx = vmimap.WithImapEmptyContext(goctx)
+	group, _ := errgroup.WithContext(goctx)
+	defer func() {
+		cancel()
+		require.NoError(t, group.Wait())
+	}()
+	deadlineCtx, cancelDeadline := context.WithTimeout(goctx, 200*time.Millisecond)
+	defer cancelDeadline()
+	ctx := &vmimap.Context{Context: &vmtypes.Context{
+		GoRoutineGroup:  group,
+		GoContextParent: goctx,
+		Ctx:             sdk.Context{}.WithContext(deadlineCtx).WithLogger(log.NewNopLogger()),
+		Env: &types.Env{Contract: types.EnvContractInfo{
+			Address: mcodec.NewAccAddressPrefixed([]byte{1, 2, 3}, "mythos"),
+		}},
+	}}
+
+	reqbz, err := json.Marshal(vmimap.ImapConnectionRequest{
+		Id:            "conn",
+		ImapServerUrl: listener.Addr().String(),
+		Auth:          vmimap.ConnectionAuth{AuthType: vmimap.ConnectionAuthTypePassword},
+	})
+	require.NoError(t, err)
+	start := time.Now()
+	result, err := vmimap.Connect(ctx, testRuntime{}, []interface{}{reqbz})
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	var resp vmimap.ImapConnectionResponse
+	require.NoError(t, json.Unmarshal(result[0].([]byte), &resp))
+	require.Equal(t, context.DeadlineExceeded.Error(), resp.Error)
+	vctx, err := vmimap.GetImapContext(goctx)
+	require.NoError(t, err)
+	_, found := vctx.GetConnection(mcodec.NewAccAddressPrefixed([]byte{1, 2, 3}, "mythos").String() + "_conn")
+	require.False(t, found)
+}
@@ -8,6 +8,7 @@ import (
 	gosmtp "github.com/emersion/go-smtp"
 
 	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
+	vmutils "github.com/loredanacirstea/wasmx/x/wasmx/vm/utils"
 )
 
 func ClientConnect(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
@@ -34,7 +35,7 @@ func ClientConnect(_context interface{}, rnh memc.RuntimeHandler, params []inter
 	conn, found := vctx.GetConnection(connId)
 	if found {
 		if conn.Info.ServerUrl == req.ServerUrl {
-			err := conn.Client.Noop()
+			err := vmutils.RunWithContext(ctx.Ctx, conn.Client.Noop)
 			if err == nil {
 				return prepareResponse(rnh, response)
 			} else {
@@ -66,6 +67,10 @@ func connectCommon(
 	connId string,
 	info SmtpConnectionRequest,
 ) ([]interface{}, error) {
+	if err := ctx.Ctx.Context().Err(); err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
 	client, err := getClient()
 	if err != nil {
 		response.Error = err.Error()
@@ -252,7 +257,7 @@ func Noop(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) (
 		response.Error = "SMTP connection not found"
 		return prepareResponse(rnh, response)
 	}
-	err = conn.Client.Noop()
+	err = vmutils.RunWithContext(ctx.Ctx, conn.Client.Noop)
 	if err != nil {
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
@@ -285,7 +290,9 @@ func Hello(_context interface{}, rnh memc.RuntimeHandler, params []interface{})
 		response.Error = "SMTP connection not found"
 		return prepareResponse(rnh, response)
 	}
-	err = conn.Client.Hello(req.LocalName)
+	err = vmutils.RunWithContext(ctx.Ctx, func() error {
+		return conn.Client.Hello(req.LocalName)
+	})
 	if err != nil {
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
@@ -318,7 +325,9 @@ func Verify(_context interface{}, rnh memc.RuntimeHandler, params []interface{})
 		response.Error = "SMTP connection not found"
 		return prepareResponse(rnh, response)
 	}
-	err = conn.Client.Verify(req.Address)
+	err = vmutils.RunWithContext(ctx.Ctx, func() error {
+		return conn.Client.Verify(req.Address)
+	})
 	if err != nil {
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
@@ -416,7 +425,9 @@ func SendMail(_context interface{}, rnh memc.RuntimeHandler, params []interface{
 	fmt.Println("--SendMail from,to--", req.From, req.To)
 
 
-	err = conn.Client.SendMail(req.From, req.To, msgreader)
+	err = vmutils.RunWithContext(ctx.Ctx, func() error {
+		return conn.Client.SendMail(req.From, req.To, msgreader)
+	})
 	if err != nil {
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
@@ -40,7 +40,7 @@ func connectSmtpClient(
 			NetDialer: dialer,
 			Config:    cfg,
 		}
-		conn, err = tlsDialer.Dial(networkType, serverUrl)
+		conn, err = tlsDialer.DialContext(ctx, networkType, serverUrl)
 	}
 	if err != nil {
 		fmt.Println("--connectSmtpClient dial err--", err)
@@ -0,0 +1,56 @@
+package runtime
+
+import (
+	"math"
+	"strings"
+
+	"github.com/second-state/WasmEdge-go/wasmedge"
+
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
+)
+
+// GAS_COST_INSTRUCTION is the gas charged for each executed wasm instruction of modules
+// stored with metering off, which do not have injected gas metering. The wazero engines
+// meter these modules differently. Modules with injected gas metering charge the same
+// gas in all runtimes and are not metered by the engine.
+const GAS_COST_INSTRUCTION = uint64(1)
+
+// WasmEdge indexes its cost table by opcode, including prefixed opcodes
+const costTableSize = math.MaxUint16 + 1
+
+// WasmEdge error message when the statistics cost limit is reached
+const costLimitExceeded = "cost limit exceeded"
+
+var instructionCostTable = newCostTable(GAS_COST_INSTRUCTION)
+
+func newCostTable(cost uint64) []uint64 {
+	table := make([]uint64, costTableSize)
+	for i := range table {
+		table[i] = cost
+	}
+	return table
+}
+
+// setCostLimit limits the execution to the remaining gas and returns the total cost before execution
+func setCostLimit(stat *wasmedge.Statistics, gasMeter memc.GasMeter) uint {
+	costBefore := stat.GetTotalCost()
+	remaining := uint(gasMeter.GasRemaining())
+	if remaining > math.MaxUint-costBefore {
+		stat.SetCostLimit(math.MaxUint)
+	} else {
+		stat.SetCostLimit(costBefore + remaining)
+	}
+	return costBefore
+}
+
+// consumeExecutionGas charges the gas meter with the execution cost.
+// Like in wazero, exceeding the limit panics with out of gas.
+func consumeExecutionGas(stat *wasmedge.Statistics, costBefore uint, gasMeter memc.GasMeter, execErr error, funcname string) {
+	consumed := uint64(stat.GetTotalCost() - costBefore)
+	if execErr != nil && strings.Contains(execErr.Error(), costLimitExceeded) && consumed <= gasMeter.GasRemaining() {
+		consumed = gasMeter.GasRemaining() + 1
+	}
+	if consumed > 0 {
+		gasMeter.ConsumeGas(consumed, "wasm execution: "+funcname)
+	}
+}
@@ -3,6 +3,7 @@ package runtime
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	sdkerrors "cosmossdk.io/errors"
@@ -40,11 +41,11 @@ func (f WasmEdgeExport) Fn() interface{} {
 }
 
 type WasmEdgeImport struct {
-	moduleName string
-	name       string
-	// inputTypes  []wasmedge.ValType
-	// outputTypes []wasmedge.ValType
-	fn interface{}
+	moduleName  string
+	name        string
+	inputTypes  []wasmedge.ValType
+	outputTypes []wasmedge.ValType
+	fn          interface{}
 }
 
 func (f WasmEdgeImport) ModuleName() string {
@@ -55,13 +56,13 @@ func (f WasmEdgeImport) Name() string {
 	return f.name
 }
 
-// func (f WasmEdgeImport) InputTypes() []interface{} {
-// 	return FromValTypeSlice(f.inputTypes)
-// }
+func (f WasmEdgeImport) InputTypes() []string {
+	return valTypeNames(f.inputTypes)
+}
 
-// func (f WasmEdgeImport) OutputTypes() []interface{} {
-// 	return FromValTypeSlice(f.outputTypes)
-// }
+func (f WasmEdgeImport) OutputTypes() []string {
+	return valTypeNames(f.outputTypes)
+}
 
 func (f WasmEdgeImport) Fn() interface{} {
 	return f.fn
@@ -94,6 +95,8 @@ type WasmEdgeFn struct {
 	inputTypes  []wasmedge.ValType
 	outputTypes []wasmedge.ValType
 	cost        int32
+	// gas charged per call, from the gas schedule
+	gas memc.HostFunctionGas
 }
 
 func (f WasmEdgeFn) Name() string {
@@ -120,7 +123,14 @@ func (f WasmEdgeFn) WrappedFn(rnh memc.RuntimeHandler) func(context interface{},
 	return func(context interface{}, callframe *wasmedge.CallingFrame, params []interface{}) ([]interface{}, wasmedge.Result) {
 		vm := rnh.GetVm().(*WasmEdgeVm)
 		vm.callframe = callframe
+		// bytes moved by nested host calls are charged by those calls
+		outerBytes := vm.hostBytes
+		vm.hostBytes = 0
 		res, err := f.fn(context, rnh, params)
+		if vm.gasMeter != nil && (f.gas.Base > 0 || f.gas.PerByte > 0) {
+			vm.gasMeter.ConsumeGas(f.gas.Gas(vm.hostBytes), "host function: "+f.name)
+		}
+		vm.hostBytes = outerBytes
 		// 0, 1, 2 are used by wasmedge for success, terminate, fail
 		if err != nil {
 			if err.Error() == memc.VM_TERMINATE_ERROR {
@@ -141,6 +151,14 @@ type WasmEdgeVm struct {
 	envs      []string
 	preopens  []string
 	fileMap   map[string][]byte
+	// host function costs, looked up when modules are built
+	gasSchedule memc.GasSchedule
+	// meter of the current call, charged by host functions
+	gasMeter memc.GasMeter
+	// bytes of wasm memory accessed by the current host function
+	hostBytes uint64
+	// number of calls in progress, including calls from host functions
+	callDepth int
 }
 
 func (wm *WasmEdgeVm) New(ctx sdk.Context, aot bool) memc.IVm {
@@ -154,12 +172,55 @@ func (wm *WasmEdgeVm) Cleanup() {
 	}
 }
 
-func (wm *WasmEdgeVm) Call(funcname string, args []interface{}, gasMeter memc.GasMeter) ([]int32, error) {
+func (wm *WasmEdgeVm) Call(funcname string, args []interface{}, gasMeter memc.GasMeter) ([]interface{}, error) {
+	gasGlobal := wm.gasGlobal()
+	var gasStart, gasOuter int64
+	if gasGlobal != nil {
+		gasOuter = gasGlobal.GetValue().(int64)
+		gasStart = gasOuter
+		if gasMeter != nil {
+			gasStart = memc.GasGlobalStart(gasMeter)
+		} else if wm.callDepth == 0 {
+			gasStart = math.MaxInt64
+		}
+		gasGlobal.SetValue(gasStart)
+	}
+	var stat *wasmedge.Statistics
+	var costBefore uint
+	if gasMeter != nil && gasGlobal == nil {
+		stat = wm.vm.GetStatistics()
+		costBefore = setCostLimit(stat, gasMeter)
+	}
+	outerMeter := wm.gasMeter
+	wm.gasMeter = gasMeter
+	wm.callDepth++
 	result, err := wm.vm.Execute(funcname, args...)
+	wm.callDepth--
+	wm.gasMeter = outerMeter
+	if gasMeter != nil {
+		if gasGlobal != nil {
+			used := memc.GasGlobalUsed(gasStart, gasGlobal.GetValue().(int64))
+			// a nested call with a gas meter is not charged to the outer call
+			gasGlobal.SetValue(gasOuter)
+			gasMeter.ConsumeGas(used, "wasm execution: "+funcname)
+		} else {
+			consumeExecutionGas(stat, costBefore, gasMeter, err, funcname)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
-	return memc.ToInt32Slice(result)
+	// wasmedge returns int32, int64, float32 and float64 values, as expected by IVm
+	return result, nil
+}
+
+// gasGlobal returns the gas global of modules with injected gas metering, or nil
+func (wm *WasmEdgeVm) gasGlobal() *wasmedge.Global {
+	mod := wm.vm.GetActiveModule()
+	if mod == nil {
+		return nil
+	}
+	return mod.FindGlobal(memc.GasGlobalExport)
 }
 
 func (wm *WasmEdgeVm) GetMemory() (memc.IMemory, error) {
@@ -178,7 +239,7 @@ func (wm *WasmEdgeVm) GetMemory() (memc.IMemory, error) {
 		return nil, fmt.Errorf("could not find vm active module")
 	}
 	mem = mod.FindMemory("memory")
-	return WasmEdgeMemory{mem}, nil
+	return memc.NewCountingMemory(WasmEdgeMemory{mem}, &wm.hostBytes), nil
 }
 
 func (wm *WasmEdgeVm) GetFunctionList() []string {
@@ -267,6 +328,10 @@ func (wm *WasmEdgeVm) ValType_I64() interface{} {
 	return wasmedge.ValType_I64
 }
 
+func (wm *WasmEdgeVm) ValType_F32() interface{} {
+	return wasmedge.ValType_F32
+}
+
 func (wm *WasmEdgeVm) ValType_F64() interface{} {
 	return wasmedge.ValType_F64
 }
@@ -314,7 +379,10 @@ func (wm *WasmEdgeVm) BuildModuleInner(rnh memc.RuntimeHandler, modname string,
 	envmod := wasmedge.NewModule(modname)
 	wm.cleanups = append(wm.cleanups, envmod.Release)
 	for _, fndef := range fndefs {
-		envmod.AddFunction(fndef.name, wasmedge.NewFunction(wasmedge.NewFunctionType(fndef.inputTypes, fndef.outputTypes), fndef.WrappedFn(rnh), context, uint(fndef.cost)))
+		// the build cost is not charged, all runtimes charge the schedule cost
+		// in WrappedFn, together with the per-byte cost
+		fndef.gas = wm.gasSchedule.Cost(modname, fndef.name)
+		envmod.AddFunction(fndef.name, wasmedge.NewFunction(wasmedge.NewFunctionType(fndef.inputTypes, fndef.outputTypes), fndef.WrappedFn(rnh), context, 0))
 	}
 	return envmod
 }
@@ -326,7 +394,7 @@ func (WasmEdgeVmMeta) LibVersion() string {
 	return wasmedge.GetVersion()
 }
 
-func (m *WasmEdgeVmMeta) InitWasmRuntime(parentCtx context.Context) {
+func (m *WasmEdgeVmMeta) InitWasmRuntime(parentCtx context.Context, _ memc.WasmRuntimeConfig) {
 	// TODO cache for compiled modules - see wazero
 }
 
@@ -349,16 +417,22 @@ func (WasmEdgeVmMeta) AnalyzeWasm(_ sdk.Context, wasmbuffer []byte) (memc.WasmMe
 	imports := ast.ListImports()
 	exports := ast.ListExports()
 	meta := &WasmEdgeMeta{}
-	meta.imports = make([]WasmEdgeImport, len(imports))
+	meta.imports = make([]WasmEdgeImport, 0, len(imports))
 	meta.exports = make([]WasmEdgeExport, len(exports))
 
-	for i, mimport := range imports {
-		// mimport.GetExternalType()
-		meta.imports[i] = WasmEdgeImport{
-			moduleName: mimport.GetModuleName(),
-			name:       mimport.GetExternalName(),
-			fn:         mimport.GetExternalValue(),
+	// only function imports, same as wazero
+	for _, mimport := range imports {
+		if mimport.GetExternalType() != wasmedge.ExternType_Function {
+			continue
 		}
+		fntype := mimport.GetExternalValue().(*wasmedge.FunctionType)
+		meta.imports = append(meta.imports, WasmEdgeImport{
+			moduleName:  mimport.GetModuleName(),
+			name:        mimport.GetExternalName(),
+			inputTypes:  fntype.GetParameters(),
+			outputTypes: fntype.GetReturns(),
+			fn:          fntype,
+		})
 	}
 	for i, mexport := range exports {
 		meta.exports[i] = WasmEdgeExport{
@@ -372,13 +446,18 @@ func (WasmEdgeVmMeta) AnalyzeWasm(_ sdk.Context, wasmbuffer []byte) (memc.WasmMe
 func (WasmEdgeVmMeta) AotCompile(_ sdk.Context, inPath string, outPath string, meteringOff bool) error {
 	// Create Configure
 	// conf := wasmedge.NewConfigure(wasmedge.THREADS, wasmedge.EXTENDED_CONST, wasmedge.TAIL_CALL, wasmedge.MULTI_MEMORIES)
+	conf := wasmedge.NewConfigure()
+	if !meteringOff {
+		// AOT code only reports costs if it is compiled with cost measuring
+		conf.SetStatisticsInstructionCounting(true)
+		conf.SetStatisticsCostMeasuring(true)
+	}
 
 	// Create Compiler
-	// compiler := wasmedge.NewCompilerWithConfig(conf)
-	compiler := wasmedge.NewCompiler()
+	compiler := wasmedge.NewCompilerWithConfig(conf)
 	defer func() {
 		compiler.Release()
-		// conf.Release()
+		conf.Release()
 	}()
 
 	// Compile WASM AOT
@@ -390,7 +469,7 @@ func (WasmEdgeVmMeta) AotCompile(_ sdk.Context, inPath string, outPath string, m
 	return nil
 }
 
-func NewWasmEdgeVm(_ sdk.Context, _ bool) memc.IVm {
+func NewWasmEdgeVm(ctx sdk.Context, _ bool) memc.IVm {
 	var cleanups []func()
 
 	// wasmedge.SetLogOff()
@@ -400,20 +479,28 @@ func NewWasmEdgeVm(_ sdk.Context, _ bool) memc.IVm {
 	conf := wasmedge.NewConfigure()
 	cleanups = append(cleanups, conf.Release)
 
-	// conf.SetStatisticsInstructionCounting(true)
+	// gas metering, see gas.go
+	conf.SetStatisticsInstructionCounting(true)
+	conf.SetStatisticsCostMeasuring(true)
 	// conf.SetStatisticsTimeMeasuring(true)
+	// memory.grow fails above this limit
+	if memoryLimitPages := memc.GetMemoryLimitPages(ctx); memoryLimitPages > 0 {
+		conf.SetMaxMemoryPage(uint(min(memoryLimitPages, memc.WASM_MAX_PAGES)))
+	}
 	// TODO allow wasi only for core contracts
 	// conf.AddConfig(wasmedge.WASI)
 	contractVm := wasmedge.NewVMWithConfig(conf)
 	// contractVm := wasmedge.NewVM()
+	contractVm.GetStatistics().SetCostTable(instructionCostTable)
 
 	// first in, last cleaned up
 	cleanups = append(cleanups, conf.Release)
 	cleanups = append(cleanups, contractVm.Release)
 
 	return &WasmEdgeVm{
-		vm:       contractVm,
-		cleanups: cleanups,
+		vm:          contractVm,
+		cleanups:    cleanups,
+		gasSchedule: memc.GetGasSchedule(ctx),
 	}
 }
 
@@ -437,6 +524,14 @@ func FromValTypeSlice(input []wasmedge.ValType) []interface{} {
 	return result
 }
 
+func valTypeNames(input []wasmedge.ValType) []string {
+	result := make([]string, len(input))
+	for i, v := range input {
+		result[i] = v.String()
+	}
+	return result
+}
+
 // Returns the hex address of the interpreter if exists or the version string
 func parseDependencyOrHexAddr(contractVersion string, part string) string {
 	dep := contractVersion
@@ -0,0 +1,213 @@
+package runtime
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+
+	"github.com/tetratelabs/wazero"
+)
+
+// the engine keeps one compiled code per wasm binary, for each engine kind,
+// so AOT deserialized modules and compiled modules share the same key
+const (
+	moduleModeInterpreter = "interpreter"
+	moduleModeCompiler    = "compiler"
+)
+
+// ModuleCacheMetrics are the counters of a ModuleCache since it was created
+type ModuleCacheMetrics struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int
+}
+
+type moduleCacheEntry struct {
+	key     ModuleCacheKey
+	mod     wazero.CompiledModule
+	refs    int
+	evicted bool
+}
+
+// ModuleCache is a size-bounded LRU cache of compiled modules, keyed by code checksum,
+// compilation mode and memory limit. Compiled modules are shared between runtimes and
+// the engine keeps one compiled code per wasm binary and mode, for all memory limits,
+// so the engine code is only closed after all the modules using it have been evicted
+// and instantiated and nobody is compiling the same binary.
+type ModuleCache struct {
+	ctx     context.Context
+	mtx     sync.Mutex
+	size    int
+	order   *list.List
+	entries map[ModuleCacheKey]*list.Element
+	// key => number of callers compiling the module after a miss
+	pending map[ModuleCacheKey]int
+	// engine code => number of cached modules, evicted modules still in use and pending compilations
+	codeUsers map[string]int
+	// engine code => an evicted module, closed when the engine code has no users
+	unused  map[string]wazero.CompiledModule
+	metrics ModuleCacheMetrics
+}
+
+func NewModuleCache(ctx context.Context, size int) *ModuleCache {
+	return &ModuleCache{
+		ctx:       ctx,
+		size:      size,
+		order:     list.New(),
+		entries:   map[ModuleCacheKey]*list.Element{},
+		pending:   map[ModuleCacheKey]int{},
+		codeUsers: map[string]int{},
+		unused:    map[string]wazero.CompiledModule{},
+	}
+}
+
+// ModuleCacheKey also contains the memory limit, because
+// the limit is applied when the module is compiled
+type ModuleCacheKey struct {
+	Checksum         string
+	Mode             string
+	MemoryLimitPages uint32
+}
+
+// engineCode identifies the engine code shared by the compiled modules with different memory limits
+func (k ModuleCacheKey) engineCode() string {
+	return fmt.Sprintf("%s_%s", k.Checksum, k.Mode)
+}
+
+func WasmChecksum(wasmbuffer []byte) string {
+	checksum := sha256.Sum256(wasmbuffer)
+	return hex.EncodeToString(checksum[:])
+}
+
+func (c *ModuleCache) Enabled() bool {
+	return c != nil && c.size > 0
+}
+
+// Get returns the cached compiled module and a release function,
+// to be called after the module has been instantiated.
+// On a miss, the caller must compile the module and call Add or Abort with the same key.
+func (c *ModuleCache) Get(key ModuleCacheKey) (wazero.CompiledModule, func()) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	elem, found := c.entries[key]
+	if !found {
+		c.pending[key] += 1
+		c.codeUsers[key.engineCode()] += 1
+		c.metrics.Misses += 1
+		telemetry.IncrCounter(1, "wasmx", "wazero", "module_cache", "miss")
+		return nil, nil
+	}
+	c.metrics.Hits += 1
+	telemetry.IncrCounter(1, "wasmx", "wazero", "module_cache", "hit")
+	c.order.MoveToFront(elem)
+	entry := elem.Value.(*moduleCacheEntry)
+	entry.refs += 1
+	return entry.mod, c.releaseFn(entry)
+}
+
+// Add caches a compiled module, evicting the least recently used ones.
+// It returns a release function, to be called after the module has been instantiated
+func (c *ModuleCache) Add(key ModuleCacheKey, mod wazero.CompiledModule) func() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.donePending(key)
+	if elem, found := c.entries[key]; found {
+		// compiled concurrently; both modules share the same engine code
+		c.order.MoveToFront(elem)
+		entry := elem.Value.(*moduleCacheEntry)
+		entry.refs += 1
+		return c.releaseFn(entry)
+	}
+	entry := &moduleCacheEntry{key: key, mod: mod, refs: 1}
+	c.entries[key] = c.order.PushFront(entry)
+	c.codeUsers[key.engineCode()] += 1
+	for c.order.Len() > c.size {
+		c.evict(c.order.Back())
+	}
+	return c.releaseFn(entry)
+}
+
+// Abort is called instead of Add, when compiling a module after a miss failed
+func (c *ModuleCache) Abort(key ModuleCacheKey) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.donePending(key)
+}
+
+func (c *ModuleCache) Metrics() ModuleCacheMetrics {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	metrics := c.metrics
+	metrics.Size = c.order.Len()
+	return metrics
+}
+
+func (c *ModuleCache) Close() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for c.order.Len() > 0 {
+		c.evict(c.order.Back())
+	}
+}
+
+// must be called with the lock held
+func (c *ModuleCache) evict(elem *list.Element) {
+	entry := elem.Value.(*moduleCacheEntry)
+	c.order.Remove(elem)
+	delete(c.entries, entry.key)
+	entry.evicted = true
+	c.metrics.Evictions += 1
+	telemetry.IncrCounter(1, "wasmx", "wazero", "module_cache", "eviction")
+	c.closeIfUnused(entry)
+}
+
+// must be called with the lock held
+func (c *ModuleCache) closeIfUnused(entry *moduleCacheEntry) {
+	if !entry.evicted || entry.refs > 0 {
+		return
+	}
+	c.unused[entry.key.engineCode()] = entry.mod
+	c.doneCode(entry.key.engineCode())
+}
+
+// must be called with the lock held
+func (c *ModuleCache) donePending(key ModuleCacheKey) {
+	c.pending[key] -= 1
+	if c.pending[key] <= 0 {
+		delete(c.pending, key)
+	}
+	c.doneCode(key.engineCode())
+}
+
+// doneCode closes the engine code after its last user is done.
+// Closing any module deletes the engine code shared by all of them.
+// must be called with the lock held
+func (c *ModuleCache) doneCode(code string) {
+	c.codeUsers[code] -= 1
+	if c.codeUsers[code] > 0 {
+		return
+	}
+	delete(c.codeUsers, code)
+	if mod, found := c.unused[code]; found {
+		delete(c.unused, code)
+		mod.Close(c.ctx)
+	}
+}
+
+func (c *ModuleCache) releaseFn(entry *moduleCacheEntry) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.mtx.Lock()
+			defer c.mtx.Unlock()
+			entry.refs -= 1
+			c.closeIfUnused(entry)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package runtime_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tetratelabs/wazero"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/vm/determinism/testutils"
+
+	runtime "github.com/loredanacirstea/wasmx-wazero"
+)
+
+func TestModuleCache(t *testing.T) {
+	ctx := context.Background()
+	cache := wazero.NewCompilationCache()
+	defer cache.Close(ctx)
+	r := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfigInterpreter().WithCompilationCache(cache))
+	defer r.Close(ctx)
+
+	modules := runtime.NewModuleCache(ctx, 1)
+	require.True(t, modules.Enabled())
+	require.False(t, runtime.NewModuleCache(ctx, 0).Enabled())
+
+	key1 := runtime.ModuleCacheKey{Checksum: runtime.WasmChecksum(wasmxSimpleStorage), Mode: "interpreter"}
+	key2 := runtime.ModuleCacheKey{Checksum: runtime.WasmChecksum(tinygoSimpleStorage), Mode: "interpreter"}
+
+	mod, release := modules.Get(key1)
+	require.Nil(t, mod)
+	require.Nil(t, release)
+	compiled1, err := r.CompileModule(ctx, wasmxSimpleStorage)
+	require.NoError(t, err)
+	release = modules.Add(key1, compiled1)
+	release()
+
+	mod, release = modules.Get(key1)
+	require.Equal(t, compiled1, mod)
+	release()
+	// releasing twice has no effect
+	release()
+
+	mod, _ = modules.Get(key2)
+	require.Nil(t, mod)
+	compiled2, err := r.CompileModule(ctx, tinygoSimpleStorage)
+	require.NoError(t, err)
+	release = modules.Add(key2, compiled2)
+	release()
+
+	mod, _ = modules.Get(key1)
+	require.Nil(t, mod)
+	modules.Abort(key1)
+
+	require.Equal(t, runtime.ModuleCacheMetrics{Hits: 1, Misses: 3, Evictions: 1, Size: 1}, modules.Metrics())
+
+	modules.Close()
+	require.Equal(t, 0, modules.Metrics().Size)
+}
+
+func TestModuleCacheMemoryLimits(t *testing.T) {
+	ctx := context.Background()
+	cache := wazero.NewCompilationCache()
+	defer cache.Close(ctx)
+	// both runtimes share the engine code of the same binary
+	r1 := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfigInterpreter().WithCompilationCache(cache).WithMemoryLimitPages(16))
+	defer r1.Close(ctx)
+	r2 := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfigInterpreter().WithCompilationCache(cache).WithMemoryLimitPages(32))
+	defer r2.Close(ctx)
+
+	modules := runtime.NewModuleCache(ctx, 1)
+	checksum := runtime.WasmChecksum(testutils.F32Add)
+	key1 := runtime.ModuleCacheKey{Checksum: checksum, Mode: "interpreter", MemoryLimitPages: 16}
+	key2 := runtime.ModuleCacheKey{Checksum: checksum, Mode: "interpreter", MemoryLimitPages: 32}
+
+	mod, _ := modules.Get(key1)
+	require.Nil(t, mod)
+	compiled1, err := r1.CompileModule(ctx, testutils.F32Add)
+	require.NoError(t, err)
+	modules.Add(key1, compiled1)()
+
+	mod, _ = modules.Get(key2)
+	require.Nil(t, mod)
+	compiled2, err := r2.CompileModule(ctx, testutils.F32Add)
+	require.NoError(t, err)
+	release := modules.Add(key2, compiled2)
+	require.Equal(t, uint64(1), modules.Metrics().Evictions)
+
+	// evicting the first module does not close the engine code of the second one
+	inst, err := r2.InstantiateModule(ctx, compiled2, wazero.NewModuleConfig().WithName(""))
+	require.NoError(t, err)
+	require.NoError(t, inst.Close(ctx))
+	release()
+
+	modules.Close()
+	_, err = r2.InstantiateModule(ctx, compiled2, wazero.NewModuleConfig().WithName(""))
+	require.Error(t, err)
+}
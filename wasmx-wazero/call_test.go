@@ -0,0 +1,50 @@
+package runtime_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
+
+	runtime "github.com/loredanacirstea/wasmx-wazero"
+)
+
+// (module
+//
+//	(func (export "add") (param i64 i64) (result i64) (i64.add (local.get 0) (local.get 1)))
+//	(func (export "swap") (param i32 f32) (result f32 i32) (local.get 1) (local.get 0)))
+var typedExports = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x0e, 0x02, 0x60, 0x02, 0x7e, 0x7e, 0x01, 0x7e, 0x60, 0x02, 0x7f, 0x7d, 0x02, 0x7d, 0x7f,
+	0x03, 0x03, 0x02, 0x00, 0x01,
+	0x07, 0x0e, 0x02, 0x03, 0x61, 0x64, 0x64, 0x00, 0x00, 0x04, 0x73, 0x77, 0x61, 0x70, 0x00, 0x01,
+	0x0a, 0x10, 0x02, 0x07, 0x00, 0x20, 0x00, 0x20, 0x01, 0x7c, 0x0b, 0x06, 0x00, 0x20, 0x01, 0x20, 0x00, 0x0b,
+}
+
+func TestCallTypedValues(t *testing.T) {
+	ctx := sdk.Context{}
+	ctx = ctx.WithContext(context.Background())
+
+	meta := &runtime.WazeroVmMeta{}
+	meta.InitWasmRuntime(ctx, memc.WasmRuntimeConfig{})
+	vm := meta.NewWasmVm(ctx, false)
+	defer vm.Cleanup()
+	require.NoError(t, vm.InstantiateWasm("", "", typedExports))
+
+	res, err := vm.Call("add", []interface{}{int64(1) << 40, int64(-3)}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{int64(1)<<40 - 3}, res)
+
+	res, err = vm.Call("swap", []interface{}{int32(-7), float32(1.5)}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{float32(1.5), int32(-7)}, res)
+
+	_, err = vm.Call("add", []interface{}{int32(1), int64(2)}, nil)
+	require.ErrorContains(t, err, "argument 0: expected i64, got int32")
+
+	_, err = vm.Call("add", []interface{}{int64(1)}, nil)
+	require.ErrorContains(t, err, "expected 2 arguments, got 1")
+}
@@ -0,0 +1,36 @@
+package runtime_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/vm/determinism"
+	"github.com/loredanacirstea/wasmx/x/wasmx/vm/determinism/testutils"
+)
+
+func TestCanonicalNaN(t *testing.T) {
+	report, code, err := determinism.Check(testutils.F32Add, determinism.Policy{Features: map[string]bool{determinism.FeatureFloats: true}})
+	require.NoError(t, err)
+	require.NoError(t, report.Error())
+
+	for _, config := range []wazero.RuntimeConfig{wazero.NewRuntimeConfigInterpreter(), wazero.NewRuntimeConfig()} {
+		ctx := context.Background()
+		r := wazero.NewRuntimeWithConfig(ctx, config)
+		mod, err := r.Instantiate(ctx, code)
+		require.NoError(t, err)
+		run := mod.ExportedFunction("run")
+
+		// NaN with a payload
+		res, err := run.Call(ctx, 0x7fc00001)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0x7fc00000), uint32(res[0]))
+
+		res, err = run.Call(ctx, 0x3f800000) // 1.0
+		require.NoError(t, err)
+		require.Equal(t, uint32(0x40000000), uint32(res[0]))
+		require.NoError(t, r.Close(ctx))
+	}
+}
@@ -0,0 +1,47 @@
+package runtime_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
+
+	runtime "github.com/loredanacirstea/wasmx-wazero"
+)
+
+// (module
+//
+//	(func (export "loop")
+//	  (loop (br 0))))
+var infiniteLoop = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x04, 0x01, 0x60, 0x00, 0x00,
+	0x03, 0x02, 0x01, 0x00,
+	0x07, 0x08, 0x01, 0x04, 0x6c, 0x6f, 0x6f, 0x70, 0x00, 0x00,
+	0x0a, 0x09, 0x01, 0x07, 0x00, 0x03, 0x40, 0x0c, 0x00, 0x0b, 0x0b,
+}
+
+func TestExecutionDeadline(t *testing.T) {
+	goCtx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	ctx := sdk.Context{}
+	ctx = ctx.WithContext(goCtx)
+
+	meta := &runtime.WazeroVmMeta{}
+	meta.InitWasmRuntime(ctx, memc.WasmRuntimeConfig{})
+
+	vm := meta.NewWasmVm(ctx, false)
+	defer vm.Cleanup()
+	err := vm.InstantiateWasm("", "", infiniteLoop)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = vm.Call("loop", []interface{}{}, nil)
+	require.Error(t, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+	require.ErrorIs(t, goCtx.Err(), context.DeadlineExceeded)
+}
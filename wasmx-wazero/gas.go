@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"errors"
+	"math"
 
 	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
 )
@@ -26,7 +27,11 @@ var ErrOutOfGas = errors.New("out of gas")
 
 // ConsumeGas attempts to consume the given amount of gas and returns error if not enough gas remains
 func (g *GasMeter) ConsumeGas(amount uint64, descriptor string) {
-	g.gasUsed += amount
+	if amount > math.MaxUint64-g.gasUsed {
+		g.gasUsed = math.MaxUint64
+	} else {
+		g.gasUsed += amount
+	}
 	if g.gasUsed > g.gasLimit {
 		// we expect this to error with out of gas
 		if g.gasMeter != nil {
@@ -0,0 +1,83 @@
+package runtime_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	membase "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/base"
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
+
+	runtime "github.com/loredanacirstea/wasmx-wazero"
+)
+
+// (module
+//
+//	(import "env" "read" (func (param i32 i32)))
+//	(memory (export "memory") 1)
+//	(func (export "run") (call 0 (i32.const 0) (i32.const 100))))
+var hostRead = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x09, 0x02, 0x60, 0x02, 0x7f, 0x7f, 0x00, 0x60, 0x00, 0x00,
+	0x02, 0x0c, 0x01, 0x03, 0x65, 0x6e, 0x76, 0x04, 0x72, 0x65, 0x61, 0x64, 0x00, 0x00,
+	0x03, 0x02, 0x01, 0x01,
+	0x05, 0x03, 0x01, 0x00, 0x01,
+	0x07, 0x10, 0x02, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x02, 0x00, 0x03, 0x72, 0x75, 0x6e, 0x00, 0x01,
+	0x0a, 0x0b, 0x01, 0x09, 0x00, 0x41, 0x00, 0x41, 0xe4, 0x00, 0x10, 0x00, 0x0b,
+}
+
+func runHostRead(t *testing.T, meta *runtime.WazeroVmMeta, ctx sdk.Context, cost int32) uint64 {
+	vm := meta.NewWasmVm(ctx, false)
+	defer vm.Cleanup()
+	rnh := membase.NewRuntimeHandlerBase(vm)
+	read := func(_ interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+		mem, err := rnh.GetMemory()
+		if err != nil {
+			return nil, err
+		}
+		_, err = mem.Read(params[0].(int32), params[1].(int32))
+		return nil, err
+	}
+	fndefs := []memc.IFn{
+		vm.BuildFn("read", read, []interface{}{vm.ValType_I32(), vm.ValType_I32()}, []interface{}{}, cost),
+	}
+	mod, err := vm.BuildModule(rnh, "env", nil, fndefs)
+	require.NoError(t, err)
+	require.NoError(t, vm.RegisterModule(mod))
+	require.NoError(t, vm.InstantiateWasm("", "", hostRead))
+
+	gasMeter := runtime.NewGasMeter(100_000_000, 0, nil)
+	_, err = vm.Call("run", []interface{}{}, gasMeter)
+	require.NoError(t, err)
+	return gasMeter.GasConsumed()
+}
+
+func TestHostFunctionGasSchedule(t *testing.T) {
+	ctx := sdk.Context{}
+	ctx = ctx.WithContext(context.Background())
+
+	meta := &runtime.WazeroVmMeta{}
+	meta.InitWasmRuntime(ctx, memc.WasmRuntimeConfig{})
+
+	free := runHostRead(t, meta, memc.WithGasSchedule(ctx, memc.GasSchedule{memc.HostFunctionKey("env", "read"): {}}), 0)
+
+	// unscheduled functions cost the default gas, not the cost they are built with
+	unscheduled := runHostRead(t, meta, ctx, 0)
+	require.Equal(t, free+memc.DefaultHostFunctionGas.Gas(100), unscheduled)
+	require.Equal(t, unscheduled, runHostRead(t, meta, ctx, 500))
+
+	schedule := memc.GasSchedule{
+		memc.HostFunctionKey("env", "read"): {Base: 1000, PerByte: 3},
+	}
+	scheduled := runHostRead(t, meta, memc.WithGasSchedule(ctx, schedule), 500)
+	require.Equal(t, free+1000+3*100, scheduled)
+
+	// entries of other modules do not apply
+	schedule = memc.GasSchedule{
+		memc.HostFunctionKey("wasmx", "read"): {Base: 1000, PerByte: 3},
+	}
+	scheduled = runHostRead(t, meta, memc.WithGasSchedule(ctx, schedule), 500)
+	require.Equal(t, unscheduled, scheduled)
+}
@@ -0,0 +1,62 @@
+package runtime_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/vm/determinism"
+	"github.com/loredanacirstea/wasmx/x/wasmx/vm/determinism/testutils"
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
+
+	runtime "github.com/loredanacirstea/wasmx-wazero"
+)
+
+func runLoop(t *testing.T, meta *runtime.WazeroVmMeta, ctx sdk.Context, aot bool, wasm []byte, n int32, gasLimit uint64) (gasUsed uint64, outOfGas bool) {
+	vm := meta.NewWasmVm(ctx, aot)
+	defer vm.Cleanup()
+	require.NoError(t, vm.InstantiateWasm("", "", wasm))
+	gasMeter := runtime.NewGasMeter(gasLimit, 0, nil)
+	defer func() {
+		if r := recover(); r != nil {
+			require.Equal(t, runtime.ErrOutOfGas, r)
+			outOfGas = true
+		}
+	}()
+	_, err := vm.Call("run", []interface{}{n}, gasMeter)
+	require.NoError(t, err)
+	return gasMeter.GasConsumed(), false
+}
+
+// TestInjectedGasMetering asserts that the interpreter and the compiler charge the same gas
+// for modules with injected gas metering, and run out of gas at the same point
+func TestInjectedGasMetering(t *testing.T) {
+	ctx := sdk.Context{}
+	ctx = ctx.WithContext(context.Background())
+	meta := &runtime.WazeroVmMeta{}
+	meta.InitWasmRuntime(ctx, memc.WasmRuntimeConfig{})
+	metered, err := determinism.InjectGasMetering(testutils.Loop)
+	require.NoError(t, err)
+
+	for _, aot := range []bool{false, true} {
+		gasUsed, outOfGas := runLoop(t, meta, ctx, aot, metered, 10, 1_000_000)
+		require.False(t, outOfGas)
+		require.Equal(t, testutils.LoopGas(10), gasUsed)
+
+		gasUsed, outOfGas = runLoop(t, meta, ctx, aot, metered, 10, testutils.LoopGas(10))
+		require.False(t, outOfGas)
+		require.Equal(t, testutils.LoopGas(10), gasUsed)
+
+		_, outOfGas = runLoop(t, meta, ctx, aot, metered, 10, testutils.LoopGas(10)-1)
+		require.True(t, outOfGas)
+
+		// calls without a gas meter are not limited
+		vm := meta.NewWasmVm(ctx, aot)
+		require.NoError(t, vm.InstantiateWasm("", "", metered))
+		_, err = vm.Call("run", []interface{}{int32(1000)}, nil)
+		require.NoError(t, err)
+		vm.Cleanup()
+	}
+}
@@ -0,0 +1,53 @@
+package runtime_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
+
+	runtime "github.com/loredanacirstea/wasmx-wazero"
+)
+
+// (module
+//
+//	(memory (export "memory") 1)
+//	(func (export "grow") (result i32)
+//	  (loop (br_if 0 (i32.ne (memory.grow (i32.const 1)) (i32.const -1))))
+//	  (memory.size)))
+var growBomb = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x05, 0x01, 0x60, 0x00, 0x01, 0x7f,
+	0x03, 0x02, 0x01, 0x00,
+	0x05, 0x03, 0x01, 0x00, 0x01,
+	0x07, 0x11, 0x02, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x02, 0x00, 0x04, 0x67, 0x72, 0x6f, 0x77, 0x00, 0x00,
+	0x0a, 0x12, 0x01, 0x10, 0x00, 0x03, 0x40, 0x41, 0x01, 0x40, 0x00, 0x41, 0x7f, 0x47, 0x0d, 0x00, 0x0b, 0x3f, 0x00, 0x0b,
+}
+
+func TestMemoryLimitPages(t *testing.T) {
+	ctx := sdk.Context{}
+	ctx = ctx.WithContext(context.Background())
+
+	meta := &runtime.WazeroVmMeta{}
+	meta.InitWasmRuntime(ctx, memc.WasmRuntimeConfig{ModuleCacheSize: 10})
+
+	// the compiled module is cached per memory limit
+	for _, pages := range []uint32{4, 8, 4} {
+		vm := meta.NewWasmVm(memc.WithMemoryLimitPages(ctx, pages), false)
+		err := vm.InstantiateWasm("", "", growBomb)
+		require.NoError(t, err)
+
+		res, err := vm.Call("grow", []interface{}{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{int32(pages)}, res)
+
+		mem, err := vm.GetMemory()
+		require.NoError(t, err)
+		require.True(t, memc.MemoryLimitReached(mem, pages))
+		require.False(t, memc.MemoryLimitReached(mem, pages+1))
+		vm.Cleanup()
+	}
+}
@@ -3,8 +3,10 @@ package runtime
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"strings"
+	"sync"
 
 	sdkerrors "cosmossdk.io/errors"
 
@@ -34,9 +36,11 @@ func (f WazeroExport) Fn() interface{} {
 }
 
 type WazeroImport struct {
-	moduleName string
-	name       string
-	fn         interface{}
+	moduleName  string
+	name        string
+	inputTypes  []api.ValueType
+	outputTypes []api.ValueType
+	fn          interface{}
 }
 
 type WazeroMeta struct {
@@ -68,6 +72,14 @@ func (f WazeroImport) Name() string {
 	return f.name
 }
 
+func (f WazeroImport) InputTypes() []string {
+	return valueTypeNames(f.inputTypes)
+}
+
+func (f WazeroImport) OutputTypes() []string {
+	return valueTypeNames(f.outputTypes)
+}
+
 func (f WazeroImport) Fn() interface{} {
 	return f.fn
 }
@@ -78,6 +90,8 @@ type WazeroFn struct {
 	inputTypes  []api.ValueType
 	outputTypes []api.ValueType
 	cost        int32
+	// gas charged per call, from the gas schedule
+	gas memc.HostFunctionGas
 }
 
 func (f WazeroFn) Name() string {
@@ -108,7 +122,14 @@ func (f WazeroFn) WrappedFn(rnh memc.RuntimeHandler, _context interface{}, input
 		for i, val := range stack[0:len(inputTypes)] {
 			params[i] = ValueFromUint64(val, inputTypes[i])
 		}
+		// bytes moved by nested host calls are charged by those calls
+		outerBytes := vm.hostBytes
+		vm.hostBytes = 0
 		results, err := f.fn(_context, rnh, params)
+		if vm.gasMeter != nil && (f.gas.Base > 0 || f.gas.PerByte > 0) {
+			vm.gasMeter.ConsumeGas(f.gas.Gas(vm.hostBytes), "host function: "+f.name)
+		}
+		vm.hostBytes = outerBytes
 		if err != nil {
 			if err.Error() == memc.VM_TERMINATE_ERROR {
 				panic(err)
@@ -135,6 +156,16 @@ type WazeroVm struct {
 	envs        []string
 	preopens    []string
 	fileMap     map[string][]byte
+	// memory limit baked into the compiled modules, 0 for the wasm default
+	memoryLimitPages uint32
+	// host function costs, looked up when modules are built
+	gasSchedule memc.GasSchedule
+	// meter of the current call, charged by host functions
+	gasMeter *GasMeter
+	// bytes of wasm memory accessed by the current host function
+	hostBytes uint64
+	// number of calls in progress, including calls from host functions
+	callDepth int
 }
 
 type WasmEngineCache struct {
@@ -144,8 +175,11 @@ type WasmEngineCache struct {
 	// CompiledConfig  wazero.RuntimeConfig
 	// CompiledRuntime wazero.Runtime
 
-	// wasmFilePath => compiledModule
-	CompiledModules map[string]wazero.CompiledModule
+	// code checksum => compiledModule
+	Modules *ModuleCache
+
+	// wasmFilePath => code checksum; wasm files are content-addressed
+	checksums sync.Map
 
 	// e.g. wazero has a compilation cache
 	CompilationCache wazero.CompilationCache
@@ -168,8 +202,8 @@ type WasmEngineCache struct {
 //	func (v *WasmEngineCache) GetCompiledRuntime() interface{} {
 //		return v.CompiledRuntime
 //	}
-func (v *WasmEngineCache) GetCompiledModules() interface{} {
-	return v.CompiledModules
+func (v *WasmEngineCache) GetModuleCache() *ModuleCache {
+	return v.Modules
 }
 func (v *WasmEngineCache) GetCompilationCache() interface{} {
 	return v.CompilationCache
@@ -183,21 +217,48 @@ func (v *WasmEngineCache) Close() {
 		v.cleanups[i]()
 	}
 }
-func (v *WasmEngineCache) GetCompiledModule(wasmFilePath string) wazero.CompiledModule {
-	mod, ok := v.CompiledModules[wasmFilePath]
-	if ok && mod != nil {
-		return mod
+
+// GetChecksum returns the checksum of the wasm code and the code, if it had to be read
+func (v *WasmEngineCache) GetChecksum(wasmFilePath string, wasmbuffer []byte) (string, []byte, error) {
+	if wasmbuffer != nil {
+		return WasmChecksum(wasmbuffer), wasmbuffer, nil
 	}
-	return nil
-}
-func (v *WasmEngineCache) SetCompiledModule(wasmFilePath string, mod wazero.CompiledModule) {
-	v.CompiledModules[wasmFilePath] = mod
+	if checksum, ok := v.checksums.Load(wasmFilePath); ok {
+		return checksum.(string), nil, nil
+	}
+	wasmbuffer, err := os.ReadFile(wasmFilePath)
+	if err != nil {
+		return "", nil, sdkerrors.Wrapf(err, "load wasm file failed %s", wasmFilePath)
+	}
+	checksum := WasmChecksum(wasmbuffer)
+	v.checksums.Store(wasmFilePath, checksum)
+	return checksum, wasmbuffer, nil
 }
 
+// NewWazeroRuntime keeps compiled code in memory, without caching compiled modules
 func NewWazeroRuntime(parentCtx context.Context) *WasmEngineCache {
+	cache, err := NewWazeroRuntimeWithConfig(parentCtx, memc.WasmRuntimeConfig{})
+	if err != nil {
+		panic(err)
+	}
+	return cache
+}
+
+func NewWazeroRuntimeWithConfig(parentCtx context.Context, cfg memc.WasmRuntimeConfig) (*WasmEngineCache, error) {
 	cache := &WasmEngineCache{ctx: parentCtx}
-	cache.CompilationCache = wazero.NewCompilationCache()
-	cache.CompiledModules = map[string]wazero.CompiledModule{}
+	if cfg.CompilationCacheDir != "" {
+		// compiled code is also written to disk, so restarts are warm
+		compilationCache, err := wazero.NewCompilationCacheWithDir(cfg.CompilationCacheDir)
+		if err != nil {
+			return nil, sdkerrors.Wrapf(err, "wazero compilation cache dir %s", cfg.CompilationCacheDir)
+		}
+		cache.CompilationCache = compilationCache
+	} else {
+		cache.CompilationCache = wazero.NewCompilationCache()
+	}
+	cache.Modules = NewModuleCache(parentCtx, cfg.ModuleCacheSize)
+	cache.AddCleanup(func() { cache.CompilationCache.Close(parentCtx) })
+	cache.AddCleanup(cache.Modules.Close)
 	// cache.InterpreterConfig = wazero.NewRuntimeConfigInterpreter().
 	// 	WithCloseOnContextDone(true).                // for now, we let the execution finish in case we need to save block data in our core contracts
 	// 	WithCompilationCache(cache.CompilationCache) // .WithDebugInfoEnabled(true)
@@ -207,16 +268,9 @@ func NewWazeroRuntime(parentCtx context.Context) *WasmEngineCache {
 
 	// cache.InterpreterRuntime = wazero.NewRuntimeWithConfig(parentCtx, cache.InterpreterConfig)
 	// cache.CompiledRuntime = wazero.NewRuntimeWithConfig(parentCtx, cache.CompiledConfig)
-	// cache.CloseFn = func() {
-	// 	// run in inverse order
-	// 	cache.InterpreterRuntime.Close(parentCtx)
-	// 	cache.CompiledRuntime.Close(parentCtx)
-	// 	cache.CompilationCache.Close(parentCtx)
-	// }
-	return cache
+	return cache, nil
 }
 
-// TODO clean cache!
 func NewWazeroVm(cache *WasmEngineCache, ctx sdk.Context, aot bool) memc.IVm {
 	var cleanups []func()
 	var config wazero.RuntimeConfig
@@ -232,17 +286,27 @@ func NewWazeroVm(cache *WasmEngineCache, ctx sdk.Context, aot bool) memc.IVm {
 		WithCloseOnContextDone(true).                // for now, we let the execution finish in case we need to save block data in our core contracts
 		WithCompilationCache(cache.CompilationCache) // .WithDebugInfoEnabled(true)
 
+	memoryLimitPages := memc.GetMemoryLimitPages(ctx)
+	if memoryLimitPages > memc.WASM_MAX_PAGES {
+		memoryLimitPages = memc.WASM_MAX_PAGES
+	}
+	if memoryLimitPages > 0 {
+		config = config.WithMemoryLimitPages(memoryLimitPages)
+	}
+
 	r := wazero.NewRuntimeWithConfig(ctx, config)
 	cleanups = append(cleanups, func() {
 		r.Close(ctx)
 	})
 
 	return &WazeroVm{
-		ctx:      ctx,
-		cache:    cache,
-		r:        r,
-		cleanups: cleanups,
-		aot:      aot,
+		ctx:              ctx,
+		cache:            cache,
+		r:                r,
+		cleanups:         cleanups,
+		aot:              aot,
+		memoryLimitPages: memoryLimitPages,
+		gasSchedule:      memc.GetGasSchedule(ctx),
 	}
 }
 
@@ -267,24 +331,51 @@ func (wm *WazeroVm) Cleanup() {
 	}
 }
 
-func (wm *WazeroVm) Call(funcname string, args []interface{}, gasMeter memc.GasMeter) ([]int32, error) {
+func (wm *WazeroVm) Call(funcname string, args []interface{}, gasMeter memc.GasMeter) ([]interface{}, error) {
 	if wm.vm == nil {
 		panic("WazeroVm not instantiated")
 	}
-	_args := make([]uint64, len(args))
-	for i, arg := range args {
-		_args[i] = uint64(arg.(int32))
-	}
 	fn := wm.vm.ExportedFunction(funcname)
 	if fn == nil {
 		return nil, fmt.Errorf("WazeroVm: exported function not found: %s", funcname)
 	}
+	def := fn.Definition()
+	_args, err := encodeArgs(args, def.ParamTypes())
+	if err != nil {
+		return nil, fmt.Errorf("WazeroVm: %s: %s", funcname, err)
+	}
+	gasGlobal := wm.gasGlobal()
+	var gasStart, gasOuter int64
+	if gasGlobal != nil {
+		gasOuter = int64(gasGlobal.Get())
+		gasStart = gasOuter
+		if gasMeter != nil {
+			gasStart = memc.GasGlobalStart(gasMeter)
+		} else if wm.callDepth == 0 {
+			gasStart = math.MaxInt64
+		}
+		gasGlobal.Set(uint64(gasStart))
+	}
 	var wrappedMeter *GasMeter
 	if gasMeter != nil {
 		wrappedMeter = NewGasMeter(gasMeter.GasRemaining(), uint64(0), gasMeter)
-		fn = fn.WithGasMeter(wrappedMeter)
+		// modules with injected gas metering charge their instructions themselves
+		if gasGlobal == nil {
+			fn = fn.WithGasMeter(wrappedMeter)
+		}
 	}
+	outerMeter := wm.gasMeter
+	wm.gasMeter = wrappedMeter
+	wm.callDepth++
 	result, err := fn.Call(wm.ctx, _args...)
+	wm.callDepth--
+	wm.gasMeter = outerMeter
+	if gasMeter != nil && gasGlobal != nil {
+		used := memc.GasGlobalUsed(gasStart, int64(gasGlobal.Get()))
+		// a nested call with a gas meter is not charged to the outer call
+		gasGlobal.Set(uint64(gasOuter))
+		wrappedMeter.ConsumeGas(used, "wasm execution: "+funcname)
+	}
 	if gasMeter != nil {
 		consumed := wrappedMeter.GasConsumed()
 		if consumed > 0 {
@@ -297,13 +388,23 @@ func (wm *WazeroVm) Call(funcname string, args []interface{}, gasMeter memc.GasM
 			return nil, err
 		}
 	}
-	_result := make([]int32, len(result))
+	resultTypes := def.ResultTypes()
+	_result := make([]interface{}, len(result))
 	for i, res := range result {
-		_result[i] = int32(res)
+		_result[i] = ValueFromUint64(res, resultTypes[i])
 	}
 	return _result, nil
 }
 
+// gasGlobal returns the gas global of modules with injected gas metering, or nil
+func (wm *WazeroVm) gasGlobal() api.MutableGlobal {
+	global, ok := wm.vm.ExportedGlobal(memc.GasGlobalExport).(api.MutableGlobal)
+	if !ok {
+		return nil
+	}
+	return global
+}
+
 func (wm *WazeroVm) GetMemory() (memc.IMemory, error) {
 	if wm.vm == nil {
 		panic("WazeroVm not instantiated")
@@ -312,7 +413,7 @@ func (wm *WazeroVm) GetMemory() (memc.IMemory, error) {
 	if mem == nil {
 		return nil, fmt.Errorf("could not find memory")
 	}
-	return WazeroMemory{mem}, nil
+	return memc.NewCountingMemory(WazeroMemory{mem}, &wm.hostBytes), nil
 }
 
 func (wm *WazeroVm) GetFunctionList() []string {
@@ -381,7 +482,6 @@ func (wm *WazeroVm) WasiFileMap() map[string][]byte {
 }
 
 func (wm *WazeroVm) InstantiateWasm(wasmFilePath string, aotFilePath string, wasmbuffer []byte) error {
-	var err error
 	cfg := wm.cfg
 	if cfg == nil {
 		cfg = wazero.NewModuleConfig()
@@ -389,45 +489,102 @@ func (wm *WazeroVm) InstantiateWasm(wasmFilePath string, aotFilePath string, was
 	// make sure wazero does not call any functions automatically
 	cfg = cfg.WithStartFunctions([]string{}...)
 
+	if !wm.cache.Modules.Enabled() {
+		return wm.instantiateUncached(wasmFilePath, aotFilePath, wasmbuffer, cfg)
+	}
+	compiledmod, release, err := wm.getCompiledModule(wasmFilePath, aotFilePath, wasmbuffer)
+	if err != nil {
+		return err
+	}
+	vm, err := wm.r.InstantiateModule(wm.ctx, compiledmod, cfg)
+	release()
+	if err != nil {
+		return sdkerrors.Wrapf(err, "load wasm file failed from buffer")
+	}
+	wm.vm = vm
+	return nil
+}
+
+// getCompiledModule returns the module from the cache or compiles it and caches it.
+// release must be called after the module is instantiated.
+func (wm *WazeroVm) getCompiledModule(wasmFilePath string, aotFilePath string, wasmbuffer []byte) (mod wazero.CompiledModule, release func(), err error) {
+	checksum, wasmbuffer, err := wm.cache.GetChecksum(wasmFilePath, wasmbuffer)
+	if err != nil {
+		return nil, nil, err
+	}
 	compilerSupported := wazero.CompilerSupported()
+	mode := moduleModeInterpreter
+	if wm.aot && compilerSupported {
+		mode = moduleModeCompiler
+	}
+	key := ModuleCacheKey{Checksum: checksum, Mode: mode, MemoryLimitPages: wm.memoryLimitPages}
+	mod, release = wm.cache.Modules.Get(key)
+	if mod != nil {
+		return mod, release, nil
+	}
+
+	if wasmbuffer == nil {
+		wasmbuffer, err = os.ReadFile(wasmFilePath)
+		if err != nil {
+			wm.cache.Modules.Abort(key)
+			return nil, nil, sdkerrors.Wrapf(err, "load wasm file failed %s", wasmFilePath)
+		}
+	}
 	if compilerSupported && aotFilePath != "" {
-		// we cache compiled modules
-		compiledmod := wm.cache.GetCompiledModule(wasmFilePath)
-		if compiledmod == nil {
-			content, err := os.Open(aotFilePath)
-			if err != nil {
-				return sdkerrors.Wrapf(err, "load original wasm file failed %s", aotFilePath)
-			}
-			origwasmbuffer, err := os.ReadFile(wasmFilePath)
-			if err != nil {
-				return sdkerrors.Wrapf(err, "load wasm file failed %s", wasmFilePath)
-			}
-			// we use the parent context here!
-			compiledmod, err = wm.r.DeserializeCompiledModule(wm.cache.ctx, origwasmbuffer, content)
-			if err != nil {
-				return sdkerrors.Wrapf(err, "module deserialization failed from buffer")
-			}
-			wm.cache.SetCompiledModule(wasmFilePath, compiledmod)
-			wm.cache.AddCleanup(func() { compiledmod.Close(wm.cache.ctx) })
+		mod, err = wm.deserializeAot(aotFilePath, wasmbuffer)
+	} else {
+		mod, err = wm.r.CompileModule(wm.ctx, wasmbuffer)
+		if err != nil {
+			err = sdkerrors.Wrapf(err, "compile wasm module failed")
 		}
-		vm, err := wm.r.InstantiateModule(wm.ctx, compiledmod, cfg)
+	}
+	if err != nil {
+		wm.cache.Modules.Abort(key)
+		return nil, nil, err
+	}
+	return mod, wm.cache.Modules.Add(key, mod), nil
+}
+
+func (wm *WazeroVm) deserializeAot(aotFilePath string, wasmbuffer []byte) (wazero.CompiledModule, error) {
+	content, err := os.Open(aotFilePath)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(err, "load original wasm file failed %s", aotFilePath)
+	}
+	// we use the parent context here!
+	compiledmod, err := wm.r.DeserializeCompiledModule(wm.cache.ctx, wasmbuffer, content)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(err, "module deserialization failed from buffer")
+	}
+	return compiledmod, nil
+}
+
+// instantiateUncached compiles the module for each instance, when the module cache is disabled
+func (wm *WazeroVm) instantiateUncached(wasmFilePath string, aotFilePath string, wasmbuffer []byte, cfg wazero.ModuleConfig) error {
+	var err error
+	if wasmbuffer == nil {
+		wasmbuffer, err = os.ReadFile(wasmFilePath)
 		if err != nil {
-			return sdkerrors.Wrapf(err, "load wasm file failed from buffer")
+			return sdkerrors.Wrapf(err, "load wasm file failed %s", wasmFilePath)
 		}
-		wm.vm = vm
-	} else {
-		if wasmbuffer == nil {
-			wasmbuffer, err = os.ReadFile(wasmFilePath)
-			if err != nil {
-				return sdkerrors.Wrapf(err, "load wasm file failed %s", wasmFilePath)
-			}
+	}
+	if wazero.CompilerSupported() && aotFilePath != "" {
+		compiledmod, err := wm.deserializeAot(aotFilePath, wasmbuffer)
+		if err != nil {
+			return err
 		}
-		vm, err := wm.r.InstantiateWithConfig(wm.ctx, wasmbuffer, cfg)
+		wm.cleanups = append(wm.cleanups, func() { compiledmod.Close(wm.cache.ctx) })
+		vm, err := wm.r.InstantiateModule(wm.ctx, compiledmod, cfg)
 		if err != nil {
 			return sdkerrors.Wrapf(err, "load wasm file failed from buffer")
 		}
 		wm.vm = vm
+		return nil
 	}
+	vm, err := wm.r.InstantiateWithConfig(wm.ctx, wasmbuffer, cfg)
+	if err != nil {
+		return sdkerrors.Wrapf(err, "load wasm file failed from buffer")
+	}
+	wm.vm = vm
 	return nil
 }
 
@@ -456,6 +613,10 @@ func (wm *WazeroVm) ValType_I64() interface{} {
 	return api.ValueTypeI64
 }
 
+func (wm *WazeroVm) ValType_F32() interface{} {
+	return api.ValueTypeF32
+}
+
 func (wm *WazeroVm) ValType_F64() interface{} {
 	return api.ValueTypeF64
 }
@@ -501,8 +662,9 @@ func (wm *WazeroVm) BuildModule(rnh memc.RuntimeHandler, modname string, context
 
 func (wm *WazeroVm) BuildModuleInner(rnh memc.RuntimeHandler, modname string, _context interface{}, fndefs []WazeroFn) wazero.HostModuleBuilder {
 	envmod := wm.r.NewHostModuleBuilder(modname)
-	// TODO cost for each function
 	for _, fndef := range fndefs {
+		// the build cost is not charged, all runtimes charge the schedule cost
+		fndef.gas = wm.gasSchedule.Cost(modname, fndef.name)
 		envmod = envmod.NewFunctionBuilder().WithGoModuleFunction(
 			api.GoModuleFunc(fndef.WrappedFn(rnh, _context, fndef.inputTypes, fndef.outputTypes)),
 			fndef.inputTypes,
@@ -521,8 +683,12 @@ func (WazeroVmMeta) LibVersion() string {
 	return wazero.Version()
 }
 
-func (m *WazeroVmMeta) InitWasmRuntime(parentCtx context.Context) {
-	m.runtime = NewWazeroRuntime(parentCtx)
+func (m *WazeroVmMeta) InitWasmRuntime(parentCtx context.Context, cfg memc.WasmRuntimeConfig) {
+	runtime, err := NewWazeroRuntimeWithConfig(parentCtx, cfg)
+	if err != nil {
+		panic(err)
+	}
+	m.runtime = runtime
 	// TODO do we need to close runtime resources? (compiled modules etc.?)
 	// they should be closed when the parent context stops anyway
 	// but we have m.runtime.Close()
@@ -569,9 +735,11 @@ func (WazeroVmMeta) AnalyzeWasm(ctx sdk.Context, wasmbuffer []byte) (memc.WasmMe
 			name = mimport.Name()
 		}
 		meta.imports[i] = WazeroImport{
-			moduleName: moduleName,
-			name:       name,
-			fn:         mimport.GoFunction(),
+			moduleName:  moduleName,
+			name:        name,
+			inputTypes:  mimport.ParamTypes(),
+			outputTypes: mimport.ResultTypes(),
+			fn:          mimport.GoFunction(),
 		}
 	}
 	i := 0
@@ -636,6 +804,14 @@ func FromValTypeSlice(input []api.ValueType) []interface{} {
 	return result
 }
 
+func valueTypeNames(input []api.ValueType) []string {
+	result := make([]string, len(input))
+	for i, v := range input {
+		result[i] = api.ValueTypeName(v)
+	}
+	return result
+}
+
 // Helper function to split "KEY=VALUE" into [KEY, VALUE]
 func splitEnv(env string) []string {
 	for i, c := range env {
@@ -661,6 +837,34 @@ func ValueFromUint64(val uint64, t api.ValueType) interface{} {
 	}
 }
 
+// encodeArgs checks that the arguments have the Go types of the wasm params and encodes them
+func encodeArgs(args []interface{}, paramTypes []api.ValueType) ([]uint64, error) {
+	if len(args) != len(paramTypes) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(paramTypes), len(args))
+	}
+	encoded := make([]uint64, len(args))
+	for i, arg := range args {
+		var ok bool
+		switch paramTypes[i] {
+		case api.ValueTypeI32:
+			_, ok = arg.(int32)
+		case api.ValueTypeI64:
+			_, ok = arg.(int64)
+		case api.ValueTypeF32:
+			_, ok = arg.(float32)
+		case api.ValueTypeF64:
+			_, ok = arg.(float64)
+		default:
+			_, ok = arg.(uint64)
+		}
+		if !ok {
+			return nil, fmt.Errorf("argument %d: expected %s, got %T", i, api.ValueTypeName(paramTypes[i]), arg)
+		}
+		encoded[i] = ValueToUint64(arg, paramTypes[i])
+	}
+	return encoded, nil
+}
+
 func ValueToUint64(val interface{}, t api.ValueType) uint64 {
 	switch t {
 	case api.ValueTypeI32:
@@ -11,6 +11,7 @@ import (
 
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/spf13/cast"
 	"golang.org/x/sync/errgroup"
@@ -40,6 +41,7 @@ import (
 
 	"cosmossdk.io/x/upgrade"
 
+	cmtdbm "github.com/cometbft/cometbft-db"
 	dbm "github.com/cosmos/cosmos-db"
 	// "github.com/cosmos/gogoproto/proto"
 
@@ -59,6 +61,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/server/config"
 
 	"github.com/cosmos/cosmos-sdk/baseapp/oe"
+	sdkserver "github.com/cosmos/cosmos-sdk/server"
+	servercmtlog "github.com/cosmos/cosmos-sdk/server/log"
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
 	"github.com/cosmos/cosmos-sdk/std"
 
@@ -161,6 +165,9 @@ import (
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	cmtcfg "github.com/cometbft/cometbft/config"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/state/indexer"
+	cmttypes "github.com/cometbft/cometbft/types"
 
 	ante "github.com/loredanacirstea/wasmx/app/ante"
 
@@ -205,6 +212,7 @@ import (
 	mctx "github.com/loredanacirstea/wasmx/context"
 
 	srvconfig "github.com/loredanacirstea/wasmx/server/config"
+	jsonrpcconfig "github.com/loredanacirstea/wasmx/x/wasmx/server/config"
 
 	"github.com/loredanacirstea/wasmx/x/network/vmcrosschain"
 	"github.com/loredanacirstea/wasmx/x/network/vmmc"
@@ -347,7 +355,15 @@ type App struct {
 	ports        mctx.NodePorts
 	initialPorts mctx.NodePorts
 
-	db dbm.DB
+	db                 dbm.DB
+	blockStoreMtx      sync.Mutex
+	blockStore         *vmp2p.BlockStore
+	blockStoreOpener   func() (*vmp2p.BlockStore, error)
+	blockStoreSyncer   *networkmodulekeeper.BlockStoreSyncer
+	blockIndexer       *vmp2p.BlockIndexer
+	blockIndexerOpener func() (*vmp2p.BlockIndexer, error)
+	eventBus           *cmttypes.EventBus
+	eventPublisher     *networkmodulekeeper.BlockEventPublisher
 
 	OrderBeginSubCall []string
 	OrderEndSubCall   []string
@@ -533,6 +549,7 @@ func NewApp(
 	// add keepers
 
 	wasmconfig := wasmxmoduletypes.DefaultWasmConfig()
+	wasmconfig.SmartQueryTimeout = jsonrpcconfig.GetWasmVmConfig(appOpts).QueryTimeout
 	app.WasmxKeeper = *wasmxmodulekeeper.NewKeeper(
 		app.goRoutineGroup,
 		app.goContextParent,
@@ -563,6 +580,30 @@ func NewApp(
 		wasmVmMeta,
 	)
 
+	// finalized blocks are kept outside of the application state
+	// the block store is opened on first use, so short-lived apps (e.g. cli) do not lock it
+	app.blockStoreOpener = func() (*vmp2p.BlockStore, error) {
+		if _, ok := db.(*dbm.MemDB); ok {
+			return vmp2p.NewBlockStore(dbm.NewMemDB()), nil
+		}
+		return vmp2p.OpenBlockStore(homePath, chainId, sdkserver.GetAppDBBackend(appOpts))
+	}
+	// finalized block events are indexed for block search
+	app.blockIndexerOpener = func() (*vmp2p.BlockIndexer, error) {
+		if _, ok := db.(*dbm.MemDB); ok {
+			return vmp2p.NewBlockIndexer(cmtdbm.NewMemDB()), nil
+		}
+		return vmp2p.OpenBlockIndexer(homePath, chainId, sdkserver.GetAppDBBackend(appOpts))
+	}
+
+	// finalized blocks and transactions are published for websocket subscriptions
+	app.eventBus = cmttypes.NewEventBus()
+	app.eventBus.SetLogger(servercmtlog.CometLoggerWrapper{Logger: logger.With(log.ModuleKey, "events")})
+	if err := app.eventBus.Start(); err != nil {
+		panic(err)
+	}
+	app.eventPublisher = networkmodulekeeper.NewBlockEventPublisher(app, app.eventBus, logger)
+
 	app.NetworkKeeper = *networkmodulekeeper.NewKeeper(
 		app.goRoutineGroup,
 		app.goContextParent,
@@ -1585,6 +1626,78 @@ func (app *App) MinGasPrices() sdk.DecCoins {
 	return app.minGasPrices
 }
 
+func (app *App) GetBlockStore() sm.BlockStore {
+	store, err := app.openBlockStore()
+	if err != nil {
+		app.Logger().Error("cannot open block store", "error", err.Error())
+		return nil
+	}
+	return store
+}
+
+func (app *App) GetBlockIndexer() indexer.BlockIndexer {
+	_, err := app.openBlockStore()
+	if err != nil {
+		app.Logger().Error("cannot open block indexer", "error", err.Error())
+		return nil
+	}
+	return app.blockIndexer
+}
+
+func (app *App) GetEventBus() *cmttypes.EventBus {
+	return app.eventBus
+}
+
+func (app *App) openBlockStore() (*vmp2p.BlockStore, error) {
+	app.blockStoreMtx.Lock()
+	defer app.blockStoreMtx.Unlock()
+	if app.blockStore != nil {
+		return app.blockStore, nil
+	}
+	blockIndexer, err := app.blockIndexerOpener()
+	if err != nil {
+		return nil, err
+	}
+	store, err := app.blockStoreOpener()
+	if err != nil {
+		blockIndexer.Close()
+		return nil, err
+	}
+	app.blockStore = store
+	app.blockIndexer = blockIndexer
+	app.blockStoreSyncer = networkmodulekeeper.NewBlockStoreSyncer(app, store, blockIndexer, app.Logger())
+	return store, nil
+}
+
+// Commit commits the application state, saves the finalized block
+// in the block store and publishes its events, once the consensus contract execution is committed
+func (app *App) Commit() (*abci.ResponseCommit, error) {
+	resp, err := app.BaseApp.Commit()
+	if err != nil {
+		return resp, err
+	}
+	app.goRoutineGroup.Go(func() error {
+		select {
+		case <-app.goContextParent.Done():
+			return nil
+		default:
+		}
+		_, err := app.openBlockStore()
+		if err == nil {
+			err = app.blockStoreSyncer.Sync(app.goContextParent)
+		}
+		if err != nil {
+			app.Logger().Error("block store sync failed", "error", err.Error())
+		}
+		err = app.eventPublisher.Publish(app.goContextParent)
+		if err != nil {
+			app.Logger().Error("block events publish failed", "error", err.Error())
+		}
+		return nil
+	})
+	return resp, nil
+}
+
 // only for debugging
 func (app *App) Db() dbm.DB {
 	return app.db
@@ -1606,6 +1719,19 @@ func (app *App) DebugDb() {
 
 func (app *App) Teardown() {
 	app.BaseApp.Close()
+	app.blockStoreMtx.Lock()
+	if app.blockStore != nil {
+		app.blockStore.Close()
+		app.blockStore = nil
+	}
+	if app.blockIndexer != nil {
+		app.blockIndexer.Close()
+		app.blockIndexer = nil
+	}
+	app.blockStoreMtx.Unlock()
+	if app.eventBus.IsRunning() {
+		app.eventBus.Stop()
+	}
 	app.Db().Close()
 	app.SnapshotManager().Close()
 }
@@ -23,9 +23,13 @@ import (
 	multichain "github.com/loredanacirstea/wasmx/multichain"
 	networktypes "github.com/loredanacirstea/wasmx/x/network/types"
 	"github.com/loredanacirstea/wasmx/x/network/vmp2p"
+	"github.com/loredanacirstea/wasmx/x/vmhttpclient"
+	httpclientconfig "github.com/loredanacirstea/wasmx/x/vmhttpclient/server/config"
 	"github.com/loredanacirstea/wasmx/x/vmhttpserver"
 	"github.com/loredanacirstea/wasmx/x/vmkv"
 	"github.com/loredanacirstea/wasmx/x/vmsql"
+	sqlconfig "github.com/loredanacirstea/wasmx/x/vmsql/server/config"
+	jsonrpcconfig "github.com/loredanacirstea/wasmx/x/wasmx/server/config"
 	wasmxtypes "github.com/loredanacirstea/wasmx/x/wasmx/types"
 	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
 
@@ -51,12 +55,30 @@ func NewAppCreator(
 	ctx, _ = mctx.WithExecutionMetaInfoEmpty(ctx)
 	ctx, _ = mctx.WithTimeoutGoroutinesInfoEmpty(ctx)
 	ctx, _ = wasmxtypes.WithSystemBootstrap(ctx)
-	ctx = vmsql.WithSqlEmptyContext(ctx)
+	homeDir := cast.ToString(appOpts.Get(flags.FlagHome))
+	sqlPolicy, err := sqlconfig.GetSqlConfig(appOpts).Policy(homeDir)
+	if err != nil {
+		panic(fmt.Sprintf("invalid sql config: %v", err))
+	}
+	ctx = vmsql.WithSqlEmptyContext(ctx, sqlPolicy)
+	httpClientConfig, err := httpclientconfig.GetHttpClientConfig(appOpts)
+	if err != nil {
+		panic(fmt.Sprintf("invalid httpclient config: %v", err))
+	}
+	httpClientCtx, err := httpClientConfig.HttpClientContext(homeDir)
+	if err != nil {
+		panic(fmt.Sprintf("invalid httpclient config: %v", err))
+	}
+	ctx = vmhttpclient.WithHttpClientContext(ctx, httpClientCtx)
 	ctx = vmkv.WithKvDbEmptyContext(ctx)
 	ctx = vmimap.WithImapEmptyContext(ctx)
 	ctx = vmsmtp.WithSmtpEmptyContext(ctx)
 	ctx = vmhttpserver.WithHttpServerEmptyContext(ctx)
-	wasmVmMeta.InitWasmRuntime(ctx)
+	wasmVmConfig := jsonrpcconfig.GetWasmVmConfig(appOpts)
+	if err := wasmVmConfig.Validate(); err != nil {
+		panic(fmt.Sprintf("invalid wasm-vm config: %v", err))
+	}
+	wasmVmMeta.InitWasmRuntime(ctx, wasmVmConfig.RuntimeConfig(homeDir))
 	appOpts.Set("goroutineGroup", g)
 	appOpts.Set("goContextParent", ctx)
 
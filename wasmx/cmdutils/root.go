@@ -68,6 +68,7 @@ import (
 	cosmosmodtypes "github.com/loredanacirstea/wasmx/x/cosmosmod/types"
 	networktypes "github.com/loredanacirstea/wasmx/x/network/types"
 	"github.com/loredanacirstea/wasmx/x/network/vmp2p"
+	"github.com/loredanacirstea/wasmx/x/vmhttpclient"
 	"github.com/loredanacirstea/wasmx/x/vmhttpserver"
 	"github.com/loredanacirstea/wasmx/x/vmkv"
 	"github.com/loredanacirstea/wasmx/x/vmsql"
@@ -105,12 +106,13 @@ func NewRootCmd(wasmVmMeta memc.IWasmVmMeta, defaultNodeHome string, initializeD
 	goctx, _ = mctx.WithExecutionMetaInfoEmpty(goctx)
 	goctx, _ = mctx.WithTimeoutGoroutinesInfoEmpty(goctx)
 	goctx, _ = wasmxtypes.WithSystemBootstrap(goctx)
-	goctx = vmsql.WithSqlEmptyContext(goctx)
+	goctx = vmsql.WithSqlEmptyContext(goctx, nil)
+	goctx = vmhttpclient.WithHttpClientEmptyContext(goctx)
 	goctx = vmkv.WithKvDbEmptyContext(goctx)
 	goctx = vmimap.WithImapEmptyContext(goctx)
 	goctx = vmsmtp.WithSmtpEmptyContext(goctx)
 	goctx = vmhttpserver.WithHttpServerEmptyContext(goctx)
-	wasmVmMeta.InitWasmRuntime(goctx)
+	wasmVmMeta.InitWasmRuntime(goctx, memc.WasmRuntimeConfig{})
 	appOpts.Set("goroutineGroup", g)
 	appOpts.Set("goContextParent", goctx)
 	appOpts.Set(flags.FlagHome, tempDir(defaultNodeHome))
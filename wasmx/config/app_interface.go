@@ -28,6 +28,9 @@ import (
 	abci "github.com/cometbft/cometbft/abci/types"
 	cmtcfg "github.com/cometbft/cometbft/config"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/state/indexer"
+	cmttypes "github.com/cometbft/cometbft/types"
 
 	mctx "github.com/loredanacirstea/wasmx/context"
 	srvconfig "github.com/loredanacirstea/wasmx/server/config"
@@ -57,6 +60,8 @@ type NetworkKeeper interface {
 	Codec() codec.Codec
 
 	GetHeaderByHeight(app MythosApp, logger log.Logger, height int64, prove bool) (*cmtproto.Header, error)
+	GetBlockSyncEntries(app MythosApp, logger log.Logger, startHeight int64, endHeight int64) ([]networktypes.BlockSyncEntry, int64, error)
+	RestoreTimeouts(ctx sdk.Context) error
 }
 
 type WasmxKeeper interface {
@@ -74,6 +79,7 @@ type WasmxKeeper interface {
 		deposit sdk.Coins,
 		label string,
 	) (wasmxtypes.ContractResponse, uint64, error)
+	TraceExecution(ctx sdk.Context, config wasmxtypes.TraceConfig, run func(ctx sdk.Context) error) (json.RawMessage, error)
 }
 
 type MythosApp interface {
@@ -124,9 +130,13 @@ type MythosApp interface {
 	GetServerConfig() *srvconfig.Config
 	GetTendermintConfig() *cmtcfg.Config
 	GetRpcClient() client.CometRPC
+	GetBlockStore() sm.BlockStore
+	GetBlockIndexer() indexer.BlockIndexer
+	GetEventBus() *cmttypes.EventBus
 
 	// baseapp
 	Query(context.Context, *abci.RequestQuery) (*abci.ResponseQuery, error)
+	Commit() (*abci.ResponseCommit, error)
 	GRPCQueryRouter() *baseapp.GRPCQueryRouter
 	MsgServiceRouter() *baseapp.MsgServiceRouter
 
@@ -6,3 +6,10 @@ var StateSyncProtocolId = "statesync"
 func GetStateSyncProtocolId(chainId string) string {
 	return StateSyncProtocolId + "_" + chainId
 }
+
+// Note protocol must be different than any other protocol ID and per chain ID
+var BlockSyncProtocolId = "blocksync"
+
+func GetBlockSyncProtocolId(chainId string) string {
+	return BlockSyncProtocolId + "_" + chainId
+}
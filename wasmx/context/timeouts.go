@@ -12,32 +12,70 @@ const TimeoutGoroutinesKey TimeoutGoroutinesContextKey = "TimeoutGoroutines"
 
 type TimeoutGoroutinesInfo struct {
 	GoRoutines map[string]context.CancelFunc
-	mu         sync.Mutex
+	// Generations are the generations of the running goroutines.
+	// A timeout started again with the same key gets a new generation,
+	// so the replaced goroutine does not clean up the new one.
+	Generations map[string]uint64
+	generation  uint64
+	mu          sync.Mutex
 }
 
 func NewTimeoutGoroutinesInfo(data map[string]context.CancelFunc) *TimeoutGoroutinesInfo {
 	return &TimeoutGoroutinesInfo{
-		GoRoutines: data,
+		GoRoutines:  data,
+		Generations: map[string]uint64{},
 	}
 }
 
 func WithTimeoutGoroutinesInfoEmpty(ctx context.Context) (context.Context, *TimeoutGoroutinesInfo) {
-	data := &TimeoutGoroutinesInfo{GoRoutines: map[string]context.CancelFunc{}}
+	data := NewTimeoutGoroutinesInfo(map[string]context.CancelFunc{})
 	return context.WithValue(ctx, TimeoutGoroutinesKey, data), data
 }
 
-func SetTimeoutGoroutine(ctx context.Context, key string, cancelfn context.CancelFunc) error {
+// SetTimeoutGoroutine registers the goroutine of a timeout and returns its generation
+func SetTimeoutGoroutine(ctx context.Context, key string, cancelfn context.CancelFunc) (uint64, error) {
 	datai := ctx.Value(TimeoutGoroutinesKey)
 	data, ok := (datai).(*TimeoutGoroutinesInfo)
 	if !ok {
-		return fmt.Errorf("TimeoutGoroutinesInfo not set on context")
+		return 0, fmt.Errorf("TimeoutGoroutinesInfo not set on context")
 	}
 	if data == nil {
-		return fmt.Errorf("TimeoutGoroutinesInfo not set on context")
+		return 0, fmt.Errorf("TimeoutGoroutinesInfo not set on context")
 	}
 	data.mu.Lock()
+	defer data.mu.Unlock()
+	data.generation++
 	data.GoRoutines[key] = cancelfn
-	data.mu.Unlock()
+	data.Generations[key] = data.generation
+	return data.generation, nil
+}
+
+// IsTimeoutGeneration returns true if the generation is the goroutine registered for the key
+func IsTimeoutGeneration(ctx context.Context, key string, generation uint64) bool {
+	data, ok := ctx.Value(TimeoutGoroutinesKey).(*TimeoutGoroutinesInfo)
+	if !ok || data == nil {
+		return false
+	}
+	data.mu.Lock()
+	defer data.mu.Unlock()
+	gen, found := data.Generations[key]
+	return found && gen == generation
+}
+
+// RemoveTimeoutGoroutineGeneration removes the goroutine registered for the key,
+// unless it was replaced by a newer generation
+func RemoveTimeoutGoroutineGeneration(ctx context.Context, key string, generation uint64) error {
+	data, ok := ctx.Value(TimeoutGoroutinesKey).(*TimeoutGoroutinesInfo)
+	if !ok || data == nil {
+		return fmt.Errorf("TimeoutGoroutinesInfo not set on context")
+	}
+	data.mu.Lock()
+	defer data.mu.Unlock()
+	if data.Generations[key] != generation {
+		return nil
+	}
+	delete(data.GoRoutines, key)
+	delete(data.Generations, key)
 	return nil
 }
 
@@ -70,6 +108,7 @@ func RemoveTimeoutGoroutine(ctx context.Context, key string) error {
 	}
 	data.mu.Lock()
 	delete(data.GoRoutines, key)
+	delete(data.Generations, key)
 	data.mu.Unlock()
 	return nil
 }
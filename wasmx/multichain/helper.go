@@ -89,6 +89,8 @@ func CreateMockAppCreator(wasmVmMeta memc.IWasmVmMeta, appCreatorFactory NewAppC
 	appOpts.Set(sdkserver.FlagUnsafeSkipUpgrades, 0)
 	appOpts.Set(sdkserver.FlagMinGasPrices, "")
 	appOpts.Set(sdkserver.FlagPruning, pruningtypes.PruningOptionDefault)
+	// test contracts create their sqlite databases in the working directory
+	appOpts.Set("sql.sqlite-dirs", []string{})
 	g, goctx, _ := GetTestCtx(logger, true)
 
 	srvCtx := sdkserver.NewDefaultContext()
@@ -117,6 +119,8 @@ func CreateNoLoggerAppCreator(wasmVmMeta memc.IWasmVmMeta, appCreatorFactory New
 	appOpts.Set(sdkserver.FlagUnsafeSkipUpgrades, 0)
 	appOpts.Set(sdkserver.FlagMinGasPrices, "")
 	appOpts.Set(sdkserver.FlagPruning, pruningtypes.PruningOptionDefault)
+	// test contracts create their sqlite databases in the working directory
+	appOpts.Set("sql.sqlite-dirs", []string{})
 	g, goctx, _ := GetTestCtx(logger, true)
 	return appCreatorFactory(wasmVmMeta, logger, db, nil, appOpts, g, goctx, &MockApiCtx{})
 }
@@ -12,6 +12,8 @@ import (
 
 	networkconfig "github.com/loredanacirstea/wasmx/x/network/server/config"
 	networkflags "github.com/loredanacirstea/wasmx/x/network/server/flags"
+	httpclientconfig "github.com/loredanacirstea/wasmx/x/vmhttpclient/server/config"
+	sqlconfig "github.com/loredanacirstea/wasmx/x/vmsql/server/config"
 	jsonrpcconfig "github.com/loredanacirstea/wasmx/x/wasmx/server/config"
 	websrvconfig "github.com/loredanacirstea/wasmx/x/websrv/server/config"
 )
@@ -29,10 +31,13 @@ const (
 // from the SDK as well as the EVM configuration to enable the JSON-RPC APIs.
 type Config struct {
 	config.Config
-	Websrv  websrvconfig.WebsrvConfig   `mapstructure:"websrv"`
-	JsonRpc jsonrpcconfig.JsonRpcConfig `mapstructure:"json-rpc"`
-	TLS     TLSConfig                   `mapstructure:"tls"`
-	Network networkconfig.NetworkConfig `mapstructure:"network"`
+	Websrv     websrvconfig.WebsrvConfig         `mapstructure:"websrv"`
+	JsonRpc    jsonrpcconfig.JsonRpcConfig       `mapstructure:"json-rpc"`
+	WasmVm     jsonrpcconfig.WasmVmConfig        `mapstructure:"wasm-vm"`
+	TLS        TLSConfig                         `mapstructure:"tls"`
+	Network    networkconfig.NetworkConfig       `mapstructure:"network"`
+	Sql        sqlconfig.SqlConfig               `mapstructure:"sql"`
+	HttpClient httpclientconfig.HttpClientConfig `mapstructure:"httpclient"`
 	// should be false; we need this for testing
 	TestingModeDisableStateSync bool `mapstructure:"testing_mode_disable_statesync"`
 }
@@ -53,11 +58,14 @@ func AppConfig() (string, interface{}) {
 	srvCfg := config.DefaultConfig()
 
 	customAppConfig := Config{
-		Config:  *srvCfg,
-		Websrv:  *websrvconfig.DefaultWebsrvConfigConfig(),
-		JsonRpc: *jsonrpcconfig.DefaultJsonRpcConfigConfig(),
-		TLS:     *DefaultTLSConfig(),
-		Network: *networkconfig.DefaultNetworkConfigConfig(),
+		Config:     *srvCfg,
+		Websrv:     *websrvconfig.DefaultWebsrvConfigConfig(),
+		JsonRpc:    *jsonrpcconfig.DefaultJsonRpcConfigConfig(),
+		WasmVm:     *jsonrpcconfig.DefaultWasmVmConfig(),
+		TLS:        *DefaultTLSConfig(),
+		Network:    *networkconfig.DefaultNetworkConfigConfig(),
+		Sql:        *sqlconfig.DefaultSqlConfig(),
+		HttpClient: *httpclientconfig.DefaultHttpClientConfig(),
 	}
 
 	// The SDK's default minimum gas price is set to "" (empty value) inside
@@ -82,11 +90,14 @@ func AppConfig() (string, interface{}) {
 // DefaultConfig returns server's default configuration.
 func DefaultConfig() *Config {
 	return &Config{
-		Config:  *config.DefaultConfig(),
-		Websrv:  *websrvconfig.DefaultWebsrvConfigConfig(),
-		JsonRpc: *jsonrpcconfig.DefaultJsonRpcConfigConfig(),
-		TLS:     *DefaultTLSConfig(),
-		Network: *networkconfig.DefaultNetworkConfigConfig(),
+		Config:     *config.DefaultConfig(),
+		Websrv:     *websrvconfig.DefaultWebsrvConfigConfig(),
+		JsonRpc:    *jsonrpcconfig.DefaultJsonRpcConfigConfig(),
+		WasmVm:     *jsonrpcconfig.DefaultWasmVmConfig(),
+		TLS:        *DefaultTLSConfig(),
+		Network:    *networkconfig.DefaultNetworkConfigConfig(),
+		Sql:        *sqlconfig.DefaultSqlConfig(),
+		HttpClient: *httpclientconfig.DefaultHttpClientConfig(),
 	}
 }
 
@@ -139,6 +150,16 @@ func GetConfig(v *viper.Viper) (Config, error) {
 		HTTPTimeout:        v.GetDuration("json-rpc.http-timeout"),
 		HTTPIdleTimeout:    v.GetDuration("json-rpc.http-idle-timeout"),
 		MaxOpenConnections: v.GetInt("json-rpc.max-open-connections"),
+		FilterCap:          v.GetInt32("json-rpc.filter-cap"),
+		FilterTimeout:      v.GetDuration("json-rpc.filter-timeout"),
+		LogsCap:            v.GetInt32("json-rpc.logs-cap"),
+		BlockRangeCap:      v.GetInt32("json-rpc.block-range-cap"),
+		FeeHistoryCap:      v.GetInt32("json-rpc.fee-history-cap"),
+	}
+	wasmVmConf := jsonrpcconfig.WasmVmConfig{
+		ModuleCacheSize:     v.GetInt("wasm-vm.module-cache-size"),
+		CompilationCacheDir: v.GetString("wasm-vm.compilation-cache-dir"),
+		QueryTimeout:        v.GetDuration("wasm-vm.query-timeout"),
 	}
 	networkConf := networkconfig.NetworkConfig{
 		Enable:             v.GetBool("network.enable"),
@@ -148,13 +169,26 @@ func GetConfig(v *viper.Viper) (Config, error) {
 		Ips:                v.GetString("network.ips"),
 		Id:                 v.GetString("network.id"),
 		InitialChains:      v.GetStringSlice(networkflags.NetworkInitialChains),
+		BlockSync:          v.GetBool(networkflags.NetworkBlockSync),
+	}
+	sqlConf := sqlconfig.SqlConfig{
+		AllowedDrivers:     v.GetStringSlice("sql.allowed-drivers"),
+		AllowedConnections: v.GetStringSlice("sql.allowed-connections"),
+		SqliteDirs:         v.GetStringSlice("sql.sqlite-dirs"),
+	}
+	httpClientConf, err := httpclientconfig.GetHttpClientConfig(v)
+	if err != nil {
+		return Config{}, err
 	}
 
 	return Config{
 		Config:                      cfg,
 		Websrv:                      websrvConf,
 		JsonRpc:                     jsonRpcConf,
+		WasmVm:                      wasmVmConf,
 		Network:                     networkConf,
+		Sql:                         sqlConf,
+		HttpClient:                  httpClientConf,
 		TestingModeDisableStateSync: false,
 	}, nil
 }
@@ -178,9 +212,21 @@ func (c Config) ValidateBasic() error {
 		return sdkerrors.Wrapf(errortypes.ErrAppConfig, "invalid json-rpc config value: %s", err.Error())
 	}
 
+	if err := c.WasmVm.Validate(); err != nil {
+		return sdkerrors.Wrapf(errortypes.ErrAppConfig, "invalid wasm-vm config value: %s", err.Error())
+	}
+
 	if err := c.Network.Validate(); err != nil {
 		return sdkerrors.Wrapf(errortypes.ErrAppConfig, "invalid network config value: %s", err.Error())
 	}
 
+	if err := c.Sql.Validate(); err != nil {
+		return sdkerrors.Wrapf(errortypes.ErrAppConfig, "invalid sql config value: %s", err.Error())
+	}
+
+	if err := c.HttpClient.Validate(); err != nil {
+		return sdkerrors.Wrapf(errortypes.ErrAppConfig, "invalid httpclient config value: %s", err.Error())
+	}
+
 	return c.Config.ValidateBasic()
 }
@@ -2,8 +2,10 @@ package config
 
 import (
 	networkconfig "github.com/loredanacirstea/wasmx/x/network/server/config"
+	httpclientconfig "github.com/loredanacirstea/wasmx/x/vmhttpclient/server/config"
+	sqlconfig "github.com/loredanacirstea/wasmx/x/vmsql/server/config"
 	jsonrpcconfig "github.com/loredanacirstea/wasmx/x/wasmx/server/config"
 	websrvconfig "github.com/loredanacirstea/wasmx/x/websrv/server/config"
 )
 
-const DefaultConfigTemplate = websrvconfig.DefaultConfigTemplate + jsonrpcconfig.DefaultConfigTemplate + networkconfig.DefaultConfigTemplate
+const DefaultConfigTemplate = websrvconfig.DefaultConfigTemplate + jsonrpcconfig.DefaultConfigTemplate + jsonrpcconfig.DefaultWasmVmConfigTemplate + networkconfig.DefaultConfigTemplate + sqlconfig.DefaultConfigTemplate + httpclientconfig.DefaultConfigTemplate
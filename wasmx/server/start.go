@@ -194,11 +194,17 @@ is performed. Note, when enabled, gRPC will also be automatically enabled.
 	cmd.Flags().Duration(jsonrpcflags.JsonRpcHTTPIdleTimeout, jsonrpcconfig.DefaultHTTPIdleTimeout, "Sets a idle timeout for json-rpc http server (0=infinite)")
 	cmd.Flags().Bool(jsonrpcflags.JsonRpcAllowUnprotectedTxs, jsonrpcconfig.DefaultAllowUnprotectedTxs, "Allow for unprotected (non EIP155 signed) transactions to be submitted via the node's RPC when the global parameter is disabled")
 	cmd.Flags().Int(jsonrpcflags.JsonRpcMaxOpenConnections, jsonrpcconfig.DefaultMaxOpenConnections, "Sets the maximum number of simultaneous connections for the server listener")
+	cmd.Flags().Int32(jsonrpcflags.JsonRpcFilterCap, jsonrpcconfig.DefaultFilterCap, "Sets the global cap for total number of filters that can be created")
+	cmd.Flags().Duration(jsonrpcflags.JsonRpcFilterTimeout, jsonrpcconfig.DefaultFilterTimeout, "Sets the time after which a filter that was not polled is uninstalled")
+	cmd.Flags().Int32(jsonrpcflags.JsonRpcLogsCap, jsonrpcconfig.DefaultLogsCap, "Sets the max number of results can be returned from single 'eth_getLogs' query")
+	cmd.Flags().Int32(jsonrpcflags.JsonRpcBlockRangeCap, jsonrpcconfig.DefaultBlockRangeCap, "Sets the max block range allowed for 'eth_getLogs' query")
+	cmd.Flags().Int32(jsonrpcflags.JsonRpcFeeHistoryCap, jsonrpcconfig.DefaultFeeHistoryCap, "Sets the max number of blocks that can be queried by 'eth_feeHistory'")
 
 	cmd.Flags().Bool(networkflags.NetworkEnable, true, "Define if the network grpc server should be enabled")
 	cmd.Flags().Bool(networkflags.NetworkLeader, false, "Set node as leader. Temporary.")
 	cmd.Flags().String(networkflags.NetworkIps, "localhost:8090", "Set node ips. Temporary.")
 	cmd.Flags().String(networkflags.NetworkNodeId, "0", "This node's index in the array of validators")
+	cmd.Flags().Bool(networkflags.NetworkBlockSync, networkconfig.DefaultBlockSync, "Replay the blocks finalized by peers before starting the node")
 	cmd.Flags().String(networkflags.NetworkAddress, networkconfig.DefaultNetworkAddress, "the network grpc server address to listen on")
 	cmd.Flags().Int(networkflags.NetworkMaxOpenConnections, networkconfig.DefaultMaxOpenConnections, "Sets the maximum number of simultaneous connections for the server listener") //nolint:lll
 
@@ -539,7 +545,7 @@ func StartInProcess(wasmVmMeta memc.IWasmVmMeta, svrCtx *server.Context, clientC
 		app := servertypes.Application(mythosapp)
 		bapp := mythosapp.GetBaseApp()
 		privValidator := pvm.LoadOrGenFilePV(ctndcfg.PrivValidatorKeyFile(), ctndcfg.PrivValidatorStateFile())
-		genesisDocProvider := getGenDocProvider2(ctndcfg)
+		genesisDocProvider := networkgrpc.GenesisDocProviderFromConfig(ctndcfg)
 
 		mythosapp.SetServerConfig(cmsrvconfig)
 		mythosapp.SetTendermintConfig(ctndcfg)
@@ -553,6 +559,8 @@ func StartInProcess(wasmVmMeta memc.IWasmVmMeta, svrCtx *server.Context, clientC
 			if err != nil {
 				return err
 			}
+		} else if cmsrvconfig.Network.BlockSync {
+			startBlockSync(mythosapp.GetGoContextParent(), csvrCtx, cmsrvconfig, chainId, mythosapp)
 		}
 
 		if !strings.Contains(chainId, "level0") && !msrvconfig.TestingModeDisableStateSync {
@@ -694,6 +702,37 @@ func startStateSync(
 	return nil
 }
 
+// startBlockSync replays the blocks finalized while the node was offline, from the first peer that can provide them
+func startBlockSync(
+	goContextParent context.Context,
+	csvrCtx *server.Context,
+	cmsrvconfig *srvconfig.Config,
+	chainId string,
+	app mcfg.MythosApp,
+) {
+	peers := networktypes.GetPeersFromConfigIps(chainId, cmsrvconfig.Network.Ips)
+	index, err := networktypes.GetCurrentNodeIdFromConfig(chainId, cmsrvconfig.Network.Id)
+	if err != nil {
+		csvrCtx.Logger.Error("could not get node id for block sync", "id", cmsrvconfig.Network.Id, "chain_id", chainId, "error", err.Error())
+		return
+	}
+	for i, peer := range peers {
+		if i == index {
+			continue
+		}
+		parts := strings.Split(peer, "@")
+		peeraddress := parts[len(parts)-1]
+		replayed, err := vmp2p.StartBlockSync(goContextParent, csvrCtx.Logger, app, mcfg.GetBlockSyncProtocolId(chainId), peeraddress)
+		if err != nil {
+			csvrCtx.Logger.Error("block sync failed", "chain_id", chainId, "peer", peeraddress, "error", err.Error())
+		}
+		// the local block index is behind after replaying blocks, so we cannot resume with another peer
+		if err == nil || replayed > 0 {
+			return
+		}
+	}
+}
+
 func startStateSyncProvider(
 	goContextParent context.Context,
 	goRoutineGroup *errgroup.Group,
@@ -718,6 +757,11 @@ func startStateSyncProvider(
 		if err != nil {
 			csvrCtx.Logger.Error("InitializeStateSyncProvider", "error", err.Error())
 		}
+		// uses the same p2p node as the state sync provider
+		err = vmp2p.InitializeBlockSyncProvider(goContextParent, csvrCtx.Logger.With("chain_id", chainId), app, mcfg.GetBlockSyncProtocolId(chainId), privateKey, port)
+		if err != nil {
+			csvrCtx.Logger.Error("InitializeBlockSyncProvider", "error", err.Error())
+		}
 	}()
 }
 
@@ -799,22 +843,6 @@ func getGenDocProvider(cfg *cmtcfg.Config) func() (*cmttypes.GenesisDoc, error)
 	}
 }
 
-// returns a function which returns the genesis doc from the genesis file.
-func getGenDocProvider2(cfg *cmtcfg.Config) func(chainId string) (*cmttypes.GenesisDoc, error) {
-	return func(chainId string) (*cmttypes.GenesisDoc, error) {
-		genFile := cfg.GenesisFile()
-		if chainId != "" {
-			genFile = strings.Replace(genFile, ".json", "_"+chainId+".json", 1)
-		}
-		appGenesis, err := genutiltypes.AppGenesisFromFile(genFile)
-		if err != nil {
-			return nil, err
-		}
-
-		return appGenesis.ToGenesisDoc()
-	}
-}
-
 func startNetworkGRPCServer(
 	goCtxParent context.Context,
 	g *errgroup.Group,
@@ -599,6 +599,31 @@ func (s *AppContext) StoreCodeWithMetadata(sender simulation.Account, wasmbin []
 	return codeId
 }
 
+// StoreCodeByAuthority stores code through a governance proposal, so it can depend on protected host APIs
+func (s *AppContext) StoreCodeByAuthority(sender simulation.Account, wasmbin []byte, deps []string) uint64 {
+	valAccount := simulation.Account{
+		PrivKey: s.Chain.SenderPrivKey,
+		PubKey:  s.Chain.SenderPrivKey.PubKey(),
+		Address: s.Chain.SenderAccount.GetAddress(),
+	}
+	initBalance := sdkmath.NewInt(DEFAULT_BALANCE).MulRaw(500000)
+	s.Faucet.Fund(s.Context(), s.BytesToAccAddressPrefixed(valAccount.Address), sdk.NewCoin(s.Chain.Config.BaseDenom, initBalance))
+
+	storeCodeMsg := &types.MsgStoreCode{
+		Sender:   s.App.WasmxKeeper.GetAuthority(),
+		ByteCode: wasmbin,
+		Deps:     deps,
+	}
+	s.PassGovProposal(valAccount, sender, []sdk.Msg{storeCodeMsg}, "", "Store code", "Store code", false)
+
+	codeId, err := s.App.WasmxKeeper.GetLastCodeId(s.Context())
+	s.S.Require().NoError(err)
+	bytecode, err := s.App.WasmxKeeper.GetByteCode(s.Context(), codeId)
+	s.S.Require().NoError(err)
+	s.S.Require().Equal(wasmbin, bytecode)
+	return codeId
+}
+
 func (s *AppContext) Deploy(sender simulation.Account, code []byte, deps []string, instantiateMsg types.WasmxExecutionMessage, funds sdk.Coins, label string, metadata *types.CodeMetadata) (uint64, mcodec.AccAddressPrefixed) {
 	msgbz, err := json.Marshal(instantiateMsg)
 	s.S.Require().NoError(err)
@@ -5,8 +5,10 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"slices"
+	"sort"
 	"strconv"
 	"time"
 
@@ -14,8 +16,6 @@ import (
 	"cosmossdk.io/log"
 
 	sdkclient "github.com/cosmos/cosmos-sdk/client"
-	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
-	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	_ "github.com/cosmos/cosmos-sdk/types/tx/amino" // Import amino.proto file for reflection
 	authante "github.com/cosmos/cosmos-sdk/x/auth/ante"
@@ -25,7 +25,6 @@ import (
 	abcicli "github.com/cometbft/cometbft/abci/client"
 	abci "github.com/cometbft/cometbft/abci/types"
 	cmtconfig "github.com/cometbft/cometbft/config"
-	cometbftenc "github.com/cometbft/cometbft/crypto/encoding"
 	"github.com/cometbft/cometbft/libs/bytes"
 	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
 	"github.com/cometbft/cometbft/libs/pubsub/query/syntax"
@@ -41,6 +40,12 @@ import (
 	wasmxtypes "github.com/loredanacirstea/wasmx/x/wasmx/types"
 )
 
+const (
+	// see cometbft rpc/core
+	defaultPerPage = 30
+	maxPerPage     = 100
+)
+
 type ABCIClient struct {
 	mapp           cfg.MythosApp
 	bapp           cfg.BaseApp
@@ -282,39 +287,9 @@ func (c *ABCIClient) Validators(ctx context.Context, height *int64, page, perPag
 		return nil, err
 	}
 
-	var bmeta types.TendermintValidators
-	err = c.nk.Codec().UnmarshalJSON(entry.ValidatorInfo, &bmeta)
+	cmtvals, err := types.ValidatorsFromEntry(c.nk.Codec(), entry)
 	if err != nil {
-		return nil, errorsmod.Wrapf(err, "ABCIClient.Validators failed to decode cmttypes.Validator")
-	}
-
-	count := len(bmeta.Validators)
-	cmtvals := make([]*cmttypes.Validator, count)
-	for i, val := range bmeta.Validators {
-		var pubkey cryptotypes.PubKey
-		err = c.mapp.InterfaceRegistry().UnpackAny(val.PubKey, &pubkey)
-		if err != nil {
-			return nil, errorsmod.Wrapf(err, "ABCIClient.Validators failed to convert unpack cryptotypes.PubKey")
-		}
-		tmPk, err := cryptocodec.ToCmtProtoPublicKey(pubkey)
-		if err != nil {
-			return nil, errorsmod.Wrapf(err, "ABCIClient.Validators failed to convert cryptotypes.PubKey to proto")
-		}
-		tmPk2, err := cometbftenc.PubKeyFromProto(tmPk)
-		if err != nil {
-			return nil, errorsmod.Wrapf(err, "ABCIClient.Validators failed to convert cryptotypes.PubKey from proto")
-		}
-		valaddr, err := hex.DecodeString(val.HexAddress)
-		if err != nil {
-			return nil, errorsmod.Wrapf(err, "ABCIClient.Validators failed to decode hex address")
-		}
-		v := &cmttypes.Validator{
-			Address:          valaddr,
-			PubKey:           tmPk2,
-			VotingPower:      val.VotingPower,
-			ProposerPriority: val.ProposerPriority,
-		}
-		cmtvals[i] = v
+		return nil, errorsmod.Wrapf(err, "ABCIClient.Validators failed")
 	}
 
 	result := &rpctypes.ResultValidators{
@@ -406,55 +381,19 @@ func (c *ABCIClient) Block(ctx context.Context, height *int64) (*rpctypes.Result
 	if err != nil {
 		return nil, err
 	}
-
-	var bmeta types.RequestProcessProposalWithMetaInfo
-	err = c.nk.Codec().UnmarshalJSON(entry.Data, &bmeta)
-	if err != nil {
-		return nil, errorsmod.Wrapf(err, "ABCIClient.Block failed to decode RequestProcessProposalWithMetaInfo")
-	}
-	b := bmeta.Request
-
-	var header cmttypes.Header
-	err = json.Unmarshal(entry.Header, &header)
+	block, blockHash, err := c.blockFromEntry(entry)
 	if err != nil {
-		return nil, errorsmod.Wrapf(err, "ABCIClient.Block failed to decode Header")
-	}
-
-	var lastCommit cmttypes.Commit
-	err = json.Unmarshal(entry.LastCommit, &lastCommit)
-	if err != nil {
-		return nil, errorsmod.Wrapf(err, "ABCIClient.Block failed to decode Commit")
-	}
-
-	var evidence cmttypes.EvidenceData
-	err = json.Unmarshal(entry.Evidence, &evidence)
-	if err != nil {
-		return nil, errorsmod.Wrapf(err, "ABCIClient.Block failed to decode EvidenceData")
+		return nil, err
 	}
-
-	if len(b.Hash) == 0 {
+	if len(blockHash) == 0 {
 		return nil, fmt.Errorf("block (%d) not found", blockHeight)
 	}
 
 	// TODO fixme
 	blockId := cmttypes.BlockID{
-		Hash:          b.Hash,
-		PartSetHeader: cmttypes.PartSetHeader{Total: 1, Hash: b.Hash},
-	}
-
-	txs := make([]cmttypes.Tx, len(b.Txs))
-	for i, tx := range b.Txs {
-		txs[i] = cmttypes.Tx(tx)
+		Hash:          blockHash,
+		PartSetHeader: cmttypes.PartSetHeader{Total: 1, Hash: blockHash},
 	}
-	block := cmttypes.MakeBlock(b.Height, txs, &lastCommit, evidence.Evidence)
-	block.ChainID = c.bapp.ChainID()
-	block.AppHash = header.AppHash
-	block.ConsensusHash = header.ConsensusHash
-	block.Header = header
-	block.LastBlockID = header.LastBlockID
-	block.LastResultsHash = header.LastResultsHash
-	block.Time = header.Time
-	block.ProposerAddress = header.ProposerAddress
 
 	result := rpctypes.ResultBlock{
 		BlockID: blockId,
@@ -464,13 +403,77 @@ func (c *ABCIClient) Block(ctx context.Context, height *int64) (*rpctypes.Result
 	return &result, nil
 }
 
+// blockFromEntry rebuilds the block from the consensus contract's block entry
+// it also returns the block hash, as computed by the consensus contract
+func (c *ABCIClient) blockFromEntry(entry *types.BlockEntry) (*cmttypes.Block, []byte, error) {
+	block, hash, err := types.BlockFromEntry(c.nk.Codec(), c.bapp.ChainID(), entry)
+	if err != nil {
+		return nil, nil, errorsmod.Wrapf(err, "ABCIClient.Block failed")
+	}
+	return block, hash, nil
+}
+
 func (c *ABCIClient) BlockByHash(ctx context.Context, hash []byte) (*rpctypes.ResultBlock, error) {
-	return nil, fmt.Errorf("ABCIClient.BlockByHash not implemented")
+	c.logger.Debug("ABCIClient.BlockByHash", "hash", hex.EncodeToString(hash))
+	blockStore := c.mapp.GetBlockStore()
+	if blockStore == nil {
+		return nil, fmt.Errorf("ABCIClient.BlockByHash: block store not set")
+	}
+	blockMeta := blockStore.LoadBlockMetaByHash(hash)
+	if blockMeta == nil {
+		return nil, fmt.Errorf("block (%X) not found", hash)
+	}
+	return c.Block(ctx, &blockMeta.Header.Height)
 }
 
+// height is nil for latest block
+func (c *ABCIClient) Header(ctx context.Context, height *int64) (*rpctypes.ResultHeader, error) {
+	c.logger.Debug("ABCIClient.Header", "height", height)
+	entry, _, err := c.GetBlockEntry(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	var header cmttypes.Header
+	err = json.Unmarshal(entry.Header, &header)
+	if err != nil {
+		return nil, errorsmod.Wrapf(err, "ABCIClient.Header failed to decode Header")
+	}
+	return &rpctypes.ResultHeader{Header: &header}, nil
+}
+
+func (c *ABCIClient) HeaderByHash(ctx context.Context, hash bytes.HexBytes) (*rpctypes.ResultHeader, error) {
+	c.logger.Debug("ABCIClient.HeaderByHash", "hash", hash.String())
+	blockStore := c.mapp.GetBlockStore()
+	if blockStore == nil {
+		return nil, fmt.Errorf("ABCIClient.HeaderByHash: block store not set")
+	}
+	blockMeta := blockStore.LoadBlockMetaByHash(hash)
+	if blockMeta == nil {
+		// same as cometbft, a missing header is not an error
+		return &rpctypes.ResultHeader{}, nil
+	}
+	return &rpctypes.ResultHeader{Header: &blockMeta.Header}, nil
+}
+
+// height is nil for latest block
 func (c *ABCIClient) BlockResults(ctx context.Context, height *int64) (*rpctypes.ResultBlockResults, error) {
-	// getHeight
-	return nil, fmt.Errorf("ABCIClient.BlockResults not implemented")
+	c.logger.Debug("ABCIClient.BlockResults", "height", height)
+	entry, blockHeight, err := c.GetBlockEntry(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	result, err := types.BlockResultFromEntry(entry)
+	if err != nil {
+		return nil, errorsmod.Wrapf(err, "ABCIClient.BlockResults failed")
+	}
+	return &rpctypes.ResultBlockResults{
+		Height:                blockHeight,
+		TxsResults:            result.TxResults,
+		FinalizeBlockEvents:   result.Events,
+		ValidatorUpdates:      result.ValidatorUpdates,
+		ConsensusParamUpdates: result.ConsensusParamUpdates,
+		AppHash:               result.AppHash,
+	}, nil
 }
 
 func (c *ABCIClient) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*rpctypes.ResultBlockchainInfo, error) {
@@ -494,19 +497,14 @@ func (c *ABCIClient) Commit(ctx context.Context, height *int64) (*rpctypes.Resul
 	// If the next block has not been committed yet,
 	// use a non-canonical commit
 	if block.Block.Height == latestHeight {
-		resp, err := c.consensusQuery("getLastBlockCommit", "[]")
-		if err != nil {
-			return nil, err
-		}
-		var commit cmttypes.Commit
-		err = json.Unmarshal(resp.Data, &commit)
+		commit, err := c.lastBlockCommit()
 		if err != nil {
 			return nil, err
 		}
 		if commit.Height != block.Block.Height {
 			return nil, fmt.Errorf("ABCIClient.Commit commit height mismatch block height: expected %d, got %d", block.Block.Height, commit.Height)
 		}
-		return rpctypes.NewResultCommit(&block.Block.Header, &commit, true), nil
+		return rpctypes.NewResultCommit(&block.Block.Header, commit, true), nil
 	}
 
 	// Return the canonical commit (comes from the block at height+1)
@@ -696,7 +694,101 @@ func (c *ABCIClient) BlockSearch(
 	page, perPage *int,
 	orderBy string,
 ) (*rpctypes.ResultBlockSearch, error) {
-	return nil, fmt.Errorf("ABCIClient.BlockSearch not implemented")
+	q, err := cmtquery.New(query)
+	if err != nil {
+		return nil, err
+	}
+	c.logger.Debug("ABCIClient.BlockSearch", "query", q.String())
+
+	blockIndexer := c.mapp.GetBlockIndexer()
+	if blockIndexer == nil {
+		return nil, fmt.Errorf("ABCIClient.BlockSearch: block indexer not set")
+	}
+	results, err := blockIndexer.Search(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	// sort results (must be done before pagination)
+	switch orderBy {
+	case "desc", "":
+		sort.Slice(results, func(i, j int) bool { return results[i] > results[j] })
+	case "asc":
+		sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+	default:
+		return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
+	}
+
+	// paginate results
+	totalCount := len(results)
+	perPageValue := validatePerPage(perPage)
+	pageValue, err := validatePage(page, perPageValue, totalCount)
+	if err != nil {
+		return nil, err
+	}
+	skipCount := validateSkipCount(pageValue, perPageValue)
+	pageSize := min(perPageValue, totalCount-skipCount)
+
+	apiResults := make([]*rpctypes.ResultBlock, 0, pageSize)
+	for i := skipCount; i < skipCount+pageSize; i++ {
+		block, err := c.Block(ctx, &results[i])
+		if err != nil {
+			// the block may be pruned
+			c.logger.Debug("ABCIClient.BlockSearch block not found", "height", results[i], "error", err.Error())
+			continue
+		}
+		apiResults = append(apiResults, block)
+	}
+	return &rpctypes.ResultBlockSearch{Blocks: apiResults, TotalCount: totalCount}, nil
+}
+
+// UnconfirmedTxs returns the transactions from the consensus contract's mempool.
+// limit defaults to 30 and is capped at 100, like in cometbft
+func (c *ABCIClient) UnconfirmedTxs(ctx context.Context, limit *int) (*rpctypes.ResultUnconfirmedTxs, error) {
+	c.logger.Debug("ABCIClient.UnconfirmedTxs", "limit", limit)
+	mempool, err := c.mempool()
+	if err != nil {
+		return nil, err
+	}
+	txs := mempool.Txs()
+	count := min(validatePerPage(limit), len(txs))
+	return &rpctypes.ResultUnconfirmedTxs{
+		Count:      count,
+		Total:      len(txs),
+		TotalBytes: mempool.TotalBytes(),
+		Txs:        txs[:count],
+	}, nil
+}
+
+// NumUnconfirmedTxs returns the number of transactions in the consensus contract's mempool
+func (c *ABCIClient) NumUnconfirmedTxs(ctx context.Context) (*rpctypes.ResultUnconfirmedTxs, error) {
+	c.logger.Debug("ABCIClient.NumUnconfirmedTxs")
+	mempool, err := c.mempool()
+	if err != nil {
+		return nil, err
+	}
+	return &rpctypes.ResultUnconfirmedTxs{
+		Count:      len(mempool.Map),
+		Total:      len(mempool.Map),
+		TotalBytes: mempool.TotalBytes(),
+	}, nil
+}
+
+// mempool reads the pending transactions from the consensus contract's context
+func (c *ABCIClient) mempool() (*types.Mempool, error) {
+	resp, err := c.fsmQuery(types.GetMempoolKey())
+	if err != nil {
+		return nil, errorsmod.Wrapf(err, "ABCIClient mempool query failed")
+	}
+	var mempool types.Mempool
+	if len(resp.Data) == 0 {
+		return &mempool, nil
+	}
+	err = json.Unmarshal(resp.Data, &mempool)
+	if err != nil {
+		return nil, errorsmod.Wrapf(err, "ABCIClient failed to decode mempool")
+	}
+	return &mempool, nil
 }
 
 // Important! fsmQuery must not create a cycle, so it must only use ExecuteWithHeader
@@ -872,6 +964,44 @@ func ContractQuery(nk types.WasmxWrapper, actionExecutor cfg.ActionExecutor, bap
 	return &resp, nil
 }
 
+func validatePage(pagePtr *int, perPage, totalCount int) (int, error) {
+	if pagePtr == nil { // no page parameter
+		return 1, nil
+	}
+
+	pages := ((totalCount - 1) / perPage) + 1
+	if pages == 0 {
+		pages = 1 // one page (even if it's empty)
+	}
+	page := *pagePtr
+	if page <= 0 || page > pages {
+		return 1, fmt.Errorf("page should be within [1, %d] range, given %d", pages, page)
+	}
+	return page, nil
+}
+
+func validatePerPage(perPagePtr *int) int {
+	if perPagePtr == nil { // no per_page parameter
+		return defaultPerPage
+	}
+
+	perPage := *perPagePtr
+	if perPage < 1 {
+		return defaultPerPage
+	} else if perPage > maxPerPage {
+		return maxPerPage
+	}
+	return perPage
+}
+
+func validateSkipCount(page, perPage int) int {
+	skipCount := (page - 1) * perPage
+	if skipCount < 0 {
+		return 0
+	}
+	return skipCount
+}
+
 func getHeight(latestHeight int64, heightPtr *int64) (int64, error) {
 	if heightPtr != nil {
 		height := *heightPtr
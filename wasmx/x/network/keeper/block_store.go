@@ -0,0 +1,152 @@
+package keeper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"cosmossdk.io/log"
+
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/state/indexer"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	mcfg "github.com/loredanacirstea/wasmx/config"
+	"github.com/loredanacirstea/wasmx/x/network/types"
+)
+
+// BlockStoreSyncer saves the blocks finalized by the consensus contract in the node's block store
+// and indexes their events in the block indexer
+type BlockStoreSyncer struct {
+	mtx     sync.Mutex
+	app     mcfg.MythosApp
+	store   sm.BlockStore
+	indexer indexer.BlockIndexer
+	logger  log.Logger
+}
+
+// indexer is optional
+func NewBlockStoreSyncer(app mcfg.MythosApp, store sm.BlockStore, indexer indexer.BlockIndexer, logger log.Logger) *BlockStoreSyncer {
+	return &BlockStoreSyncer{
+		app:     app,
+		store:   store,
+		indexer: indexer,
+		logger:  logger.With(log.ModuleKey, "network", "service", "blockstore"),
+	}
+}
+
+// Sync saves all finalized blocks that are not in the block store yet.
+// Blocks are read from the consensus contract storage, so Sync must not be called
+// from inside a contract execution: it must only use ExecuteWithHeader
+func (s *BlockStoreSyncer) Sync(ctx context.Context) error {
+	// a sync is already running; the next commit will pick up any missed blocks
+	if !s.mtx.TryLock() {
+		return nil
+	}
+	defer s.mtx.Unlock()
+
+	bapp := s.app.GetBaseApp()
+	client := NewABCIClient(s.app, bapp, s.logger, s.app.GetNetworkKeeper(), nil, nil, s.app.GetActionExecutor()).(*ABCIClient)
+
+	latestHeight, err := client.LatestBlockHeight(ctx)
+	if err != nil {
+		return err
+	}
+	height := s.store.Height() + 1
+	// a new store (e.g. for a state synced node) starts from the latest finalized block
+	if s.store.Height() == 0 {
+		height = latestHeight
+	}
+	if height <= 0 || height > latestHeight {
+		return nil
+	}
+
+	block, err := client.blockByHeight(ctx, height)
+	if err != nil {
+		return err
+	}
+	for ; height <= latestHeight; height++ {
+		var seenCommit *cmttypes.Commit
+		var nextBlock *cmttypes.Block
+		if height < latestHeight {
+			nextBlock, err = client.blockByHeight(ctx, height+1)
+			if err != nil {
+				return err
+			}
+			seenCommit = nextBlock.LastCommit
+		} else {
+			seenCommit, err = client.lastBlockCommit()
+			if err != nil {
+				return err
+			}
+		}
+		if seenCommit == nil || seenCommit.Height != height {
+			return fmt.Errorf("cannot save block %d: missing commit", height)
+		}
+
+		blockParts, err := block.MakePartSet(cmttypes.BlockPartSizeBytes)
+		if err != nil {
+			return err
+		}
+		s.store.SaveBlock(block, blockParts, seenCommit)
+		s.logger.Debug("saved block", "height", height, "hash", block.Hash().String())
+
+		if s.indexer != nil {
+			err = s.indexBlock(ctx, client, block)
+			if err != nil {
+				return err
+			}
+		}
+		block = nextBlock
+	}
+	return nil
+}
+
+func (s *BlockStoreSyncer) indexBlock(ctx context.Context, client *ABCIClient, block *cmttypes.Block) error {
+	entry, _, err := client.GetBlockEntryByHeight(ctx, block.Height)
+	if err != nil {
+		return err
+	}
+	result, err := types.BlockResultFromEntry(entry)
+	if err != nil {
+		return err
+	}
+	return s.indexer.Index(cmttypes.EventDataNewBlockEvents{
+		Height: block.Height,
+		Events: result.Events,
+		NumTxs: int64(len(block.Txs)),
+	})
+}
+
+// blockByHeight returns the block finalized by the consensus contract. The block store indexes
+// blocks by their computed hash, so it must be the hash the contract reports in the rpc BlockIDs.
+func (c *ABCIClient) blockByHeight(ctx context.Context, height int64) (*cmttypes.Block, error) {
+	entry, _, err := c.GetBlockEntryByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	block, hash, err := c.blockFromEntry(entry)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(block.Hash(), hash) {
+		return nil, fmt.Errorf("block %d hash mismatch: computed %X, consensus contract %X", height, block.Hash(), hash)
+	}
+	return block, nil
+}
+
+// lastBlockCommit returns the commit for the latest block, before it is included in the next block
+func (c *ABCIClient) lastBlockCommit() (*cmttypes.Commit, error) {
+	resp, err := c.consensusQuery("getLastBlockCommit", "[]")
+	if err != nil {
+		return nil, err
+	}
+	var commit cmttypes.Commit
+	err = json.Unmarshal(resp.Data, &commit)
+	if err != nil {
+		return nil, err
+	}
+	return &commit, nil
+}
@@ -0,0 +1,61 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"cosmossdk.io/log"
+
+	mcfg "github.com/loredanacirstea/wasmx/config"
+	"github.com/loredanacirstea/wasmx/x/network/types"
+)
+
+func (k *Keeper) GetBlockSyncEntries(app mcfg.MythosApp, logger log.Logger, startHeight int64, endHeight int64) ([]types.BlockSyncEntry, int64, error) {
+	return GetBlockSyncEntries(app, logger, startHeight, endHeight)
+}
+
+// GetBlockSyncEntries returns the finalized blocks in [startHeight, endHeight], each with the commit that finalized it,
+// together with the latest block height. endHeight is capped at the latest block height.
+// Important! it must not create a cycle, so it must only use ExecuteWithHeader
+func GetBlockSyncEntries(app mcfg.MythosApp, logger log.Logger, startHeight int64, endHeight int64) ([]types.BlockSyncEntry, int64, error) {
+	if startHeight < 1 || endHeight < startHeight {
+		return nil, 0, fmt.Errorf("invalid block range: %d - %d", startHeight, endHeight)
+	}
+	bapp := app.GetBaseApp()
+	client := NewABCIClient(app, bapp, logger, app.GetNetworkKeeper(), nil, nil, app.GetActionExecutor()).(*ABCIClient)
+
+	ctx := context.TODO()
+	latestHeight, err := client.LatestBlockHeight(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if endHeight > latestHeight {
+		endHeight = latestHeight
+	}
+
+	entries := []types.BlockSyncEntry{}
+	for height := startHeight; height <= endHeight; height++ {
+		entry, _, err := client.GetBlockEntryByHeight(ctx, height)
+		if err != nil {
+			return nil, 0, err
+		}
+		entrybz, err := json.Marshal(entry)
+		if err != nil {
+			return nil, 0, err
+		}
+		commit, err := client.Commit(ctx, &height)
+		if err != nil {
+			return nil, 0, err
+		}
+		if commit.Commit == nil {
+			return nil, 0, fmt.Errorf("missing commit for block %d", height)
+		}
+		commitbz, err := commit.Commit.ToProto().Marshal()
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, types.BlockSyncEntry{Entry: entrybz, Commit: commitbz})
+	}
+	return entries, latestHeight, nil
+}
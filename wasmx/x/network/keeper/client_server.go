@@ -17,8 +17,6 @@ import (
 	grpcrecovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"github.com/rs/cors"
 
-	gws "github.com/gorilla/websocket"
-
 	errorsmod "cosmossdk.io/errors"
 	"cosmossdk.io/log"
 
@@ -38,11 +36,13 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
 	_ "github.com/cosmos/cosmos-sdk/types/tx/amino" // Import amino.proto file for reflection
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	cmtconfig "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/libs/bytes"
 	cmtnet "github.com/cometbft/cometbft/libs/net"
+	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
 	"github.com/cometbft/cometbft/node"
 	"github.com/cometbft/cometbft/p2p"
 	pvm "github.com/cometbft/cometbft/privval"
@@ -252,6 +252,22 @@ func RegisterGRPCServer(
 	return handler, nil
 }
 
+// GenesisDocProviderFromConfig returns a function which returns the genesis doc of a chain
+// from its genesis file: genesis_<chainId>.json
+func GenesisDocProviderFromConfig(cfg *cmtconfig.Config) mcfg.GenesisDocProvider {
+	return func(chainId string) (*cmttypes.GenesisDoc, error) {
+		genFile := cfg.GenesisFile()
+		if chainId != "" {
+			genFile = strings.Replace(genFile, ".json", "_"+chainId+".json", 1)
+		}
+		appGenesis, err := genutiltypes.AppGenesisFromFile(genFile)
+		if err != nil {
+			return nil, err
+		}
+		return appGenesis.ToGenesisDoc()
+	}
+}
+
 func loadGenDoc(genesisDocProvider mcfg.GenesisDocProvider, chainId string) (*cmttypes.GenesisDoc, error) {
 	genDoc, err := genesisDocProvider(chainId)
 	if err != nil {
@@ -355,28 +371,41 @@ func InitChain(
 	return resInit, nil
 }
 
-type WebsocketManager struct {
-	gws.Upgrader
-
-	// funcMap       map[string]*RPCFunc
-	logger        log.Logger
-	wsConnOptions []func(*wsConnection)
-}
-
-type wsConnection struct {
-	baseConn   *gws.Conn
-	remoteAddr string
-	// funcMap    map[string]*RPCFunc
-}
-
 func StartRPC(svrCtx *server.Context, ctx context.Context, app servertypes.Application, networkWrap client.CometRPC, logger log.Logger, cfg *config.Config) error {
 	// listenAddrs := splitAndTrimEmpty(n.config.RPC.ListenAddress, ",", " ")
 	listenAddr := svrCtx.Config.RPC.ListenAddress
 
-	env := Environment{app: app, networkWrap: networkWrap, serverConfig: svrCtx.Config, config: cfg}
+	mythosapp, err := GetMythosApp(app)
+	if err != nil {
+		return err
+	}
+	eventBus := mythosapp.GetEventBus()
+
+	env := NewEnvironment(app, networkWrap, svrCtx.Config, cfg, eventBus, logger.With(log.ModuleKey, "rpc"))
+	chainId := mythosapp.GetBaseApp().ChainID()
+	genDoc, err := loadGenDoc(GenesisDocProviderFromConfig(svrCtx.Config), chainId)
+	if err != nil {
+		// subchains may not have a genesis file
+		logger.Error("cannot load genesis doc for RPC server", "chain_id", chainId, "error", err.Error())
+	} else if err := env.InitGenesisChunks(genDoc); err != nil {
+		return err
+	}
 	routes := env.GetRoutes()
-	wm := WebsocketManager{logger: logger}
 	rpcLogger := servercmtlog.CometLoggerWrapper{Logger: logger.With(log.ModuleKey, "rpc-server")}
+	config := cometjsonserver.DefaultConfig()
+
+	wmLogger := rpcLogger.With("protocol", "websocket")
+	wm := cometjsonserver.NewWebsocketManager(routes,
+		cometjsonserver.OnDisconnect(func(remoteAddr string) {
+			err := eventBus.UnsubscribeAll(context.Background(), remoteAddr)
+			if err != nil && err != cmtpubsub.ErrSubscriptionNotFound {
+				wmLogger.Error("Failed to unsubscribe addr from events", "addr", remoteAddr, "err", err)
+			}
+		}),
+		cometjsonserver.ReadLimit(config.MaxBodyBytes),
+		cometjsonserver.WriteChanCapacity(svrCtx.Config.RPC.WebSocketWriteBufferSize),
+	)
+	wm.SetLogger(wmLogger)
 	mux := http.NewServeMux()
 	mux.HandleFunc("/websocket", wm.WebsocketHandler)
 	cometjsonserver.RegisterRPCFuncs(mux, routes, rpcLogger)
@@ -389,7 +418,6 @@ func StartRPC(svrCtx *server.Context, ctx context.Context, app servertypes.Appli
 	}
 	// return rootHandler
 
-	config := cometjsonserver.DefaultConfig()
 	// config.MaxBodyBytes = cfg.API.MaxBodyBytes
 	// config.MaxHeaderBytes = cfg.API.MaxHeaderBytes
 	// config.MaxOpenConnections = cfg.API.MaxOpenConnections
@@ -444,39 +472,6 @@ func addCORSHandler(rpcConfig *cmtconfig.RPCConfig, h http.Handler) http.Handler
 	return h
 }
 
-func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	wsConn, err := wm.Upgrade(w, r, nil)
-	if err != nil {
-		// TODO - return http error
-		wm.logger.Error("Failed to upgrade connection", "err", err)
-		return
-	}
-	defer func() {
-		if err := wsConn.Close(); err != nil {
-			wm.logger.Error("Failed to close connection", "err", err)
-		}
-	}()
-
-	// register connection
-	// con := newWSConnection(wsConn, wm.funcMap, wm.wsConnOptions...)
-	con := &wsConnection{
-		// funcMap:    wm.funcMap,
-		remoteAddr: wsConn.RemoteAddr().String(),
-		baseConn:   wsConn,
-	}
-
-	// con.SetLogger(wm.logger.With("remote", wsConn.RemoteAddr()))
-	wm.logger.Info("New websocket connection", "remote", con.remoteAddr)
-	// err = con.Start() // BLOCKING
-	// if err != nil {
-	// 	wm.logger.Error("Failed to start connection", "err", err)
-	// 	return
-	// }
-	// if err := con.Stop(); err != nil {
-	// 	wm.logger.Error("error while stopping connection", "error", err)
-	// }
-}
-
 func GetBaseApp(app servertypes.Application) (mcfg.BaseApp, error) {
 	bapp, ok := app.(mcfg.BaseApp)
 	if !ok {
@@ -0,0 +1,145 @@
+package keeper
+
+import (
+	"context"
+	"sync"
+
+	"cosmossdk.io/log"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	mcfg "github.com/loredanacirstea/wasmx/config"
+	"github.com/loredanacirstea/wasmx/x/network/types"
+)
+
+// BlockEventPublisher publishes the finalized blocks and their transactions on the node's event bus,
+// for the websocket subscriptions
+type BlockEventPublisher struct {
+	mtx        sync.Mutex
+	app        mcfg.MythosApp
+	eventBus   *cmttypes.EventBus
+	logger     log.Logger
+	lastHeight int64
+}
+
+func NewBlockEventPublisher(app mcfg.MythosApp, eventBus *cmttypes.EventBus, logger log.Logger) *BlockEventPublisher {
+	return &BlockEventPublisher{
+		app:      app,
+		eventBus: eventBus,
+		logger:   logger.With(log.ModuleKey, "network", "service", "events"),
+	}
+}
+
+// Publish fires the events of all finalized blocks that were not published yet.
+// Blocks are read from the consensus contract storage, so Publish must not be called
+// from inside a contract execution: it must only use ExecuteWithHeader
+func (p *BlockEventPublisher) Publish(ctx context.Context) error {
+	// a publish is already running; the next commit will pick up any missed blocks
+	if !p.mtx.TryLock() {
+		return nil
+	}
+	defer p.mtx.Unlock()
+
+	// nobody is listening; start from the latest block once we have subscribers
+	if p.eventBus.NumClients() == 0 {
+		p.lastHeight = 0
+		return nil
+	}
+
+	bapp := p.app.GetBaseApp()
+	client := NewABCIClient(p.app, bapp, p.logger, p.app.GetNetworkKeeper(), nil, nil, p.app.GetActionExecutor()).(*ABCIClient)
+	latestHeight, err := client.LatestBlockHeight(ctx)
+	if err != nil {
+		return err
+	}
+	if p.lastHeight == 0 {
+		p.lastHeight = latestHeight - 1
+	}
+	for height := p.lastHeight + 1; height <= latestHeight; height++ {
+		entry, _, err := client.GetBlockEntryByHeight(ctx, height)
+		if err != nil {
+			return err
+		}
+		block, _, err := client.blockFromEntry(entry)
+		if err != nil {
+			return err
+		}
+		result, err := types.BlockResultFromEntry(entry)
+		if err != nil {
+			return err
+		}
+		blockParts, err := block.MakePartSet(cmttypes.BlockPartSizeBytes)
+		if err != nil {
+			return err
+		}
+		blockID := cmttypes.BlockID{Hash: block.Hash(), PartSetHeader: blockParts.Header()}
+		validatorUpdates, err := cmttypes.PB2TM.ValidatorUpdates(result.ValidatorUpdates)
+		if err != nil {
+			return err
+		}
+		p.fireEvents(block, blockID, result, validatorUpdates)
+		p.lastHeight = height
+	}
+	return nil
+}
+
+// fireEvents publishes the same events as the cometbft block executor
+func (p *BlockEventPublisher) fireEvents(
+	block *cmttypes.Block,
+	blockID cmttypes.BlockID,
+	abciResponse *abci.ResponseFinalizeBlock,
+	validatorUpdates []*cmttypes.Validator,
+) {
+	if err := p.eventBus.PublishEventNewBlock(cmttypes.EventDataNewBlock{
+		Block:               block,
+		BlockID:             blockID,
+		ResultFinalizeBlock: *abciResponse,
+	}); err != nil {
+		p.logger.Error("failed publishing new block", "err", err)
+	}
+
+	if err := p.eventBus.PublishEventNewBlockHeader(cmttypes.EventDataNewBlockHeader{
+		Header: block.Header,
+	}); err != nil {
+		p.logger.Error("failed publishing new block header", "err", err)
+	}
+
+	if err := p.eventBus.PublishEventNewBlockEvents(cmttypes.EventDataNewBlockEvents{
+		Height: block.Height,
+		Events: abciResponse.Events,
+		NumTxs: int64(len(block.Txs)),
+	}); err != nil {
+		p.logger.Error("failed publishing new block events", "err", err)
+	}
+
+	for _, ev := range block.Evidence.Evidence {
+		if err := p.eventBus.PublishEventNewEvidence(cmttypes.EventDataNewEvidence{
+			Evidence: ev,
+			Height:   block.Height,
+		}); err != nil {
+			p.logger.Error("failed publishing new evidence", "err", err)
+		}
+	}
+
+	for i, tx := range block.Data.Txs {
+		if i >= len(abciResponse.TxResults) {
+			break
+		}
+		if err := p.eventBus.PublishEventTx(cmttypes.EventDataTx{TxResult: abci.TxResult{
+			Height: block.Height,
+			Index:  uint32(i),
+			Tx:     tx,
+			Result: *(abciResponse.TxResults[i]),
+		}}); err != nil {
+			p.logger.Error("failed publishing event TX", "err", err)
+		}
+	}
+
+	if len(validatorUpdates) > 0 {
+		if err := p.eventBus.PublishEventValidatorSetUpdates(
+			cmttypes.EventDataValidatorSetUpdates{ValidatorUpdates: validatorUpdates}); err != nil {
+			p.logger.Error("failed publishing event", "err", err)
+		}
+	}
+}
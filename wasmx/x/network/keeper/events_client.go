@@ -0,0 +1,100 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+
+	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
+	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	rpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+var _ rpcclient.EventsClient = (*ABCIClient)(nil)
+
+// Subscribe subscribes to the node's event bus, like the cometbft local client.
+// Events are published after each block is committed (see BlockEventPublisher).
+// The returned channel is closed when the subscription is canceled.
+func (c *ABCIClient) Subscribe(
+	ctx context.Context,
+	subscriber,
+	query string,
+	outCapacity ...int,
+) (out <-chan rpctypes.ResultEvent, err error) {
+	eventBus := c.mapp.GetEventBus()
+	if eventBus == nil {
+		return nil, fmt.Errorf("ABCIClient.Subscribe: event bus not set")
+	}
+	q, err := cmtquery.New(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	outCap := 1
+	if len(outCapacity) > 0 {
+		outCap = outCapacity[0]
+	}
+
+	var sub cmttypes.Subscription
+	if outCap > 0 {
+		sub, err = eventBus.Subscribe(ctx, subscriber, q, outCap)
+	} else {
+		sub, err = eventBus.SubscribeUnbuffered(ctx, subscriber, q)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	outc := make(chan rpctypes.ResultEvent, outCap)
+	go c.eventsRoutine(sub, q, outc)
+	return outc, nil
+}
+
+func (c *ABCIClient) eventsRoutine(
+	sub cmttypes.Subscription,
+	q cmtpubsub.Query,
+	outc chan<- rpctypes.ResultEvent,
+) {
+	defer close(outc)
+	for {
+		select {
+		case msg := <-sub.Out():
+			result := rpctypes.ResultEvent{Query: q.String(), Data: msg.Data(), Events: msg.Events()}
+			if cap(outc) == 0 {
+				outc <- result
+			} else {
+				select {
+				case outc <- result:
+				default:
+					c.logger.Error("wanted to publish ResultEvent, but out channel is full", "query", result.Query)
+				}
+			}
+		case <-sub.Canceled():
+			if sub.Err() != cmtpubsub.ErrUnsubscribed {
+				c.logger.Error("subscription was canceled", "err", sub.Err(), "query", q.String())
+			}
+			return
+		}
+	}
+}
+
+func (c *ABCIClient) Unsubscribe(ctx context.Context, subscriber, query string) error {
+	eventBus := c.mapp.GetEventBus()
+	if eventBus == nil {
+		return fmt.Errorf("ABCIClient.Unsubscribe: event bus not set")
+	}
+	q, err := cmtquery.New(query)
+	if err != nil {
+		return fmt.Errorf("failed to parse query: %w", err)
+	}
+	return eventBus.Unsubscribe(ctx, subscriber, q)
+}
+
+func (c *ABCIClient) UnsubscribeAll(ctx context.Context, subscriber string) error {
+	eventBus := c.mapp.GetEventBus()
+	if eventBus == nil {
+		return fmt.Errorf("ABCIClient.UnsubscribeAll: event bus not set")
+	}
+	return eventBus.UnsubscribeAll(ctx, subscriber)
+}
@@ -232,6 +232,13 @@ func (r *ActionExecutor) ExecuteInternal(
 	if goCtx == nil {
 		goCtx = context.Background()
 	}
+	// non-consensus executions are bounded by the caller deadline,
+	// so the wasm runtime and host APIs stop when it expires
+	if deadline, ok := goCtx.Deadline(); ok && (mode == sdk.ExecModeQuery || mode == sdk.ExecModeSimulate) {
+		baseCtx, cancel := context.WithDeadline(sdkCtx.Context(), deadline)
+		defer cancel()
+		sdkCtx = sdkCtx.WithContext(baseCtx)
+	}
 	goCtx = context.WithValue(goCtx, sdk.SdkContextKey, sdkCtx)
 
 	// call app BeginTransaction hook
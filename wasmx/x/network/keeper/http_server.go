@@ -2,11 +2,21 @@ package keeper
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+
+	"cosmossdk.io/log"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	cmtconfig "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/libs/bytes"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
+	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	cometp2p "github.com/cometbft/cometbft/p2p"
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	cometcore "github.com/cometbft/cometbft/rpc/core"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
@@ -18,6 +28,19 @@ import (
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
 
 	"github.com/loredanacirstea/wasmx/server/config"
+	"github.com/loredanacirstea/wasmx/x/network/types"
+)
+
+const (
+	// maxQueryLength is the maximum length of a subscription query
+	maxQueryLength = 512
+
+	// SubscribeTimeout is the maximum time we wait to subscribe for an event.
+	SubscribeTimeout = 5 * time.Second
+
+	// genesisChunkSize is the maximum size, in bytes, of each
+	// chunk in the genesis structure for the chunked API
+	genesisChunkSize = 16 * 1024 * 1024 // 16
 )
 
 type Environment struct {
@@ -25,6 +48,11 @@ type Environment struct {
 	networkWrap  client.CometRPC
 	serverConfig *cmtconfig.Config
 	config       *config.Config
+	eventBus     *comettypes.EventBus
+	logger       log.Logger
+
+	genDoc    *comettypes.GenesisDoc
+	genChunks []string
 }
 
 func NewEnvironment(
@@ -32,15 +60,45 @@ func NewEnvironment(
 	networkWrap client.CometRPC,
 	serverConfig *cmtconfig.Config,
 	config *config.Config,
+	eventBus *comettypes.EventBus,
+	logger log.Logger,
 ) Environment {
 	return Environment{
-		app,
-		networkWrap,
-		serverConfig,
-		config,
+		app:          app,
+		networkWrap:  networkWrap,
+		serverConfig: serverConfig,
+		config:       config,
+		eventBus:     eventBus,
+		logger:       logger,
 	}
 }
 
+// abciClient returns the network client for routes that are not part of client.CometRPC
+func (env *Environment) abciClient() (*ABCIClient, error) {
+	abciclient, ok := env.networkWrap.(*ABCIClient)
+	if !ok {
+		return nil, fmt.Errorf("rpc route not supported by network client %T", env.networkWrap)
+	}
+	return abciclient, nil
+}
+
+// InitGenesisChunks sets the genesis doc and splits it into chunks
+// for the genesis_chunked API. It should be called on service startup.
+func (env *Environment) InitGenesisChunks(genDoc *comettypes.GenesisDoc) error {
+	data, err := cmtjson.Marshal(genDoc)
+	if err != nil {
+		return err
+	}
+	genChunks := make([]string, 0)
+	for i := 0; i < len(data); i += genesisChunkSize {
+		end := min(i+genesisChunkSize, len(data))
+		genChunks = append(genChunks, base64.StdEncoding.EncodeToString(data[i:end]))
+	}
+	env.genDoc = genDoc
+	env.genChunks = genChunks
+	return nil
+}
+
 // Routes is a map of available routes.
 func (env *Environment) GetRoutes() cometcore.RoutesMap {
 	return cometcore.RoutesMap{
@@ -87,24 +145,118 @@ func (env *Environment) GetRoutes() cometcore.RoutesMap {
 	}
 }
 
+// Subscribe for events via WebSocket.
+// Events are published after each block is committed (see BlockEventPublisher)
 func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
-	fmt.Println("= WS Subscribe")
-	return nil, fmt.Errorf("Subscribe not implemented")
+	addr := ctx.RemoteAddr()
+	rpcConfig := env.serverConfig.RPC
+
+	if env.eventBus.NumClients() >= rpcConfig.MaxSubscriptionClients {
+		return nil, fmt.Errorf("max_subscription_clients %d reached", rpcConfig.MaxSubscriptionClients)
+	} else if env.eventBus.NumClientSubscriptions(addr) >= rpcConfig.MaxSubscriptionsPerClient {
+		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", rpcConfig.MaxSubscriptionsPerClient)
+	} else if len(query) > maxQueryLength {
+		return nil, errors.New("maximum query length exceeded")
+	}
+
+	env.logger.Info("Subscribe to query", "remote", addr, "query", query)
+
+	q, err := cmtquery.New(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
+	defer cancel()
+
+	sub, err := env.eventBus.Subscribe(subCtx, addr, q, rpcConfig.SubscriptionBufferSize)
+	if err != nil {
+		return nil, err
+	}
+
+	closeIfSlow := rpcConfig.CloseOnSlowClient
+
+	// Capture the current ID, since it can change in the future.
+	subscriptionID := ctx.JSONReq.ID
+	go func() {
+		for {
+			select {
+			case msg := <-sub.Out():
+				resultEvent := &ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()}
+				resp := rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
+				writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				err := ctx.WSConn.WriteRPCResponse(writeCtx, resp)
+				cancel()
+				if err != nil {
+					env.logger.Info("Can't write response (slow client)", "to", addr, "subscriptionID", subscriptionID, "err", err)
+
+					if closeIfSlow {
+						err := errors.New("subscription was canceled (reason: slow client)")
+						resp := rpctypes.RPCServerError(subscriptionID, err)
+						if !ctx.WSConn.TryWriteRPCResponse(resp) {
+							env.logger.Info("Can't write response (slow client)", "to", addr, "subscriptionID", subscriptionID, "err", err)
+						}
+						return
+					}
+				}
+			case <-sub.Canceled():
+				if sub.Err() != cmtpubsub.ErrUnsubscribed {
+					reason := "node exited"
+					if sub.Err() != nil {
+						reason = sub.Err().Error()
+					}
+					err := fmt.Errorf("subscription was canceled (reason: %s)", reason)
+					resp := rpctypes.RPCServerError(subscriptionID, err)
+					if !ctx.WSConn.TryWriteRPCResponse(resp) {
+						env.logger.Info("Can't write response (slow client)", "to", addr, "subscriptionID", subscriptionID, "err", err)
+					}
+				}
+				return
+			}
+		}
+	}()
+
+	return &ctypes.ResultSubscribe{}, nil
 }
 
 func (env *Environment) Unsubscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultUnsubscribe, error) {
-	fmt.Println("= WS Unsubscribe")
-	return nil, fmt.Errorf("Unsubscribe not implemented")
+	addr := ctx.RemoteAddr()
+	env.logger.Info("Unsubscribe from query", "remote", addr, "query", query)
+	q, err := cmtquery.New(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+	err = env.eventBus.Unsubscribe(context.Background(), addr, q)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultUnsubscribe{}, nil
 }
 
 func (env *Environment) UnsubscribeAll(ctx *rpctypes.Context) (*ctypes.ResultUnsubscribe, error) {
-	fmt.Println("= WS UnsubscribeAll")
-	return nil, fmt.Errorf("UnsubscribeAll not implemented")
+	addr := ctx.RemoteAddr()
+	env.logger.Info("Unsubscribe from all", "remote", addr)
+	err := env.eventBus.UnsubscribeAll(context.Background(), addr)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultUnsubscribe{}, nil
 }
 
+// Health gets node health. Returns empty result (200 OK) on success, no
+// response - in case of an error.
+// The node is healthy if the consensus contract storage can be queried.
+// More: https://docs.cometbft.com/v0.38.x/rpc/#/Info/health
 func (env *Environment) Health(*rpctypes.Context) (*ctypes.ResultHealth, error) {
-	fmt.Println("= WS Health")
-	return nil, fmt.Errorf("Health not implemented")
+	client, err := env.abciClient()
+	if err != nil {
+		return nil, err
+	}
+	_, err = client.LatestBlockHeight(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultHealth{}, nil
 }
 
 func (env *Environment) Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
@@ -112,9 +264,66 @@ func (env *Environment) Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, err
 	return env.networkWrap.Status(context.TODO())
 }
 
+// NetInfo returns network info.
+// Peers are the chain's nodes from the network.ips configuration, in the form
+// `validatorAddress@/ip4/<ip>/tcp/<port>/p2p/<peerId>`; the current node is the listener.
+// More: https://docs.cometbft.com/v0.38.x/rpc/#/Info/net_info
 func (env *Environment) NetInfo(*rpctypes.Context) (*ctypes.ResultNetInfo, error) {
-	fmt.Println("= WS NetInfo")
-	return nil, fmt.Errorf("NetInfo not implemented")
+	bapp, err := GetBaseApp(env.app)
+	if err != nil {
+		return nil, err
+	}
+	chainId := bapp.ChainID()
+	nodeIps := types.GetPeersFromConfigIps(chainId, env.config.Network.Ips)
+	currentNodeId, err := types.GetCurrentNodeIdFromConfig(chainId, env.config.Network.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	listeners := []string{}
+	peers := []ctypes.Peer{}
+	for i, nodeIp := range nodeIps {
+		if nodeIp == "" {
+			continue
+		}
+		if i == currentNodeId {
+			listeners = append(listeners, nodeIp)
+			continue
+		}
+		peer, err := netInfoPeer(chainId, nodeIp)
+		if err != nil {
+			return nil, err
+		}
+		peers = append(peers, *peer)
+	}
+	return &ctypes.ResultNetInfo{
+		Listening: len(listeners) > 0,
+		Listeners: listeners,
+		NPeers:    len(peers),
+		Peers:     peers,
+	}, nil
+}
+
+func netInfoPeer(chainId string, nodeIp string) (*ctypes.Peer, error) {
+	parts := strings.Split(nodeIp, "@")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid peer address: %s", nodeIp)
+	}
+	// /ip4/<ip>/tcp/<port>/p2p/<peerId>
+	addrParts := strings.Split(parts[1], "/")
+	if len(addrParts) != 7 {
+		return nil, fmt.Errorf("invalid peer multiaddress: %s", parts[1])
+	}
+	return &ctypes.Peer{
+		NodeInfo: cometp2p.DefaultNodeInfo{
+			DefaultNodeID: cometp2p.ID(addrParts[6]),
+			ListenAddr:    parts[1],
+			Network:       chainId,
+			Moniker:       parts[0],
+		},
+		IsOutbound: true,
+		RemoteIP:   addrParts[2],
+	}, nil
 }
 
 func (env *Environment) BlockchainInfo(
@@ -125,14 +334,37 @@ func (env *Environment) BlockchainInfo(
 	return env.networkWrap.BlockchainInfo(context.TODO(), minHeight, maxHeight)
 }
 
+// Genesis returns genesis file.
+// More: https://docs.cometbft.com/v0.38.x/rpc/#/Info/genesis
 func (env *Environment) Genesis(*rpctypes.Context) (*ctypes.ResultGenesis, error) {
-	fmt.Println("= WS Genesis")
-	return nil, fmt.Errorf("Genesis not implemented")
+	if env.genDoc == nil {
+		return nil, errors.New("genesis doc is not loaded")
+	}
+	if len(env.genChunks) > 1 {
+		return nil, errors.New("genesis response is large, please use the genesis_chunked API instead")
+	}
+	return &ctypes.ResultGenesis{Genesis: env.genDoc}, nil
 }
 
+// GenesisChunked returns the genesis file in base64 encoded chunks.
+// More: https://docs.cometbft.com/v0.38.x/rpc/#/Info/genesis_chunked
 func (env *Environment) GenesisChunked(_ *rpctypes.Context, chunk uint) (*ctypes.ResultGenesisChunk, error) {
-	fmt.Println("= WS GenesisChunked")
-	return nil, fmt.Errorf("GenesisChunked not implemented")
+	if env.genChunks == nil {
+		return nil, fmt.Errorf("service configuration error, genesis chunks are not initialized")
+	}
+	if len(env.genChunks) == 0 {
+		return nil, fmt.Errorf("service configuration error, there are no chunks")
+	}
+
+	id := int(chunk)
+	if id > len(env.genChunks)-1 {
+		return nil, fmt.Errorf("there are %d chunks, %d is invalid", len(env.genChunks)-1, id)
+	}
+	return &ctypes.ResultGenesisChunk{
+		TotalChunks: len(env.genChunks),
+		ChunkNumber: id,
+		Data:        env.genChunks[id],
+	}, nil
 }
 
 func (env *Environment) Block(ctx *rpctypes.Context, height *int64) (*ctypes.ResultBlock, error) {
@@ -158,14 +390,25 @@ func (env *Environment) Commit(_ *rpctypes.Context, heightPtr *int64) (*ctypes.R
 	return env.networkWrap.Commit(context.TODO(), heightPtr)
 }
 
+// Header gets block header at a given height.
+// If no height is provided, it will fetch the latest header.
+// More: https://docs.cometbft.com/v0.38.x/rpc/#/Info/header
 func (env *Environment) Header(_ *rpctypes.Context, heightPtr *int64) (*ctypes.ResultHeader, error) {
-	fmt.Println("= WS Header")
-	return nil, fmt.Errorf("Header not implemented")
+	client, err := env.abciClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.Header(context.TODO(), heightPtr)
 }
 
+// HeaderByHash gets header by hash.
+// More: https://docs.cometbft.com/v0.38.x/rpc/#/Info/header_by_hash
 func (env *Environment) HeaderByHash(_ *rpctypes.Context, hash bytes.HexBytes) (*ctypes.ResultHeader, error) {
-	fmt.Println("= WS HeaderByHash")
-	return nil, fmt.Errorf("HeaderByHash not implemented")
+	client, err := env.abciClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.HeaderByHash(context.TODO(), hash)
 }
 
 func (env *Environment) CheckTx(_ *rpctypes.Context, tx comettypes.Tx) (*ctypes.ResultCheckTx, error) {
@@ -174,7 +417,10 @@ func (env *Environment) CheckTx(_ *rpctypes.Context, tx comettypes.Tx) (*ctypes.
 		Tx:   tx,
 		Type: abci.CheckTxType_New,
 	}
-	abciclient := env.networkWrap.(*ABCIClient)
+	abciclient, err := env.abciClient()
+	if err != nil {
+		return nil, err
+	}
 	resp, err := abciclient.CheckTx(context.TODO(), req)
 	if err != nil {
 		return nil, err
@@ -202,13 +448,15 @@ func (env *Environment) TxSearch(
 	return env.networkWrap.TxSearch(context.TODO(), query, prove, pagePtr, perPagePtr, orderBy)
 }
 
+// BlockSearch searches for a paginated set of blocks matching
+// FinalizeBlock event search criteria.
+// More: https://docs.cometbft.com/v0.38.x/rpc/#/Info/block_search
 func (env *Environment) BlockSearch(
 	ctx *rpctypes.Context,
 	query string,
 	pagePtr, perPagePtr *int,
 	orderBy string,
 ) (*ctypes.ResultBlockSearch, error) {
-	fmt.Println("= WS BlockSearch")
 	return env.networkWrap.BlockSearch(context.TODO(), query, pagePtr, perPagePtr, orderBy)
 }
 
@@ -239,18 +487,32 @@ func (env *Environment) ConsensusParams(
 	heightPtr *int64,
 ) (*ctypes.ResultConsensusParams, error) {
 	fmt.Println("= WS ConsensusParams")
-	client := env.networkWrap.(*ABCIClient)
+	client, err := env.abciClient()
+	if err != nil {
+		return nil, err
+	}
 	return client.ConsensusParams(context.TODO(), heightPtr)
 }
 
+// UnconfirmedTxs gets unconfirmed transactions (maximum ?limit entries)
+// from the consensus contract's mempool, in order.
+// More: https://docs.cometbft.com/v0.38.x/rpc/#/Info/unconfirmed_txs
 func (env *Environment) UnconfirmedTxs(_ *rpctypes.Context, limitPtr *int) (*ctypes.ResultUnconfirmedTxs, error) {
-	fmt.Println("= WS UnconfirmedTxs")
-	return nil, fmt.Errorf("UnconfirmedTxs not implemented")
+	client, err := env.abciClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.UnconfirmedTxs(context.TODO(), limitPtr)
 }
 
+// NumUnconfirmedTxs gets the number of unconfirmed transactions.
+// More: https://docs.cometbft.com/v0.38.x/rpc/#/Info/num_unconfirmed_txs
 func (env *Environment) NumUnconfirmedTxs(*rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, error) {
-	fmt.Println("= WS NumUnconfirmedTxs")
-	return nil, fmt.Errorf("NumUnconfirmedTxs not implemented")
+	client, err := env.abciClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.NumUnconfirmedTxs(context.TODO())
 }
 
 // BroadcastTxCommit returns with the responses from CheckTx and ExecTxResult.
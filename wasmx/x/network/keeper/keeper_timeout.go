@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"cosmossdk.io/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	mctx "github.com/loredanacirstea/wasmx/context"
@@ -16,13 +17,19 @@ import (
 // maybe only from the contract that the interval is for?
 func (k *Keeper) StartTimeout(goCtx context.Context, msg *types.MsgStartTimeoutRequest) (*types.MsgStartTimeoutResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
-	k.goRoutineGroup.Go(func() error {
-		err := k.startTimeoutInternalGoroutine(msg, ctx.ChainID())
-		if err != nil {
-			k.actionExecutor.GetLogger().Error(err.Error())
-		}
-		return nil
-	})
+	info := types.TimeoutInfo{
+		Sender:   msg.Sender,
+		Contract: msg.Contract,
+		Id:       msg.Id,
+		Args:     msg.Args,
+		Deadline: time.Now().Add(time.Duration(msg.Delay) * time.Millisecond).UnixMilli(),
+	}
+	// persisted, so the timeout can be re-armed after a node restart
+	err := k.setTimeoutInfo(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	k.armTimeout(info, ctx.ChainID())
 	return &types.MsgStartTimeoutResponse{}, nil
 }
 
@@ -32,6 +39,8 @@ func (k *Keeper) CancelTimeout(goCtx context.Context, msg *types.MsgCancelTimeou
 	resp := &types.MsgCancelTimeoutResponse{}
 
 	timeoutKey := TimeoutKey(ctx.ChainID(), msg.Sender, msg.Id)
+	k.deleteTimeoutInfo(ctx, timeoutKey)
+
 	cancelfn, err := mctx.GetTimeoutGoroutine(k.goContextParent, timeoutKey)
 	if err != nil || cancelfn == nil {
 		return resp, err
@@ -44,11 +53,69 @@ func (k *Keeper) CancelTimeout(goCtx context.Context, msg *types.MsgCancelTimeou
 	return &types.MsgCancelTimeoutResponse{}, nil
 }
 
+// RestoreTimeouts re-arms the persisted timeouts, after a node restart.
+// Timeouts whose deadline has passed are executed right away.
+func (k *Keeper) RestoreTimeouts(ctx sdk.Context) error {
+	chainId := ctx.ChainID()
+	infos, err := k.getTimeoutInfos(ctx, []byte(chainId+"_"))
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		timeoutKey := TimeoutKey(chainId, info.Sender, info.Id)
+		cancelfn, err := mctx.GetTimeoutGoroutine(k.goContextParent, timeoutKey)
+		if err != nil {
+			return err
+		}
+		// already running in this process
+		if cancelfn != nil {
+			continue
+		}
+		k.Logger(ctx).Info("re-arming timeout", "sender", info.Sender, "contract", info.Contract, "id", info.Id, "deadline", info.Deadline)
+		k.armTimeout(info, chainId)
+	}
+	return nil
+}
+
+// armTimeout starts the goroutine of a timeout. A running timeout with the same id is
+// replaced: it is canceled and its generation is no longer current, so it cannot
+// execute or remove the record of the new timeout.
+func (k *Keeper) armTimeout(info types.TimeoutInfo, chainId string) {
+	timeoutKey := TimeoutKey(chainId, info.Sender, info.Id)
+	prevcancel, err := mctx.GetTimeoutGoroutine(k.goContextParent, timeoutKey)
+	if err == nil && prevcancel != nil {
+		prevcancel()
+	}
+	goctx, cancel := context.WithCancel(k.goContextParent)
+	generation, err := mctx.SetTimeoutGoroutine(k.goContextParent, timeoutKey, cancel)
+	if err != nil {
+		cancel()
+		k.actionExecutor.GetLogger().Error("cannot start timeout", "error", err.Error())
+		return
+	}
+	k.goRoutineGroup.Go(func() error {
+		defer func() {
+			err := mctx.RemoveTimeoutGoroutineGeneration(k.goContextParent, timeoutKey, generation)
+			if err != nil {
+				k.actionExecutor.GetLogger().Error("error removing goroutine", "error", err.Error())
+			}
+		}()
+		err := k.startTimeoutInternalGoroutine(goctx, cancel, info, chainId, generation)
+		if err != nil {
+			k.actionExecutor.GetLogger().Error(err.Error())
+		}
+		return nil
+	})
+}
+
 func (k *Keeper) startTimeoutInternalGoroutine(
-	msg *types.MsgStartTimeoutRequest,
+	goctx context.Context,
+	cancel context.CancelFunc,
+	info types.TimeoutInfo,
 	chainId string,
+	generation uint64,
 ) error {
-	description := fmt.Sprintf("chain_id=%s, delay %dms, contract %s, args: %s ", chainId, msg.Delay, msg.Contract, string(msg.Args))
+	description := fmt.Sprintf("chain_id=%s, deadline %d, contract %s, args: %s ", chainId, info.Deadline, info.Contract, string(info.Args))
 
 	select {
 	case <-k.goContextParent.Done():
@@ -58,11 +125,6 @@ func (k *Keeper) startTimeoutInternalGoroutine(
 		// continue
 	}
 
-	timeoutKey := TimeoutKey(chainId, msg.Sender, msg.Id)
-	goctx, cancel := context.WithCancel(k.goContextParent)
-
-	mctx.SetTimeoutGoroutine(k.goContextParent, timeoutKey, cancel)
-
 	// these channels need to be buffered to prevent the goroutine below from hanging indefinitely
 	intervalEnded := make(chan bool, 1)
 	errCh := make(chan error, 1)
@@ -70,7 +132,7 @@ func (k *Keeper) startTimeoutInternalGoroutine(
 	defer close(errCh)
 	go func() {
 		k.actionExecutor.GetLogger().Debug("eventual execution triggered", "description", description)
-		err := k.startTimeoutInternal(goctx, description, msg, chainId)
+		err := k.startTimeoutInternal(goctx, description, info, chainId, generation)
 		if err != nil {
 			k.actionExecutor.GetLogger().Error("eventual execution failed", "err", err, "description", description)
 			errCh <- err
@@ -93,10 +155,15 @@ func (k *Keeper) startTimeoutInternalGoroutine(
 func (k *Keeper) startTimeoutInternal(
 	goctx context.Context,
 	description string,
-	msg *types.MsgStartTimeoutRequest,
+	info types.TimeoutInfo,
 	chainId string,
+	generation uint64,
 ) error {
-	duration := time.Duration(msg.Delay) * time.Millisecond
+	duration := time.Until(time.UnixMilli(info.Deadline))
+	if duration < 0 {
+		duration = 0
+	}
+	timeoutKey := TimeoutKey(chainId, info.Sender, info.Id)
 
 	// either sleep action finishes first or the goroutine context is canceled or the parent context is finished (node is stopping)
 	select {
@@ -112,11 +179,25 @@ func (k *Keeper) startTimeoutInternal(
 	k.actionExecutor.GetLogger().Debug("eventual execution started", "description", description)
 
 	cb := func(goctx context.Context) (any, error) {
+		// checked inside the execution, which is serialized with the transactions
+		// that can start or cancel the timeout
+		if !mctx.IsTimeoutGeneration(k.goContextParent, timeoutKey, generation) {
+			k.actionExecutor.GetLogger().Debug("timeout was replaced or canceled, we do not execute it", "description", description)
+			return nil, nil
+		}
+		// unregistered before execution, so the contract can start a timeout with the same id
+		// without canceling this execution
+		err := mctx.RemoveTimeoutGoroutineGeneration(k.goContextParent, timeoutKey, generation)
+		if err != nil {
+			return nil, err
+		}
 		ctx := sdk.UnwrapSDKContext(goctx)
+		// removed before execution, so the contract can start a timeout with the same id
+		k.deleteTimeoutInfo(ctx, timeoutKey)
 		execmsg := &types.MsgExecuteContract{
-			Sender:   msg.Sender,
-			Contract: msg.Contract,
-			Msg:      msg.Args,
+			Sender:   info.Sender,
+			Contract: info.Contract,
+			Msg:      info.Args,
 		}
 		res, err := k.ExecuteEntryPoint(ctx, wasmxtypes.ENTRY_POINT_TIMED, execmsg)
 		if err != nil {
@@ -133,6 +214,18 @@ func (k *Keeper) startTimeoutInternal(
 	bapp := k.actionExecutor.GetBaseApp()
 	_, err := k.actionExecutor.Execute(goctx, bapp.LastBlockHeight(), sdk.ExecModeFinalize, cb)
 	if err != nil {
+		// the failed execution was not committed, so we still need to remove the timeout
+		_, err2 := k.actionExecutor.Execute(goctx, bapp.LastBlockHeight(), sdk.ExecModeFinalize, func(goctx context.Context) (any, error) {
+			// a timeout with the same id was started after this one
+			if cancelfn, _ := mctx.GetTimeoutGoroutine(k.goContextParent, timeoutKey); cancelfn != nil {
+				return nil, nil
+			}
+			k.deleteTimeoutInfo(sdk.UnwrapSDKContext(goctx), timeoutKey)
+			return nil, nil
+		})
+		if err2 != nil {
+			k.actionExecutor.GetLogger().Error("could not remove timeout", "error", err2.Error(), "description", description)
+		}
 		return err
 	}
 	return nil
@@ -141,3 +234,35 @@ func (k *Keeper) startTimeoutInternal(
 func TimeoutKey(chainId string, sender string, id string) string {
 	return fmt.Sprintf("%s_%s_%s", chainId, sender, id)
 }
+
+func (k *Keeper) timeoutStore(ctx sdk.Context) prefix.Store {
+	return k.wasmxKeeper.ContractStore(ctx, wasmxtypes.ContractStorageType_SingleConsensus, wasmxtypes.KeyTimeoutPrefix)
+}
+
+func (k *Keeper) setTimeoutInfo(ctx sdk.Context, info types.TimeoutInfo) error {
+	bz, err := k.cdc.Marshal(&info)
+	if err != nil {
+		return err
+	}
+	k.timeoutStore(ctx).Set([]byte(TimeoutKey(ctx.ChainID(), info.Sender, info.Id)), bz)
+	return nil
+}
+
+func (k *Keeper) deleteTimeoutInfo(ctx sdk.Context, timeoutKey string) {
+	k.timeoutStore(ctx).Delete([]byte(timeoutKey))
+}
+
+func (k *Keeper) getTimeoutInfos(ctx sdk.Context, keyPrefix []byte) ([]types.TimeoutInfo, error) {
+	iter := prefix.NewStore(k.timeoutStore(ctx), keyPrefix).Iterator(nil, nil)
+	defer iter.Close()
+	infos := make([]types.TimeoutInfo, 0)
+	for ; iter.Valid(); iter.Next() {
+		var info types.TimeoutInfo
+		err := k.cdc.Unmarshal(iter.Value(), &info)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
@@ -79,3 +79,21 @@ func (k *Keeper) ContractCall(goCtx context.Context, req *types.QueryContractCal
 
 	return &types.QueryContractCallResponse{Data: resp}, nil
 }
+
+// PendingTimeouts lists the timeouts scheduled by a contract that have not been executed or canceled yet
+func (k *Keeper) PendingTimeouts(goCtx context.Context, req *types.QueryPendingTimeoutsRequest) (*types.QueryPendingTimeoutsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	contractAddress, err := k.wasmxKeeper.GetAddressOrRole(ctx, req.Contract)
+	if err != nil {
+		return nil, sdkerr.Wrap(err, "contract")
+	}
+	timeouts, err := k.getTimeoutInfos(ctx, []byte(TimeoutKey(ctx.ChainID(), contractAddress.String(), "")))
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryPendingTimeoutsResponse{Timeouts: timeouts}, nil
+}
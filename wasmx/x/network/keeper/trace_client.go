@@ -0,0 +1,188 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	wasmxtypes "github.com/loredanacirstea/wasmx/x/wasmx/types"
+)
+
+// defaultTraceTimeout is the debug_trace* timeout, same as geth.
+// It is checked before executing each transaction replayed for the trace.
+const defaultTraceTimeout = 5 * time.Second
+
+// TraceTx re-executes a transaction with a debug tracer and returns the trace.
+// The transaction runs on the state of the previous block, after the transactions
+// preceding it in its block. The ante handlers run before the messages of each transaction,
+// so fees and sequences are deducted like in the block. The begin/end blockers are not replayed.
+func (c *ABCIClient) TraceTx(goCtx context.Context, hash []byte, config wasmxtypes.TraceConfig) (json.RawMessage, error) {
+	resTx, err := c.Tx(goCtx, hash, false)
+	if err != nil {
+		return nil, err
+	}
+	if resTx.Height <= 1 {
+		return nil, fmt.Errorf("cannot trace transactions from the genesis block")
+	}
+	resBlock, err := c.Block(goCtx, &resTx.Height)
+	if err != nil {
+		return nil, err
+	}
+	if int(resTx.Index) >= len(resBlock.Block.Txs) {
+		return nil, fmt.Errorf("tx index %d out of range for block %d", resTx.Index, resTx.Height)
+	}
+
+	goCtx, cancel, err := traceTimeoutContext(goCtx, config)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	sdkCtx, err := c.bapp.CreateQueryContext(resTx.Height-1, false)
+	if err != nil {
+		return nil, err
+	}
+	sdkCtx = sdkCtx.WithBlockHeader(*resBlock.Block.Header.ToProto())
+
+	for _, txbz := range resBlock.Block.Txs[:resTx.Index] {
+		if err := goCtx.Err(); err != nil {
+			return nil, fmt.Errorf("trace interrupted: %w", err)
+		}
+		tx, txCtx, err := c.traceTxContext(sdkCtx, txbz)
+		if err != nil {
+			return nil, err
+		}
+		txCtx, err = c.runTraceAnte(txCtx, tx)
+		if err != nil {
+			// transactions failing the ante handlers do not change the state
+			continue
+		}
+		// failed messages only revert their own state changes
+		cacheCtx, write := txCtx.CacheContext()
+		if err := c.runTraceMsgs(goCtx, cacheCtx, tx.GetMsgs()); err == nil {
+			write()
+		}
+	}
+
+	tx, txCtx, err := c.traceTxContext(sdkCtx, resBlock.Block.Txs[resTx.Index])
+	if err != nil {
+		return nil, err
+	}
+	txCtx, err = c.runTraceAnte(txCtx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("tx failed the ante handler: %w", err)
+	}
+	return c.mapp.GetWasmxKeeper().TraceExecution(txCtx, config, func(ctx sdk.Context) error {
+		return c.runTraceMsgs(goCtx, ctx, tx.GetMsgs())
+	})
+}
+
+// TraceCall executes a message with a debug tracer on the state of the given block
+// and returns the trace. The state changes are discarded.
+func (c *ABCIClient) TraceCall(goCtx context.Context, height int64, msg sdk.Msg, config wasmxtypes.TraceConfig) (json.RawMessage, error) {
+	goCtx, cancel, err := traceTimeoutContext(goCtx, config)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	sdkCtx, err := c.bapp.CreateQueryContext(height, false)
+	if err != nil {
+		return nil, err
+	}
+	sdkCtx = sdkCtx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	return c.mapp.GetWasmxKeeper().TraceExecution(sdkCtx, config, func(ctx sdk.Context) error {
+		return c.runTraceMsgs(goCtx, ctx, []sdk.Msg{msg})
+	})
+}
+
+// traceTxContext decodes the transaction and prepares its execution context
+func (c *ABCIClient) traceTxContext(sdkCtx sdk.Context, txbz cmttypes.Tx) (sdk.Tx, sdk.Context, error) {
+	tx, err := c.mapp.TxConfig().TxDecoder()(txbz)
+	if err != nil {
+		return nil, sdkCtx, fmt.Errorf("failed to decode tx %X: %w", txbz.Hash(), err)
+	}
+	var gasMeter storetypes.GasMeter = storetypes.NewInfiniteGasMeter()
+	if feeTx, ok := tx.(sdk.FeeTx); ok {
+		gasMeter = storetypes.NewGasMeter(feeTx.GetGas())
+	}
+	return tx, sdkCtx.WithTxBytes(txbz).WithGasMeter(gasMeter), nil
+}
+
+// runTraceAnte runs the ante handlers of the transaction like the BaseApp does in
+// FinalizeBlock: the state changes are kept only if all the handlers succeed
+func (c *ABCIClient) runTraceAnte(sdkCtx sdk.Context, tx sdk.Tx) (sdk.Context, error) {
+	anteHandler := c.mapp.GetBaseApp().AnteHandler()
+	if anteHandler == nil {
+		return sdkCtx, nil
+	}
+	cacheCtx, write := sdkCtx.CacheContext()
+	newCtx, err := anteHandler(cacheCtx, tx, false)
+	if err != nil {
+		return sdkCtx, err
+	}
+	write()
+	if newCtx.IsZero() {
+		return sdkCtx, nil
+	}
+	// the ante handlers may set a new gas meter, but the state is the parent one
+	return newCtx.WithMultiStore(sdkCtx.MultiStore()).WithEventManager(sdk.NewEventManager()), nil
+}
+
+// runTraceMsgs executes the messages like the BaseApp does for a simulated transaction,
+// with the BeginTransaction/EndTransaction hooks
+func (c *ABCIClient) runTraceMsgs(goCtx context.Context, sdkCtx sdk.Context, msgs []sdk.Msg) (err error) {
+	goCtx = context.WithValue(goCtx, sdk.SdkContextKey, sdkCtx)
+	bapp := c.mapp.GetBaseApp()
+	if bapp.BeginTransaction != nil {
+		if err := bapp.BeginTransaction(goCtx, sdk.ExecModeSimulate, sdkCtx.TxBytes()); err != nil {
+			c.logger.Error("BeginTransaction", "err", err)
+		}
+	}
+	defer func() {
+		// recover from out-of-gas panic
+		if r := recover(); r != nil {
+			switch rType := r.(type) {
+			case storetypes.ErrorOutOfGas:
+				err = fmt.Errorf("out of gas in location: %v", rType.Descriptor)
+			default:
+				err = fmt.Errorf("execution panic: %v", r)
+			}
+		}
+		if bapp.EndTransaction != nil {
+			if err2 := bapp.EndTransaction(goCtx, sdk.ExecModeSimulate, sdk.GasInfo{}, nil, nil, err); err2 != nil {
+				c.logger.Error("EndTransaction", "err", err2)
+			}
+		}
+	}()
+
+	for _, msg := range msgs {
+		handler := c.mapp.MsgServiceRouter().Handler(msg)
+		if handler == nil {
+			return fmt.Errorf("no message handler found for %s", sdk.MsgTypeURL(msg))
+		}
+		if _, err := handler(sdkCtx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func traceTimeoutContext(goCtx context.Context, config wasmxtypes.TraceConfig) (context.Context, context.CancelFunc, error) {
+	timeout := defaultTraceTimeout
+	if config.Timeout != nil {
+		var err error
+		timeout, err = time.ParseDuration(*config.Timeout)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid trace timeout %q: %w", *config.Timeout, err)
+		}
+	}
+	goCtx, cancel := context.WithTimeout(goCtx, timeout)
+	return goCtx, cancel, nil
+}
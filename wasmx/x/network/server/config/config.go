@@ -7,6 +7,7 @@ const (
 	DefaultNetworkAddress = "0.0.0.0:" + DefaultNetworkPort
 	DefaultNetworkIps     = DefaultNetworkAddress
 	DefaultNodeId         = "0"
+	DefaultBlockSync      = false
 
 	// DefaultMaxOpenConnections represents the amount of open connections (unlimited = 0)
 	DefaultMaxOpenConnections = 0
@@ -27,6 +28,8 @@ type NetworkConfig struct {
 	// comma separated list of values for each initialized chain
 	Id            string   `mapstructure:"id"`
 	InitialChains []string `mapstructure:"initial-chains"`
+	// catch up with the peers' latest blocks before starting the node
+	BlockSync bool `mapstructure:"block-sync"`
 }
 
 // DefaultEVMConfig returns the default EVM configuration
@@ -39,6 +42,7 @@ func DefaultNetworkConfigConfig() *NetworkConfig {
 		Ips:                DefaultNetworkIps,
 		Id:                 DefaultNodeId,
 		InitialChains:      DefaultInitialChains,
+		BlockSync:          DefaultBlockSync,
 	}
 }
 
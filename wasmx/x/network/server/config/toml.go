@@ -28,4 +28,7 @@ id = "{{ .Network.Id }}"
 # Comma separated list of types of chains. E.g. "mythos" or "mythos,level0"
 initial-chains = [{{ range .Network.InitialChains }}{{ printf "%q, " . }}{{end}}]
 
+# BlockSync replays the blocks finalized by peers while the node was offline, before starting consensus.
+block-sync = {{ .Network.BlockSync }}
+
 `
@@ -9,4 +9,5 @@ const (
 	NetworkIps                = "network.ips"
 	NetworkNodeId             = "network.id"
 	NetworkInitialChains      = "network.initial-chains"
+	NetworkBlockSync          = "network.block-sync"
 )
@@ -133,6 +133,11 @@ func InitConsensusContract(
 func StartNode(mythosapp mcfg.MythosApp, logger log.Logger, networkServer mcfg.NetworkKeeper) error {
 	cb := func(goctx context.Context) (any, error) {
 		ctx := sdk.UnwrapSDKContext(goctx)
+		// re-arm timeouts scheduled before the node was stopped
+		err := networkServer.RestoreTimeouts(ctx)
+		if err != nil {
+			return nil, err
+		}
 		msg := []byte(fmt.Sprintf(`{"RunHook":{"hook":"%s","data":""}}`, wasmxtypes.HOOK_START_NODE))
 		res, err := networkServer.ExecuteContract(ctx, &types.MsgExecuteContract{
 			Sender:   wasmxtypes.ROLE_HOOKS_NONC,
@@ -0,0 +1,113 @@
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cometbftenc "github.com/cometbft/cometbft/crypto/encoding"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// BlockSyncEntry is a finalized block, as sent by a block sync provider
+type BlockSyncEntry struct {
+	Entry  []byte `json:"entry"`  // BlockEntry
+	Commit []byte `json:"commit"` // cmtproto.Commit for this block
+}
+
+// BlockFromEntry rebuilds the cometbft block from a stored block entry.
+// It also returns the proposal hash.
+func BlockFromEntry(cdc codec.JSONCodec, chainId string, entry *BlockEntry) (*cmttypes.Block, []byte, error) {
+	var bmeta RequestProcessProposalWithMetaInfo
+	err := cdc.UnmarshalJSON(entry.Data, &bmeta)
+	if err != nil {
+		return nil, nil, errorsmod.Wrapf(err, "failed to decode RequestProcessProposalWithMetaInfo")
+	}
+	b := bmeta.Request
+
+	var header cmttypes.Header
+	err = json.Unmarshal(entry.Header, &header)
+	if err != nil {
+		return nil, nil, errorsmod.Wrapf(err, "failed to decode Header")
+	}
+
+	var lastCommit cmttypes.Commit
+	err = json.Unmarshal(entry.LastCommit, &lastCommit)
+	if err != nil {
+		return nil, nil, errorsmod.Wrapf(err, "failed to decode Commit")
+	}
+
+	var evidence cmttypes.EvidenceData
+	err = json.Unmarshal(entry.Evidence, &evidence)
+	if err != nil {
+		return nil, nil, errorsmod.Wrapf(err, "failed to decode EvidenceData")
+	}
+
+	txs := make([]cmttypes.Tx, len(b.Txs))
+	for i, tx := range b.Txs {
+		txs[i] = cmttypes.Tx(tx)
+	}
+	block := cmttypes.MakeBlock(b.Height, txs, &lastCommit, evidence.Evidence)
+	block.ChainID = chainId
+	block.AppHash = header.AppHash
+	block.ConsensusHash = header.ConsensusHash
+	block.Header = header
+	block.LastBlockID = header.LastBlockID
+	block.LastResultsHash = header.LastResultsHash
+	block.Time = header.Time
+	block.ProposerAddress = header.ProposerAddress
+	return block, b.Hash, nil
+}
+
+// BlockResultFromEntry decodes the FinalizeBlock response saved with the block entry
+func BlockResultFromEntry(entry *BlockEntry) (*abci.ResponseFinalizeBlock, error) {
+	var result abci.ResponseFinalizeBlock
+	err := json.Unmarshal(entry.Result, &result)
+	if err != nil {
+		return nil, errorsmod.Wrapf(err, "failed to decode ResponseFinalizeBlock")
+	}
+	return &result, nil
+}
+
+// ValidatorsFromEntry decodes the validator set that signed the block entry
+func ValidatorsFromEntry(cdc codec.Codec, entry *BlockEntry) ([]*cmttypes.Validator, error) {
+	var bmeta TendermintValidators
+	err := cdc.UnmarshalJSON(entry.ValidatorInfo, &bmeta)
+	if err != nil {
+		return nil, errorsmod.Wrapf(err, "failed to decode cmttypes.Validator")
+	}
+
+	cmtvals := make([]*cmttypes.Validator, len(bmeta.Validators))
+	for i, val := range bmeta.Validators {
+		var pubkey cryptotypes.PubKey
+		err = cdc.InterfaceRegistry().UnpackAny(val.PubKey, &pubkey)
+		if err != nil {
+			return nil, errorsmod.Wrapf(err, "failed to convert unpack cryptotypes.PubKey")
+		}
+		tmPk, err := cryptocodec.ToCmtProtoPublicKey(pubkey)
+		if err != nil {
+			return nil, errorsmod.Wrapf(err, "failed to convert cryptotypes.PubKey to proto")
+		}
+		tmPk2, err := cometbftenc.PubKeyFromProto(tmPk)
+		if err != nil {
+			return nil, errorsmod.Wrapf(err, "failed to convert cryptotypes.PubKey from proto")
+		}
+		valaddr, err := hex.DecodeString(val.HexAddress)
+		if err != nil {
+			return nil, errorsmod.Wrapf(err, "failed to decode hex address")
+		}
+		cmtvals[i] = &cmttypes.Validator{
+			Address:          valaddr,
+			PubKey:           tmPk2,
+			VotingPower:      val.VotingPower,
+			ProposerPriority: val.ProposerPriority,
+		}
+	}
+	return cmtvals, nil
+}
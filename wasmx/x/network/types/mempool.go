@@ -0,0 +1,72 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// MempoolTx is a pending transaction, as kept by the consensus contract
+type MempoolTx struct {
+	Tx     []byte `json:"tx"`
+	Gas    uint64 `json:"gas"`
+	Leader string `json:"leader"` // chain id of the leader chain, for atomic crosschain transactions
+}
+
+// MempoolTxs are the pending transactions, in the order in which they were added to the mempool.
+// The consensus contract encodes them as a map, by transaction hash.
+type MempoolTxs []MempoolTx
+
+func (m *MempoolTxs) UnmarshalJSON(bz []byte) error {
+	if bytes.Equal(bytes.TrimSpace(bz), []byte("null")) {
+		*m = MempoolTxs{}
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("mempool transactions must be a json object")
+	}
+	txs := MempoolTxs{}
+	for dec.More() {
+		// the transaction hash
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		var tx MempoolTx
+		if err := dec.Decode(&tx); err != nil {
+			return err
+		}
+		txs = append(txs, tx)
+	}
+	*m = txs
+	return nil
+}
+
+// Mempool is the consensus contract's mempool
+type Mempool struct {
+	Map MempoolTxs `json:"temp"`
+}
+
+// Txs returns the pending transactions, in mempool order
+func (m *Mempool) Txs() cmttypes.Txs {
+	txs := make(cmttypes.Txs, len(m.Map))
+	for i, mtx := range m.Map {
+		txs[i] = mtx.Tx
+	}
+	return txs
+}
+
+// TotalBytes returns the size of all pending transactions
+func (m *Mempool) TotalBytes() int64 {
+	total := int64(0)
+	for _, mtx := range m.Map {
+		total += int64(len(mtx.Tx))
+	}
+	return total
+}
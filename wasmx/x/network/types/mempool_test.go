@@ -0,0 +1,32 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/loredanacirstea/wasmx/x/network/types"
+)
+
+func TestMempoolDecode(t *testing.T) {
+	// the consensus contract's map is ordered by insertion, not by key
+	data := []byte(`{"tempsize":5,"temp":{"zz":{"tx":"AQID","gas":100,"leader":""},"aa":{"tx":"BAU=","gas":200,"leader":"leader_1"}},"tobesent":{}}`)
+	var mempool types.Mempool
+	err := json.Unmarshal(data, &mempool)
+	require.NoError(t, err)
+	require.Len(t, mempool.Map, 2)
+	require.Equal(t, []byte{1, 2, 3}, []byte(mempool.Txs()[0]))
+	require.Equal(t, []byte{4, 5}, []byte(mempool.Txs()[1]))
+	require.Equal(t, uint64(200), mempool.Map[1].Gas)
+	require.Equal(t, "leader_1", mempool.Map[1].Leader)
+	require.Equal(t, int64(5), mempool.TotalBytes())
+
+	mempool = types.Mempool{}
+	err = json.Unmarshal([]byte(`{"tempsize":0,"temp":{}}`), &mempool)
+	require.NoError(t, err)
+	require.Len(t, mempool.Txs(), 0)
+
+	err = json.Unmarshal([]byte(`{"temp":[]}`), &mempool)
+	require.Error(t, err)
+}
@@ -215,55 +215,192 @@ func (m *QueryContractCallResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_QueryContractCallResponse proto.InternalMessageInfo
 
+// TimeoutInfo is a delayed ENTRY_POINT_TIMED execution, persisted
+// so it can be re-armed after a node restart
+type TimeoutInfo struct {
+	// Sender is the contract that scheduled the timeout
+	Sender string `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	// Contract is the contract on which the timed entry point is called
+	Contract string `protobuf:"bytes,2,opt,name=contract,proto3" json:"contract,omitempty"`
+	Id       string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Args     []byte `protobuf:"bytes,4,opt,name=args,proto3" json:"args,omitempty"`
+	// Deadline is the absolute unix timestamp in milliseconds
+	Deadline int64 `protobuf:"varint,5,opt,name=deadline,proto3" json:"deadline,omitempty"`
+}
+
+func (m *TimeoutInfo) Reset()         { *m = TimeoutInfo{} }
+func (m *TimeoutInfo) String() string { return proto.CompactTextString(m) }
+func (*TimeoutInfo) ProtoMessage()    {}
+func (*TimeoutInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b6a247dd5f5b796, []int{4}
+}
+func (m *TimeoutInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimeoutInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimeoutInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TimeoutInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeoutInfo.Merge(m, src)
+}
+func (m *TimeoutInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimeoutInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeoutInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeoutInfo proto.InternalMessageInfo
+
+// QueryPendingTimeoutsRequest is the request type for the
+// Query/PendingTimeouts RPC method
+type QueryPendingTimeoutsRequest struct {
+	// Contract is the address of the contract that scheduled the timeouts
+	Contract string `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
+}
+
+func (m *QueryPendingTimeoutsRequest) Reset()         { *m = QueryPendingTimeoutsRequest{} }
+func (m *QueryPendingTimeoutsRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryPendingTimeoutsRequest) ProtoMessage()    {}
+func (*QueryPendingTimeoutsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b6a247dd5f5b796, []int{5}
+}
+func (m *QueryPendingTimeoutsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryPendingTimeoutsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryPendingTimeoutsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryPendingTimeoutsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryPendingTimeoutsRequest.Merge(m, src)
+}
+func (m *QueryPendingTimeoutsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryPendingTimeoutsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryPendingTimeoutsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryPendingTimeoutsRequest proto.InternalMessageInfo
+
+// QueryPendingTimeoutsResponse is the response type for the
+// Query/PendingTimeouts RPC method
+type QueryPendingTimeoutsResponse struct {
+	Timeouts []TimeoutInfo `protobuf:"bytes,1,rep,name=timeouts,proto3" json:"timeouts"`
+}
+
+func (m *QueryPendingTimeoutsResponse) Reset()         { *m = QueryPendingTimeoutsResponse{} }
+func (m *QueryPendingTimeoutsResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryPendingTimeoutsResponse) ProtoMessage()    {}
+func (*QueryPendingTimeoutsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b6a247dd5f5b796, []int{6}
+}
+func (m *QueryPendingTimeoutsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryPendingTimeoutsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryPendingTimeoutsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryPendingTimeoutsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryPendingTimeoutsResponse.Merge(m, src)
+}
+func (m *QueryPendingTimeoutsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryPendingTimeoutsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryPendingTimeoutsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryPendingTimeoutsResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*QueryMultiChainRequest)(nil), "mythos.network.v1.QueryMultiChainRequest")
 	proto.RegisterType((*QueryMultiChainResponse)(nil), "mythos.network.v1.QueryMultiChainResponse")
 	proto.RegisterType((*QueryContractCallRequest)(nil), "mythos.network.v1.QueryContractCallRequest")
 	proto.RegisterType((*QueryContractCallResponse)(nil), "mythos.network.v1.QueryContractCallResponse")
+	proto.RegisterType((*TimeoutInfo)(nil), "mythos.network.v1.TimeoutInfo")
+	proto.RegisterType((*QueryPendingTimeoutsRequest)(nil), "mythos.network.v1.QueryPendingTimeoutsRequest")
+	proto.RegisterType((*QueryPendingTimeoutsResponse)(nil), "mythos.network.v1.QueryPendingTimeoutsResponse")
 }
 
 func init() { proto.RegisterFile("mythos/network/v1/query.proto", fileDescriptor_3b6a247dd5f5b796) }
 
 var fileDescriptor_3b6a247dd5f5b796 = []byte{
-	// 607 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x3f, 0x6f, 0xd3, 0x40,
-	0x1c, 0xb5, 0xd3, 0x3f, 0xd0, 0x23, 0x02, 0x71, 0x42, 0xc5, 0x8d, 0xc0, 0x89, 0x2c, 0x86, 0x10,
-	0xc0, 0x97, 0x14, 0xc1, 0xc0, 0x46, 0x02, 0x42, 0x1d, 0x3a, 0xe0, 0x91, 0x25, 0xba, 0xd8, 0x57,
-	0xc7, 0xaa, 0x7d, 0xe7, 0xfa, 0xce, 0x49, 0xa3, 0x28, 0x42, 0x62, 0x62, 0x44, 0xe2, 0x0b, 0x64,
-	0x44, 0x4c, 0x0c, 0x7c, 0x88, 0x8c, 0x95, 0x58, 0x98, 0x0a, 0x4d, 0x18, 0xf8, 0x0c, 0x4c, 0xc8,
-	0x67, 0x27, 0xa4, 0x0d, 0xad, 0xd2, 0xc9, 0xbe, 0xdf, 0x7b, 0xf7, 0xfc, 0x7e, 0xf7, 0x7e, 0x67,
-	0x70, 0x37, 0xe8, 0x89, 0x36, 0xe3, 0x88, 0x12, 0xd1, 0x65, 0xd1, 0x3e, 0xea, 0xd4, 0xd0, 0x41,
-	0x4c, 0xa2, 0x9e, 0x19, 0x46, 0x4c, 0x30, 0x78, 0x33, 0x85, 0xcd, 0x0c, 0x36, 0x3b, 0xb5, 0xc2,
-	0x2d, 0x97, 0xb9, 0x4c, 0xa2, 0x28, 0x79, 0x4b, 0x89, 0x85, 0x2d, 0x97, 0x31, 0xd7, 0x27, 0x48,
-	0xae, 0x5a, 0xf1, 0x1e, 0xc2, 0x34, 0xd3, 0x28, 0xdc, 0xc9, 0x20, 0x1c, 0x7a, 0x08, 0x53, 0xca,
-	0x04, 0x16, 0x1e, 0xa3, 0x3c, 0x43, 0x75, 0x9b, 0xf1, 0x80, 0x71, 0xd4, 0xc2, 0x9c, 0xa0, 0x4e,
-	0xad, 0x45, 0x04, 0xae, 0x21, 0x9b, 0x79, 0x34, 0xc3, 0x2b, 0xf3, 0xb8, 0xb4, 0x36, 0x63, 0x85,
-	0xd8, 0xf5, 0xa8, 0x14, 0x9b, 0x6a, 0x2d, 0x36, 0x63, 0xc7, 0x5c, 0xb0, 0x20, 0xc5, 0x8d, 0xb7,
-	0x60, 0xf3, 0x75, 0xa2, 0xb0, 0x1b, 0xfb, 0xc2, 0x6b, 0xb4, 0xb1, 0x47, 0x2d, 0x72, 0x10, 0x13,
-	0x2e, 0xe0, 0x3d, 0x70, 0x3d, 0x48, 0x8a, 0x4d, 0x3b, 0xa9, 0x36, 0x3d, 0x47, 0x53, 0x4b, 0x6a,
-	0x79, 0xc3, 0xca, 0x07, 0x33, 0xea, 0x8e, 0x03, 0x9f, 0x00, 0x20, 0x1d, 0x34, 0x1d, 0x2c, 0xb0,
-	0x96, 0x2b, 0xa9, 0xe5, 0x7c, 0x7d, 0xf3, 0xcf, 0x71, 0x11, 0x5a, 0xb8, 0xdb, 0x60, 0x54, 0x44,
-	0xd8, 0x16, 0xbb, 0x84, 0x73, 0xec, 0x12, 0x6b, 0x43, 0x32, 0x5f, 0x60, 0x81, 0x9f, 0x5d, 0x7d,
-	0x3f, 0x2c, 0xaa, 0xbf, 0x87, 0x45, 0xc5, 0x78, 0x09, 0x6e, 0x2f, 0x18, 0xe0, 0x21, 0xa3, 0x9c,
-	0xc0, 0x0a, 0x58, 0x95, 0xaa, 0xea, 0x85, 0xaa, 0x92, 0x63, 0x7c, 0xc9, 0x01, 0x4d, 0xea, 0x4c,
-	0xe1, 0x06, 0xf6, 0xfd, 0xcb, 0xb5, 0xb2, 0x09, 0xd6, 0x39, 0xa1, 0x0e, 0x89, 0x64, 0x1b, 0x1b,
-	0x56, 0xb6, 0x82, 0x1a, 0xb8, 0x82, 0x1d, 0x27, 0x22, 0x9c, 0x6b, 0x2b, 0x12, 0x98, 0x2e, 0xcf,
-	0x34, 0xbf, 0xba, 0x64, 0xf3, 0x10, 0x83, 0xb5, 0xbd, 0x98, 0x3a, 0x5c, 0x5b, 0x2b, 0xad, 0x94,
-	0xaf, 0x6d, 0x6f, 0x99, 0x69, 0x9e, 0x66, 0x92, 0xa7, 0x99, 0x25, 0x69, 0x36, 0x98, 0x47, 0xeb,
-	0xd5, 0xd1, 0x71, 0x51, 0xf9, 0xfc, 0xa3, 0x58, 0x76, 0x3d, 0xd1, 0x8e, 0x5b, 0xa6, 0xcd, 0x02,
-	0x94, 0x85, 0x9f, 0x3e, 0x1e, 0x71, 0x67, 0x1f, 0x89, 0x5e, 0x48, 0xb8, 0xdc, 0xc0, 0xad, 0x54,
-	0x19, 0x1a, 0x20, 0xef, 0x90, 0x30, 0xf1, 0x4f, 0x6d, 0x8f, 0x70, 0x6d, 0xbd, 0xb4, 0x92, 0xf4,
-	0x3b, 0x5f, 0x33, 0x5e, 0x81, 0xad, 0xff, 0x9c, 0xd8, 0xe5, 0xcf, 0x7e, 0xfb, 0x24, 0x07, 0xd6,
-	0xa4, 0x12, 0xfc, 0xaa, 0x82, 0xfc, 0xbc, 0x1c, 0x7c, 0x60, 0x2e, 0xdc, 0x16, 0xf3, 0xbc, 0x98,
-	0x0a, 0x0f, 0x97, 0x23, 0xa7, 0x0e, 0x8d, 0x9d, 0x77, 0xdf, 0x7e, 0x7d, 0xcc, 0x35, 0xe0, 0xf3,
-	0xf9, 0xd9, 0xee, 0x9f, 0xce, 0x79, 0x80, 0xec, 0x6c, 0x2b, 0xea, 0x67, 0xa1, 0x0d, 0x90, 0x8d,
-	0x7d, 0x1f, 0xf5, 0xff, 0x05, 0x37, 0x80, 0x43, 0x15, 0xdc, 0x38, 0x33, 0x84, 0xf0, 0xfe, 0x79,
-	0x66, 0x16, 0x6e, 0x4a, 0xa1, 0xb2, 0x0c, 0x35, 0x73, 0xfd, 0x54, 0xba, 0xae, 0x42, 0xf3, 0x42,
-	0xd7, 0x89, 0xad, 0x53, 0x16, 0xeb, 0xd6, 0xe8, 0x44, 0x57, 0x3e, 0x8d, 0x75, 0x65, 0x34, 0xd6,
-	0xd5, 0xa3, 0xb1, 0xae, 0xfe, 0x1c, 0xeb, 0xea, 0x87, 0x89, 0xae, 0x1c, 0x4d, 0x74, 0xe5, 0xfb,
-	0x44, 0x57, 0xde, 0x54, 0xe7, 0x66, 0xc4, 0x67, 0x11, 0x71, 0x30, 0xc5, 0xb6, 0x17, 0x71, 0x41,
-	0x30, 0xea, 0x62, 0x1e, 0x1c, 0xa2, 0xc3, 0xd9, 0x37, 0xe5, 0xc4, 0xb4, 0xd6, 0xe5, 0x2f, 0xe0,
-	0xf1, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xa2, 0x1d, 0x3c, 0x98, 0xf1, 0x04, 0x00, 0x00,
+	// 743 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xcf, 0x6f, 0xdb, 0x36,
+	0x14, 0x96, 0xfc, 0x23, 0x4b, 0x18, 0x23, 0xc1, 0x88, 0x21, 0x53, 0xbc, 0x4c, 0x36, 0x84, 0x1d,
+	0xbc, 0x6c, 0x13, 0xe3, 0x0c, 0x1b, 0xb0, 0x9d, 0x36, 0x7b, 0xc3, 0x90, 0x43, 0x80, 0x4d, 0xd8,
+	0x69, 0x97, 0x8c, 0x96, 0x18, 0x85, 0x88, 0x44, 0x3a, 0x22, 0x95, 0x1f, 0x08, 0x82, 0x61, 0x3b,
+	0xed, 0x58, 0xa0, 0xff, 0x40, 0x8e, 0x45, 0x4f, 0x3d, 0xf4, 0x8f, 0xc8, 0x31, 0x40, 0x0f, 0xed,
+	0x29, 0x6d, 0x9d, 0x1e, 0xfa, 0x37, 0xf4, 0x54, 0x88, 0xa2, 0x5d, 0x39, 0x76, 0x02, 0xb7, 0x27,
+	0x89, 0xfc, 0x1e, 0x3f, 0x7e, 0xef, 0x7b, 0xef, 0x11, 0x7c, 0x1e, 0x9f, 0xc8, 0x3d, 0x2e, 0x10,
+	0x23, 0xf2, 0x88, 0x27, 0xfb, 0xe8, 0xb0, 0x8d, 0x0e, 0x52, 0x92, 0x9c, 0xb8, 0xfd, 0x84, 0x4b,
+	0x0e, 0x3f, 0xce, 0x61, 0x57, 0xc3, 0xee, 0x61, 0xbb, 0xfe, 0x49, 0xc8, 0x43, 0xae, 0x50, 0x94,
+	0xfd, 0xe5, 0x81, 0xf5, 0xd5, 0x90, 0xf3, 0x30, 0x22, 0x48, 0xad, 0x7a, 0xe9, 0x2e, 0xc2, 0x4c,
+	0x73, 0xd4, 0xd7, 0x34, 0x84, 0xfb, 0x14, 0x61, 0xc6, 0xb8, 0xc4, 0x92, 0x72, 0x26, 0x34, 0x6a,
+	0xfb, 0x5c, 0xc4, 0x5c, 0xa0, 0x1e, 0x16, 0x04, 0x1d, 0xb6, 0x7b, 0x44, 0xe2, 0x36, 0xf2, 0x39,
+	0x65, 0x1a, 0x5f, 0x2f, 0xe2, 0x4a, 0xda, 0x28, 0xaa, 0x8f, 0x43, 0xca, 0x14, 0xd9, 0x90, 0x6b,
+	0x32, 0x19, 0x3f, 0x15, 0x92, 0xc7, 0x39, 0xee, 0xfc, 0x03, 0x56, 0xfe, 0xc8, 0x18, 0xb6, 0xd3,
+	0x48, 0xd2, 0xee, 0x1e, 0xa6, 0xcc, 0x23, 0x07, 0x29, 0x11, 0x12, 0x7e, 0x01, 0x96, 0xe2, 0x6c,
+	0x73, 0xc7, 0xcf, 0x76, 0x77, 0x68, 0x60, 0x99, 0x4d, 0xb3, 0xb5, 0xe0, 0xd5, 0xe2, 0x51, 0xe8,
+	0x56, 0x00, 0xbf, 0x03, 0x40, 0x29, 0xd8, 0x09, 0xb0, 0xc4, 0x56, 0xa9, 0x69, 0xb6, 0x6a, 0x9d,
+	0x95, 0x37, 0x57, 0x0d, 0xe8, 0xe1, 0xa3, 0x2e, 0x67, 0x32, 0xc1, 0xbe, 0xdc, 0x26, 0x42, 0xe0,
+	0x90, 0x78, 0x0b, 0x2a, 0xf2, 0x17, 0x2c, 0xf1, 0x8f, 0xf3, 0xff, 0x9f, 0x37, 0xcc, 0xd7, 0xe7,
+	0x0d, 0xc3, 0xf9, 0x15, 0x7c, 0x3a, 0x21, 0x40, 0xf4, 0x39, 0x13, 0x04, 0xae, 0x83, 0x8a, 0x62,
+	0x35, 0xef, 0x64, 0x55, 0x31, 0xce, 0xa3, 0x12, 0xb0, 0x14, 0xcf, 0x10, 0xee, 0xe2, 0x28, 0x7a,
+	0xbf, 0x54, 0x56, 0xc0, 0x9c, 0x20, 0x2c, 0x20, 0x89, 0x4a, 0x63, 0xc1, 0xd3, 0x2b, 0x68, 0x81,
+	0x8f, 0x70, 0x10, 0x24, 0x44, 0x08, 0xab, 0xac, 0x80, 0xe1, 0xf2, 0x46, 0xf2, 0x95, 0x19, 0x93,
+	0x87, 0x18, 0x54, 0x77, 0x53, 0x16, 0x08, 0xab, 0xda, 0x2c, 0xb7, 0x16, 0x37, 0x57, 0xdd, 0xbc,
+	0x9e, 0x6e, 0x56, 0x4f, 0x57, 0x57, 0xd2, 0xed, 0x72, 0xca, 0x3a, 0x1b, 0x17, 0x57, 0x0d, 0xe3,
+	0xe1, 0xf3, 0x46, 0x2b, 0xa4, 0x72, 0x2f, 0xed, 0xb9, 0x3e, 0x8f, 0x91, 0x2e, 0x7e, 0xfe, 0xf9,
+	0x46, 0x04, 0xfb, 0x48, 0x9e, 0xf4, 0x89, 0x50, 0x07, 0x84, 0x97, 0x33, 0x43, 0x07, 0xd4, 0x02,
+	0xd2, 0xcf, 0xf4, 0x33, 0x9f, 0x12, 0x61, 0xcd, 0x35, 0xcb, 0x59, 0xbe, 0xc5, 0x3d, 0xe7, 0x37,
+	0xb0, 0x3a, 0xc5, 0xb1, 0x0f, 0xf0, 0xfe, 0x5f, 0x13, 0x2c, 0xfe, 0x49, 0x63, 0xc2, 0x53, 0xb9,
+	0xc5, 0x76, 0x79, 0xc1, 0x48, 0x73, 0xcc, 0xc8, 0x3a, 0x98, 0xf7, 0x35, 0x81, 0xb6, 0x78, 0xb4,
+	0x86, 0x4b, 0xa0, 0x44, 0x03, 0xed, 0x6f, 0x89, 0x06, 0x10, 0x82, 0x0a, 0x4e, 0x42, 0x91, 0x9b,
+	0xea, 0xa9, 0xff, 0xec, 0x7c, 0x40, 0x70, 0x10, 0x51, 0x46, 0xac, 0x6a, 0xd3, 0x6c, 0x95, 0xbd,
+	0xd1, 0xda, 0xf9, 0x01, 0x7c, 0xa6, 0x92, 0xf9, 0x9d, 0xb0, 0x80, 0xb2, 0x50, 0xcb, 0x11, 0xc3,
+	0x0e, 0x28, 0x5e, 0x6d, 0x8e, 0x5f, 0xed, 0xfc, 0x0d, 0xd6, 0xa6, 0x1f, 0xd5, 0x56, 0xfc, 0x04,
+	0xe6, 0xa5, 0xde, 0xb3, 0x4c, 0x55, 0x31, 0xdb, 0x9d, 0x78, 0x03, 0xdc, 0x82, 0x01, 0x9d, 0x4a,
+	0x56, 0x36, 0x6f, 0x74, 0x6a, 0xf3, 0x69, 0x19, 0x54, 0xd5, 0x15, 0xf0, 0xb1, 0x09, 0x6a, 0x45,
+	0xbf, 0xe1, 0x57, 0x53, 0xa8, 0x6e, 0xeb, 0xe3, 0xfa, 0xd7, 0xb3, 0x05, 0xe7, 0xba, 0x9d, 0xad,
+	0xff, 0x9e, 0xbc, 0xba, 0x5f, 0xea, 0xc2, 0x9f, 0x8b, 0xc3, 0x7f, 0x3a, 0x3e, 0x08, 0x67, 0x68,
+	0x68, 0x03, 0x3a, 0xd5, 0x5d, 0x7d, 0x86, 0x7c, 0x1c, 0x45, 0xe8, 0xf4, 0x5d, 0x67, 0x9f, 0xc1,
+	0x73, 0x13, 0x2c, 0xdf, 0x98, 0x52, 0xf8, 0xe5, 0x6d, 0x62, 0x26, 0x9e, 0x92, 0xfa, 0xfa, 0x2c,
+	0xa1, 0x5a, 0xf5, 0xf7, 0x4a, 0xf5, 0x06, 0x74, 0xef, 0x54, 0x9d, 0xc9, 0x1a, 0x97, 0x28, 0xc1,
+	0xf2, 0x8d, 0x02, 0x42, 0xf7, 0xb6, 0x6b, 0xa7, 0x37, 0x49, 0x1d, 0xcd, 0x1c, 0xaf, 0xb5, 0x1a,
+	0x1d, 0xef, 0xe2, 0xa5, 0x6d, 0x3c, 0x18, 0xd8, 0xc6, 0xc5, 0xc0, 0x36, 0x2f, 0x07, 0xb6, 0xf9,
+	0x62, 0x60, 0x9b, 0xf7, 0xae, 0x6d, 0xe3, 0xf2, 0xda, 0x36, 0x9e, 0x5d, 0xdb, 0xc6, 0x5f, 0x1b,
+	0x85, 0xd1, 0x8d, 0x78, 0x42, 0x02, 0xcc, 0xb0, 0x4f, 0x13, 0x21, 0x09, 0x46, 0x47, 0x58, 0xc4,
+	0xc7, 0xe8, 0x78, 0x94, 0xa9, 0x1a, 0xe4, 0xde, 0x9c, 0x7a, 0x99, 0xbf, 0x7d, 0x3b, 0x00, 0x6b,
+	0x17, 0x32, 0x65, 0x88, 0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -281,6 +418,8 @@ type QueryClient interface {
 	// ContractCall
 	ContractCall(ctx context.Context, in *QueryContractCallRequest, opts ...grpc.CallOption) (*QueryContractCallResponse, error)
 	QueryMultiChain(ctx context.Context, in *QueryMultiChainRequest, opts ...grpc.CallOption) (*QueryMultiChainResponse, error)
+	// PendingTimeouts lists the persisted timeouts scheduled by a contract
+	PendingTimeouts(ctx context.Context, in *QueryPendingTimeoutsRequest, opts ...grpc.CallOption) (*QueryPendingTimeoutsResponse, error)
 }
 
 type queryClient struct {
@@ -309,11 +448,22 @@ func (c *queryClient) QueryMultiChain(ctx context.Context, in *QueryMultiChainRe
 	return out, nil
 }
 
+func (c *queryClient) PendingTimeouts(ctx context.Context, in *QueryPendingTimeoutsRequest, opts ...grpc.CallOption) (*QueryPendingTimeoutsResponse, error) {
+	out := new(QueryPendingTimeoutsResponse)
+	err := c.cc.Invoke(ctx, "/mythos.network.v1.Query/PendingTimeouts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QueryServer is the server API for Query service.
 type QueryServer interface {
 	// ContractCall
 	ContractCall(context.Context, *QueryContractCallRequest) (*QueryContractCallResponse, error)
 	QueryMultiChain(context.Context, *QueryMultiChainRequest) (*QueryMultiChainResponse, error)
+	// PendingTimeouts lists the persisted timeouts scheduled by a contract
+	PendingTimeouts(context.Context, *QueryPendingTimeoutsRequest) (*QueryPendingTimeoutsResponse, error)
 }
 
 // UnimplementedQueryServer can be embedded to have forward compatible implementations.
@@ -326,6 +476,9 @@ func (*UnimplementedQueryServer) ContractCall(ctx context.Context, req *QueryCon
 func (*UnimplementedQueryServer) QueryMultiChain(ctx context.Context, req *QueryMultiChainRequest) (*QueryMultiChainResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryMultiChain not implemented")
 }
+func (*UnimplementedQueryServer) PendingTimeouts(ctx context.Context, req *QueryPendingTimeoutsRequest) (*QueryPendingTimeoutsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PendingTimeouts not implemented")
+}
 
 func RegisterQueryServer(s grpc1.Server, srv QueryServer) {
 	s.RegisterService(&_Query_serviceDesc, srv)
@@ -367,6 +520,24 @@ func _Query_QueryMultiChain_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Query_PendingTimeouts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryPendingTimeoutsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).PendingTimeouts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mythos.network.v1.Query/PendingTimeouts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).PendingTimeouts(ctx, req.(*QueryPendingTimeoutsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Query_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "mythos.network.v1.Query",
 	HandlerType: (*QueryServer)(nil),
@@ -379,6 +550,10 @@ var _Query_serviceDesc = grpc.ServiceDesc{
 			MethodName: "QueryMultiChain",
 			Handler:    _Query_QueryMultiChain_Handler,
 		},
+		{
+			MethodName: "PendingTimeouts",
+			Handler:    _Query_PendingTimeouts_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "mythos/network/v1/query.proto",
@@ -555,6 +730,129 @@ func (m *QueryContractCallResponse) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	return len(dAtA) - i, nil
 }
 
+func (m *TimeoutInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TimeoutInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TimeoutInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Deadline != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Deadline))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Args) > 0 {
+		i -= len(m.Args)
+		copy(dAtA[i:], m.Args)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Args)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Contract) > 0 {
+		i -= len(m.Contract)
+		copy(dAtA[i:], m.Contract)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Contract)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryPendingTimeoutsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryPendingTimeoutsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryPendingTimeoutsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Contract) > 0 {
+		i -= len(m.Contract)
+		copy(dAtA[i:], m.Contract)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Contract)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryPendingTimeoutsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryPendingTimeoutsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryPendingTimeoutsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Timeouts) > 0 {
+		for iNdEx := len(m.Timeouts) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Timeouts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
 	offset -= sovQuery(v)
 	base := offset
@@ -646,6 +944,62 @@ func (m *QueryContractCallResponse) Size() (n int) {
 	return n
 }
 
+func (m *TimeoutInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	l = len(m.Contract)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	l = len(m.Args)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Deadline != 0 {
+		n += 1 + sovQuery(uint64(m.Deadline))
+	}
+	return n
+}
+
+func (m *QueryPendingTimeoutsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Contract)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryPendingTimeoutsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Timeouts) > 0 {
+		for _, e := range m.Timeouts {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovQuery(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1182,6 +1536,371 @@ func (m *QueryContractCallResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *TimeoutInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TimeoutInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TimeoutInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Contract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Contract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Args", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Args = append(m.Args[:0], dAtA[iNdEx:postIndex]...)
+			if m.Args == nil {
+				m.Args = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deadline", wireType)
+			}
+			m.Deadline = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Deadline |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryPendingTimeoutsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryPendingTimeoutsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryPendingTimeoutsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Contract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Contract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryPendingTimeoutsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryPendingTimeoutsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryPendingTimeoutsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeouts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timeouts = append(m.Timeouts, TimeoutInfo{})
+			if err := m.Timeouts[len(m.Timeouts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipQuery(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -22,6 +22,7 @@ var BLOCK_DELAY = "blockTimeout"
 var VALIDATORS_KEY = "validators"
 var STATE_KEY = "state"
 var FSM_CONTEXT_KEY = "context_"
+var MEMPOOL_KEY = "mempool"
 
 type IndexedTransaction struct {
 	Height int64  `json:"height"`
@@ -70,6 +71,10 @@ func GetLastBlockHeightKey() string {
 	return BLOCK_LAST_INDEX
 }
 
+func GetMempoolKey() string {
+	return MEMPOOL_KEY
+}
+
 func GetConsensusParamsKey(index int64) string {
 	return PARAMS_KEY + strconv.Itoa(int(index))
 }
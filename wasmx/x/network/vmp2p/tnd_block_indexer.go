@@ -0,0 +1,51 @@
+package vmp2p
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cmtdbm "github.com/cometbft/cometbft-db"
+	dbm "github.com/cosmos/cosmos-db"
+
+	"github.com/cometbft/cometbft/state/indexer"
+	blockidxkv "github.com/cometbft/cometbft/state/indexer/block/kv"
+)
+
+var _ indexer.BlockIndexer = (*BlockIndexer)(nil)
+
+// BlockIndexer indexes the FinalizeBlock events of each block by height,
+// so blocks can be searched with queries like "block.height > 5 AND transfer.amount = '1stake'".
+// It uses the CometBFT key-value block indexer.
+type BlockIndexer struct {
+	*blockidxkv.BlockerIndexer
+	db cmtdbm.DB
+}
+
+func NewBlockIndexer(db cmtdbm.DB) *BlockIndexer {
+	return &BlockIndexer{
+		BlockerIndexer: blockidxkv.New(db),
+		db:             db,
+	}
+}
+
+// OpenBlockIndexer opens the block index database of a chain, in the node's home directory.
+// Backends not supported by the CometBFT indexer fall back to goleveldb.
+func OpenBlockIndexer(homeDir string, chainId string, backendType dbm.BackendType) (*BlockIndexer, error) {
+	dataDir := filepath.Join(homeDir, "data", "blockindex")
+	if err := os.MkdirAll(dataDir, 0o744); err != nil {
+		return nil, fmt.Errorf("failed to create blockindex directory: %w", err)
+	}
+	db, err := cmtdbm.NewDB(chainId, cmtdbm.BackendType(backendType), dataDir)
+	if err != nil {
+		db, err = cmtdbm.NewDB(chainId, cmtdbm.GoLevelDBBackend, dataDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return NewBlockIndexer(db), nil
+}
+
+func (bi *BlockIndexer) Close() error {
+	return bi.db.Close()
+}
@@ -1,89 +1,553 @@
 package vmp2p
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
 
+	"github.com/cosmos/gogoproto/proto"
+
+	dbm "github.com/cosmos/cosmos-db"
+
+	"github.com/cometbft/cometbft/evidence"
+	cmtstore "github.com/cometbft/cometbft/proto/tendermint/store"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	sm "github.com/cometbft/cometbft/state"
 	cmttypes "github.com/cometbft/cometbft/types"
 )
 
-type BlockStore struct{}
+// blocks with more parts than this are saved part by part, not in a single batch
+const maxBlockPartsToBatch = 10
+
+var blockStoreKey = []byte("blockStore")
+
+var _ sm.BlockStore = (*BlockStore)(nil)
+
+// BlockStore keeps the finalized blocks of a chain, with the same
+// key layout and semantics as the CometBFT block store:
+//   - BlockMeta:  meta information about each block
+//   - Block part: parts of each block, aggregated w/ PartSet
+//   - Commit:     the canonical commit for each block (from block.LastCommit at height+1)
+//   - SeenCommit: the +2/3 precommits seen locally for each block
+//
+// The store contains all contiguous blocks between base and height (inclusive).
+// Like the CometBFT store, methods panic on decoding errors, which indicate a corrupted database.
+type BlockStore struct {
+	db dbm.DB
 
-func (s BlockStore) Base() int64 {
-	return 0
+	// mtx guards base & height, so they are always in sync with the database
+	mtx    sync.RWMutex
+	base   int64
+	height int64
 }
 
-func (s BlockStore) Height() int64 {
-	return 0
+// NewBlockStore returns a BlockStore initialized to the last height saved in the db
+func NewBlockStore(db dbm.DB) *BlockStore {
+	bss := LoadBlockStoreState(db)
+	return &BlockStore{
+		db:     db,
+		base:   bss.Base,
+		height: bss.Height,
+	}
 }
 
-func (s BlockStore) Size() int64 {
-	return 0
+// OpenBlockStore opens the block store database of a chain, in the node's home directory
+func OpenBlockStore(homeDir string, chainId string, backendType dbm.BackendType) (*BlockStore, error) {
+	dataDir := filepath.Join(homeDir, "data", "blockstore")
+	if err := os.MkdirAll(dataDir, 0o744); err != nil {
+		return nil, fmt.Errorf("failed to create blockstore directory: %w", err)
+	}
+	db, err := dbm.NewDB(chainId, backendType, dataDir)
+	if err != nil {
+		return nil, err
+	}
+	return NewBlockStore(db), nil
 }
 
-func (s BlockStore) LoadBaseMeta() *cmttypes.BlockMeta {
-	return nil
+func (bs *BlockStore) IsEmpty() bool {
+	bs.mtx.RLock()
+	defer bs.mtx.RUnlock()
+	return bs.base == bs.height && bs.base == 0
 }
 
-func (s BlockStore) LoadBlockMeta(height int64) *cmttypes.BlockMeta {
-	return nil
+// Base returns the first known contiguous block height, or 0 for empty block stores.
+func (bs *BlockStore) Base() int64 {
+	bs.mtx.RLock()
+	defer bs.mtx.RUnlock()
+	return bs.base
 }
 
-func (s BlockStore) LoadBlock(height int64) *cmttypes.Block {
-	return nil
+// Height returns the last known contiguous block height, or 0 for empty block stores.
+func (bs *BlockStore) Height() int64 {
+	bs.mtx.RLock()
+	defer bs.mtx.RUnlock()
+	return bs.height
 }
 
-func (s BlockStore) SaveBlock(block *cmttypes.Block, blockParts *cmttypes.PartSet, seenCommit *cmttypes.Commit) {
+// Size returns the number of blocks in the block store.
+func (bs *BlockStore) Size() int64 {
+	bs.mtx.RLock()
+	defer bs.mtx.RUnlock()
+	if bs.height == 0 {
+		return 0
+	}
+	return bs.height - bs.base + 1
+}
 
+func (bs *BlockStore) LoadBaseMeta() *cmttypes.BlockMeta {
+	bs.mtx.RLock()
+	defer bs.mtx.RUnlock()
+	if bs.base == 0 {
+		return nil
+	}
+	return bs.LoadBlockMeta(bs.base)
+}
+
+func (bs *BlockStore) LoadBlockMeta(height int64) *cmttypes.BlockMeta {
+	bz := bs.mustGet(calcBlockMetaKey(height))
+	if len(bz) == 0 {
+		return nil
+	}
+	pbbm := new(cmtproto.BlockMeta)
+	err := proto.Unmarshal(bz, pbbm)
+	if err != nil {
+		panic(fmt.Errorf("unmarshal to cmtproto.BlockMeta: %w", err))
+	}
+	blockMeta, err := cmttypes.BlockMetaFromProto(pbbm)
+	if err != nil {
+		panic(fmt.Errorf("error from proto blockMeta: %w", err))
+	}
+	return blockMeta
 }
 
-func (s BlockStore) SaveBlockWithExtendedCommit(block *cmttypes.Block, blockParts *cmttypes.PartSet, seenCommit *cmttypes.ExtendedCommit) {
+// LoadBlock returns the block with the given height, or nil if it is not found.
+func (bs *BlockStore) LoadBlock(height int64) *cmttypes.Block {
+	blockMeta := bs.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil
+	}
 
+	buf := []byte{}
+	for i := 0; i < int(blockMeta.BlockID.PartSetHeader.Total); i++ {
+		part := bs.LoadBlockPart(height, i)
+		// the block may have been pruned after we loaded the meta
+		if part == nil {
+			return nil
+		}
+		buf = append(buf, part.Bytes...)
+	}
+	pbb := new(cmtproto.Block)
+	err := proto.Unmarshal(buf, pbb)
+	if err != nil {
+		panic(fmt.Errorf("error reading block: %w", err))
+	}
+	block, err := cmttypes.BlockFromProto(pbb)
+	if err != nil {
+		panic(fmt.Errorf("error from proto block: %w", err))
+	}
+	return block
 }
 
-func (s BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64, error) {
-	return 0, 0, nil
+func (bs *BlockStore) SaveBlock(block *cmttypes.Block, blockParts *cmttypes.PartSet, seenCommit *cmttypes.Commit) {
+	if block == nil {
+		panic("BlockStore can only save a non-nil block")
+	}
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	if err := bs.saveBlockToBatch(block, blockParts, seenCommit, batch); err != nil {
+		panic(err)
+	}
+
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	bs.height = block.Height
+	if bs.base == 0 {
+		bs.base = block.Height
+	}
+	if err := bs.saveStateAndWriteDB(batch, "failed to save block"); err != nil {
+		panic(err)
+	}
 }
 
-func (s BlockStore) LoadBlockByHash(hash []byte) *cmttypes.Block {
-	return nil
+func (bs *BlockStore) SaveBlockWithExtendedCommit(block *cmttypes.Block, blockParts *cmttypes.PartSet, seenCommit *cmttypes.ExtendedCommit) {
+	if block == nil {
+		panic("BlockStore can only save a non-nil block")
+	}
+	if err := seenCommit.EnsureExtensions(true); err != nil {
+		panic(fmt.Errorf("problems saving block with extensions: %w", err))
+	}
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	if err := bs.saveBlockToBatch(block, blockParts, seenCommit.ToCommit(), batch); err != nil {
+		panic(err)
+	}
+	if err := batch.Set(calcExtCommitKey(block.Height), mustEncode(seenCommit.ToProto())); err != nil {
+		panic(err)
+	}
+
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	bs.height = block.Height
+	if bs.base == 0 {
+		bs.base = block.Height
+	}
+	if err := bs.saveStateAndWriteDB(batch, "failed to save block with extended commit"); err != nil {
+		panic(err)
+	}
 }
 
-func (s BlockStore) LoadBlockMetaByHash(hash []byte) *cmttypes.BlockMeta {
-	return nil
+// PruneBlocks removes blocks up to (but not including) height.
+// It returns the number of pruned blocks and the evidence retain height:
+// headers and commits needed to prove evidence are kept from that height onwards.
+func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64, error) {
+	if height <= 0 {
+		return 0, -1, fmt.Errorf("height must be greater than 0")
+	}
+	bs.mtx.RLock()
+	if height > bs.height {
+		bs.mtx.RUnlock()
+		return 0, -1, fmt.Errorf("cannot prune beyond the latest height %v", bs.height)
+	}
+	base := bs.base
+	bs.mtx.RUnlock()
+	if height < base {
+		return 0, -1, fmt.Errorf("cannot prune to height %v, it is lower than base height %v", height, base)
+	}
+
+	pruned := uint64(0)
+	batch := bs.db.NewBatch()
+	defer func() {
+		batch.Close()
+	}()
+	flush := func(batch dbm.Batch, base int64) error {
+		// update base first, so nobody tries to access missing blocks
+		bs.mtx.Lock()
+		defer batch.Close()
+		defer bs.mtx.Unlock()
+		bs.base = base
+		return bs.saveStateAndWriteDB(batch, "failed to prune")
+	}
+
+	evidencePoint := height
+	for h := base; h < height; h++ {
+		meta := bs.LoadBlockMeta(h)
+		if meta == nil { // already deleted
+			continue
+		}
+
+		// keep the headers and commits that can still prove malicious behavior
+		if evidencePoint == height && !evidence.IsEvidenceExpired(state.LastBlockHeight, state.LastBlockTime, h, meta.Header.Time, state.ConsensusParams.Evidence) {
+			evidencePoint = h
+		}
+		if h < evidencePoint {
+			if err := batch.Delete(calcBlockMetaKey(h)); err != nil {
+				return 0, -1, err
+			}
+			if err := batch.Delete(calcBlockCommitKey(h)); err != nil {
+				return 0, -1, err
+			}
+		}
+		if err := batch.Delete(calcBlockHashKey(meta.BlockID.Hash)); err != nil {
+			return 0, -1, err
+		}
+		if err := batch.Delete(calcSeenCommitKey(h)); err != nil {
+			return 0, -1, err
+		}
+		if err := batch.Delete(calcExtCommitKey(h)); err != nil {
+			return 0, -1, err
+		}
+		for p := 0; p < int(meta.BlockID.PartSetHeader.Total); p++ {
+			if err := batch.Delete(calcBlockPartKey(h, p)); err != nil {
+				return 0, -1, err
+			}
+		}
+		pruned++
+
+		// flush every 1000 blocks, so batches do not become too large
+		if pruned%1000 == 0 {
+			if err := flush(batch, h); err != nil {
+				return 0, -1, err
+			}
+			batch = bs.db.NewBatch()
+		}
+	}
+
+	if err := flush(batch, height); err != nil {
+		return 0, -1, err
+	}
+	return pruned, evidencePoint, nil
 }
 
-func (s BlockStore) LoadBlockPart(height int64, index int) *cmttypes.Part {
-	return nil
+func (bs *BlockStore) LoadBlockByHash(hash []byte) *cmttypes.Block {
+	height, found := bs.heightByHash(hash)
+	if !found {
+		return nil
+	}
+	return bs.LoadBlock(height)
 }
 
-func (s BlockStore) LoadBlockCommit(height int64) *cmttypes.Commit {
-	return nil
+func (bs *BlockStore) LoadBlockMetaByHash(hash []byte) *cmttypes.BlockMeta {
+	height, found := bs.heightByHash(hash)
+	if !found {
+		return nil
+	}
+	return bs.LoadBlockMeta(height)
 }
 
-func (s BlockStore) LoadSeenCommit(height int64) *cmttypes.Commit {
-	return nil
+func (bs *BlockStore) LoadBlockPart(height int64, index int) *cmttypes.Part {
+	bz := bs.mustGet(calcBlockPartKey(height, index))
+	if len(bz) == 0 {
+		return nil
+	}
+	pbpart := new(cmtproto.Part)
+	err := proto.Unmarshal(bz, pbpart)
+	if err != nil {
+		panic(fmt.Errorf("unmarshal to cmtproto.Part failed: %w", err))
+	}
+	part, err := cmttypes.PartFromProto(pbpart)
+	if err != nil {
+		panic(fmt.Errorf("error reading block part: %w", err))
+	}
+	return part
 }
 
-func (s BlockStore) LoadBlockExtendedCommit(height int64) *cmttypes.ExtendedCommit {
-	return nil
+// LoadBlockCommit returns the canonical Commit for the given height.
+// It comes from block.LastCommit at height+1.
+func (bs *BlockStore) LoadBlockCommit(height int64) *cmttypes.Commit {
+	return bs.loadCommit(calcBlockCommitKey(height))
 }
 
-func (s BlockStore) DeleteLatestBlock() error {
-	return nil
+// LoadSeenCommit returns the locally seen Commit for the given height.
+// It is available before block height+1 is finalized.
+func (bs *BlockStore) LoadSeenCommit(height int64) *cmttypes.Commit {
+	return bs.loadCommit(calcSeenCommitKey(height))
 }
 
-func (s BlockStore) Close() error {
-	return nil
+func (bs *BlockStore) LoadBlockExtendedCommit(height int64) *cmttypes.ExtendedCommit {
+	bz := bs.mustGet(calcExtCommitKey(height))
+	if len(bz) == 0 {
+		return nil
+	}
+	pbec := new(cmtproto.ExtendedCommit)
+	err := proto.Unmarshal(bz, pbec)
+	if err != nil {
+		panic(fmt.Errorf("decoding extended commit: %w", err))
+	}
+	extCommit, err := cmttypes.ExtendedCommitFromProto(pbec)
+	if err != nil {
+		panic(fmt.Errorf("converting extended commit: %w", err))
+	}
+	return extCommit
 }
 
-func (s BlockStore) SaveSeenCommit(height int64, seenCommit *cmttypes.Commit) error {
-	seenCommitbz, err := json.Marshal(seenCommit)
-	fmt.Println("---BlockStore.SaveSeenCommit--", err, string(seenCommitbz))
+// DeleteLatestBlock removes the block at the latest height, lowering height by one
+func (bs *BlockStore) DeleteLatestBlock() error {
+	bs.mtx.RLock()
+	targetHeight := bs.height
+	bs.mtx.RUnlock()
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	// delete what we can, so partial blocks are fully removed
+	if meta := bs.LoadBlockMeta(targetHeight); meta != nil {
+		if err := batch.Delete(calcBlockHashKey(meta.BlockID.Hash)); err != nil {
+			return err
+		}
+		for p := 0; p < int(meta.BlockID.PartSetHeader.Total); p++ {
+			if err := batch.Delete(calcBlockPartKey(targetHeight, p)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := batch.Delete(calcBlockCommitKey(targetHeight)); err != nil {
+		return err
+	}
+	if err := batch.Delete(calcSeenCommitKey(targetHeight)); err != nil {
+		return err
+	}
+	if err := batch.Delete(calcExtCommitKey(targetHeight)); err != nil {
+		return err
+	}
+	// delete the meta last, so we do not leave dangling keys
+	if err := batch.Delete(calcBlockMetaKey(targetHeight)); err != nil {
+		return err
+	}
+
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	bs.height = targetHeight - 1
+	if bs.height < bs.base {
+		bs.base = 0
+		bs.height = 0
+	}
+	return bs.saveStateAndWriteDB(batch, "failed to delete the latest block")
+}
+
+func (bs *BlockStore) Close() error {
+	return bs.db.Close()
+}
+
+// SaveSeenCommit saves a seen commit, used by the state sync reactor when bootstrapping the node
+func (bs *BlockStore) SaveSeenCommit(height int64, seenCommit *cmttypes.Commit) error {
+	bz, err := proto.Marshal(seenCommit.ToProto())
 	if err != nil {
+		return fmt.Errorf("unable to marshal commit: %w", err)
+	}
+	return bs.db.Set(calcSeenCommitKey(height), bz)
+}
+
+func (bs *BlockStore) saveBlockToBatch(block *cmttypes.Block, blockParts *cmttypes.PartSet, seenCommit *cmttypes.Commit, batch dbm.Batch) error {
+	height := block.Height
+	hash := block.Hash()
+
+	if g, w := height, bs.Height()+1; bs.Base() > 0 && g != w {
+		return fmt.Errorf("BlockStore can only save contiguous blocks. Wanted %v, got %v", w, g)
+	}
+	if !blockParts.IsComplete() {
+		return errors.New("BlockStore can only save complete block part sets")
+	}
+	if seenCommit == nil || height != seenCommit.Height {
+		return fmt.Errorf("BlockStore cannot save seen commit of a different height (block: %d)", height)
+	}
+
+	// parts must be saved before the block meta, which marks the block as complete
+	saveBlockPartsToBatch := blockParts.Count() <= maxBlockPartsToBatch
+	for i := 0; i < int(blockParts.Total()); i++ {
+		pbp, err := blockParts.GetPart(i).ToProto()
+		if err != nil {
+			return fmt.Errorf("unable to make part into proto: %w", err)
+		}
+		if saveBlockPartsToBatch {
+			err = batch.Set(calcBlockPartKey(height, i), mustEncode(pbp))
+		} else {
+			err = bs.db.Set(calcBlockPartKey(height, i), mustEncode(pbp))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	pbm := cmttypes.NewBlockMeta(block, blockParts).ToProto()
+	if pbm == nil {
+		return errors.New("nil blockmeta")
+	}
+	if err := batch.Set(calcBlockMetaKey(height), mustEncode(pbm)); err != nil {
+		return err
+	}
+	if err := batch.Set(calcBlockHashKey(hash), []byte(fmt.Sprintf("%d", height))); err != nil {
 		return err
 	}
-	// TODO commitAfterStateSync
+	if block.LastCommit != nil && height > 1 {
+		if err := batch.Set(calcBlockCommitKey(height-1), mustEncode(block.LastCommit.ToProto())); err != nil {
+			return err
+		}
+	}
+	return batch.Set(calcSeenCommitKey(height), mustEncode(seenCommit.ToProto()))
+}
+
+// the caller must hold at least a read lock on bs
+func (bs *BlockStore) saveStateAndWriteDB(batch dbm.Batch, errMsg string) error {
+	bss := cmtstore.BlockStoreState{
+		Base:   bs.base,
+		Height: bs.height,
+	}
+	if err := batch.Set(blockStoreKey, mustEncode(&bss)); err != nil {
+		return err
+	}
+	err := batch.WriteSync()
+	if err != nil {
+		return fmt.Errorf("error writing batch to DB %q: (base %d, height %d): %w", errMsg, bs.base, bs.height, err)
+	}
 	return nil
 }
+
+func (bs *BlockStore) loadCommit(key []byte) *cmttypes.Commit {
+	bz := bs.mustGet(key)
+	if len(bz) == 0 {
+		return nil
+	}
+	pbc := new(cmtproto.Commit)
+	err := proto.Unmarshal(bz, pbc)
+	if err != nil {
+		panic(fmt.Errorf("error reading block commit: %w", err))
+	}
+	commit, err := cmttypes.CommitFromProto(pbc)
+	if err != nil {
+		panic(fmt.Errorf("converting commit from proto: %w", err))
+	}
+	return commit
+}
+
+func (bs *BlockStore) heightByHash(hash []byte) (int64, bool) {
+	bz := bs.mustGet(calcBlockHashKey(hash))
+	if len(bz) == 0 {
+		return 0, false
+	}
+	height, err := strconv.ParseInt(string(bz), 10, 64)
+	if err != nil {
+		panic(fmt.Errorf("failed to extract height from %s: %w", string(bz), err))
+	}
+	return height, true
+}
+
+func (bs *BlockStore) mustGet(key []byte) []byte {
+	bz, err := bs.db.Get(key)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// LoadBlockStoreState returns the base & height saved in the db, or zero values for a new store
+func LoadBlockStoreState(db dbm.DB) cmtstore.BlockStoreState {
+	bz, err := db.Get(blockStoreKey)
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) == 0 {
+		return cmtstore.BlockStoreState{}
+	}
+	var bss cmtstore.BlockStoreState
+	if err := proto.Unmarshal(bz, &bss); err != nil {
+		panic(fmt.Sprintf("could not unmarshal bytes: %X", bz))
+	}
+	return bss
+}
+
+func mustEncode(pb proto.Message) []byte {
+	bz, err := proto.Marshal(pb)
+	if err != nil {
+		panic(fmt.Errorf("unable to marshal: %w", err))
+	}
+	return bz
+}
+
+func calcBlockMetaKey(height int64) []byte {
+	return []byte(fmt.Sprintf("H:%v", height))
+}
+
+func calcBlockPartKey(height int64, partIndex int) []byte {
+	return []byte(fmt.Sprintf("P:%v:%v", height, partIndex))
+}
+
+func calcBlockCommitKey(height int64) []byte {
+	return []byte(fmt.Sprintf("C:%v", height))
+}
+
+func calcSeenCommitKey(height int64) []byte {
+	return []byte(fmt.Sprintf("SC:%v", height))
+}
+
+func calcExtCommitKey(height int64) []byte {
+	return []byte(fmt.Sprintf("EC:%v", height))
+}
+
+func calcBlockHashKey(hash []byte) []byte {
+	return []byte(fmt.Sprintf("BH:%x", hash))
+}
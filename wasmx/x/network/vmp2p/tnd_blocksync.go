@@ -1,90 +1,602 @@
 package vmp2p
 
 import (
-	"github.com/cometbft/cometbft/libs/log"
-	"github.com/cometbft/cometbft/p2p"
-	"github.com/cometbft/cometbft/p2p/conn"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	sm "github.com/cometbft/cometbft/state"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	log "cosmossdk.io/log"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	mcfg "github.com/loredanacirstea/wasmx/config"
+	mctx "github.com/loredanacirstea/wasmx/context"
+	networkserver "github.com/loredanacirstea/wasmx/x/network/server"
+	networktypes "github.com/loredanacirstea/wasmx/x/network/types"
 )
 
+// maximum number of blocks sent in one block sync response
+const BlockSyncBatchSize = 20
+
+var BlockSyncRequestTimeout = time.Second * 30
+
+var BlockSyncConnectRetries = 3
+
 type blockSyncReactor interface {
 	SwitchToBlockSync(sm.State) error
 }
 
-type MockBlockSyncReactor struct{}
+// BlockSyncAnchor is the last block trusted by the node;
+// the next synced block must extend it
+type BlockSyncAnchor struct {
+	Height             int64
+	BlockHash          []byte
+	NextValidatorsHash []byte
+}
+
+// BlockSyncValidators are the validator sets after the trusted block.
+// They are updated with each replayed block, like the cometbft state.
+type BlockSyncValidators struct {
+	// Validators sign the next block
+	Validators *cmttypes.ValidatorSet
+	// NextValidators sign the block after the next one
+	NextValidators *cmttypes.ValidatorSet
+	// LastHeightChanged is the first height signed by the current validators
+	LastHeightChanged int64
+}
 
-func (*MockBlockSyncReactor) SwitchToBlockSync(sm.State) error {
+// Update applies the validator updates returned by the block at height; they sign from height + 2
+func (v *BlockSyncValidators) Update(height int64, updates []abci.ValidatorUpdate) error {
+	changes, err := cmttypes.PB2TM.ValidatorUpdates(updates)
+	if err != nil {
+		return err
+	}
+	nextValidators := v.NextValidators.Copy()
+	if len(changes) > 0 {
+		err = nextValidators.UpdateWithChangeSet(changes)
+		if err != nil {
+			return fmt.Errorf("block %d validator updates: %v", height, err)
+		}
+		v.LastHeightChanged = height + 2
+	}
+	nextValidators.IncrementProposerPriority(1)
+	v.Validators = v.NextValidators
+	v.NextValidators = nextValidators
 	return nil
 }
 
-// SetSwitch allows setting a switch.
-func (*MockBlockSyncReactor) SetSwitch(*p2p.Switch) {
+// BlockSyncBlock is a decoded BlockSyncEntry
+type BlockSyncBlock struct {
+	Entry      *networktypes.BlockEntry
+	Block      *cmttypes.Block
+	Commit     *cmttypes.Commit
+	Validators *cmttypes.ValidatorSet
+}
 
+func (b *BlockSyncBlock) Anchor() BlockSyncAnchor {
+	return BlockSyncAnchor{
+		Height:             b.Block.Height,
+		BlockHash:          b.Block.Hash(),
+		NextValidatorsHash: b.Block.NextValidatorsHash,
+	}
 }
 
-// GetChannels returns the list of MConnection.ChannelDescriptor. Make sure
-// that each ID is unique across all the reactors added to the switch.
-func (*MockBlockSyncReactor) GetChannels() []*conn.ChannelDescriptor {
-	return nil
+func DecodeBlockSyncEntry(cdc codec.Codec, chainId string, entry networktypes.BlockSyncEntry) (*BlockSyncBlock, error) {
+	var bentry networktypes.BlockEntry
+	err := json.Unmarshal(entry.Entry, &bentry)
+	if err != nil {
+		return nil, err
+	}
+	block, _, err := networktypes.BlockFromEntry(cdc, chainId, &bentry)
+	if err != nil {
+		return nil, err
+	}
+	var commitpb cmtproto.Commit
+	err = commitpb.Unmarshal(entry.Commit)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := cmttypes.CommitFromProto(&commitpb)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := networktypes.ValidatorsFromEntry(cdc, &bentry)
+	if err != nil {
+		return nil, err
+	}
+	valset, err := cmttypes.ValidatorSetFromExistingValidators(vals)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockSyncBlock{
+		Entry:      &bentry,
+		Block:      block,
+		Commit:     commit,
+		Validators: valset,
+	}, nil
 }
 
-// InitPeer is called by the switch before the peer is started. Use it to
-// initialize data for the peer (e.g. peer state).
-//
-// NOTE: The switch won't call AddPeer nor RemovePeer if it fails to start
-// the peer. Do not store any data associated with the peer in the reactor
-// itself unless you don't want to have a state, which is never cleaned up.
-func (*MockBlockSyncReactor) InitPeer(peer p2p.Peer) p2p.Peer {
-	// TODO init peer, this is temp
-	return p2p.CreateRandomPeer(true)
+// VerifyBlockSyncBlock checks that the block extends the trusted block and that it was committed by its validator set.
+// The commit signatures are verified by the consensus contract.
+func VerifyBlockSyncBlock(verifier ClientVerification, chainId string, trusted BlockSyncAnchor, b *BlockSyncBlock) error {
+	height := b.Block.Height
+	if height != trusted.Height+1 {
+		return fmt.Errorf("block height mismatch: expected %d, got %d", trusted.Height+1, height)
+	}
+	if !bytes.Equal(b.Block.LastBlockID.Hash, trusted.BlockHash) {
+		return fmt.Errorf("block %d last_block_id mismatch: expected %X, got %X", height, trusted.BlockHash, b.Block.LastBlockID.Hash)
+	}
+	valsetHash := b.Validators.Hash()
+	if !bytes.Equal(b.Block.ValidatorsHash, valsetHash) {
+		return fmt.Errorf("block %d validators hash mismatch: expected %X, got %X", height, b.Block.ValidatorsHash, valsetHash)
+	}
+	if !bytes.Equal(trusted.NextValidatorsHash, valsetHash) {
+		return fmt.Errorf("block %d validators do not match the trusted next validators: expected %X, got %X", height, trusted.NextValidatorsHash, valsetHash)
+	}
+	if b.Commit.Height != height {
+		return fmt.Errorf("commit height mismatch: expected %d, got %d", height, b.Commit.Height)
+	}
+	if !bytes.Equal(b.Commit.BlockID.Hash, b.Block.Hash()) {
+		return fmt.Errorf("commit %d block hash mismatch: expected %X, got %X", height, b.Block.Hash(), b.Commit.BlockID.Hash)
+	}
+	return verifier.VerifyCommitLightByContract(chainId, b.Commit.BlockID, height, b.Commit, b.Validators)
 }
 
-// AddPeer is called by the switch after the peer is added and successfully
-// started. Use it to start goroutines communicating with the peer.
-func (*MockBlockSyncReactor) AddPeer(peer p2p.Peer) {
+type blockSyncProvider struct {
+	goContextParent context.Context
+	logger          log.Logger
+	app             mcfg.MythosApp
+}
 
+// InitializeBlockSyncProvider serves finalized blocks to peers that are catching up
+func InitializeBlockSyncProvider(
+	goContextParent context.Context,
+	sdklogger log.Logger,
+	app mcfg.MythosApp,
+	protocolId string,
+	privateKey []byte,
+	port string,
+) error {
+	sdklogger.Info("start blocksync provider service", "port", port)
+	p2pctx, err := GetP2PContext(goContextParent)
+	if err != nil {
+		return err
+	}
+	provider := &blockSyncProvider{
+		goContextParent: goContextParent,
+		logger:          sdklogger,
+		app:             app,
+	}
+	// the state sync provider already listens on this port
+	if p2pctx.Node != nil {
+		(*p2pctx.Node).SetStreamHandler(protocol.ID(protocolId), provider.handleStream)
+		return nil
+	}
+	_, err = startNodeWithIdentityAndGossip(goContextParent, p2pctx, sdklogger, privateKey, port, protocolId, provider.handleStream)
+	return err
 }
 
-// RemovePeer is called by the switch when the peer is stopped (due to error
-// or other reason).
-func (*MockBlockSyncReactor) RemovePeer(peer p2p.Peer, reason interface{}) {
+func (p *blockSyncProvider) handleStream(stream network.Stream) {
+	rw := bufio.NewReadWriter(bufio.NewReader(stream), bufio.NewWriter(stream))
+	peeraddr := stream.Conn().RemoteMultiaddr().String() + "/p2p/" + stream.Conn().RemotePeer().String()
+	go readDataStd(p.goContextParent, p.logger, rw, string(stream.Protocol()), peeraddr, func(msgbz []byte, frompeer string) {
+		resp := p.handleRequest(msgbz)
+		respbz, err := json.Marshal(resp)
+		if err != nil {
+			p.logger.Error("blocksync response marshal failed", "error", err.Error())
+			return
+		}
+		err = writeData(rw, respbz)
+		if err != nil {
+			p.logger.Debug("blocksync response failed", "peer", frompeer, "error", err.Error())
+		}
+	})
+}
 
+func (p *blockSyncProvider) handleRequest(msgbz []byte) *BlockSyncResponse {
+	var req BlockSyncRequest
+	err := json.Unmarshal(msgbz, &req)
+	if err != nil {
+		return &BlockSyncResponse{Error: err.Error()}
+	}
+	if req.EndHeight-req.StartHeight >= BlockSyncBatchSize {
+		req.EndHeight = req.StartHeight + BlockSyncBatchSize - 1
+	}
+	blocks, lastHeight, err := p.app.GetNetworkKeeper().GetBlockSyncEntries(p.app, p.logger, req.StartHeight, req.EndHeight)
+	if err != nil {
+		return &BlockSyncResponse{Error: err.Error()}
+	}
+	return &BlockSyncResponse{Blocks: blocks, LastHeight: lastHeight}
 }
 
-// Receive is called by the switch when an envelope is received from any connected
-// peer on any of the channels registered by the reactor
-func (*MockBlockSyncReactor) Receive(p2p.Envelope) {
+// BlockSyncClient requests finalized blocks from a block sync provider
+type BlockSyncClient struct {
+	node   host.Host
+	stream network.Stream
+	rw     *bufio.ReadWriter
+}
 
+func NewBlockSyncClient(protocolId string, peeraddress string) (*BlockSyncClient, error) {
+	node, err := libp2p.New(libp2p.NoListenAddrs, libp2p.Ping(false))
+	if err != nil {
+		return nil, err
+	}
+	var stream network.Stream
+	for i := 1; i <= BlockSyncConnectRetries; i++ {
+		stream, err = connectPeerInternal(node, protocolId, peeraddress)
+		if err == nil {
+			break
+		}
+		time.Sleep(time.Second * 2)
+	}
+	if err != nil {
+		node.Close()
+		return nil, err
+	}
+	return &BlockSyncClient{
+		node:   node,
+		stream: stream,
+		rw:     bufio.NewReadWriter(bufio.NewReader(stream), bufio.NewWriter(stream)),
+	}, nil
 }
 
-func (*MockBlockSyncReactor) IsRunning() bool {
-	return true
+func (c *BlockSyncClient) Request(startHeight int64, endHeight int64) (*BlockSyncResponse, error) {
+	reqbz, err := json.Marshal(&BlockSyncRequest{StartHeight: startHeight, EndHeight: endHeight})
+	if err != nil {
+		return nil, err
+	}
+	err = writeData(c.rw, reqbz)
+	if err != nil {
+		return nil, err
+	}
+	err = c.stream.SetReadDeadline(time.Now().Add(BlockSyncRequestTimeout))
+	if err != nil {
+		return nil, err
+	}
+	respbz, err := c.rw.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	var resp BlockSyncResponse
+	err = json.Unmarshal(respbz, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("blocksync provider error: %s", resp.Error)
+	}
+	return &resp, nil
 }
-func (*MockBlockSyncReactor) OnReset() error {
-	return nil
 
+func (c *BlockSyncClient) Close() error {
+	c.stream.Close()
+	return c.node.Close()
 }
-func (*MockBlockSyncReactor) OnStart() error {
-	return nil
+
+// StartBlockSync catches up with the blocks finalized by the network since the node's last block.
+// Must be called before the consensus contract is started.
+// Returns the number of replayed blocks.
+func StartBlockSync(
+	goContextParent context.Context,
+	logger log.Logger,
+	app mcfg.MythosApp,
+	protocolId string,
+	peeraddress string,
+) (int64, error) {
+	height := app.GetBaseApp().LastBlockHeight()
+	if height == 0 {
+		return 0, fmt.Errorf("cannot block sync without a committed block")
+	}
+	// the validator updates of the previous block are needed for the validators of the next block
+	startHeight := max(height-1, 1)
+	entries, _, err := app.GetNetworkKeeper().GetBlockSyncEntries(app, logger, startHeight, height)
+	if err != nil {
+		return 0, err
+	}
+	if len(entries) == 0 || entries[len(entries)-1].Entry == nil {
+		return 0, fmt.Errorf("block %d not found", height)
+	}
+	cdc := app.GetNetworkKeeper().Codec()
+	blocks := []*BlockSyncBlock{}
+	for _, entry := range entries {
+		b, err := DecodeBlockSyncEntry(cdc, app.GetBaseApp().ChainID(), entry)
+		if err != nil {
+			return 0, err
+		}
+		blocks = append(blocks, b)
+	}
+	trusted := blocks[len(blocks)-1]
+	validators, err := trustedBlockValidators(blocks)
+	if err != nil {
+		return 0, err
+	}
+	return syncBlocks(goContextParent, logger, app, protocolId, peeraddress, trusted.Anchor(), validators)
 }
-func (*MockBlockSyncReactor) OnStop() {
 
+// trustedBlockValidators rebuilds the validator sets after the last block from the node's own blocks
+func trustedBlockValidators(blocks []*BlockSyncBlock) (*BlockSyncValidators, error) {
+	trusted := blocks[len(blocks)-1]
+	// the validator sets before the first block's updates
+	validators := &BlockSyncValidators{
+		Validators:        blocks[0].Validators.Copy(),
+		NextValidators:    trusted.Validators.Copy(),
+		LastHeightChanged: trusted.Block.Height + 1,
+	}
+	for _, b := range blocks {
+		result, err := networktypes.BlockResultFromEntry(b.Entry)
+		if err != nil {
+			return nil, err
+		}
+		err = validators.Update(b.Block.Height, result.ValidatorUpdates)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !bytes.Equal(validators.Validators.Hash(), trusted.Block.NextValidatorsHash) {
+		return nil, fmt.Errorf("block %d next validators hash mismatch: expected %X, got %X", trusted.Block.Height, trusted.Block.NextValidatorsHash, validators.Validators.Hash())
+	}
+	return validators, nil
 }
-func (*MockBlockSyncReactor) Quit() <-chan struct{} {
-	return nil
+
+// syncBlocks verifies and replays all the peer's blocks after the trusted block,
+// then bootstraps the consensus contract at the last replayed block.
+// The validator sets after the trusted block are updated with each replayed block.
+// Returns the number of replayed blocks.
+func syncBlocks(
+	goContextParent context.Context,
+	logger log.Logger,
+	app mcfg.MythosApp,
+	protocolId string,
+	peeraddress string,
+	trusted BlockSyncAnchor,
+	validators *BlockSyncValidators,
+) (int64, error) {
+	bapp := app.GetBaseApp()
+	chainId := bapp.ChainID()
+	cdc := app.GetNetworkKeeper().Codec()
+	verifier := NewClientVerification(app, logger, nil)
+	logger = logger.With("chain_id", chainId, "peer", peeraddress)
+
+	client, err := NewBlockSyncClient(protocolId, peeraddress)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	logger.Info("starting block sync", "height", trusted.Height)
+	var last *BlockSyncBlock
+	var lastResp *abci.ResponseFinalizeBlock
+	replayed := int64(0)
+	syncErr := func() error {
+		for {
+			select {
+			case <-goContextParent.Done():
+				return goContextParent.Err()
+			default:
+			}
+
+			resp, err := client.Request(trusted.Height+1, trusted.Height+BlockSyncBatchSize)
+			if err != nil {
+				return err
+			}
+			if len(resp.Blocks) == 0 {
+				return nil
+			}
+			for _, entry := range resp.Blocks {
+				b, err := DecodeBlockSyncEntry(cdc, chainId, entry)
+				if err != nil {
+					return err
+				}
+				err = VerifyBlockSyncBlock(verifier, chainId, trusted, b)
+				if err != nil {
+					return err
+				}
+				// the block confirms the state it is executed on
+				if !bytes.Equal(bapp.LastCommitID().Hash, b.Block.AppHash) {
+					return fmt.Errorf("app hash mismatch before block %d: expected %X, got %X", b.Block.Height, b.Block.AppHash, bapp.LastCommitID().Hash)
+				}
+				res, err := replayBlock(app, b)
+				if err != nil {
+					return err
+				}
+				err = validators.Update(b.Block.Height, res.ValidatorUpdates)
+				if err != nil {
+					return err
+				}
+				if !bytes.Equal(validators.Validators.Hash(), b.Block.NextValidatorsHash) {
+					return fmt.Errorf("block %d next validators hash mismatch: expected %X, got %X", b.Block.Height, b.Block.NextValidatorsHash, validators.Validators.Hash())
+				}
+				saveSyncedBlock(app, logger, b)
+				last = b
+				lastResp = res
+				trusted = b.Anchor()
+				replayed += 1
+			}
+			logger.Info("block sync progress", "height", trusted.Height, "last_height", resp.LastHeight)
+			if trusted.Height >= resp.LastHeight {
+				return nil
+			}
+		}
+	}()
+	if last == nil {
+		if syncErr == nil {
+			logger.Info("block sync: node is up to date", "height", trusted.Height)
+		}
+		return 0, syncErr
+	}
+
+	// the consensus contract must continue from the last replayed block, even if the sync stopped early
+	state, err := blockSyncState(app, last, lastResp, validators)
+	if err != nil {
+		return replayed, err
+	}
+	err = bootstrapConsensusContract(app, logger, app.InterfaceRegistry(), app.JSONCodec(), state)
+	if err != nil {
+		return replayed, err
+	}
+	logger.Info("block sync finished", "height", trusted.Height, "blocks", replayed)
+	return replayed, syncErr
 }
-func (*MockBlockSyncReactor) Reset() error {
-	return nil
+
+// replayBlock executes the block, like the consensus contract does through the FinalizeBlock and Commit host functions
+func replayBlock(app mcfg.MythosApp, b *BlockSyncBlock) (*abci.ResponseFinalizeBlock, error) {
+	bapp := app.GetBaseApp()
+	if bapp.LastBlockHeight()+1 != b.Block.Height {
+		return nil, fmt.Errorf("cannot replay block %d at height %d", b.Block.Height, bapp.LastBlockHeight())
+	}
+	cdc := app.GetNetworkKeeper().Codec()
+	var bmeta networktypes.RequestProcessProposalWithMetaInfo
+	err := cdc.UnmarshalJSON(b.Entry.Data, &bmeta)
+	if err != nil {
+		return nil, err
+	}
+	metainfo := map[string][]byte{}
+	for key, value := range bmeta.Metainfo {
+		metainfo[key] = []byte(value)
+	}
+	err = mctx.SetExecutionMetaInfo(app.GetGoContextParent(), cdc, metainfo)
+	if err != nil {
+		return nil, err
+	}
+
+	req := bmeta.Request
+	resp, err := bapp.FinalizeBlockSimple(&abci.RequestFinalizeBlock{
+		Txs:                req.Txs,
+		DecidedLastCommit:  req.ProposedLastCommit,
+		Misbehavior:        req.Misbehavior,
+		Hash:               req.Hash,
+		Height:             req.Height,
+		Time:               req.Time,
+		NextValidatorsHash: req.NextValidatorsHash,
+		ProposerAddress:    req.ProposerAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+	oe := bapp.GetOptimisticExecution()
+	if oe.Initialized() {
+		oe.Reset()
+	}
+	_, err = app.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
-func (*MockBlockSyncReactor) SetLogger(log.Logger) {
 
+// saveSyncedBlock adds the block to the node's block store, because synced blocks are not indexed by the consensus contract
+func saveSyncedBlock(app mcfg.MythosApp, logger log.Logger, b *BlockSyncBlock) {
+	store := app.GetBlockStore()
+	if store == nil {
+		return
+	}
+	if store.Height() != 0 && store.Height()+1 != b.Block.Height {
+		return
+	}
+	parts, err := b.Block.MakePartSet(cmttypes.BlockPartSizeBytes)
+	if err != nil {
+		logger.Error("cannot save synced block", "height", b.Block.Height, "error", err.Error())
+		return
+	}
+	store.SaveBlock(b.Block, parts, b.Commit)
 }
-func (*MockBlockSyncReactor) Start() error {
-	return nil
+
+// blockSyncState is the consensus state after the last replayed block
+func blockSyncState(app mcfg.MythosApp, last *BlockSyncBlock, resp *abci.ResponseFinalizeBlock, validators *BlockSyncValidators) (sm.State, error) {
+	bapp := app.GetBaseApp()
+	height := last.Block.Height
+
+	cb := func(goctx context.Context) (any, error) {
+		return bapp.GetConsensusParams(sdk.UnwrapSDKContext(goctx)), nil
+	}
+	res, err := app.GetActionExecutor().ExecuteWithMockHeader(context.Background(), sdk.ExecModeQuery, cb)
+	if err != nil {
+		return sm.State{}, err
+	}
+	params := cmttypes.ConsensusParamsFromProto(res.(cmtproto.ConsensusParams))
+
+	return sm.State{
+		Version: cmtstate.Version{
+			Consensus: last.Block.Version,
+		},
+		ChainID:                          bapp.ChainID(),
+		InitialHeight:                    1,
+		LastBlockHeight:                  height,
+		LastBlockID:                      last.Commit.BlockID,
+		LastBlockTime:                    last.Block.Time,
+		NextValidators:                   validators.NextValidators.Copy(),
+		Validators:                       validators.Validators.Copy(),
+		LastValidators:                   last.Validators,
+		LastHeightValidatorsChanged:      validators.LastHeightChanged,
+		ConsensusParams:                  params,
+		LastHeightConsensusParamsChanged: height + 1,
+		LastResultsHash:                  sm.TxResultsHash(resp.TxResults),
+		AppHash:                          bapp.LastCommitID().Hash,
+	}, nil
 }
-func (*MockBlockSyncReactor) Stop() error {
-	return nil
+
+// BlockSyncReactor catches up with the blocks finalized after the state sync snapshot
+// and then starts the consensus contract
+type BlockSyncReactor struct {
+	goContextParent context.Context
+	logger          log.Logger
+	chainId         string
+	protocolId      string
+	peerAddress     string
 }
-func (*MockBlockSyncReactor) String() string {
-	return ""
+
+func NewBlockSyncReactor(goContextParent context.Context, logger log.Logger, chainId string, peerAddress string) *BlockSyncReactor {
+	return &BlockSyncReactor{
+		goContextParent: goContextParent,
+		logger:          logger,
+		chainId:         chainId,
+		protocolId:      mcfg.GetBlockSyncProtocolId(chainId),
+		peerAddress:     peerAddress,
+	}
+}
+
+func (r *BlockSyncReactor) SwitchToBlockSync(state sm.State) error {
+	multichainapp, err := mcfg.GetMultiChainApp(r.goContextParent)
+	if err != nil {
+		return err
+	}
+	iapp, err := multichainapp.GetApp(r.chainId)
+	if err != nil {
+		return err
+	}
+	app, ok := iapp.(mcfg.MythosApp)
+	if !ok {
+		return fmt.Errorf("cannot block sync: chain %s is not a MythosApp", r.chainId)
+	}
+
+	trusted := BlockSyncAnchor{
+		Height:             state.LastBlockHeight,
+		BlockHash:          state.LastBlockID.Hash,
+		NextValidatorsHash: state.Validators.Hash(),
+	}
+	validators := &BlockSyncValidators{
+		Validators:        state.Validators.Copy(),
+		NextValidators:    state.NextValidators.Copy(),
+		LastHeightChanged: state.LastHeightValidatorsChanged,
+	}
+	_, err = syncBlocks(r.goContextParent, r.logger, app, r.protocolId, r.peerAddress, trusted, validators)
+	if err != nil {
+		// the consensus contract can still catch up from the state sync height
+		r.logger.Error("block sync failed", "height", app.GetBaseApp().LastBlockHeight(), "error", err.Error())
+	}
+	return networkserver.StartNode(app, r.logger, app.GetNetworkKeeper())
 }
@@ -157,54 +157,6 @@ func (s StateStore) Bootstrap(state sm.State) error {
 	interfaceRegistry := s.InterfaceRegistry
 	jsonCdc := s.JsonCdc
 
-	nextValidators, err := cmtValidatorSetToWasmxValidatorSet(interfaceRegistry, state.NextValidators)
-	if err != nil {
-		return err
-	}
-	nextValidatorsBz, err := jsonCdc.MarshalJSON(nextValidators)
-	if err != nil {
-		return err
-	}
-	validators, err := cmtValidatorSetToWasmxValidatorSet(interfaceRegistry, state.Validators)
-	if err != nil {
-		return err
-	}
-	validatorsBz, err := jsonCdc.MarshalJSON(validators)
-	if err != nil {
-		return err
-	}
-	lastValidators, err := cmtValidatorSetToWasmxValidatorSet(interfaceRegistry, state.LastValidators)
-	if err != nil {
-		return err
-	}
-	lastValidatorsBz, err := jsonCdc.MarshalJSON(lastValidators)
-	if err != nil {
-		return err
-	}
-
-	cstate := &State{
-		Version:                          state.Version,
-		ChainID:                          state.ChainID,
-		InitialHeight:                    state.InitialHeight,
-		LastBlockHeight:                  state.LastBlockHeight,
-		LastBlockID:                      state.LastBlockID,
-		LastBlockTime:                    state.LastBlockTime,
-		NextValidators:                   nextValidatorsBz,
-		Validators:                       validatorsBz,
-		LastValidators:                   lastValidatorsBz,
-		LastHeightValidatorsChanged:      state.LastHeightValidatorsChanged,
-		ConsensusParams:                  state.ConsensusParams,
-		LastHeightConsensusParamsChanged: state.LastHeightConsensusParamsChanged,
-		LastResultsHash:                  state.LastResultsHash,
-		AppHash:                          state.AppHash,
-	}
-
-	statebz, err := json.Marshal(cstate)
-	// fmt.Println("---StateStore.Bootstrap--", err, string(statebz))
-	if err != nil {
-		return err
-	}
-
 	multichainapp, err := mcfg.GetMultiChainApp(goContextParent)
 	if err != nil {
 		return err
@@ -259,24 +211,69 @@ func (s StateStore) Bootstrap(state sm.State) error {
 	// END version for external chains (mythos, level0)
 	// }
 
-	msg := []byte(fmt.Sprintf(`{"execute":{"action":{"type":"bootstrapAfterStateSync","params": [{"key":"state","value":"%s"}],"event":null}}}`, base64.StdEncoding.EncodeToString(statebz)))
-	err = networkserver.ConsensusTx(app, logger, app.GetNetworkKeeper(), msg)
+	err = bootstrapConsensusContract(app, logger, interfaceRegistry, jsonCdc, state)
 	if err != nil {
 		return err
 	}
 
-	// s.GoContextParent.
-	// return nil
-
 	// TODO send a message to provider to stop state sync
 
-	err = networkserver.StartNode(app, logger, app.GetNetworkKeeper())
+	// the node is started by the BlockSyncReactor, after it catches up with the latest blocks
+	// app.DebugDb()
+	return nil
+}
+
+// bootstrapConsensusContract sets the consensus contract state, after the application state was synced
+func bootstrapConsensusContract(app mcfg.MythosApp, logger log.Logger, interfaceRegistry cdctypes.InterfaceRegistry, jsonCdc codec.JSONCodec, state sm.State) error {
+	nextValidators, err := cmtValidatorSetToWasmxValidatorSet(interfaceRegistry, state.NextValidators)
+	if err != nil {
+		return err
+	}
+	nextValidatorsBz, err := jsonCdc.MarshalJSON(nextValidators)
+	if err != nil {
+		return err
+	}
+	validators, err := cmtValidatorSetToWasmxValidatorSet(interfaceRegistry, state.Validators)
+	if err != nil {
+		return err
+	}
+	validatorsBz, err := jsonCdc.MarshalJSON(validators)
+	if err != nil {
+		return err
+	}
+	lastValidators, err := cmtValidatorSetToWasmxValidatorSet(interfaceRegistry, state.LastValidators)
+	if err != nil {
+		return err
+	}
+	lastValidatorsBz, err := jsonCdc.MarshalJSON(lastValidators)
 	if err != nil {
 		return err
 	}
 
-	// app.DebugDb()
-	return nil
+	cstate := &State{
+		Version:                          state.Version,
+		ChainID:                          state.ChainID,
+		InitialHeight:                    state.InitialHeight,
+		LastBlockHeight:                  state.LastBlockHeight,
+		LastBlockID:                      state.LastBlockID,
+		LastBlockTime:                    state.LastBlockTime,
+		NextValidators:                   nextValidatorsBz,
+		Validators:                       validatorsBz,
+		LastValidators:                   lastValidatorsBz,
+		LastHeightValidatorsChanged:      state.LastHeightValidatorsChanged,
+		ConsensusParams:                  state.ConsensusParams,
+		LastHeightConsensusParamsChanged: state.LastHeightConsensusParamsChanged,
+		LastResultsHash:                  state.LastResultsHash,
+		AppHash:                          state.AppHash,
+	}
+
+	statebz, err := json.Marshal(cstate)
+	if err != nil {
+		return err
+	}
+
+	msg := []byte(fmt.Sprintf(`{"execute":{"action":{"type":"bootstrapAfterStateSync","params": [{"key":"state","value":"%s"}],"event":null}}}`, base64.StdEncoding.EncodeToString(statebz)))
+	return networkserver.ConsensusTx(app, logger, app.GetNetworkKeeper(), msg)
 }
 
 // PruneStates takes the height from which to start pruning and which height stop at
@@ -47,7 +47,7 @@ type StateSyncContext struct {
 	ProtocolId        string
 	AbciClient        abcicli.Client
 	StateSyncReactor  *statesync.Reactor
-	BcReactor         *MockBlockSyncReactor
+	BcReactor         *BlockSyncReactor
 	StateSyncProvider statesync.StateProvider
 	StateStore        *StateStore
 	StateSyncGenesis  sm.State
@@ -408,7 +408,7 @@ func InitializeStateSync(
 	peer := NewPeer(peeraddress, stream, peerInfo, protocolId, p2pctx, connectToPeerFn)
 
 	logger := servercmtlog.CometLoggerWrapper{Logger: sdklogger}
-	bcReactor := &MockBlockSyncReactor{}
+	bcReactor := NewBlockSyncReactor(goContextParent, sdklogger, chainId, peeraddress)
 	metricsProvider := node.DefaultMetricsProvider(ctndcfg.Instrumentation)
 	_, p2pMetrics, _, _, proxyMetrics, _, ssMetrics := metricsProvider(chainId)
 
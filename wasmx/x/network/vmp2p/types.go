@@ -14,6 +14,7 @@ import (
 
 	"cosmossdk.io/log"
 
+	networktypes "github.com/loredanacirstea/wasmx/x/network/types"
 	vmtypes "github.com/loredanacirstea/wasmx/x/wasmx/vm"
 )
 
@@ -181,6 +182,17 @@ type StartStateSyncRespResponse struct {
 	Error string `json:"error"`
 }
 
+type BlockSyncRequest struct {
+	StartHeight int64 `json:"start_height"`
+	EndHeight   int64 `json:"end_height"`
+}
+
+type BlockSyncResponse struct {
+	Blocks     []networktypes.BlockSyncEntry `json:"blocks"`
+	LastHeight int64                         `json:"last_height"`
+	Error      string                        `json:"error"`
+}
+
 type MsgStart struct {
 	PrivateKey []byte     `json:"pk"`
 	ProtocolId string     `json:"protocolId"`
@@ -0,0 +1,158 @@
+package vmhttpclient
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResponseCache is a least recently used cache of GET responses.
+// Responses are served for the cache TTL and then revalidated with their ETag or Last-Modified headers.
+type ResponseCache struct {
+	mtx     sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type cacheEntry struct {
+	key          string
+	response     HttpResponse
+	etag         string
+	lastModified string
+	expires      time.Time
+}
+
+func NewResponseCache(size int, ttl time.Duration) *ResponseCache {
+	return &ResponseCache{
+		size:    size,
+		ttl:     ttl,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// responseCacheKey identifies a request of a contract; contracts do not share cached responses
+func responseCacheKey(address string, req HttpRequest) string {
+	bz, _ := json.Marshal(struct {
+		Address string
+		Url     string
+		Header  http.Header
+	}{address, req.Url, req.Header})
+	hash := sha256.Sum256(bz)
+	return hex.EncodeToString(hash[:])
+}
+
+// get returns a copy of the cached entry, or nil
+func (c *ResponseCache) get(key string) *cacheEntry {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(elem)
+	entry := *elem.Value.(*cacheEntry)
+	return &entry
+}
+
+// put caches a successful response, unless the server does not allow storing it
+func (c *ResponseCache) put(key string, response HttpResponse) {
+	if response.StatusCode != http.StatusOK {
+		return
+	}
+	ttl, ok := c.lifetime(response.Header)
+	if !ok {
+		return
+	}
+	entry := &cacheEntry{
+		key:          key,
+		response:     response,
+		etag:         response.Header.Get("ETag"),
+		lastModified: response.Header.Get("Last-Modified"),
+		expires:      time.Now().Add(ttl),
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		last := c.order.Back()
+		c.order.Remove(last)
+		delete(c.entries, last.Value.(*cacheEntry).key)
+	}
+}
+
+// refresh extends a revalidated entry, with the headers of the not modified response
+func (c *ResponseCache) refresh(key string, header http.Header) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	ttl, ok := c.lifetime(header)
+	if !ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return
+	}
+	entry := elem.Value.(*cacheEntry)
+	if etag := header.Get("ETag"); etag != "" {
+		entry.etag = etag
+	}
+	entry.expires = time.Now().Add(ttl)
+}
+
+// lifetime returns how long a response can be served from the cache, at most the cache TTL.
+// Responses that are private, or must be revalidated before each use, are not cached.
+func (c *ResponseCache) lifetime(header http.Header) (time.Duration, bool) {
+	ttl := c.ttl
+	for _, directive := range strings.Split(strings.Join(header.Values("Cache-Control"), ","), ",") {
+		name, value, _ := strings.Cut(strings.ToLower(strings.TrimSpace(directive)), "=")
+		switch name {
+		case "no-store", "no-cache", "private":
+			return 0, false
+		case "max-age", "s-maxage":
+			seconds, err := strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+			if err != nil || seconds <= 0 {
+				return 0, false
+			}
+			if seconds < int64(ttl/time.Second) {
+				ttl = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return ttl, true
+}
+
+func (e *cacheEntry) fresh() bool {
+	return time.Now().Before(e.expires)
+}
+
+// setValidators makes the request conditional on the cached response having changed
+func (e *cacheEntry) setValidators(req *http.Request) {
+	if e.etag != "" {
+		req.Header.Set("If-None-Match", e.etag)
+	}
+	if e.lastModified != "" {
+		req.Header.Set("If-Modified-Since", e.lastModified)
+	}
+}
+
+func (e *cacheEntry) cachedResponse() *HttpResponse {
+	response := e.response
+	response.Cached = true
+	return &response
+}
@@ -0,0 +1,293 @@
+package vmhttpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const maxRedirects = 10
+
+// minLimiterSweep is the number of rate limiters a client holds before it removes the idle ones
+const minLimiterSweep = 1024
+
+// Config defines the http clients of contracts
+type Config struct {
+	// Default is the policy of contracts without their own policy
+	Default Policy
+	// Policies are keyed by contract address or role; the address has priority
+	Policies map[string]Policy
+	// DataDir is the absolute directory of downloaded files; each contract has a subdirectory.
+	// Empty disables downloads.
+	DataDir string
+	// CacheSize is the number of cached responses. 0 disables the cache.
+	CacheSize int
+	// CacheTTL is how long a cached response is served without revalidation
+	CacheTTL time.Duration
+}
+
+// HttpClientContext holds the http clients shared by all contracts
+type HttpClientContext struct {
+	DataDir  string
+	Cache    *ResponseCache
+	Default  *Client
+	Policies map[string]*Client
+}
+
+// Client makes the requests of contracts with the same policy
+type Client struct {
+	Policy Policy
+	client *http.Client
+
+	mtx      sync.Mutex
+	limiters map[string]*rate.Limiter
+	sweepAt  int
+}
+
+// NewHttpClientContext validates the configuration and builds the clients
+func NewHttpClientContext(cfg Config) (*HttpClientContext, error) {
+	if cfg.DataDir != "" && !filepath.IsAbs(cfg.DataDir) {
+		return nil, fmt.Errorf("http data directory must be an absolute path: %s", cfg.DataDir)
+	}
+	if cfg.CacheSize < 0 {
+		return nil, fmt.Errorf("http cache size cannot be negative")
+	}
+	if cfg.CacheTTL < 0 {
+		return nil, fmt.Errorf("http cache ttl cannot be negative")
+	}
+	defaultClient, err := NewClient(cfg.Default)
+	if err != nil {
+		return nil, err
+	}
+	vctx := &HttpClientContext{
+		DataDir:  cfg.DataDir,
+		Default:  defaultClient,
+		Policies: map[string]*Client{},
+	}
+	for key, policy := range cfg.Policies {
+		client, err := NewClient(policy)
+		if err != nil {
+			return nil, fmt.Errorf("http policy %s: %v", key, err)
+		}
+		vctx.Policies[key] = client
+	}
+	if cfg.CacheSize > 0 {
+		vctx.Cache = NewResponseCache(cfg.CacheSize, cfg.CacheTTL)
+	}
+	return vctx, nil
+}
+
+// Client returns the client of a contract, by its address or role
+func (h *HttpClientContext) Client(address string, role string) *Client {
+	if client, ok := h.Policies[address]; ok {
+		return client
+	}
+	if client, ok := h.Policies[role]; ok && role != "" {
+		return client
+	}
+	return h.Default
+}
+
+// NewClient returns a client enforcing the policy
+func NewClient(policy Policy) (*Client, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if policy.BlockPrivateIPs {
+		dialer.Control = dialControl
+	}
+	transport := &http.Transport{
+		// no proxy from the environment, so connections are checked against the policy
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       &tls.Config{MinVersion: tls.VersionTLS12},
+	}
+	c := &Client{
+		Policy:   policy,
+		limiters: map[string]*rate.Limiter{},
+		sweepAt:  minLimiterSweep,
+	}
+	c.client = &http.Client{
+		Transport: transport,
+		Timeout:   policy.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return policy.CheckUrl(req.URL)
+		},
+	}
+	return c, nil
+}
+
+// Allow reports whether the contract can make a request now
+func (c *Client) Allow(address string) bool {
+	if c.Policy.RateLimit == 0 {
+		return true
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	limiter, ok := c.limiters[address]
+	if !ok {
+		if len(c.limiters) >= c.sweepAt {
+			c.sweepLimiters()
+		}
+		limiter = rate.NewLimiter(rate.Limit(c.Policy.RateLimit), c.Policy.RateBurst)
+		c.limiters[address] = limiter
+	}
+	return limiter.Allow()
+}
+
+// sweepLimiters removes the limiters with a full burst, which allow the same requests as a new limiter.
+// The next sweep runs when the number of limiters doubles, so sweeps take amortized constant time.
+func (c *Client) sweepLimiters() {
+	now := time.Now()
+	for address, limiter := range c.limiters {
+		if limiter.TokensAt(now) >= float64(c.Policy.RateBurst) {
+			delete(c.limiters, address)
+		}
+	}
+	c.sweepAt = max(2*len(c.limiters), minLimiterSweep)
+}
+
+// Limiters returns the number of contracts with a rate limiter
+func (c *Client) Limiters() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return len(c.limiters)
+}
+
+// HTTPClient returns an http client for libraries which send requests for a contract, like oauth2.
+// Each request, including redirects, is checked against the policy and the rate limit
+// of the contract, and response bodies are limited to the policy max body size.
+func (h *HttpClientContext) HTTPClient(address string, role string) *http.Client {
+	client := h.Client(address, role)
+	return &http.Client{
+		Transport:     &policyTransport{client: client, address: address},
+		Timeout:       client.client.Timeout,
+		CheckRedirect: client.client.CheckRedirect,
+	}
+}
+
+// policyTransport enforces the client policy on requests of other http clients,
+// which can wrap it with their own transport
+type policyTransport struct {
+	client  *Client
+	address string
+}
+
+func (t *policyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	err := t.client.Policy.CheckUrl(req.URL)
+	if err == nil && !t.client.Allow(t.address) {
+		err = fmt.Errorf("http request rate limit exceeded")
+	}
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	resp, err := t.client.client.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if limit := t.client.Policy.MaxBodySize; limit > 0 {
+		if resp.ContentLength > limit {
+			resp.Body.Close()
+			return nil, errBodyTooLarge
+		}
+		resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: limit}
+	}
+	return resp, nil
+}
+
+var errBodyTooLarge = fmt.Errorf("http response body exceeds max length")
+
+// limitedBody fails reading a response body longer than the limit
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.remaining {
+		n = int(b.remaining)
+		b.remaining = 0
+		return n, errBodyTooLarge
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
+
+// Do sends the request of a contract and reads the response, as the response handler requests
+func (h *HttpClientContext) Do(ctx context.Context, address string, role string, reqw HttpRequestWrap) (*HttpResponse, error) {
+	client := h.Client(address, role)
+	httpreq, err := BuildHttpRequest(ctx, reqw.Request)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Policy.CheckUrl(httpreq.URL); err != nil {
+		return nil, err
+	}
+
+	var cacheKey string
+	var cached *cacheEntry
+	useCache := h.Cache != nil && !reqw.ResponseHandler.NoCache && reqw.ResponseHandler.FilePath == "" && httpreq.Method == http.MethodGet
+	if useCache {
+		cacheKey = responseCacheKey(address, reqw.Request)
+		cached = h.Cache.get(cacheKey)
+		if cached != nil && cached.fresh() {
+			return cached.cachedResponse(), nil
+		}
+		if cached != nil {
+			cached.setValidators(httpreq)
+		}
+	}
+
+	if !client.Allow(address) {
+		return nil, fmt.Errorf("http request rate limit exceeded")
+	}
+	resp, err := client.client.Do(httpreq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	limit := client.Policy.bodyLimit(reqw.ResponseHandler.MaxSize)
+	if reqw.ResponseHandler.FilePath != "" {
+		return h.download(resp, address, reqw.ResponseHandler.FilePath, limit)
+	}
+	if useCache && cached != nil && resp.StatusCode == http.StatusNotModified {
+		h.Cache.refresh(cacheKey, resp.Header)
+		return cached.cachedResponse(), nil
+	}
+	r, err := readHttpResponse(resp, limit)
+	if err != nil {
+		return nil, err
+	}
+	if useCache {
+		h.Cache.put(cacheKey, *r)
+	}
+	return r, nil
+}
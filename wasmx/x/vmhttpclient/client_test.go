@@ -0,0 +1,442 @@
+package vmhttpclient_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/loredanacirstea/wasmx/x/vmhttpclient"
+)
+
+const contractA = "mythos1contracta"
+const contractB = "mythos1contractb"
+
+// testPolicy allows requests to the httptest server
+func testPolicy() vmhttpclient.Policy {
+	policy := vmhttpclient.DefaultPolicy()
+	policy.BlockPrivateIPs = false
+	policy.RateLimit = 0
+	return policy
+}
+
+func newTestContext(t *testing.T, cfg vmhttpclient.Config) *vmhttpclient.HttpClientContext {
+	vctx, err := vmhttpclient.NewHttpClientContext(cfg)
+	require.NoError(t, err)
+	return vctx
+}
+
+func get(u string) vmhttpclient.HttpRequestWrap {
+	return vmhttpclient.HttpRequestWrap{Request: vmhttpclient.HttpRequest{Method: "GET", Url: u}}
+}
+
+func TestPolicyCheckUrl(t *testing.T) {
+	policy := vmhttpclient.DefaultPolicy()
+	policy.AllowedSchemes = []string{"https"}
+	policy.AllowedHosts = []string{"api.example.com", "*.example.org"}
+	require.NoError(t, policy.Validate())
+
+	for u, expErr := range map[string]string{
+		"https://api.example.com/v1":      "",
+		"https://API.example.com:8443/v1": "",
+		"https://a.b.example.org/":        "",
+		"https://example.org/":            "http host not allowed: example.org",
+		"https://example.com/":            "http host not allowed: example.com",
+		"https://api.example.com.evil/":   "http host not allowed: api.example.com.evil",
+		"http://api.example.com/":         "http scheme not allowed: http",
+		"file:///etc/passwd":              "http scheme not allowed: file",
+	} {
+		parsed, err := url.Parse(u)
+		require.NoError(t, err)
+		err = policy.CheckUrl(parsed)
+		if expErr == "" {
+			require.NoError(t, err, u)
+		} else {
+			require.EqualError(t, err, expErr, u)
+		}
+	}
+
+	policy.AllowedHosts = []string{"*."}
+	require.ErrorContains(t, policy.Validate(), "invalid allowed host")
+	policy.AllowedHosts = []string{}
+	policy.RateLimit = 1
+	policy.RateBurst = 0
+	require.ErrorContains(t, policy.Validate(), "http rate burst must be at least 1")
+}
+
+func TestRequestPolicies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "http://example.invalid/", http.StatusFound)
+			return
+		}
+		fmt.Fprint(w, "hello")
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	// httptest listens on a loopback address
+	vctx := newTestContext(t, vmhttpclient.Config{Default: vmhttpclient.DefaultPolicy()})
+	_, err := vctx.Do(ctx, contractA, "", get(srv.URL))
+	require.ErrorContains(t, err, "http connection to private address not allowed: 127.0.0.1")
+
+	restricted := testPolicy()
+	restricted.AllowedHosts = []string{"example.com"}
+	limited := testPolicy()
+	limited.RateLimit = 1
+	limited.RateBurst = 2
+	vctx = newTestContext(t, vmhttpclient.Config{
+		Default:  testPolicy(),
+		Policies: map[string]vmhttpclient.Policy{contractB: restricted, "limited": limited},
+	})
+	resp, err := vctx.Do(ctx, contractA, "", get(srv.URL))
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+	require.Equal(t, "hello", string(resp.Data))
+
+	// contract policies have priority over role policies
+	_, err = vctx.Do(ctx, contractB, "limited", get(srv.URL))
+	require.ErrorContains(t, err, "http host not allowed: 127.0.0.1")
+
+	// redirects are checked against the policy
+	allowLocal := testPolicy()
+	allowLocal.AllowedHosts = []string{"127.0.0.1"}
+	vctx.Policies[contractB], err = vmhttpclient.NewClient(allowLocal)
+	require.NoError(t, err)
+	_, err = vctx.Do(ctx, contractB, "", get(srv.URL+"/redirect"))
+	require.ErrorContains(t, err, "http host not allowed: example.invalid")
+
+	// the rate limit applies to each contract with the role
+	for i := 0; i < 2; i++ {
+		_, err = vctx.Do(ctx, contractA, "limited", get(srv.URL))
+		require.NoError(t, err)
+	}
+	_, err = vctx.Do(ctx, contractA, "limited", get(srv.URL))
+	require.EqualError(t, err, "http request rate limit exceeded")
+	_, err = vctx.Do(ctx, "mythos1contractc", "limited", get(srv.URL))
+	require.NoError(t, err)
+}
+
+func TestHTTPClientPolicy(t *testing.T) {
+	body := make([]byte, 100)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"access_token":"token","token_type":"bearer"}`)
+		case "/chunked":
+			w.(http.Flusher).Flush()
+			w.Write(body)
+		default:
+			w.Write([]byte(r.Header.Get("Authorization")))
+		}
+	}))
+	defer srv.Close()
+
+	restricted := testPolicy()
+	restricted.AllowedHosts = []string{"example.com"}
+	policy := testPolicy()
+	policy.MaxBodySize = 50
+	policy.RateLimit = 1
+	policy.RateBurst = 3
+	vctx := newTestContext(t, vmhttpclient.Config{
+		Default:  policy,
+		Policies: map[string]vmhttpclient.Policy{contractB: restricted},
+	})
+
+	// the oauth2 library uses the client from the context for token and api requests
+	config := &oauth2.Config{Endpoint: oauth2.Endpoint{TokenURL: srv.URL + "/token"}}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, vctx.HTTPClient(contractB, ""))
+	_, err := config.Exchange(ctx, "code")
+	require.ErrorContains(t, err, "http host not allowed: 127.0.0.1")
+
+	ctx = context.WithValue(context.Background(), oauth2.HTTPClient, vctx.HTTPClient(contractA, ""))
+	token, err := config.Exchange(ctx, "code")
+	require.NoError(t, err)
+	client := config.Client(ctx, token)
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, "Bearer token", string(data))
+
+	resp, err = client.Get(srv.URL + "/chunked")
+	require.NoError(t, err)
+	_, err = io.ReadAll(resp.Body)
+	require.EqualError(t, err, "http response body exceeds max length")
+	resp.Body.Close()
+
+	// token and api requests count for the rate limit of the contract
+	_, err = client.Get(srv.URL)
+	require.ErrorContains(t, err, "http request rate limit exceeded")
+}
+
+func TestRequestDeadline(t *testing.T) {
+	canceled := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(canceled)
+	}))
+	defer srv.Close()
+
+	vctx := newTestContext(t, vmhttpclient.Config{Default: testPolicy()})
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := vctx.Do(ctx, contractA, "", get(srv.URL))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 5*time.Second)
+	// the request is aborted, not left running on the server
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not canceled")
+	}
+}
+
+func TestPrivateAddresses(t *testing.T) {
+	vctx := newTestContext(t, vmhttpclient.Config{Default: vmhttpclient.DefaultPolicy()})
+	for _, host := range []string{
+		"0.1.2.3",
+		"10.0.0.1",
+		"100.64.0.1",
+		"169.254.169.254",
+		"198.18.0.1",
+		"224.0.0.1",
+		"255.255.255.255",
+		"[::1]",
+		"[::ffff:127.0.0.1]",
+		"[64:ff9b::a00:1]",
+		"[fd00::1]",
+		"[ff02::1]",
+	} {
+		_, err := vctx.Do(context.Background(), contractA, "", get("http://"+host+"/"))
+		require.ErrorContains(t, err, "http connection to private address not allowed", host)
+	}
+}
+
+func TestRateLimiters(t *testing.T) {
+	policy := testPolicy()
+	policy.RateLimit = 10
+	policy.RateBurst = 1
+	client, err := vmhttpclient.NewClient(policy)
+	require.NoError(t, err)
+
+	for i := 0; i < 1024; i++ {
+		require.True(t, client.Allow(fmt.Sprintf("mythos1contract%d", i)))
+	}
+	require.Equal(t, 1024, client.Limiters())
+	require.False(t, client.Allow("mythos1contract1023"))
+
+	// limiters that refilled their burst are removed when the next contract is added
+	time.Sleep(200 * time.Millisecond)
+	require.True(t, client.Allow("mythos1contractnew"))
+	require.Equal(t, 1, client.Limiters())
+}
+
+func TestRequestMaxBodySize(t *testing.T) {
+	body := make([]byte, 100)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			// flushing before writing the body omits the Content-Length
+			w.(http.Flusher).Flush()
+		}
+		w.Write(body)
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	policy := testPolicy()
+	policy.MaxBodySize = 50
+	vctx := newTestContext(t, vmhttpclient.Config{Default: policy})
+	for _, path := range []string{"/", "/chunked"} {
+		_, err := vctx.Do(ctx, contractA, "", get(srv.URL+path))
+		require.EqualError(t, err, "http response body exceeds max length", path)
+	}
+
+	policy.MaxBodySize = 0
+	vctx = newTestContext(t, vmhttpclient.Config{Default: policy})
+	resp, err := vctx.Do(ctx, contractA, "", get(srv.URL+"/chunked"))
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 100)
+
+	reqw := get(srv.URL + "/chunked")
+	reqw.ResponseHandler.MaxSize = 99
+	_, err = vctx.Do(ctx, contractA, "", reqw)
+	require.EqualError(t, err, "http response body exceeds max length")
+}
+
+func TestRequestCache(t *testing.T) {
+	var hits, notModified atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		switch r.URL.Path {
+		case "/nostore":
+			w.Header().Set("Cache-Control", "no-store")
+		case "/nocache":
+			w.Header().Set("Cache-Control", "no-cache")
+		case "/private":
+			w.Header().Set("Cache-Control", "private, max-age=60")
+		case "/maxage0":
+			w.Header().Set("Cache-Control", "public, max-age=0")
+		}
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, "cached body")
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	vctx := newTestContext(t, vmhttpclient.Config{Default: testPolicy(), CacheSize: 10, CacheTTL: time.Hour})
+	resp, err := vctx.Do(ctx, contractA, "", get(srv.URL))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+	resp, err = vctx.Do(ctx, contractA, "", get(srv.URL))
+	require.NoError(t, err)
+	require.True(t, resp.Cached)
+	require.Equal(t, "cached body", string(resp.Data))
+	require.Equal(t, int32(1), hits.Load())
+
+	// contracts do not share cached responses
+	resp, err = vctx.Do(ctx, contractB, "", get(srv.URL))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+
+	reqw := get(srv.URL)
+	reqw.ResponseHandler.NoCache = true
+	resp, err = vctx.Do(ctx, contractA, "", reqw)
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+	require.Equal(t, int32(3), hits.Load())
+
+	// expired responses are revalidated with their ETag
+	vctx = newTestContext(t, vmhttpclient.Config{Default: testPolicy(), CacheSize: 10, CacheTTL: 0})
+	hits.Store(0)
+	_, err = vctx.Do(ctx, contractA, "", get(srv.URL))
+	require.NoError(t, err)
+	resp, err = vctx.Do(ctx, contractA, "", get(srv.URL))
+	require.NoError(t, err)
+	require.True(t, resp.Cached)
+	require.Equal(t, 200, resp.StatusCode)
+	require.Equal(t, "cached body", string(resp.Data))
+	require.Equal(t, int32(2), hits.Load())
+	require.Equal(t, int32(1), notModified.Load())
+
+	// responses the server does not allow to share or reuse without revalidation are not cached
+	for _, path := range []string{"/nostore", "/nocache", "/private", "/maxage0"} {
+		for i := 0; i < 2; i++ {
+			resp, err = vctx.Do(ctx, contractA, "", get(srv.URL+path))
+			require.NoError(t, err)
+			require.False(t, resp.Cached, path)
+		}
+	}
+	require.Equal(t, int32(1), notModified.Load())
+}
+
+func TestRequestMultipartForm(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseMultipartForm(1 << 20)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		file, header, err := r.FormFile("upload")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		data := make([]byte, header.Size)
+		file.Read(data)
+		fmt.Fprintf(w, "%s|%v|%s|%s|%s", r.FormValue("name"), r.Form["tag"], header.Filename, header.Header.Get("Content-Type"), data)
+	}))
+	defer srv.Close()
+
+	vctx := newTestContext(t, vmhttpclient.Config{Default: testPolicy()})
+	reqw := vmhttpclient.HttpRequestWrap{Request: vmhttpclient.HttpRequest{
+		Method: "POST",
+		Url:    srv.URL,
+		MultipartForm: &vmhttpclient.MultipartForm{
+			Value: map[string][]string{"name": {"report"}, "tag": {"a", "b"}},
+			File:  []vmhttpclient.MultipartFile{{FieldName: "upload", FileName: "data.csv", ContentType: "text/csv", Data: []byte("1,2,3")}},
+		},
+	}}
+	resp, err := vctx.Do(context.Background(), contractA, "", reqw)
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Data))
+	require.Equal(t, "report|[a b]|data.csv|text/csv|1,2,3", string(resp.Data))
+
+	reqw.Request.Data = []byte("data")
+	_, err = vctx.Do(context.Background(), contractA, "", reqw)
+	require.EqualError(t, err, "http request cannot have both data and a multipart form")
+}
+
+func TestRequestDownload(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		fmt.Fprint(w, `{"a":1}`)
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	download := func(path string, filePath string) vmhttpclient.HttpRequestWrap {
+		reqw := get(srv.URL + path)
+		reqw.ResponseHandler.FilePath = filePath
+		return reqw
+	}
+
+	vctx := newTestContext(t, vmhttpclient.Config{Default: testPolicy()})
+	_, err := vctx.Do(ctx, contractA, "", download("/", "data"))
+	require.EqualError(t, err, "http file downloads are disabled")
+
+	dataDir := t.TempDir()
+	vctx = newTestContext(t, vmhttpclient.Config{Default: testPolicy(), DataDir: dataDir})
+	resp, err := vctx.Do(ctx, contractA, "", download("/", "files/data"))
+	require.NoError(t, err)
+	require.Equal(t, "files/data.json", resp.FilePath)
+	require.Equal(t, int64(7), resp.ContentLength)
+	require.Empty(t, resp.Data)
+	bz, err := os.ReadFile(filepath.Join(dataDir, contractA, "files", "data.json"))
+	require.NoError(t, err)
+	require.Equal(t, `{"a":1}`, string(bz))
+
+	for _, filePath := range []string{"../data", "/tmp/data", "files/../../data"} {
+		_, err = vctx.Do(ctx, contractA, "", download("/", filePath))
+		require.EqualError(t, err, "invalid http download path: "+filePath)
+	}
+
+	// unsuccessful responses are not written to a file
+	resp, err = vctx.Do(ctx, contractA, "", download("/missing", "missing.txt"))
+	require.NoError(t, err)
+	require.Equal(t, 404, resp.StatusCode)
+	require.Equal(t, "", resp.FilePath)
+	require.Contains(t, string(resp.Data), "404 page not found")
+	require.NoFileExists(t, filepath.Join(dataDir, contractA, "missing.txt"))
+
+	reqw := download("/", "small.json")
+	reqw.ResponseHandler.MaxSize = 3
+	_, err = vctx.Do(ctx, contractA, "", reqw)
+	require.EqualError(t, err, "http response body exceeds max length")
+	entries, err := os.ReadDir(filepath.Join(dataDir, contractA))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
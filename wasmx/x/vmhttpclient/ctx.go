@@ -0,0 +1,29 @@
+package vmhttpclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithHttpClientEmptyContext sets an http client context with the default policy,
+// without downloads or a response cache
+func WithHttpClientEmptyContext(ctx context.Context) context.Context {
+	vctx, err := NewHttpClientContext(Config{Default: DefaultPolicy()})
+	if err != nil {
+		panic(err)
+	}
+	return context.WithValue(ctx, HttpClientContextKey, vctx)
+}
+
+func WithHttpClientContext(ctx context.Context, vctx *HttpClientContext) context.Context {
+	return context.WithValue(ctx, HttpClientContextKey, vctx)
+}
+
+func GetHttpClientContext(goContextParent context.Context) (*HttpClientContext, error) {
+	vctx_ := goContextParent.Value(HttpClientContextKey)
+	vctx, ok := (vctx_).(*HttpClientContext)
+	if !ok || vctx == nil {
+		return nil, fmt.Errorf("httpclient context not set")
+	}
+	return vctx, nil
+}
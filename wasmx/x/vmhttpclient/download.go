@@ -0,0 +1,78 @@
+package vmhttpclient
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// download streams a successful response body to filePath, in the data directory of the contract.
+// Unsuccessful responses are read in memory, so the contract can inspect them.
+func (h *HttpClientContext) download(resp *http.Response, address string, filePath string, limit int64) (*HttpResponse, error) {
+	if h.DataDir == "" {
+		return nil, fmt.Errorf("http file downloads are disabled")
+	}
+	if !filepath.IsLocal(filePath) {
+		return nil, fmt.Errorf("invalid http download path: %s", filePath)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return readHttpResponse(resp, limit)
+	}
+	if limit > 0 && limit < resp.ContentLength {
+		return nil, fmt.Errorf("http response body exceeds max length")
+	}
+	filePath = filepath.Clean(filePath)
+	if filepath.Ext(filePath) == "" {
+		filePath += contentTypeExtension(resp.Header.Get("Content-Type"))
+	}
+	path := filepath.Join(h.DataDir, address, filePath)
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	// written to a temporary file first, so a failed download does not replace an existing file
+	tmp, err := os.CreateTemp(dir, ".download-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	var body io.Reader = resp.Body
+	if limit > 0 {
+		body = io.LimitReader(resp.Body, limit+1)
+	}
+	n, err := io.Copy(tmp, body)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && n > limit {
+		return nil, fmt.Errorf("http response body exceeds max length")
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, err
+	}
+
+	response := newHttpResponse(resp)
+	response.ContentLength = n
+	response.FilePath = filepath.ToSlash(filePath)
+	return response, nil
+}
+
+// contentTypeExtension returns the file extension of a media type, or ""
+func contentTypeExtension(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	exts, err := mime.ExtensionsByType(mediaType)
+	if err != nil || len(exts) == 0 {
+		return ""
+	}
+	return exts[0]
+}
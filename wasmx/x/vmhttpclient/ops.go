@@ -2,16 +2,22 @@ package vmhttpclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
 
 	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
 )
 
 func Request(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	response := &HttpResponseWrap{Error: ""}
+	ctx := _context.(*Context)
 	keyptr, _ := memc.GetPointerFromParams(rnh, params, 0)
 	requestbz, err := rnh.ReadMemFromPtr(keyptr)
 	if err != nil {
@@ -23,22 +29,14 @@ func Request(_context interface{}, rnh memc.RuntimeHandler, params []interface{}
 		return nil, err
 	}
 
-	httpreq, err := BuildHttpRequest(reqw.Request)
+	vctx, err := GetHttpClientContext(ctx.Context.GoContextParent)
 	if err != nil {
-		response.Error = err.Error()
-		return prepareResponse(rnh, response)
-	}
-
-	// Make the request
-	client := &http.Client{}
-	resp, err := client.Do(httpreq)
-	if err != nil {
-		response.Error = err.Error()
-		return prepareResponse(rnh, response)
+		return nil, err
 	}
-	defer resp.Body.Close()
+	address := ctx.Env.Contract.Address
+	role := ctx.CosmosHandler.GetRoleByContractAddress(ctx.Ctx, address)
 
-	r, err := BuildHttpResponse(resp, reqw.ResponseHandler)
+	r, err := vctx.Do(ctx.Ctx, address.String(), role, reqw)
 	if err != nil {
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
@@ -55,8 +53,21 @@ func prepareResponse(rnh memc.RuntimeHandler, response interface{}) ([]interface
 	return rnh.AllocateWriteMem(responsebz)
 }
 
-func BuildHttpRequest(req HttpRequest) (*http.Request, error) {
-	httpreq, err := http.NewRequest(req.Method, req.Url, bytes.NewBuffer(req.Data))
+// BuildHttpRequest builds a request that is canceled when the execution context is done
+func BuildHttpRequest(ctx context.Context, req HttpRequest) (*http.Request, error) {
+	body := bytes.NewBuffer(req.Data)
+	contentType := ""
+	if req.MultipartForm != nil {
+		if len(req.Data) > 0 {
+			return nil, fmt.Errorf("http request cannot have both data and a multipart form")
+		}
+		var err error
+		body, contentType, err = buildMultipartBody(req.MultipartForm)
+		if err != nil {
+			return nil, err
+		}
+	}
+	httpreq, err := http.NewRequestWithContext(ctx, req.Method, req.Url, body)
 	if err != nil {
 		return nil, err
 	}
@@ -66,30 +77,92 @@ func BuildHttpRequest(req HttpRequest) (*http.Request, error) {
 			httpreq.Header.Add(key, value)
 		}
 	}
-	// TODO multipart uploads
+	if contentType != "" {
+		httpreq.Header.Set("Content-Type", contentType)
+	}
 	return httpreq, nil
 }
 
+// BuildHttpResponse reads the response body in memory
 func BuildHttpResponse(resp *http.Response, resph ResponseHandler) (*HttpResponse, error) {
-	response := &HttpResponse{
+	if resph.FilePath != "" {
+		return nil, fmt.Errorf("http file downloads are only supported by the httpclient Request")
+	}
+	return readHttpResponse(resp, resph.MaxSize)
+}
+
+func newHttpResponse(resp *http.Response) *HttpResponse {
+	return &HttpResponse{
 		Status:        resp.Status,
 		StatusCode:    resp.StatusCode,
 		ContentLength: resp.ContentLength,
 		Uncompressed:  resp.Uncompressed,
 		Header:        resp.Header,
 	}
+}
 
-	if resph.FilePath != "" {
-		// TODO download to file & set the apropriate extension based on Content-Type
-		return nil, fmt.Errorf("not implemented")
-	}
-	if resph.MaxSize > 0 && resph.MaxSize < resp.ContentLength {
+// readHttpResponse reads at most limit bytes of the response body; 0 means no limit
+func readHttpResponse(resp *http.Response, limit int64) (*HttpResponse, error) {
+	response := newHttpResponse(resp)
+	if limit > 0 && limit < resp.ContentLength {
 		return nil, fmt.Errorf("http response body exceeds max length")
 	}
-	body, err := io.ReadAll(resp.Body)
+	var body []byte
+	var err error
+	if limit > 0 {
+		body, err = io.ReadAll(io.LimitReader(resp.Body, limit+1))
+		if err == nil && int64(len(body)) > limit {
+			return nil, fmt.Errorf("http response body exceeds max length")
+		}
+	} else {
+		body, err = io.ReadAll(resp.Body)
+	}
 	if err != nil {
 		return nil, err
 	}
 	response.Data = body
 	return response, nil
 }
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func buildMultipartBody(form *MultipartForm) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	// sorted, so the body does not depend on map iteration order
+	keys := make([]string, 0, len(form.Value))
+	for key := range form.Value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range form.Value[key] {
+			if err := writer.WriteField(key, value); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	for _, file := range form.File {
+		if file.FieldName == "" {
+			return nil, "", fmt.Errorf("multipart file has no field name")
+		}
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(file.FieldName), quoteEscaper.Replace(file.FileName)))
+		header.Set("Content-Type", contentType)
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(file.Data); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return body, writer.FormDataContentType(), nil
+}
@@ -0,0 +1,173 @@
+package vmhttpclient
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Policy restricts the outbound requests of contracts
+type Policy struct {
+	// AllowedSchemes are the url schemes contracts can request
+	AllowedSchemes []string
+	// AllowedHosts are host names, or "*.domain" for all subdomains of domain. Empty allows any host.
+	AllowedHosts []string
+	// BlockPrivateIPs rejects connections to loopback, private, shared, link-local, multicast and reserved addresses
+	BlockPrivateIPs bool
+	// Timeout of a request, including redirects and reading the response body. 0 means no timeout.
+	Timeout time.Duration
+	// MaxBodySize is the maximum response body size. 0 means no limit.
+	MaxBodySize int64
+	// RateLimit is the number of requests per second a contract can make. 0 means no limit.
+	RateLimit float64
+	// RateBurst is the number of requests a contract can make at once
+	RateBurst int
+}
+
+// DefaultPolicy allows http and https requests to public addresses
+func DefaultPolicy() Policy {
+	return Policy{
+		AllowedSchemes:  []string{"https", "http"},
+		AllowedHosts:    []string{},
+		BlockPrivateIPs: true,
+		Timeout:         30 * time.Second,
+		MaxBodySize:     10 << 20,
+		RateLimit:       10,
+		RateBurst:       20,
+	}
+}
+
+// Validate returns an error if the policy fields are invalid
+func (p Policy) Validate() error {
+	if len(p.AllowedSchemes) == 0 {
+		return fmt.Errorf("no allowed http schemes")
+	}
+	for _, scheme := range p.AllowedSchemes {
+		if scheme != "http" && scheme != "https" {
+			return fmt.Errorf("unsupported http scheme: %s", scheme)
+		}
+	}
+	for _, host := range p.AllowedHosts {
+		if strings.TrimPrefix(host, "*.") == "" || strings.ContainsAny(host, "/:") {
+			return fmt.Errorf("invalid allowed host: %q", host)
+		}
+	}
+	if p.Timeout < 0 {
+		return fmt.Errorf("http timeout cannot be negative")
+	}
+	if p.MaxBodySize < 0 {
+		return fmt.Errorf("http max body size cannot be negative")
+	}
+	if p.RateLimit < 0 {
+		return fmt.Errorf("http rate limit cannot be negative")
+	}
+	if p.RateLimit > 0 && p.RateBurst < 1 {
+		return fmt.Errorf("http rate burst must be at least 1")
+	}
+	return nil
+}
+
+// CheckUrl returns an error if the policy does not allow requests to the url.
+// Private addresses are checked when connecting, after the host is resolved.
+func (p Policy) CheckUrl(u *url.URL) error {
+	if !slices.Contains(p.AllowedSchemes, u.Scheme) {
+		return fmt.Errorf("http scheme not allowed: %s", u.Scheme)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return fmt.Errorf("http request url has no host")
+	}
+	if !p.hostAllowed(host) {
+		return fmt.Errorf("http host not allowed: %s", host)
+	}
+	return nil
+}
+
+// bodyLimit returns the response body limit for a requested maximum size, or 0 for no limit
+func (p Policy) bodyLimit(maxSize int64) int64 {
+	if maxSize > 0 && (p.MaxBodySize == 0 || maxSize < p.MaxBodySize) {
+		return maxSize
+	}
+	return p.MaxBodySize
+}
+
+func (p Policy) hostAllowed(host string) bool {
+	if len(p.AllowedHosts) == 0 {
+		return true
+	}
+	for _, allowed := range p.AllowedHosts {
+		allowed = strings.ToLower(allowed)
+		if domain, ok := strings.CutPrefix(allowed, "*."); ok {
+			if strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+			continue
+		}
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// privatePrefixes are the address ranges contracts cannot connect to: non-public,
+// shared, benchmarking, multicast and reserved addresses, and the IPv6 ranges
+// that embed or translate to IPv4 addresses
+var privatePrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("169.254.0.0/16"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("192.88.99.0/24"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("224.0.0.0/4"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("::/96"), // unspecified, loopback and IPv4-compatible
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001::/23"),
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("2002::/16"),
+	netip.MustParsePrefix("fc00::/7"),
+	netip.MustParsePrefix("fe80::/10"),
+	netip.MustParsePrefix("fec0::/10"),
+	netip.MustParsePrefix("ff00::/8"),
+}
+
+// dialControl rejects connections to private addresses. It runs after name resolution,
+// so host names resolving to private addresses are rejected too.
+func dialControl(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil || isPrivateIP(ip) {
+		return fmt.Errorf("http connection to private address not allowed: %s", host)
+	}
+	return nil
+}
+
+// isPrivateIP reports whether the address is in privatePrefixes; IPv4-mapped IPv6 addresses are checked as IPv4
+func isPrivateIP(ip netip.Addr) bool {
+	ip = ip.Unmap().WithZone("")
+	for _, prefix := range privatePrefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
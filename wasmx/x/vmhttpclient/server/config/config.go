@@ -0,0 +1,181 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/cast"
+
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+
+	"github.com/loredanacirstea/wasmx/x/vmhttpclient"
+)
+
+// PolicyConfig restricts the outbound http requests of contracts
+type PolicyConfig struct {
+	// AllowedSchemes are the url schemes contracts can request: http, https
+	AllowedSchemes []string `mapstructure:"allowed-schemes"`
+	// AllowedHosts are host names, or "*.domain" for all subdomains of domain. Empty allows any host.
+	AllowedHosts []string `mapstructure:"allowed-hosts"`
+	// BlockPrivateIPs rejects connections to loopback, private, shared, link-local, multicast and reserved addresses
+	BlockPrivateIPs bool `mapstructure:"block-private-ips"`
+	// Timeout of a request. 0 means no timeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// MaxBodySize is the maximum response body size in bytes. 0 means no limit.
+	MaxBodySize int64 `mapstructure:"max-body-size"`
+	// RateLimit is the number of requests per second a contract can make. 0 means no limit.
+	RateLimit float64 `mapstructure:"rate-limit"`
+	// RateBurst is the number of requests a contract can make at once
+	RateBurst int `mapstructure:"rate-burst"`
+}
+
+// HttpClientConfig defines the outbound http requests of contracts with the httpclient host functions.
+type HttpClientConfig struct {
+	// PolicyConfig is the default policy
+	PolicyConfig `mapstructure:",squash"`
+	// Policies are keyed by contract address or role. Unset fields are taken from the default policy.
+	Policies map[string]PolicyConfig `mapstructure:"policies"`
+	// DataDir is the directory of downloaded files; relative paths are resolved from the node home.
+	// Empty disables downloads; the default, since downloaded files have no disk quota.
+	DataDir string `mapstructure:"data-dir"`
+	// CacheSize is the number of cached GET responses. 0 disables the cache.
+	CacheSize int `mapstructure:"cache-size"`
+	// CacheTTL is how long a cached response is served before it is revalidated
+	CacheTTL time.Duration `mapstructure:"cache-ttl"`
+}
+
+// DefaultPolicyConfig returns the default policy
+func DefaultPolicyConfig() PolicyConfig {
+	p := vmhttpclient.DefaultPolicy()
+	return PolicyConfig{
+		AllowedSchemes:  p.AllowedSchemes,
+		AllowedHosts:    p.AllowedHosts,
+		BlockPrivateIPs: p.BlockPrivateIPs,
+		Timeout:         p.Timeout,
+		MaxBodySize:     p.MaxBodySize,
+		RateLimit:       p.RateLimit,
+		RateBurst:       p.RateBurst,
+	}
+}
+
+// DefaultHttpClientConfig returns the default httpclient configuration
+func DefaultHttpClientConfig() *HttpClientConfig {
+	return &HttpClientConfig{
+		PolicyConfig: DefaultPolicyConfig(),
+		Policies:     map[string]PolicyConfig{},
+		DataDir:      "",
+		CacheSize:    0,
+		CacheTTL:     time.Minute,
+	}
+}
+
+// GetHttpClientConfig reads the httpclient configuration from the app options, using defaults for unset values
+func GetHttpClientConfig(appOpts servertypes.AppOptions) (HttpClientConfig, error) {
+	cfg := *DefaultHttpClientConfig()
+	if v := appOpts.Get("httpclient.allowed-schemes"); v != nil {
+		cfg.AllowedSchemes = cast.ToStringSlice(v)
+	}
+	if v := appOpts.Get("httpclient.allowed-hosts"); v != nil {
+		cfg.AllowedHosts = cast.ToStringSlice(v)
+	}
+	if v := appOpts.Get("httpclient.block-private-ips"); v != nil {
+		cfg.BlockPrivateIPs = cast.ToBool(v)
+	}
+	if v := appOpts.Get("httpclient.timeout"); v != nil {
+		cfg.Timeout = cast.ToDuration(v)
+	}
+	if v := appOpts.Get("httpclient.max-body-size"); v != nil {
+		cfg.MaxBodySize = cast.ToInt64(v)
+	}
+	if v := appOpts.Get("httpclient.rate-limit"); v != nil {
+		cfg.RateLimit = cast.ToFloat64(v)
+	}
+	if v := appOpts.Get("httpclient.rate-burst"); v != nil {
+		cfg.RateBurst = cast.ToInt(v)
+	}
+	if v := appOpts.Get("httpclient.data-dir"); v != nil {
+		cfg.DataDir = cast.ToString(v)
+	}
+	if v := appOpts.Get("httpclient.cache-size"); v != nil {
+		cfg.CacheSize = cast.ToInt(v)
+	}
+	if v := appOpts.Get("httpclient.cache-ttl"); v != nil {
+		cfg.CacheTTL = cast.ToDuration(v)
+	}
+	if v := appOpts.Get("httpclient.policies"); v != nil {
+		for key, value := range cast.ToStringMap(v) {
+			policy, err := decodePolicy(cfg.PolicyConfig, value)
+			if err != nil {
+				return cfg, fmt.Errorf("invalid httpclient policy %s: %v", key, err)
+			}
+			cfg.Policies[key] = policy
+		}
+	}
+	return cfg, nil
+}
+
+// decodePolicy overrides the fields of the default policy which are set in value
+func decodePolicy(defaultPolicy PolicyConfig, value interface{}) (PolicyConfig, error) {
+	policy := defaultPolicy
+	// decoding appends to existing slices, so unset slices are filled in after decoding
+	policy.AllowedSchemes = nil
+	policy.AllowedHosts = nil
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+		ErrorUnused:      true,
+		Result:           &policy,
+	})
+	if err != nil {
+		return policy, err
+	}
+	if err := decoder.Decode(value); err != nil {
+		return policy, err
+	}
+	if policy.AllowedSchemes == nil {
+		policy.AllowedSchemes = defaultPolicy.AllowedSchemes
+	}
+	if policy.AllowedHosts == nil {
+		policy.AllowedHosts = defaultPolicy.AllowedHosts
+	}
+	return policy, nil
+}
+
+// Validate returns an error if the httpclient configuration fields are invalid.
+func (c HttpClientConfig) Validate() error {
+	_, err := c.HttpClientContext("/")
+	return err
+}
+
+// HttpClientContext builds the http clients, with the data directory resolved from the node home
+func (c HttpClientConfig) HttpClientContext(homeDir string) (*vmhttpclient.HttpClientContext, error) {
+	cfg := vmhttpclient.Config{
+		Default:   c.PolicyConfig.Policy(),
+		Policies:  map[string]vmhttpclient.Policy{},
+		DataDir:   c.DataDir,
+		CacheSize: c.CacheSize,
+		CacheTTL:  c.CacheTTL,
+	}
+	if cfg.DataDir != "" && !filepath.IsAbs(cfg.DataDir) {
+		cfg.DataDir = filepath.Join(homeDir, cfg.DataDir)
+	}
+	for key, policy := range c.Policies {
+		cfg.Policies[key] = policy.Policy()
+	}
+	return vmhttpclient.NewHttpClientContext(cfg)
+}
+
+// Policy returns the policy enforced by the http client
+func (c PolicyConfig) Policy() vmhttpclient.Policy {
+	return vmhttpclient.Policy{
+		AllowedSchemes:  c.AllowedSchemes,
+		AllowedHosts:    c.AllowedHosts,
+		BlockPrivateIPs: c.BlockPrivateIPs,
+		Timeout:         c.Timeout,
+		MaxBodySize:     c.MaxBodySize,
+		RateLimit:       c.RateLimit,
+		RateBurst:       c.RateBurst,
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+// DefaultConfigTemplate defines the configuration template for the httpclient configuration
+const DefaultConfigTemplate = `
+###############################################################################
+###                         HttpClient Configuration                        ###
+###############################################################################
+
+[httpclient]
+
+# AllowedSchemes are the url schemes contracts can request: http, https
+allowed-schemes = [{{ range .HttpClient.AllowedSchemes }}{{ printf "%q, " . }}{{end}}]
+
+# AllowedHosts are host names, or "*.domain" for all subdomains of domain. Empty allows any host.
+allowed-hosts = [{{ range .HttpClient.AllowedHosts }}{{ printf "%q, " . }}{{end}}]
+
+# BlockPrivateIPs rejects connections to loopback, private, shared, link-local, multicast and reserved addresses,
+# after the host name is resolved.
+block-private-ips = {{ .HttpClient.BlockPrivateIPs }}
+
+# Timeout of a request, including redirects and reading the response. 0 means no timeout.
+timeout = "{{ .HttpClient.Timeout }}"
+
+# MaxBodySize is the maximum response body size in bytes. 0 means no limit.
+max-body-size = {{ .HttpClient.MaxBodySize }}
+
+# RateLimit is the number of requests per second a contract can make. 0 means no limit.
+rate-limit = {{ .HttpClient.RateLimit }}
+
+# RateBurst is the number of requests a contract can make at once.
+rate-burst = {{ .HttpClient.RateBurst }}
+
+# DataDir is the directory of downloaded files, with a subdirectory for each contract.
+# Relative paths are resolved from the node home. Empty disables downloads.
+# Downloads are disabled by default, because downloaded files have no disk quota.
+data-dir = "{{ .HttpClient.DataDir }}"
+
+# CacheSize is the number of cached GET responses. 0 disables the cache.
+cache-size = {{ .HttpClient.CacheSize }}
+
+# CacheTTL is how long a cached response is served before it is revalidated with its ETag.
+cache-ttl = "{{ .HttpClient.CacheTTL }}"
+
+# Policies override the default policy for a contract address or role. Unset fields are taken
+# from the default policy. E.g.
+# [httpclient.policies.oracle]
+# allowed-hosts = ["api.example.com", "*.example.org"]
+# rate-limit = 50
+{{ range $key, $policy := .HttpClient.Policies }}
+[httpclient.policies.{{ printf "%q" $key }}]
+allowed-schemes = [{{ range $policy.AllowedSchemes }}{{ printf "%q, " . }}{{end}}]
+allowed-hosts = [{{ range $policy.AllowedHosts }}{{ printf "%q, " . }}{{end}}]
+block-private-ips = {{ $policy.BlockPrivateIPs }}
+timeout = "{{ $policy.Timeout }}"
+max-body-size = {{ $policy.MaxBodySize }}
+rate-limit = {{ $policy.RateLimit }}
+rate-burst = {{ $policy.RateBurst }}
+{{ end }}
+`
@@ -24,6 +24,10 @@ const HOST_WASMX_ENV_HTTP_EXPORT = "wasmx_httpclient_"
 
 const HOST_WASMX_ENV_HTTP = "httpclient"
 
+type ContextKey string
+
+const HttpClientContextKey ContextKey = "httpclient-context"
+
 type Context struct {
 	*vmtypes.Context
 }
@@ -33,14 +37,31 @@ type HttpRequest struct {
 	Url    string      `json:"url"`
 	Header http.Header `json:"header"`
 	Data   []byte      `json:"data"`
+	// MultipartForm is sent as a multipart/form-data body instead of Data
+	MultipartForm *MultipartForm `json:"multipart_form,omitempty"`
 	// TODO
-	// MultipartForm *multipart.Form
 	// TLS *tls.ConnectionState
 }
 
+type MultipartForm struct {
+	Value map[string][]string `json:"value"`
+	File  []MultipartFile     `json:"file"`
+}
+
+type MultipartFile struct {
+	FieldName   string `json:"field_name"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Data        []byte `json:"data"`
+}
+
 type ResponseHandler struct {
-	MaxSize  int64  `json:"max_size"`
+	MaxSize int64 `json:"max_size"`
+	// FilePath streams the response body to a file in the contract's data directory.
+	// The extension is set from the Content-Type if the path has none.
 	FilePath string `json:"file_path"`
+	// NoCache skips the response cache
+	NoCache bool `json:"no_cache"`
 }
 
 type HttpRequestWrap struct {
@@ -55,6 +76,10 @@ type HttpResponse struct {
 	Uncompressed  bool        `json:"uncompressed"`
 	Header        http.Header `json:"header"`
 	Data          []byte      `json:"data"`
+	// FilePath is the downloaded file, relative to the contract's data directory
+	FilePath string `json:"file_path"`
+	// Cached is true if the response was served from the response cache
+	Cached bool `json:"cached"`
 	// TODO
 	// TLS           *tls.ConnectionState  `json:"tls"`
 }
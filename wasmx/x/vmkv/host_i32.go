@@ -17,9 +17,9 @@ func BuildWasmxKvVM_i32(ctx_ *vmtypes.Context, rnh memc.RuntimeHandler) (interfa
 		vm.BuildFn("Set", Set, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
 		vm.BuildFn("Delete", Delete, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
 		vm.BuildFn("Iterator", Iterator, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		// vm.BuildFn("NewBatch", NewBatch, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
+		vm.BuildFn("BatchAtomic", BatchAtomic, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
+		vm.BuildFn("Stats", Stats, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
 		// vm.BuildFn("Print", Print, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		// vm.BuildFn("Stats", Stats, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
 	}
 
 	return vm.BuildModule(rnh, "kvdb", context, fndefs)
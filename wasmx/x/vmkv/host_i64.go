@@ -10,16 +10,16 @@ func BuildWasmxKvVM_i64(ctx_ *vmtypes.Context, rnh memc.RuntimeHandler) (interfa
 	vm := rnh.GetVm()
 	// follow cosmos-db interface
 	fndefs := []memc.IFn{
-		vm.BuildFn("Connect", Connect, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		vm.BuildFn("Close", Close, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		vm.BuildFn("Get", Get, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		vm.BuildFn("Has", Has, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		vm.BuildFn("Set", Set, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		vm.BuildFn("Delete", Delete, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		vm.BuildFn("Iterator", Iterator, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		// vm.BuildFn("NewBatch", NewBatch, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		// vm.BuildFn("Print", Print, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		// vm.BuildFn("Stats", Stats, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
+		vm.BuildFn("Connect", Connect, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Close", Close, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Get", Get, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Has", Has, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Set", Set, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Delete", Delete, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Iterator", Iterator, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("BatchAtomic", BatchAtomic, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Stats", Stats, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		// vm.BuildFn("Print", Print, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
 	}
 
 	return vm.BuildModule(rnh, "kvdb", context, fndefs)
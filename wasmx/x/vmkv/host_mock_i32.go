@@ -17,9 +17,9 @@ func BuildWasmxKvVMMock_i32(ctx_ *vmtypes.Context, rnh memc.RuntimeHandler) (int
 		vm.BuildFn("Set", SetMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
 		vm.BuildFn("Delete", DeleteMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
 		vm.BuildFn("Iterator", IteratorMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		// vm.BuildFn("NewBatch", NewBatchMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
+		vm.BuildFn("BatchAtomic", BatchAtomicMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
+		vm.BuildFn("Stats", StatsMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
 		// vm.BuildFn("Print", PrintMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		// vm.BuildFn("Stats", StatsMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
 	}
 
 	return vm.BuildModule(rnh, "kvdb", context, fndefs)
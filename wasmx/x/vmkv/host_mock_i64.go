@@ -10,16 +10,16 @@ func BuildWasmxKvVMMock_i64(ctx_ *vmtypes.Context, rnh memc.RuntimeHandler) (int
 	vm := rnh.GetVm()
 	// follow cosmos-db interface
 	fndefs := []memc.IFn{
-		vm.BuildFn("Connect", ConnectMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		vm.BuildFn("Close", CloseMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		vm.BuildFn("Get", GetMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		vm.BuildFn("Has", HasMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		vm.BuildFn("Set", SetMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		vm.BuildFn("Delete", DeleteMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		vm.BuildFn("Iterator", IteratorMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		// vm.BuildFn("NewBatch", NewBatchMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		// vm.BuildFn("Print", PrintMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
-		// vm.BuildFn("Stats", StatsMock, []interface{}{vm.ValType_I32()}, []interface{}{vm.ValType_I32()}, 0),
+		vm.BuildFn("Connect", ConnectMock, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Close", CloseMock, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Get", GetMock, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Has", HasMock, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Set", SetMock, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Delete", DeleteMock, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Iterator", IteratorMock, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("BatchAtomic", BatchAtomicMock, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Stats", StatsMock, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		// vm.BuildFn("Print", PrintMock, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
 	}
 
 	return vm.BuildModule(rnh, "kvdb", context, fndefs)
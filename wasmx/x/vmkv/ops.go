@@ -1,10 +1,12 @@
 package vmkv
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 
 	consensusmeta "cosmossdk.io/store/consensusmeta"
+	storetypes "cosmossdk.io/store/types"
 	dbm "github.com/cosmos/cosmos-db"
 
 	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
@@ -215,18 +217,72 @@ func Delete(_context interface{}, rnh memc.RuntimeHandler, params []interface{})
 }
 
 func Iterator(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
-	response := &KvIteratorResponse{Error: ""}
+	response := &KvIteratorResponse{Error: "", Pairs: []KvPair{}}
+	ctx := _context.(*Context)
+	keyptr, _ := memc.GetPointerFromParams(rnh, params, 0)
+	requestbz, err := rnh.ReadMemFromPtr(keyptr)
+	if err != nil {
+		return nil, err
+	}
+	var req KvIteratorRequest
+	err = json.Unmarshal(requestbz, &req)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := getConnectionFromCtx(ctx, req.Id)
+	if err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+	response = conn.Iterate(req)
 	return prepareResponse(rnh, response)
 }
 
-// func NewBatch(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
-// 	response := &KvNewBatchResponse{Error: ""}
-// 	return prepareResponse(rnh, response)
-// }
+// BatchAtomic applies all operations or none of them
+func BatchAtomic(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	response := &KvBatchAtomicResponse{Error: ""}
+	ctx := _context.(*Context)
+	keyptr, _ := memc.GetPointerFromParams(rnh, params, 0)
+	requestbz, err := rnh.ReadMemFromPtr(keyptr)
+	if err != nil {
+		return nil, err
+	}
+	var req KvBatchAtomicRequest
+	err = json.Unmarshal(requestbz, &req)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := getConnectionFromCtx(ctx, req.Id)
+	if err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+	response, err = conn.ApplyBatch(req)
+	if err != nil {
+		return nil, err
+	}
+	return prepareResponse(rnh, response)
+}
 
 func Stats(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
-	// TODO implement
-	response := map[string]string{}
+	response := &KvStatsResponse{Error: "", Stats: map[string]string{}}
+	ctx := _context.(*Context)
+	keyptr, _ := memc.GetPointerFromParams(rnh, params, 0)
+	requestbz, err := rnh.ReadMemFromPtr(keyptr)
+	if err != nil {
+		return nil, err
+	}
+	var req KvStatsRequest
+	err = json.Unmarshal(requestbz, &req)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := getConnectionFromCtx(ctx, req.Id)
+	if err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+	response.Stats = conn.Stats()
 	return prepareResponse(rnh, response)
 }
 
@@ -243,6 +299,10 @@ func buildConnectionId(id string, ctx *Context) string {
 }
 
 func getConnectionFromCtx(ctx *Context, id string) (*KvOpenConnection, error) {
+	// the kv db operations are not cancelable, so we check the execution deadline before each one
+	if err := ctx.Ctx.Context().Err(); err != nil {
+		return nil, err
+	}
 	vctx, err := GetKvDbContext(ctx.Context.GoContextParent)
 	if err != nil {
 		return nil, err
@@ -255,6 +315,13 @@ func getConnectionFromCtx(ctx *Context, id string) (*KvOpenConnection, error) {
 	return conn, nil
 }
 
+func emptyToNil(bz []byte) []byte {
+	if len(bz) == 0 {
+		return nil
+	}
+	return bz
+}
+
 func setTempStoreIfNotSet(conn *KvOpenConnection) error {
 	if conn.Store != nil {
 		return nil
@@ -267,3 +334,86 @@ func setTempStoreIfNotSet(conn *KvOpenConnection) error {
 	}
 	return nil
 }
+
+// Iterate returns a page of pairs in [Start, End), in ascending or descending order.
+// NextKey is the Start of the next ascending page, or the End of the next descending page.
+func (conn *KvOpenConnection) Iterate(req KvIteratorRequest) *KvIteratorResponse {
+	response := &KvIteratorResponse{Error: "", Pairs: []KvPair{}}
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultIteratorLimit
+	}
+	if limit > MaxIteratorLimit {
+		response.Error = fmt.Sprintf("iterator limit too high: %d, max %d", limit, MaxIteratorLimit)
+		return response
+	}
+	if len(req.Start) > 0 && len(req.End) > 0 && bytes.Compare(req.Start, req.End) > 0 {
+		response.Error = "iterator start is after end"
+		return response
+	}
+
+	setTempStoreIfNotSet(conn)
+
+	start, end := emptyToNil(req.Start), emptyToNil(req.End)
+	var iter storetypes.Iterator
+	if req.Reverse {
+		iter = conn.getCurrentStore().ReverseIterator(start, end)
+	} else {
+		iter = conn.getCurrentStore().Iterator(start, end)
+	}
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if uint32(len(response.Pairs)) == limit {
+			response.NextKey = iter.Key()
+			if req.Reverse {
+				// end is exclusive, so the next page ends right after this key
+				response.NextKey = append(bytes.Clone(response.NextKey), 0)
+			}
+			break
+		}
+		response.Pairs = append(response.Pairs, KvPair{Key: iter.Key(), Value: iter.Value()})
+	}
+	// store iterators report an error once they are exhausted, so iter.Error() is not checked
+	return response
+}
+
+// ApplyBatch validates all operations before writing any of them
+func (conn *KvOpenConnection) ApplyBatch(req KvBatchAtomicRequest) (*KvBatchAtomicResponse, error) {
+	response := &KvBatchAtomicResponse{Error: ""}
+	for i, op := range req.Operations {
+		if len(op.Key) == 0 {
+			response.Error = fmt.Sprintf("batch operation %d: empty key", i)
+			return response, nil
+		}
+		if !op.Delete && op.Value == nil {
+			response.Error = fmt.Sprintf("batch operation %d: nil value", i)
+			return response, nil
+		}
+	}
+
+	setTempStoreIfNotSet(conn)
+
+	batch, ok := conn.getCurrentStore().CacheWrap().(storetypes.CacheKVStore)
+	if !ok {
+		return nil, fmt.Errorf("CacheWrap interface not CacheKVStore")
+	}
+	for _, op := range req.Operations {
+		if op.Delete {
+			batch.Delete(op.Key)
+		} else {
+			batch.Set(op.Key, op.Value)
+		}
+	}
+	batch.Write()
+	return response, nil
+}
+
+// Stats are read from the database, they do not include uncommitted changes
+func (conn *KvOpenConnection) Stats() map[string]string {
+	stats := conn.Db.Stats()
+	if stats == nil {
+		return map[string]string{}
+	}
+	return stats
+}
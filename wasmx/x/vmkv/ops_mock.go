@@ -35,12 +35,12 @@ func DeleteMock(_context interface{}, rnh memc.RuntimeHandler, params []interfac
 }
 
 func IteratorMock(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
-	response := &KvIteratorResponse{Error: ""}
+	response := &KvIteratorResponse{Error: "", Pairs: []KvPair{}}
 	return prepareResponse(rnh, response)
 }
 
-func NewBatchMock(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
-	response := &KvNewBatchResponse{Error: ""}
+func BatchAtomicMock(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	response := &KvBatchAtomicResponse{Error: ""}
 	return prepareResponse(rnh, response)
 }
 
@@ -50,6 +50,6 @@ func PrintMock(_context interface{}, rnh memc.RuntimeHandler, params []interface
 }
 
 func StatsMock(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
-	response := map[string]string{}
+	response := &KvStatsResponse{Error: "", Stats: map[string]string{}}
 	return prepareResponse(rnh, response)
 }
@@ -0,0 +1,191 @@
+package vmkv_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"cosmossdk.io/log"
+	storetypes "cosmossdk.io/store/types"
+	dbm "github.com/cosmos/cosmos-db"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	mcodec "github.com/loredanacirstea/wasmx/codec"
+	"github.com/loredanacirstea/wasmx/x/vmkv"
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+	vmtypes "github.com/loredanacirstea/wasmx/x/wasmx/vm"
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
+)
+
+// testRuntime passes requests and responses as byte slices instead of wasm memory pointers
+type testRuntime struct{}
+
+func (testRuntime) GetVm() memc.IVm                               { return nil }
+func (testRuntime) GetMemory() (memc.IMemory, error)              { return nil, nil }
+func (testRuntime) PtrParamsLength() int                          { return 1 }
+func (testRuntime) ReadStringFromPtr(interface{}) (string, error) { return "", nil }
+func (testRuntime) ReadJsString(arr []byte) string                { return string(arr) }
+func (testRuntime) ReadMemFromPtr(pointer []interface{}) ([]byte, error) {
+	return pointer[0].([]byte), nil
+}
+func (testRuntime) AllocateWriteMem(data []byte) ([]interface{}, error) {
+	return []interface{}{data}, nil
+}
+
+func newTestConnection(t *testing.T, count int) *vmkv.KvOpenConnection {
+	conn := &vmkv.KvOpenConnection{Db: dbm.NewMemDB(), StoreKeys: []string{}, TempStoresMap: map[string]storetypes.CacheKVStore{}}
+	ops := []vmkv.KvBatchOperation{}
+	for i := 0; i < count; i++ {
+		ops = append(ops, vmkv.KvBatchOperation{Key: []byte(fmt.Sprintf("key%04d", i)), Value: []byte{byte(i)}})
+	}
+	resp, err := conn.ApplyBatch(vmkv.KvBatchAtomicRequest{Operations: ops})
+	require.NoError(t, err)
+	require.Equal(t, "", resp.Error)
+	return conn
+}
+
+func pairKeys(pairs []vmkv.KvPair) []string {
+	keys := []string{}
+	for _, pair := range pairs {
+		keys = append(keys, string(pair.Key))
+	}
+	return keys
+}
+
+func TestIteratePages(t *testing.T) {
+	conn := newTestConnection(t, 5)
+
+	resp := conn.Iterate(vmkv.KvIteratorRequest{Limit: 2})
+	require.Equal(t, "", resp.Error)
+	require.Equal(t, []string{"key0000", "key0001"}, pairKeys(resp.Pairs))
+	require.Equal(t, []byte("key0002"), resp.NextKey)
+
+	resp = conn.Iterate(vmkv.KvIteratorRequest{Start: resp.NextKey, Limit: 2})
+	require.Equal(t, []string{"key0002", "key0003"}, pairKeys(resp.Pairs))
+	resp = conn.Iterate(vmkv.KvIteratorRequest{Start: resp.NextKey, Limit: 2})
+	require.Equal(t, []string{"key0004"}, pairKeys(resp.Pairs))
+	require.Empty(t, resp.NextKey)
+
+	// the next reverse page ends right after the first key not returned
+	resp = conn.Iterate(vmkv.KvIteratorRequest{Reverse: true, Limit: 2})
+	require.Equal(t, []string{"key0004", "key0003"}, pairKeys(resp.Pairs))
+	require.Equal(t, append([]byte("key0002"), 0), resp.NextKey)
+
+	resp = conn.Iterate(vmkv.KvIteratorRequest{End: resp.NextKey, Reverse: true, Limit: 2})
+	require.Equal(t, []string{"key0002", "key0001"}, pairKeys(resp.Pairs))
+	resp = conn.Iterate(vmkv.KvIteratorRequest{End: resp.NextKey, Reverse: true, Limit: 2})
+	require.Equal(t, []string{"key0000"}, pairKeys(resp.Pairs))
+	require.Empty(t, resp.NextKey)
+
+	// the range end is exclusive
+	resp = conn.Iterate(vmkv.KvIteratorRequest{Start: []byte("key0001"), End: []byte("key0003")})
+	require.Equal(t, []string{"key0001", "key0002"}, pairKeys(resp.Pairs))
+
+	resp = conn.Iterate(vmkv.KvIteratorRequest{Start: []byte("key0003"), End: []byte("key0001")})
+	require.Equal(t, "iterator start is after end", resp.Error)
+}
+
+func TestIterateLimit(t *testing.T) {
+	conn := newTestConnection(t, int(vmkv.MaxIteratorLimit)+1)
+
+	resp := conn.Iterate(vmkv.KvIteratorRequest{})
+	require.Equal(t, "", resp.Error)
+	require.Len(t, resp.Pairs, int(vmkv.DefaultIteratorLimit))
+	require.Equal(t, []byte(fmt.Sprintf("key%04d", vmkv.DefaultIteratorLimit)), resp.NextKey)
+
+	resp = conn.Iterate(vmkv.KvIteratorRequest{Limit: vmkv.MaxIteratorLimit})
+	require.Equal(t, "", resp.Error)
+	require.Len(t, resp.Pairs, int(vmkv.MaxIteratorLimit))
+	require.NotEmpty(t, resp.NextKey)
+
+	resp = conn.Iterate(vmkv.KvIteratorRequest{Limit: vmkv.MaxIteratorLimit + 1})
+	require.Equal(t, "iterator limit too high: 1001, max 1000", resp.Error)
+	require.Empty(t, resp.Pairs)
+}
+
+func TestApplyBatchAtomic(t *testing.T) {
+	conn := newTestConnection(t, 2)
+
+	resp, err := conn.ApplyBatch(vmkv.KvBatchAtomicRequest{Operations: []vmkv.KvBatchOperation{
+		{Key: []byte("key0000"), Delete: true},
+		{Key: []byte("new"), Value: []byte{1}},
+		{Key: []byte{}, Value: []byte{2}},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "batch operation 2: empty key", resp.Error)
+
+	resp, err = conn.ApplyBatch(vmkv.KvBatchAtomicRequest{Operations: []vmkv.KvBatchOperation{
+		{Key: []byte("new"), Value: []byte{1}},
+		{Key: []byte("nilvalue")},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "batch operation 1: nil value", resp.Error)
+
+	// failed batches do not write anything
+	iter := conn.Iterate(vmkv.KvIteratorRequest{})
+	require.Equal(t, []string{"key0000", "key0001"}, pairKeys(iter.Pairs))
+
+	resp, err = conn.ApplyBatch(vmkv.KvBatchAtomicRequest{Operations: []vmkv.KvBatchOperation{
+		{Key: []byte("key0000"), Delete: true},
+		{Key: []byte("new"), Value: []byte{1}},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "", resp.Error)
+	iter = conn.Iterate(vmkv.KvIteratorRequest{})
+	require.Equal(t, []string{"key0001", "new"}, pairKeys(iter.Pairs))
+}
+
+func TestStats(t *testing.T) {
+	conn := newTestConnection(t, 3)
+
+	// uncommitted changes are not counted
+	stats := conn.Stats()
+	require.Equal(t, map[string]string{"database.type": "memDB", "database.size": "0"}, stats)
+
+	require.NoError(t, conn.Db.Set([]byte("key"), []byte{1}))
+	stats = conn.Stats()
+	require.Equal(t, "1", stats["database.size"])
+}
+
+func TestHostDeadline(t *testing.T) {
+	goctx, cancel := context.WithCancel(context.Background())
+	goctx = vmkv.WithKvDbEmptyContext(goctx)
+	group, _ := errgroup.WithContext(goctx)
+	defer func() {
+		cancel()
+		require.NoError(t, group.Wait())
+	}()
+	sdkctx := sdk.Context{}.WithContext(goctx).WithLogger(log.NewNopLogger())
+	ctx := &vmkv.Context{Context: &vmtypes.Context{
+		GoRoutineGroup:  group,
+		GoContextParent: goctx,
+		Ctx:             sdkctx,
+		Env: &types.Env{Contract: types.EnvContractInfo{
+			Address: mcodec.NewAccAddressPrefixed([]byte{1, 2, 3}, "mythos"),
+		}},
+	}}
+	call := func(fn func(interface{}, memc.RuntimeHandler, []interface{}) ([]interface{}, error), req interface{}, resp interface{}) {
+		reqbz, err := json.Marshal(req)
+		require.NoError(t, err)
+		result, err := fn(ctx, testRuntime{}, []interface{}{reqbz})
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(result[0].([]byte), resp))
+	}
+
+	var connResp vmkv.KvConnectionResponse
+	call(vmkv.Connect, vmkv.KvConnectionRequest{Id: "conn", Driver: string(dbm.MemDBBackend), Name: "test"}, &connResp)
+	require.Equal(t, "", connResp.Error)
+	var getResp vmkv.KvGetResponse
+	call(vmkv.Get, vmkv.KvGetRequest{Id: "conn", Key: []byte("key")}, &getResp)
+	require.Equal(t, "", getResp.Error)
+
+	// operations after the execution deadline are not run
+	deadlineCtx, cancelDeadline := context.WithTimeout(goctx, 0)
+	defer cancelDeadline()
+	ctx.Ctx = sdkctx.WithContext(deadlineCtx)
+	call(vmkv.Get, vmkv.KvGetRequest{Id: "conn", Key: []byte("key")}, &getResp)
+	require.Equal(t, context.DeadlineExceeded.Error(), getResp.Error)
+}
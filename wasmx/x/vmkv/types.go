@@ -28,6 +28,11 @@ const HOST_WASMX_ENV_KVDB_EXPORT = "wasmx_kvdb_"
 
 const HOST_WASMX_ENV_KVDB = "kvdb"
 
+const (
+	DefaultIteratorLimit = uint32(100)
+	MaxIteratorLimit     = uint32(1000)
+)
+
 type ContextKey string
 
 const KvDbContextKey ContextKey = "kvdb-context"
@@ -177,23 +182,46 @@ type KvDeleteResponse struct {
 type KvIteratorRequest struct {
 	Id    string `json:"id"`
 	Start []byte `json:"start"`
-	End   []byte `json:"end"`
+	// exclusive
+	End     []byte `json:"end"`
+	Reverse bool   `json:"reverse"`
+	// max number of pairs returned, 0 returns up to DefaultIteratorLimit
+	Limit uint32 `json:"limit"`
+}
+
+type KvPair struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
 }
 
 type KvIteratorResponse struct {
-	Error  string   `json:"error"`
-	Values [][]byte `json:"values"`
+	Error string   `json:"error"`
+	Pairs []KvPair `json:"pairs"`
+	// next page bound, empty when there are no more pairs:
+	// use it as start for forward iterators and as end for reverse iterators
+	NextKey []byte `json:"next_key"`
+}
+
+type KvBatchOperation struct {
+	Key    []byte `json:"key"`
+	Value  []byte `json:"value"`
+	Delete bool   `json:"delete"`
 }
 
-type KvNewBatchRequest struct {
-	Id   string `json:"id"`
-	Size int    `json:"size"`
+type KvBatchAtomicRequest struct {
+	Id         string             `json:"id"`
+	Operations []KvBatchOperation `json:"operations"`
 }
 
-type KvNewBatchResponse struct {
+type KvBatchAtomicResponse struct {
 	Error string `json:"error"`
 }
 
 type KvStatsRequest struct {
 	Id string `json:"id"`
 }
+
+type KvStatsResponse struct {
+	Error string            `json:"error"`
+	Stats map[string]string `json:"stats"`
+}
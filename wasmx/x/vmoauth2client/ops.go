@@ -2,9 +2,11 @@ package vmoauth2client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 
 	vmhttpclient "github.com/loredanacirstea/wasmx/x/vmhttpclient"
 	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
@@ -56,8 +58,12 @@ func ExchangeCodeForToken(_context interface{}, rnh memc.RuntimeHandler, params
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
 	}
+	httpctx, _, err := policyContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	config := req.Config.toConfig()
-	token, err := config.Exchange(ctx.Ctx, req.AuthorizationCode)
+	token, err := config.Exchange(httpctx, req.AuthorizationCode)
 	if err != nil {
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
@@ -88,7 +94,11 @@ func RefreshToken(_context interface{}, rnh memc.RuntimeHandler, params []interf
 		RefreshToken: req.RefreshToken,
 	}
 
-	newToken, err := req.Config.toConfig().TokenSource(ctx.Ctx, token).Token()
+	httpctx, _, err := policyContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	newToken, err := req.Config.toConfig().TokenSource(httpctx, token).Token()
 	if err != nil {
 		response.Error = fmt.Errorf("Failed to refresh token: %s", err).Error()
 		return prepareResponse(rnh, response)
@@ -115,7 +125,10 @@ func Oauth2ClientGet(_context interface{}, rnh memc.RuntimeHandler, params []int
 		return prepareResponse(rnh, response)
 	}
 
-	client := req.Config.toConfig().Client(ctx.Ctx, req.Token)
+	client, err := oauth2Client(ctx, req.Config, req.Token)
+	if err != nil {
+		return nil, err
+	}
 	resp, err := client.Get(req.RequestUri)
 	if err != nil {
 		response.Error = err.Error()
@@ -150,9 +163,12 @@ func Oauth2ClientDo(_context interface{}, rnh memc.RuntimeHandler, params []inte
 		return prepareResponse(rnh, response)
 	}
 
-	client := req.Config.toConfig().Client(ctx.Ctx, req.Token)
+	client, err := oauth2Client(ctx, req.Config, req.Token)
+	if err != nil {
+		return nil, err
+	}
 
-	httpreq, err := vmhttpclient.BuildHttpRequest(req.Request)
+	httpreq, err := vmhttpclient.BuildHttpRequest(ctx.Ctx, req.Request)
 	if err != nil {
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
@@ -191,9 +207,18 @@ func Oauth2ClientPost(_context interface{}, rnh memc.RuntimeHandler, params []in
 		return prepareResponse(rnh, response)
 	}
 
-	client := req.Config.toConfig().Client(ctx.Ctx, req.Token)
+	client, err := oauth2Client(ctx, req.Config, req.Token)
+	if err != nil {
+		return nil, err
+	}
 	body := bytes.NewReader(req.Data)
-	resp, err := client.Post(req.RequestUri, req.ContentType, body)
+	httpreq, err := http.NewRequestWithContext(ctx.Ctx, http.MethodPost, req.RequestUri, body)
+	if err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+	httpreq.Header.Set("Content-Type", req.ContentType)
+	resp, err := client.Do(httpreq)
 	if err != nil {
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
@@ -208,6 +233,32 @@ func Oauth2ClientPost(_context interface{}, rnh memc.RuntimeHandler, params []in
 	return prepareResponse(rnh, response)
 }
 
+// policyContext returns a context with the http client of the contract, which the oauth2 library
+// uses for token and api requests, so they follow the http client policy of the contract
+func policyContext(ctx *Context) (context.Context, *http.Client, error) {
+	vctx, err := vmhttpclient.GetHttpClientContext(ctx.Context.GoContextParent)
+	if err != nil {
+		return nil, nil, err
+	}
+	address := ctx.Env.Contract.Address
+	role := ctx.CosmosHandler.GetRoleByContractAddress(ctx.Ctx, address)
+	client := vctx.HTTPClient(address.String(), role)
+	return context.WithValue(ctx.Ctx, oauth2.HTTPClient, client), client, nil
+}
+
+// oauth2Client returns a client which authorizes its requests with the token,
+// through the http client of the contract
+func oauth2Client(ctx *Context, config OAuth2Config, token *oauth2.Token) (*http.Client, error) {
+	httpctx, policyClient, err := policyContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := config.toConfig().Client(httpctx, token)
+	client.Timeout = policyClient.Timeout
+	client.CheckRedirect = policyClient.CheckRedirect
+	return client, nil
+}
+
 func prepareResponse(rnh memc.RuntimeHandler, response interface{}) ([]interface{}, error) {
 	responsebz, err := json.Marshal(response)
 	if err != nil {
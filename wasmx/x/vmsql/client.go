@@ -0,0 +1,59 @@
+package vmsql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/version"
+)
+
+// GetQueryCmd returns the cli query commands for this module.
+// They read the node's own databases, not the chain state.
+func GetQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        ModuleName,
+		Short:                      fmt.Sprintf("Querying commands for the %s module", ModuleName),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		CmdSchemaVersions(),
+	)
+	return cmd
+}
+
+// CmdSchemaVersions prints the last migration this node applied to each table of a database
+func CmdSchemaVersions() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "schema-versions [driver] [connection]",
+		Short:   "Show the schema version of each table of a node database",
+		Long:    "Show the last migration this node applied to each table of a database. Compare the output across nodes to check that their sql databases converged.",
+		Args:    cobra.ExactArgs(2),
+		Example: fmt.Sprintf("$ %s query %s schema-versions sqlite3 ~/.mythos/data/sql/contract.db", version.AppName, ModuleName),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := sql.Open(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			versions, err := AppliedSchemaVersions(cmd.Context(), db)
+			if err != nil {
+				return err
+			}
+			bz, err := json.Marshal(versions)
+			if err != nil {
+				return err
+			}
+			cmd.Println(string(bz))
+			return nil
+		},
+		SilenceUsage: true,
+	}
+	return cmd
+}
@@ -5,8 +5,8 @@ import (
 	"fmt"
 )
 
-func WithSqlEmptyContext(ctx context.Context) context.Context {
-	vctx := &SqlContext{DbConnections: map[string]*SqlOpenConnection{}}
+func WithSqlEmptyContext(ctx context.Context, policy *ConnectionPolicy) context.Context {
+	vctx := &SqlContext{DbConnections: map[string]*SqlOpenConnection{}, Policy: policy}
 	return context.WithValue(ctx, SqlContextKey, vctx)
 }
 
@@ -0,0 +1,6 @@
+// Package drivers registers the sql drivers compiled into the node binary.
+// Each driver is selected with a build tag: sqlite (pure Go, registered as "sqlite"),
+// postgres (registered as "postgres") and mysql (registered as "mysql").
+// The cgo "sqlite3" driver is always linked through cosmos-db.
+// Which drivers contracts can actually use is configured in the [sql] section of app.toml.
+package drivers
@@ -0,0 +1,7 @@
+//go:build mysql
+
+package drivers
+
+import (
+	_ "github.com/go-sql-driver/mysql"
+)
@@ -0,0 +1,7 @@
+//go:build postgres
+
+package drivers
+
+import (
+	_ "github.com/lib/pq"
+)
@@ -0,0 +1,7 @@
+//go:build sqlite
+
+package drivers
+
+import (
+	_ "modernc.org/sqlite"
+)
@@ -0,0 +1,38 @@
+//go:build sqlite
+
+package drivers_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/loredanacirstea/wasmx/x/vmsql/drivers"
+
+	"github.com/loredanacirstea/wasmx/x/vmsql"
+)
+
+func TestSqlite(t *testing.T) {
+	dir := t.TempDir()
+	policy, err := vmsql.NewConnectionPolicy([]string{"sqlite"}, nil, []string{dir})
+	require.NoError(t, err)
+
+	conn, err := policy.Resolve("sqlite", "test.db")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "test.db"), conn)
+
+	db, err := sql.Open("sqlite", conn)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(`CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO users (id, name) VALUES (?, ?)`, 1, "Alice")
+	require.NoError(t, err)
+
+	var name string
+	require.NoError(t, db.QueryRow(`SELECT name FROM users WHERE id = ?`, 1).Scan(&name))
+	require.Equal(t, "Alice", name)
+	require.FileExists(t, conn)
+}
@@ -16,6 +16,15 @@ func BuildWasmxSqlVM_i64(ctx_ *vmtypes.Context, rnh memc.RuntimeHandler) (interf
 		vm.BuildFn("Execute", Execute, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
 		vm.BuildFn("BatchAtomic", BatchAtomic, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
 		vm.BuildFn("Query", Query, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Prepare", Prepare, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("ExecutePrepared", ExecutePrepared, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("ClosePrepared", ClosePrepared, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("QueryOpen", QueryOpen, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("QueryNext", QueryNext, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("QueryClose", QueryClose, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("Migrate", Migrate, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("RegisterMigrations", RegisterMigrations, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
+		vm.BuildFn("SchemaVersion", SchemaVersion, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
 		// TODO
 		// vm.BuildFn("SetOptions", SetOptions, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
 		// vm.BuildFn("QueryRow", QueryRow, []interface{}{vm.ValType_I64()}, []interface{}{vm.ValType_I64()}, 0),
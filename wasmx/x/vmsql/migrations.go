@@ -0,0 +1,370 @@
+package vmsql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	storetypes "cosmossdk.io/store/types"
+
+	vmtypes "github.com/loredanacirstea/wasmx/x/wasmx/vm"
+)
+
+// SchemaMigrationsTable records the migrations applied to each table of a database
+const SchemaMigrationsTable = "wasmx_schema_migrations"
+
+// Migrations of each table are registered on-chain, in the contract storage, so all nodes
+// apply the same migrations to their databases. Registered migrations cannot change.
+// The pending ones are applied when the contract connects, atomically, like a BatchAtomic.
+
+// migrationsRegistryPrefix is the storage prefix of the migrations registered for a connection id:
+// SQL_MIGRATIONS_STORAGE_PREFIX | len(id) | id, followed by the table name
+func migrationsRegistryPrefix(id string) []byte {
+	key := append([]byte{}, vmtypes.SQL_MIGRATIONS_STORAGE_PREFIX...)
+	key = binary.BigEndian.AppendUint16(key, uint16(len(id)))
+	return append(key, id...)
+}
+
+func migrationsRegistryKey(id string, table string) []byte {
+	return append(migrationsRegistryPrefix(id), table...)
+}
+
+// registerMigrations adds the migrations of a table to the registry in the contract storage
+// and returns the last registered version. Migrations which are already registered
+// must be unchanged; new migrations must have higher versions.
+func registerMigrations(store storetypes.KVStore, id string, table string, migrations []SqlMigration) (int64, error) {
+	if len(id) > 0xffff {
+		return 0, fmt.Errorf("sql connection id is too long")
+	}
+	if _, _, err := migrationTarget(SqlMigrateRequest{Table: table, Migrations: migrations}); err != nil {
+		return 0, err
+	}
+	key := migrationsRegistryKey(id, table)
+	registered, err := registeredMigrations(store.Get(key))
+	if err != nil {
+		return 0, err
+	}
+	versions := map[int64]SqlMigration{}
+	last := int64(0)
+	for _, m := range registered {
+		versions[m.Version] = m
+		last = m.Version
+	}
+	changed := false
+	for _, m := range migrations {
+		if m.Version > last {
+			registered = append(registered, m)
+			last = m.Version
+			changed = true
+			continue
+		}
+		old, found := versions[m.Version]
+		if !found {
+			return 0, fmt.Errorf("migration %d is older than the registered version %d", m.Version, last)
+		}
+		if !sameMigration(old, m) {
+			return 0, fmt.Errorf("registered migration %d cannot change", m.Version)
+		}
+	}
+	if changed {
+		value, err := json.Marshal(registered)
+		if err != nil {
+			return 0, err
+		}
+		store.Set(key, value)
+	}
+	return last, nil
+}
+
+// ApplyRegisteredMigrations applies the pending registered migrations of the connection id,
+// table by table, and returns the schema version of each table. It stops at the first table
+// which fails to migrate; that table is left unchanged.
+func (conn *SqlOpenConnection) ApplyRegisteredMigrations(ctx context.Context, store storetypes.KVStore, id string, height int64) (map[string]int64, error) {
+	prefix := migrationsRegistryPrefix(id)
+	type tableMigrations struct {
+		table      string
+		migrations []SqlMigration
+	}
+	tables := []tableMigrations{}
+	iter := storetypes.KVStorePrefixIterator(store, prefix)
+	for ; iter.Valid(); iter.Next() {
+		table := string(iter.Key()[len(prefix):])
+		migrations, err := registeredMigrations(iter.Value())
+		if err != nil {
+			iter.Close()
+			return nil, fmt.Errorf("table %s: %v", table, err)
+		}
+		tables = append(tables, tableMigrations{table: table, migrations: migrations})
+	}
+	iter.Close()
+
+	versions := map[string]int64{}
+	for _, t := range tables {
+		resp, err := conn.ApplyMigrations(ctx, SqlMigrateRequest{Table: t.table, Migrations: t.migrations}, height)
+		if err != nil {
+			return versions, err
+		}
+		if resp.Error != "" {
+			return versions, fmt.Errorf("table %s: %s", t.table, resp.Error)
+		}
+		versions[t.table] = resp.Version
+	}
+	return versions, nil
+}
+
+func hasRegisteredMigrations(store storetypes.KVStore, id string) bool {
+	iter := storetypes.KVStorePrefixIterator(store, migrationsRegistryPrefix(id))
+	defer iter.Close()
+	return iter.Valid()
+}
+
+func registeredMigrations(value []byte) ([]SqlMigration, error) {
+	migrations := []SqlMigration{}
+	if len(value) == 0 {
+		return migrations, nil
+	}
+	if err := json.Unmarshal(value, &migrations); err != nil {
+		return nil, fmt.Errorf("invalid registered migrations: %v", err)
+	}
+	return migrations, nil
+}
+
+func sameMigration(a SqlMigration, b SqlMigration) bool {
+	abz, err1 := json.Marshal(a)
+	bbz, err2 := json.Marshal(b)
+	return err1 == nil && err2 == nil && bytes.Equal(abz, bbz)
+}
+
+// AppliedSchemaVersions returns the last migration applied to each table of a database,
+// sorted by table. It reads the database directly, outside of contract calls.
+func AppliedSchemaVersions(ctx context.Context, db *sql.DB) ([]SqlTableVersion, error) {
+	query := fmt.Sprintf("SELECT table_name, MAX(version) FROM %s GROUP BY table_name", SchemaMigrationsTable)
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s: %v", SchemaMigrationsTable, err)
+	}
+	defer rows.Close()
+	versions := []SqlTableVersion{}
+	for rows.Next() {
+		var v SqlTableVersion
+		if err := rows.Scan(&v.Table, &v.Version); err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Table < versions[j].Table })
+	return versions, nil
+}
+
+// ApplyMigrations brings the schema of a table to the target version inside the open transaction.
+// Pending migrations are applied in order. Only with an explicit target version, applied migrations
+// above the target are reverted in reverse order, with the rollback scripts recorded when they were applied.
+// Migrating is atomic: if any command fails, the response has the error and the schema is unchanged.
+// The returned error is only set if the transaction itself failed.
+func (conn *SqlOpenConnection) ApplyMigrations(ctx context.Context, req SqlMigrateRequest, height int64) (*SqlMigrateResponse, error) {
+	response := &SqlMigrateResponse{Applied: []int64{}, Reverted: []int64{}}
+	target, revert, err := migrationTarget(req)
+	if err != nil {
+		response.Error = err.Error()
+		return response, nil
+	}
+	tx := conn.OpenSavepointTx
+	txerr, err := withSavepoint(ctx, tx, "migrate", func() error {
+		if err := conn.createMigrationsTable(ctx); err != nil {
+			return err
+		}
+		applied, err := conn.appliedMigrations(ctx, req.Table)
+		if err != nil {
+			return err
+		}
+		for i := len(applied) - 1; revert && i >= 0 && applied[i].Version > target; i-- {
+			if _, err := conn.execCommands(ctx, applied[i].Down); err != nil {
+				return fmt.Errorf("revert migration %d: %v", applied[i].Version, err)
+			}
+			query := fmt.Sprintf("DELETE FROM %s WHERE table_name = %s AND version = %s", SchemaMigrationsTable, conn.placeholder(1), conn.placeholder(2))
+			if _, err := tx.ExecContext(ctx, query, req.Table, applied[i].Version); err != nil {
+				return err
+			}
+			response.Reverted = append(response.Reverted, applied[i].Version)
+			applied = applied[:i]
+		}
+		version := int64(0)
+		appliedVersions := map[int64]bool{}
+		for _, m := range applied {
+			version = m.Version
+			appliedVersions[m.Version] = true
+		}
+		for _, m := range req.Migrations {
+			if m.Version > target || appliedVersions[m.Version] {
+				continue
+			}
+			if m.Version < version {
+				return fmt.Errorf("migration %d is older than the applied version %d", m.Version, version)
+			}
+			if _, err := conn.execCommands(ctx, m.Up); err != nil {
+				return fmt.Errorf("apply migration %d: %v", m.Version, err)
+			}
+			down, err := json.Marshal(m.Down)
+			if err != nil {
+				return err
+			}
+			query := fmt.Sprintf("INSERT INTO %s (table_name, version, down, block_height) VALUES (%s, %s, %s, %s)", SchemaMigrationsTable, conn.placeholder(1), conn.placeholder(2), conn.placeholder(3), conn.placeholder(4))
+			if _, err := tx.ExecContext(ctx, query, req.Table, m.Version, string(down), height); err != nil {
+				return err
+			}
+			response.Applied = append(response.Applied, m.Version)
+			version = m.Version
+		}
+		response.Version = version
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if txerr != nil {
+		response.Error = txerr.Error()
+		response.Applied = []int64{}
+		response.Reverted = []int64{}
+		response.Version, err = conn.SchemaVersion(ctx, req.Table)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// SchemaVersion returns the last migration applied to a table, or 0
+func (conn *SqlOpenConnection) SchemaVersion(ctx context.Context, table string) (int64, error) {
+	if err := conn.createMigrationsTable(ctx); err != nil {
+		return 0, err
+	}
+	var version sql.NullInt64
+	query := fmt.Sprintf("SELECT MAX(version) FROM %s WHERE table_name = %s", SchemaMigrationsTable, conn.placeholder(1))
+	err := conn.OpenSavepointTx.QueryRowContext(ctx, query, table).Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+	return version.Int64, nil
+}
+
+type appliedMigration struct {
+	Version int64
+	Down    []SqlExecuteCommand
+}
+
+func (conn *SqlOpenConnection) appliedMigrations(ctx context.Context, table string) ([]appliedMigration, error) {
+	query := fmt.Sprintf("SELECT version, down FROM %s WHERE table_name = %s ORDER BY version", SchemaMigrationsTable, conn.placeholder(1))
+	rows, err := conn.OpenSavepointTx.QueryContext(ctx, query, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	applied := []appliedMigration{}
+	for rows.Next() {
+		var m appliedMigration
+		var down string
+		if err := rows.Scan(&m.Version, &down); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(down), &m.Down); err != nil {
+			return nil, fmt.Errorf("invalid rollback script of migration %d: %v", m.Version, err)
+		}
+		applied = append(applied, m)
+	}
+	return applied, rows.Err()
+}
+
+func (conn *SqlOpenConnection) createMigrationsTable(ctx context.Context) error {
+	query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (table_name VARCHAR(255) NOT NULL, version BIGINT NOT NULL, down TEXT NOT NULL, block_height BIGINT NOT NULL, PRIMARY KEY (table_name, version))", SchemaMigrationsTable)
+	_, err := conn.OpenSavepointTx.ExecContext(ctx, query)
+	return err
+}
+
+// placeholder returns the i-th (1-based) query parameter placeholder of the driver
+func (conn *SqlOpenConnection) placeholder(i int) string {
+	if conn.Driver == "postgres" {
+		return fmt.Sprintf("$%d", i)
+	}
+	return "?"
+}
+
+// migrationTarget checks that migration versions are positive and increasing
+// and returns the version to migrate to. Without a target version, the pending migrations
+// are applied and nothing is reverted; reverting requires an explicit target.
+func migrationTarget(req SqlMigrateRequest) (target int64, revert bool, err error) {
+	if req.Table == "" {
+		return 0, false, fmt.Errorf("migration table name is empty")
+	}
+	last := int64(0)
+	for _, m := range req.Migrations {
+		if m.Version <= last {
+			return 0, false, fmt.Errorf("migration versions must be positive and increasing: %d after %d", m.Version, last)
+		}
+		last = m.Version
+	}
+	if req.TargetVersion == nil {
+		return last, false, nil
+	}
+	if *req.TargetVersion < 0 {
+		return 0, false, fmt.Errorf("invalid target version: %d", *req.TargetVersion)
+	}
+	return *req.TargetVersion, true, nil
+}
+
+// withSavepoint runs fn inside a savepoint, which is rolled back if fn fails.
+// The error of fn is returned as txerr; err is set if the savepoint itself failed.
+func withSavepoint(ctx context.Context, tx *sql.Tx, savepoint string, fn func() error) (txerr error, err error) {
+	_, err = tx.ExecContext(ctx, fmt.Sprintf("SAVEPOINT %s", savepoint))
+	if err != nil {
+		return nil, fmt.Errorf("cannot add savepoint: %s, %s", savepoint, err.Error())
+	}
+	txerr = fn()
+	if txerr != nil {
+		_, err = tx.Exec(fmt.Sprintf("ROLLBACK TO %s", savepoint))
+		if err != nil {
+			return txerr, fmt.Errorf("cannot rollback to savepoint: %s, %s", savepoint, err.Error())
+		}
+		// the savepoint stays on the stack after ROLLBACK TO
+		_, err = tx.Exec(fmt.Sprintf("RELEASE %s", savepoint))
+		if err != nil {
+			return txerr, fmt.Errorf("cannot release savepoint: %s, %s", savepoint, err.Error())
+		}
+		return txerr, nil
+	}
+	_, err = tx.Exec(fmt.Sprintf("RELEASE %s", savepoint))
+	if err != nil {
+		return nil, fmt.Errorf("cannot release savepoint: %s, %s", savepoint, err.Error())
+	}
+	return nil, nil
+}
+
+// execCommands executes the commands in order in the open transaction and stops at the first error
+func (conn *SqlOpenConnection) execCommands(ctx context.Context, commands []SqlExecuteCommand) ([]SqlExecuteResponse, error) {
+	responses := []SqlExecuteResponse{}
+	for _, cmd := range commands {
+		if err := conn.CheckQuery(cmd.Query); err != nil {
+			return responses, err
+		}
+		reqparams, err := parseRequestParams(cmd.Params)
+		if err != nil {
+			return responses, err
+		}
+
+		qparams := parseSqlQueryParams(reqparams)
+		res, err := conn.OpenSavepointTx.ExecContext(ctx, cmd.Query, qparams...)
+		if err != nil {
+			return responses, err
+		}
+		execResp := SqlExecuteResponse{}
+		prepareExecutionResponse(res, &execResp)
+		responses = append(responses, execResp)
+	}
+	return responses, nil
+}
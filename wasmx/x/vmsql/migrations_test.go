@@ -0,0 +1,152 @@
+package vmsql_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/loredanacirstea/wasmx/x/vmsql"
+)
+
+func TestApplyMigrations(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	defer tx.Rollback()
+	conn := &vmsql.SqlOpenConnection{Db: db, Driver: "sqlite3", OpenSavepointTx: tx}
+
+	migrations := []vmsql.SqlMigration{
+		{
+			Version: 1,
+			Up:      []vmsql.SqlExecuteCommand{{Query: `CREATE TABLE items (id INTEGER PRIMARY KEY)`}},
+			Down:    []vmsql.SqlExecuteCommand{{Query: `DROP TABLE items`}},
+		},
+		{
+			Version: 2,
+			Up:      []vmsql.SqlExecuteCommand{{Query: `ALTER TABLE items ADD COLUMN name TEXT`}},
+			Down:    []vmsql.SqlExecuteCommand{{Query: `ALTER TABLE items DROP COLUMN name`}},
+		},
+	}
+	req := vmsql.SqlMigrateRequest{Table: "items", Migrations: migrations}
+
+	resp, err := conn.ApplyMigrations(ctx, req, 5)
+	require.NoError(t, err)
+	require.Equal(t, "", resp.Error)
+	require.Equal(t, int64(2), resp.Version)
+	require.Equal(t, []int64{1, 2}, resp.Applied)
+	_, err = tx.Exec(`INSERT INTO items (id, name) VALUES (1, 'first')`)
+	require.NoError(t, err)
+
+	// applied migrations are not applied again
+	resp, err = conn.ApplyMigrations(ctx, req, 6)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.Version)
+	require.Empty(t, resp.Applied)
+
+	// without a target version, missing migrations are not reverted
+	resp, err = conn.ApplyMigrations(ctx, vmsql.SqlMigrateRequest{Table: "items"}, 6)
+	require.NoError(t, err)
+	require.Equal(t, "", resp.Error)
+	require.Equal(t, int64(2), resp.Version)
+	require.Empty(t, resp.Reverted)
+	resp, err = conn.ApplyMigrations(ctx, vmsql.SqlMigrateRequest{Table: "items", Migrations: migrations[:1]}, 6)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.Version)
+	require.Empty(t, resp.Reverted)
+
+	// revert to version 1 with the recorded rollback scripts
+	target := int64(1)
+	resp, err = conn.ApplyMigrations(ctx, vmsql.SqlMigrateRequest{Table: "items", TargetVersion: &target}, 7)
+	require.NoError(t, err)
+	require.Equal(t, "", resp.Error)
+	require.Equal(t, int64(1), resp.Version)
+	require.Equal(t, []int64{2}, resp.Reverted)
+	_, err = tx.Exec(`INSERT INTO items (id, name) VALUES (2, 'second')`)
+	require.ErrorContains(t, err, "no column named name")
+
+	// a failing migration leaves the schema unchanged
+	req.Migrations = append(migrations, vmsql.SqlMigration{
+		Version: 3,
+		Up:      []vmsql.SqlExecuteCommand{{Query: `CREATE TABLE other (id INTEGER)`}, {Query: `INVALID`}},
+	})
+	resp, err = conn.ApplyMigrations(ctx, req, 8)
+	require.NoError(t, err)
+	require.Contains(t, resp.Error, "apply migration 3")
+	require.Equal(t, int64(1), resp.Version)
+	require.Empty(t, resp.Applied)
+	version, err := conn.SchemaVersion(ctx, "items")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), version)
+	_, err = tx.Exec(`INSERT INTO items (id, name) VALUES (2, 'second')`)
+	require.ErrorContains(t, err, "no column named name")
+	_, err = tx.Exec(`SELECT * FROM other`)
+	require.ErrorContains(t, err, "no such table")
+
+	// versions of other tables are separate
+	version, err = conn.SchemaVersion(ctx, "others")
+	require.NoError(t, err)
+	require.Equal(t, int64(0), version)
+
+	resp, err = conn.ApplyMigrations(ctx, vmsql.SqlMigrateRequest{Table: "items", Migrations: []vmsql.SqlMigration{{Version: 2}, {Version: 1}}}, 9)
+	require.NoError(t, err)
+	require.Contains(t, resp.Error, "migration versions must be positive and increasing")
+}
+
+func TestHostRegisteredMigrations(t *testing.T) {
+	h := newHostTest(t)
+	migrations := []vmsql.SqlMigration{
+		{
+			Version: 1,
+			Up:      []vmsql.SqlExecuteCommand{{Query: `CREATE TABLE items (id INTEGER PRIMARY KEY)`}},
+			Down:    []vmsql.SqlExecuteCommand{{Query: `DROP TABLE items`}},
+		},
+		{
+			Version: 2,
+			Up:      []vmsql.SqlExecuteCommand{{Query: `ALTER TABLE items ADD COLUMN name TEXT`}},
+			Down:    []vmsql.SqlExecuteCommand{{Query: `ALTER TABLE items DROP COLUMN name`}},
+		},
+	}
+	var regResp vmsql.SqlRegisterMigrationsResponse
+	h.call(vmsql.RegisterMigrations, vmsql.SqlRegisterMigrationsRequest{Id: "conn", Table: "items", Migrations: migrations[:1]}, &regResp)
+	require.Equal(t, "", regResp.Error)
+	require.Equal(t, int64(1), regResp.Version)
+
+	// registered migrations are idempotent, new ones are appended
+	h.call(vmsql.RegisterMigrations, vmsql.SqlRegisterMigrationsRequest{Id: "conn", Table: "items", Migrations: migrations}, &regResp)
+	require.Equal(t, "", regResp.Error)
+	require.Equal(t, int64(2), regResp.Version)
+
+	// registered migrations cannot change
+	changed := migrations[0]
+	changed.Up = []vmsql.SqlExecuteCommand{{Query: `CREATE TABLE items (id TEXT)`}}
+	h.call(vmsql.RegisterMigrations, vmsql.SqlRegisterMigrationsRequest{Id: "conn", Table: "items", Migrations: []vmsql.SqlMigration{changed}}, &regResp)
+	require.Contains(t, regResp.Error, "cannot change")
+
+	// connecting applies the registered migrations
+	path := filepath.Join(t.TempDir(), "test.db")
+	var resp vmsql.SqlConnectionResponse
+	h.call(vmsql.Connect, vmsql.SqlConnectionRequest{Id: "conn", Driver: "sqlite3", Connection: path}, &resp)
+	require.Equal(t, "", resp.Error)
+	require.Equal(t, map[string]int64{"items": 2}, resp.SchemaVersions)
+	h.endTransaction()
+
+	// the applied versions can be read outside of contract calls
+	db, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	defer db.Close()
+	versions, err := vmsql.AppliedSchemaVersions(context.Background(), db)
+	require.NoError(t, err)
+	require.Equal(t, []vmsql.SqlTableVersion{{Table: "items", Version: 2}}, versions)
+
+	// reconnecting does not apply them again
+	h.call(vmsql.Connect, vmsql.SqlConnectionRequest{Id: "conn", Driver: "sqlite3", Connection: path}, &resp)
+	require.Equal(t, "", resp.Error)
+	require.Equal(t, map[string]int64{"items": 2}, resp.SchemaVersions)
+	h.endTransaction()
+}
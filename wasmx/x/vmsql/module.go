@@ -69,7 +69,7 @@ func (a AppModuleBasic) GetTxCmd() *cobra.Command {
 
 // GetQueryCmd returns the root query command for the module. The subcommands of this root command are used by end-users to generate new queries to the subset of the state defined by the module
 func (a AppModuleBasic) GetQueryCmd() *cobra.Command {
-	return nil
+	return GetQueryCmd()
 }
 
 // ----------------------------------------------------------------------------
@@ -136,6 +136,7 @@ func (am AppModule) EndTransaction(ctx context.Context, txmode sdk.ExecMode, gIn
 		if conn.OpenSavepointTx == nil {
 			continue
 		}
+		conn.closeTxResources()
 		if shouldCommit {
 			cmd := "RELEASE sp0"
 			if txerr != nil {
@@ -173,6 +174,7 @@ func (am AppModule) BeginSubCall(ctx context.Context, level uint32, index uint32
 		savepoint := buildSavepoint(level, index)
 		cmd := fmt.Sprintf("SAVEPOINT %s", savepoint)
 		conn.SavePointMap[savepoint] = true
+		conn.SavepointDepth += 1
 		_, err := conn.OpenSavepointTx.Exec(cmd)
 		if err != nil {
 			return fmt.Errorf("cannot add savepoint: %s, %s", savepoint, err.Error())
@@ -195,10 +197,12 @@ func (am AppModule) EndSubCall(_ context.Context, level uint32, index uint32, is
 		if !conn.hasSavePoint(savepoint) {
 			continue
 		}
+		rollback := isquery || txerr != nil
 		cmd := fmt.Sprintf("RELEASE sp%d_%d", level, index)
-		if isquery || txerr != nil {
+		if rollback {
 			cmd = fmt.Sprintf("ROLLBACK TO sp%d_%d", level, index)
 		}
+		conn.endSavepoint(rollback)
 		_, err := conn.OpenSavepointTx.Exec(cmd)
 		if err != nil {
 			return fmt.Errorf("db tx command failed: %s, %s", cmd, err.Error())
@@ -1,10 +1,12 @@
 package vmsql
 
 import (
+	"context"
 	"database/sql"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
 	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
@@ -37,17 +39,27 @@ func Connect(_context interface{}, rnh memc.RuntimeHandler, params []interface{}
 		if conn.Connection == req.Connection {
 			// we test the connection with a ping
 			// if not successful, we try one more time to open it, below
-			err := conn.Db.Ping()
+			err := conn.Db.PingContext(ctx.Ctx)
 			if err == nil {
-				return prepareResponse(rnh, response)
+				return connectMigrate(ctx, rnh, conn, req.Id, response)
 			}
 		} else {
 			response.Error = "connection id already in use"
 			return prepareResponse(rnh, response)
 		}
 	}
-	// TODO req.Connection - should we restrict this path and make it relative to our DataDirectory? or introduce a list of allowed directories that WASMX can modify and make sure the path is within these directories.
-	db, err := sql.Open(req.Driver, req.Connection)
+	connection, err := vctx.Policy.Resolve(req.Driver, req.Connection)
+	if err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+	if dir := sqliteDir(connection); isSqliteDriver(req.Driver) && dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			response.Error = err.Error()
+			return prepareResponse(rnh, response)
+		}
+	}
+	db, err := sql.Open(req.Driver, connection)
 	if err != nil {
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
@@ -72,16 +84,42 @@ func Connect(_context interface{}, rnh memc.RuntimeHandler, params []interface{}
 		}
 	})
 
-	err = vctx.SetConnection(connId, req.Connection, db, closedChannel)
+	err = vctx.SetConnection(connId, req.Driver, req.Connection, db, closedChannel)
 	if err != nil {
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
 	}
 
-	if req.Driver == "sqlite3" {
+	if isSqliteDriver(req.Driver) {
 		db.Exec("PRAGMA journal_mode = WAL")
 		db.Exec("PRAGMA foreign_keys = ON")
 	}
+	conn, found = vctx.GetConnection(connId)
+	if !found {
+		response.Error = "sql connection not found"
+		return prepareResponse(rnh, response)
+	}
+	return connectMigrate(ctx, rnh, conn, req.Id, response)
+}
+
+// connectMigrate applies the pending migrations registered by the contract for the connection id
+func connectMigrate(ctx *Context, rnh memc.RuntimeHandler, conn *SqlOpenConnection, id string, response *SqlConnectionResponse) ([]interface{}, error) {
+	if !hasRegisteredMigrations(ctx.ContractStore, id) {
+		return prepareResponse(rnh, response)
+	}
+	if conn.OpenSavepointTx == nil {
+		err := beginDbTx(conn, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	versions, err := conn.ApplyRegisteredMigrations(ctx.Ctx, ctx.ContractStore, id, ctx.Ctx.BlockHeight())
+	if len(versions) > 0 {
+		response.SchemaVersions = versions
+	}
+	if err != nil {
+		response.Error = fmt.Sprintf("migration failed: %v", err)
+	}
 	return prepareResponse(rnh, response)
 }
 
@@ -110,6 +148,11 @@ func Close(_context interface{}, rnh memc.RuntimeHandler, params []interface{})
 		response.Error = "sql connection not found"
 		return prepareResponse(rnh, response)
 	}
+	db.closeTxResources()
+	for _, stmt := range db.Statements {
+		stmt.Close()
+	}
+	db.Statements = map[string]*sql.Stmt{}
 	err = db.Db.Close()
 	if err != nil {
 		response.Error = err.Error()
@@ -191,6 +234,11 @@ func Execute(_context interface{}, rnh memc.RuntimeHandler, params []interface{}
 		}
 	}
 
+	if err := db.CheckQuery(req.Query); err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+
 	reqparams, err := parseRequestParams(req.Params)
 	if err != nil {
 		response.Error = err.Error()
@@ -256,57 +304,242 @@ func BatchAtomic(_context interface{}, rnh memc.RuntimeHandler, params []interfa
 	}
 
 	// to make this atomic, we add a savepoint
-	savepoint := "batchatomic"
-	_, err = db.OpenSavepointTx.Exec(fmt.Sprintf("SAVEPOINT %s", savepoint))
+	txerr, err := withSavepoint(ctx.Ctx, db.OpenSavepointTx, "batchatomic", func() error {
+		responses, err := db.execCommands(ctx.Ctx, req.Commands)
+		response.Responses = responses
+		return err
+	})
 	if err != nil {
-		ctx.Logger(ctx.Ctx).Error(fmt.Sprintf("cannot add savepoint: %s, %s", savepoint, err.Error()))
+		ctx.Logger(ctx.Ctx).Error(err.Error())
 		return nil, err
 	}
+	if txerr != nil {
+		response.Error = txerr.Error()
+	}
+	return prepareResponse(rnh, response)
+}
 
-	var txerr error
-	for _, cmd := range req.Commands {
-		reqparams, err := parseRequestParams(cmd.Params)
-		if err != nil {
-			txerr = err
-			break
-		}
+func Query(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	ctx := _context.(*Context)
+	keyptr, _ := memc.GetPointerFromParams(rnh, params, 0)
+	requestbz, err := rnh.ReadMemFromPtr(keyptr)
+	if err != nil {
+		return nil, err
+	}
+	var req SqlQueryRequest
+	err = json.Unmarshal(requestbz, &req)
+	if err != nil {
+		return nil, err
+	}
 
-		qparams := parseSqlQueryParams(reqparams)
-		res, err := db.OpenSavepointTx.ExecContext(ctx.Ctx, cmd.Query, qparams...)
+	vctx, err := GetSqlContext(ctx.Context.GoContextParent)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SqlQueryResponse{Error: ""}
+	connId := buildConnectionId(req.Id, ctx)
+	db, found := vctx.GetConnection(connId)
+	if !found {
+		response.Error = "sql connection not found"
+		return prepareResponse(rnh, response)
+	}
+
+	if db.OpenSavepointTx == nil {
+		err := beginDbTx(db, ctx)
 		if err != nil {
-			txerr = err
-			break
+			return nil, err
 		}
-		execResp := SqlExecuteResponse{}
-		prepareExecutionResponse(res, &execResp)
-		response.Responses = append(response.Responses, execResp)
 	}
-	if txerr != nil {
-		_, err = db.OpenSavepointTx.Exec(fmt.Sprintf("ROLLBACK TO %s", savepoint))
+
+	if err := db.CheckQuery(req.Query); err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+
+	reqparams, err := parseRequestParams(req.Params)
+	if err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+
+	qparams := parseSqlQueryParams(reqparams)
+	rows, err := db.OpenSavepointTx.QueryContext(ctx.Ctx, req.Query, qparams...)
+	if err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+	defer rows.Close()
+
+	if rows == nil {
+		response.Data = []byte(`[]`)
+		return prepareResponse(rnh, response)
+	}
+
+	resp, err := RowsToJSON(rows)
+	if err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+	response.Data = resp
+	return prepareResponse(rnh, response)
+}
+
+// Prepare parses a query once; the statement can be executed
+// with ExecutePrepared or read with QueryOpen until it is closed
+func Prepare(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	ctx := _context.(*Context)
+	keyptr, _ := memc.GetPointerFromParams(rnh, params, 0)
+	requestbz, err := rnh.ReadMemFromPtr(keyptr)
+	if err != nil {
+		return nil, err
+	}
+	var req SqlPrepareRequest
+	err = json.Unmarshal(requestbz, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	vctx, err := GetSqlContext(ctx.Context.GoContextParent)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SqlPrepareResponse{Error: ""}
+	connId := buildConnectionId(req.Id, ctx)
+	db, found := vctx.GetConnection(connId)
+	if !found {
+		response.Error = "sql connection not found"
+		return prepareResponse(rnh, response)
+	}
+	if _, found := db.Statements[req.StatementId]; found {
+		response.Error = "prepared statement id already in use"
+		return prepareResponse(rnh, response)
+	}
+
+	if err := db.CheckQuery(req.Query); err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+
+	stmt, err := db.Db.PrepareContext(ctx.Ctx, req.Query)
+	if err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+	db.Statements[req.StatementId] = stmt
+	return prepareResponse(rnh, response)
+}
+
+func ExecutePrepared(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	ctx := _context.(*Context)
+	keyptr, _ := memc.GetPointerFromParams(rnh, params, 0)
+	requestbz, err := rnh.ReadMemFromPtr(keyptr)
+	if err != nil {
+		return nil, err
+	}
+	var req SqlExecutePreparedRequest
+	err = json.Unmarshal(requestbz, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	vctx, err := GetSqlContext(ctx.Context.GoContextParent)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SqlExecuteResponse{Error: ""}
+	connId := buildConnectionId(req.Id, ctx)
+	db, found := vctx.GetConnection(connId)
+	if !found {
+		response.Error = "sql connection not found"
+		return prepareResponse(rnh, response)
+	}
+
+	if db.OpenSavepointTx == nil {
+		err := beginDbTx(db, ctx)
 		if err != nil {
-			ctx.Logger(ctx.Ctx).Error(fmt.Sprintf("cannot rollback to savepoint: %s, %s", savepoint, err.Error()))
 			return nil, err
 		}
-		response.Error = txerr.Error()
+	}
+
+	stmt, err := db.txStatement(ctx.Ctx, req.StatementId)
+	if err != nil {
+		response.Error = err.Error()
 		return prepareResponse(rnh, response)
 	}
 
-	_, err = db.OpenSavepointTx.Exec(fmt.Sprintf("RELEASE %s", savepoint))
+	reqparams, err := parseRequestParams(req.Params)
+	if err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+
+	qparams := parseSqlQueryParams(reqparams)
+	res, err := stmt.ExecContext(ctx.Ctx, qparams...)
+	if err != nil {
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+
+	prepareExecutionResponse(res, response)
+
+	return prepareResponse(rnh, response)
+}
+
+func ClosePrepared(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	ctx := _context.(*Context)
+	keyptr, _ := memc.GetPointerFromParams(rnh, params, 0)
+	requestbz, err := rnh.ReadMemFromPtr(keyptr)
+	if err != nil {
+		return nil, err
+	}
+	var req SqlClosePreparedRequest
+	err = json.Unmarshal(requestbz, &req)
 	if err != nil {
-		ctx.Logger(ctx.Ctx).Error(fmt.Sprintf("cannot release savepoint: %s, %s", savepoint, err.Error()))
 		return nil, err
 	}
+
+	vctx, err := GetSqlContext(ctx.Context.GoContextParent)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SqlClosePreparedResponse{Error: ""}
+	connId := buildConnectionId(req.Id, ctx)
+	db, found := vctx.GetConnection(connId)
+	if !found {
+		response.Error = "sql connection not found"
+		return prepareResponse(rnh, response)
+	}
+	stmt, found := db.Statements[req.StatementId]
+	if !found {
+		response.Error = "prepared statement not found"
+		return prepareResponse(rnh, response)
+	}
+	if txstmt, found := db.TxStatements[req.StatementId]; found {
+		txstmt.Close()
+		delete(db.TxStatements, req.StatementId)
+	}
+	delete(db.Statements, req.StatementId)
+	err = stmt.Close()
+	if err != nil {
+		response.Error = err.Error()
+	}
 	return prepareResponse(rnh, response)
 }
 
-func Query(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+// QueryOpen opens a cursor, which reads rows on demand with QueryNext.
+// Cursors read inside the current transaction and are closed when it ends.
+func QueryOpen(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	ctx := _context.(*Context)
 	keyptr, _ := memc.GetPointerFromParams(rnh, params, 0)
 	requestbz, err := rnh.ReadMemFromPtr(keyptr)
 	if err != nil {
 		return nil, err
 	}
-	var req SqlQueryRequest
+	var req SqlQueryOpenRequest
 	err = json.Unmarshal(requestbz, &req)
 	if err != nil {
 		return nil, err
@@ -317,13 +550,17 @@ func Query(_context interface{}, rnh memc.RuntimeHandler, params []interface{})
 		return nil, err
 	}
 
-	response := &SqlQueryResponse{Error: ""}
+	response := &SqlQueryOpenResponse{Error: "", Columns: []SqlColumn{}}
 	connId := buildConnectionId(req.Id, ctx)
 	db, found := vctx.GetConnection(connId)
 	if !found {
 		response.Error = "sql connection not found"
 		return prepareResponse(rnh, response)
 	}
+	if _, found := db.Cursors[req.CursorId]; found {
+		response.Error = "cursor id already in use"
+		return prepareResponse(rnh, response)
+	}
 
 	if db.OpenSavepointTx == nil {
 		err := beginDbTx(db, ctx)
@@ -337,26 +574,230 @@ func Query(_context interface{}, rnh memc.RuntimeHandler, params []interface{})
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
 	}
-
 	qparams := parseSqlQueryParams(reqparams)
-	rows, err := db.OpenSavepointTx.QueryContext(ctx.Ctx, req.Query, qparams...)
+
+	var rows *sql.Rows
+	if req.StatementId != "" {
+		stmt, serr := db.txStatement(ctx.Ctx, req.StatementId)
+		if serr != nil {
+			response.Error = serr.Error()
+			return prepareResponse(rnh, response)
+		}
+		rows, err = stmt.QueryContext(ctx.Ctx, qparams...)
+	} else {
+		if cerr := db.CheckQuery(req.Query); cerr != nil {
+			response.Error = cerr.Error()
+			return prepareResponse(rnh, response)
+		}
+		rows, err = db.OpenSavepointTx.QueryContext(ctx.Ctx, req.Query, qparams...)
+	}
 	if err != nil {
 		response.Error = err.Error()
 		return prepareResponse(rnh, response)
 	}
-	defer rows.Close()
 
-	if rows == nil {
-		response.Data = []byte(`[]`)
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		rows.Close()
+		response.Error = err.Error()
+		return prepareResponse(rnh, response)
+	}
+	for _, column := range columns {
+		response.Columns = append(response.Columns, SqlColumn{Name: column.Name(), DatabaseType: column.DatabaseTypeName()})
+	}
+	values, err := readCursorRows(rows, columns)
+	if err != nil {
+		response.Error = err.Error()
+		response.Columns = []SqlColumn{}
 		return prepareResponse(rnh, response)
 	}
+	db.Cursors[req.CursorId] = &SqlCursor{Rows: values, Depth: db.SavepointDepth}
+	response.Buffered = true
+	return prepareResponse(rnh, response)
+}
 
-	resp, err := RowsToJSON(rows)
+func QueryNext(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	ctx := _context.(*Context)
+	keyptr, _ := memc.GetPointerFromParams(rnh, params, 0)
+	requestbz, err := rnh.ReadMemFromPtr(keyptr)
+	if err != nil {
+		return nil, err
+	}
+	var req SqlQueryNextRequest
+	err = json.Unmarshal(requestbz, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	vctx, err := GetSqlContext(ctx.Context.GoContextParent)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SqlQueryNextResponse{Error: "", Rows: [][]SqlValue{}}
+	connId := buildConnectionId(req.Id, ctx)
+	db, found := vctx.GetConnection(connId)
+	if !found {
+		response.Error = "sql connection not found"
+		return prepareResponse(rnh, response)
+	}
+	cursor, found := db.Cursors[req.CursorId]
+	if !found {
+		response.Error = "cursor not found"
+		return prepareResponse(rnh, response)
+	}
+	count := req.Count
+	if count == 0 {
+		count = DefaultCursorFetchCount
+	}
+	if count < 0 || count > MaxCursorFetchCount {
+		response.Error = fmt.Sprintf("count must be between 1 and %d", MaxCursorFetchCount)
+		return prepareResponse(rnh, response)
+	}
+
+	rows, done := cursor.next(count)
+	response.Rows = rows
+	response.Done = done
+	return prepareResponse(rnh, response)
+}
+
+func QueryClose(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	ctx := _context.(*Context)
+	keyptr, _ := memc.GetPointerFromParams(rnh, params, 0)
+	requestbz, err := rnh.ReadMemFromPtr(keyptr)
+	if err != nil {
+		return nil, err
+	}
+	var req SqlQueryCloseRequest
+	err = json.Unmarshal(requestbz, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	vctx, err := GetSqlContext(ctx.Context.GoContextParent)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SqlQueryCloseResponse{Error: ""}
+	connId := buildConnectionId(req.Id, ctx)
+	db, found := vctx.GetConnection(connId)
+	if !found {
+		response.Error = "sql connection not found"
+		return prepareResponse(rnh, response)
+	}
+	cursor, found := db.Cursors[req.CursorId]
+	if !found {
+		response.Error = "cursor not found"
+		return prepareResponse(rnh, response)
+	}
+	delete(db.Cursors, req.CursorId)
+	cursor.close()
+	return prepareResponse(rnh, response)
+}
+
+// Migrate applies or reverts the schema migrations of a table, atomically
+func Migrate(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	ctx := _context.(*Context)
+	keyptr, _ := memc.GetPointerFromParams(rnh, params, 0)
+	requestbz, err := rnh.ReadMemFromPtr(keyptr)
+	if err != nil {
+		return nil, err
+	}
+	var req SqlMigrateRequest
+	err = json.Unmarshal(requestbz, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	vctx, err := GetSqlContext(ctx.Context.GoContextParent)
+	if err != nil {
+		return nil, err
+	}
+
+	connId := buildConnectionId(req.Id, ctx)
+	db, found := vctx.GetConnection(connId)
+	if !found {
+		response := &SqlMigrateResponse{Error: "sql connection not found", Applied: []int64{}, Reverted: []int64{}}
+		return prepareResponse(rnh, response)
+	}
+
+	if db.OpenSavepointTx == nil {
+		err := beginDbTx(db, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := db.ApplyMigrations(ctx.Ctx, req, ctx.Ctx.BlockHeight())
+	if err != nil {
+		ctx.Logger(ctx.Ctx).Error(err.Error())
+		return nil, err
+	}
+	return prepareResponse(rnh, response)
+}
+
+// RegisterMigrations adds migrations of a table to the contract's on-chain registry.
+// They are applied by every node when the contract connects with the same id.
+func RegisterMigrations(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	ctx := _context.(*Context)
+	keyptr, _ := memc.GetPointerFromParams(rnh, params, 0)
+	requestbz, err := rnh.ReadMemFromPtr(keyptr)
+	if err != nil {
+		return nil, err
+	}
+	var req SqlRegisterMigrationsRequest
+	err = json.Unmarshal(requestbz, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SqlRegisterMigrationsResponse{Error: ""}
+	response.Version, err = registerMigrations(ctx.ContractStore, req.Id, req.Table, req.Migrations)
 	if err != nil {
 		response.Error = err.Error()
+	}
+	return prepareResponse(rnh, response)
+}
+
+// SchemaVersion returns the last migration this node applied to a table
+func SchemaVersion(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	ctx := _context.(*Context)
+	keyptr, _ := memc.GetPointerFromParams(rnh, params, 0)
+	requestbz, err := rnh.ReadMemFromPtr(keyptr)
+	if err != nil {
+		return nil, err
+	}
+	var req SqlSchemaVersionRequest
+	err = json.Unmarshal(requestbz, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	vctx, err := GetSqlContext(ctx.Context.GoContextParent)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SqlSchemaVersionResponse{Error: ""}
+	connId := buildConnectionId(req.Id, ctx)
+	db, found := vctx.GetConnection(connId)
+	if !found {
+		response.Error = "sql connection not found"
 		return prepareResponse(rnh, response)
 	}
-	response.Data = resp
+
+	if db.OpenSavepointTx == nil {
+		err := beginDbTx(db, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response.Version, err = db.SchemaVersion(ctx.Ctx, req.Table)
+	if err != nil {
+		response.Error = err.Error()
+	}
 	return prepareResponse(rnh, response)
 }
 
@@ -370,12 +811,15 @@ func prepareResponse(rnh memc.RuntimeHandler, response interface{}) ([]interface
 }
 
 func beginDbTx(db *SqlOpenConnection, ctx *Context) error {
-	tx, err := db.Db.BeginTx(ctx.Ctx, nil)
+	// the transaction outlives the call that opened it, so it must not be
+	// rolled back when the call deadline expires
+	tx, err := db.Db.BeginTx(context.WithoutCancel(ctx.Ctx), nil)
 	if err != nil {
 		return fmt.Errorf("cannot begin atomic db transaction: %v", err)
 	}
 	db.OpenSavepointTx = tx
 	db.SavePointMap["sp0"] = true
+	db.SavepointDepth = 1
 	_, err = tx.Exec("SAVEPOINT sp0")
 	if err != nil {
 		return fmt.Errorf("cannot add savepoint sp0: %v", err)
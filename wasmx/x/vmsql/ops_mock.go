@@ -33,3 +33,48 @@ func QueryMock(_context interface{}, rnh memc.RuntimeHandler, params []interface
 	response := &SqlQueryResponse{Error: ""}
 	return prepareResponse(rnh, response)
 }
+
+func PrepareMock(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	response := &SqlPrepareResponse{Error: ""}
+	return prepareResponse(rnh, response)
+}
+
+func ExecutePreparedMock(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	response := &SqlExecuteResponse{Error: ""}
+	return prepareResponse(rnh, response)
+}
+
+func ClosePreparedMock(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	response := &SqlClosePreparedResponse{Error: ""}
+	return prepareResponse(rnh, response)
+}
+
+func QueryOpenMock(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	response := &SqlQueryOpenResponse{Error: "", Columns: []SqlColumn{}}
+	return prepareResponse(rnh, response)
+}
+
+func QueryNextMock(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	response := &SqlQueryNextResponse{Error: "", Rows: [][]SqlValue{}, Done: true}
+	return prepareResponse(rnh, response)
+}
+
+func QueryCloseMock(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	response := &SqlQueryCloseResponse{Error: ""}
+	return prepareResponse(rnh, response)
+}
+
+func MigrateMock(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	response := &SqlMigrateResponse{Error: "", Applied: []int64{}, Reverted: []int64{}}
+	return prepareResponse(rnh, response)
+}
+
+func RegisterMigrationsMock(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	response := &SqlRegisterMigrationsResponse{Error: ""}
+	return prepareResponse(rnh, response)
+}
+
+func SchemaVersionMock(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
+	response := &SqlSchemaVersionResponse{Error: ""}
+	return prepareResponse(rnh, response)
+}
@@ -0,0 +1,233 @@
+package vmsql_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/dbadapter"
+	"cosmossdk.io/store/prefix"
+	dbm "github.com/cosmos/cosmos-db"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	mcodec "github.com/loredanacirstea/wasmx/codec"
+	"github.com/loredanacirstea/wasmx/x/vmsql"
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+	vmtypes "github.com/loredanacirstea/wasmx/x/wasmx/vm"
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
+)
+
+// testRuntime passes requests and responses as byte slices instead of wasm memory pointers
+type testRuntime struct{}
+
+func (testRuntime) GetVm() memc.IVm                               { return nil }
+func (testRuntime) GetMemory() (memc.IMemory, error)              { return nil, nil }
+func (testRuntime) PtrParamsLength() int                          { return 1 }
+func (testRuntime) ReadStringFromPtr(interface{}) (string, error) { return "", nil }
+func (testRuntime) ReadJsString(arr []byte) string                { return string(arr) }
+func (testRuntime) ReadMemFromPtr(pointer []interface{}) ([]byte, error) {
+	return pointer[0].([]byte), nil
+}
+func (testRuntime) AllocateWriteMem(data []byte) ([]interface{}, error) {
+	return []interface{}{data}, nil
+}
+
+type hostFn func(context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error)
+
+type hostTest struct {
+	t      *testing.T
+	ctx    *vmsql.Context
+	module vmsql.AppModule
+}
+
+func newHostTest(t *testing.T) *hostTest {
+	goctx, cancel := context.WithCancel(context.Background())
+	goctx = vmsql.WithSqlEmptyContext(goctx, nil)
+	group, _ := errgroup.WithContext(goctx)
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, group.Wait())
+	})
+	sdkctx := sdk.Context{}.WithContext(goctx).WithLogger(log.NewNopLogger())
+	ctx := &vmsql.Context{Context: &vmtypes.Context{
+		GoRoutineGroup:  group,
+		GoContextParent: goctx,
+		Ctx:             sdkctx,
+		Logger:          func(ctx sdk.Context) log.Logger { return ctx.Logger() },
+		ContractStore:   prefix.NewStore(&dbadapter.Store{DB: dbm.NewMemDB()}, []byte("contract")),
+		Env: &types.Env{Contract: types.EnvContractInfo{
+			Address: mcodec.NewAccAddressPrefixed([]byte{1, 2, 3}, "mythos"),
+		}},
+	}}
+	return &hostTest{t: t, ctx: ctx, module: vmsql.NewAppModule(goctx)}
+}
+
+func (h *hostTest) call(fn hostFn, req interface{}, resp interface{}) {
+	reqbz, err := json.Marshal(req)
+	require.NoError(h.t, err)
+	result, err := fn(h.ctx, testRuntime{}, []interface{}{reqbz})
+	require.NoError(h.t, err)
+	require.NoError(h.t, json.Unmarshal(result[0].([]byte), resp))
+}
+
+func (h *hostTest) execute(query string) {
+	var resp vmsql.SqlExecuteResponse
+	h.call(vmsql.Execute, vmsql.SqlExecuteRequest{Id: "conn", Query: query}, &resp)
+	require.Equal(h.t, "", resp.Error)
+}
+
+func (h *hostTest) next(cursorId string, count int) vmsql.SqlQueryNextResponse {
+	var resp vmsql.SqlQueryNextResponse
+	h.call(vmsql.QueryNext, vmsql.SqlQueryNextRequest{Id: "conn", CursorId: cursorId, Count: count}, &resp)
+	return resp
+}
+
+func (h *hostTest) open(cursorId string, query string) {
+	var resp vmsql.SqlQueryOpenResponse
+	h.call(vmsql.QueryOpen, vmsql.SqlQueryOpenRequest{Id: "conn", CursorId: cursorId, Query: query}, &resp)
+	require.Equal(h.t, "", resp.Error)
+	require.True(h.t, resp.Buffered)
+}
+
+func (h *hostTest) connect() *vmsql.SqlOpenConnection {
+	var resp vmsql.SqlConnectionResponse
+	h.call(vmsql.Connect, vmsql.SqlConnectionRequest{Id: "conn", Driver: "sqlite3", Connection: filepath.Join(h.t.TempDir(), "test.db")}, &resp)
+	require.Equal(h.t, "", resp.Error)
+	vctx, err := vmsql.GetSqlContext(h.ctx.GoContextParent)
+	require.NoError(h.t, err)
+	conn, found := vctx.GetConnection(h.ctx.Env.Contract.Address.String() + "_conn")
+	require.True(h.t, found)
+
+	h.execute(`CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT)`)
+	h.endTransaction()
+	return conn
+}
+
+func (h *hostTest) endTransaction() {
+	require.NoError(h.t, h.module.EndTransaction(h.ctx.GoContextParent, sdk.ExecModeFinalize, sdk.GasInfo{}, nil, nil, nil))
+}
+
+func intParam(t *testing.T, value int) vmsql.Params {
+	bz, err := json.Marshal(vmsql.SqlQueryParam{Value: value})
+	require.NoError(t, err)
+	return vmsql.Params{bz}
+}
+
+func TestHostPreparedStatements(t *testing.T) {
+	h := newHostTest(t)
+	conn := h.connect()
+
+	var prepResp vmsql.SqlPrepareResponse
+	h.call(vmsql.Prepare, vmsql.SqlPrepareRequest{Id: "conn", StatementId: "insert", Query: `INSERT INTO items (id, name) VALUES (?, 'item')`}, &prepResp)
+	require.Equal(t, "", prepResp.Error)
+	h.call(vmsql.Prepare, vmsql.SqlPrepareRequest{Id: "conn", StatementId: "insert", Query: `SELECT 1`}, &prepResp)
+	require.Equal(t, "prepared statement id already in use", prepResp.Error)
+
+	// the statement is reused for each execution
+	for i := 1; i <= 3; i++ {
+		var resp vmsql.SqlExecuteResponse
+		h.call(vmsql.ExecutePrepared, vmsql.SqlExecutePreparedRequest{Id: "conn", StatementId: "insert", Params: intParam(t, i)}, &resp)
+		require.Equal(t, "", resp.Error)
+		require.Equal(t, int64(i), resp.LastInsertId)
+	}
+	require.Len(t, conn.TxStatements, 1)
+
+	// the transaction statement is closed when the transaction ends; the statement is not
+	h.endTransaction()
+	require.Nil(t, conn.OpenSavepointTx)
+	require.Empty(t, conn.TxStatements)
+	var resp vmsql.SqlExecuteResponse
+	h.call(vmsql.ExecutePrepared, vmsql.SqlExecutePreparedRequest{Id: "conn", StatementId: "insert", Params: intParam(t, 4)}, &resp)
+	require.Equal(t, "", resp.Error)
+
+	var closeResp vmsql.SqlClosePreparedResponse
+	h.call(vmsql.ClosePrepared, vmsql.SqlClosePreparedRequest{Id: "conn", StatementId: "insert"}, &closeResp)
+	require.Equal(t, "", closeResp.Error)
+	h.call(vmsql.ExecutePrepared, vmsql.SqlExecutePreparedRequest{Id: "conn", StatementId: "insert", Params: intParam(t, 5)}, &resp)
+	require.Equal(t, "prepared statement not found: insert", resp.Error)
+}
+
+func TestHostCursors(t *testing.T) {
+	h := newHostTest(t)
+	conn := h.connect()
+	h.execute(`INSERT INTO items (id, name) VALUES (1, 'a'), (2, 'b'), (3, 'c'), (4, 'd'), (5, 'e')`)
+
+	h.open("all", `SELECT id FROM items ORDER BY id`)
+	resp := h.next("all", 2)
+	require.Equal(t, "", resp.Error)
+	require.False(t, resp.Done)
+	require.Equal(t, [][]vmsql.SqlValue{{{Type: vmsql.SqlValueInt, Int: 1}}, {{Type: vmsql.SqlValueInt, Int: 2}}}, resp.Rows)
+
+	// rows are read when the cursor is opened, so statements can run while it is open
+	h.execute(`INSERT INTO items (id, name) VALUES (6, 'f')`)
+
+	// a cursor opened in a sub call that is rolled back is closed
+	require.NoError(t, h.module.BeginSubCall(h.ctx.GoContextParent, 1, 0, false))
+	h.open("sub", `SELECT id FROM items`)
+	require.NoError(t, h.module.EndSubCall(h.ctx.GoContextParent, 1, 0, false, context.Canceled))
+	require.Equal(t, "cursor not found", h.next("sub", 1).Error)
+
+	// cursors of released sub calls stay open
+	require.NoError(t, h.module.BeginSubCall(h.ctx.GoContextParent, 1, 1, false))
+	h.open("released", `SELECT id FROM items`)
+	require.NoError(t, h.module.EndSubCall(h.ctx.GoContextParent, 1, 1, false, nil))
+	require.Equal(t, "", h.next("released", 1).Error)
+
+	// the rollback did not abort the outer cursor
+	resp = h.next("all", 0)
+	require.Equal(t, "", resp.Error)
+	require.True(t, resp.Done)
+	require.Equal(t, [][]vmsql.SqlValue{{{Type: vmsql.SqlValueInt, Int: 3}}, {{Type: vmsql.SqlValueInt, Int: 4}}, {{Type: vmsql.SqlValueInt, Int: 5}}}, resp.Rows)
+	resp = h.next("all", 2)
+	require.True(t, resp.Done)
+	require.Empty(t, resp.Rows)
+
+	require.Equal(t, "count must be between 1 and 1000", h.next("all", vmsql.MaxCursorFetchCount+1).Error)
+
+	var closeResp vmsql.SqlQueryCloseResponse
+	h.call(vmsql.QueryClose, vmsql.SqlQueryCloseRequest{Id: "conn", CursorId: "all"}, &closeResp)
+	require.Equal(t, "", closeResp.Error)
+	require.Equal(t, "cursor not found", h.next("all", 1).Error)
+
+	// cursors are closed when the transaction ends
+	h.endTransaction()
+	require.Empty(t, conn.Cursors)
+	require.Equal(t, "cursor not found", h.next("released", 1).Error)
+
+	var openResp vmsql.SqlQueryOpenResponse
+	h.call(vmsql.QueryOpen, vmsql.SqlQueryOpenRequest{Id: "conn", CursorId: "large", Query: fmt.Sprintf(`WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c LIMIT %d) SELECT x FROM c`, vmsql.MaxBufferedCursorRows+1)}, &openResp)
+	require.Equal(t, "cursor has more than 10000 rows", openResp.Error)
+	require.Equal(t, "cursor not found", h.next("large", 1).Error)
+
+	// the buffered rows are also bounded by size
+	h.call(vmsql.QueryOpen, vmsql.SqlQueryOpenRequest{Id: "conn", CursorId: "large", Query: `WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c LIMIT 10) SELECT randomblob(1024 * 1024) FROM c`}, &openResp)
+	require.Equal(t, fmt.Sprintf("cursor has more than %d bytes", vmsql.MaxBufferedCursorBytes), openResp.Error)
+	require.False(t, openResp.Buffered)
+	require.Equal(t, "cursor not found", h.next("large", 1).Error)
+}
+
+func TestHostDeadline(t *testing.T) {
+	h := newHostTest(t)
+	h.connect()
+	sdkctx := h.ctx.Ctx
+	goctx, cancel := context.WithTimeout(sdkctx.Context(), 200*time.Millisecond)
+	defer cancel()
+	h.ctx.Ctx = sdkctx.WithContext(goctx)
+
+	start := time.Now()
+	var resp vmsql.SqlQueryResponse
+	h.call(vmsql.Query, vmsql.SqlQueryRequest{Id: "conn", Query: `WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c) SELECT count(*) FROM c`}, &resp)
+	require.Equal(t, context.DeadlineExceeded.Error(), resp.Error)
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	// the transaction of the connection outlives the deadline
+	h.ctx.Ctx = sdkctx
+	h.execute(`INSERT INTO items (id, name) VALUES (1, 'a')`)
+	h.endTransaction()
+}
@@ -0,0 +1,227 @@
+package vmsql
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// sqlite drivers: "sqlite" (modernc.org/sqlite) and "sqlite3" (github.com/mattn/go-sqlite3, linked through cosmos-db)
+var sqliteDrivers = []string{"sqlite", "sqlite3"}
+
+func isSqliteDriver(driver string) bool {
+	return slices.Contains(sqliteDrivers, driver)
+}
+
+// ConnectionPolicy restricts the databases contracts can connect to
+type ConnectionPolicy struct {
+	drivers     map[string]bool
+	connections []*regexp.Regexp
+	sqliteDirs  []string
+}
+
+// NewConnectionPolicy returns a policy allowing the given drivers.
+// Connection strings of non-sqlite drivers must fully match one of the connection patterns.
+// Sqlite database files must be inside one of the sqlite directories, which must be absolute;
+// relative database paths are resolved from the first directory. No directories allows any path.
+func NewConnectionPolicy(drivers []string, connections []string, sqliteDirs []string) (*ConnectionPolicy, error) {
+	policy := &ConnectionPolicy{drivers: map[string]bool{}}
+	for _, driver := range drivers {
+		if driver == "" {
+			return nil, fmt.Errorf("empty sql driver name")
+		}
+		policy.drivers[driver] = true
+	}
+	for _, pattern := range connections {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid sql connection pattern %q: %v", pattern, err)
+		}
+		policy.connections = append(policy.connections, re)
+	}
+	for _, dir := range sqliteDirs {
+		if !filepath.IsAbs(dir) {
+			return nil, fmt.Errorf("sqlite directory must be an absolute path: %s", dir)
+		}
+		policy.sqliteDirs = append(policy.sqliteDirs, filepath.Clean(dir))
+	}
+	return policy, nil
+}
+
+// Resolve checks the driver and connection string against the policy
+// and returns the connection string to open. A nil policy allows any connection.
+func (p *ConnectionPolicy) Resolve(driver string, connection string) (string, error) {
+	if p == nil {
+		return connection, nil
+	}
+	if !p.drivers[driver] {
+		return "", fmt.Errorf("sql driver not allowed: %s", driver)
+	}
+	if isSqliteDriver(driver) {
+		return p.resolveSqlitePath(connection)
+	}
+	if !p.connectionAllowed(connection) {
+		return "", fmt.Errorf("sql connection not allowed for driver %s", driver)
+	}
+	return connection, nil
+}
+
+// sqliteDir returns the directory of a sqlite database file, or "" if it is in memory
+func sqliteDir(connection string) string {
+	if isSqliteMemory(connection) {
+		return ""
+	}
+	path, _ := splitSqliteConnection(connection)
+	return filepath.Dir(path)
+}
+
+func (p *ConnectionPolicy) connectionAllowed(connection string) bool {
+	for _, re := range p.connections {
+		if re.MatchString(connection) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ConnectionPolicy) resolveSqlitePath(connection string) (string, error) {
+	if len(p.sqliteDirs) == 0 {
+		return connection, nil
+	}
+	if isSqliteMemory(connection) {
+		return connection, nil
+	}
+	path, query := splitSqliteConnection(connection)
+	// an empty path opens a temporary database outside the sqlite directories
+	if path == "" {
+		return "", fmt.Errorf("sqlite database path not allowed: %s", connection)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(p.sqliteDirs[0], path)
+	}
+	path = filepath.Clean(path)
+	for _, dir := range p.sqliteDirs {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if strings.HasPrefix(connection, "file:") {
+			path = "file:" + path
+		}
+		if query != "" {
+			path += "?" + query
+		}
+		return path, nil
+	}
+	return "", fmt.Errorf("sqlite database path not allowed: %s", connection)
+}
+
+// splitSqliteConnection returns the file path and the query of a sqlite connection string
+func splitSqliteConnection(connection string) (string, string) {
+	path := strings.TrimPrefix(connection, "file:")
+	query := ""
+	if i := strings.Index(path, "?"); i >= 0 {
+		path, query = path[:i], path[i+1:]
+	}
+	return path, query
+}
+
+// isSqliteMemory returns true for ":memory:" and for "file:" URIs whose mode query
+// parameters are all "memory". Any other connection string opens a file.
+func isSqliteMemory(connection string) bool {
+	if connection == ":memory:" {
+		return true
+	}
+	if !strings.HasPrefix(connection, "file:") {
+		return false
+	}
+	path, query := splitSqliteConnection(connection)
+	if path == ":memory:" {
+		return true
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return false
+	}
+	modes := values["mode"]
+	if len(modes) == 0 {
+		return false
+	}
+	for _, mode := range modes {
+		if mode != "memory" {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckQuery rejects sqlite statements that open other database files: ATTACH, DETACH and VACUUM INTO.
+// They would bypass the sqlite directories of the policy, so they are rejected on every sqlite connection.
+func (conn *SqlOpenConnection) CheckQuery(query string) error {
+	if !isSqliteDriver(conn.Driver) {
+		return nil
+	}
+	vacuum := false
+	for _, word := range sqlKeywords(query) {
+		switch word {
+		case ";":
+			vacuum = false
+		case "ATTACH", "DETACH":
+			return fmt.Errorf("sqlite %s is not allowed", word)
+		case "VACUUM":
+			vacuum = true
+		case "INTO":
+			if vacuum {
+				return fmt.Errorf("sqlite VACUUM INTO is not allowed")
+			}
+		}
+	}
+	return nil
+}
+
+// sqlKeywords returns the upper case words and statement separators of a query,
+// skipping string literals, quoted identifiers and comments
+func sqlKeywords(query string) []string {
+	words := []string{}
+	runes := []rune(query)
+	// skipTo returns the index after the next end token, from i
+	skipTo := func(i int, end string) int {
+		n := len([]rune(end))
+		for ; i+n <= len(runes); i++ {
+			if string(runes[i:i+n]) == end {
+				return i + n
+			}
+		}
+		return len(runes)
+	}
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			// doubled quotes are escapes, which skipping twice handles
+			i = skipTo(i+1, string(c))
+		case c == '[':
+			i = skipTo(i+1, "]")
+		case c == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			i = skipTo(i+2, "\n")
+		case c == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i = skipTo(i+2, "*/")
+		case c == ';':
+			words = append(words, ";")
+			i++
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '$') {
+				i++
+			}
+			words = append(words, strings.ToUpper(string(runes[start:i])))
+		default:
+			i++
+		}
+	}
+	return words
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cast"
+
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+
+	"github.com/loredanacirstea/wasmx/x/vmsql"
+)
+
+// DefaultSqliteDir is the default directory of sqlite databases, relative to the node home
+const DefaultSqliteDir = "data/sql"
+
+// DefaultAllowedDrivers are the sqlite drivers; server databases must be allowed explicitly
+var DefaultAllowedDrivers = []string{"sqlite", "sqlite3"}
+
+// SqlConfig defines which databases contracts can connect to through the sql host functions.
+type SqlConfig struct {
+	// AllowedDrivers are the drivers contracts can use. Drivers must also be compiled in
+	// with the sqlite, postgres or mysql build tags.
+	AllowedDrivers []string `mapstructure:"allowed-drivers"`
+	// AllowedConnections are regular expressions; connection strings of non-sqlite drivers
+	// must fully match one of them.
+	AllowedConnections []string `mapstructure:"allowed-connections"`
+	// SqliteDirs are the directories sqlite database files can be in. Relative paths are resolved
+	// from the node home and relative database paths from the first directory. Empty allows any path.
+	SqliteDirs []string `mapstructure:"sqlite-dirs"`
+}
+
+// DefaultSqlConfig returns the default sql configuration
+func DefaultSqlConfig() *SqlConfig {
+	return &SqlConfig{
+		AllowedDrivers:     DefaultAllowedDrivers,
+		AllowedConnections: []string{},
+		SqliteDirs:         []string{DefaultSqliteDir},
+	}
+}
+
+// GetSqlConfig reads the sql configuration from the app options, using defaults for unset values
+func GetSqlConfig(appOpts servertypes.AppOptions) SqlConfig {
+	cfg := *DefaultSqlConfig()
+	if v := appOpts.Get("sql.allowed-drivers"); v != nil {
+		cfg.AllowedDrivers = cast.ToStringSlice(v)
+	}
+	if v := appOpts.Get("sql.allowed-connections"); v != nil {
+		cfg.AllowedConnections = cast.ToStringSlice(v)
+	}
+	if v := appOpts.Get("sql.sqlite-dirs"); v != nil {
+		cfg.SqliteDirs = cast.ToStringSlice(v)
+	}
+	return cfg
+}
+
+// Validate returns an error if the sql configuration fields are invalid.
+func (c SqlConfig) Validate() error {
+	_, err := c.Policy("/")
+	return err
+}
+
+// Policy returns the connection policy, with sqlite directories resolved from the node home
+func (c SqlConfig) Policy(homeDir string) (*vmsql.ConnectionPolicy, error) {
+	dirs := make([]string, len(c.SqliteDirs))
+	for i, dir := range c.SqliteDirs {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(homeDir, dir)
+		}
+		dirs[i] = dir
+	}
+	return vmsql.NewConnectionPolicy(c.AllowedDrivers, c.AllowedConnections, dirs)
+}
@@ -0,0 +1,23 @@
+package config
+
+// DefaultConfigTemplate defines the configuration template for the sql configuration
+const DefaultConfigTemplate = `
+###############################################################################
+###                             SQL Configuration                           ###
+###############################################################################
+
+[sql]
+
+# AllowedDrivers are the database drivers contracts can connect with. Drivers must also
+# be compiled into the binary, with the sqlite, postgres or mysql build tags.
+allowed-drivers = [{{ range .Sql.AllowedDrivers }}{{ printf "%q, " . }}{{end}}]
+
+# AllowedConnections are regular expressions; connection strings of non-sqlite drivers
+# must fully match one of them. E.g. ["postgres://wasmx:[^@]*@localhost:5432/wasmx_[a-z0-9_]+"]
+allowed-connections = [{{ range .Sql.AllowedConnections }}{{ printf "%q, " . }}{{end}}]
+
+# SqliteDirs are the directories sqlite database files can be in. Relative paths are resolved
+# from the node home and relative database paths from the first directory. Empty allows any path.
+sqlite-dirs = [{{ range .Sql.SqliteDirs }}{{ printf "%q, " . }}{{end}}]
+
+`
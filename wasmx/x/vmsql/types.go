@@ -1,6 +1,7 @@
 package vmsql
 
 import (
+	"context"
 	"database/sql"
 	"encoding/base64"
 	"encoding/json"
@@ -36,12 +37,88 @@ type Context struct {
 	*vmtypes.Context
 }
 
+const (
+	// DefaultCursorFetchCount is the number of rows QueryNext returns when no count is requested
+	DefaultCursorFetchCount = 100
+	// MaxCursorFetchCount is the max number of rows QueryNext returns
+	MaxCursorFetchCount = 1000
+	// MaxBufferedCursorRows is the max number of rows of a cursor read when it is opened
+	MaxBufferedCursorRows = 10 * MaxCursorFetchCount
+	// MaxBufferedCursorBytes is the max size of the values of a cursor read when it is opened
+	MaxBufferedCursorBytes = 8 * 1024 * 1024
+)
+
 type SqlOpenConnection struct {
+	Driver          string
 	Connection      string
 	Db              *sql.DB
 	OpenSavepointTx *sql.Tx
 	SavePointMap    map[string]bool
 	Closed          chan struct{}
+	// Statements are prepared on the database and outlive transactions
+	Statements map[string]*sql.Stmt
+	// TxStatements are the Statements bound to OpenSavepointTx
+	TxStatements map[string]*sql.Stmt
+	// Cursors are open until closed, until OpenSavepointTx ends
+	// or until the sub call which opened them is rolled back
+	Cursors map[string]*SqlCursor
+	// SavepointDepth is the number of open savepoints
+	SavepointDepth int
+}
+
+// SqlCursor returns the rows of a query in pages. It does not stream: all rows are read when the cursor
+// is opened, up to MaxBufferedCursorRows and MaxBufferedCursorBytes: server drivers (postgres, mysql) cannot run other statements on the
+// transaction connection while rows are open, and sqlite aborts open reads when a savepoint is rolled back.
+type SqlCursor struct {
+	// Rows are the rows not returned yet
+	Rows [][]SqlValue
+	Done bool
+	// Depth is the savepoint depth the cursor was opened at
+	Depth int
+}
+
+// readCursorRows reads all rows, or returns an error if there are more than
+// MaxBufferedCursorRows or if their values are larger than MaxBufferedCursorBytes
+func readCursorRows(rows *sql.Rows, columns []*sql.ColumnType) ([][]SqlValue, error) {
+	defer rows.Close()
+	values := [][]SqlValue{}
+	size := 0
+	for {
+		row, done, err := ScanSqlValues(rows, columns, 1)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			return values, nil
+		}
+		if len(values) == MaxBufferedCursorRows {
+			return nil, fmt.Errorf("cursor has more than %d rows", MaxBufferedCursorRows)
+		}
+		for _, value := range row[0] {
+			size += value.size()
+		}
+		if size > MaxBufferedCursorBytes {
+			return nil, fmt.Errorf("cursor has more than %d bytes", MaxBufferedCursorBytes)
+		}
+		values = append(values, row[0])
+	}
+}
+
+// next returns up to count rows; done is true when there are no more rows
+func (cursor *SqlCursor) next(count int) ([][]SqlValue, bool) {
+	if count >= len(cursor.Rows) {
+		rows := cursor.Rows
+		cursor.close()
+		return rows, true
+	}
+	rows := cursor.Rows[:count]
+	cursor.Rows = cursor.Rows[count:]
+	return rows, false
+}
+
+func (cursor *SqlCursor) close() {
+	cursor.Done = true
+	cursor.Rows = [][]SqlValue{}
 }
 
 func (conn *SqlOpenConnection) hasSavePoint(savepoint string) bool {
@@ -49,9 +126,55 @@ func (conn *SqlOpenConnection) hasSavePoint(savepoint string) bool {
 	return ok && sv
 }
 
+// txStatement returns the prepared statement bound to the open transaction
+func (conn *SqlOpenConnection) txStatement(ctx context.Context, id string) (*sql.Stmt, error) {
+	if stmt, found := conn.TxStatements[id]; found {
+		return stmt, nil
+	}
+	stmt, found := conn.Statements[id]
+	if !found {
+		return nil, fmt.Errorf("prepared statement not found: %s", id)
+	}
+	txstmt := conn.OpenSavepointTx.StmtContext(ctx, stmt)
+	conn.TxStatements[id] = txstmt
+	return txstmt, nil
+}
+
+// closeTxResources closes the cursors and statements bound to the open transaction
+func (conn *SqlOpenConnection) closeTxResources() {
+	for _, cursor := range conn.Cursors {
+		cursor.close()
+	}
+	for _, stmt := range conn.TxStatements {
+		stmt.Close()
+	}
+	conn.Cursors = map[string]*SqlCursor{}
+	conn.TxStatements = map[string]*sql.Stmt{}
+	conn.SavepointDepth = 0
+}
+
+// endSavepoint is called when the innermost savepoint is released or rolled back;
+// cursors opened after a rolled back savepoint would read undone changes, so they are closed
+func (conn *SqlOpenConnection) endSavepoint(rollback bool) {
+	for id, cursor := range conn.Cursors {
+		if cursor.Depth < conn.SavepointDepth {
+			continue
+		}
+		if rollback {
+			cursor.close()
+			delete(conn.Cursors, id)
+		} else {
+			cursor.Depth = conn.SavepointDepth - 1
+		}
+	}
+	conn.SavepointDepth -= 1
+}
+
 type SqlContext struct {
 	mtx           sync.Mutex
 	DbConnections map[string]*SqlOpenConnection
+	// Policy restricts the databases contracts can connect to; nil allows any
+	Policy *ConnectionPolicy
 }
 
 func (p *SqlContext) GetConnection(id string) (*SqlOpenConnection, bool) {
@@ -61,14 +184,23 @@ func (p *SqlContext) GetConnection(id string) (*SqlOpenConnection, bool) {
 	return db, found
 }
 
-func (p *SqlContext) SetConnection(id string, connection string, db *sql.DB, closed chan struct{}) error {
+func (p *SqlContext) SetConnection(id string, driver string, connection string, db *sql.DB, closed chan struct{}) error {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 	_, found := p.DbConnections[id]
 	if found {
 		return fmt.Errorf("cannot overwrite sql connection: %s", id)
 	}
-	p.DbConnections[id] = &SqlOpenConnection{Db: db, Connection: connection, Closed: closed, SavePointMap: make(map[string]bool, 0)}
+	p.DbConnections[id] = &SqlOpenConnection{
+		Db:           db,
+		Driver:       driver,
+		Connection:   connection,
+		Closed:       closed,
+		SavePointMap: make(map[string]bool, 0),
+		Statements:   map[string]*sql.Stmt{},
+		TxStatements: map[string]*sql.Stmt{},
+		Cursors:      map[string]*SqlCursor{},
+	}
 	return nil
 }
 
@@ -86,6 +218,8 @@ type SqlConnectionRequest struct {
 
 type SqlConnectionResponse struct {
 	Error string `json:"error"`
+	// SchemaVersions are the versions of the tables with registered migrations, after connecting
+	SchemaVersions map[string]int64 `json:"schema_versions,omitempty"`
 }
 
 type SqlCloseRequest struct {
@@ -186,3 +320,156 @@ type SqlQueryRowRequest struct {
 type SqlQueryRowResponse struct {
 	Error string `json:"error"`
 }
+
+type SqlPrepareRequest struct {
+	Id          string `json:"id"`
+	StatementId string `json:"statement_id"`
+	Query       string `json:"query"`
+}
+
+type SqlPrepareResponse struct {
+	Error string `json:"error"`
+}
+
+type SqlExecutePreparedRequest struct {
+	Id          string `json:"id"`
+	StatementId string `json:"statement_id"`
+	Params      Params `json:"params"`
+}
+
+type SqlClosePreparedRequest struct {
+	Id          string `json:"id"`
+	StatementId string `json:"statement_id"`
+}
+
+type SqlClosePreparedResponse struct {
+	Error string `json:"error"`
+}
+
+// SqlQueryOpenRequest opens a cursor over the results of Query
+// or, if StatementId is set, of the prepared statement.
+// Cursors do not stream: the rows are read when the cursor is opened; queries returning
+// more than 10000 rows or 8MiB of values fail and should be paged with LIMIT instead.
+type SqlQueryOpenRequest struct {
+	Id          string `json:"id"`
+	CursorId    string `json:"cursor_id"`
+	Query       string `json:"query"`
+	StatementId string `json:"statement_id"`
+	Params      Params `json:"params"`
+}
+
+type SqlColumn struct {
+	Name         string `json:"name"`
+	DatabaseType string `json:"database_type"`
+}
+
+type SqlQueryOpenResponse struct {
+	Error   string      `json:"error"`
+	Columns []SqlColumn `json:"columns"`
+	// Buffered is true when all rows were read into host memory when the cursor was opened,
+	// instead of being streamed from the database by QueryNext
+	Buffered bool `json:"buffered"`
+}
+
+type SqlQueryNextRequest struct {
+	Id       string `json:"id"`
+	CursorId string `json:"cursor_id"`
+	// Count is the max number of rows returned, DefaultCursorFetchCount if 0
+	Count int `json:"count"`
+}
+
+type SqlQueryNextResponse struct {
+	Error string       `json:"error"`
+	Rows  [][]SqlValue `json:"rows"`
+	// Done is true when there are no more rows
+	Done bool `json:"done"`
+}
+
+type SqlQueryCloseRequest struct {
+	Id       string `json:"id"`
+	CursorId string `json:"cursor_id"`
+}
+
+type SqlQueryCloseResponse struct {
+	Error string `json:"error"`
+}
+
+const (
+	SqlValueNull  = "null"
+	SqlValueInt   = "int64"
+	SqlValueFloat = "float"
+	SqlValueText  = "text"
+	SqlValueBlob  = "blob"
+	SqlValueTime  = "time"
+)
+
+// SqlValue is a typed column value; only the field of its type is set
+type SqlValue struct {
+	Type  string  `json:"type"`
+	Int   int64   `json:"int,omitempty"`
+	Float float64 `json:"float,omitempty"`
+	Text  string  `json:"text,omitempty"`
+	Blob  []byte  `json:"blob,omitempty"`
+	// Time is in UTC, RFC3339 with nanoseconds
+	Time string `json:"time,omitempty"`
+}
+
+// size is the number of bytes a value buffers
+func (v SqlValue) size() int {
+	return 8 + len(v.Text) + len(v.Blob) + len(v.Time)
+}
+
+// SqlMigration is a versioned schema change; Down reverts Up
+type SqlMigration struct {
+	Version int64               `json:"version"`
+	Up      []SqlExecuteCommand `json:"up"`
+	Down    []SqlExecuteCommand `json:"down"`
+}
+
+// SqlMigrateRequest brings the schema of Table to TargetVersion, reverting
+// applied migrations above it. If TargetVersion is not set, the pending
+// migrations are applied and nothing is reverted.
+type SqlMigrateRequest struct {
+	Id            string         `json:"id"`
+	Table         string         `json:"table"`
+	Migrations    []SqlMigration `json:"migrations"`
+	TargetVersion *int64         `json:"target_version,omitempty"`
+}
+
+type SqlMigrateResponse struct {
+	Error string `json:"error"`
+	// Version is the schema version after migrating
+	Version  int64   `json:"version"`
+	Applied  []int64 `json:"applied"`
+	Reverted []int64 `json:"reverted"`
+}
+
+// SqlRegisterMigrationsRequest adds migrations to the on-chain registry of Table.
+// They are applied when the contract connects with the Id.
+type SqlRegisterMigrationsRequest struct {
+	Id         string         `json:"id"`
+	Table      string         `json:"table"`
+	Migrations []SqlMigration `json:"migrations"`
+}
+
+type SqlRegisterMigrationsResponse struct {
+	Error string `json:"error"`
+	// Version is the last registered version of the table
+	Version int64 `json:"version"`
+}
+
+// SqlTableVersion is the last migration applied to a table
+type SqlTableVersion struct {
+	Table   string `json:"table"`
+	Version int64  `json:"version"`
+}
+
+type SqlSchemaVersionRequest struct {
+	Id    string `json:"id"`
+	Table string `json:"table"`
+}
+
+type SqlSchemaVersionResponse struct {
+	Error   string `json:"error"`
+	Version int64  `json:"version"`
+}
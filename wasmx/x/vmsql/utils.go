@@ -3,6 +3,11 @@ package vmsql
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 )
 
 func RowsToJSON(rows *sql.Rows) ([]byte, error) {
@@ -40,3 +45,82 @@ func RowsToJSON(rows *sql.Rows) ([]byte, error) {
 
 	return json.Marshal(results)
 }
+
+// ToSqlValue converts a scanned column value to a typed value.
+// Drivers which return numbers and text as bytes are disambiguated by the column type.
+func ToSqlValue(value interface{}, column *sql.ColumnType) SqlValue {
+	switch v := value.(type) {
+	case nil:
+		return SqlValue{Type: SqlValueNull}
+	case int64:
+		return SqlValue{Type: SqlValueInt, Int: v}
+	case int32:
+		return SqlValue{Type: SqlValueInt, Int: int64(v)}
+	case int:
+		return SqlValue{Type: SqlValueInt, Int: int64(v)}
+	case uint64:
+		if v > math.MaxInt64 {
+			// does not fit an int64 value
+			return SqlValue{Type: SqlValueText, Text: strconv.FormatUint(v, 10)}
+		}
+		return SqlValue{Type: SqlValueInt, Int: int64(v)}
+	case bool:
+		if v {
+			return SqlValue{Type: SqlValueInt, Int: 1}
+		}
+		return SqlValue{Type: SqlValueInt, Int: 0}
+	case float64:
+		return SqlValue{Type: SqlValueFloat, Float: v}
+	case float32:
+		return SqlValue{Type: SqlValueFloat, Float: float64(v)}
+	case string:
+		return SqlValue{Type: SqlValueText, Text: v}
+	case time.Time:
+		return SqlValue{Type: SqlValueTime, Time: v.UTC().Format(time.RFC3339Nano)}
+	case []byte:
+		typeName := ""
+		if column != nil {
+			typeName = strings.ToUpper(column.DatabaseTypeName())
+		}
+		switch {
+		case strings.Contains(typeName, "INT"):
+			if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+				return SqlValue{Type: SqlValueInt, Int: i}
+			}
+		case strings.Contains(typeName, "FLOAT") || strings.Contains(typeName, "DOUBLE") || strings.Contains(typeName, "REAL"):
+			if f, err := strconv.ParseFloat(string(v), 64); err == nil {
+				return SqlValue{Type: SqlValueFloat, Float: f}
+			}
+		case strings.Contains(typeName, "CHAR") || strings.Contains(typeName, "TEXT") || strings.Contains(typeName, "CLOB") || strings.Contains(typeName, "JSON") || strings.Contains(typeName, "DECIMAL") || strings.Contains(typeName, "NUMERIC"):
+			return SqlValue{Type: SqlValueText, Text: string(v)}
+		}
+		return SqlValue{Type: SqlValueBlob, Blob: v}
+	default:
+		return SqlValue{Type: SqlValueText, Text: fmt.Sprint(v)}
+	}
+}
+
+// ScanSqlValues reads up to count rows as typed values;
+// done is true when there are no more rows
+func ScanSqlValues(rows *sql.Rows, columns []*sql.ColumnType, count int) ([][]SqlValue, bool, error) {
+	results := [][]SqlValue{}
+	for len(results) < count {
+		if !rows.Next() {
+			return results, true, rows.Err()
+		}
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, false, err
+		}
+		row := make([]SqlValue, len(columns))
+		for i, value := range values {
+			row[i] = ToSqlValue(value, columns[i])
+		}
+		results = append(results, row)
+	}
+	return results, false, nil
+}
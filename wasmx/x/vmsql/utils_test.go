@@ -0,0 +1,74 @@
+package vmsql_test
+
+import (
+	"database/sql"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/loredanacirstea/wasmx/x/vmsql"
+)
+
+func TestScanSqlValues(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY, price REAL, name TEXT, data BLOB, created DATETIME)`)
+	require.NoError(t, err)
+	created := time.Date(2024, 5, 6, 7, 8, 9, 10, time.UTC)
+	_, err = db.Exec(`INSERT INTO items VALUES (?, ?, ?, ?, ?), (?, ?, ?, ?, ?), (?, ?, ?, ?, ?)`,
+		1, 1.5, "first", []byte{1, 2}, created,
+		2, nil, "second", nil, nil,
+		3, 3.25, nil, []byte{3}, nil,
+	)
+	require.NoError(t, err)
+
+	rows, err := db.Query(`SELECT id, price, name, data, created FROM items ORDER BY id`)
+	require.NoError(t, err)
+	defer rows.Close()
+	columns, err := rows.ColumnTypes()
+	require.NoError(t, err)
+
+	values, done, err := vmsql.ScanSqlValues(rows, columns, 2)
+	require.NoError(t, err)
+	require.False(t, done)
+	require.Equal(t, [][]vmsql.SqlValue{
+		{
+			{Type: vmsql.SqlValueInt, Int: 1},
+			{Type: vmsql.SqlValueFloat, Float: 1.5},
+			{Type: vmsql.SqlValueText, Text: "first"},
+			{Type: vmsql.SqlValueBlob, Blob: []byte{1, 2}},
+			{Type: vmsql.SqlValueTime, Time: "2024-05-06T07:08:09.00000001Z"},
+		},
+		{
+			{Type: vmsql.SqlValueInt, Int: 2},
+			{Type: vmsql.SqlValueNull},
+			{Type: vmsql.SqlValueText, Text: "second"},
+			{Type: vmsql.SqlValueNull},
+			{Type: vmsql.SqlValueNull},
+		},
+	}, values)
+
+	values, done, err = vmsql.ScanSqlValues(rows, columns, 2)
+	require.NoError(t, err)
+	require.True(t, done)
+	require.Len(t, values, 1)
+	require.Equal(t, vmsql.SqlValue{Type: vmsql.SqlValueFloat, Float: 3.25}, values[0][1])
+}
+
+func TestToSqlValueBytes(t *testing.T) {
+	// drivers like mysql return numbers as bytes; without column types they stay blobs
+	require.Equal(t, vmsql.SqlValue{Type: vmsql.SqlValueBlob, Blob: []byte("12")}, vmsql.ToSqlValue([]byte("12"), nil))
+	require.Equal(t, vmsql.SqlValue{Type: vmsql.SqlValueInt, Int: 1}, vmsql.ToSqlValue(true, nil))
+	require.Equal(t, vmsql.SqlValue{Type: vmsql.SqlValueText, Text: "abc"}, vmsql.ToSqlValue("abc", nil))
+}
+
+func TestToSqlValueUint64(t *testing.T) {
+	require.Equal(t, vmsql.SqlValue{Type: vmsql.SqlValueInt, Int: math.MaxInt64}, vmsql.ToSqlValue(uint64(math.MaxInt64), nil))
+	// values above the int64 range are encoded as text
+	require.Equal(t, vmsql.SqlValue{Type: vmsql.SqlValueText, Text: "18446744073709551615"}, vmsql.ToSqlValue(uint64(math.MaxUint64), nil))
+}
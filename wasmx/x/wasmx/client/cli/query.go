@@ -47,6 +47,7 @@ func GetQueryCmd(wasmVmMeta memc.IWasmVmMeta, queryRoute string, ac address.Code
 		GetCmdGetContractState(ac),
 		GetCmdLibVersion(wasmVmMeta),
 		GetCmdQueryParams(ac),
+		GetCmdQueryGasSchedule(ac),
 		GetCmdBuildAddress(ac),
 	)
 	return queryCmd
@@ -620,3 +621,35 @@ func GetCmdQueryParams(ac address.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// GetCmdQueryGasSchedule shows the gas costs of all host functions
+func GetCmdQueryGasSchedule(ac address.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gas-schedule",
+		Short: "Query the gas costs of all host functions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			mcctx, err := multichain.MultiChainCtxByChainId(clientCtx, cmd.Flags(), []signing.CustomGetSigner{})
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(mcctx.ClientCtx)
+
+			res, err := queryClient.GasSchedule(cmd.Context(), &types.QueryGasScheduleRequest{})
+			if err != nil {
+				return err
+			}
+			return mcctx.ClientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	multichain.AddMultiChainFlagsToCmd(cmd)
+
+	return cmd
+}
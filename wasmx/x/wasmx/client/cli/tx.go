@@ -67,6 +67,9 @@ func GetTxCmd(wasmVmMeta memc.IWasmVmMeta, ac address.Codec, appCreator multicha
 		InstantiateContractCmd(wasmVmMeta, ac, appCreator),
 		InstantiateContract2Cmd(wasmVmMeta, ac, appCreator),
 		ExecuteContractCmd(wasmVmMeta, ac, appCreator),
+		MigrateContractCmd(wasmVmMeta, ac, appCreator),
+		UpdateContractAdminCmd(wasmVmMeta, ac, appCreator),
+		ClearContractAdminCmd(wasmVmMeta, ac, appCreator),
 		NewProposalExecuteContractCmd(wasmVmMeta, ac, appCreator),
 	)
 	return txCmd
@@ -217,6 +220,8 @@ $ %s tx wasmx instantiate 1 '{"foo":"bar"}' --admin="$(%s keys show mykey -a)" \
 
 	cmd.Flags().String(flagAmount, "", "Coins to send to the contract during instantiation")
 	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
+	cmd.Flags().String(flagAdmin, "", "Address or key name of an admin")
+	cmd.Flags().Bool(flagNoAdmin, false, "Instantiate an immutable contract, without an admin")
 	multichain.AddMultiChainFlagsToCmd(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
@@ -273,6 +278,7 @@ $ %s tx wasmx instantiate2 1 '{"foo":"bar"}' $(echo -n "testing" | xxd -ps) --ad
 				Funds:  data.Funds,
 				Salt:   salt,
 				FixMsg: fixMsg,
+				Admin:  data.Admin,
 			}
 			if err := msg.ValidateBasic(); err != nil {
 				return err
@@ -284,6 +290,9 @@ $ %s tx wasmx instantiate2 1 '{"foo":"bar"}' $(echo -n "testing" | xxd -ps) --ad
 
 	cmd.Flags().String(flagAmount, "", "Coins to send to the contract during instantiation")
 	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
+	cmd.Flags().String(flagAdmin, "", "Address or key name of an admin")
+	cmd.Flags().Bool(flagNoAdmin, false, "Instantiate an immutable contract, without an admin")
+	cmd.Flags().Bool(flagFixMsg, false, "An optional flag to include the json_encoded_init_args for the predictable address generation mode")
 	decoder.RegisterFlags(cmd.PersistentFlags(), "salt")
 	multichain.AddMultiChainFlagsToCmd(cmd)
 	flags.AddTxFlagsToCmd(cmd)
@@ -322,6 +331,24 @@ func parseInstantiateArgs(addrCodec address.Codec, rawCodeID, initMsg string, kr
 		return nil, err
 	}
 
+	adminStr, err := flags.GetString(flagAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("admin: %s", err)
+	}
+	noAdmin, err := flags.GetBool(flagNoAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("no-admin: %s", err)
+	}
+	if adminStr != "" && noAdmin {
+		return nil, fmt.Errorf("you set an admin and passed --no-admin, those cannot both be true")
+	}
+	if adminStr != "" {
+		adminStr, err = resolveAddress(addrCodec, kr, adminStr)
+		if err != nil {
+			return nil, fmt.Errorf("admin: %s", err)
+		}
+	}
+
 	// build and sign the transaction, then broadcast to Tendermint
 	msg := types.MsgInstantiateContract{
 		Sender: senderstr,
@@ -329,6 +356,7 @@ func parseInstantiateArgs(addrCodec address.Codec, rawCodeID, initMsg string, kr
 		Label:  label,
 		Funds:  amount,
 		Msg:    msgbz,
+		Admin:  adminStr,
 	}
 	return &msg, nil
 }
@@ -411,3 +439,172 @@ func wasmxMsgWrap(jsonmsg string) ([]byte, error) {
 	}
 	return msgbz, nil
 }
+
+// MigrateContractCmd will migrate a contract to a new code version
+func MigrateContractCmd(wasmVmMeta memc.IWasmVmMeta, _ address.Codec, appCreator multichain.NewAppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "migrate [contract_addr_bech32] [new_code_id_int64] [json_encoded_migration_args]",
+		Short:   "Migrate a wasm contract to a new code version",
+		Aliases: []string{"update", "mig", "m"},
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			mcctx, err := multichain.MultiChainCtxByChainIdWithAppMsgs(wasmVmMeta, clientCtx, cmd.Flags(), []signing.CustomGetSigner{}, appCreator)
+			if err != nil {
+				return err
+			}
+			txf, err := tx.NewFactoryCLI(mcctx.ClientCtx, cmd.Flags())
+			if err != nil {
+				return err
+			}
+			contractAddr_, err := mcctx.CustomAddrCodec.StringToAddressPrefixedUnsafe(args[0])
+			if err != nil {
+				return err
+			}
+			contractAddr := mcctx.CustomAddrCodec.BytesToAccAddressPrefixed(contractAddr_.Bytes())
+			codeID, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("code id: %s", err)
+			}
+			msgbz, err := wasmxMsgWrap(args[2])
+			if err != nil {
+				return err
+			}
+			senderstr, err := mcctx.CustomAddrCodec.BytesToString(mcctx.ClientCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+			msg := &types.MsgMigrateContract{
+				Sender:   senderstr,
+				Contract: contractAddr.String(),
+				CodeId:   codeID,
+				Msg:      msgbz,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxWithFactory(mcctx.ClientCtx, txf, msg)
+		},
+		SilenceUsage: true,
+	}
+	multichain.AddMultiChainFlagsToCmd(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// UpdateContractAdminCmd sets a new admin for a contract
+func UpdateContractAdminCmd(wasmVmMeta memc.IWasmVmMeta, _ address.Codec, appCreator multichain.NewAppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "set-contract-admin [contract_addr_bech32] [new_admin_addr_bech32]",
+		Short:   "Set new admin for a contract",
+		Aliases: []string{"new-admin", "admin", "set-adm", "sa"},
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			mcctx, err := multichain.MultiChainCtxByChainIdWithAppMsgs(wasmVmMeta, clientCtx, cmd.Flags(), []signing.CustomGetSigner{}, appCreator)
+			if err != nil {
+				return err
+			}
+			txf, err := tx.NewFactoryCLI(mcctx.ClientCtx, cmd.Flags())
+			if err != nil {
+				return err
+			}
+			contractAddr_, err := mcctx.CustomAddrCodec.StringToAddressPrefixedUnsafe(args[0])
+			if err != nil {
+				return err
+			}
+			contractAddr := mcctx.CustomAddrCodec.BytesToAccAddressPrefixed(contractAddr_.Bytes())
+			newAdmin, err := resolveAddress(mcctx.CustomAddrCodec, mcctx.ClientCtx.Keyring, args[1])
+			if err != nil {
+				return fmt.Errorf("new admin: %s", err)
+			}
+			senderstr, err := mcctx.CustomAddrCodec.BytesToString(mcctx.ClientCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+			msg := &types.MsgUpdateAdmin{
+				Sender:   senderstr,
+				Contract: contractAddr.String(),
+				NewAdmin: newAdmin,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxWithFactory(mcctx.ClientCtx, txf, msg)
+		},
+		SilenceUsage: true,
+	}
+	multichain.AddMultiChainFlagsToCmd(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// ClearContractAdminCmd clears an admin for a contract, making it immutable
+func ClearContractAdminCmd(wasmVmMeta memc.IWasmVmMeta, _ address.Codec, appCreator multichain.NewAppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "clear-contract-admin [contract_addr_bech32]",
+		Short:   "Clears admin for a contract to prevent further migrations",
+		Aliases: []string{"clear-admin", "clr-adm"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			mcctx, err := multichain.MultiChainCtxByChainIdWithAppMsgs(wasmVmMeta, clientCtx, cmd.Flags(), []signing.CustomGetSigner{}, appCreator)
+			if err != nil {
+				return err
+			}
+			txf, err := tx.NewFactoryCLI(mcctx.ClientCtx, cmd.Flags())
+			if err != nil {
+				return err
+			}
+			contractAddr_, err := mcctx.CustomAddrCodec.StringToAddressPrefixedUnsafe(args[0])
+			if err != nil {
+				return err
+			}
+			contractAddr := mcctx.CustomAddrCodec.BytesToAccAddressPrefixed(contractAddr_.Bytes())
+			senderstr, err := mcctx.CustomAddrCodec.BytesToString(mcctx.ClientCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+			msg := &types.MsgClearAdmin{
+				Sender:   senderstr,
+				Contract: contractAddr.String(),
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxWithFactory(mcctx.ClientCtx, txf, msg)
+		},
+		SilenceUsage: true,
+	}
+	multichain.AddMultiChainFlagsToCmd(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// resolveAddress returns the bech32 address for an address or a key name from the keyring
+func resolveAddress(addrCodec address.Codec, kr keyring.Keyring, addressOrKeyName string) (string, error) {
+	if _, err := addrCodec.StringToBytes(addressOrKeyName); err == nil {
+		return addressOrKeyName, nil
+	}
+	if kr == nil {
+		return "", fmt.Errorf("invalid address %s", addressOrKeyName)
+	}
+	record, err := kr.Key(addressOrKeyName)
+	if err != nil {
+		return "", fmt.Errorf("invalid address or key name %s: %s", addressOrKeyName, err)
+	}
+	addr, err := record.GetAddress()
+	if err != nil {
+		return "", err
+	}
+	return addrCodec.BytesToString(addr)
+}
@@ -242,8 +242,8 @@ func EncodeWasmMsg(senderBech32 string, msg *types.WasmMsg) ([]sdk.Msg, error) {
 			CodeId: msg.Instantiate.CodeID,
 			Label:  msg.Instantiate.Label,
 			Msg:    msg.Instantiate.Msg,
-			// Admin:  msg.Instantiate.Admin,
-			Funds: coins,
+			Admin:  msg.Instantiate.Admin,
+			Funds:  coins,
 		}
 		return []sdk.Msg{&sdkMsg}, nil
 	case msg.Instantiate2 != nil:
@@ -254,7 +254,7 @@ func EncodeWasmMsg(senderBech32 string, msg *types.WasmMsg) ([]sdk.Msg, error) {
 
 		sdkMsg := wasmxtypes.MsgInstantiateContract2{
 			Sender: senderBech32,
-			// Admin:  msg.Instantiate2.Admin,
+			Admin:  msg.Instantiate2.Admin,
 			CodeId: msg.Instantiate2.CodeID,
 			Label:  msg.Instantiate2.Label,
 			Msg:    msg.Instantiate2.Msg,
@@ -264,27 +264,27 @@ func EncodeWasmMsg(senderBech32 string, msg *types.WasmMsg) ([]sdk.Msg, error) {
 			FixMsg: false,
 		}
 		return []sdk.Msg{&sdkMsg}, nil
-	// case msg.Migrate != nil:
-	// 	sdkMsg := types.MsgMigrateContract{
-	// 		Sender:   senderBech32,
-	// 		Contract: msg.Migrate.ContractAddr,
-	// 		CodeID:   msg.Migrate.NewCodeID,
-	// 		Msg:      msg.Migrate.Msg,
-	// 	}
-	// 	return []sdk.Msg{&sdkMsg}, nil
-	// case msg.ClearAdmin != nil:
-	// 	sdkMsg := types.MsgClearAdmin{
-	// 		Sender:   senderBech32,
-	// 		Contract: msg.ClearAdmin.ContractAddr,
-	// 	}
-	// 	return []sdk.Msg{&sdkMsg}, nil
-	// case msg.UpdateAdmin != nil:
-	// 	sdkMsg := types.MsgUpdateAdmin{
-	// 		Sender:   senderBech32,
-	// 		Contract: msg.UpdateAdmin.ContractAddr,
-	// 		NewAdmin: msg.UpdateAdmin.Admin,
-	// 	}
-	// 	return []sdk.Msg{&sdkMsg}, nil
+	case msg.Migrate != nil:
+		sdkMsg := wasmxtypes.MsgMigrateContract{
+			Sender:   senderBech32,
+			Contract: msg.Migrate.ContractAddr,
+			CodeId:   msg.Migrate.NewCodeID,
+			Msg:      msg.Migrate.Msg,
+		}
+		return []sdk.Msg{&sdkMsg}, nil
+	case msg.ClearAdmin != nil:
+		sdkMsg := wasmxtypes.MsgClearAdmin{
+			Sender:   senderBech32,
+			Contract: msg.ClearAdmin.ContractAddr,
+		}
+		return []sdk.Msg{&sdkMsg}, nil
+	case msg.UpdateAdmin != nil:
+		sdkMsg := wasmxtypes.MsgUpdateAdmin{
+			Sender:   senderBech32,
+			Contract: msg.UpdateAdmin.ContractAddr,
+			NewAdmin: msg.UpdateAdmin.Admin,
+		}
+		return []sdk.Msg{&sdkMsg}, nil
 	default:
 		return nil, errorsmod.Wrap(wasmxtypes.ErrUnknownMsg, "unknown variant of Wasm")
 	}
@@ -577,8 +577,7 @@ func WasmQuerier(k wasmQueryKeeper) func(ctx sdk.Context, request *cw8types.Wasm
 			res := cw8types.ContractInfoResponse{
 				CodeID:  info.CodeId,
 				Creator: info.Creator,
-				// TODO
-				// Admin:   info.Admin,
+				Admin:   info.Admin,
 				Pinned:  k.IsPinnedCode(ctx, info.CodeId),
 				IBCPort: info.IbcPortId,
 			}
@@ -4,3 +4,4 @@ package types
 var ERROR_FLAG_EXECUTION = "cosmwasm_8: execute"
 var ERROR_FLAG_QUERY = "cosmwasm_8: query"
 var ERROR_FLAG_REPLY = "cosmwasm_8: reply"
+var ERROR_FLAG_MIGRATE = "cosmwasm_8: migrate"
@@ -14,6 +14,11 @@ func InitGenesis(ctx sdk.Context, k keeper.Keeper, genState types.GenesisState)
 	for _, contract := range genState.SystemContracts {
 		k.SetSystemContract(ctx, contract)
 	}
+	for _, contractAdmin := range genState.ContractAdmins {
+		if err := k.ImportContractAdmin(ctx, contractAdmin); err != nil {
+			panic(err)
+		}
+	}
 	// TODO
 	// genState.Contracts
 	// genState.Codes
@@ -30,5 +35,12 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
 		genState.SystemContracts = append(genState.SystemContracts, contract)
 		return false
 	})
+	k.IterateContractAdmins(ctx, func(contract sdk.AccAddress, admin string) bool {
+		genState.ContractAdmins = append(genState.ContractAdmins, types.ContractAdmin{
+			ContractAddress: k.AccBech32Codec().BytesToAccAddressPrefixed(contract).String(),
+			Admin:           admin,
+		})
+		return false
+	})
 	return genState
 }
@@ -3,6 +3,7 @@ package keeper
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
@@ -18,6 +19,7 @@ import (
 	cw8types "github.com/loredanacirstea/wasmx/x/wasmx/cw8/types"
 	"github.com/loredanacirstea/wasmx/x/wasmx/ioutils"
 	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+	"github.com/loredanacirstea/wasmx/x/wasmx/vm/determinism"
 )
 
 func (k *Keeper) Create(ctx sdk.Context, creator mcodec.AccAddressPrefixed, wasmByteCode []byte, deps []string, metadata types.CodeMetadata, pinned bool, meteringOff bool, source []byte) (uint64, []byte, error) {
@@ -54,12 +56,24 @@ func (k *Keeper) UnpinCode(ctx sdk.Context, codeId uint64) error {
 	return k.unpinCode(ctx, codeId)
 }
 
-func (k *Keeper) Instantiate(ctx sdk.Context, codeId uint64, creator mcodec.AccAddressPrefixed, msg types.RawContractMessage, funds sdk.Coins, label string) (mcodec.AccAddressPrefixed, []byte, error) {
-	return k.instantiate(ctx, codeId, creator, msg, funds, label)
+func (k *Keeper) Instantiate(ctx sdk.Context, codeId uint64, creator mcodec.AccAddressPrefixed, admin mcodec.AccAddressPrefixed, msg types.RawContractMessage, funds sdk.Coins, label string) (mcodec.AccAddressPrefixed, []byte, error) {
+	return k.instantiate(ctx, codeId, creator, admin, msg, funds, label)
 }
 
-func (k *Keeper) Instantiate2(ctx sdk.Context, codeId uint64, senderAddr mcodec.AccAddressPrefixed, msg types.RawContractMessage, funds sdk.Coins, salt []byte, fixMsg bool, label string) (mcodec.AccAddressPrefixed, []byte, error) {
-	return k.instantiate2(ctx, codeId, senderAddr, msg, funds, salt, fixMsg, label)
+func (k *Keeper) Instantiate2(ctx sdk.Context, codeId uint64, senderAddr mcodec.AccAddressPrefixed, admin mcodec.AccAddressPrefixed, msg types.RawContractMessage, funds sdk.Coins, salt []byte, fixMsg bool, label string) (mcodec.AccAddressPrefixed, []byte, error) {
+	return k.instantiate2(ctx, codeId, senderAddr, admin, msg, funds, salt, fixMsg, label)
+}
+
+func (k *Keeper) Migrate(ctx sdk.Context, contractAddr mcodec.AccAddressPrefixed, caller mcodec.AccAddressPrefixed, newCodeId uint64, msg types.RawContractMessage) ([]byte, error) {
+	return k.migrate(ctx, contractAddr, caller, newCodeId, msg)
+}
+
+func (k *Keeper) UpdateContractAdmin(ctx sdk.Context, contractAddr mcodec.AccAddressPrefixed, caller mcodec.AccAddressPrefixed, newAdmin mcodec.AccAddressPrefixed) error {
+	return k.setContractAdminByCaller(ctx, contractAddr, caller, newAdmin)
+}
+
+func (k *Keeper) ClearContractAdmin(ctx sdk.Context, contractAddr mcodec.AccAddressPrefixed, caller mcodec.AccAddressPrefixed) error {
+	return k.setContractAdminByCaller(ctx, contractAddr, caller, mcodec.AccAddressPrefixed{})
 }
 
 func (k *Keeper) Execute(ctx sdk.Context, contractAddr mcodec.AccAddressPrefixed, senderAddr mcodec.AccAddressPrefixed, msg types.RawContractMessage, funds sdk.Coins, dependencies []string, inBackground bool) ([]byte, error) {
@@ -174,21 +188,29 @@ func (k *Keeper) GetContractDependencyInnerWithoutRoles(ctx sdk.Context, addr mc
 		aotFilePath = k.wasmvm.BuildPathPinned(codeInfo.CodeHash)
 	}
 	filepath := k.wasmvm.GetCodeFilePath(codeInfo)
+	maxMemoryPages := codeInfo.MaxMemoryPages
+	if maxMemoryPages == 0 {
+		maxMemoryPages = k.GetMaxMemoryPages(ctx)
+	}
 
 	cdep := types.ContractDependency{
-		Address:       addr,
-		Label:         contractInfo.Label,
-		StoreKey:      prefixStoreKey,
-		CodeFilePath:  filepath,
-		AotFilePath:   aotFilePath,
-		SystemDeps:    sdeps,
-		Bytecode:      codeInfo.InterpretedBytecodeRuntime,
-		CodeHash:      codeInfo.CodeHash,
-		CodeId:        contractInfo.CodeId,
-		SystemDepsRaw: codeInfo.Deps,
-		StorageType:   contractInfo.StorageType,
-		Pinned:        codeInfo.Pinned,
-		MeteringOff:   codeInfo.MeteringOff,
+		Address:        addr,
+		Label:          contractInfo.Label,
+		StoreKey:       prefixStoreKey,
+		CodeFilePath:   filepath,
+		AotFilePath:    aotFilePath,
+		SystemDeps:     sdeps,
+		Bytecode:       codeInfo.InterpretedBytecodeRuntime,
+		CodeHash:       codeInfo.CodeHash,
+		CodeId:         contractInfo.CodeId,
+		SystemDepsRaw:  codeInfo.Deps,
+		StorageType:    contractInfo.StorageType,
+		Pinned:         codeInfo.Pinned,
+		MeteringOff:    codeInfo.MeteringOff,
+		MaxMemoryPages: maxMemoryPages,
+		MaxCallDepth:   k.GetMaxCallDepth(ctx),
+		GasSchedule:    k.getVmGasSchedule(ctx),
+		WasiFsQuota:    k.GetWasiFsQuota(ctx),
 	}
 	return cdep, nil
 }
@@ -204,7 +226,19 @@ func (k *Keeper) create(ctx sdk.Context, creator *mcodec.AccAddressPrefixed, was
 			return 0, checksum, sdkerr.Wrap(types.ErrCreateFailed, err.Error())
 		}
 	}
-	codeInfo, err := k.createCodeInfo(ctx, *creator, wasmCode, deps, metadata, pinned, meteringOff, source)
+	creatorRole := ""
+	// only contracts can have roles
+	if k.HasContractInfo(ctx, *creator) {
+		creatorRole = k.GetRoleByContractAddress(ctx, *creator)
+	}
+	role := ""
+	if ioutils.IsWasm(wasmCode) && k.GetWasmValidation(ctx).HasRoleFeatures() {
+		role = creatorRole
+	}
+	// protected host APIs are only provided at runtime to contracts with a role,
+	// so only the authority and contracts with a role can store code requiring them
+	allowProtected := creatorRole != "" || creator.String() == k.authority
+	codeInfo, err := k.createCodeInfo(ctx, *creator, wasmCode, deps, metadata, pinned, meteringOff, source, role, allowProtected)
 	if err != nil {
 		return 0, checksum, err
 	}
@@ -230,12 +264,14 @@ func (k *Keeper) create(ctx sdk.Context, creator *mcodec.AccAddressPrefixed, was
 	return codeID, checksum, nil
 }
 
-func (k *Keeper) createCodeInfo(ctx sdk.Context, creator mcodec.AccAddressPrefixed, wasmCode []byte, deps []string, metadata types.CodeMetadata, pinned bool, meteringOff bool, source []byte) (codeInfo types.CodeInfo, err error) {
+// createCodeInfo validates and stores the code. role is the role of the creator contract,
+// which can allow extra wasm features. allowProtected allows the code to depend on protected host APIs.
+func (k *Keeper) createCodeInfo(ctx sdk.Context, creator mcodec.AccAddressPrefixed, wasmCode []byte, deps []string, metadata types.CodeMetadata, pinned bool, meteringOff bool, source []byte, role string, allowProtected bool) (codeInfo types.CodeInfo, err error) {
 	var checksum []byte
 	var reportDeps = make([]string, 0)
 
 	if ioutils.IsWasm(wasmCode) {
-		checksum, reportDeps, err = k.createWasm(ctx, wasmCode)
+		checksum, reportDeps, err = k.createWasm(ctx, wasmCode, deps, role, meteringOff)
 	} else {
 		if len(deps) > 0 && types.HasUtf8Dep(deps) {
 			checksum, reportDeps, err = k.createSourceInterpreted(ctx, wasmCode, deps)
@@ -247,6 +283,13 @@ func (k *Keeper) createCodeInfo(ctx sdk.Context, creator mcodec.AccAddressPrefix
 		return types.CodeInfo{}, err
 	}
 	reportDeps = uniqueStrings(append(reportDeps, deps...))
+	if !allowProtected {
+		for _, dep := range reportDeps {
+			if types.PROTECTED_HOST_APIS[dep] {
+				return types.CodeInfo{}, sdkerr.Wrapf(sdkerrors.ErrUnauthorized, "protected host API %s can only be used by the authority or contracts with a role", dep)
+			}
+		}
+	}
 
 	if len(checksum) == 0 {
 		return types.CodeInfo{}, sdkerr.Wrap(types.ErrCreateFailed, "this is not wasm code, use deploy")
@@ -267,9 +310,22 @@ func (k *Keeper) createCodeInfo(ctx sdk.Context, creator mcodec.AccAddressPrefix
 	return codeInfo, nil
 }
 
-func (k *Keeper) createWasm(ctx sdk.Context, wasmCode []byte) (checksum []byte, reportDeps []string, err error) {
+// createWasm stores the validated code, with injected gas metering unless metering is off.
+// The checksum is the sha256 hash of the stored code, which is not the hash of the uploaded code.
+func (k *Keeper) createWasm(ctx sdk.Context, wasmCode []byte, deps []string, role string, meteringOff bool) (checksum []byte, reportDeps []string, err error) {
 	ctx.GasMeter().ConsumeGas(k.gasRegister.CompileCosts(len(wasmCode)), "Compiling wasm bytecode")
-	report, err := k.wasmvm.AnalyzeWasm(ctx, wasmCode)
+	wasmCode, err = k.ValidateWasm(ctx, wasmCode, role)
+	if err != nil {
+		return checksum, nil, err
+	}
+	// all runtimes charge the same gas for the instructions of metered code
+	if !meteringOff {
+		wasmCode, err = determinism.InjectGasMetering(wasmCode)
+		if err != nil {
+			return checksum, nil, sdkerr.Wrap(types.ErrCreateFailed, err.Error())
+		}
+	}
+	report, err := k.wasmvm.AnalyzeWasm(ctx, wasmCode, deps)
 	if err != nil {
 		return checksum, nil, sdkerr.Wrap(types.ErrCreateFailed, err.Error())
 	}
@@ -281,6 +337,23 @@ func (k *Keeper) createWasm(ctx sdk.Context, wasmCode []byte) (checksum []byte,
 	return checksum, report.Dependencies, nil
 }
 
+// ValidateWasm checks the module against the wasm_validation params and
+// returns the code to be stored, with NaN-producing float operations canonicalized
+func (k *Keeper) ValidateWasm(ctx sdk.Context, wasmCode []byte, role string) ([]byte, error) {
+	report, canonicalCode, err := determinism.Check(wasmCode, k.GetWasmValidation(ctx).Policy(role))
+	if err != nil {
+		return nil, sdkerr.Wrap(types.ErrCreateFailed, err.Error())
+	}
+	if err := report.Error(); err != nil {
+		return nil, sdkerr.Wrap(types.ErrWasmFeatures, err.Error())
+	}
+	if canonicalCode != nil {
+		k.Logger(ctx).Debug("canonicalized float operations", "count", report.CanonicalizedOps)
+		return canonicalCode, nil
+	}
+	return wasmCode, nil
+}
+
 func (k *Keeper) createSourceInterpreted(_ sdk.Context, sourceCode []byte, deps []string) (checksum []byte, reportDeps []string, err error) {
 	// TODO actually run the source code in the compiler
 	// and verify that it is valid
@@ -348,7 +421,7 @@ func (k *Keeper) CreateInterpreted(
 		provenance = &mcodec.AccAddressPrefixed{}
 	}
 
-	_, runtimeCode, err := k.instantiateInternal(ctx, codeID, *creator, *provenance, types.ContractStorageType_CoreConsensus, initMsg, deposit, contractAddress, &codeInfo, label)
+	_, runtimeCode, err := k.instantiateInternal(ctx, codeID, *creator, *provenance, mcodec.AccAddressPrefixed{}, types.ContractStorageType_CoreConsensus, initMsg, deposit, contractAddress, &codeInfo, label)
 	if err != nil {
 		return 0, checksum, contractAddress, sdkerr.Wrap(types.ErrCreateFailed, err.Error())
 	}
@@ -473,6 +546,7 @@ func (k *Keeper) instantiate(
 	ctx sdk.Context,
 	codeID uint64,
 	creator mcodec.AccAddressPrefixed,
+	admin mcodec.AccAddressPrefixed,
 	initMsg []byte,
 	deposit sdk.Coins,
 	label string,
@@ -489,13 +563,14 @@ func (k *Keeper) instantiate(
 	}
 	// TODO deps: support multiple types of address generation
 	contractAddress := k.EwasmClassicAddressGenerator(creator)(ctx, codeID, codeInfo.CodeHash)
-	return k.instantiateInternal(ctx, codeID, creator, mcodec.AccAddressPrefixed{}, types.ContractStorageType_CoreConsensus, initMsg, deposit, contractAddress, codeInfo, label)
+	return k.instantiateInternal(ctx, codeID, creator, mcodec.AccAddressPrefixed{}, admin, types.ContractStorageType_CoreConsensus, initMsg, deposit, contractAddress, codeInfo, label)
 }
 
 func (k *Keeper) instantiate2(
 	ctx sdk.Context,
 	codeID uint64,
 	creator mcodec.AccAddressPrefixed,
+	admin mcodec.AccAddressPrefixed,
 	initMsg []byte,
 	deposit sdk.Coins,
 	salt []byte,
@@ -515,7 +590,7 @@ func (k *Keeper) instantiate2(
 	// TODO if we support multiple types of address generation
 	// the type should be saved in CodeInfo
 	contractAddress := k.EwasmPredictableAddressGenerator(creator, salt, initMsg, fixMsg)(ctx, codeID, codeInfo.CodeHash)
-	return k.instantiateInternal(ctx, codeID, creator, mcodec.AccAddressPrefixed{}, types.ContractStorageType_CoreConsensus, initMsg, deposit, contractAddress, codeInfo, label)
+	return k.instantiateInternal(ctx, codeID, creator, mcodec.AccAddressPrefixed{}, admin, types.ContractStorageType_CoreConsensus, initMsg, deposit, contractAddress, codeInfo, label)
 }
 
 func (k *Keeper) instantiateInternal(
@@ -523,6 +598,7 @@ func (k *Keeper) instantiateInternal(
 	codeID uint64,
 	creator mcodec.AccAddressPrefixed,
 	provenance mcodec.AccAddressPrefixed,
+	admin mcodec.AccAddressPrefixed,
 	storageType types.ContractStorageType,
 	initMsg []byte,
 	deposit sdk.Coins,
@@ -555,6 +631,7 @@ func (k *Keeper) instantiateInternal(
 	// persist instance first
 	contractInfo := types.NewContractInfo(codeID, creator.String(), provenance.String(), initMsg, label)
 	contractInfo.StorageType = storageType
+	contractInfo.Admin = admin.String()
 
 	// check for IBC flag - TODO use codeInfo.Dependencies
 	// report, err := k.wasmvm.AnalyzeWasm(codeInfo.CodeHash)
@@ -731,10 +808,20 @@ func (k *Keeper) execute(ctx sdk.Context, contractAddress mcodec.AccAddressPrefi
 	if err != nil || contractInfo == nil || codeInfo == nil {
 		// This can be just an ethcall sending value
 		// we do not fail, to maintain compatibility with EVM
+		tracer := types.GetTracer(ctx)
+		if tracer != nil {
+			tracer.CaptureEnter(types.CALL_TYPE_CALL, caller.Bytes(), contractAddress.Bytes(), nil, ctx.GasMeter().Limit(), coins.AmountOf(k.denom).BigInt())
+		}
 		if coins.IsZero() {
+			if tracer != nil {
+				tracer.CaptureExit(nil, 0, nil)
+			}
 			return nil, nil
 		}
 		err := k.GetBankKeeper().SendCoinsPrefixed(ctx, caller, contractAddress, coins)
+		if tracer != nil {
+			tracer.CaptureExit(nil, 0, err)
+		}
 		return nil, err
 	}
 
@@ -784,7 +871,7 @@ func (k *Keeper) execute(ctx sdk.Context, contractAddress mcodec.AccAddressPrefi
 	k.consumeRuntimeGas(ctx, gasUsed)
 
 	if execErr != nil {
-		return nil, sdkerr.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return nil, executionError(execErr)
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
@@ -855,7 +942,7 @@ func (k *Keeper) ExecuteEntryPoint(ctx sdk.Context, contractEntryPoint string, c
 	k.consumeRuntimeGas(ctx, gasUsed)
 
 	if execErr != nil {
-		return nil, sdkerr.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return nil, executionError(execErr)
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
@@ -876,6 +963,113 @@ func (k *Keeper) ExecuteEntryPoint(ctx sdk.Context, contractEntryPoint string, c
 	return data, nil
 }
 
+// migrate changes the code of a contract instance and calls the migrate entry point of the new code
+func (k *Keeper) migrate(ctx sdk.Context, contractAddress mcodec.AccAddressPrefixed, caller mcodec.AccAddressPrefixed, newCodeID uint64, msg []byte) ([]byte, error) {
+	defer telemetry.MeasureSince(time.Now(), "wasmx", "contract", "migrate")
+	contractInfo, codeInfo, prefixStoreKey, err := k.ContractInstance(ctx, contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	if contractInfo == nil || codeInfo == nil {
+		return nil, sdkerr.Wrapf(types.ErrNotFound, "contract %s", contractAddress.String())
+	}
+	if err := RequireNotSystemContract(contractAddress.Bytes(), codeInfo.Deps); err != nil {
+		return nil, err
+	}
+	if contractInfo.Admin == "" || contractInfo.Admin != caller.String() {
+		return nil, sdkerr.Wrap(sdkerrors.ErrUnauthorized, "can not migrate")
+	}
+
+	newCodeInfo, err := k.GetCodeInfo(ctx, newCodeID)
+	if err != nil {
+		return nil, sdkerr.Wrapf(err, "migrate: cannot get code info")
+	}
+	if newCodeInfo == nil {
+		return nil, sdkerr.Wrap(types.ErrNotFound, "code")
+	}
+	if err := RequireNotSystemContract(contractAddress.Bytes(), newCodeInfo.Deps); err != nil {
+		return nil, err
+	}
+
+	migrateCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, newCodeID), len(msg))
+	ctx.GasMeter().ConsumeGas(migrateCosts, "Loading wasm module: migrate")
+
+	contractDeps, err := k.ContractDepsFromCodeDeps(ctx, newCodeInfo.Deps)
+	if err != nil {
+		return nil, err
+	}
+
+	// store the new code id before the migration call, so the contract can be called back
+	contractInfo.CodeId = newCodeID
+	err = k.storeContractInfo(ctx, contractAddress, *contractInfo)
+	if err != nil {
+		return nil, sdkerr.Wrap(err, "migrate store contract info")
+	}
+
+	gasMeter := k.gasMeter(ctx)
+	info := types.NewInfo(gasMeter, caller, caller, nil)
+	env, err := types.NewEnv(k.accBech32Codec, ctx, k.denom, contractAddress, newCodeInfo.CodeHash, newCodeInfo.InterpretedBytecodeRuntime, newCodeInfo.Deps, info)
+	if err != nil {
+		return nil, err
+	}
+
+	extendedContractInfo, err := k.GetContractDependencyInner(ctx, contractAddress, *contractInfo, *newCodeInfo, prefixStoreKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// prepare querier
+	handler := k.NewCosmosHandler(ctx, contractAddress)
+	store := k.ContractStore(ctx, contractInfo.StorageType, prefixStoreKey)
+	res, gasUsed, execErr := k.wasmvm.ExecuteEntryPoint(ctx, types.ENTRY_POINT_MIGRATE, newCodeInfo, env, msg, store, handler, gasMeter, extendedContractInfo, contractDeps, false)
+	k.consumeRuntimeGas(ctx, gasUsed)
+	if execErr != nil {
+		return nil, sdkerr.Wrap(types.ErrMigrationFailed, execErr.Error())
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeMigrate,
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(newCodeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+	))
+
+	err = k.handleResponseEvents(ctx, contractAddress.String(), contractInfo.IbcPortId, res.Attributes, res.Events)
+	if err != nil {
+		return nil, sdkerr.Wrap(err, "dispatch events")
+	}
+
+	data, err := k.handleResponseMessages(ctx, contractAddress, contractInfo.IbcPortId, res.Messages, res.Data)
+	if err != nil {
+		return nil, sdkerr.Wrap(err, "dispatch message")
+	}
+	return data, nil
+}
+
+// setContractAdminByCaller sets a new admin or clears the admin, if newAdmin is empty.
+// Only the current admin can do this.
+func (k *Keeper) setContractAdminByCaller(ctx sdk.Context, contractAddress mcodec.AccAddressPrefixed, caller mcodec.AccAddressPrefixed, newAdmin mcodec.AccAddressPrefixed) error {
+	contractInfo, err := k.GetContractInfo(ctx, contractAddress)
+	if err != nil {
+		return err
+	}
+	if contractInfo == nil {
+		return sdkerr.Wrapf(types.ErrNotFound, "contract %s", contractAddress.String())
+	}
+	if contractInfo.Admin == "" || contractInfo.Admin != caller.String() {
+		return sdkerr.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	if err := k.setContractAdmin(ctx, contractAddress, newAdmin.String()); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUpdateAdmin,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyNewAdmin, newAdmin.String()),
+	))
+	return nil
+}
+
 // For CosmWasm compatibility
 // reply is only called from keeper internal functions (dispatchSubmessages) after processing the submessage
 func (k *Keeper) Reply(ctx sdk.Context, contractAddress mcodec.AccAddressPrefixed, reply cw8types.Reply) ([]byte, error) {
@@ -917,7 +1111,7 @@ func (k *Keeper) Reply(ctx sdk.Context, contractAddress mcodec.AccAddressPrefixe
 	res, gasUsed, execErr := k.wasmvm.Reply(ctx, codeInfo, env, replyBz, store, handler, k.gasMeter(ctx), extendedContractInfo, nil)
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if execErr != nil {
-		return nil, sdkerr.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return nil, executionError(execErr)
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
@@ -986,7 +1180,7 @@ func (k *Keeper) executeWithOrigin(ctx sdk.Context, origin mcodec.AccAddressPref
 	// res, _, execErr = k.handleExecutionRerun(ctx, codeInfo.CodeHash, env, info, msg, prefixStore, cosmwasmAPI, querier, gas, costJSONDeserialization, contractAddress, contractInfo, res, gasUsed, execErr, k.wasmVM.Execute)
 
 	if execErr != nil {
-		return nil, sdkerr.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return nil, executionError(execErr)
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
@@ -1058,7 +1252,7 @@ func (k *Keeper) query(ctx sdk.Context, contractAddress mcodec.AccAddressPrefixe
 
 	// res, _, execErr = k.handleExecutionRerun(ctx, codeInfo.CodeHash, env, info, msg, prefixStore, cosmwasmAPI, querier, gas, costJSONDeserialization, contractAddress, contractInfo, res, gasUsed, execErr, k.wasmVM.Execute)
 	if execErr != nil {
-		return nil, sdkerr.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return nil, executionError(execErr)
 	}
 
 	// data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IbcPortId, res.Attributes, res.Data, res.Events)
@@ -1069,6 +1263,15 @@ func (k *Keeper) query(ctx sdk.Context, contractAddress mcodec.AccAddressPrefixe
 	return &res, nil
 }
 
+// executionError wraps the error of a failed contract execution. A timed out execution
+// keeps its ErrExecutionTimeout code, so callers can tell it from a contract error.
+func executionError(err error) error {
+	if errors.Is(err, types.ErrExecutionTimeout) {
+		return err
+	}
+	return sdkerr.Wrap(types.ErrExecuteFailed, err.Error())
+}
+
 // handleResponseEvents processes the contract response data by emitting events
 func (k *Keeper) handleResponseEvents(
 	ctx sdk.Context,
@@ -0,0 +1,41 @@
+package keeper_test
+
+import (
+	"crypto/sha256"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+	"github.com/loredanacirstea/wasmx/x/wasmx/vm/determinism"
+	"github.com/loredanacirstea/wasmx/x/wasmx/vm/determinism/testutils"
+)
+
+func (suite *KeeperTestSuite) TestValidateWasm() {
+	t := suite.T()
+	wasmxKeeper := suite.WasmxKeeper
+	ctx := suite.Ctx
+	wasmxKeeper.SetParams(ctx, types.DefaultParams())
+
+	// the default params do not allow simd
+	_, err := wasmxKeeper.ValidateWasm(ctx, testutils.SimdConst, "")
+	require.ErrorIs(t, err, types.ErrWasmFeatures)
+	require.ErrorContains(t, err, "simd not allowed")
+
+	// NaN-producing float operations are canonicalized, so the stored code
+	// and its checksum are not the uploaded code and its hash
+	code, err := wasmxKeeper.ValidateWasm(ctx, testutils.F32Add, "")
+	require.NoError(t, err)
+	require.NotEqual(t, testutils.F32Add, code)
+	require.NotEqual(t, sha256.Sum256(testutils.F32Add), sha256.Sum256(code))
+	_, canonicalCode, err := determinism.Check(testutils.F32Add, types.DefaultWasmValidation.Policy(""))
+	require.NoError(t, err)
+	require.Equal(t, canonicalCode, code)
+
+	// the code is stored unchanged if the params allow NaNs
+	params := types.DefaultParams()
+	params.WasmValidation.Features = append(params.WasmValidation.Features, determinism.FeatureFloatNaNs)
+	wasmxKeeper.SetParams(ctx, params)
+	code, err = wasmxKeeper.ValidateWasm(ctx, testutils.F32Add, "")
+	require.NoError(t, err)
+	require.Equal(t, testutils.F32Add, code)
+}
@@ -66,6 +66,12 @@ func (k *Keeper) storeCodeInfo(ctx sdk.Context, codeId uint64, codeInfo types.Co
 }
 
 func (k *Keeper) storeContractInfo(ctx sdk.Context, addr mcodec.AccAddressPrefixed, value types.ContractInfo) error {
+	// the admin is not stored by the code registry
+	if err := k.setContractAdmin(ctx, addr, value.Admin); err != nil {
+		return err
+	}
+	value.Admin = ""
+
 	contractAddr := k.GetCodeRegistryAddress(ctx)
 	databz, err := json.Marshal(&value)
 	if err != nil {
@@ -79,6 +85,55 @@ func (k *Keeper) storeContractInfo(ctx sdk.Context, addr mcodec.AccAddressPrefix
 	return nil
 }
 
+// setContractAdmin stores the contract admin; an empty admin removes it
+func (k *Keeper) setContractAdmin(ctx sdk.Context, addr mcodec.AccAddressPrefixed, admin string) error {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetContractAdminKey(addr.Bytes())
+	if admin == "" {
+		store.Delete(key)
+		return nil
+	}
+	adminAddr, err := k.accBech32Codec.StringToAccAddressPrefixed(admin)
+	if err != nil {
+		return sdkerr.Wrap(err, "admin")
+	}
+	store.Set(key, adminAddr.Bytes())
+	return nil
+}
+
+// GetContractAdmin returns the bech32 admin address of a contract or an empty string
+func (k *Keeper) GetContractAdmin(ctx sdk.Context, addr sdk.AccAddress) string {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetContractAdminKey(addr))
+	if len(bz) == 0 {
+		return ""
+	}
+	return k.accBech32Codec.BytesToAccAddressPrefixed(bz).String()
+}
+
+// ImportContractAdmin sets a contract admin from genesis
+func (k *Keeper) ImportContractAdmin(ctx sdk.Context, contractAdmin types.ContractAdmin) error {
+	contractAddr, err := k.accBech32Codec.StringToAccAddressPrefixed(contractAdmin.ContractAddress)
+	if err != nil {
+		return sdkerr.Wrap(err, "contract")
+	}
+	return k.setContractAdmin(ctx, contractAddr, contractAdmin.Admin)
+}
+
+// IterateContractAdmins iterates through all contracts that have an admin.
+// The callback method can return true to abort early.
+func (k *Keeper) IterateContractAdmins(ctx sdk.Context, cb func(contract sdk.AccAddress, admin string) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyContractAdminPrefix)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		admin := k.accBech32Codec.BytesToAccAddressPrefixed(iter.Value()).String()
+		// cb returns true to stop early
+		if cb(iter.Key(), admin) {
+			return
+		}
+	}
+}
+
 func (k *Keeper) GetLastCodeId(ctx sdk.Context) (codeId uint64, err error) {
 	contractAddr := k.GetCodeRegistryAddress(ctx)
 	msg := `{"GetLastCodeId":{}}`
@@ -154,6 +209,7 @@ func (k *Keeper) GetContractInfo(ctx sdk.Context, address mcodec.AccAddressPrefi
 	if res.ContractInfo == nil {
 		return nil, nil
 	}
+	res.ContractInfo.Admin = k.GetContractAdmin(ctx, address.Bytes())
 	return res.ContractInfo, nil
 }
 
@@ -184,6 +240,9 @@ func (k *Keeper) ContractInstance(ctx sdk.Context, contractAddress mcodec.AccAdd
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	if res.ContractInfo != nil {
+		res.ContractInfo.Admin = k.GetContractAdmin(ctx, contractAddress.Bytes())
+	}
 	return res.ContractInfo, res.CodeInfo, prefixStoreKey, nil
 }
 
@@ -225,6 +284,7 @@ func (k *Keeper) IterateContractInfos(ctx sdk.Context, cb func(sdk.AccAddress, t
 		if err != nil {
 			panic(sdkerr.Wrapf(err, "code iteration: cannot unmarshal CodeInfo: %s", hex.EncodeToString(iter.Value())))
 		}
+		c.Admin = k.GetContractAdmin(ctx, key)
 		// cb returns true to stop early
 		if cb(key, c) {
 			return
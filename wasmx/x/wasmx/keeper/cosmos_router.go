@@ -152,7 +152,7 @@ func (h *WasmxCosmosHandler) Create(codeId uint64, creator mcodec.AccAddressPref
 	if value != nil {
 		funds = sdk.NewCoins(sdk.NewCoin(h.Keeper.denom, sdkmath.NewIntFromBigInt(value)))
 	}
-	address, _, err := h.Keeper.Instantiate(h.Ctx, codeId, creator, initMsg, funds, label)
+	address, _, err := h.Keeper.Instantiate(h.Ctx, codeId, creator, mcodec.AccAddressPrefixed{}, initMsg, funds, label)
 	if err != nil {
 		return nil, err
 	}
@@ -164,7 +164,7 @@ func (h *WasmxCosmosHandler) Create2(codeId uint64, creator mcodec.AccAddressPre
 	if value != nil {
 		funds = sdk.NewCoins(sdk.NewCoin(h.Keeper.denom, sdkmath.NewIntFromBigInt(value)))
 	}
-	address, _, err := h.Keeper.Instantiate2(h.Ctx, codeId, creator, initMsg, funds, salt, false, label)
+	address, _, err := h.Keeper.Instantiate2(h.Ctx, codeId, creator, mcodec.AccAddressPrefixed{}, initMsg, funds, salt, false, label)
 	return &address, err
 }
 func (h *WasmxCosmosHandler) Deploy(bytecode []byte, sender *mcodec.AccAddressPrefixed, provenance *mcodec.AccAddressPrefixed, initMsg []byte, value *big.Int, deps []string, metadata types.CodeMetadata, label string, salt []byte, source []byte) (codeId uint64, checksum []byte, contractAddress mcodec.AccAddressPrefixed, err error) {
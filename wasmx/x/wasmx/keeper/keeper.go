@@ -6,6 +6,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -56,9 +57,13 @@ type (
 		cch *cchtypes.ContractHandlerMap
 		// queryGasLimit is the max wasmvm gas that can be spent on executing a query with a contract
 		queryGasLimit uint64
-		gasRegister   types.GasRegister
-		denom         string
-		permAddrs     map[string]authtypes.PermissionsForAddress
+		// queryTimeout is the wall-clock deadline of a query with a contract
+		queryTimeout time.Duration
+		// the last gas schedule decoded from params, shared by all contract calls
+		gasSchedule *gasScheduleCache
+		gasRegister types.GasRegister
+		denom       string
+		permAddrs   map[string]authtypes.PermissionsForAddress
 
 		wasmvm  *WasmxEngine
 		tempDir string
@@ -171,6 +176,8 @@ func NewKeeper(
 		accBech32Codec:        accBech32Codec,
 
 		queryGasLimit: wasmConfig.SmartQueryGasLimit,
+		queryTimeout:  wasmConfig.SmartQueryTimeout,
+		gasSchedule:   &gasScheduleCache{},
 		gasRegister:   NewDefaultWasmGasRegister(),
 		wasmvm:        wasmvm,
 		tempDir:       tempDir,
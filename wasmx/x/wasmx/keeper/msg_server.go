@@ -3,12 +3,14 @@ package keeper
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	sdkerr "cosmossdk.io/errors"
 	sdkmath "cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	errortypes "github.com/cosmos/cosmos-sdk/types/errors"
 
+	mcodec "github.com/loredanacirstea/wasmx/codec"
 	"github.com/loredanacirstea/wasmx/x/wasmx/types"
 	cchtypes "github.com/loredanacirstea/wasmx/x/wasmx/types/contract_handler"
 )
@@ -30,6 +32,12 @@ var _ types.MsgServer = msgServer{}
 func (m msgServer) ExecuteEth(goCtx context.Context, msg *types.MsgExecuteEth) (*types.MsgExecuteEthResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 	ctx = ctx.WithValue(cchtypes.CONTEXT_COIN_TYPE_KEY, cchtypes.COIN_TYPE_ETH)
+	// eth_estimateGas simulates the transaction, so it is bounded like a query
+	if ctx.ExecMode() == sdk.ExecModeSimulate {
+		var cancel context.CancelFunc
+		ctx, cancel = m.Keeper.WithQueryDeadline(ctx)
+		defer cancel()
+	}
 	tx := msg.AsTransaction()
 	senderAddr, err := m.accBech32Codec.StringToAccAddressPrefixed(msg.Sender)
 	if err != nil {
@@ -143,7 +151,12 @@ func (m msgServer) InstantiateContract(goCtx context.Context, msg *types.MsgInst
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
 
-	contractAddr, data, err := m.Keeper.Instantiate(ctx, msg.CodeId, senderAddr, msg.Msg, msg.Funds, msg.Label)
+	adminAddr, err := m.parseAdmin(msg.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	contractAddr, data, err := m.Keeper.Instantiate(ctx, msg.CodeId, senderAddr, adminAddr, msg.Msg, msg.Funds, msg.Label)
 	if err != nil {
 		return nil, err
 	}
@@ -171,7 +184,11 @@ func (m msgServer) InstantiateContract2(goCtx context.Context, msg *types.MsgIns
 		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
-	contractAddr, data, err := m.Keeper.Instantiate2(ctx, msg.CodeId, senderAddr, msg.Msg, msg.Funds, msg.Salt, msg.FixMsg, msg.Label)
+	adminAddr, err := m.parseAdmin(msg.Admin)
+	if err != nil {
+		return nil, err
+	}
+	contractAddr, data, err := m.Keeper.Instantiate2(ctx, msg.CodeId, senderAddr, adminAddr, msg.Msg, msg.Funds, msg.Salt, msg.FixMsg, msg.Label)
 	if err != nil {
 		return nil, err
 	}
@@ -182,6 +199,110 @@ func (m msgServer) InstantiateContract2(goCtx context.Context, msg *types.MsgIns
 	}, nil
 }
 
+// MigrateContract changes the code of a contract and calls its migrate entry point
+func (m msgServer) MigrateContract(goCtx context.Context, msg *types.MsgMigrateContract) (*types.MsgMigrateContractResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	senderAddr, err := m.accBech32Codec.StringToAccAddressPrefixed(msg.Sender)
+	if err != nil {
+		return nil, sdkerr.Wrap(err, "sender")
+	}
+	contractAddr, err := m.accBech32Codec.StringToAccAddressPrefixed(msg.Contract)
+	if err != nil {
+		return nil, sdkerr.Wrap(err, "contract")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	data, err := m.Keeper.Migrate(ctx, contractAddr, senderAddr, msg.CodeId, msg.Msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgMigrateContractResponse{
+		Data: data,
+	}, nil
+}
+
+// UpdateAdmin sets a new admin for a contract
+func (m msgServer) UpdateAdmin(goCtx context.Context, msg *types.MsgUpdateAdmin) (*types.MsgUpdateAdminResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	senderAddr, err := m.accBech32Codec.StringToAccAddressPrefixed(msg.Sender)
+	if err != nil {
+		return nil, sdkerr.Wrap(err, "sender")
+	}
+	contractAddr, err := m.accBech32Codec.StringToAccAddressPrefixed(msg.Contract)
+	if err != nil {
+		return nil, sdkerr.Wrap(err, "contract")
+	}
+	newAdminAddr, err := m.accBech32Codec.StringToAccAddressPrefixed(msg.NewAdmin)
+	if err != nil {
+		return nil, sdkerr.Wrap(err, "new admin")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	if err := m.Keeper.UpdateContractAdmin(ctx, contractAddr, senderAddr, newAdminAddr); err != nil {
+		return nil, err
+	}
+	return &types.MsgUpdateAdminResponse{}, nil
+}
+
+// ClearAdmin removes the admin of a contract, making it immutable
+func (m msgServer) ClearAdmin(goCtx context.Context, msg *types.MsgClearAdmin) (*types.MsgClearAdminResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	senderAddr, err := m.accBech32Codec.StringToAccAddressPrefixed(msg.Sender)
+	if err != nil {
+		return nil, sdkerr.Wrap(err, "sender")
+	}
+	contractAddr, err := m.accBech32Codec.StringToAccAddressPrefixed(msg.Contract)
+	if err != nil {
+		return nil, sdkerr.Wrap(err, "contract")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	if err := m.Keeper.ClearContractAdmin(ctx, contractAddr, senderAddr); err != nil {
+		return nil, err
+	}
+	return &types.MsgClearAdminResponse{}, nil
+}
+
+// parseAdmin returns an empty address if no admin is set
+func (m msgServer) parseAdmin(admin string) (mcodec.AccAddressPrefixed, error) {
+	if admin == "" {
+		return mcodec.AccAddressPrefixed{}, nil
+	}
+	adminAddr, err := m.accBech32Codec.StringToAccAddressPrefixed(admin)
+	if err != nil {
+		return mcodec.AccAddressPrefixed{}, sdkerr.Wrap(err, "admin")
+	}
+	return adminAddr, nil
+}
+
 // CompileContract does an AOT compilation for a contract
 func (m msgServer) CompileContract(goCtx context.Context, msg *types.MsgCompileContract) (*types.MsgCompileContractResponse, error) {
 	if err := msg.ValidateBasic(); err != nil {
@@ -207,6 +328,32 @@ func (m msgServer) CompileContract(goCtx context.Context, msg *types.MsgCompileC
 	return &types.MsgCompileContractResponse{}, nil
 }
 
+func (m msgServer) UpdateParams(goCtx context.Context, msg *types.MsgUpdateParams) (*types.MsgUpdateParamsResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	authority := m.Keeper.GetAuthority()
+	if authority != msg.Authority {
+		return nil, sdkerr.Wrapf(errortypes.ErrUnauthorized, "invalid authority; expected %s, got %s", authority, msg.Authority)
+	}
+
+	// a changed gas schedule must have a new version, so clients can tell pricing changed
+	current := m.Keeper.GetGasSchedule(ctx)
+	if !current.Equal(msg.Params.GasSchedule) && msg.Params.GasSchedule.Version <= current.Version {
+		return nil, sdkerr.Wrapf(errortypes.ErrInvalidRequest, "gas schedule version must be higher than %d, got %d", current.Version, msg.Params.GasSchedule.Version)
+	}
+	m.Keeper.SetParams(ctx, msg.Params)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUpdateParams,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyGasScheduleVersion, fmt.Sprintf("%d", msg.Params.GasSchedule.Version)),
+	))
+	return &types.MsgUpdateParamsResponse{}, nil
+}
+
 func (m msgServer) ExecuteContract(goCtx context.Context, msg *types.MsgExecuteContract) (*types.MsgExecuteContractResponse, error) {
 	return m.Keeper.ExecuteContract(goCtx, msg)
 }
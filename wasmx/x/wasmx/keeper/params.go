@@ -1,17 +1,99 @@
 package keeper
 
 import (
-	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+	"bytes"
+	"fmt"
+	"sync"
 
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
 )
 
 // GetParams get all parameters as types.Params
-func (k *Keeper) GetParams(ctx sdk.Context) types.Params {
-	return types.NewParams()
+func (k *Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	for _, pair := range params.ParamSetPairs() {
+		k.paramstore.GetIfExists(ctx, pair.Key, pair.Value)
+	}
+	return params
 }
 
 // SetParams set the params
 func (k *Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.paramstore.SetParamSet(ctx, &params)
 }
+
+// GetMaxMemoryPages returns the max number of memory pages of a contract instance.
+// 0 means no limit is set.
+func (k *Keeper) GetMaxMemoryPages(ctx sdk.Context) (pages uint32) {
+	k.paramstore.GetIfExists(ctx, types.ParamStoreKeyMaxMemoryPages, &pages)
+	return pages
+}
+
+// GetMaxCallDepth returns the max nesting level of contract calls.
+// 0 means no limit is set.
+func (k *Keeper) GetMaxCallDepth(ctx sdk.Context) (depth uint32) {
+	k.paramstore.GetIfExists(ctx, types.ParamStoreKeyMaxCallDepth, &depth)
+	return depth
+}
+
+// GetGasSchedule returns the gas costs of host functions
+func (k *Keeper) GetGasSchedule(ctx sdk.Context) (schedule types.GasSchedule) {
+	k.paramstore.GetIfExists(ctx, types.ParamStoreKeyGasSchedule, &schedule)
+	return schedule
+}
+
+// GetWasmValidation returns the features and limits enforced on uploaded wasm modules.
+// Chains without the parameter use the default validation.
+func (k *Keeper) GetWasmValidation(ctx sdk.Context) types.WasmValidation {
+	if !k.paramstore.Has(ctx, types.ParamStoreKeyWasmValidation) {
+		return types.DefaultWasmValidation
+	}
+	var validation types.WasmValidation
+	k.paramstore.Get(ctx, types.ParamStoreKeyWasmValidation, &validation)
+	return validation
+}
+
+// GetWasiFsQuota returns the max bytes of file content a contract can store
+// in its WASI data directory. Chains without the parameter use the default quota.
+func (k *Keeper) GetWasiFsQuota(ctx sdk.Context) uint64 {
+	if !k.paramstore.Has(ctx, types.ParamStoreKeyWasiFsQuota) {
+		return types.DefaultWasiFsQuota
+	}
+	var quota uint64
+	k.paramstore.Get(ctx, types.ParamStoreKeyWasiFsQuota, &quota)
+	return quota
+}
+
+// gasScheduleCache keeps the last decoded gas schedule,
+// so it is not rebuilt for each contract call
+type gasScheduleCache struct {
+	mtx      sync.Mutex
+	raw      []byte
+	schedule memc.GasSchedule
+}
+
+var gasScheduleAmino = codec.NewLegacyAmino()
+
+// getVmGasSchedule returns the gas schedule in the form used by the wasm vms.
+// The stored value is always read, so the gas used does not depend on the cache.
+func (k *Keeper) getVmGasSchedule(ctx sdk.Context) memc.GasSchedule {
+	raw := k.paramstore.GetRaw(ctx, types.ParamStoreKeyGasSchedule)
+	cache := k.gasSchedule
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	if cache.schedule != nil && bytes.Equal(cache.raw, raw) {
+		return cache.schedule
+	}
+	var schedule types.GasSchedule
+	if len(raw) > 0 {
+		if err := gasScheduleAmino.UnmarshalJSON(raw, &schedule); err != nil {
+			panic(fmt.Errorf("cannot decode gas schedule: %w", err))
+		}
+	}
+	cache.raw = raw
+	cache.schedule = schedule.ToMemc()
+	return cache.schedule
+}
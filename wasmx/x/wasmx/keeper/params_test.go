@@ -3,6 +3,7 @@ package keeper_test
 import (
 	"github.com/stretchr/testify/require"
 
+	"github.com/loredanacirstea/wasmx/x/wasmx/keeper"
 	"github.com/loredanacirstea/wasmx/x/wasmx/types"
 )
 
@@ -14,3 +15,53 @@ func (suite *KeeperTestSuite) TestGetParams() {
 	keeper.SetParams(ctx, params)
 	require.EqualValues(t, params, keeper.GetParams(ctx))
 }
+
+func (suite *KeeperTestSuite) TestUpdateParams() {
+	t := suite.T()
+	wasmxKeeper := suite.WasmxKeeper
+	ctx := suite.Ctx
+	msgServer := keeper.NewMsgServerImpl(wasmxKeeper)
+	wasmxKeeper.SetParams(ctx, types.DefaultParams())
+
+	params := types.DefaultParams()
+	params.GasSchedule = types.GasSchedule{
+		Version:       2,
+		HostFunctions: []types.HostFunctionGas{{Module: "wasmx", Name: "storageStore", Base: 100, PerByte: 2}},
+	}
+
+	_, err := msgServer.UpdateParams(ctx, &types.MsgUpdateParams{Authority: "mythos1notauthority", Params: params})
+	require.ErrorContains(t, err, "invalid authority")
+	require.Equal(t, types.DefaultGasSchedule, wasmxKeeper.GetGasSchedule(ctx))
+
+	// a changed gas schedule needs a higher version
+	stale := params
+	stale.GasSchedule.Version = types.DefaultGasSchedule.Version
+	_, err = msgServer.UpdateParams(ctx, &types.MsgUpdateParams{Authority: wasmxKeeper.GetAuthority(), Params: stale})
+	require.ErrorContains(t, err, "gas schedule version must be higher than 1, got 1")
+
+	_, err = msgServer.UpdateParams(ctx, &types.MsgUpdateParams{Authority: wasmxKeeper.GetAuthority(), Params: params})
+	require.NoError(t, err)
+	require.Equal(t, params, wasmxKeeper.GetParams(ctx))
+
+	// other params can change without a new gas schedule version
+	params.MaxCallDepth = 100
+	_, err = msgServer.UpdateParams(ctx, &types.MsgUpdateParams{Authority: wasmxKeeper.GetAuthority(), Params: params})
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), wasmxKeeper.GetMaxCallDepth(ctx))
+
+	wasmxKeeper.SetParams(ctx, types.DefaultParams())
+}
+
+func (suite *KeeperTestSuite) TestWasiFsQuotaParam() {
+	t := suite.T()
+	wasmxKeeper := suite.WasmxKeeper
+	ctx := suite.Ctx
+
+	params := types.DefaultParams()
+	params.WasiFsQuota = 1024
+	wasmxKeeper.SetParams(ctx, params)
+	require.Equal(t, uint64(1024), wasmxKeeper.GetWasiFsQuota(ctx))
+
+	wasmxKeeper.SetParams(ctx, types.DefaultParams())
+	require.Equal(t, types.DefaultWasiFsQuota, wasmxKeeper.GetWasiFsQuota(ctx))
+}
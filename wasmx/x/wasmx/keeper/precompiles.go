@@ -71,11 +71,16 @@ func (k *Keeper) BootstrapSystemContracts(
 		} else {
 			wasmbin := precompiles.GetPrecompileByLabel(k.AddressCodec(), contract.Label)
 
-			codeInfo, err = k.createCodeInfo(ctx, bootstrapAccountAddr, wasmbin, contract.Deps, contract.Metadata.ToJson(), contract.Pinned, contract.MeteringOff, contract.Source)
+			role := ""
+			if contract.Role != nil {
+				role = contract.Role.Role
+			}
+			codeInfo, err = k.createCodeInfo(ctx, bootstrapAccountAddr, wasmbin, contract.Deps, contract.Metadata.ToJson(), contract.Pinned, contract.MeteringOff, contract.Source, role, true)
 			if err != nil {
 				return sdkerr.Wrap(err, "store system contract: "+contract.Label)
 			}
 		}
+		codeInfo.MaxMemoryPages = contract.MaxMemoryPages
 
 		contractInfo = types.NewContractInfo(codeID, bootstrapAccountAddr.String(), bootstrapAccountAddr.String(), contract.InitMessage, contract.Label)
 		if !contract.Native {
@@ -16,6 +16,7 @@ import (
 	mcodec "github.com/loredanacirstea/wasmx/codec"
 	"github.com/loredanacirstea/wasmx/x/wasmx/types"
 	cchtypes "github.com/loredanacirstea/wasmx/x/wasmx/types/contract_handler"
+	"github.com/loredanacirstea/wasmx/x/wasmx/vm"
 )
 
 var _ types.QueryServer = &Keeper{}
@@ -168,6 +169,8 @@ func (k *Keeper) SmartContractCall(c context.Context, req *types.QuerySmartContr
 	}
 	// TODO validate deps
 	ctx := sdk.UnwrapSDKContext(c).WithGasMeter(storetypes.NewGasMeter(k.queryGasLimit))
+	ctx, cancel := k.WithQueryDeadline(ctx)
+	defer cancel()
 	if err := req.QueryData.ValidateBasic(); err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid query data")
 	}
@@ -233,6 +236,8 @@ func (k *Keeper) CallEth(c context.Context, req *types.QueryCallEthRequest) (rsp
 	// TODO validate deps
 	ctx := sdk.UnwrapSDKContext(c).WithGasMeter(storetypes.NewGasMeter(k.queryGasLimit))
 	ctx = ctx.WithValue(cchtypes.CONTEXT_COIN_TYPE_KEY, cchtypes.COIN_TYPE_ETH)
+	ctx, cancel := k.WithQueryDeadline(ctx)
+	defer cancel()
 	// recover from out-of-gas panic
 	defer func() {
 		if r := recover(); r != nil {
@@ -299,6 +304,8 @@ func (k *Keeper) DebugContractCall(c context.Context, req *types.QueryDebugContr
 	}
 	// TODO validate deps
 	ctx := sdk.UnwrapSDKContext(c).WithGasMeter(storetypes.NewGasMeter(k.queryGasLimit))
+	ctx, cancel := k.WithQueryDeadline(ctx)
+	defer cancel()
 	if err := req.QueryData.ValidateBasic(); err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid query data")
 	}
@@ -472,3 +479,30 @@ func (k *Keeper) ContractsByCreator(c context.Context, req *types.QueryContracts
 	// }, nil
 	return nil, nil
 }
+
+// GasSchedule returns the gas costs of all host functions, as charged at the current schedule version
+func (k *Keeper) GasSchedule(c context.Context, req *types.QueryGasScheduleRequest) (*types.QueryGasScheduleResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	schedule := k.GetGasSchedule(ctx)
+	costs, err := vm.EffectiveGasSchedule(schedule.ToMemc())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &types.QueryGasScheduleResponse{
+		Version:       schedule.Version,
+		HostFunctions: costs,
+	}, nil
+}
+
+// WithQueryDeadline bounds the wall-clock execution time of a query or non-consensus call.
+// The deadline is propagated to the wasm runtime and to the host APIs.
+func (k *Keeper) WithQueryDeadline(ctx sdk.Context) (sdk.Context, context.CancelFunc) {
+	if k.queryTimeout == 0 {
+		return ctx, func() {}
+	}
+	goCtx, cancel := context.WithTimeout(ctx.Context(), k.queryTimeout)
+	return ctx.WithContext(goCtx), cancel
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
 )
 
 func (suite *KeeperTestSuite) TestParamsQuery() {
@@ -16,3 +17,37 @@ func (suite *KeeperTestSuite) TestParamsQuery() {
 	require.NoError(t, err)
 	require.Equal(t, &types.QueryParamsResponse{Params: params}, response)
 }
+
+func (suite *KeeperTestSuite) TestGasScheduleQuery() {
+	t := suite.T()
+	keeper := suite.WasmxKeeper
+	ctx := suite.Ctx
+	params := types.DefaultParams()
+	params.GasSchedule = types.GasSchedule{
+		Version:       2,
+		HostFunctions: []types.HostFunctionGas{{Module: "wasmx", Name: "storageStore", Base: 100, PerByte: 2}},
+	}
+	keeper.SetParams(ctx, params)
+	defer keeper.SetParams(ctx, types.DefaultParams())
+
+	response, err := keeper.GasSchedule(ctx, &types.QueryGasScheduleRequest{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), response.Version)
+	require.Greater(t, len(response.HostFunctions), 1)
+
+	// all host functions are listed; the ones without a schedule entry have the default cost
+	scheduled := 0
+	for _, fn := range response.HostFunctions {
+		if fn.Module == "wasmx" && fn.Name == "storageStore" {
+			require.Equal(t, params.GasSchedule.HostFunctions[0], fn)
+			scheduled += 1
+			continue
+		}
+		require.Equal(t, memc.DefaultHostFunctionGas.Base, fn.Base, fn.Module+"."+fn.Name)
+		require.Equal(t, memc.DefaultHostFunctionGas.PerByte, fn.PerByte, fn.Module+"."+fn.Name)
+	}
+	require.Equal(t, 1, scheduled)
+
+	_, err = keeper.GasSchedule(ctx, nil)
+	require.Error(t, err)
+}
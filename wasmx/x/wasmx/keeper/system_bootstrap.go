@@ -145,10 +145,15 @@ func (k *Keeper) UpdateSystemCache(ctx sdk.Context, req *types.SystemBootstrap)
 }
 
 func (k *Keeper) FinalizeBlockResultHandler(ctx sdk.Context, resp *abci.ResponseFinalizeBlock) error {
+	k.appendBlockBloom(resp)
 	return nil
 }
 
 func (k *Keeper) EndBlockResultHandler(ctx sdk.Context, resp *abci.ResponseFinalizeBlock) error {
+	if resp == nil {
+		return nil
+	}
+	k.appendBlockBloom(resp)
 	evs := resp.Events
 	for _, txr := range resp.TxResults {
 		evs = append(evs, txr.GetEvents()...)
@@ -216,3 +221,12 @@ func (k *Keeper) UpdateSystemCacheCodes(ctx sdk.Context, cache *types.SystemBoot
 	cache.CodeRegistryContractInfo = contractInfo
 	return nil
 }
+
+// appendBlockBloom adds the bloom filter of the block ethereum logs to the block events.
+// Block events are not part of the results hash, so this does not change consensus.
+func (k *Keeper) appendBlockBloom(resp *abci.ResponseFinalizeBlock) {
+	if resp == nil {
+		return
+	}
+	resp.Events = append(resp.Events, types.BlockBloomEvent(k.addressCodec, resp.TxResults))
+}
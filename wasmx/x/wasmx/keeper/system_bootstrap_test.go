@@ -0,0 +1,25 @@
+package keeper_test
+
+import (
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+)
+
+func (suite *KeeperTestSuite) TestFinalizeBlockForgedLog() {
+	t := suite.T()
+	// a contract can emit its own wasmxlog event, with an invalid contract address
+	resp := &abci.ResponseFinalizeBlock{
+		TxResults: []*abci.ExecTxResult{{Events: []abci.Event{
+			{Type: types.CustomContractEventPrefix + types.EventTypeWasmxLog, Attributes: []abci.EventAttribute{
+				{Key: types.AttributeKeyContractAddr, Value: "notanaddress"},
+				{Key: types.AttributeKeyTopic, Value: "0x01"},
+			}},
+		}}},
+	}
+	require.NoError(t, suite.WasmxKeeper.FinalizeBlockResultHandler(suite.Ctx, resp))
+	_, found, err := types.BlockBloomFromEvents(resp.Events)
+	require.NoError(t, err)
+	require.True(t, found)
+}
@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	"encoding/json"
+	"math/big"
+
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/tracers"
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+)
+
+// TraceExecution runs the execution callback with the configured debug tracer
+// and returns the trace. The state changes are discarded.
+func (k *Keeper) TraceExecution(ctx sdk.Context, config types.TraceConfig, run func(ctx sdk.Context) error) (json.RawMessage, error) {
+	branchCtx, _ := ctx.CacheContext()
+	pre := traceStateReader{k: k, ctx: ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())}
+	post := traceStateReader{k: k, ctx: branchCtx.WithGasMeter(storetypes.NewInfiniteGasMeter())}
+	tracer, err := tracers.New(config.Tracer, config.TracerConfig, pre, post)
+	if err != nil {
+		return nil, err
+	}
+
+	execCtx, write := branchCtx.CacheContext()
+	runErr := run(types.WithTracer(execCtx, tracer))
+	if runErr == nil {
+		write()
+	}
+	res, err := tracer.GetResult()
+	if err != nil && runErr != nil {
+		return nil, runErr
+	}
+	return res, err
+}
+
+// traceStateReader reads the account state for the debug tracers
+type traceStateReader struct {
+	k   *Keeper
+	ctx sdk.Context
+}
+
+func (r traceStateReader) GetBalance(addr sdk.AccAddress) *big.Int {
+	return r.k.GetBankKeeper().GetBalancePrefixed(r.ctx, r.k.accBech32Codec.BytesToAccAddressPrefixed(addr), r.k.denom).Amount.BigInt()
+}
+
+func (r traceStateReader) GetNonce(addr sdk.AccAddress) uint64 {
+	acc, err := r.k.GetAccountKeeper().GetAccountPrefixed(r.ctx, r.k.accBech32Codec.BytesToAccAddressPrefixed(addr))
+	if err != nil || acc == nil {
+		return 0
+	}
+	return acc.GetSequence()
+}
+
+func (r traceStateReader) GetCode(addr sdk.AccAddress) []byte {
+	_, codeInfo, _, err := r.k.ContractInstance(r.ctx, r.k.accBech32Codec.BytesToAccAddressPrefixed(addr))
+	if err != nil || codeInfo == nil {
+		return nil
+	}
+	return codeInfo.InterpretedBytecodeRuntime
+}
+
+func (r traceStateReader) GetState(addr sdk.AccAddress, key []byte) []byte {
+	contractInfo, _, prefixStoreKey, err := r.k.ContractInstance(r.ctx, r.k.accBech32Codec.BytesToAccAddressPrefixed(addr))
+	if err != nil || contractInfo == nil {
+		return nil
+	}
+	return r.k.ContractStore(r.ctx, contractInfo.StorageType, prefixStoreKey).Get(key)
+}
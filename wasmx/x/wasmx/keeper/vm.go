@@ -83,8 +83,8 @@ func (k *WasmxEngine) CreateUtf8(sourceCode []byte, extension string) (types.Che
 	return checksum, nil
 }
 
-func (k *WasmxEngine) AnalyzeWasm(ctx sdk.Context, code types.WasmCode) (memc.AnalysisReport, error) {
-	return vm.AnalyzeWasm(ctx, k.WasmRuntime, code)
+func (k *WasmxEngine) AnalyzeWasm(ctx sdk.Context, code types.WasmCode, deps []string) (memc.AnalysisReport, error) {
+	return vm.AnalyzeWasm(ctx, k.WasmRuntime, code, deps)
 }
 
 func (k *WasmxEngine) Instantiate(
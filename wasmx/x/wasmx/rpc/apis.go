@@ -14,8 +14,11 @@ import (
 	menc "github.com/loredanacirstea/wasmx/encoding"
 
 	"github.com/loredanacirstea/wasmx/x/wasmx/rpc/backend"
+	"github.com/loredanacirstea/wasmx/x/wasmx/rpc/namespaces/debug"
 	"github.com/loredanacirstea/wasmx/x/wasmx/rpc/namespaces/eth"
+	"github.com/loredanacirstea/wasmx/x/wasmx/rpc/namespaces/eth/filters"
 	"github.com/loredanacirstea/wasmx/x/wasmx/rpc/namespaces/net"
+	"github.com/loredanacirstea/wasmx/x/wasmx/rpc/namespaces/txpool"
 )
 
 // RPC namespaces and API version
@@ -69,12 +72,12 @@ func init() {
 					Service:   eth.NewPublicAPI(svrCtx.Logger, evmBackend),
 					Public:    true,
 				},
-				// {
-				// 	Namespace: EthNamespace,
-				// 	Version:   apiVersion,
-				// 	Service:   filters.NewPublicAPI(ctx.Logger, clientCtx, tmWSClient, evmBackend),
-				// 	Public:    true,
-				// },
+				{
+					Namespace: EthNamespace,
+					Version:   apiVersion,
+					Service:   filters.NewPublicAPI(svrCtx.Logger, clientCtx, ctx, evmBackend),
+					Public:    true,
+				},
 			}
 		},
 		// Web3Namespace: func(*server.Context, client.Context, *rpcclient.WSClient, bool) []rpc.API {
@@ -113,32 +116,42 @@ func init() {
 		// 		},
 		// 	}
 		// },
-		// TxPoolNamespace: func(ctx *server.Context, _ client.Context, _ *rpcclient.WSClient, _ bool, _ types.EVMTxIndexer) []rpc.API {
-		// 	return []rpc.API{
-		// 		{
-		// 			Namespace: TxPoolNamespace,
-		// 			Version:   apiVersion,
-		// 			Service:   txpool.NewPublicAPI(ctx.Logger),
-		// 			Public:    true,
-		// 		},
-		// 	}
-		// },
-		// DebugNamespace: func(ctx *server.Context,
-		// 	clientCtx client.Context,
-		// 	_ *rpcclient.WSClient,
-		// 	allowUnprotectedTxs bool,
-		// 	indexer types.EVMTxIndexer,
-		// ) []rpc.API {
-		// 	evmBackend := backend.NewBackend(ctx, ctx.Logger, clientCtx, allowUnprotectedTxs, indexer)
-		// 	return []rpc.API{
-		// 		{
-		// 			Namespace: DebugNamespace,
-		// 			Version:   apiVersion,
-		// 			Service:   debug.NewAPI(ctx, evmBackend),
-		// 			Public:    true,
-		// 		},
-		// 	}
-		// },
+		TxPoolNamespace: func(svrCtx *server.Context,
+			clientCtx client.Context,
+			ctx context.Context,
+			_ *rpcclient.WSClient,
+			allowUnprotectedTxs bool,
+			chainId string,
+			chainConfig menc.ChainConfig,
+		) []rpc.API {
+			evmBackend := backend.NewBackend(svrCtx, svrCtx.Logger, clientCtx, ctx, allowUnprotectedTxs, chainId, chainConfig)
+			return []rpc.API{
+				{
+					Namespace: TxPoolNamespace,
+					Version:   apiVersion,
+					Service:   txpool.NewPublicAPI(svrCtx.Logger, evmBackend),
+					Public:    true,
+				},
+			}
+		},
+		DebugNamespace: func(svrCtx *server.Context,
+			clientCtx client.Context,
+			ctx context.Context,
+			_ *rpcclient.WSClient,
+			allowUnprotectedTxs bool,
+			chainId string,
+			chainConfig menc.ChainConfig,
+		) []rpc.API {
+			evmBackend := backend.NewBackend(svrCtx, svrCtx.Logger, clientCtx, ctx, allowUnprotectedTxs, chainId, chainConfig)
+			return []rpc.API{
+				{
+					Namespace: DebugNamespace,
+					Version:   apiVersion,
+					Service:   debug.NewAPI(svrCtx.Logger, evmBackend),
+					Public:    true,
+				},
+			}
+		},
 		// MinerNamespace: func(ctx *server.Context,
 		// 	clientCtx client.Context,
 		// 	_ *rpcclient.WSClient,
@@ -2,7 +2,9 @@ package backend
 
 import (
 	"context"
+	"encoding/json"
 	"math/big"
+	"time"
 
 	address "cosmossdk.io/core/address"
 	"cosmossdk.io/log"
@@ -15,12 +17,14 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	// "github.com/ethereum/go-ethereum/params"
 	// "github.com/ethereum/go-ethereum/rpc"
 
 	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
 
 	// tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
 
@@ -62,6 +66,11 @@ type EVMBackend interface {
 	// UnprotectedAllowed() bool
 	// RPCGasCap() uint64 // global gas cap for eth_call over rpc: DoS protection
 	// RPCEVMTimeout() time.Duration // global timeout for eth_call over rpc: DoS protection
+	RPCFilterCap() int32
+	RPCFilterTimeout() time.Duration
+	RPCLogsCap() int32
+	RPCBlockRangeCap() int32
+	RPCFeeHistoryCap() int32
 	// RPCTxFeeCap() float64         // RPCTxFeeCap is the global transaction fee(price * gaslimit) cap for send-transaction variants. The unit is ether.
 	// RPCMinGasPrice() int64
 
@@ -83,8 +92,8 @@ type EVMBackend interface {
 	BlockNumberFromTendermintByHash(blockHash common.Hash) (*big.Int, error)
 	EthMsgsFromTendermintBlock(block *tmrpctypes.ResultBlock, blockRes *tmrpctypes.ResultBlockResults) ([]*ethtypes.Transaction, []common.Hash)
 	BlockBloom(blockRes *tmrpctypes.ResultBlockResults) (ethtypes.Bloom, error)
-	// HeaderByNumber(blockNum rpctypes.BlockNumber) (*ethtypes.Header, error)
-	// HeaderByHash(blockHash common.Hash) (*ethtypes.Header, error)
+	HeaderByNumber(blockNum rpctypes.BlockNumber) (*ethtypes.Header, error)
+	HeaderByHash(blockHash common.Hash) (*ethtypes.Header, error)
 	RPCBlockFromTendermintBlock(resBlock *tmrpctypes.ResultBlock, blockRes *tmrpctypes.ResultBlockResults, fullTx bool) (map[string]interface{}, error)
 	EthBlockByNumber(blockNum rpctypes.BlockNumber) (*ethtypes.Block, error)
 	EthBlockFromTendermintBlock(resBlock *tmrpctypes.ResultBlock, blockRes *tmrpctypes.ResultBlockResults) (*ethtypes.Block, error)
@@ -100,12 +109,14 @@ type EVMBackend interface {
 	ChainID() (*hexutil.Big, error)
 	// ChainConfig() *params.ChainConfig
 	// GlobalMinGasPrice() (sdk.Dec, error)
-	// BaseFee(blockRes *tmrpctypes.ResultBlockResults) (*big.Int, error)
+	BaseFee(blockRes *tmrpctypes.ResultBlockResults) (*big.Int, error)
 	// CurrentHeader() *ethtypes.Header
 	PendingTransactions() ([]*sdk.Tx, []common.Hash, error)
+	PendingRPCTransactions() ([]*rpctypes.RPCTransaction, error)
 	// GetCoinbase() (sdk.AccAddress, error)
-	// FeeHistory(blockCount rpc.DecimalOrHex, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*rpctypes.FeeHistoryResult, error)
-	// SuggestGasTipCap(baseFee *big.Int) (*big.Int, error)
+	FeeHistory(blockCount math.HexOrDecimal64, lastBlock rpctypes.BlockNumber, rewardPercentiles []float64) (*rpctypes.FeeHistoryResult, error)
+	SuggestGasTipCap(baseFee *big.Int) (*big.Int, error)
+	BlockGasLimit(height int64) uint64
 
 	// // Tx Info
 	GetTransactionByHash(txHash common.Hash) (*rpctypes.RPCTransaction, error)
@@ -129,10 +140,12 @@ type EVMBackend interface {
 	// Filter API
 	GetLogs(hash common.Hash) ([][]*ethtypes.Log, error)
 	GetLogsByHeight(height *int64) ([][]*ethtypes.Log, error)
+	GetLogsFromBlock(block *tmtypes.Block, blockRes *tmrpctypes.ResultBlockResults) ([][]*ethtypes.Log, error)
 	BloomStatus() (uint64, uint64)
 
-	// // Tracing
-	// TraceTransaction(hash common.Hash, config *evmtypes.TraceConfig) (interface{}, error)
+	// Tracing
+	TraceTransaction(hash common.Hash, config *wasmxtypes.TraceConfig) (json.RawMessage, error)
+	TraceCall(args rpctypes.TransactionArgs, blockNrOrHash rpctypes.BlockNumberOrHash, config *wasmxtypes.TraceConfig) (json.RawMessage, error)
 	// TraceBlock(height rpctypes.BlockNumber, config *evmtypes.TraceConfig, block *tmrpctypes.ResultBlock) ([]*evmtypes.TxTraceResult, error)
 
 	AddressCodec() address.Codec
@@ -153,6 +166,7 @@ type Backend struct {
 	chainConfig         *menc.ChainConfig
 	allowUnprotectedTxs bool
 	addressCodec        address.Codec
+	gasTip              *gasTipCache
 }
 
 // NewBackend creates a new Backend instance for cosmos and ethereum namespaces
@@ -186,6 +200,7 @@ func NewBackend(
 		chainConfig:         &conf,
 		allowUnprotectedTxs: allowUnprotectedTxs,
 		addressCodec:        addressCodec,
+		gasTip:              &gasTipCache{},
 	}
 }
 
@@ -283,83 +283,82 @@ func (b *Backend) EthMsgsFromTendermintBlock(
 	return result, hashes
 }
 
-// // HeaderByNumber returns the block header identified by height.
-// func (b *Backend) HeaderByNumber(blockNum rpctypes.BlockNumber) (*ethtypes.Header, error) {
-// 	resBlock, err := b.TendermintBlockByNumber(blockNum)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	if resBlock == nil {
-// 		return nil, errors.Errorf("block not found for height %d", blockNum)
-// 	}
-
-// 	blockRes, err := b.TendermintBlockResultByNumber(&resBlock.Block.Height)
-// 	if err != nil {
-// 		return nil, fmt.Errorf("block result not found for height %d", resBlock.Block.Height)
-// 	}
-
-// 	bloom, err := b.BlockBloom(blockRes)
-// 	if err != nil {
-// 		b.logger.Debug("HeaderByNumber BlockBloom failed", "height", resBlock.Block.Height)
-// 	}
-
-// 	baseFee, err := b.BaseFee(blockRes)
-// 	if err != nil {
-// 		// handle the error for pruned node.
-// 		b.logger.Error("failed to fetch Base Fee from prunned block. Check node prunning configuration", "height", resBlock.Block.Height, "error", err)
-// 	}
-
-// 	ethHeader := rpctypes.EthHeaderFromTendermint(resBlock.Block.Header, bloom, baseFee)
-// 	return ethHeader, nil
-// }
-
-// // HeaderByHash returns the block header identified by hash.
-// func (b *Backend) HeaderByHash(blockHash common.Hash) (*ethtypes.Header, error) {
-// 	resBlock, err := b.TendermintBlockByHash(blockHash)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	if resBlock == nil {
-// 		return nil, errors.Errorf("block not found for hash %s", blockHash.Hex())
-// 	}
-
-// 	blockRes, err := b.TendermintBlockResultByNumber(&resBlock.Block.Height)
-// 	if err != nil {
-// 		return nil, errors.Errorf("block result not found for height %d", resBlock.Block.Height)
-// 	}
-
-// 	bloom, err := b.BlockBloom(blockRes)
-// 	if err != nil {
-// 		b.logger.Debug("HeaderByHash BlockBloom failed", "height", resBlock.Block.Height)
-// 	}
-
-// 	baseFee, err := b.BaseFee(blockRes)
-// 	if err != nil {
-// 		// handle the error for pruned node.
-// 		b.logger.Error("failed to fetch Base Fee from prunned block. Check node prunning configuration", "height", resBlock.Block.Height, "error", err)
-// 	}
-
-// 	ethHeader := rpctypes.EthHeaderFromTendermint(resBlock.Block.Header, bloom, baseFee)
-// 	return ethHeader, nil
-// }
-
-// // BlockBloom query block bloom filter from block results
-func (b *Backend) BlockBloom(blockRes *tmrpctypes.ResultBlockResults) (ethtypes.Bloom, error) {
-	// TODO
-	return ethtypes.Bloom{}, nil
-	// for _, event := range blockRes.EndBlockEvents {
-	// 	if event.Type != wasmxtypes.EventTypeBlockBloom {
-	// 		continue
-	// 	}
+// HeaderByNumber returns the block header identified by height.
+func (b *Backend) HeaderByNumber(blockNum rpctypes.BlockNumber) (*ethtypes.Header, error) {
+	resBlock, err := b.TendermintBlockByNumber(blockNum)
+	if err != nil {
+		return nil, err
+	}
 
-	// 	for _, attr := range event.Attributes {
-	// 		if bytes.Equal(attr.Key, bAttributeKeyEthereumBloom) {
-	// 			return ethtypes.BytesToBloom(attr.Value), nil
-	// 		}
-	// 	}
-	// }
-	return ethtypes.Bloom{}, errors.New("block bloom event is not found")
+	if resBlock == nil {
+		return nil, errors.Errorf("block not found for height %d", blockNum)
+	}
+
+	blockRes, err := b.TendermintBlockResultByNumber(&resBlock.Block.Height)
+	if err != nil {
+		return nil, fmt.Errorf("block result not found for height %d", resBlock.Block.Height)
+	}
+
+	bloom, err := b.BlockBloom(blockRes)
+	if err != nil {
+		b.logger.Debug("HeaderByNumber BlockBloom failed", "height", resBlock.Block.Height)
+	}
+
+	baseFee, err := b.BaseFee(blockRes)
+	if err != nil {
+		// handle the error for pruned node.
+		b.logger.Error("failed to fetch Base Fee from prunned block. Check node prunning configuration", "height", resBlock.Block.Height, "error", err)
+	}
+
+	ethHeader := rpctypes.EthHeaderFromTendermint(resBlock.Block.Header, bloom, baseFee)
+	return ethHeader, nil
+}
+
+// HeaderByHash returns the block header identified by hash.
+func (b *Backend) HeaderByHash(blockHash common.Hash) (*ethtypes.Header, error) {
+	resBlock, err := b.TendermintBlockByHash(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if resBlock == nil {
+		return nil, errors.Errorf("block not found for hash %s", blockHash.Hex())
+	}
+
+	blockRes, err := b.TendermintBlockResultByNumber(&resBlock.Block.Height)
+	if err != nil {
+		return nil, errors.Errorf("block result not found for height %d", resBlock.Block.Height)
+	}
+
+	bloom, err := b.BlockBloom(blockRes)
+	if err != nil {
+		b.logger.Debug("HeaderByHash BlockBloom failed", "height", resBlock.Block.Height)
+	}
+
+	baseFee, err := b.BaseFee(blockRes)
+	if err != nil {
+		// handle the error for pruned node.
+		b.logger.Error("failed to fetch Base Fee from prunned block. Check node prunning configuration", "height", resBlock.Block.Height, "error", err)
+	}
+
+	ethHeader := rpctypes.EthHeaderFromTendermint(resBlock.Block.Header, bloom, baseFee)
+	return ethHeader, nil
+}
+
+// BlockBloom returns the block bloom filter emitted with the block results.
+// For blocks without the bloom event, it is computed from the logs of the block results.
+func (b *Backend) BlockBloom(blockRes *tmrpctypes.ResultBlockResults) (ethtypes.Bloom, error) {
+	bloom, found, err := wasmxtypes.BlockBloomFromEvents(blockRes.FinalizeBlockEvents)
+	if err != nil {
+		return ethtypes.Bloom{}, err
+	}
+	if found {
+		return bloom, nil
+	}
+	blockLogs, err := GetLogsFromBlockResults(b.addressCodec, blockRes)
+	if err != nil {
+		return ethtypes.Bloom{}, err
+	}
+	return rpctypes.BloomFromLogs(blockLogs), nil
 }
 
 // RPCBlockFromTendermintBlock returns a JSON-RPC compatible Ethereum block from a
@@ -452,9 +451,8 @@ func (b *Backend) RPCBlockFromTendermintBlock(
 	// 	gasUsed += uint64(txsResult.GetGasUsed()) // #nosec G701 -- checked for int overflow already
 	// }
 
-	// TODO
-	gasLimit := int64(wasmxtypes.DefaultBlockGasLimit)
-	gasUsed := uint64(30000000)
+	gasLimit := int64(b.BlockGasLimit(block.Height)) //#nosec G701 -- gas limit is capped by consensus params
+	gasUsed := BlockGasUsed(blockRes)
 
 	formattedBlock := rpctypes.FormatBlock(
 		block.Header, block.Size(),
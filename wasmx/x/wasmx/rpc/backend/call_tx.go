@@ -20,9 +20,20 @@ import (
 	wasmxtypes "github.com/loredanacirstea/wasmx/x/wasmx/types"
 )
 
-// GasPrice returns the current gas price
+// GasPrice returns the suggested gas price: the base fee plus the suggested gas tip
 func (b *Backend) GasPrice() (*hexutil.Big, error) {
-	result := big.NewInt(1000000)
+	header, err := b.HeaderByNumber(rpctypes.EthLatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	tip, err := b.SuggestGasTipCap(header.BaseFee)
+	if err != nil {
+		return nil, err
+	}
+	result := new(big.Int).Set(tip)
+	if header.BaseFee != nil {
+		result.Add(result, header.BaseFee)
+	}
 	return (*hexutil.Big)(result), nil
 }
 
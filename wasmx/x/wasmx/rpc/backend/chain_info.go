@@ -1,19 +1,33 @@
 package backend
 
 import (
+	"fmt"
 	"math/big"
+	"sync"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	cmtrpcclient "github.com/cometbft/cometbft/rpc/client"
 	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
 	tndtypes "github.com/cometbft/cometbft/rpc/core/types"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
 
+	rpctypes "github.com/loredanacirstea/wasmx/x/wasmx/rpc/types"
 	wasmxtypes "github.com/loredanacirstea/wasmx/x/wasmx/types"
 )
 
+const (
+	// gasPriceOracleBlocks is the number of recent blocks used to suggest a gas tip
+	gasPriceOracleBlocks = 20
+	// gasPriceOraclePercentile is the percentile of the recent gas tips that is suggested
+	gasPriceOraclePercentile = 60
+	// defaultGasPrice is suggested when there are no recent transactions and no minimum gas price
+	defaultGasPrice = 1000000
+)
+
 // ChainID is the EIP-155 replay-protection chain id for the current ethereum chain config.
 func (b *Backend) ChainID() (*hexutil.Big, error) {
 	eip155ChainID, err := wasmxtypes.ParseEvmChainID(b.clientCtx.ChainID)
@@ -40,12 +54,10 @@ func (b *Backend) BaseFee(blockRes *tmrpctypes.ResultBlockResults) (*big.Int, er
 // PendingTransactions returns the transactions that are in the transaction pool
 // and have a from address that is one of the accounts this node manages.
 func (b *Backend) PendingTransactions() ([]*sdk.Tx, []common.Hash, error) {
-	// TODO
-	res := tndtypes.ResultUnconfirmedTxs{}
-	// res, err := b.clientCtx.Client.UnconfirmedTxs(b.ctx, nil)
-	// if err != nil {
-	// 	return nil, nil, err
-	// }
+	res, err := b.unconfirmedTxs()
+	if err != nil {
+		return nil, nil, err
+	}
 
 	result := make([]*sdk.Tx, 0, len(res.Txs))
 	hashes := make([]common.Hash, 0, len(res.Txs))
@@ -60,3 +72,248 @@ func (b *Backend) PendingTransactions() ([]*sdk.Tx, []common.Hash, error) {
 
 	return result, hashes, nil
 }
+
+// PendingRPCTransactions returns the ethereum transactions that are in the transaction pool,
+// in mempool order
+func (b *Backend) PendingRPCTransactions() ([]*rpctypes.RPCTransaction, error) {
+	txs, txHashes, err := b.PendingTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*rpctypes.RPCTransaction, 0, len(txs))
+	for i, tx := range txs {
+		for _, msg := range (*tx).GetMsgs() {
+			ethMsg, ok := msg.(*wasmxtypes.MsgExecuteEth)
+			if !ok {
+				// not valid ethereum tx
+				break
+			}
+
+			// transaction is not included in the block yet, so we use zero values
+			rpctx, err := b.NewTransactionFromMsg(
+				txHashes[i],
+				ethMsg,
+				common.Hash{},
+				uint64(0),
+				uint64(0),
+				nil,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, rpctx)
+		}
+	}
+	return result, nil
+}
+
+// unconfirmedTxs returns the transactions from this chain's mempool
+func (b *Backend) unconfirmedTxs() (*tndtypes.ResultUnconfirmedTxs, error) {
+	mempoolClient, ok := b.clientCtx.Client.(cmtrpcclient.MempoolClient)
+	if !ok {
+		return nil, fmt.Errorf("client does not support the mempool API")
+	}
+	return mempoolClient.UnconfirmedTxs(b.ctx, nil)
+}
+
+// FeeHistory returns the gas used ratio, base fee and gas tip percentiles
+// of the requested blocks. The gas tip of a transaction is its gas price,
+// computed from the fee charged by the ante handler, minus the block base fee.
+func (b *Backend) FeeHistory(
+	blockCount math.HexOrDecimal64,
+	lastBlock rpctypes.BlockNumber,
+	rewardPercentiles []float64,
+) (*rpctypes.FeeHistoryResult, error) {
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid reward percentile: %f", p)
+		}
+		if i > 0 && p < rewardPercentiles[i-1] {
+			return nil, fmt.Errorf("invalid reward percentile: #%d:%f > #%d:%f", i-1, rewardPercentiles[i-1], i, p)
+		}
+	}
+	if blockCount == 0 {
+		return &rpctypes.FeeHistoryResult{OldestBlock: (*hexutil.Big)(new(big.Int))}, nil
+	}
+
+	latest, err := b.BlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	lastHeight := int64(latest)
+	if lastBlock >= 0 {
+		if lastBlock.Int64() > lastHeight {
+			return nil, fmt.Errorf("request beyond head block: requested %d, head %d", lastBlock.Int64(), lastHeight)
+		}
+		lastHeight = lastBlock.Int64()
+	}
+	count := int64(blockCount)
+	if maxCount := int64(b.RPCFeeHistoryCap()); maxCount > 0 && count > maxCount {
+		count = maxCount
+	}
+	if count > lastHeight {
+		count = lastHeight
+	}
+	oldestHeight := lastHeight - count + 1
+
+	result := &rpctypes.FeeHistoryResult{
+		OldestBlock:  (*hexutil.Big)(big.NewInt(oldestHeight)),
+		BaseFee:      make([]*hexutil.Big, count+1),
+		GasUsedRatio: make([]float64, count),
+	}
+	if len(rewardPercentiles) > 0 {
+		result.Reward = make([][]*hexutil.Big, count)
+	}
+	for i := int64(0); i < count; i++ {
+		fees, err := b.blockFees(oldestHeight + i)
+		if err != nil {
+			return nil, err
+		}
+		result.BaseFee[i] = (*hexutil.Big)(fees.baseFee)
+		// the next block has the same base fee
+		result.BaseFee[i+1] = (*hexutil.Big)(fees.baseFee)
+		result.GasUsedRatio[i] = float64(fees.gasUsed) / float64(fees.gasLimit)
+		if len(rewardPercentiles) > 0 {
+			rewards := rpctypes.RewardPercentiles(fees.txs, rewardPercentiles, fees.gasUsed)
+			result.Reward[i] = make([]*hexutil.Big, len(rewards))
+			for j, reward := range rewards {
+				result.Reward[i][j] = (*hexutil.Big)(reward)
+			}
+		}
+	}
+	return result, nil
+}
+
+// SuggestGasTipCap returns a gas tip from the tips paid in the recent blocks.
+// The tip plus the base fee is never lower than the node's minimum gas price.
+func (b *Backend) SuggestGasTipCap(baseFee *big.Int) (*big.Int, error) {
+	latest, err := b.BlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	height := int64(latest)
+	// the cached tip does not depend on the requested base fee
+	tip := b.gasTip.get(height)
+	if tip == nil {
+		tip, err = b.recentGasTip(height)
+		if err != nil {
+			return nil, err
+		}
+		b.gasTip.set(height, tip)
+	}
+
+	// the minimum gas price includes the base fee, the block rewards do not
+	minTip := b.cfg.GetMinGasPrices().AmountOf(b.chainConfig.BaseDenom).TruncateInt().BigInt()
+	if minTip.Sign() == 0 {
+		minTip = big.NewInt(defaultGasPrice)
+	}
+	if baseFee != nil && baseFee.Sign() > 0 {
+		minTip = new(big.Int).Sub(minTip, baseFee)
+		if minTip.Sign() < 0 {
+			minTip = new(big.Int)
+		}
+	}
+	if tip.Cmp(minTip) < 0 {
+		return minTip, nil
+	}
+	return tip, nil
+}
+
+// recentGasTip returns the gasPriceOraclePercentile of the tips paid in the blocks up to height
+func (b *Backend) recentGasTip(height int64) (*big.Int, error) {
+	var txs []rpctypes.TxGasAndReward
+	var gasUsed uint64
+	for h := height; h > 0 && h > height-gasPriceOracleBlocks; h-- {
+		fees, err := b.blockFees(h)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, fees.txs...)
+		for _, tx := range fees.txs {
+			gasUsed += tx.GasUsed
+		}
+	}
+	return rpctypes.RewardPercentiles(txs, []float64{gasPriceOraclePercentile}, gasUsed)[0], nil
+}
+
+// BlockGasLimit returns the block gas limit from the consensus params
+func (b *Backend) BlockGasLimit(height int64) uint64 {
+	networkClient, ok := b.clientCtx.Client.(cmtrpcclient.NetworkClient)
+	if !ok {
+		return wasmxtypes.DefaultBlockGasLimit
+	}
+	res, err := networkClient.ConsensusParams(b.ctx, &height)
+	if err != nil {
+		b.logger.Debug("failed to query consensus params", "height", height, "error", err.Error())
+		return wasmxtypes.DefaultBlockGasLimit
+	}
+	if res.ConsensusParams.Block.MaxGas <= 0 {
+		return wasmxtypes.DefaultBlockGasLimit
+	}
+	return uint64(res.ConsensusParams.Block.MaxGas)
+}
+
+// blockFees contains the fee data of a block
+type blockFees struct {
+	baseFee  *big.Int
+	gasUsed  uint64
+	gasLimit uint64
+	txs      []rpctypes.TxGasAndReward
+}
+
+func (b *Backend) blockFees(height int64) (*blockFees, error) {
+	blockRes, err := b.TendermintBlockResultByNumber(&height)
+	if err != nil {
+		return nil, fmt.Errorf("block result not found for height %d: %w", height, err)
+	}
+	baseFee, err := b.BaseFee(blockRes)
+	if err != nil || baseFee == nil {
+		baseFee = new(big.Int)
+	}
+
+	fees := &blockFees{
+		baseFee:  baseFee,
+		gasUsed:  BlockGasUsed(blockRes),
+		gasLimit: b.BlockGasLimit(height),
+	}
+	for _, txResult := range blockRes.TxsResults {
+		gasPrice := TxGasPrice(txResult, b.chainConfig.BaseDenom)
+		if gasPrice == nil {
+			continue
+		}
+		reward := new(big.Int).Sub(gasPrice, baseFee)
+		if reward.Sign() < 0 {
+			reward = new(big.Int)
+		}
+		fees.txs = append(fees.txs, rpctypes.TxGasAndReward{
+			GasUsed: uint64(txResult.GasUsed), //#nosec G701 -- gas used is never negative
+			Reward:  reward,
+		})
+	}
+	return fees, nil
+}
+
+// gasTipCache caches the tip paid in the recent blocks, for the latest block
+type gasTipCache struct {
+	mu     sync.Mutex
+	height int64
+	tip    *big.Int
+}
+
+func (c *gasTipCache) get(height int64) *big.Int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tip == nil || c.height != height {
+		return nil
+	}
+	return new(big.Int).Set(c.tip)
+}
+
+func (c *gasTipCache) set(height int64, tip *big.Int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.height = height
+	c.tip = new(big.Int).Set(tip)
+}
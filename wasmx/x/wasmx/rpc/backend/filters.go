@@ -1,6 +1,9 @@
 package backend
 
 import (
+	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/pkg/errors"
@@ -15,18 +18,51 @@ func (b *Backend) GetLogs(hash common.Hash) ([][]*ethtypes.Log, error) {
 	if resBlock == nil {
 		return nil, errors.Errorf("block not found for hash %s", hash)
 	}
-	return b.GetLogsByHeight(&resBlock.Block.Header.Height)
+	blockRes, err := b.TendermintBlockResultByNumber(&resBlock.Block.Height)
+	if err != nil {
+		return nil, err
+	}
+	return b.GetLogsFromBlock(resBlock.Block, blockRes)
 }
 
 // GetLogsByHeight returns all the logs from all the ethereum transactions in a block.
 func (b *Backend) GetLogsByHeight(height *int64) ([][]*ethtypes.Log, error) {
+	resBlock, err := b.clientCtx.Client.Block(b.ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	if resBlock == nil || resBlock.Block == nil {
+		return nil, errors.Errorf("block not found for height %d", *height)
+	}
 	// NOTE: we query the state in case the tx result logs are not persisted after an upgrade.
-	blockRes, err := b.TendermintBlockResultByNumber(height)
+	blockRes, err := b.TendermintBlockResultByNumber(&resBlock.Block.Height)
 	if err != nil {
 		return nil, err
 	}
+	return b.GetLogsFromBlock(resBlock.Block, blockRes)
+}
 
-	return GetLogsFromBlockResults(b.addressCodec, blockRes)
+// GetLogsFromBlock returns the logs of each transaction in a block,
+// with the block and transaction information set.
+func (b *Backend) GetLogsFromBlock(block *tmtypes.Block, blockRes *tmrpctypes.ResultBlockResults) ([][]*ethtypes.Log, error) {
+	blockLogs, err := GetLogsFromBlockResults(b.addressCodec, blockRes)
+	if err != nil {
+		return nil, err
+	}
+	blockHash := common.BytesToHash(block.Hash())
+	for txIndex, logs := range blockLogs {
+		var txHash common.Hash
+		if txIndex < len(block.Txs) {
+			txHash = common.BytesToHash(block.Txs[txIndex].Hash())
+		}
+		for _, log := range logs {
+			log.BlockNumber = uint64(block.Height)
+			log.BlockHash = blockHash
+			log.TxHash = txHash
+			log.TxIndex = uint(txIndex)
+		}
+	}
+	return blockLogs, nil
 }
 
 // BloomStatus returns the BloomBitsBlocks and the number of processed sections maintained
@@ -5,6 +5,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	jsonrpcconfig "github.com/loredanacirstea/wasmx/x/wasmx/server/config"
 )
 
 // Accounts returns the list of accounts available to this node.
@@ -65,6 +67,34 @@ func (b *Backend) RPCEVMTimeout() time.Duration {
 	return b.cfg.JsonRpc.EVMTimeout
 }
 
+// RPCFilterCap is the limit for total number of filters that can be created
+func (b *Backend) RPCFilterCap() int32 {
+	return b.cfg.JsonRpc.FilterCap
+}
+
+// RPCFilterTimeout is the time after which a filter that was not polled is uninstalled
+func (b *Backend) RPCFilterTimeout() time.Duration {
+	if b.cfg.JsonRpc.FilterTimeout == 0 {
+		return jsonrpcconfig.DefaultFilterTimeout
+	}
+	return b.cfg.JsonRpc.FilterTimeout
+}
+
+// RPCLogsCap defines the max number of results can be returned from single `eth_getLogs` query.
+func (b *Backend) RPCLogsCap() int32 {
+	return b.cfg.JsonRpc.LogsCap
+}
+
+// RPCBlockRangeCap defines the max block range allowed for `eth_getLogs` query.
+func (b *Backend) RPCBlockRangeCap() int32 {
+	return b.cfg.JsonRpc.BlockRangeCap
+}
+
+// RPCFeeHistoryCap defines the max number of blocks that can be queried by `eth_feeHistory`.
+func (b *Backend) RPCFeeHistoryCap() int32 {
+	return b.cfg.JsonRpc.FeeHistoryCap
+}
+
 // // RPCGasCap is the global gas cap for eth-call variants.
 // func (b *Backend) RPCGasCap() uint64 {
 // 	return b.cfg.JsonRpc.GasCap
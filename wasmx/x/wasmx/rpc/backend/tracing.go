@@ -0,0 +1,114 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	rpctypes "github.com/loredanacirstea/wasmx/x/wasmx/rpc/types"
+	wasmxtypes "github.com/loredanacirstea/wasmx/x/wasmx/types"
+)
+
+// TraceClient is implemented by the node clients that can re-execute
+// transactions and calls with a debug tracer
+type TraceClient interface {
+	TraceTx(ctx context.Context, hash []byte, config wasmxtypes.TraceConfig) (json.RawMessage, error)
+	TraceCall(ctx context.Context, height int64, msg sdk.Msg, config wasmxtypes.TraceConfig) (json.RawMessage, error)
+}
+
+func (b *Backend) traceClient() (TraceClient, error) {
+	client, ok := b.clientCtx.Client.(TraceClient)
+	if !ok {
+		return nil, errors.New("debug tracing is not supported by the node client")
+	}
+	return client, nil
+}
+
+// TraceTransaction returns the trace of a transaction, re-executed with the configured tracer.
+func (b *Backend) TraceTransaction(hash common.Hash, config *wasmxtypes.TraceConfig) (json.RawMessage, error) {
+	client, err := b.traceClient()
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		config = &wasmxtypes.TraceConfig{}
+	}
+	return client.TraceTx(b.ctx, hash.Bytes(), *config)
+}
+
+// TraceCall returns the trace of a call executed on the state of the given block,
+// with the configured tracer.
+func (b *Backend) TraceCall(args rpctypes.TransactionArgs, blockNrOrHash rpctypes.BlockNumberOrHash, config *wasmxtypes.TraceConfig) (json.RawMessage, error) {
+	client, err := b.traceClient()
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		config = &wasmxtypes.TraceConfig{}
+	}
+	blockNum, err := b.BlockNumberFromTendermint(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	height := blockNum.Int64()
+	if height < 0 {
+		latest, err := b.BlockNumber()
+		if err != nil {
+			return nil, err
+		}
+		height = int64(latest)
+	}
+	msg, err := b.callMsgFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return client.TraceCall(b.ctx, height, msg, *config)
+}
+
+// callMsgFromArgs builds an unsigned MsgExecuteEth from the call arguments
+func (b *Backend) callMsgFromArgs(args rpctypes.TransactionArgs) (*wasmxtypes.MsgExecuteEth, error) {
+	var data []byte
+	if args.Input != nil {
+		data = *args.Input
+	} else if args.Data != nil {
+		data = *args.Data
+	}
+	txData := &ethtypes.LegacyTx{
+		To:       args.To,
+		Data:     data,
+		Value:    big.NewInt(0),
+		GasPrice: big.NewInt(0),
+	}
+	if args.Value != nil {
+		txData.Value = args.Value.ToInt()
+	}
+	if args.GasPrice != nil {
+		txData.GasPrice = args.GasPrice.ToInt()
+	}
+	if args.Gas != nil {
+		txData.Gas = uint64(*args.Gas)
+	}
+	if args.Nonce != nil {
+		txData.Nonce = uint64(*args.Nonce)
+	}
+	txbz, err := ethtypes.NewTx(txData).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	from := common.Address{}
+	if args.From != nil {
+		from = *args.From
+	}
+	sender, err := b.addressCodec.BytesToString(wasmxtypes.AccAddressFromEvm(from))
+	if err != nil {
+		return nil, err
+	}
+	return &wasmxtypes.MsgExecuteEth{Data: txbz, Sender: sender}, nil
+}
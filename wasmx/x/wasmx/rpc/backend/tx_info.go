@@ -12,8 +12,6 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
-	tndtypes "github.com/cometbft/cometbft/rpc/core/types"
-
 	rpctypes "github.com/loredanacirstea/wasmx/x/wasmx/rpc/types"
 	wasmxtypes "github.com/loredanacirstea/wasmx/x/wasmx/types"
 )
@@ -274,14 +272,11 @@ func (b *Backend) queryTendermintTxIndexer(query string) (*wasmxtypes.TxResult,
 func (b *Backend) getTransactionByHashPending(txHash common.Hash) (*rpctypes.RPCTransaction, error) {
 	hexTx := txHash.Hex()
 	// try to find tx in mempool
-
-	// TODO
-	// res, err := b.clientCtx.Client.UnconfirmedTxs(b.ctx, nil)
-	// if err != nil {
-	// 	b.logger.Debug("tx not found", "hash", hexTx, "error", err.Error())
-	// 	return nil, nil
-	// }
-	res := tndtypes.ResultUnconfirmedTxs{}
+	res, err := b.unconfirmedTxs()
+	if err != nil {
+		b.logger.Debug("tx not found", "hash", hexTx, "error", err.Error())
+		return nil, nil
+	}
 
 	for _, txBz := range res.Txs {
 		if common.BytesToHash(txBz.Hash()).Hex() == hexTx {
@@ -2,6 +2,7 @@ package backend
 
 import (
 	"encoding/hex"
+	"math/big"
 	"strconv"
 
 	"google.golang.org/grpc/codes"
@@ -57,6 +58,9 @@ func TxLogsFromEvents(addressCodec address.Codec, events []abci.Event) ([]*ethty
 // ParseTxLogsFromEvent parse tx logs from one event
 func ParseTxLogsFromEvent(addressCodec address.Codec, event abci.Event) (*ethtypes.Log, error) {
 	var log ethtypes.Log
+	if contractAddress, ok := wasmxtypes.LogContractAddress(addressCodec, event); ok {
+		log.Address = wasmxtypes.EvmAddressFromAcc(contractAddress)
+	}
 	for _, attr := range event.Attributes {
 		// we now parse all wasmx logs, regardless of AttributeKeyEventType
 		if attr.Key == wasmxtypes.AttributeKeyIndex {
@@ -65,12 +69,6 @@ func ParseTxLogsFromEvent(addressCodec address.Codec, event abci.Event) (*ethtyp
 				return nil, err
 			}
 			log.Index = uint(index)
-		} else if attr.Key == wasmxtypes.AttributeKeyContractAddr {
-			contractAddress, err := addressCodec.StringToBytes(string(attr.Value))
-			if err != nil {
-				return nil, err
-			}
-			log.Address = wasmxtypes.EvmAddressFromAcc(contractAddress)
 		} else if attr.Key == wasmxtypes.AttributeKeyTopic {
 			log.Topics = append(log.Topics, common.HexToHash(string(attr.Value)))
 		} else if attr.Key == wasmxtypes.AttributeKeyData {
@@ -104,6 +102,43 @@ func ContractAddressFromEvents(addressCodec address.Codec, events []abci.Event)
 	return nil
 }
 
+// BlockGasUsed returns the gas used by all the transactions of a block
+func BlockGasUsed(blockRes *tmrpctypes.ResultBlockResults) uint64 {
+	gasUsed := uint64(0)
+	for _, txResult := range blockRes.TxsResults {
+		if txResult.GasUsed > 0 {
+			gasUsed += uint64(txResult.GasUsed) //#nosec G701 -- checked for negative values
+		}
+	}
+	return gasUsed
+}
+
+// TxGasPrice returns the gas price paid by a transaction, from the fee
+// charged by the ante handler and the transaction gas limit.
+// It returns nil if no fee was charged, e.g. for a transaction failing in the ante handler.
+func TxGasPrice(txResult *abci.ExecTxResult, denom string) *big.Int {
+	if txResult.GasWanted <= 0 {
+		return nil
+	}
+	for _, event := range txResult.Events {
+		if event.Type != sdk.EventTypeTx {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key != sdk.AttributeKeyFee {
+				continue
+			}
+			fee, err := sdk.ParseCoinsNormalized(attr.Value)
+			if err != nil {
+				return nil
+			}
+			amount := fee.AmountOf(denom)
+			return new(big.Int).Quo(amount.BigInt(), big.NewInt(txResult.GasWanted))
+		}
+	}
+	return nil
+}
+
 // getAccountNonce returns the account nonce for the given account address.
 // If the pending value is true, it will iterate over the mempool (pending)
 // txs in order to compute and return the pending tx sequence.
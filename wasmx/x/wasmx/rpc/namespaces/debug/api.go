@@ -0,0 +1,41 @@
+package debug
+
+import (
+	"encoding/json"
+
+	"cosmossdk.io/log"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/rpc/backend"
+	rpctypes "github.com/loredanacirstea/wasmx/x/wasmx/rpc/types"
+	wasmxtypes "github.com/loredanacirstea/wasmx/x/wasmx/types"
+)
+
+// API is the debug_ prefixed set of APIs in the Debug JSON-RPC spec.
+// The supported tracers are callTracer (default) and prestateTracer.
+type API struct {
+	logger  log.Logger
+	backend backend.EVMBackend
+}
+
+// NewAPI creates a new API definition for the tracing methods of the Ethereum service.
+func NewAPI(logger log.Logger, backend backend.EVMBackend) *API {
+	return &API{
+		logger:  logger.With("module", "debug"),
+		backend: backend,
+	}
+}
+
+// TraceTransaction returns the trace of a transaction, re-executed with the configured tracer
+func (a *API) TraceTransaction(hash common.Hash, config *wasmxtypes.TraceConfig) (json.RawMessage, error) {
+	a.logger.Debug("debug_traceTransaction", "hash", hash)
+	return a.backend.TraceTransaction(hash, config)
+}
+
+// TraceCall returns the trace of a call executed on the state of the given block,
+// with the configured tracer
+func (a *API) TraceCall(args rpctypes.TransactionArgs, blockNrOrHash rpctypes.BlockNumberOrHash, config *wasmxtypes.TraceConfig) (json.RawMessage, error) {
+	a.logger.Debug("debug_traceCall", "args", args.String(), "block number or hash", blockNrOrHash)
+	return a.backend.TraceCall(args, blockNrOrHash, config)
+}
@@ -7,11 +7,11 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/loredanacirstea/wasmx/x/wasmx/rpc/backend"
 	rpctypes "github.com/loredanacirstea/wasmx/x/wasmx/rpc/types"
-	wasmxtypes "github.com/loredanacirstea/wasmx/x/wasmx/types"
 )
 
 // The Ethereum JSON-RPC API
@@ -57,8 +57,8 @@ type EthereumAPI interface {
 	// ProtocolVersion() hexutil.Uint
 	GasPrice() (*hexutil.Big, error)
 	EstimateGas(args rpctypes.TransactionArgs, blockNrOptional *rpctypes.BlockNumber) (hexutil.Uint64, error)
-	// FeeHistory(blockCount rpc.DecimalOrHex, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*rpctypes.FeeHistoryResult, error)
-	// MaxPriorityFeePerGas() (*hexutil.Big, error)
+	FeeHistory(blockCount math.HexOrDecimal64, lastBlock rpctypes.BlockNumber, rewardPercentiles []float64) (*rpctypes.FeeHistoryResult, error)
+	MaxPriorityFeePerGas() (*hexutil.Big, error)
 	ChainId() (*hexutil.Big, error)
 
 	// Getting Uncles
@@ -287,24 +287,28 @@ func (e *PublicAPI) EstimateGas(args rpctypes.TransactionArgs, blockNrOptional *
 	return e.backend.EstimateGas(args, blockNrOptional)
 }
 
-// func (e *PublicAPI) FeeHistory(blockCount rpc.DecimalOrHex,
-// 	lastBlock rpc.BlockNumber,
-// 	rewardPercentiles []float64,
-// ) (*rpctypes.FeeHistoryResult, error) {
-// 	e.logger.Debug("eth_feeHistory")
-// 	return e.backend.FeeHistory(blockCount, lastBlock, rewardPercentiles)
-// }
+// FeeHistory returns the fee market history of the requested blocks.
+func (e *PublicAPI) FeeHistory(blockCount math.HexOrDecimal64,
+	lastBlock rpctypes.BlockNumber,
+	rewardPercentiles []float64,
+) (*rpctypes.FeeHistoryResult, error) {
+	e.logger.Debug("eth_feeHistory")
+	return e.backend.FeeHistory(blockCount, lastBlock, rewardPercentiles)
+}
 
-// // MaxPriorityFeePerGas returns a suggestion for a gas tip cap for dynamic fee transactions.
-// func (e *PublicAPI) MaxPriorityFeePerGas() (*hexutil.Big, error) {
-// 	e.logger.Debug("eth_maxPriorityFeePerGas")
-// 	head := e.backend.CurrentHeader()
-// 	tipcap, err := e.backend.SuggestGasTipCap(head.BaseFee)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	return (*hexutil.Big)(tipcap), nil
-// }
+// MaxPriorityFeePerGas returns a suggestion for a gas tip cap for dynamic fee transactions.
+func (e *PublicAPI) MaxPriorityFeePerGas() (*hexutil.Big, error) {
+	e.logger.Debug("eth_maxPriorityFeePerGas")
+	head, err := e.backend.HeaderByNumber(rpctypes.EthLatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	tipcap, err := e.backend.SuggestGasTipCap(head.BaseFee)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(tipcap), nil
+}
 
 // ChainId is the EIP-155 replay-protection chain id for the current ethereum chain config.
 func (e *PublicAPI) ChainId() (*hexutil.Big, error) { //nolint
@@ -458,37 +462,5 @@ func (e *PublicAPI) GetTransactionLogs(txHash common.Hash) ([]*ethtypes.Log, err
 // and have a from address that is one of the accounts this node manages.
 func (e *PublicAPI) GetPendingTransactions() ([]*rpctypes.RPCTransaction, error) {
 	e.logger.Debug("eth_getPendingTransactions")
-
-	txs, txHashes, err := e.backend.PendingTransactions()
-	if err != nil {
-		return nil, err
-	}
-
-	result := make([]*rpctypes.RPCTransaction, 0, len(txs))
-	for i, tx := range txs {
-		for _, msg := range (*tx).GetMsgs() {
-			ethMsg, ok := msg.(*wasmxtypes.MsgExecuteEth)
-			if !ok {
-				// not valid ethereum tx
-				break
-			}
-
-			// transaction is not included in the block yet, so we use zero values
-			rpctx, err := e.backend.NewTransactionFromMsg(
-				txHashes[i],
-				ethMsg,
-				common.Hash{},
-				uint64(0),
-				uint64(0),
-				nil,
-			)
-			if err != nil {
-				return nil, err
-			}
-
-			result = append(result, rpctx)
-		}
-	}
-
-	return result, nil
+	return e.backend.PendingRPCTransactions()
 }
@@ -0,0 +1,392 @@
+package filters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/rpc/backend"
+	rpctypes "github.com/loredanacirstea/wasmx/x/wasmx/rpc/types"
+)
+
+// FilterAPI gathers the Ethereum filter API methods
+type FilterAPI interface {
+	NewBlockFilter() (rpc.ID, error)
+	NewFilter(criteria FilterCriteria) (rpc.ID, error)
+	GetFilterChanges(id rpc.ID) (interface{}, error)
+	GetFilterLogs(ctx context.Context, id rpc.ID) ([]*ethtypes.Log, error)
+	UninstallFilter(id rpc.ID) bool
+	GetLogs(ctx context.Context, crit FilterCriteria) ([]*ethtypes.Log, error)
+	NewHeads(ctx context.Context) (*rpc.Subscription, error)
+	Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subscription, error)
+}
+
+var _ FilterAPI = (*PublicFilterAPI)(nil)
+
+// filter is a helper struct that holds meta information over the filter type
+// and associated subscription in the event system.
+type filter struct {
+	typ        Type
+	lastPolled time.Time // the filter is uninstalled if it is not polled before the filter timeout
+	hashes     []common.Hash
+	crit       FilterCriteria
+	logs       []*ethtypes.Log
+	s          *Subscription // associated subscription in event system
+}
+
+// PublicFilterAPI offers support to create and manage filters. This will allow external clients to retrieve various
+// information related to the Ethereum protocol such as blocks, transactions and logs.
+type PublicFilterAPI struct {
+	logger    log.Logger
+	backend   backend.EVMBackend
+	events    *EventSystem
+	filtersMu sync.Mutex
+	filters   map[rpc.ID]*filter
+}
+
+// NewPublicAPI returns a new PublicFilterAPI instance.
+// The filters that are not polled are uninstalled after the configured filter timeout.
+func NewPublicAPI(logger log.Logger, clientCtx client.Context, ctx context.Context, backend backend.EVMBackend) *PublicFilterAPI {
+	logger = logger.With("api", "filter")
+	eventsClient, ok := clientCtx.Client.(rpcclient.EventsClient)
+	if !ok {
+		logger.Error("node client does not support event subscriptions")
+	}
+	api := &PublicFilterAPI{
+		logger:  logger,
+		backend: backend,
+		events:  NewEventSystem(logger, ctx, eventsClient, backend),
+		filters: make(map[rpc.ID]*filter),
+	}
+
+	go api.timeoutLoop(ctx, backend.RPCFilterTimeout())
+
+	return api
+}
+
+// timeoutLoop runs every timeout and uninstalls the filters that were not polled
+// since the last run, until the context is done.
+func (api *PublicFilterAPI) timeoutLoop(ctx context.Context, timeout time.Duration) {
+	ticker := time.NewTicker(timeout)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		api.filtersMu.Lock()
+		for id, f := range api.filters {
+			if time.Since(f.lastPolled) < timeout {
+				continue
+			}
+			delete(api.filters, id)
+			f.s.Unsubscribe()
+		}
+		api.filtersMu.Unlock()
+	}
+}
+
+// NewBlockFilter creates a filter that fetches blocks that are imported into the chain.
+// It is part of the filter package since polling goes with eth_getFilterChanges.
+//
+// https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_newblockfilter
+func (api *PublicFilterAPI) NewBlockFilter() (rpc.ID, error) {
+	api.logger.Debug("eth_newBlockFilter")
+	api.filtersMu.Lock()
+	defer api.filtersMu.Unlock()
+
+	if err := api.checkFilterCap(); err != nil {
+		return "", fmt.Errorf("error creating block filter: %w", err)
+	}
+
+	sub, err := api.events.SubscribeNewHeads()
+	if err != nil {
+		return "", fmt.Errorf("error creating block filter: %w", err)
+	}
+	api.filters[sub.ID()] = &filter{
+		typ:        BlocksSubscription,
+		lastPolled: time.Now(),
+		hashes:     []common.Hash{},
+		s:          sub,
+	}
+
+	go func() {
+		for {
+			select {
+			case header := <-sub.Headers():
+				api.filtersMu.Lock()
+				if f, found := api.filters[sub.ID()]; found {
+					f.hashes = append(f.hashes, header.Hash)
+				}
+				api.filtersMu.Unlock()
+			case <-sub.Unsubscribed():
+				return
+			}
+		}
+	}()
+
+	return sub.ID(), nil
+}
+
+// NewFilter creates a new filter and returns the filter id. It can be
+// used to retrieve logs when the state changes. This method cannot be
+// used to fetch logs that are already stored in the state.
+//
+// Default criteria for the from and to block are "latest".
+// Using "latest" as block number will return logs for mined blocks.
+//
+// In case "fromBlock" > "toBlock" an error is returned.
+//
+// https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_newfilter
+func (api *PublicFilterAPI) NewFilter(criteria FilterCriteria) (rpc.ID, error) {
+	api.logger.Debug("eth_newFilter")
+	api.filtersMu.Lock()
+	defer api.filtersMu.Unlock()
+
+	if err := api.checkFilterCap(); err != nil {
+		return "", fmt.Errorf("error creating filter: %w", err)
+	}
+	if err := checkBlockRange(criteria); err != nil {
+		return "", err
+	}
+
+	sub, err := api.events.SubscribeLogs(criteria)
+	if err != nil {
+		return "", fmt.Errorf("error creating filter: %w", err)
+	}
+	api.filters[sub.ID()] = &filter{
+		typ:        LogsSubscription,
+		crit:       criteria,
+		lastPolled: time.Now(),
+		logs:       make([]*ethtypes.Log, 0),
+		s:          sub,
+	}
+
+	go func() {
+		for {
+			select {
+			case logs := <-sub.Logs():
+				api.filtersMu.Lock()
+				if f, found := api.filters[sub.ID()]; found {
+					f.logs = append(f.logs, logs...)
+				}
+				api.filtersMu.Unlock()
+			case <-sub.Unsubscribed():
+				return
+			}
+		}
+	}()
+
+	return sub.ID(), nil
+}
+
+// GetFilterChanges returns the logs for the filter with the given id since
+// last time it was called. This can be used for polling.
+//
+// For block filters the result is []common.Hash.
+// For log filters the result is []Log.
+//
+// https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getfilterchanges
+func (api *PublicFilterAPI) GetFilterChanges(id rpc.ID) (interface{}, error) {
+	api.logger.Debug("eth_getFilterChanges", "id", id)
+	api.filtersMu.Lock()
+	defer api.filtersMu.Unlock()
+
+	f, found := api.filters[id]
+	if !found {
+		return nil, fmt.Errorf("filter %s not found", id)
+	}
+	f.lastPolled = time.Now()
+
+	switch f.typ {
+	case BlocksSubscription:
+		hashes := f.hashes
+		f.hashes = nil
+		return returnHashes(hashes), nil
+	case LogsSubscription:
+		logs := f.logs
+		f.logs = nil
+		return returnLogs(logs), nil
+	default:
+		return nil, fmt.Errorf("invalid filter %s type %d", id, f.typ)
+	}
+}
+
+// GetFilterLogs returns the logs for the filter with the given id.
+// If the filter could not be found an empty array of logs is returned.
+//
+// https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getfilterlogs
+func (api *PublicFilterAPI) GetFilterLogs(ctx context.Context, id rpc.ID) ([]*ethtypes.Log, error) {
+	api.logger.Debug("eth_getFilterLogs", "id", id)
+	api.filtersMu.Lock()
+	f, found := api.filters[id]
+	api.filtersMu.Unlock()
+
+	if !found {
+		return returnLogs(nil), fmt.Errorf("filter %s not found", id)
+	}
+	if f.typ != LogsSubscription {
+		return returnLogs(nil), fmt.Errorf("filter %s doesn't have a LogsSubscription type: got %d", id, f.typ)
+	}
+
+	logs, err := api.filterLogs(ctx, f.crit)
+	if err != nil {
+		return nil, err
+	}
+	return returnLogs(logs), nil
+}
+
+// UninstallFilter removes the filter with the given filter id.
+//
+// https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_uninstallfilter
+func (api *PublicFilterAPI) UninstallFilter(id rpc.ID) bool {
+	api.logger.Debug("eth_uninstallFilter", "id", id)
+	api.filtersMu.Lock()
+	defer api.filtersMu.Unlock()
+
+	f, found := api.filters[id]
+	if !found {
+		return false
+	}
+	delete(api.filters, id)
+	f.s.Unsubscribe()
+	return true
+}
+
+// GetLogs returns logs matching the given argument that are stored within the state.
+//
+// https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getlogs
+func (api *PublicFilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*ethtypes.Log, error) {
+	api.logger.Debug("eth_getLogs")
+	if err := checkBlockRange(crit); err != nil {
+		return nil, err
+	}
+	logs, err := api.filterLogs(ctx, crit)
+	if err != nil {
+		return nil, err
+	}
+	return returnLogs(logs), nil
+}
+
+// NewHeads send a notification each time a new (header) block is appended to the chain.
+// It is only available over websockets: eth_subscribe("newHeads")
+func (api *PublicFilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	api.logger.Debug("eth_subscribe", "type", "newHeads")
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	sub, err := api.events.SubscribeNewHeads()
+	if err != nil {
+		return nil, err
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case header := <-sub.Headers():
+				if err := notifier.Notify(rpcSub.ID, header.Data); err != nil {
+					api.logger.Debug("failed to send newHeads notification", "id", rpcSub.ID, "error", err.Error())
+				}
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// Logs creates a subscription that fires for all new log that match the given filter criteria.
+// It is only available over websockets: eth_subscribe("logs", criteria)
+func (api *PublicFilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subscription, error) {
+	api.logger.Debug("eth_subscribe", "type", "logs")
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	if err := checkBlockRange(crit); err != nil {
+		return nil, err
+	}
+
+	sub, err := api.events.SubscribeLogs(crit)
+	if err != nil {
+		return nil, err
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case logs := <-sub.Logs():
+				for _, log := range logs {
+					if err := notifier.Notify(rpcSub.ID, log); err != nil {
+						api.logger.Debug("failed to send logs notification", "id", rpcSub.ID, "error", err.Error())
+					}
+				}
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// filterLogs returns the stored logs matching the criteria, within the configured logs and block range caps
+func (api *PublicFilterAPI) filterLogs(ctx context.Context, crit FilterCriteria) ([]*ethtypes.Log, error) {
+	var f *Filter
+	if crit.BlockHash != nil {
+		f = NewBlockFilter(api.logger, api.backend, crit)
+	} else {
+		// Convert the RPC block numbers into internal representations
+		begin := rpctypes.EthLatestBlockNumber.Int64()
+		if crit.FromBlock != nil {
+			begin = crit.FromBlock.Int64()
+		}
+		end := rpctypes.EthLatestBlockNumber.Int64()
+		if crit.ToBlock != nil {
+			end = crit.ToBlock.Int64()
+		}
+		f = NewRangeFilter(api.logger, api.backend, begin, end, crit.Addresses, crit.Topics)
+	}
+	return f.Logs(ctx, int(api.backend.RPCLogsCap()), int64(api.backend.RPCBlockRangeCap()))
+}
+
+// checkFilterCap returns an error if the filters cap is reached. It must be called with the filters lock held.
+func (api *PublicFilterAPI) checkFilterCap() error {
+	filterCap := api.backend.RPCFilterCap()
+	if filterCap > 0 && len(api.filters) >= int(filterCap) {
+		return errors.New("too many filters")
+	}
+	return nil
+}
+
+// checkBlockRange returns an error if the from block is after the to block
+func checkBlockRange(crit FilterCriteria) error {
+	if crit.FromBlock == nil || crit.ToBlock == nil {
+		return nil
+	}
+	from, to := crit.FromBlock.Int64(), crit.ToBlock.Int64()
+	if from >= 0 && to >= 0 && from > to {
+		return errors.New("invalid block range: fromBlock is after toBlock")
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package filters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/rpc/backend"
+	rpctypes "github.com/loredanacirstea/wasmx/x/wasmx/rpc/types"
+)
+
+// Type determines the kind of filter or subscription.
+type Type byte
+
+const (
+	// UnknownSubscription indicates an unknown subscription type
+	UnknownSubscription Type = iota
+	// LogsSubscription queries for new logs
+	LogsSubscription
+	// BlocksSubscription queries for new block headers
+	BlocksSubscription
+)
+
+const (
+	// subscriberName is the name of the event bus subscriber for all filters
+	subscriberName = "eth-filters"
+	// eventsCapacity is the buffer size of the new block events channel
+	eventsCapacity = 100
+	// subscriptionCapacity is the buffer size of each subscription channel
+	subscriptionCapacity = 100
+)
+
+var newBlockQuery = tmtypes.QueryForEvent(tmtypes.EventNewBlock).String()
+
+// Header is a new block header, as sent to the subscribers
+type Header struct {
+	Hash common.Hash
+	Data map[string]interface{}
+}
+
+// Subscription is created by the EventSystem for each filter or websocket subscription
+type Subscription struct {
+	id        rpc.ID
+	typ       Type
+	created   time.Time
+	logsCrit  FilterCriteria
+	logs      chan []*ethtypes.Log
+	headers   chan *Header
+	es        *EventSystem
+	unsubOnce sync.Once
+	unsubbed  chan struct{}
+}
+
+// ID returns the subscription ID
+func (s *Subscription) ID() rpc.ID {
+	return s.id
+}
+
+// Logs returns the channel receiving the matching logs of each new block
+func (s *Subscription) Logs() <-chan []*ethtypes.Log {
+	return s.logs
+}
+
+// Headers returns the channel receiving the new block headers
+func (s *Subscription) Headers() <-chan *Header {
+	return s.headers
+}
+
+// Unsubscribed is closed when the subscription is removed
+func (s *Subscription) Unsubscribed() <-chan struct{} {
+	return s.unsubbed
+}
+
+// Unsubscribe removes the subscription from the event system.
+// It is safe to call it multiple times.
+func (s *Subscription) Unsubscribe() {
+	s.unsubOnce.Do(func() {
+		s.es.uninstall(s)
+		close(s.unsubbed)
+	})
+}
+
+// EventSystem creates subscriptions, processes the node's new block events and
+// broadcasts the headers and logs to the subscriptions matching their criteria.
+// It subscribes to the node's event bus only while it has subscriptions.
+type EventSystem struct {
+	logger  log.Logger
+	ctx     context.Context
+	client  rpcclient.EventsClient
+	backend backend.EVMBackend
+
+	mtx  sync.Mutex
+	subs map[rpc.ID]*Subscription
+	// eventCh receives the new block events; nil when not subscribed to the event bus
+	eventCh <-chan tmrpctypes.ResultEvent
+}
+
+// NewEventSystem creates a new manager that listens for the node's new block events
+// and forwards them to the subscriptions.
+func NewEventSystem(logger log.Logger, ctx context.Context, client rpcclient.EventsClient, backend backend.EVMBackend) *EventSystem {
+	return &EventSystem{
+		logger:  logger,
+		ctx:     ctx,
+		client:  client,
+		backend: backend,
+		subs:    make(map[rpc.ID]*Subscription),
+	}
+}
+
+// SubscribeLogs creates a subscription that receives the logs of each new block
+// matching the given criteria.
+func (es *EventSystem) SubscribeLogs(crit FilterCriteria) (*Subscription, error) {
+	return es.subscribe(&Subscription{
+		id:       rpc.NewID(),
+		typ:      LogsSubscription,
+		created:  time.Now().UTC(),
+		logsCrit: crit,
+		logs:     make(chan []*ethtypes.Log, subscriptionCapacity),
+	})
+}
+
+// SubscribeNewHeads subscribes to new block headers events.
+func (es *EventSystem) SubscribeNewHeads() (*Subscription, error) {
+	return es.subscribe(&Subscription{
+		id:      rpc.NewID(),
+		typ:     BlocksSubscription,
+		created: time.Now().UTC(),
+		headers: make(chan *Header, subscriptionCapacity),
+	})
+}
+
+func (es *EventSystem) subscribe(sub *Subscription) (*Subscription, error) {
+	es.mtx.Lock()
+	defer es.mtx.Unlock()
+
+	if es.client == nil {
+		return nil, fmt.Errorf("event subscriptions are not supported by the node client")
+	}
+	if es.eventCh == nil {
+		eventCh, err := es.client.Subscribe(es.ctx, subscriberName, newBlockQuery, eventsCapacity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to subscribe to new blocks: %w", err)
+		}
+		es.eventCh = eventCh
+		go es.eventLoop(eventCh)
+	}
+
+	sub.es = es
+	sub.unsubbed = make(chan struct{})
+	es.subs[sub.id] = sub
+	return sub, nil
+}
+
+// uninstall removes the subscription and unsubscribes from the event bus
+// when there are no subscriptions left, so the node stops publishing events.
+func (es *EventSystem) uninstall(sub *Subscription) {
+	es.mtx.Lock()
+	defer es.mtx.Unlock()
+
+	delete(es.subs, sub.id)
+	if len(es.subs) > 0 || es.eventCh == nil {
+		return
+	}
+	es.eventCh = nil
+	if err := es.client.UnsubscribeAll(es.ctx, subscriberName); err != nil {
+		es.logger.Debug("failed to unsubscribe from new blocks", "error", err.Error())
+	}
+}
+
+// eventLoop reads the new block events until the event bus subscription is canceled
+func (es *EventSystem) eventLoop(eventCh <-chan tmrpctypes.ResultEvent) {
+	for ev := range eventCh {
+		data, ok := ev.Data.(tmtypes.EventDataNewBlock)
+		if !ok || data.Block == nil {
+			es.logger.Debug("event data type mismatch", "type", fmt.Sprintf("%T", ev.Data))
+			continue
+		}
+		es.handleNewBlock(data)
+	}
+
+	// the subscription was canceled by the event bus
+	es.mtx.Lock()
+	defer es.mtx.Unlock()
+	if es.eventCh == eventCh {
+		es.eventCh = nil
+	}
+}
+
+func (es *EventSystem) handleNewBlock(data tmtypes.EventDataNewBlock) {
+	block := data.Block
+	blockRes := &tmrpctypes.ResultBlockResults{
+		Height:                block.Height,
+		TxsResults:            data.ResultFinalizeBlock.TxResults,
+		FinalizeBlockEvents:   data.ResultFinalizeBlock.Events,
+		ValidatorUpdates:      data.ResultFinalizeBlock.ValidatorUpdates,
+		ConsensusParamUpdates: data.ResultFinalizeBlock.ConsensusParamUpdates,
+		AppHash:               data.ResultFinalizeBlock.AppHash,
+	}
+	blockLogs, err := es.backend.GetLogsFromBlock(block, blockRes)
+	if err != nil {
+		es.logger.Error("failed to parse block logs", "height", block.Height, "error", err.Error())
+		return
+	}
+	var logs []*ethtypes.Log
+	for _, txLogs := range blockLogs {
+		logs = append(logs, txLogs...)
+	}
+
+	baseFee, err := es.backend.BaseFee(blockRes)
+	if err != nil {
+		es.logger.Debug("failed to fetch base fee", "height", block.Height, "error", err.Error())
+	}
+	header := &Header{
+		Hash: common.BytesToHash(block.Hash()),
+		Data: rpctypes.FormatHeader(block.Header, rpctypes.BloomFromLogs(blockLogs), baseFee),
+	}
+
+	es.mtx.Lock()
+	defer es.mtx.Unlock()
+	for _, sub := range es.subs {
+		switch sub.typ {
+		case BlocksSubscription:
+			select {
+			case sub.headers <- header:
+			default:
+				es.logger.Debug("dropped new header, subscription channel is full", "id", sub.id)
+			}
+		case LogsSubscription:
+			matched := FilterLogs(logs, sub.logsCrit.FromBlock, sub.logsCrit.ToBlock, sub.logsCrit.Addresses, sub.logsCrit.Topics)
+			if len(matched) == 0 {
+				continue
+			}
+			select {
+			case sub.logs <- matched:
+			default:
+				es.logger.Debug("dropped new logs, subscription channel is full", "id", sub.id)
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package filters
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"cosmossdk.io/log"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/rpc/backend"
+	rpctypes "github.com/loredanacirstea/wasmx/x/wasmx/rpc/types"
+)
+
+// Filter can be used to retrieve and filter logs.
+type Filter struct {
+	logger   log.Logger
+	backend  backend.EVMBackend
+	criteria FilterCriteria
+}
+
+// NewBlockFilter creates a new filter which directly inspects the contents of
+// a block to figure out whether it is interesting or not.
+func NewBlockFilter(logger log.Logger, backend backend.EVMBackend, criteria FilterCriteria) *Filter {
+	return newFilter(logger, backend, criteria)
+}
+
+// NewRangeFilter creates a new filter which uses the block blooms to figure out whether
+// a particular block is interesting or not.
+func NewRangeFilter(logger log.Logger, backend backend.EVMBackend, begin, end int64, addresses []common.Address, topics [][]common.Hash) *Filter {
+	criteria := FilterCriteria{
+		FromBlock: big.NewInt(begin),
+		ToBlock:   big.NewInt(end),
+		Addresses: addresses,
+		Topics:    topics,
+	}
+	return newFilter(logger, backend, criteria)
+}
+
+// newFilter returns a new Filter
+func newFilter(logger log.Logger, backend backend.EVMBackend, criteria FilterCriteria) *Filter {
+	return &Filter{
+		logger:   logger,
+		backend:  backend,
+		criteria: criteria,
+	}
+}
+
+// Logs searches the blockchain for the log entries matching the filter criteria.
+// A logLimit or blockLimit of 0 means no limit.
+func (f *Filter) Logs(ctx context.Context, logLimit int, blockLimit int64) ([]*ethtypes.Log, error) {
+	// If we're doing singleton block filtering, execute and return
+	if f.criteria.BlockHash != nil && *f.criteria.BlockHash != (common.Hash{}) {
+		resBlock, err := f.backend.TendermintBlockByHash(*f.criteria.BlockHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch header by hash %s: %w", f.criteria.BlockHash, err)
+		}
+		if resBlock == nil {
+			return nil, fmt.Errorf("unknown block %s", f.criteria.BlockHash)
+		}
+		return f.blockLogs(resBlock.Block.Height)
+	}
+
+	head, err := f.backend.BlockNumber()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest block number: %w", err)
+	}
+	latest := int64(head)
+
+	from := latest
+	if f.criteria.FromBlock != nil && f.criteria.FromBlock.Int64() >= 0 {
+		from = f.criteria.FromBlock.Int64()
+	}
+	to := latest
+	if f.criteria.ToBlock != nil && f.criteria.ToBlock.Int64() >= 0 {
+		to = f.criteria.ToBlock.Int64()
+	}
+	if from == int64(rpctypes.EthEarliestBlockNumber) {
+		from = 1
+	}
+	if to > latest {
+		to = latest
+	}
+	if from > to {
+		return nil, fmt.Errorf("invalid block range: from block %d is after to block %d", from, to)
+	}
+	if blockLimit > 0 && to-from > blockLimit {
+		return nil, fmt.Errorf("maximum [from, to] blocks distance: %d", blockLimit)
+	}
+
+	logs := []*ethtypes.Log{}
+	for height := from; height <= to; height++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		filtered, err := f.blockLogs(height)
+		if err != nil {
+			return nil, err
+		}
+		if logLimit > 0 && len(logs)+len(filtered) > logLimit {
+			return nil, fmt.Errorf("query returned more than %d results", logLimit)
+		}
+		logs = append(logs, filtered...)
+	}
+	return logs, nil
+}
+
+// blockLogs returns the logs matching the filter criteria of a block.
+// The block is skipped if its bloom filter does not match the criteria.
+func (f *Filter) blockLogs(height int64) ([]*ethtypes.Log, error) {
+	blockRes, err := f.backend.TendermintBlockResultByNumber(&height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block result from Tendermint %d: %w", height, err)
+	}
+	bloom, err := f.backend.BlockBloom(blockRes)
+	if err != nil {
+		return nil, err
+	}
+	if !BloomMatches(bloom, f.criteria.Addresses, f.criteria.Topics) {
+		return []*ethtypes.Log{}, nil
+	}
+
+	resBlock, err := f.backend.TendermintBlockByNumber(rpctypes.BlockNumber(height))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block from Tendermint %d: %w", height, err)
+	}
+	if resBlock == nil {
+		return nil, fmt.Errorf("block not found for height %d", height)
+	}
+	blockLogs, err := f.backend.GetLogsFromBlock(resBlock.Block, blockRes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch logs block number %d: %w", height, err)
+	}
+	var unfiltered []*ethtypes.Log
+	for _, logs := range blockLogs {
+		unfiltered = append(unfiltered, logs...)
+	}
+	return FilterLogs(unfiltered, nil, nil, f.criteria.Addresses, f.criteria.Topics), nil
+}
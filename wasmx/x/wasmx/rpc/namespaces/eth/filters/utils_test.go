@@ -0,0 +1,65 @@
+package filters_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/rpc/namespaces/eth/filters"
+	rpctypes "github.com/loredanacirstea/wasmx/x/wasmx/rpc/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	addr1  = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	addr2  = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	topic1 = common.HexToHash("0x01")
+	topic2 = common.HexToHash("0x02")
+	topic3 = common.HexToHash("0x03")
+)
+
+func TestFilterCriteriaUnmarshal(t *testing.T) {
+	var crit filters.FilterCriteria
+	err := json.Unmarshal([]byte(`{"fromBlock":"0x2","toBlock":"latest","address":"0x1111111111111111111111111111111111111111","topics":[null,["0x0000000000000000000000000000000000000000000000000000000000000002","0x0000000000000000000000000000000000000000000000000000000000000003"]]}`), &crit)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), crit.FromBlock)
+	require.Equal(t, big.NewInt(rpctypes.EthLatestBlockNumber.Int64()), crit.ToBlock)
+	require.Equal(t, []common.Address{addr1}, crit.Addresses)
+	require.Equal(t, [][]common.Hash{nil, {topic2, topic3}}, crit.Topics)
+
+	err = json.Unmarshal([]byte(`{"blockHash":"0x0000000000000000000000000000000000000000000000000000000000000001","fromBlock":"0x1"}`), &crit)
+	require.Error(t, err)
+
+	err = json.Unmarshal([]byte(`{"topics":[null,null,null,null,null]}`), &crit)
+	require.Error(t, err)
+}
+
+func TestFilterLogs(t *testing.T) {
+	logs := []*ethtypes.Log{
+		{Address: addr1, Topics: []common.Hash{topic1, topic2}, BlockNumber: 1},
+		{Address: addr2, Topics: []common.Hash{topic1, topic3}, BlockNumber: 2},
+		{Address: addr1, Topics: []common.Hash{topic2}, BlockNumber: 3},
+	}
+
+	require.Len(t, filters.FilterLogs(logs, nil, nil, nil, nil), 3)
+	require.Equal(t, []*ethtypes.Log{logs[0], logs[2]}, filters.FilterLogs(logs, nil, nil, []common.Address{addr1}, nil))
+	require.Equal(t, []*ethtypes.Log{logs[1]}, filters.FilterLogs(logs, big.NewInt(2), big.NewInt(3), nil, [][]common.Hash{{topic1}}))
+	require.Equal(t, []*ethtypes.Log{logs[0], logs[1]}, filters.FilterLogs(logs, nil, nil, nil, [][]common.Hash{nil, {topic2, topic3}}))
+	require.Equal(t, []*ethtypes.Log{logs[0], logs[1]}, filters.FilterLogs(logs, big.NewInt(-1), big.NewInt(-1), nil, [][]common.Hash{{}, {}}))
+}
+
+func TestBloomMatches(t *testing.T) {
+	bloom := rpctypes.BloomFromLogs([][]*ethtypes.Log{
+		{{Address: addr1, Topics: []common.Hash{topic1}}},
+	})
+
+	require.True(t, filters.BloomMatches(bloom, nil, nil))
+	require.True(t, filters.BloomMatches(bloom, []common.Address{addr1, addr2}, [][]common.Hash{{topic1}}))
+	require.False(t, filters.BloomMatches(bloom, []common.Address{addr2}, nil))
+	require.False(t, filters.BloomMatches(bloom, nil, [][]common.Hash{{topic2}}))
+	require.True(t, filters.BloomMatches(ethtypes.Bloom{}, nil, [][]common.Hash{nil}))
+}
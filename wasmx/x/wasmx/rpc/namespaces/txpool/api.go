@@ -0,0 +1,98 @@
+package txpool
+
+import (
+	"fmt"
+
+	"cosmossdk.io/log"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/rpc/backend"
+	rpctypes "github.com/loredanacirstea/wasmx/x/wasmx/rpc/types"
+)
+
+// PublicAPI offers an API for the transaction pool. It only operates on data that is non-confidential.
+// The pool is the consensus contract's mempool: all transactions are pending, none are queued.
+type PublicAPI struct {
+	logger  log.Logger
+	backend backend.EVMBackend
+}
+
+// NewPublicAPI creates a new tx pool service that gives information about the transaction pool.
+func NewPublicAPI(logger log.Logger, backend backend.EVMBackend) *PublicAPI {
+	return &PublicAPI{
+		logger:  logger.With("module", "txpool"),
+		backend: backend,
+	}
+}
+
+// Content returns the transactions contained within the transaction pool,
+// grouped by sender address and nonce
+func (api *PublicAPI) Content() (map[string]map[string]map[string]*rpctypes.RPCTransaction, error) {
+	api.logger.Debug("txpool_content")
+	txs, err := api.backend.PendingRPCTransactions()
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[string]map[string]*rpctypes.RPCTransaction)
+	for _, tx := range txs {
+		account := tx.From.Hex()
+		if _, ok := pending[account]; !ok {
+			pending[account] = make(map[string]*rpctypes.RPCTransaction)
+		}
+		pending[account][fmt.Sprintf("%d", tx.Nonce)] = tx
+	}
+	return map[string]map[string]map[string]*rpctypes.RPCTransaction{
+		"pending": pending,
+		"queued":  make(map[string]map[string]*rpctypes.RPCTransaction),
+	}, nil
+}
+
+// Inspect returns the content of the transaction pool and flattens it into an easily inspectable list
+func (api *PublicAPI) Inspect() (map[string]map[string]map[string]string, error) {
+	api.logger.Debug("txpool_inspect")
+	txs, err := api.backend.PendingRPCTransactions()
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[string]map[string]string)
+	for _, tx := range txs {
+		account := tx.From.Hex()
+		if _, ok := pending[account]; !ok {
+			pending[account] = make(map[string]string)
+		}
+		pending[account][fmt.Sprintf("%d", tx.Nonce)] = formatTx(tx)
+	}
+	return map[string]map[string]map[string]string{
+		"pending": pending,
+		"queued":  make(map[string]map[string]string),
+	}, nil
+}
+
+// Status returns the number of pending and queued transaction in the pool.
+func (api *PublicAPI) Status() (map[string]hexutil.Uint, error) {
+	api.logger.Debug("txpool_status")
+	txs, err := api.backend.PendingRPCTransactions()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]hexutil.Uint{
+		"pending": hexutil.Uint(len(txs)),
+		"queued":  hexutil.Uint(0),
+	}, nil
+}
+
+// formatTx has the same format as geth's txpool_inspect
+func formatTx(tx *rpctypes.RPCTransaction) string {
+	if tx.To == nil {
+		return fmt.Sprintf("contract creation: %s wei + %d gas × %s wei", bigString(tx.Value), tx.Gas, bigString(tx.GasPrice))
+	}
+	return fmt.Sprintf("%s: %s wei + %d gas × %s wei", tx.To.Hex(), bigString(tx.Value), tx.Gas, bigString(tx.GasPrice))
+}
+
+func bigString(value *hexutil.Big) string {
+	if value == nil {
+		return "0"
+	}
+	return value.ToInt().String()
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FeeHistoryResult is the eth_feeHistory result
+type FeeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
+// TxGasAndReward is the gas used and the effective gas tip paid by an included transaction
+type TxGasAndReward struct {
+	GasUsed uint64
+	Reward  *big.Int
+}
+
+// RewardPercentiles returns the gas tips at the given ascending percentiles,
+// weighted by the gas used of each transaction, like geth does for eth_feeHistory.
+// It returns zero rewards for a block without transactions.
+func RewardPercentiles(txs []TxGasAndReward, percentiles []float64, gasUsed uint64) []*big.Int {
+	rewards := make([]*big.Int, len(percentiles))
+	if len(txs) == 0 {
+		for i := range rewards {
+			rewards[i] = new(big.Int)
+		}
+		return rewards
+	}
+
+	sorted := make([]TxGasAndReward, len(txs))
+	copy(sorted, txs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Reward.Cmp(sorted[j].Reward) < 0
+	})
+
+	txIndex := 0
+	sumGasUsed := sorted[0].GasUsed
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(gasUsed) * p / 100)
+		for sumGasUsed < thresholdGasUsed && txIndex < len(sorted)-1 {
+			txIndex++
+			sumGasUsed += sorted[txIndex].GasUsed
+		}
+		rewards[i] = new(big.Int).Set(sorted[txIndex].Reward)
+	}
+	return rewards
+}
@@ -0,0 +1,32 @@
+package types_test
+
+import (
+	"math/big"
+	"testing"
+
+	rpctypes "github.com/loredanacirstea/wasmx/x/wasmx/rpc/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewardPercentiles(t *testing.T) {
+	rewards := rpctypes.RewardPercentiles(nil, []float64{10, 50}, 0)
+	require.Equal(t, []*big.Int{big.NewInt(0), big.NewInt(0)}, rewards)
+
+	txs := []rpctypes.TxGasAndReward{
+		{GasUsed: 50000, Reward: big.NewInt(300)},
+		{GasUsed: 21000, Reward: big.NewInt(100)},
+		{GasUsed: 29000, Reward: big.NewInt(200)},
+	}
+	rewards = rpctypes.RewardPercentiles(txs, []float64{0, 21, 22, 50, 51, 100}, 100000)
+	require.Equal(t, []*big.Int{
+		big.NewInt(100),
+		big.NewInt(100),
+		big.NewInt(200),
+		big.NewInt(200),
+		big.NewInt(300),
+		big.NewInt(300),
+	}, rewards)
+	// the transactions are not reordered
+	require.Equal(t, big.NewInt(300), txs[0].Reward)
+}
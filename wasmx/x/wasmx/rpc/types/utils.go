@@ -73,6 +73,48 @@ func EthHeaderFromTendermint(header tmtypes.Header, bloom ethtypes.Bloom, baseFe
 	}
 }
 
+// FormatHeader returns the JSON-RPC representation of a tendermint header,
+// as sent to the newHeads subscribers. Like FormatBlock, the hash is the tendermint block hash.
+func FormatHeader(header tmtypes.Header, bloom ethtypes.Bloom, baseFee *big.Int) map[string]interface{} {
+	ethHeader := EthHeaderFromTendermint(header, bloom, baseFee)
+	result := map[string]interface{}{
+		"number":           (*hexutil.Big)(ethHeader.Number),
+		"hash":             hexutil.Bytes(header.Hash()),
+		"parentHash":       ethHeader.ParentHash,
+		"nonce":            ethHeader.Nonce,
+		"sha3Uncles":       ethHeader.UncleHash,
+		"logsBloom":        ethHeader.Bloom,
+		"stateRoot":        ethHeader.Root,
+		"miner":            ethHeader.Coinbase,
+		"mixHash":          ethHeader.MixDigest,
+		"difficulty":       (*hexutil.Big)(ethHeader.Difficulty),
+		"extraData":        hexutil.Bytes(ethHeader.Extra),
+		"gasLimit":         hexutil.Uint64(ethHeader.GasLimit),
+		"gasUsed":          hexutil.Uint64(ethHeader.GasUsed),
+		"timestamp":        hexutil.Uint64(ethHeader.Time),
+		"transactionsRoot": ethHeader.TxHash,
+		"receiptsRoot":     ethHeader.ReceiptHash,
+	}
+	if baseFee != nil {
+		result["baseFeePerGas"] = (*hexutil.Big)(baseFee)
+	}
+	return result
+}
+
+// BloomFromLogs returns the bloom filter of all the logs in a block
+func BloomFromLogs(blockLogs [][]*ethtypes.Log) ethtypes.Bloom {
+	var bloom ethtypes.Bloom
+	for _, logs := range blockLogs {
+		for _, log := range logs {
+			bloom.Add(log.Address.Bytes())
+			for _, topic := range log.Topics {
+				bloom.Add(topic[:])
+			}
+		}
+	}
+	return bloom
+}
+
 // TODO
 // BlockMaxGasFromConsensusParams returns the gas limit for the current block from the chain consensus params.
 func BlockMaxGasFromConsensusParams(goCtx context.Context, clientCtx client.Context, blockHeight int64) (int64, error) {
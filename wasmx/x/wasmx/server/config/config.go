@@ -3,7 +3,14 @@ package config
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"time"
+
+	"github.com/spf13/cast"
+
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
 )
 
 const (
@@ -28,6 +35,30 @@ const (
 
 	// DefaultMaxOpenConnections represents the amount of open connections (unlimited = 0)
 	DefaultMaxOpenConnections = 0
+
+	// DefaultFilterCap is the default cap for total number of filters that can be created
+	DefaultFilterCap int32 = 200
+
+	// DefaultFilterTimeout is the default time after which an unpolled filter is uninstalled
+	DefaultFilterTimeout = 5 * time.Minute
+
+	// DefaultLogsCap is the default cap of results returned from single 'eth_getLogs' query
+	DefaultLogsCap int32 = 10000
+
+	// DefaultBlockRangeCap is the default cap of block range allowed for 'eth_getLogs' query
+	DefaultBlockRangeCap int32 = 10000
+
+	// DefaultFeeHistoryCap is the default cap of blocks that can be queried by 'eth_feeHistory'
+	DefaultFeeHistoryCap int32 = 100
+
+	// DefaultModuleCacheSize is the default number of compiled wasm modules kept in memory
+	DefaultModuleCacheSize = 256
+
+	// DefaultCompilationCacheDir is the default directory of the compiled code cache, relative to the node home
+	DefaultCompilationCacheDir = "data/wasm-compilation-cache"
+
+	// DefaultQueryTimeout is the default wall-clock deadline of a contract query
+	DefaultQueryTimeout = 10 * time.Second
 )
 
 // JsonRpcConfig defines the application configuration values for JSON RPC module.
@@ -49,16 +80,37 @@ type JsonRpcConfig struct {
 	// MaxOpenConnections sets the maximum number of simultaneous connections
 	// for the server listener.
 	MaxOpenConnections int `mapstructure:"max-open-connections"`
+	// FilterCap is the global cap for total number of filters that can be created.
+	FilterCap int32 `mapstructure:"filter-cap"`
+	// FilterTimeout is the time after which a filter that was not polled is uninstalled.
+	FilterTimeout time.Duration `mapstructure:"filter-timeout"`
+	// LogsCap defines the max number of results can be returned from single `eth_getLogs` query.
+	LogsCap int32 `mapstructure:"logs-cap"`
+	// BlockRangeCap defines the max block range allowed for `eth_getLogs` query.
+	BlockRangeCap int32 `mapstructure:"block-range-cap"`
+	// FeeHistoryCap defines the max number of blocks that can be queried by `eth_feeHistory`.
+	FeeHistoryCap int32 `mapstructure:"fee-history-cap"`
+}
+
+// WasmVmConfig defines the configuration values of the wasm runtime.
+type WasmVmConfig struct {
+	// ModuleCacheSize is the max number of compiled modules kept in memory (0 disables the cache).
+	ModuleCacheSize int `mapstructure:"module-cache-size"`
+	// CompilationCacheDir is where compiled code is persisted, so restarts are warm.
+	// Relative paths are resolved from the node home. Empty keeps compiled code only in memory.
+	CompilationCacheDir string `mapstructure:"compilation-cache-dir"`
+	// QueryTimeout is the wall-clock deadline of contract queries and non-consensus calls (0 disables it).
+	QueryTimeout time.Duration `mapstructure:"query-timeout"`
 }
 
 // GetDefaultAPINamespaces returns the default list of JSON-RPC namespaces that should be enabled
 func GetDefaultAPINamespaces() []string {
-	return []string{"eth", "net"}
+	return []string{"eth", "net", "txpool"}
 }
 
 // GetAPINamespaces returns the all the available JSON-RPC API namespaces.
 func GetAPINamespaces() []string {
-	return []string{"eth", "net"}
+	return []string{"eth", "net", "txpool", "debug"}
 	// return []string{"web3", "eth", "personal", "net", "txpool", "debug", "miner"}
 }
 
@@ -74,6 +126,58 @@ func DefaultJsonRpcConfigConfig() *JsonRpcConfig {
 		HTTPIdleTimeout:     DefaultHTTPIdleTimeout,
 		AllowUnprotectedTxs: DefaultAllowUnprotectedTxs,
 		MaxOpenConnections:  DefaultMaxOpenConnections,
+		FilterCap:           DefaultFilterCap,
+		FilterTimeout:       DefaultFilterTimeout,
+		LogsCap:             DefaultLogsCap,
+		BlockRangeCap:       DefaultBlockRangeCap,
+		FeeHistoryCap:       DefaultFeeHistoryCap,
+	}
+}
+
+// DefaultWasmVmConfig returns the default wasm runtime configuration
+func DefaultWasmVmConfig() *WasmVmConfig {
+	return &WasmVmConfig{
+		ModuleCacheSize:     DefaultModuleCacheSize,
+		CompilationCacheDir: DefaultCompilationCacheDir,
+		QueryTimeout:        DefaultQueryTimeout,
+	}
+}
+
+// GetWasmVmConfig reads the wasm runtime configuration from the app options, using defaults for unset values
+func GetWasmVmConfig(appOpts servertypes.AppOptions) WasmVmConfig {
+	cfg := *DefaultWasmVmConfig()
+	if v := appOpts.Get("wasm-vm.module-cache-size"); v != nil {
+		cfg.ModuleCacheSize = cast.ToInt(v)
+	}
+	if v := appOpts.Get("wasm-vm.compilation-cache-dir"); v != nil {
+		cfg.CompilationCacheDir = cast.ToString(v)
+	}
+	if v := appOpts.Get("wasm-vm.query-timeout"); v != nil {
+		cfg.QueryTimeout = cast.ToDuration(v)
+	}
+	return cfg
+}
+
+// Validate returns an error if the wasm runtime configuration fields are invalid.
+func (c WasmVmConfig) Validate() error {
+	if c.ModuleCacheSize < 0 {
+		return errors.New("wasm-vm module cache size cannot be negative")
+	}
+	if c.QueryTimeout < 0 {
+		return errors.New("wasm-vm query timeout cannot be negative")
+	}
+	return nil
+}
+
+// RuntimeConfig returns the wasm runtime configuration, with paths resolved from the node home
+func (c WasmVmConfig) RuntimeConfig(homeDir string) memc.WasmRuntimeConfig {
+	cacheDir := c.CompilationCacheDir
+	if cacheDir != "" && !filepath.IsAbs(cacheDir) {
+		cacheDir = filepath.Join(homeDir, cacheDir)
+	}
+	return memc.WasmRuntimeConfig{
+		ModuleCacheSize:     c.ModuleCacheSize,
+		CompilationCacheDir: cacheDir,
 	}
 }
 
@@ -95,6 +199,26 @@ func (c JsonRpcConfig) Validate() error {
 		return errors.New("JSON-RPC HTTP idle timeout duration cannot be negative")
 	}
 
+	if c.FilterCap < 0 {
+		return errors.New("JSON-RPC filter-cap cannot be negative")
+	}
+
+	if c.FilterTimeout < 0 {
+		return errors.New("JSON-RPC filter timeout duration cannot be negative")
+	}
+
+	if c.LogsCap < 0 {
+		return errors.New("JSON-RPC logs cap cannot be negative")
+	}
+
+	if c.BlockRangeCap < 0 {
+		return errors.New("JSON-RPC block range cap cannot be negative")
+	}
+
+	if c.FeeHistoryCap < 0 {
+		return errors.New("JSON-RPC fee history cap cannot be negative")
+	}
+
 	// check for duplicates
 	seenAPIs := make(map[string]bool)
 	for _, api := range c.API {
@@ -38,4 +38,41 @@ allow-unprotected-txs = {{ .JsonRpc.AllowUnprotectedTxs }}
 # for the server listener.
 max-open-connections = {{ .JsonRpc.MaxOpenConnections }}
 
+# FilterCap sets the global cap for total number of filters that can be created (unlimited = 0)
+filter-cap = {{ .JsonRpc.FilterCap }}
+
+# FilterTimeout is the time after which a filter that was not polled is uninstalled.
+filter-timeout = "{{ .JsonRpc.FilterTimeout }}"
+
+# LogsCap defines the max number of results can be returned from single 'eth_getLogs' query (unlimited = 0).
+logs-cap = {{ .JsonRpc.LogsCap }}
+
+# BlockRangeCap defines the max block range allowed for 'eth_getLogs' query (unlimited = 0).
+block-range-cap = {{ .JsonRpc.BlockRangeCap }}
+
+# FeeHistoryCap defines the max number of blocks that can be queried by 'eth_feeHistory' (unlimited = 0).
+fee-history-cap = {{ .JsonRpc.FeeHistoryCap }}
+
+`
+
+// DefaultWasmVmConfigTemplate defines the configuration template for the wasm runtime configuration
+const DefaultWasmVmConfigTemplate = `
+###############################################################################
+###                             Wasm VM Configuration                       ###
+###############################################################################
+
+[wasm-vm]
+
+# ModuleCacheSize is the max number of compiled wasm modules kept in memory,
+# least recently used modules are evicted first (disabled = 0).
+module-cache-size = {{ .WasmVm.ModuleCacheSize }}
+
+# CompilationCacheDir is where compiled code is persisted, so restarts are warm.
+# Relative paths are resolved from the node home. Empty keeps compiled code only in memory.
+compilation-cache-dir = "{{ .WasmVm.CompilationCacheDir }}"
+
+# QueryTimeout is the wall-clock deadline of contract queries, eth_call, eth_estimateGas
+# and websrv requests (disabled = 0).
+query-timeout = "{{ .WasmVm.QueryTimeout }}"
+
 `
@@ -11,4 +11,9 @@ const (
 	JsonRpcHTTPIdleTimeout     = "json-rpc.http-idle-timeout"
 	JsonRpcAllowUnprotectedTxs = "json-rpc.allow-unprotected-txs"
 	JsonRpcMaxOpenConnections  = "json-rpc.max-open-connections"
+	JsonRpcFilterCap           = "json-rpc.filter-cap"
+	JsonRpcFilterTimeout       = "json-rpc.filter-timeout"
+	JsonRpcLogsCap             = "json-rpc.logs-cap"
+	JsonRpcBlockRangeCap       = "json-rpc.block-range-cap"
+	JsonRpcFeeHistoryCap       = "json-rpc.fee-history-cap"
 )
@@ -99,7 +99,33 @@ func StartJsonRpc(
 		return nil, nil, err
 	}
 
-	errCh := make(chan error, 1)
+	// the websocket server serves the same APIs, including the eth_subscribe subscriptions
+	var wsSrv *http.Server
+	if cfg.WsAddress != "" {
+		wsSrv = &http.Server{
+			Addr:              cfg.WsAddress,
+			Handler:           rpcServer.WebsocketHandler([]string{"*"}),
+			ReadHeaderTimeout: cfg.HTTPTimeout,
+			IdleTimeout:       cfg.HTTPIdleTimeout,
+		}
+	}
+
+	errCh := make(chan error, 2)
+	if wsSrv != nil {
+		wsLn, err := Listen(wsSrv.Addr, &cfg)
+		if err != nil {
+			ln.Close()
+			return nil, nil, err
+		}
+		go func() {
+			svrCtx.Logger.Info("Starting JSON-RPC websocket server", "address", cfg.WsAddress)
+			if err := wsSrv.Serve(wsLn); err != nil && err != http.ErrServerClosed {
+				svrCtx.Logger.Error("failed to start JSON-RPC websocket server", "error", err.Error())
+				errCh <- err
+			}
+		}()
+	}
+
 	go func() {
 		svrCtx.Logger.Info("Starting JSON-RPC server", "address", cfg.Address)
 		if err := httpSrv.Serve(ln); err != nil {
@@ -119,10 +145,17 @@ func StartJsonRpc(
 		// gracefully stop the JSON-RPC server.
 		logger.Info("stopping JSON-RPC server...", "address", cfg.Address)
 		httpSrv.Close()
-		close(errCh)
+		if wsSrv != nil {
+			wsSrv.Close()
+		}
+		rpcServer.Stop()
 		return httpSrv, httpSrvDone, nil
 	case err := <-errCh:
 		svrCtx.Logger.Error("failed to boot JSON-RPC server", "error", err.Error())
+		httpSrv.Close()
+		if wsSrv != nil {
+			wsSrv.Close()
+		}
 		return nil, nil, err
 	}
 }
@@ -0,0 +1,176 @@
+package tracers
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+)
+
+type callLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+	// Position of the log relative to the frame's sub-calls
+	Position hexutil.Uint `json:"position"`
+}
+
+type callFrame struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	To           *common.Address `json:"to,omitempty"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []callFrame     `json:"calls,omitempty"`
+	Logs         []callLog       `json:"logs,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+}
+
+func (f *callFrame) processOutput(output []byte, err error) {
+	if len(output) > 0 {
+		f.Output = common.CopyBytes(output)
+	}
+	if err == nil {
+		return
+	}
+	f.Error = err.Error()
+	if reason, unpackErr := abi.UnpackRevert(output); unpackErr == nil {
+		f.RevertReason = reason
+	}
+}
+
+type callTracerConfig struct {
+	// OnlyTopCall does not trace the sub-calls
+	OnlyTopCall bool `json:"onlyTopCall"`
+	// WithLog includes the emitted logs in the call frames
+	WithLog bool `json:"withLog"`
+}
+
+// CallTracer records the call tree of an execution, with the same output as
+// the geth callTracer
+type CallTracer struct {
+	config    callTracerConfig
+	callstack []callFrame
+	root      *callFrame
+	system    systemCalls
+	// depth of the calls ignored because of OnlyTopCall
+	ignored int
+}
+
+var _ Tracer = (*CallTracer)(nil)
+
+func NewCallTracer(config json.RawMessage) (*CallTracer, error) {
+	var cfg callTracerConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	return &CallTracer{config: cfg}, nil
+}
+
+func (t *CallTracer) CaptureEnter(typ string, from sdk.AccAddress, to sdk.AccAddress, input []byte, gas uint64, value *big.Int) {
+	if t.system.enter(to) {
+		return
+	}
+	// we only trace the first top level call
+	if t.root != nil || t.ignored > 0 || (t.config.OnlyTopCall && len(t.callstack) > 0) {
+		t.ignored += 1
+		return
+	}
+	toAddr := types.EvmAddressFromAcc(to)
+	frame := callFrame{
+		Type:  typ,
+		From:  types.EvmAddressFromAcc(from),
+		To:    &toAddr,
+		Input: common.CopyBytes(input),
+		Gas:   hexutil.Uint64(gas),
+	}
+	if value != nil {
+		frame.Value = (*hexutil.Big)(new(big.Int).Set(value))
+	}
+	t.callstack = append(t.callstack, frame)
+}
+
+func (t *CallTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	if t.system.exit() {
+		return
+	}
+	if t.ignored > 0 {
+		t.ignored -= 1
+		return
+	}
+	size := len(t.callstack)
+	if size == 0 {
+		return
+	}
+	frame := t.callstack[size-1]
+	t.callstack = t.callstack[:size-1]
+	frame.GasUsed = hexutil.Uint64(gasUsed)
+	frame.processOutput(output, err)
+
+	if size == 1 {
+		t.root = &frame
+		return
+	}
+	parent := &t.callstack[size-2]
+	parent.Calls = append(parent.Calls, frame)
+}
+
+func (t *CallTracer) CaptureStorageRead(contract sdk.AccAddress, key []byte, value []byte) {}
+
+func (t *CallTracer) CaptureStorageWrite(contract sdk.AccAddress, key []byte, value []byte) {}
+
+func (t *CallTracer) CaptureLog(contract sdk.AccAddress, topics [][32]byte, data []byte) {
+	if !t.config.WithLog || t.ignored > 0 || t.system.skipped(contract) || len(t.callstack) == 0 {
+		return
+	}
+	frame := &t.callstack[len(t.callstack)-1]
+	log := callLog{
+		Address:  types.EvmAddressFromAcc(contract),
+		Topics:   make([]common.Hash, len(topics)),
+		Data:     common.CopyBytes(data),
+		Position: hexutil.Uint(len(frame.Calls)),
+	}
+	for i, topic := range topics {
+		log.Topics[i] = common.Hash(topic)
+	}
+	frame.Logs = append(frame.Logs, log)
+}
+
+// GetResult returns the call tree. Calls that did not end
+// (e.g. because the execution ran out of gas) are closed as failed.
+func (t *CallTracer) GetResult() (json.RawMessage, error) {
+	for len(t.callstack) > 0 {
+		t.CaptureExit(nil, 0, errors.New("execution aborted"))
+	}
+	if t.root == nil {
+		return nil, errors.New("no contract call was traced")
+	}
+	if t.config.WithLog {
+		clearFailedLogs(t.root, false)
+	}
+	return json.Marshal(t.root)
+}
+
+// clearFailedLogs removes the logs of the failed calls, as they were reverted
+func clearFailedLogs(frame *callFrame, parentFailed bool) {
+	failed := frame.Error != "" || parentFailed
+	if failed {
+		frame.Logs = nil
+	}
+	for i := range frame.Calls {
+		clearFailedLogs(&frame.Calls[i], failed)
+	}
+}
@@ -0,0 +1,182 @@
+package tracers
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+)
+
+// account is the prestateTracer account state.
+// Storage keys and values are hex encoded, as wasm contracts
+// are not limited to 32 bytes keys and values.
+type account struct {
+	Balance *hexutil.Big             `json:"balance,omitempty"`
+	Code    hexutil.Bytes            `json:"code,omitempty"`
+	Nonce   uint64                   `json:"nonce,omitempty"`
+	Storage map[string]hexutil.Bytes `json:"storage,omitempty"`
+}
+
+type prestateTracerConfig struct {
+	// DiffMode returns the state before and after the execution, only for the modified accounts
+	DiffMode bool `json:"diffMode"`
+}
+
+// PrestateTracer records the state of the accounts and storage keys touched by
+// an execution, with the same output as the geth prestateTracer
+type PrestateTracer struct {
+	config prestateTracerConfig
+	pre    StateReader
+	post   StateReader
+	// accounts in the order they were touched
+	addrs    []sdk.AccAddress
+	accounts map[common.Address]*account
+	// storage keys in the order they were touched
+	keys   map[common.Address][][]byte
+	system systemCalls
+}
+
+var _ Tracer = (*PrestateTracer)(nil)
+
+func NewPrestateTracer(config json.RawMessage, pre StateReader, post StateReader) (*PrestateTracer, error) {
+	var cfg prestateTracerConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	return &PrestateTracer{
+		config:   cfg,
+		pre:      pre,
+		post:     post,
+		accounts: map[common.Address]*account{},
+		keys:     map[common.Address][][]byte{},
+	}, nil
+}
+
+func (t *PrestateTracer) CaptureEnter(typ string, from sdk.AccAddress, to sdk.AccAddress, input []byte, gas uint64, value *big.Int) {
+	if t.system.enter(to) {
+		return
+	}
+	t.lookupAccount(from)
+	t.lookupAccount(to)
+}
+
+func (t *PrestateTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	t.system.exit()
+}
+
+func (t *PrestateTracer) CaptureStorageRead(contract sdk.AccAddress, key []byte, value []byte) {
+	t.lookupStorage(contract, key)
+}
+
+func (t *PrestateTracer) CaptureStorageWrite(contract sdk.AccAddress, key []byte, value []byte) {
+	t.lookupStorage(contract, key)
+}
+
+func (t *PrestateTracer) CaptureLog(contract sdk.AccAddress, topics [][32]byte, data []byte) {}
+
+// lookupAccount reads the account state before the execution, when first touched
+func (t *PrestateTracer) lookupAccount(addr sdk.AccAddress) {
+	evmaddr := types.EvmAddressFromAcc(addr)
+	if _, ok := t.accounts[evmaddr]; ok {
+		return
+	}
+	t.addrs = append(t.addrs, addr)
+	t.accounts[evmaddr] = &account{
+		Balance: (*hexutil.Big)(t.pre.GetBalance(addr)),
+		Nonce:   t.pre.GetNonce(addr),
+		Code:    t.pre.GetCode(addr),
+		Storage: map[string]hexutil.Bytes{},
+	}
+}
+
+// lookupStorage reads the storage key value before the execution, when first touched
+func (t *PrestateTracer) lookupStorage(contract sdk.AccAddress, key []byte) {
+	if t.system.skipped(contract) {
+		return
+	}
+	t.lookupAccount(contract)
+	evmaddr := types.EvmAddressFromAcc(contract)
+	hexkey := hexutil.Encode(key)
+	if _, ok := t.accounts[evmaddr].Storage[hexkey]; ok {
+		return
+	}
+	t.accounts[evmaddr].Storage[hexkey] = t.pre.GetState(contract, key)
+	t.keys[evmaddr] = append(t.keys[evmaddr], common.CopyBytes(key))
+}
+
+func (t *PrestateTracer) GetResult() (json.RawMessage, error) {
+	if !t.config.DiffMode {
+		return json.Marshal(t.accounts)
+	}
+	pre, post := t.processDiffState()
+	return json.Marshal(struct {
+		Post map[common.Address]*account `json:"post"`
+		Pre  map[common.Address]*account `json:"pre"`
+	}{post, pre})
+}
+
+// processDiffState keeps only the modified accounts and fields.
+// Post contains the changed fields, pre their previous values.
+func (t *PrestateTracer) processDiffState() (map[common.Address]*account, map[common.Address]*account) {
+	pre := map[common.Address]*account{}
+	post := map[common.Address]*account{}
+	for _, addr := range t.addrs {
+		evmaddr := types.EvmAddressFromAcc(addr)
+		prevAccount := t.accounts[evmaddr]
+		modified := false
+		postAccount := &account{Storage: map[string]hexutil.Bytes{}}
+
+		newBalance := t.post.GetBalance(addr)
+		if (*big.Int)(prevAccount.Balance).Cmp(newBalance) != 0 {
+			modified = true
+			postAccount.Balance = (*hexutil.Big)(newBalance)
+		}
+		newNonce := t.post.GetNonce(addr)
+		if newNonce != prevAccount.Nonce {
+			modified = true
+			postAccount.Nonce = newNonce
+		}
+		newCode := t.post.GetCode(addr)
+		if !bytes.Equal(newCode, prevAccount.Code) {
+			modified = true
+			postAccount.Code = newCode
+		}
+
+		prevStorage := map[string]hexutil.Bytes{}
+		for _, key := range t.keys[evmaddr] {
+			hexkey := hexutil.Encode(key)
+			prevValue := prevAccount.Storage[hexkey]
+			newValue := t.post.GetState(addr, key)
+			if bytes.Equal(prevValue, newValue) {
+				continue
+			}
+			modified = true
+			// empty values are omitted, as in geth
+			if len(prevValue) > 0 {
+				prevStorage[hexkey] = prevValue
+			}
+			if len(newValue) > 0 {
+				postAccount.Storage[hexkey] = newValue
+			}
+		}
+		if !modified {
+			continue
+		}
+		pre[evmaddr] = &account{
+			Balance: prevAccount.Balance,
+			Nonce:   prevAccount.Nonce,
+			Code:    prevAccount.Code,
+			Storage: prevStorage,
+		}
+		post[evmaddr] = postAccount
+	}
+	return pre, post
+}
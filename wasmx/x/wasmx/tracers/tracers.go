@@ -0,0 +1,90 @@
+package tracers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+)
+
+const (
+	CallTracerName     = "callTracer"
+	PrestateTracerName = "prestateTracer"
+)
+
+// StateReader reads the account state needed by the tracers,
+// from the state before or after the traced execution
+type StateReader interface {
+	GetBalance(addr sdk.AccAddress) *big.Int
+	GetNonce(addr sdk.AccAddress) uint64
+	GetCode(addr sdk.AccAddress) []byte
+	GetState(addr sdk.AccAddress, key []byte) []byte
+}
+
+// Tracer records a contract execution and returns the geth-compatible trace result
+type Tracer interface {
+	types.Tracer
+	// GetResult returns the JSON trace, after the execution ended
+	GetResult() (json.RawMessage, error)
+}
+
+// New creates a tracer by name. The pre and post readers
+// read the state before and after the traced execution.
+func New(name string, config json.RawMessage, pre StateReader, post StateReader) (Tracer, error) {
+	switch name {
+	case "", CallTracerName:
+		return NewCallTracer(config)
+	case PrestateTracerName:
+		return NewPrestateTracer(config, pre, post)
+	default:
+		return nil, fmt.Errorf("tracer not supported: %s", name)
+	}
+}
+
+var (
+	// the Ethereum precompiles are traced like any other contract
+	lastPrecompileAddress = new(big.Int).SetBytes(types.AccAddressFromHex(types.ADDR_BLAKE2F))
+	// system contracts (bank, consensus, roles, etc.) are deployed at low reserved addresses
+	reservedAddressLimit = big.NewInt(0x10000)
+)
+
+// isSystemContract returns true for the core system contract addresses
+func isSystemContract(addr sdk.AccAddress) bool {
+	if types.IsSystemAddress(addr) {
+		return true
+	}
+	n := new(big.Int).SetBytes(addr)
+	return n.Cmp(lastPrecompileAddress) > 0 && n.Cmp(reservedAddressLimit) < 0
+}
+
+// systemCalls skips the calls to system contracts (e.g. bank, consensus)
+// and everything executed inside them, so traces only contain user contracts
+type systemCalls struct {
+	depth int
+}
+
+// enter returns true if the call is skipped
+func (s *systemCalls) enter(to sdk.AccAddress) bool {
+	if s.depth > 0 || isSystemContract(to) {
+		s.depth += 1
+		return true
+	}
+	return false
+}
+
+// exit returns true if the ended call was skipped
+func (s *systemCalls) exit() bool {
+	if s.depth > 0 {
+		s.depth -= 1
+		return true
+	}
+	return false
+}
+
+// skipped returns true while executing a system contract
+func (s *systemCalls) skipped(contract sdk.AccAddress) bool {
+	return s.depth > 0 || isSystemContract(contract)
+}
@@ -0,0 +1,230 @@
+package tracers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/tracers"
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	sender    = types.AccAddressFromEvm(common.HexToAddress("0x1111111111111111111111111111111111111111"))
+	contract1 = types.AccAddressFromEvm(common.HexToAddress("0x2222222222222222222222222222222222222222"))
+	contract2 = types.AccAddressFromEvm(common.HexToAddress("0x3333333333333333333333333333333333333333"))
+	bank      = types.AccAddressFromEvm(common.HexToAddress("0x0000000000000000000000000000000000000031"))
+	storeKey  = []byte{1}
+)
+
+type mockState struct {
+	balances map[string]*big.Int
+	nonces   map[string]uint64
+	code     map[string][]byte
+	storage  map[string][]byte
+}
+
+func newMockState() *mockState {
+	return &mockState{
+		balances: map[string]*big.Int{},
+		nonces:   map[string]uint64{},
+		code:     map[string][]byte{},
+		storage:  map[string][]byte{},
+	}
+}
+
+func (s *mockState) GetBalance(addr sdk.AccAddress) *big.Int {
+	if b, ok := s.balances[addr.String()]; ok {
+		return b
+	}
+	return big.NewInt(0)
+}
+
+func (s *mockState) GetNonce(addr sdk.AccAddress) uint64 {
+	return s.nonces[addr.String()]
+}
+
+func (s *mockState) GetCode(addr sdk.AccAddress) []byte {
+	return s.code[addr.String()]
+}
+
+func (s *mockState) GetState(addr sdk.AccAddress, key []byte) []byte {
+	return s.storage[addr.String()+string(key)]
+}
+
+// revertOutput encodes an Error(string) revert
+func revertOutput(reason string) []byte {
+	data := hexutil.MustDecode("0x08c379a0")
+	data = append(data, common.LeftPadBytes(big.NewInt(32).Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(big.NewInt(int64(len(reason))).Bytes(), 32)...)
+	return append(data, common.RightPadBytes([]byte(reason), 32)...)
+}
+
+type callResult struct {
+	Type         string         `json:"type"`
+	From         common.Address `json:"from"`
+	To           common.Address `json:"to"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	Output       hexutil.Bytes  `json:"output"`
+	Error        string         `json:"error"`
+	RevertReason string         `json:"revertReason"`
+	Calls        []callResult   `json:"calls"`
+	Logs         []struct {
+		Address  common.Address `json:"address"`
+		Position hexutil.Uint   `json:"position"`
+	} `json:"logs"`
+}
+
+func runCallTracer(t *testing.T, config string) callResult {
+	tracer, err := tracers.New(tracers.CallTracerName, json.RawMessage(config), nil, nil)
+	require.NoError(t, err)
+
+	tracer.CaptureEnter(types.CALL_TYPE_CALL, sender, contract1, []byte{0xaa}, 100000, big.NewInt(1))
+	// system contract calls are not traced
+	tracer.CaptureEnter(types.CALL_TYPE_CALL, contract1, bank, nil, 1000, nil)
+	tracer.CaptureLog(bank, nil, nil)
+	tracer.CaptureExit(nil, 10, nil)
+	tracer.CaptureLog(contract1, [][32]byte{{1}}, []byte{1})
+	tracer.CaptureEnter(types.CALL_TYPE_STATICCALL, contract1, contract2, []byte{0xbb}, 5000, nil)
+	tracer.CaptureExit([]byte{0xcc}, 300, nil)
+	tracer.CaptureEnter(types.CALL_TYPE_CALL, contract1, contract2, []byte{0xdd}, 5000, nil)
+	tracer.CaptureLog(contract2, nil, []byte{2})
+	tracer.CaptureExit(revertOutput("not allowed"), 200, errors.New("execution reverted"))
+	tracer.CaptureExit([]byte{0xee}, 1000, nil)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	var frame callResult
+	require.NoError(t, json.Unmarshal(res, &frame))
+	return frame
+}
+
+func TestCallTracer(t *testing.T) {
+	frame := runCallTracer(t, "")
+	require.Equal(t, types.CALL_TYPE_CALL, frame.Type)
+	require.Equal(t, types.EvmAddressFromAcc(sender), frame.From)
+	require.Equal(t, types.EvmAddressFromAcc(contract1), frame.To)
+	require.Equal(t, hexutil.Uint64(1000), frame.GasUsed)
+	require.Equal(t, hexutil.Bytes{0xee}, frame.Output)
+	require.Empty(t, frame.Logs)
+	require.Len(t, frame.Calls, 2)
+
+	require.Equal(t, types.CALL_TYPE_STATICCALL, frame.Calls[0].Type)
+	require.Equal(t, hexutil.Bytes{0xcc}, frame.Calls[0].Output)
+	require.Equal(t, "", frame.Calls[0].Error)
+
+	require.Equal(t, "execution reverted", frame.Calls[1].Error)
+	require.Equal(t, "not allowed", frame.Calls[1].RevertReason)
+}
+
+func TestCallTracerConfig(t *testing.T) {
+	frame := runCallTracer(t, `{"withLog":true}`)
+	require.Len(t, frame.Logs, 1)
+	require.Equal(t, types.EvmAddressFromAcc(contract1), frame.Logs[0].Address)
+	require.Equal(t, hexutil.Uint(0), frame.Logs[0].Position)
+	// logs of reverted calls are removed
+	require.Empty(t, frame.Calls[1].Logs)
+
+	frame = runCallTracer(t, `{"onlyTopCall":true}`)
+	require.Empty(t, frame.Calls)
+	require.Equal(t, hexutil.Bytes{0xee}, frame.Output)
+}
+
+func TestCallTracerAborted(t *testing.T) {
+	tracer, err := tracers.New("", nil, nil, nil)
+	require.NoError(t, err)
+	_, err = tracer.GetResult()
+	require.Error(t, err)
+
+	tracer.CaptureEnter(types.CALL_TYPE_CALL, sender, contract1, nil, 100, nil)
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	var frame callResult
+	require.NoError(t, json.Unmarshal(res, &frame))
+	require.Equal(t, "execution aborted", frame.Error)
+
+	_, err = tracers.New("4byteTracer", nil, nil, nil)
+	require.Error(t, err)
+}
+
+type accountResult struct {
+	Balance *hexutil.Big             `json:"balance"`
+	Nonce   uint64                   `json:"nonce"`
+	Code    hexutil.Bytes            `json:"code"`
+	Storage map[string]hexutil.Bytes `json:"storage"`
+}
+
+func runPrestateTracer(t *testing.T, config string) []byte {
+	pre := newMockState()
+	pre.balances[sender.String()] = big.NewInt(1000)
+	pre.nonces[sender.String()] = 1
+	pre.code[contract1.String()] = []byte{0x60}
+	pre.storage[contract1.String()+string(storeKey)] = []byte{5}
+
+	post := newMockState()
+	post.balances[sender.String()] = big.NewInt(900)
+	post.nonces[sender.String()] = 2
+	post.code[contract1.String()] = []byte{0x60}
+	post.storage[contract1.String()+string(storeKey)] = []byte{6}
+
+	tracer, err := tracers.New(tracers.PrestateTracerName, json.RawMessage(config), pre, post)
+	require.NoError(t, err)
+	tracer.CaptureEnter(types.CALL_TYPE_CALL, sender, contract1, nil, 100000, nil)
+	tracer.CaptureEnter(types.CALL_TYPE_CALL, contract1, bank, nil, 1000, nil)
+	tracer.CaptureStorageWrite(bank, storeKey, []byte{1})
+	tracer.CaptureExit(nil, 10, nil)
+	tracer.CaptureStorageRead(contract1, storeKey, []byte{5})
+	tracer.CaptureStorageWrite(contract1, storeKey, []byte{6})
+	tracer.CaptureEnter(types.CALL_TYPE_STATICCALL, contract1, contract2, nil, 1000, nil)
+	tracer.CaptureExit(nil, 10, nil)
+	tracer.CaptureExit(nil, 100, nil)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	return res
+}
+
+func TestPrestateTracer(t *testing.T) {
+	var accounts map[common.Address]accountResult
+	require.NoError(t, json.Unmarshal(runPrestateTracer(t, ""), &accounts))
+	require.Len(t, accounts, 3)
+	require.NotContains(t, accounts, types.EvmAddressFromAcc(bank))
+
+	acc := accounts[types.EvmAddressFromAcc(sender)]
+	require.Equal(t, big.NewInt(1000), acc.Balance.ToInt())
+	require.Equal(t, uint64(1), acc.Nonce)
+
+	acc = accounts[types.EvmAddressFromAcc(contract1)]
+	require.Equal(t, hexutil.Bytes{0x60}, acc.Code)
+	require.Equal(t, hexutil.Bytes{5}, acc.Storage[hexutil.Encode(storeKey)])
+}
+
+func TestPrestateTracerDiffMode(t *testing.T) {
+	var diff struct {
+		Pre  map[common.Address]accountResult `json:"pre"`
+		Post map[common.Address]accountResult `json:"post"`
+	}
+	require.NoError(t, json.Unmarshal(runPrestateTracer(t, `{"diffMode":true}`), &diff))
+	// contract2 was not modified
+	require.Len(t, diff.Pre, 2)
+	require.Len(t, diff.Post, 2)
+
+	senderAddr := types.EvmAddressFromAcc(sender)
+	require.Equal(t, big.NewInt(1000), diff.Pre[senderAddr].Balance.ToInt())
+	require.Equal(t, big.NewInt(900), diff.Post[senderAddr].Balance.ToInt())
+	require.Equal(t, uint64(2), diff.Post[senderAddr].Nonce)
+
+	contractAddr := types.EvmAddressFromAcc(contract1)
+	require.Equal(t, hexutil.Bytes{5}, diff.Pre[contractAddr].Storage[hexutil.Encode(storeKey)])
+	require.Equal(t, hexutil.Bytes{6}, diff.Post[contractAddr].Storage[hexutil.Encode(storeKey)])
+	// unchanged code is only in the pre state
+	require.Nil(t, diff.Post[contractAddr].Code)
+}
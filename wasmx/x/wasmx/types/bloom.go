@@ -0,0 +1,85 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	address "cosmossdk.io/core/address"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BlockBloomEvent returns a block event with the bloom filter of the ethereum logs
+// emitted by the block transactions, so log filters can skip a block without decoding its logs.
+// The bloom is only a hint for log filters, so malformed logs are skipped instead of failing the block.
+func BlockBloomEvent(addressCodec address.Codec, txResults []*abci.ExecTxResult) abci.Event {
+	var bloom ethtypes.Bloom
+	for _, txResult := range txResults {
+		if txResult == nil {
+			continue
+		}
+		for _, event := range txResult.Events {
+			if event.Type != (CustomContractEventPrefix + EventTypeWasmxLog) {
+				continue
+			}
+			if contractAddress, ok := LogContractAddress(addressCodec, event); ok {
+				bloom.Add(EvmAddressFromAcc(contractAddress).Bytes())
+			}
+			for _, attr := range event.Attributes {
+				if attr.Key == AttributeKeyTopic {
+					topic := common.HexToHash(attr.Value)
+					bloom.Add(topic[:])
+				}
+			}
+		}
+	}
+	return abci.Event{
+		Type: EventTypeBlockBloom,
+		Attributes: []abci.EventAttribute{
+			{Key: AttributeKeyBloom, Value: hex.EncodeToString(bloom.Bytes()), Index: false},
+		},
+	}
+}
+
+// LogContractAddress returns the address of the contract emitting a wasmx log event.
+// The keeper sets it as the first event attribute; contracts can add their own
+// contract_address attributes, which are ignored.
+func LogContractAddress(addressCodec address.Codec, event abci.Event) (sdk.AccAddress, bool) {
+	if len(event.Attributes) == 0 || event.Attributes[0].Key != AttributeKeyContractAddr {
+		return nil, false
+	}
+	contractAddress, err := addressCodec.StringToBytes(event.Attributes[0].Value)
+	if err != nil {
+		return nil, false
+	}
+	return contractAddress, true
+}
+
+// BlockBloomFromEvents returns the block bloom filter from the block events.
+// found is false for blocks finalized without the bloom event.
+func BlockBloomFromEvents(events []abci.Event) (bloom ethtypes.Bloom, found bool, err error) {
+	for _, event := range events {
+		if event.Type != EventTypeBlockBloom {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key != AttributeKeyBloom {
+				continue
+			}
+			bz, err := hex.DecodeString(attr.Value)
+			if err != nil {
+				return bloom, false, err
+			}
+			if len(bz) != ethtypes.BloomByteLength {
+				return bloom, false, fmt.Errorf("invalid block bloom length: %d", len(bz))
+			}
+			bloom.SetBytes(bz)
+			return bloom, true, nil
+		}
+	}
+	return bloom, false, nil
+}
@@ -0,0 +1,84 @@
+package types_test
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	addresscodec "github.com/cosmos/cosmos-sdk/codec/address"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+)
+
+func TestBlockBloomEvent(t *testing.T) {
+	addrCodec := addresscodec.NewBech32Codec("mythos")
+	contract := sdk.AccAddress(common.HexToAddress("0x0000000000000000000000000000000000000005").Bytes())
+	contractStr, err := addrCodec.BytesToString(contract)
+	require.NoError(t, err)
+	topic := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+	txResults := []*abci.ExecTxResult{
+		{Events: []abci.Event{
+			{Type: types.EventTypeExecute},
+			{Type: types.CustomContractEventPrefix + types.EventTypeWasmxLog, Attributes: []abci.EventAttribute{
+				{Key: types.AttributeKeyContractAddr, Value: contractStr},
+				{Key: types.AttributeKeyTopic, Value: topic.Hex()},
+				{Key: types.AttributeKeyData, Value: "0x"},
+			}},
+		}},
+	}
+	ev := types.BlockBloomEvent(addrCodec, txResults)
+
+	bloom, found, err := types.BlockBloomFromEvents([]abci.Event{{Type: types.EventTypeExecute}, ev})
+	require.NoError(t, err)
+	require.True(t, found)
+
+	log := &ethtypes.Log{Address: types.EvmAddressFromAcc(contract), Topics: []common.Hash{topic}}
+	require.Equal(t, ethtypes.CreateBloom(ethtypes.Receipts{{Logs: []*ethtypes.Log{log}}}), bloom)
+	require.True(t, ethtypes.BloomLookup(bloom, topic))
+	require.False(t, ethtypes.BloomLookup(bloom, common.HexToHash("0x01")))
+
+	_, found, err = types.BlockBloomFromEvents([]abci.Event{{Type: types.EventTypeExecute}})
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestBlockBloomEventForgedAddress(t *testing.T) {
+	addrCodec := addresscodec.NewBech32Codec("mythos")
+	contract := sdk.AccAddress(common.HexToAddress("0x0000000000000000000000000000000000000005").Bytes())
+	contractStr, err := addrCodec.BytesToString(contract)
+	require.NoError(t, err)
+	forged := sdk.AccAddress(common.HexToAddress("0x0000000000000000000000000000000000000006").Bytes())
+	forgedStr, err := addrCodec.BytesToString(forged)
+	require.NoError(t, err)
+
+	// contracts can emit their own wasmxlog events, with any attributes after the keeper's contract address
+	txResults := []*abci.ExecTxResult{
+		{Events: []abci.Event{
+			{Type: types.CustomContractEventPrefix + types.EventTypeWasmxLog, Attributes: []abci.EventAttribute{
+				{Key: types.AttributeKeyContractAddr, Value: contractStr},
+				{Key: types.AttributeKeyContractAddr, Value: "notanaddress"},
+				{Key: types.AttributeKeyContractAddr, Value: forgedStr},
+			}},
+			{Type: types.CustomContractEventPrefix + types.EventTypeWasmxLog, Attributes: []abci.EventAttribute{
+				{Key: types.AttributeKeyContractAddr, Value: "notanaddress"},
+			}},
+		}},
+	}
+	ev := types.BlockBloomEvent(addrCodec, txResults)
+	bloom, found, err := types.BlockBloomFromEvents([]abci.Event{ev})
+	require.NoError(t, err)
+	require.True(t, found)
+	require.True(t, ethtypes.BloomLookup(bloom, types.EvmAddressFromAcc(contract)))
+	require.False(t, ethtypes.BloomLookup(bloom, types.EvmAddressFromAcc(forged)))
+
+	address, ok := types.LogContractAddress(addrCodec, txResults[0].Events[0])
+	require.True(t, ok)
+	require.Equal(t, contract, address)
+	_, ok = types.LogContractAddress(addrCodec, txResults[0].Events[1])
+	require.False(t, ok)
+}
@@ -15,6 +15,10 @@ func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgExecuteContract{}, "wasmx/MsgExecuteContract", nil)
 	cdc.RegisterConcrete(&MsgCompileContract{}, "wasmx/MsgCompileContract", nil)
 	cdc.RegisterConcrete(&MsgExecuteEth{}, "wasmx/MsgExecuteEth", nil)
+	cdc.RegisterConcrete(&MsgMigrateContract{}, "wasmx/MsgMigrateContract", nil)
+	cdc.RegisterConcrete(&MsgUpdateAdmin{}, "wasmx/MsgUpdateAdmin", nil)
+	cdc.RegisterConcrete(&MsgClearAdmin{}, "wasmx/MsgClearAdmin", nil)
+	cdc.RegisterConcrete(&MsgUpdateParams{}, "wasmx/MsgUpdateParams", nil)
 }
 
 func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
@@ -26,6 +30,10 @@ func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
 		&MsgExecuteContract{},
 		&MsgCompileContract{},
 		&MsgExecuteEth{},
+		&MsgMigrateContract{},
+		&MsgUpdateAdmin{},
+		&MsgClearAdmin{},
+		&MsgUpdateParams{},
 	)
 
 	registry.RegisterImplementations(
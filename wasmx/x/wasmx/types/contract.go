@@ -65,6 +65,7 @@ type CodeInfo struct {
 	RuntimeHash                   Checksum           `json:"runtime_hash"`
 	Source                        RawContractMessage `json:"source"`
 	SourceVerified                bool               `json:"source_verified"`
+	MaxMemoryPages                uint32             `json:"max_memory_pages"`
 }
 
 type ContractInfo struct {
@@ -75,6 +76,8 @@ type ContractInfo struct {
 	InitMessage RawContractMessage  `json:"init_message"`
 	Provenance  string              `json:"provenance"`
 	IbcPortId   string              `json:"ibc_port_id"`
+	// Admin is kept by the wasmx module, not by the code registry contract
+	Admin string `json:"admin,omitempty"`
 }
 
 type ContractInstance struct {
@@ -166,6 +169,7 @@ func (v *CodeInfo) ToProto() *CodeInfoPB {
 		RuntimeHash:                   v.RuntimeHash,
 		Source:                        v.Source,
 		SourceVerified:                v.SourceVerified,
+		MaxMemoryPages:                v.MaxMemoryPages,
 	}
 }
 
@@ -185,6 +189,7 @@ func (v *CodeInfoPB) ToJson() *CodeInfo {
 		RuntimeHash:                   v.RuntimeHash,
 		Source:                        v.Source,
 		SourceVerified:                v.SourceVerified,
+		MaxMemoryPages:                v.MaxMemoryPages,
 	}
 }
 
@@ -200,6 +205,7 @@ func (v *ContractInfo) ToProto() *ContractInfoPB {
 		InitMessage: v.InitMessage,
 		Provenance:  v.Provenance,
 		IbcPortId:   v.IbcPortId,
+		Admin:       v.Admin,
 	}
 }
 
@@ -215,6 +221,7 @@ func (v *ContractInfoPB) ToJson() *ContractInfo {
 		InitMessage: v.InitMessage,
 		Provenance:  v.Provenance,
 		IbcPortId:   v.IbcPortId,
+		Admin:       v.Admin,
 	}
 }
 
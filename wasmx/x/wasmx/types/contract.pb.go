@@ -223,7 +223,8 @@ func (m *CodeMetadataPB) GetOrigin() *CodeOriginPB {
 // CodeInfo is data for the uploaded contract WASM code
 type CodeInfoPB struct {
 	// CodeHash is the unique identifier created by hashing the
-	// wasm or interpreted code
+	// stored wasm or interpreted code; wasm code is stored with
+	// NaN-producing float operations canonicalized
 	CodeHash []byte `protobuf:"bytes,1,opt,name=code_hash,json=codeHash,proto3" json:"code_hash,omitempty"`
 	// Creator address who initially stored the code
 	Creator string `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
@@ -240,6 +241,8 @@ type CodeInfoPB struct {
 	RuntimeHash                   []byte `protobuf:"bytes,9,opt,name=runtime_hash,json=runtimeHash,proto3" json:"runtime_hash,omitempty"`
 	Source                        []byte `protobuf:"bytes,10,opt,name=source,proto3" json:"source,omitempty"`
 	SourceVerified                bool   `protobuf:"varint,11,opt,name=source_verified,json=sourceVerified,proto3" json:"source_verified,omitempty"`
+	// overrides the max_memory_pages module param for this code, if not 0
+	MaxMemoryPages uint32 `protobuf:"varint,12,opt,name=max_memory_pages,json=maxMemoryPages,proto3" json:"max_memory_pages,omitempty"`
 }
 
 func (m *CodeInfoPB) Reset()         { *m = CodeInfoPB{} }
@@ -352,6 +355,13 @@ func (m *CodeInfoPB) GetSourceVerified() bool {
 	return false
 }
 
+func (m *CodeInfoPB) GetMaxMemoryPages() uint32 {
+	if m != nil {
+		return m.MaxMemoryPages
+	}
+	return 0
+}
+
 type CodeOriginPB struct {
 	// unique chain ID
 	ChainId string `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
@@ -421,6 +431,10 @@ type ContractInfoPB struct {
 	// factory/deployer address
 	Provenance string `protobuf:"bytes,6,opt,name=provenance,proto3" json:"provenance,omitempty"`
 	IbcPortId  string `protobuf:"bytes,7,opt,name=ibc_port_id,json=ibcPortId,proto3" json:"ibc_port_id,omitempty"`
+	// TODO either tx hash or
+	// AbsoluteTxPosition created = 8;
+	// Admin is an optional address that can migrate the contract
+	Admin string `protobuf:"bytes,9,opt,name=admin,proto3" json:"admin,omitempty"`
 }
 
 func (m *ContractInfoPB) Reset()         { *m = ContractInfoPB{} }
@@ -505,6 +519,13 @@ func (m *ContractInfoPB) GetIbcPortId() string {
 	return ""
 }
 
+func (m *ContractInfoPB) GetAdmin() string {
+	if m != nil {
+		return m.Admin
+	}
+	return ""
+}
+
 // AbsoluteTxPosition is a unique transaction position that allows for global
 // ordering of transactions.
 type AbsoluteTxPosition struct {
@@ -575,62 +596,65 @@ func init() {
 func init() { proto.RegisterFile("mythos/wasmx/v1/contract.proto", fileDescriptor_8858b63f7ddfb8d9) }
 
 var fileDescriptor_8858b63f7ddfb8d9 = []byte{
-	// 877 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0xc1, 0x6e, 0x23, 0x45,
-	0x10, 0x8d, 0x63, 0xc7, 0xb1, 0x6b, 0xbc, 0x49, 0xb6, 0x37, 0x0a, 0xc3, 0xb2, 0x6b, 0x87, 0x08,
-	0x44, 0xc4, 0xc1, 0x66, 0x03, 0x1c, 0xe0, 0xc4, 0x4e, 0xd0, 0x92, 0x1c, 0xa2, 0xb5, 0x3a, 0x11,
-	0x07, 0x2e, 0xa3, 0x9e, 0x99, 0xf2, 0x4c, 0x13, 0x4f, 0xb7, 0xe9, 0x6e, 0x7b, 0xed, 0x9f, 0x40,
-	0x7c, 0x02, 0x12, 0x3f, 0xb3, 0xdc, 0xf6, 0xc8, 0x29, 0x42, 0xc9, 0x85, 0x6f, 0xd8, 0x13, 0xea,
-	0x9e, 0xf6, 0xc6, 0x20, 0xef, 0xc9, 0xfd, 0x5e, 0xbd, 0x2a, 0xab, 0x5e, 0x55, 0x0d, 0x74, 0xcb,
-	0x85, 0x29, 0xa4, 0x1e, 0xbc, 0x62, 0xba, 0x9c, 0x0f, 0x66, 0xcf, 0x06, 0xa9, 0x14, 0x46, 0xb1,
-	0xd4, 0xf4, 0x27, 0x4a, 0x1a, 0x49, 0x76, 0xab, 0x78, 0xdf, 0xc5, 0xfb, 0xb3, 0x67, 0x8f, 0xf7,
-	0x73, 0x99, 0x4b, 0x17, 0x1b, 0xd8, 0x57, 0x25, 0x3b, 0xfa, 0x05, 0x1e, 0x9e, 0xfa, 0xc4, 0x4b,
-	0x23, 0x15, 0xcb, 0x71, 0x18, 0x91, 0x17, 0x50, 0xbf, 0xc6, 0x45, 0x58, 0x3b, 0xac, 0x1d, 0x77,
-	0xa2, 0xaf, 0xde, 0xde, 0xf4, 0xbe, 0xc8, 0xb9, 0x29, 0xa6, 0x49, 0x3f, 0x95, 0xe5, 0x20, 0x95,
-	0x25, 0x9a, 0x64, 0x64, 0xee, 0x1f, 0x63, 0x9e, 0xe8, 0x41, 0xb2, 0x30, 0xa8, 0xfb, 0x67, 0x38,
-	0x8f, 0xec, 0x83, 0xda, 0x02, 0x64, 0x1f, 0xb6, 0x66, 0x6c, 0x3c, 0xc5, 0x70, 0xd3, 0x56, 0xa2,
-	0x15, 0x38, 0xfa, 0x75, 0x13, 0x76, 0x4e, 0x65, 0x86, 0x17, 0x68, 0x58, 0xc6, 0x0c, 0x1b, 0x46,
-	0x24, 0x84, 0x86, 0x60, 0x25, 0xba, 0x7f, 0x6c, 0x47, 0x8d, 0xd7, 0x37, 0xbd, 0x1a, 0x75, 0x8c,
-	0x2d, 0x91, 0x32, 0x83, 0x79, 0xb8, 0x79, 0x58, 0x3f, 0x6e, 0xd3, 0x0a, 0x58, 0x3d, 0x4f, 0xa5,
-	0x08, 0xeb, 0xab, 0x7a, 0xcb, 0x90, 0x27, 0xd0, 0x64, 0x53, 0x53, 0x48, 0x15, 0x36, 0x56, 0x62,
-	0x9e, 0xb3, 0x79, 0x9a, 0x1b, 0x0c, 0xb7, 0x56, 0xf3, 0x2c, 0x43, 0x0e, 0xa0, 0xce, 0x12, 0x1e,
-	0x36, 0x5d, 0xcb, 0x55, 0xc0, 0x12, 0xe4, 0x53, 0x08, 0x7e, 0xd6, 0x52, 0xc4, 0x3a, 0x2d, 0xb0,
-	0x64, 0xe1, 0xf6, 0x4a, 0x22, 0xd8, 0xc0, 0xa5, 0xe3, 0xc9, 0xd7, 0xd0, 0x94, 0x8a, 0xe7, 0x5c,
-	0x84, 0xad, 0xc3, 0xda, 0x71, 0x70, 0xf2, 0xb4, 0xff, 0x3f, 0xfb, 0xfb, 0xb6, 0xe3, 0x97, 0x4e,
-	0x32, 0x8c, 0xa8, 0x17, 0x7f, 0xdb, 0xf8, 0xe7, 0xf7, 0x5e, 0xed, 0xe8, 0xcf, 0x3a, 0x80, 0x0d,
-	0x9f, 0x8b, 0x91, 0x1c, 0x46, 0xe4, 0x23, 0x68, 0xa7, 0x32, 0xc3, 0xb8, 0x60, 0xba, 0xa8, 0x66,
-	0x40, 0x5b, 0x96, 0x38, 0x63, 0xba, 0x20, 0x21, 0x6c, 0xa7, 0x0a, 0x99, 0x91, 0xca, 0x99, 0xda,
-	0xa6, 0x4b, 0x48, 0x08, 0x34, 0x32, 0x9c, 0xe8, 0xb0, 0xee, 0x8c, 0x72, 0x6f, 0x72, 0x00, 0xcd,
-	0x09, 0x17, 0x02, 0x33, 0xe7, 0x46, 0x8b, 0x7a, 0x44, 0x3e, 0x86, 0x4e, 0x89, 0x06, 0x15, 0x17,
-	0x79, 0x2c, 0x47, 0x23, 0xe7, 0x47, 0x8b, 0x06, 0x4b, 0xee, 0xe5, 0x68, 0x44, 0x9e, 0x43, 0xab,
-	0xf4, 0x03, 0x72, 0xae, 0x04, 0x27, 0xbd, 0xb5, 0x3d, 0xdd, 0x4f, 0xd1, 0xd9, 0xb2, 0x41, 0xdf,
-	0xa5, 0x91, 0x17, 0xd0, 0xe3, 0xc2, 0xa0, 0x9a, 0x28, 0x34, 0x98, 0xc5, 0x76, 0x43, 0x5c, 0x63,
-	0x19, 0x4e, 0xc6, 0x72, 0x51, 0xa2, 0x30, 0xce, 0xcf, 0x0e, 0x7d, 0xba, 0x22, 0x8b, 0xbc, 0xea,
-	0xfb, 0x77, 0x22, 0xf2, 0x1d, 0x3c, 0x59, 0x5b, 0x47, 0x4d, 0x85, 0xe1, 0x25, 0x3a, 0xcb, 0x3b,
-	0xf4, 0xf1, 0x9a, 0x22, 0xb4, 0x52, 0xd8, 0x7e, 0xbd, 0xb8, 0x72, 0xb5, 0xed, 0x32, 0x02, 0xcf,
-	0x39, 0x63, 0x0f, 0xa0, 0xa9, 0xe5, 0x54, 0xa5, 0x18, 0x82, 0x0b, 0x7a, 0x44, 0x3e, 0x83, 0xdd,
-	0xea, 0x15, 0xcf, 0x50, 0xf1, 0x11, 0xc7, 0x2c, 0x0c, 0x9c, 0x5b, 0x3b, 0x15, 0xfd, 0xa3, 0x67,
-	0xfd, 0x2c, 0xcf, 0xa1, 0xb3, 0x3a, 0x69, 0xf2, 0x21, 0xb4, 0xd2, 0x82, 0x71, 0x11, 0xf3, 0xac,
-	0xda, 0x6e, 0xba, 0xed, 0xf0, 0x79, 0x66, 0x47, 0xc9, 0xb2, 0x4c, 0xa1, 0xd6, 0xcb, 0x51, 0x7a,
-	0xe8, 0x4b, 0xfd, 0xe1, 0xee, 0xa4, 0xba, 0x4d, 0xbf, 0x1a, 0x1f, 0xc0, 0xb6, 0xeb, 0xdc, 0x17,
-	0x6b, 0xd0, 0xa6, 0x85, 0x55, 0xad, 0xf7, 0xac, 0xc5, 0x3e, 0x6c, 0x8d, 0x59, 0x82, 0xe3, 0xea,
-	0x56, 0x68, 0x05, 0xc8, 0x0f, 0xd0, 0xd1, 0xd5, 0xb9, 0xc7, 0x66, 0x31, 0x41, 0xb7, 0x1e, 0x3b,
-	0x27, 0x9f, 0xac, 0x99, 0xf0, 0x7f, 0xbe, 0x0d, 0x57, 0x8b, 0x09, 0xd2, 0x40, 0xdf, 0x03, 0xf2,
-	0x0d, 0x74, 0xb8, 0xe0, 0x26, 0x2e, 0x51, 0x6b, 0x96, 0x57, 0x97, 0xd5, 0x89, 0x0e, 0xde, 0xde,
-	0xf4, 0x08, 0x65, 0xaf, 0x96, 0xe9, 0x17, 0x55, 0x94, 0x06, 0x56, 0xeb, 0x01, 0xe9, 0x02, 0x4c,
-	0x94, 0x9c, 0xa1, 0x60, 0x22, 0x45, 0xb7, 0x63, 0x6d, 0xba, 0xc2, 0x90, 0x2e, 0x04, 0x3c, 0x49,
-	0xe3, 0x89, 0x54, 0xc6, 0x36, 0xec, 0x4e, 0x8f, 0xb6, 0x79, 0x92, 0x0e, 0xa5, 0x32, 0xe7, 0x4b,
-	0xc3, 0x29, 0x90, 0xe7, 0x89, 0x96, 0xe3, 0xa9, 0xc1, 0xab, 0xf9, 0x50, 0x6a, 0x6e, 0xb8, 0x14,
-	0x76, 0xe0, 0xc9, 0x58, 0xa6, 0xd7, 0x71, 0x81, 0x3c, 0x2f, 0x8c, 0x77, 0x2b, 0x70, 0xdc, 0x99,
-	0xa3, 0xec, 0x64, 0xcc, 0x3c, 0xe6, 0x22, 0xc3, 0xb9, 0xf3, 0xac, 0x41, 0xb7, 0xcd, 0xfc, 0xdc,
-	0xc2, 0xcf, 0xaf, 0xe1, 0xd1, 0x9a, 0xc6, 0xc9, 0x43, 0x78, 0x70, 0x2a, 0x15, 0x9e, 0x4a, 0xa1,
-	0x51, 0xe8, 0xa9, 0xde, 0xdb, 0xb0, 0x94, 0x3d, 0x80, 0x7b, 0xaa, 0x46, 0x1e, 0xc1, 0xee, 0x25,
-	0x17, 0xf9, 0x78, 0x45, 0xb7, 0x49, 0x00, 0x9a, 0x17, 0x58, 0x4a, 0xb5, 0xd8, 0xab, 0x93, 0x07,
-	0xd0, 0xbe, 0x52, 0x4c, 0x68, 0x8e, 0xc2, 0xec, 0x35, 0xa2, 0xb3, 0xd7, 0xb7, 0xdd, 0xda, 0x9b,
-	0xdb, 0x6e, 0xed, 0xef, 0xdb, 0x6e, 0xed, 0xb7, 0xbb, 0xee, 0xc6, 0x9b, 0xbb, 0xee, 0xc6, 0x5f,
-	0x77, 0xdd, 0x8d, 0x9f, 0xfa, 0x2b, 0x5f, 0xdd, 0xb1, 0x54, 0x98, 0x31, 0xc1, 0x52, 0xae, 0xb4,
-	0x41, 0xe6, 0x3f, 0xfb, 0x73, 0xff, 0x6b, 0x67, 0xa8, 0x93, 0xa6, 0xfb, 0xa4, 0x7f, 0xf9, 0x6f,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x2c, 0x52, 0x2f, 0xbd, 0x1b, 0x06, 0x00, 0x00,
+	// 914 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0xcf, 0x6e, 0x23, 0xc5,
+	0x13, 0x8e, 0x63, 0xc7, 0xb1, 0xcb, 0xce, 0x9f, 0xed, 0x8d, 0xf2, 0x9b, 0xdf, 0xb2, 0x6b, 0x87,
+	0x08, 0x44, 0xc4, 0xc1, 0x66, 0x03, 0x1c, 0xe0, 0xc4, 0x3a, 0x68, 0x49, 0x0e, 0xd1, 0x5a, 0x9d,
+	0x88, 0x03, 0x97, 0x51, 0xcf, 0x4c, 0x79, 0xa6, 0x89, 0xa7, 0x7b, 0xe8, 0x6e, 0x67, 0xed, 0x97,
+	0x40, 0x3c, 0x02, 0xaf, 0xc1, 0x1b, 0xec, 0x71, 0x8f, 0x9c, 0x22, 0x94, 0x5c, 0xb8, 0x73, 0xdb,
+	0x13, 0xea, 0x3f, 0xd9, 0x18, 0x64, 0x4e, 0xe9, 0xef, 0xab, 0xaf, 0x2a, 0xaa, 0xaf, 0xaa, 0xc6,
+	0xd0, 0x2b, 0x17, 0xa6, 0x90, 0x7a, 0xf8, 0x9a, 0xe9, 0x72, 0x3e, 0xbc, 0x7e, 0x3e, 0x4c, 0xa5,
+	0x30, 0x8a, 0xa5, 0x66, 0x50, 0x29, 0x69, 0x24, 0xd9, 0xf1, 0xf1, 0x81, 0x8b, 0x0f, 0xae, 0x9f,
+	0x3f, 0xd9, 0xcb, 0x65, 0x2e, 0x5d, 0x6c, 0x68, 0x5f, 0x5e, 0x76, 0xf8, 0x13, 0x3c, 0x3a, 0x09,
+	0x89, 0x17, 0x46, 0x2a, 0x96, 0xe3, 0x78, 0x44, 0x5e, 0x42, 0xfd, 0x0a, 0x17, 0x51, 0xed, 0xa0,
+	0x76, 0xd4, 0x1d, 0x7d, 0xf1, 0xee, 0xa6, 0xff, 0x59, 0xce, 0x4d, 0x31, 0x4b, 0x06, 0xa9, 0x2c,
+	0x87, 0xa9, 0x2c, 0xd1, 0x24, 0x13, 0xf3, 0xf0, 0x98, 0xf2, 0x44, 0x0f, 0x93, 0x85, 0x41, 0x3d,
+	0x38, 0xc5, 0xf9, 0xc8, 0x3e, 0xa8, 0x2d, 0x40, 0xf6, 0x60, 0xe3, 0x9a, 0x4d, 0x67, 0x18, 0xad,
+	0xdb, 0x4a, 0xd4, 0x83, 0xc3, 0x9f, 0xd7, 0x61, 0xfb, 0x44, 0x66, 0x78, 0x8e, 0x86, 0x65, 0xcc,
+	0xb0, 0xf1, 0x88, 0x44, 0xd0, 0x10, 0xac, 0x44, 0xf7, 0x1f, 0xdb, 0xa3, 0xc6, 0x9b, 0x9b, 0x7e,
+	0x8d, 0x3a, 0xc6, 0x96, 0x48, 0x99, 0xc1, 0x3c, 0x5a, 0x3f, 0xa8, 0x1f, 0xb5, 0xa9, 0x07, 0x56,
+	0xcf, 0x53, 0x29, 0xa2, 0xfa, 0xb2, 0xde, 0x32, 0xe4, 0x29, 0x34, 0xd9, 0xcc, 0x14, 0x52, 0x45,
+	0x8d, 0xa5, 0x58, 0xe0, 0x6c, 0x9e, 0xe6, 0x06, 0xa3, 0x8d, 0xe5, 0x3c, 0xcb, 0x90, 0x7d, 0xa8,
+	0xb3, 0x84, 0x47, 0x4d, 0xd7, 0xb2, 0x0f, 0x58, 0x82, 0x7c, 0x0c, 0x9d, 0x1f, 0xb5, 0x14, 0xb1,
+	0x4e, 0x0b, 0x2c, 0x59, 0xb4, 0xb9, 0x94, 0x08, 0x36, 0x70, 0xe1, 0x78, 0xf2, 0x25, 0x34, 0xa5,
+	0xe2, 0x39, 0x17, 0x51, 0xeb, 0xa0, 0x76, 0xd4, 0x39, 0x7e, 0x36, 0xf8, 0x97, 0xfd, 0x03, 0xdb,
+	0xf1, 0x2b, 0x27, 0x19, 0x8f, 0x68, 0x10, 0x7f, 0xdd, 0xf8, 0xf3, 0xd7, 0x7e, 0xed, 0xf0, 0xaf,
+	0x3a, 0x80, 0x0d, 0x9f, 0x89, 0x89, 0x1c, 0x8f, 0xc8, 0x07, 0xd0, 0x4e, 0x65, 0x86, 0x71, 0xc1,
+	0x74, 0xe1, 0x67, 0x40, 0x5b, 0x96, 0x38, 0x65, 0xba, 0x20, 0x11, 0x6c, 0xa6, 0x0a, 0x99, 0x91,
+	0xca, 0x99, 0xda, 0xa6, 0xf7, 0x90, 0x10, 0x68, 0x64, 0x58, 0xe9, 0xa8, 0xee, 0x8c, 0x72, 0x6f,
+	0xb2, 0x0f, 0xcd, 0x8a, 0x0b, 0x81, 0x99, 0x73, 0xa3, 0x45, 0x03, 0x22, 0x1f, 0x42, 0xb7, 0x44,
+	0x83, 0x8a, 0x8b, 0x3c, 0x96, 0x93, 0x89, 0xf3, 0xa3, 0x45, 0x3b, 0xf7, 0xdc, 0xab, 0xc9, 0x84,
+	0xbc, 0x80, 0x56, 0x19, 0x06, 0xe4, 0x5c, 0xe9, 0x1c, 0xf7, 0x57, 0xf6, 0xf4, 0x30, 0x45, 0x67,
+	0xcb, 0x1a, 0x7d, 0x9f, 0x46, 0x5e, 0x42, 0x9f, 0x0b, 0x83, 0xaa, 0x52, 0x68, 0x30, 0x8b, 0xed,
+	0x86, 0xb8, 0xc6, 0x32, 0xac, 0xa6, 0x72, 0x51, 0xa2, 0x30, 0xce, 0xcf, 0x2e, 0x7d, 0xb6, 0x24,
+	0x1b, 0x05, 0xd5, 0xb7, 0xef, 0x45, 0xe4, 0x1b, 0x78, 0xba, 0xb2, 0x8e, 0x9a, 0x09, 0xc3, 0x4b,
+	0x74, 0x96, 0x77, 0xe9, 0x93, 0x15, 0x45, 0xa8, 0x57, 0xd8, 0x7e, 0x83, 0xd8, 0xbb, 0xda, 0x76,
+	0x19, 0x9d, 0xc0, 0x39, 0x63, 0xf7, 0xa1, 0xa9, 0xe5, 0x4c, 0xa5, 0x18, 0x81, 0x0b, 0x06, 0x44,
+	0x3e, 0x81, 0x1d, 0xff, 0x8a, 0xaf, 0x51, 0xf1, 0x09, 0xc7, 0x2c, 0xea, 0x38, 0xb7, 0xb6, 0x3d,
+	0xfd, 0x7d, 0x60, 0xc9, 0x11, 0xec, 0x96, 0x6c, 0x1e, 0x97, 0x58, 0x4a, 0xb5, 0x88, 0x2b, 0x96,
+	0xa3, 0x8e, 0xba, 0x07, 0xb5, 0xa3, 0x2d, 0xba, 0x5d, 0xb2, 0xf9, 0xb9, 0xa3, 0xc7, 0x96, 0x0d,
+	0x53, 0x3f, 0x83, 0xee, 0xf2, 0x4e, 0x90, 0xff, 0x43, 0x2b, 0x2d, 0x18, 0x17, 0x31, 0xcf, 0xfc,
+	0x1d, 0xd0, 0x4d, 0x87, 0xcf, 0x32, 0x3b, 0x74, 0x96, 0x65, 0x0a, 0xb5, 0xbe, 0x1f, 0x7a, 0x80,
+	0xa1, 0xd4, 0x6f, 0xee, 0xa2, 0xfc, 0x15, 0x87, 0x25, 0xfa, 0x1f, 0x6c, 0x3a, 0x8f, 0x42, 0xb1,
+	0x06, 0x6d, 0x5a, 0xe8, 0x6b, 0xfd, 0xc7, 0x02, 0xed, 0xc1, 0xc6, 0x94, 0x25, 0x38, 0xf5, 0x57,
+	0x45, 0x3d, 0x20, 0xdf, 0x41, 0x57, 0xfb, 0x0f, 0x43, 0x6c, 0x16, 0x15, 0xba, 0x45, 0xda, 0x3e,
+	0xfe, 0x68, 0xc5, 0x2e, 0xfc, 0xe3, 0x2b, 0x72, 0xb9, 0xa8, 0x90, 0x76, 0xf4, 0x03, 0x20, 0x5f,
+	0x41, 0x97, 0x0b, 0x6e, 0xe2, 0x12, 0xb5, 0x66, 0xb9, 0xbf, 0xc1, 0xee, 0x68, 0xff, 0xdd, 0x4d,
+	0x9f, 0x50, 0xf6, 0xfa, 0x3e, 0xfd, 0xdc, 0x47, 0x69, 0xc7, 0x6a, 0x03, 0x20, 0x3d, 0x80, 0x4a,
+	0xc9, 0x6b, 0x14, 0x4c, 0xa4, 0xe8, 0xb6, 0xb1, 0x4d, 0x97, 0x18, 0xd2, 0x83, 0x0e, 0x4f, 0xd2,
+	0xb8, 0x92, 0xca, 0xd8, 0x86, 0xdd, 0x91, 0xd2, 0x36, 0x4f, 0xd2, 0xb1, 0x54, 0xe6, 0x2c, 0xb3,
+	0x9d, 0xb1, 0xac, 0xe4, 0xc2, 0xcd, 0xbd, 0x4d, 0x3d, 0x08, 0xde, 0x51, 0x20, 0x2f, 0x12, 0x2d,
+	0xa7, 0x33, 0x83, 0x97, 0xf3, 0xb1, 0xd4, 0xdc, 0x70, 0x29, 0xec, 0xc2, 0x24, 0x53, 0x99, 0x5e,
+	0xc5, 0x05, 0xf2, 0xbc, 0x30, 0xc1, 0xc3, 0x8e, 0xe3, 0x4e, 0x1d, 0x65, 0xe7, 0x65, 0xe6, 0x31,
+	0x17, 0x19, 0xce, 0x9d, 0x93, 0x0d, 0xba, 0x69, 0xe6, 0x67, 0x16, 0x7e, 0x7a, 0x05, 0x8f, 0x57,
+	0xd8, 0x41, 0x1e, 0xc1, 0xd6, 0x89, 0x54, 0x78, 0x22, 0x85, 0x46, 0xa1, 0x67, 0x7a, 0x77, 0xcd,
+	0x52, 0xf6, 0x80, 0x1e, 0xa8, 0x1a, 0x79, 0x0c, 0x3b, 0x17, 0x5c, 0xe4, 0xd3, 0x25, 0xdd, 0x3a,
+	0x01, 0x68, 0xfa, 0x0d, 0xda, 0xad, 0x93, 0x2d, 0x68, 0x5f, 0x2a, 0x26, 0x34, 0x47, 0x61, 0x76,
+	0x1b, 0xa3, 0xd3, 0x37, 0xb7, 0xbd, 0xda, 0xdb, 0xdb, 0x5e, 0xed, 0x8f, 0xdb, 0x5e, 0xed, 0x97,
+	0xbb, 0xde, 0xda, 0xdb, 0xbb, 0xde, 0xda, 0xef, 0x77, 0xbd, 0xb5, 0x1f, 0x06, 0x4b, 0x5f, 0xed,
+	0xa9, 0x54, 0x98, 0x31, 0xc1, 0x52, 0xae, 0xb4, 0x41, 0x16, 0x7e, 0x36, 0xe6, 0xe1, 0xaf, 0x9d,
+	0xac, 0x4e, 0x9a, 0xee, 0x27, 0xe1, 0xf3, 0xbf, 0x07, 0x00, 0xe1, 0x92, 0x3c, 0xa2, 0x5b, 0x06,
+	0x00, 0x00,
 }
 
 func (this *CodeMetadataPB) Equal(that interface{}) bool {
@@ -740,6 +764,9 @@ func (this *CodeInfoPB) Equal(that interface{}) bool {
 	if this.SourceVerified != that1.SourceVerified {
 		return false
 	}
+	if this.MaxMemoryPages != that1.MaxMemoryPages {
+		return false
+	}
 	return true
 }
 func (this *CodeOriginPB) Equal(that interface{}) bool {
@@ -809,6 +836,9 @@ func (this *ContractInfoPB) Equal(that interface{}) bool {
 	if this.IbcPortId != that1.IbcPortId {
 		return false
 	}
+	if this.Admin != that1.Admin {
+		return false
+	}
 	return true
 }
 func (m *ContractStoragePB) Marshal() (dAtA []byte, err error) {
@@ -954,6 +984,11 @@ func (m *CodeInfoPB) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.MaxMemoryPages != 0 {
+		i = encodeVarintContract(dAtA, i, uint64(m.MaxMemoryPages))
+		i--
+		dAtA[i] = 0x60
+	}
 	if m.SourceVerified {
 		i--
 		if m.SourceVerified {
@@ -1105,6 +1140,13 @@ func (m *ContractInfoPB) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Admin) > 0 {
+		i -= len(m.Admin)
+		copy(dAtA[i:], m.Admin)
+		i = encodeVarintContract(dAtA, i, uint64(len(m.Admin)))
+		i--
+		dAtA[i] = 0x4a
+	}
 	if len(m.IbcPortId) > 0 {
 		i -= len(m.IbcPortId)
 		copy(dAtA[i:], m.IbcPortId)
@@ -1304,6 +1346,9 @@ func (m *CodeInfoPB) Size() (n int) {
 	if m.SourceVerified {
 		n += 2
 	}
+	if m.MaxMemoryPages != 0 {
+		n += 1 + sovContract(uint64(m.MaxMemoryPages))
+	}
 	return n
 }
 
@@ -1356,6 +1401,10 @@ func (m *ContractInfoPB) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovContract(uint64(l))
 	}
+	l = len(m.Admin)
+	if l > 0 {
+		n += 1 + l + sovContract(uint64(l))
+	}
 	return n
 }
 
@@ -2166,6 +2215,25 @@ func (m *CodeInfoPB) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.SourceVerified = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxMemoryPages", wireType)
+			}
+			m.MaxMemoryPages = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowContract
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxMemoryPages |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipContract(dAtA[iNdEx:])
@@ -2530,6 +2598,38 @@ func (m *ContractInfoPB) Unmarshal(dAtA []byte) error {
 			}
 			m.IbcPortId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Admin", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowContract
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthContract
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthContract
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Admin = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipContract(dAtA[iNdEx:])
@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	mcodec "github.com/loredanacirstea/wasmx/codec"
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
 )
 
 // Coin is a string representation of the sdk.Coin type (more portable than sdk.Int)
@@ -135,6 +136,14 @@ type ContractDependency struct {
 	StorageType   ContractStorageType
 	Pinned        bool
 	MeteringOff   bool
+	// max memory pages of the contract instance, 0 for no limit
+	MaxMemoryPages uint32
+	// max nesting level of contract calls, 0 for no limit
+	MaxCallDepth uint32
+	// gas costs of host functions, from the module params
+	GasSchedule memc.GasSchedule
+	// max bytes of file content in the WASI data directory
+	WasiFsQuota uint64
 }
 
 func (v ContractDependency) Clone() *ContractDependency {
@@ -143,20 +152,24 @@ func (v ContractDependency) Clone() *ContractDependency {
 		deps[i] = dep.Clone()
 	}
 	return &ContractDependency{
-		Address:       mcodec.NewAccAddressPrefixed(cloneBytes(v.Address.Bytes()), v.Address.Prefix()),
-		Role:          v.Role,
-		RoleLabel:     v.RoleLabel,
-		Label:         v.Label,
-		StoreKey:      cloneBytes(v.StoreKey),
-		CodeFilePath:  v.CodeFilePath,
-		AotFilePath:   v.AotFilePath,
-		SystemDeps:    deps,
-		Bytecode:      cloneBytes(v.Bytecode),
-		CodeHash:      cloneBytes(v.CodeHash),
-		CodeId:        v.CodeId,
-		SystemDepsRaw: cloneStrings(v.SystemDepsRaw),
-		StorageType:   v.StorageType,
-		Pinned:        v.Pinned,
+		Address:        mcodec.NewAccAddressPrefixed(cloneBytes(v.Address.Bytes()), v.Address.Prefix()),
+		Role:           v.Role,
+		RoleLabel:      v.RoleLabel,
+		Label:          v.Label,
+		StoreKey:       cloneBytes(v.StoreKey),
+		CodeFilePath:   v.CodeFilePath,
+		AotFilePath:    v.AotFilePath,
+		SystemDeps:     deps,
+		Bytecode:       cloneBytes(v.Bytecode),
+		CodeHash:       cloneBytes(v.CodeHash),
+		CodeId:         v.CodeId,
+		SystemDepsRaw:  cloneStrings(v.SystemDepsRaw),
+		StorageType:    v.StorageType,
+		Pinned:         v.Pinned,
+		MaxMemoryPages: v.MaxMemoryPages,
+		MaxCallDepth:   v.MaxCallDepth,
+		GasSchedule:    v.GasSchedule,
+		WasiFsQuota:    v.WasiFsQuota,
 	}
 }
 
@@ -88,6 +88,21 @@ var (
 
 	ErrInvalidCoreContractCall = sdkerr.Register(DefaultCodespace, 31, "invalid core contract call")
 
+	// ErrMemoryLimit error when a contract grows its memory up to the max_memory_pages limit
+	ErrMemoryLimit = sdkerr.Register(DefaultCodespace, 32, "contract memory limit exceeded")
+
+	// ErrCallDepth error when contract calls are nested deeper than the max_call_depth limit
+	ErrCallDepth = sdkerr.Register(DefaultCodespace, 33, "max call depth exceeded")
+
+	// ErrExecutionTimeout error when a query or non-consensus call runs past its wall-clock deadline
+	ErrExecutionTimeout = sdkerr.Register(DefaultCodespace, 34, "contract execution deadline exceeded")
+
+	// ErrWasmFeatures error when uploaded wasm code uses features or sizes not allowed by the wasm_validation params
+	ErrWasmFeatures = sdkerr.Register(DefaultCodespace, 35, "wasm module uses features which are not allowed")
+
+	// ErrReservedStorageKey error when a contract writes or deletes a storage key reserved by the host, like the WASI filesystem keys
+	ErrReservedStorageKey = sdkerr.Register(DefaultCodespace, 36, "reserved storage key")
+
 	// ErrInvalidCode error if an attribute/event from the contract is invalid
 	_ = sdkerr.Register(DefaultCodespace, 45, "invalid code id")
 )
@@ -12,9 +12,12 @@ const (
 	EventTypeExecute      = "execute"
 	EventTypeExecuteEth   = "execute-eth"
 	EventTypeMigrate      = "migrate"
+	EventTypeUpdateAdmin  = "update_contract_admin"
 	EventTypePinCode      = "pin_code"
 	EventTypeUnpinCode    = "unpin_code"
 	EventTypeRegisterRole = "register_role"
+	EventTypeUpdateParams = "update_params"
+	EventTypeBlockBloom   = "block_bloom"
 )
 
 // event attributes returned from contract execution
@@ -24,6 +27,7 @@ const (
 	AttributeKeyContractAddr        = "contract_address"
 	AttributeKeyContractAddrCreated = "contract_address_created"
 	AttributeKeyCodeID              = "code_id"
+	AttributeKeyNewAdmin            = "new_admin_address"
 	AttributeKeyChecksum            = "code_checksum"
 	AttributeKeyResultDataHex       = "result"
 	AttributeKeyRequiredCapability  = "required_capability"
@@ -33,6 +37,10 @@ const (
 	AttributeKeyRole            = "role"
 	AttributeKeyRoleLabel       = "role_label"
 	AttributeKeyContractAddress = "contract_address"
+
+	AttributeKeyGasScheduleVersion = "gas_schedule_version"
+
+	AttributeKeyBloom = "bloom"
 )
 
 // wasmx
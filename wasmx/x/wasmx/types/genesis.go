@@ -39,5 +39,10 @@ func (gs GenesisState) Validate() error {
 			return err
 		}
 	}
+	for _, contractAdmin := range gs.ContractAdmins {
+		if contractAdmin.ContractAddress == "" || contractAdmin.Admin == "" {
+			return fmt.Errorf("empty contract admin entry")
+		}
+	}
 	return nil
 }
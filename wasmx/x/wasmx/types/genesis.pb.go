@@ -37,6 +37,8 @@ type GenesisState struct {
 	// initiate pinned/AOT compiled contracts from a provided folder
 	// instead of compiling the contracts from wasm
 	CompiledFolderPath string `protobuf:"bytes,8,opt,name=compiled_folder_path,json=compiledFolderPath,proto3" json:"compiled_folder_path,omitempty"`
+	// contract admins, kept by the wasmx module outside of the code registry
+	ContractAdmins []ContractAdmin `protobuf:"bytes,9,rep,name=contract_admins,json=contractAdmins,proto3" json:"contract_admins,omitempty"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -128,6 +130,13 @@ func (m *GenesisState) GetCompiledFolderPath() string {
 	return ""
 }
 
+func (m *GenesisState) GetContractAdmins() []ContractAdmin {
+	if m != nil {
+		return m.ContractAdmins
+	}
+	return nil
+}
+
 type SystemContract struct {
 	Address     string              `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	Label       string              `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
@@ -143,6 +152,8 @@ type SystemContract struct {
 	Metadata      CodeMetadataPB      `protobuf:"bytes,10,opt,name=metadata,proto3" json:"metadata"`
 	ContractState []ContractStoragePB `protobuf:"bytes,11,rep,name=contract_state,json=contractState,proto3" json:"contract_state"`
 	Source        []byte              `protobuf:"bytes,12,opt,name=source,proto3" json:"source,omitempty"`
+	// overrides the max_memory_pages module param, if not 0
+	MaxMemoryPages uint32 `protobuf:"varint,13,opt,name=max_memory_pages,json=maxMemoryPages,proto3" json:"max_memory_pages,omitempty"`
 }
 
 func (m *SystemContract) Reset()         { *m = SystemContract{} }
@@ -262,6 +273,13 @@ func (m *SystemContract) GetSource() []byte {
 	return nil
 }
 
+func (m *SystemContract) GetMaxMemoryPages() uint32 {
+	if m != nil {
+		return m.MaxMemoryPages
+	}
+	return 0
+}
+
 // Code - for importing and exporting code data
 type Code struct {
 	CodeId    uint64     `protobuf:"varint,1,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
@@ -384,6 +402,59 @@ func (m *Contract) GetContractState() []ContractStoragePB {
 	return nil
 }
 
+// ContractAdmin - for importing and exporting contract admins
+type ContractAdmin struct {
+	ContractAddress string `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	Admin           string `protobuf:"bytes,2,opt,name=admin,proto3" json:"admin,omitempty"`
+}
+
+func (m *ContractAdmin) Reset()         { *m = ContractAdmin{} }
+func (m *ContractAdmin) String() string { return proto.CompactTextString(m) }
+func (*ContractAdmin) ProtoMessage()    {}
+func (*ContractAdmin) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfefbcf06aaa1e73, []int{4}
+}
+func (m *ContractAdmin) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ContractAdmin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ContractAdmin.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ContractAdmin) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractAdmin.Merge(m, src)
+}
+func (m *ContractAdmin) XXX_Size() int {
+	return m.Size()
+}
+func (m *ContractAdmin) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractAdmin.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractAdmin proto.InternalMessageInfo
+
+func (m *ContractAdmin) GetContractAddress() string {
+	if m != nil {
+		return m.ContractAddress
+	}
+	return ""
+}
+
+func (m *ContractAdmin) GetAdmin() string {
+	if m != nil {
+		return m.Admin
+	}
+	return ""
+}
+
 // Sequence key and value of an id generation counter
 type Sequence struct {
 	IdKey []byte `protobuf:"bytes,1,opt,name=id_key,json=idKey,proto3" json:"id_key,omitempty"`
@@ -394,7 +465,7 @@ func (m *Sequence) Reset()         { *m = Sequence{} }
 func (m *Sequence) String() string { return proto.CompactTextString(m) }
 func (*Sequence) ProtoMessage()    {}
 func (*Sequence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dfefbcf06aaa1e73, []int{4}
+	return fileDescriptor_dfefbcf06aaa1e73, []int{5}
 }
 func (m *Sequence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -442,65 +513,70 @@ func init() {
 	proto.RegisterType((*SystemContract)(nil), "mythos.wasmx.v1.SystemContract")
 	proto.RegisterType((*Code)(nil), "mythos.wasmx.v1.Code")
 	proto.RegisterType((*Contract)(nil), "mythos.wasmx.v1.Contract")
+	proto.RegisterType((*ContractAdmin)(nil), "mythos.wasmx.v1.ContractAdmin")
 	proto.RegisterType((*Sequence)(nil), "mythos.wasmx.v1.Sequence")
 }
 
 func init() { proto.RegisterFile("mythos/wasmx/v1/genesis.proto", fileDescriptor_dfefbcf06aaa1e73) }
 
 var fileDescriptor_dfefbcf06aaa1e73 = []byte{
-	// 819 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x4f, 0x8f, 0xdb, 0x44,
-	0x14, 0x5f, 0x13, 0xc7, 0x9b, 0x1d, 0x9b, 0xdd, 0x6a, 0xd8, 0xee, 0x4e, 0xb7, 0x34, 0x09, 0x81,
-	0x43, 0x90, 0x50, 0xc2, 0x06, 0xa1, 0x0a, 0x0e, 0x48, 0x9b, 0x22, 0x4a, 0x41, 0x55, 0x23, 0xa7,
-	0x27, 0x2e, 0xd6, 0xc4, 0x7e, 0x49, 0x2c, 0x6c, 0x8f, 0xf1, 0x4c, 0xd2, 0xfa, 0xc2, 0x67, 0xe8,
-	0xe7, 0xe0, 0x93, 0xf4, 0x58, 0x71, 0xe2, 0xb4, 0x42, 0xbb, 0x37, 0x3e, 0x02, 0x27, 0x34, 0xe3,
-	0xb1, 0xf3, 0xa7, 0x89, 0xf6, 0xc0, 0x29, 0x79, 0xef, 0xf7, 0xde, 0x6f, 0xde, 0x7b, 0xf3, 0x7b,
-	0x63, 0xf4, 0x28, 0xce, 0xc5, 0x9c, 0xf1, 0xfe, 0x2b, 0xca, 0xe3, 0xd7, 0xfd, 0xe5, 0x65, 0x7f,
-	0x06, 0x09, 0xf0, 0x90, 0xf7, 0xd2, 0x8c, 0x09, 0x86, 0x4f, 0x0a, 0xb8, 0xa7, 0xe0, 0xde, 0xf2,
-	0xf2, 0xe2, 0x74, 0xc6, 0x66, 0x4c, 0x61, 0x7d, 0xf9, 0xaf, 0x08, 0xbb, 0xf8, 0x78, 0x9b, 0x25,
-	0xa5, 0x19, 0x8d, 0x35, 0xc9, 0x45, 0x73, 0x1b, 0xf5, 0x59, 0x22, 0x32, 0xea, 0x0b, 0x8d, 0x5f,
-	0x6c, 0xe3, 0x19, 0x8b, 0xa0, 0xc0, 0x3a, 0x7f, 0x98, 0xc8, 0x79, 0x5a, 0x94, 0x34, 0x16, 0x54,
-	0x00, 0xfe, 0x1a, 0x59, 0x05, 0x39, 0x31, 0xda, 0x46, 0xd7, 0x1e, 0x9c, 0xf7, 0xb6, 0x4a, 0xec,
-	0x8d, 0x14, 0x3c, 0x34, 0xdf, 0x5e, 0xb7, 0x0e, 0x5c, 0x1d, 0x8c, 0xbf, 0x45, 0x0f, 0x26, 0x8c,
-	0x09, 0x2e, 0x32, 0x9a, 0x7a, 0xd4, 0xf7, 0xd9, 0x22, 0x11, 0x1e, 0x0d, 0x82, 0x0c, 0x38, 0x27,
-	0x1f, 0xb4, 0x8d, 0xee, 0x91, 0x7b, 0x5e, 0x05, 0x5c, 0x15, 0xf8, 0x55, 0x01, 0xe3, 0x4b, 0x54,
-	0x97, 0x15, 0x71, 0x52, 0x6b, 0xd7, 0xba, 0xf6, 0xe0, 0xfe, 0x7b, 0x27, 0xba, 0x2c, 0x02, 0x7d,
-	0x5e, 0x11, 0x89, 0x47, 0xe8, 0x1e, 0xcf, 0xb9, 0x80, 0xd8, 0x2b, 0x7b, 0xe5, 0xc4, 0x54, 0xd9,
-	0xad, 0xf7, 0xb2, 0xc7, 0x2a, 0xf0, 0x89, 0x8e, 0xd3, 0x3c, 0x27, 0x7c, 0xc3, 0xcb, 0xf1, 0xf7,
-	0xa8, 0xee, 0xb3, 0x00, 0x38, 0xa9, 0xef, 0x29, 0xe2, 0x09, 0x0b, 0x60, 0x78, 0x2e, 0x93, 0xff,
-	0xb9, 0x6e, 0x9d, 0xa8, 0xd8, 0x2f, 0x58, 0x1c, 0x0a, 0x88, 0x53, 0x91, 0xbb, 0x45, 0x32, 0x7e,
-	0x89, 0x8e, 0x56, 0x05, 0x59, 0x8a, 0xe9, 0xc1, 0x0e, 0x26, 0x5d, 0xca, 0x43, 0xcd, 0xf6, 0x51,
-	0x95, 0xb3, 0xc6, 0xb8, 0x22, 0x92, 0xac, 0x1c, 0x7e, 0x5b, 0x40, 0xe2, 0x03, 0x27, 0x87, 0x7b,
-	0x58, 0xc7, 0x3a, 0x62, 0xc5, 0x5a, 0xe5, 0xac, 0xb3, 0x56, 0x4e, 0xfc, 0x25, 0x3a, 0xf5, 0x59,
-	0x9c, 0x86, 0x11, 0x04, 0xde, 0x94, 0x45, 0x01, 0x64, 0x5e, 0x4a, 0xc5, 0x9c, 0x34, 0xd4, 0x6d,
-	0xe1, 0x12, 0xfb, 0x41, 0x41, 0x23, 0x2a, 0xe6, 0x9d, 0x37, 0x26, 0x3a, 0xde, 0x9c, 0x26, 0x26,
-	0xe8, 0xb0, 0xbc, 0x65, 0x43, 0xe5, 0x95, 0x26, 0x3e, 0x45, 0xf5, 0x88, 0x4e, 0x20, 0xd2, 0xb7,
-	0x5f, 0x18, 0xf8, 0x29, 0x72, 0xb8, 0x60, 0x19, 0x9d, 0x81, 0x27, 0xf2, 0x14, 0x48, 0xad, 0x6d,
-	0x74, 0x8f, 0x07, 0x9f, 0xed, 0x9d, 0xd1, 0xb8, 0x08, 0x7e, 0x99, 0xa7, 0xe0, 0xda, 0x7c, 0x65,
-	0xe0, 0x6f, 0x90, 0x13, 0x26, 0xa1, 0xf0, 0x62, 0xe0, 0x9c, 0xce, 0x80, 0x98, 0x6d, 0xa3, 0xeb,
-	0x0c, 0xcf, 0xfe, 0xbd, 0x6e, 0x61, 0x97, 0xbe, 0x2a, 0xd3, 0x9f, 0x17, 0xa8, 0x6b, 0xcb, 0x58,
-	0x6d, 0xe0, 0x33, 0x64, 0xa5, 0x61, 0x92, 0x40, 0x40, 0xea, 0x6d, 0xa3, 0xdb, 0x70, 0xb5, 0x25,
-	0xfd, 0x09, 0x15, 0xe1, 0x12, 0x88, 0x55, 0xf8, 0x0b, 0x0b, 0x7f, 0x82, 0x9c, 0x18, 0x04, 0x64,
-	0x61, 0x32, 0xf3, 0xd8, 0x74, 0x4a, 0x0e, 0x15, 0x6a, 0x97, 0xbe, 0x17, 0xd3, 0x29, 0x7e, 0x8c,
-	0x4c, 0x29, 0x4c, 0x35, 0x3b, 0x7b, 0xf0, 0xe9, 0x1d, 0x1a, 0x94, 0x7a, 0x76, 0x55, 0x02, 0xc6,
-	0xc8, 0x0c, 0x20, 0xe5, 0xe4, 0xa8, 0x5d, 0xeb, 0x1e, 0xb9, 0xea, 0x3f, 0xbe, 0x42, 0x8d, 0x18,
-	0x04, 0x0d, 0xa8, 0xa0, 0x04, 0x29, 0xc2, 0xd6, 0x4e, 0x35, 0x3e, 0xd7, 0x41, 0xa3, 0xa1, 0x16,
-	0x75, 0x95, 0x86, 0x5f, 0xa0, 0xe3, 0x52, 0x3e, 0x1e, 0x97, 0x7b, 0x4d, 0x6c, 0x25, 0x9b, 0xce,
-	0x5d, 0x83, 0xae, 0xb8, 0x3e, 0xf4, 0x2b, 0x40, 0x3e, 0x0b, 0x67, 0xc8, 0xe2, 0x6c, 0x91, 0xf9,
-	0x40, 0x1c, 0x39, 0x68, 0x57, 0x5b, 0x9d, 0xdf, 0x91, 0x29, 0x4b, 0xc1, 0xe7, 0xe8, 0x50, 0x6e,
-	0x80, 0x17, 0x06, 0x4a, 0x07, 0xa6, 0x6b, 0x49, 0xf3, 0x59, 0x80, 0xbf, 0x93, 0x1b, 0x21, 0x81,
-	0x64, 0xca, 0x94, 0x14, 0xec, 0xc1, 0xc3, 0x9d, 0xdd, 0x3c, 0x4b, 0xa6, 0x6c, 0xd5, 0x89, 0xaf,
-	0x3d, 0xf8, 0x11, 0x42, 0x2a, 0x7f, 0x92, 0x0b, 0xf5, 0x42, 0xc8, 0xc3, 0x15, 0xe3, 0x50, 0x3a,
-	0x3a, 0x7f, 0x1a, 0xa8, 0x51, 0x89, 0xf1, 0x73, 0x74, 0xaf, 0xea, 0x7a, 0x53, 0x95, 0x27, 0xa5,
-	0xbf, 0x7c, 0x73, 0x7e, 0x42, 0x55, 0x83, 0xeb, 0xa5, 0xb5, 0xf6, 0xce, 0x67, 0xa3, 0x3c, 0xc7,
-	0x5f, 0xf3, 0xee, 0x18, 0x76, 0xed, 0x7f, 0x0d, 0xbb, 0xf3, 0x18, 0x35, 0xca, 0x6d, 0xc6, 0xf7,
-	0x91, 0x15, 0x06, 0xde, 0xaf, 0x90, 0xab, 0x4e, 0x1c, 0xb7, 0x1e, 0x06, 0x3f, 0x43, 0x2e, 0xb7,
-	0x6b, 0x49, 0xa3, 0x05, 0xa8, 0xba, 0x4d, 0xb7, 0x30, 0x86, 0x3f, 0xbe, 0xbd, 0x69, 0x1a, 0xef,
-	0x6e, 0x9a, 0xc6, 0xdf, 0x37, 0x4d, 0xe3, 0xcd, 0x6d, 0xf3, 0xe0, 0xdd, 0x6d, 0xf3, 0xe0, 0xaf,
-	0xdb, 0xe6, 0xc1, 0x2f, 0xbd, 0x59, 0x28, 0xe6, 0x8b, 0x49, 0xcf, 0x67, 0x71, 0x3f, 0x62, 0x19,
-	0x04, 0x34, 0xa1, 0x7e, 0x98, 0x71, 0x01, 0x54, 0x7f, 0x17, 0x5e, 0xeb, 0x5f, 0xb9, 0x96, 0x7c,
-	0x62, 0xa9, 0xcf, 0xc3, 0x57, 0xff, 0x05, 0x00, 0x00, 0xff, 0xff, 0x93, 0x32, 0x02, 0xf9, 0xc0,
-	0x06, 0x00, 0x00,
+	// 893 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xcd, 0x6e, 0x23, 0x45,
+	0x10, 0xce, 0x10, 0xdb, 0x89, 0xcb, 0x4e, 0x1c, 0x35, 0xd9, 0xa4, 0x93, 0x65, 0x6d, 0xaf, 0xe1,
+	0x60, 0x24, 0x64, 0x13, 0x23, 0xb4, 0x82, 0x03, 0x52, 0xbc, 0x88, 0x65, 0x41, 0xd1, 0x5a, 0x93,
+	0x3d, 0x71, 0x19, 0xb5, 0x67, 0xca, 0xce, 0x80, 0x67, 0x7a, 0x98, 0x6e, 0x67, 0x33, 0x17, 0x9e,
+	0x81, 0x13, 0x0f, 0xc3, 0x13, 0xec, 0x71, 0xc5, 0x89, 0x53, 0x84, 0x92, 0x1b, 0x8f, 0xc0, 0x09,
+	0xf5, 0xcf, 0x8c, 0x1d, 0x6f, 0xac, 0x15, 0xe2, 0x64, 0x57, 0x7d, 0xf5, 0x7d, 0xfe, 0xba, 0xab,
+	0xaa, 0x0d, 0x8f, 0xa2, 0x4c, 0x5e, 0x70, 0xd1, 0x7f, 0xc5, 0x44, 0x74, 0xd5, 0xbf, 0x3c, 0xe9,
+	0x4f, 0x31, 0x46, 0x11, 0x8a, 0x5e, 0x92, 0x72, 0xc9, 0x49, 0xc3, 0xc0, 0x3d, 0x0d, 0xf7, 0x2e,
+	0x4f, 0x8e, 0xf7, 0xa7, 0x7c, 0xca, 0x35, 0xd6, 0x57, 0xdf, 0x4c, 0xd9, 0xf1, 0x07, 0xab, 0x2a,
+	0x09, 0x4b, 0x59, 0x64, 0x45, 0x8e, 0x9b, 0xab, 0xa8, 0xcf, 0x63, 0x99, 0x32, 0x5f, 0x5a, 0xfc,
+	0x78, 0x15, 0x4f, 0xf9, 0x0c, 0x0d, 0xd6, 0xf9, 0xad, 0x0c, 0xf5, 0x67, 0xc6, 0xd2, 0xb9, 0x64,
+	0x12, 0xc9, 0xe7, 0x50, 0x31, 0xe2, 0xd4, 0x69, 0x3b, 0xdd, 0xda, 0xe0, 0xb0, 0xb7, 0x62, 0xb1,
+	0x37, 0xd2, 0xf0, 0xb0, 0xf4, 0xfa, 0xba, 0xb5, 0xe1, 0xda, 0x62, 0xf2, 0x25, 0x1c, 0x8d, 0x39,
+	0x97, 0x42, 0xa6, 0x2c, 0xf1, 0x98, 0xef, 0xf3, 0x79, 0x2c, 0x3d, 0x16, 0x04, 0x29, 0x0a, 0x41,
+	0xdf, 0x6b, 0x3b, 0xdd, 0xaa, 0x7b, 0x58, 0x14, 0x9c, 0x1a, 0xfc, 0xd4, 0xc0, 0xe4, 0x04, 0xca,
+	0xca, 0x91, 0xa0, 0x9b, 0xed, 0xcd, 0x6e, 0x6d, 0xf0, 0xe0, 0xad, 0x5f, 0x74, 0xf9, 0x0c, 0xed,
+	0xef, 0x99, 0x4a, 0x32, 0x82, 0x3d, 0x91, 0x09, 0x89, 0x91, 0x97, 0x9f, 0x55, 0xd0, 0x92, 0x66,
+	0xb7, 0xde, 0x62, 0x9f, 0xeb, 0xc2, 0xa7, 0xb6, 0xce, 0xea, 0x34, 0xc4, 0x9d, 0xac, 0x20, 0x5f,
+	0x43, 0xd9, 0xe7, 0x01, 0x0a, 0x5a, 0x5e, 0x63, 0xe2, 0x29, 0x0f, 0x70, 0x78, 0xa8, 0xc8, 0x7f,
+	0x5f, 0xb7, 0x1a, 0xba, 0xf6, 0x13, 0x1e, 0x85, 0x12, 0xa3, 0x44, 0x66, 0xae, 0x21, 0x93, 0x97,
+	0x50, 0x5d, 0x18, 0xaa, 0x68, 0xa5, 0xa3, 0x7b, 0x94, 0xac, 0x95, 0x87, 0x56, 0xed, 0xfd, 0x82,
+	0xb3, 0xa4, 0xb8, 0x10, 0x52, 0xaa, 0x02, 0x7f, 0x9e, 0x63, 0xec, 0xa3, 0xa0, 0x5b, 0x6b, 0x54,
+	0xcf, 0x6d, 0xc5, 0x42, 0xb5, 0xe0, 0x2c, 0xab, 0x16, 0x49, 0xf2, 0x29, 0xec, 0xfb, 0x3c, 0x4a,
+	0xc2, 0x19, 0x06, 0xde, 0x84, 0xcf, 0x02, 0x4c, 0xbd, 0x84, 0xc9, 0x0b, 0xba, 0xad, 0xbb, 0x45,
+	0x72, 0xec, 0x1b, 0x0d, 0x8d, 0x98, 0xbc, 0x20, 0x3f, 0x42, 0x23, 0x37, 0xe5, 0xb1, 0x20, 0x0a,
+	0x63, 0x41, 0xab, 0xda, 0x4d, 0x73, 0xed, 0x19, 0x4f, 0x55, 0xd9, 0xf0, 0xb1, 0xb5, 0x74, 0xb4,
+	0x42, 0x5f, 0x32, 0xb6, 0xeb, 0x2f, 0x33, 0x44, 0xe7, 0xf7, 0x12, 0xec, 0xde, 0xed, 0x1c, 0xa1,
+	0xb0, 0x95, 0x4f, 0x94, 0xa3, 0x3d, 0xe6, 0x21, 0xd9, 0x87, 0xf2, 0x8c, 0x8d, 0x71, 0x66, 0x27,
+	0xcd, 0x04, 0xe4, 0x19, 0xd4, 0x85, 0xe4, 0x29, 0x9b, 0xa2, 0x27, 0xb3, 0x04, 0xe9, 0x66, 0xdb,
+	0xe9, 0xee, 0x0e, 0x3e, 0x5a, 0xeb, 0xf5, 0xdc, 0x14, 0xbf, 0xcc, 0x12, 0x74, 0x6b, 0x62, 0x11,
+	0x90, 0x2f, 0xa0, 0x1e, 0xc6, 0xa1, 0xf4, 0x22, 0x14, 0x82, 0x4d, 0x91, 0x96, 0xda, 0x4e, 0xb7,
+	0x3e, 0x3c, 0xf8, 0xe7, 0xba, 0x45, 0x5c, 0xf6, 0x2a, 0xa7, 0x9f, 0x19, 0xd4, 0xad, 0xa9, 0x5a,
+	0x1b, 0x90, 0x03, 0xa8, 0x24, 0x61, 0x1c, 0x63, 0x40, 0xcb, 0x6d, 0xa7, 0xbb, 0xed, 0xda, 0x48,
+	0xe5, 0x63, 0x26, 0xc3, 0x4b, 0xa4, 0x15, 0x93, 0x37, 0x11, 0x79, 0x0c, 0xf5, 0x08, 0x25, 0xa6,
+	0x61, 0x3c, 0xf5, 0xf8, 0x64, 0x42, 0xb7, 0x34, 0x5a, 0xcb, 0x73, 0x2f, 0x26, 0x13, 0xf2, 0x04,
+	0x4a, 0x6a, 0x09, 0x74, 0x9f, 0x6a, 0x83, 0x0f, 0xdf, 0x31, 0xef, 0x6a, 0x77, 0x5c, 0x4d, 0x20,
+	0x04, 0x4a, 0x01, 0x26, 0xa6, 0x67, 0x55, 0x57, 0x7f, 0x27, 0xa7, 0xb0, 0x1d, 0xa1, 0x64, 0x01,
+	0x93, 0x8c, 0x42, 0xdb, 0xb9, 0x77, 0x81, 0xd4, 0xe4, 0x9f, 0xd9, 0xa2, 0xd1, 0xd0, 0x2e, 0x50,
+	0x41, 0x23, 0x2f, 0xa0, 0xe8, 0x9d, 0x27, 0x24, 0x93, 0x48, 0x6b, 0x7a, 0x28, 0x3a, 0xef, 0xba,
+	0xe8, 0x42, 0x6b, 0xc7, 0x2f, 0x00, 0xf5, 0x04, 0x1d, 0x40, 0x45, 0xf0, 0x79, 0xea, 0x23, 0xad,
+	0xab, 0x8b, 0x76, 0x6d, 0x44, 0xba, 0xb0, 0x17, 0xb1, 0x2b, 0x2f, 0xc2, 0x88, 0xa7, 0x99, 0x97,
+	0xb0, 0x29, 0x0a, 0xba, 0xd3, 0x76, 0xba, 0x3b, 0xee, 0x6e, 0xc4, 0xae, 0xce, 0x74, 0x7a, 0xa4,
+	0xb2, 0x9d, 0x5f, 0xa0, 0xa4, 0x4c, 0x93, 0x43, 0xd8, 0x52, 0x7b, 0xe9, 0x85, 0x81, 0x9e, 0x98,
+	0x92, 0x5b, 0x51, 0xe1, 0xf3, 0x80, 0x7c, 0x05, 0x55, 0x03, 0xc4, 0x13, 0xae, 0x87, 0xa6, 0x36,
+	0x78, 0x78, 0xef, 0xb9, 0x9f, 0xc7, 0x13, 0xbe, 0x38, 0xb3, 0x6f, 0x33, 0xe4, 0x11, 0x80, 0xe6,
+	0x8f, 0x33, 0xa9, 0xdf, 0x2d, 0x65, 0x53, 0x2b, 0x0e, 0x55, 0xa2, 0xf3, 0x87, 0x03, 0xdb, 0xc5,
+	0xd8, 0x7e, 0x0c, 0x7b, 0x4b, 0x63, 0xbf, 0x3c, 0xbf, 0x8d, 0xc5, 0xcc, 0x9b, 0x39, 0xfe, 0x0e,
+	0x8a, 0xab, 0x58, 0xb6, 0xd6, 0x5a, 0x7b, 0x93, 0x77, 0xec, 0xd5, 0xfd, 0xa5, 0xec, 0x3d, 0x6d,
+	0xd9, 0xfc, 0x5f, 0x6d, 0xe9, 0x8c, 0x60, 0xe7, 0xce, 0x56, 0xff, 0x97, 0x83, 0xed, 0x43, 0x59,
+	0x6f, 0x7c, 0xbe, 0xa0, 0x3a, 0xe8, 0x3c, 0x81, 0xed, 0xfc, 0xd5, 0x22, 0x0f, 0xa0, 0x12, 0x06,
+	0xde, 0x4f, 0x98, 0x69, 0x89, 0xba, 0x5b, 0x0e, 0x83, 0xef, 0x31, 0x53, 0xc4, 0x4b, 0x36, 0x9b,
+	0xa3, 0x26, 0x96, 0x5c, 0x13, 0x0c, 0xbf, 0x7d, 0x7d, 0xd3, 0x74, 0xde, 0xdc, 0x34, 0x9d, 0xbf,
+	0x6e, 0x9a, 0xce, 0xaf, 0xb7, 0xcd, 0x8d, 0x37, 0xb7, 0xcd, 0x8d, 0x3f, 0x6f, 0x9b, 0x1b, 0x3f,
+	0xf4, 0xa6, 0xa1, 0xbc, 0x98, 0x8f, 0x7b, 0x3e, 0x8f, 0xfa, 0x33, 0x9e, 0x62, 0xc0, 0x62, 0xe6,
+	0x87, 0xa9, 0x90, 0xc8, 0xec, 0xff, 0xdf, 0x95, 0xfd, 0x54, 0x4f, 0x82, 0x18, 0x57, 0xf4, 0xdf,
+	0xe0, 0x67, 0xff, 0x0e, 0x00, 0xed, 0x33, 0x22, 0x3e, 0xa8, 0x07, 0x00, 0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -523,6 +599,20 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ContractAdmins) > 0 {
+		for iNdEx := len(m.ContractAdmins) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ContractAdmins[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
 	if len(m.CompiledFolderPath) > 0 {
 		i -= len(m.CompiledFolderPath)
 		copy(dAtA[i:], m.CompiledFolderPath)
@@ -640,6 +730,11 @@ func (m *SystemContract) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.MaxMemoryPages != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.MaxMemoryPages))
+		i--
+		dAtA[i] = 0x68
+	}
 	if len(m.Source) > 0 {
 		i -= len(m.Source)
 		copy(dAtA[i:], m.Source)
@@ -850,6 +945,43 @@ func (m *Contract) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ContractAdmin) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ContractAdmin) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContractAdmin) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Admin) > 0 {
+		i -= len(m.Admin)
+		copy(dAtA[i:], m.Admin)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.Admin)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ContractAddress) > 0 {
+		i -= len(m.ContractAddress)
+		copy(dAtA[i:], m.ContractAddress)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.ContractAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Sequence) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -942,6 +1074,12 @@ func (m *GenesisState) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovGenesis(uint64(l))
 	}
+	if len(m.ContractAdmins) > 0 {
+		for _, e := range m.ContractAdmins {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -997,6 +1135,9 @@ func (m *SystemContract) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovGenesis(uint64(l))
 	}
+	if m.MaxMemoryPages != 0 {
+		n += 1 + sovGenesis(uint64(m.MaxMemoryPages))
+	}
 	return n
 }
 
@@ -1039,6 +1180,23 @@ func (m *Contract) Size() (n int) {
 	return n
 }
 
+func (m *ContractAdmin) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContractAddress)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	l = len(m.Admin)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	return n
+}
+
 func (m *Sequence) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1357,6 +1515,40 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 			}
 			m.CompiledFolderPath = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractAdmins", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContractAdmins = append(m.ContractAdmins, ContractAdmin{})
+			if err := m.ContractAdmins[len(m.ContractAdmins)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -1753,6 +1945,25 @@ func (m *SystemContract) Unmarshal(dAtA []byte) error {
 				m.Source = []byte{}
 			}
 			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxMemoryPages", wireType)
+			}
+			m.MaxMemoryPages = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxMemoryPages |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -2059,6 +2270,120 @@ func (m *Contract) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ContractAdmin) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractAdmin: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractAdmin: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContractAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Admin", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Admin = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Sequence) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
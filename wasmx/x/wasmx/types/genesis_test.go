@@ -39,6 +39,39 @@ func TestGenesisState_Validate(t *testing.T) {
 			genState: &types.GenesisState{},
 			valid:    false,
 		},
+		{
+			desc: "empty contract admin",
+			genState: &types.GenesisState{
+				Params:                  types.DefaultParams(),
+				BootstrapAccountAddress: bootstrapAccount,
+				ContractAdmins:          []types.ContractAdmin{{ContractAddress: bootstrapAccount}},
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicate gas schedule entry",
+			genState: &types.GenesisState{
+				Params: types.NewParams(types.DefaultMaxMemoryPages, types.DefaultMaxCallDepth, types.GasSchedule{
+					Version: 2,
+					HostFunctions: []types.HostFunctionGas{
+						{Module: "wasmx", Name: "storageStore", Base: 2000, PerByte: 30},
+						{Module: "wasmx", Name: "storageStore", Base: 1000},
+					},
+				}, types.DefaultWasmValidation, types.DefaultWasiFsQuota),
+				BootstrapAccountAddress: bootstrapAccount,
+			},
+			valid: false,
+		},
+		{
+			desc: "unknown wasm feature",
+			genState: &types.GenesisState{
+				Params: types.NewParams(types.DefaultMaxMemoryPages, types.DefaultMaxCallDepth, types.DefaultGasSchedule, types.WasmValidation{
+					Features: []string{"floats", "gc"},
+				}, types.DefaultWasiFsQuota),
+				BootstrapAccountAddress: bootstrapAccount,
+			},
+			valid: false,
+		},
 		// this line is used by starport scaffolding # types/genesis/testcase
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
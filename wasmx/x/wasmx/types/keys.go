@@ -36,12 +36,17 @@ const (
 	contractStorePrefix = iota + 1
 	prefixSystemContract
 	cacheSystemBootstrap
+	contractAdminPrefix
+	timeoutPrefix
 )
 
 var (
 	KeyContractStorePrefix  = []byte{contractStorePrefix}
 	KeyPrefixSystemContract = []byte{prefixSystemContract}
 	KeyCacheSystemBootstrap = []byte{cacheSystemBootstrap}
+	KeyContractAdminPrefix  = []byte{contractAdminPrefix}
+	// KeyTimeoutPrefix is used in the single consensus store for persisted timeouts
+	KeyTimeoutPrefix = []byte{timeoutPrefix}
 )
 
 // GetContractStorePrefix returns the store prefix for the WASM contract instance
@@ -49,6 +54,11 @@ func GetContractStorePrefix(addr sdk.AccAddress) []byte {
 	return append(KeyContractStorePrefix, addr...)
 }
 
+// GetContractAdminKey returns the store key for the admin of the WASM contract instance
+func GetContractAdminKey(addr sdk.AccAddress) []byte {
+	return append(KeyContractAdminPrefix, addr...)
+}
+
 // GetCacheSystemBootstrapPrefix returns the store prefix for the system cache information for bootstrapping
 func GetCacheSystemBootstrapPrefix() []byte {
 	return KeyCacheSystemBootstrap
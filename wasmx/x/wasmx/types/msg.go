@@ -165,6 +165,11 @@ func (msg MsgInstantiateContract) ValidateWithAddress(addressCodec address.Codec
 	if _, err := addressCodec.StringToBytes(msg.Sender); err != nil {
 		return sdkerr.Wrap(err, "sender")
 	}
+	if len(msg.Admin) != 0 {
+		if _, err := addressCodec.StringToBytes(msg.Admin); err != nil {
+			return sdkerr.Wrap(err, "admin")
+		}
+	}
 	return nil
 }
 
@@ -193,6 +198,28 @@ func (msg MsgCompileContract) ValidateWithAddress(addressCodec address.Codec) er
 	return nil
 }
 
+func (msg MsgUpdateParams) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateParams) Type() string {
+	return "update-params"
+}
+
+func (msg MsgUpdateParams) ValidateBasic() error {
+	return msg.Params.Validate()
+}
+
+func (msg MsgUpdateParams) ValidateWithAddress(addressCodec address.Codec) error {
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+	if _, err := addressCodec.StringToBytes(msg.Authority); err != nil {
+		return sdkerr.Wrap(err, "authority")
+	}
+	return nil
+}
+
 func (msg MsgExecuteContract) Route() string {
 	return RouterKey
 }
@@ -347,6 +374,101 @@ func (msg MsgInstantiateContract2) ValidateWithAddress(addressCodec address.Code
 	if _, err := addressCodec.StringToBytes(msg.Sender); err != nil {
 		return sdkerr.Wrap(err, "sender")
 	}
+	if len(msg.Admin) != 0 {
+		if _, err := addressCodec.StringToBytes(msg.Admin); err != nil {
+			return sdkerr.Wrap(err, "admin")
+		}
+	}
+	return nil
+}
+
+func (msg MsgMigrateContract) Route() string {
+	return RouterKey
+}
+
+func (msg MsgMigrateContract) Type() string {
+	return "migrate"
+}
+
+func (msg MsgMigrateContract) ValidateBasic() error {
+	if msg.CodeId == 0 {
+		return sdkerr.Wrap(sdkerrors.ErrInvalidRequest, "code id is required")
+	}
+	if err := msg.Msg.ValidateBasic(); err != nil {
+		return sdkerr.Wrap(err, "payload msg")
+	}
+	return nil
+}
+
+func (msg MsgMigrateContract) ValidateWithAddress(addressCodec address.Codec) error {
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+	if _, err := addressCodec.StringToBytes(msg.Sender); err != nil {
+		return sdkerr.Wrap(err, "sender")
+	}
+	if _, err := addressCodec.StringToBytes(msg.Contract); err != nil {
+		return sdkerr.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgUpdateAdmin) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateAdmin) Type() string {
+	return "update-contract-admin"
+}
+
+func (msg MsgUpdateAdmin) ValidateBasic() error {
+	if len(msg.NewAdmin) == 0 {
+		return sdkerr.Wrap(sdkerrors.ErrInvalidRequest, "new admin is required")
+	}
+	if msg.NewAdmin == msg.Sender {
+		return sdkerr.Wrap(ErrInvalidMsg, "new admin is the same as the old")
+	}
+	return nil
+}
+
+func (msg MsgUpdateAdmin) ValidateWithAddress(addressCodec address.Codec) error {
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+	if _, err := addressCodec.StringToBytes(msg.Sender); err != nil {
+		return sdkerr.Wrap(err, "sender")
+	}
+	if _, err := addressCodec.StringToBytes(msg.NewAdmin); err != nil {
+		return sdkerr.Wrap(err, "new admin")
+	}
+	if _, err := addressCodec.StringToBytes(msg.Contract); err != nil {
+		return sdkerr.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgClearAdmin) Route() string {
+	return RouterKey
+}
+
+func (msg MsgClearAdmin) Type() string {
+	return "clear-contract-admin"
+}
+
+func (msg MsgClearAdmin) ValidateBasic() error {
+	return nil
+}
+
+func (msg MsgClearAdmin) ValidateWithAddress(addressCodec address.Codec) error {
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+	if _, err := addressCodec.StringToBytes(msg.Sender); err != nil {
+		return sdkerr.Wrap(err, "sender")
+	}
+	if _, err := addressCodec.StringToBytes(msg.Contract); err != nil {
+		return sdkerr.Wrap(err, "contract")
+	}
 	return nil
 }
 
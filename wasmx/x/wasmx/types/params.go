@@ -1,35 +1,119 @@
 package types
 
 import (
+	fmt "fmt"
+	"slices"
+
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 	"gopkg.in/yaml.v2"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/vm/determinism"
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
 )
 
 var _ paramtypes.ParamSet = (*Params)(nil)
 
+var (
+	// 512MiB, interpreters (python, javascript) need more memory than compiled contracts
+	DefaultMaxMemoryPages = uint32(8192)
+	DefaultMaxCallDepth   = uint32(1024)
+	// 16MiB of file content per contract
+	DefaultWasiFsQuota = uint64(16 * 1024 * 1024)
+	// storage functions are charged like the cosmos-sdk KV store, per byte read or written;
+	// host functions missing from the schedule cost memc.DefaultHostFunctionGas
+	DefaultGasSchedule = GasSchedule{
+		Version: 1,
+		HostFunctions: []HostFunctionGas{
+			{Module: "wasmx", Name: "storageStore", Base: 2000, PerByte: 30},
+			{Module: "wasmx", Name: "storageLoad", Base: 1000, PerByte: 3},
+			{Module: "wasmx", Name: "storageDelete", Base: 1000, PerByte: 30},
+			{Module: "wasmx", Name: "storageDeleteRange", Base: 1000, PerByte: 30},
+			{Module: "wasmx", Name: "storageLoadRange", Base: 1000, PerByte: 3},
+			{Module: "wasmx", Name: "storageLoadRangePairs", Base: 1000, PerByte: 3},
+			{Module: "env", Name: "db_write", Base: 2000, PerByte: 30},
+			{Module: "env", Name: "db_read", Base: 1000, PerByte: 3},
+			{Module: "env", Name: "db_remove", Base: 1000, PerByte: 30},
+		},
+	}
+	// features used by the compilers we support (rust, tinygo, assemblyscript, ...);
+	// interpreters can additionally use simd and reference types
+	DefaultWasmValidation = WasmValidation{
+		Features: []string{
+			determinism.FeatureFloats,
+			determinism.FeatureNonTrappingFloatToInt,
+			determinism.FeatureSignExtension,
+			determinism.FeatureMultiValue,
+			determinism.FeatureMutableGlobals,
+			determinism.FeatureBulkMemory,
+		},
+		MaxFunctions:    50000,
+		MaxTables:       16,
+		MaxTableSize:    1 << 20,
+		MaxGlobals:      4096,
+		MaxDataSegments: 100000,
+		RoleFeatures: []RoleWasmFeatures{
+			{Role: ROLE_INTERPRETER_PYTHON, Features: []string{determinism.FeatureReferenceTypes, determinism.FeatureSIMD}},
+			{Role: ROLE_INTERPRETER_JS, Features: []string{determinism.FeatureReferenceTypes, determinism.FeatureSIMD}},
+		},
+	}
+)
+
+// Parameter keys
+var (
+	ParamStoreKeyMaxMemoryPages = []byte("MaxMemoryPages")
+	ParamStoreKeyMaxCallDepth   = []byte("MaxCallDepth")
+	ParamStoreKeyGasSchedule    = []byte("GasSchedule")
+	ParamStoreKeyWasmValidation = []byte("WasmValidation")
+	ParamStoreKeyWasiFsQuota    = []byte("WasiFsQuota")
+)
+
 // ParamKeyTable the param key table for launch module
 func ParamKeyTable() paramtypes.KeyTable {
 	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
 }
 
 // NewParams creates a new Params instance
-func NewParams() Params {
-	return Params{}
+func NewParams(maxMemoryPages uint32, maxCallDepth uint32, gasSchedule GasSchedule, wasmValidation WasmValidation, wasiFsQuota uint64) Params {
+	return Params{
+		MaxMemoryPages: maxMemoryPages,
+		MaxCallDepth:   maxCallDepth,
+		GasSchedule:    gasSchedule,
+		WasmValidation: wasmValidation,
+		WasiFsQuota:    wasiFsQuota,
+	}
 }
 
 // DefaultParams returns a default set of parameters
 func DefaultParams() Params {
-	return NewParams()
+	return NewParams(DefaultMaxMemoryPages, DefaultMaxCallDepth, DefaultGasSchedule, DefaultWasmValidation, DefaultWasiFsQuota)
 }
 
 // ParamSetPairs get the params.ParamSet
 func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
-	return paramtypes.ParamSetPairs{}
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(ParamStoreKeyMaxMemoryPages, &p.MaxMemoryPages, validateMaxMemoryPages),
+		paramtypes.NewParamSetPair(ParamStoreKeyMaxCallDepth, &p.MaxCallDepth, validateUint32),
+		paramtypes.NewParamSetPair(ParamStoreKeyGasSchedule, &p.GasSchedule, validateGasSchedule),
+		paramtypes.NewParamSetPair(ParamStoreKeyWasmValidation, &p.WasmValidation, validateWasmValidation),
+		paramtypes.NewParamSetPair(ParamStoreKeyWasiFsQuota, &p.WasiFsQuota, validateUint64),
+	}
 }
 
 // Validate validates the set of params
 func (p Params) Validate() error {
-	return nil
+	if err := validateMaxMemoryPages(p.MaxMemoryPages); err != nil {
+		return err
+	}
+	if err := validateUint32(p.MaxCallDepth); err != nil {
+		return err
+	}
+	if err := validateGasSchedule(p.GasSchedule); err != nil {
+		return err
+	}
+	if err := validateWasmValidation(p.WasmValidation); err != nil {
+		return err
+	}
+	return validateUint64(p.WasiFsQuota)
 }
 
 // String implements the Stringer interface.
@@ -37,3 +121,131 @@ func (p Params) String() string {
 	out, _ := yaml.Marshal(p)
 	return string(out)
 }
+
+func validateUint32(i interface{}) error {
+	_, ok := i.(uint32)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateMaxMemoryPages(i interface{}) error {
+	v, ok := i.(uint32)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v > memc.WASM_MAX_PAGES {
+		return fmt.Errorf("max memory pages cannot be higher than %d: %d", memc.WASM_MAX_PAGES, v)
+	}
+	return nil
+}
+
+func validateGasSchedule(i interface{}) error {
+	v, ok := i.(GasSchedule)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return v.Validate()
+}
+
+// Validate checks that each host function is priced once
+func (s GasSchedule) Validate() error {
+	seen := map[string]bool{}
+	for _, fn := range s.HostFunctions {
+		if fn.Module == "" || fn.Name == "" {
+			return fmt.Errorf("gas schedule: host function needs a module and a name: %s.%s", fn.Module, fn.Name)
+		}
+		key := memc.HostFunctionKey(fn.Module, fn.Name)
+		if seen[key] {
+			return fmt.Errorf("gas schedule: duplicate host function %s", key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// Equal returns true if both schedules have the same version and costs
+func (s GasSchedule) Equal(other GasSchedule) bool {
+	return s.Version == other.Version && slices.Equal(s.HostFunctions, other.HostFunctions)
+}
+
+// ToMemc returns the schedule indexed by host function, as used by the wasm vms
+func (s GasSchedule) ToMemc() memc.GasSchedule {
+	schedule := make(memc.GasSchedule, len(s.HostFunctions))
+	for _, fn := range s.HostFunctions {
+		schedule[memc.HostFunctionKey(fn.Module, fn.Name)] = memc.HostFunctionGas{Base: fn.Base, PerByte: fn.PerByte}
+	}
+	return schedule
+}
+
+func validateWasmValidation(i interface{}) error {
+	v, ok := i.(WasmValidation)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return v.Validate()
+}
+
+// Validate checks that only known features are allowed and each role is listed once
+func (v WasmValidation) Validate() error {
+	if err := validateWasmFeatures(v.Features); err != nil {
+		return err
+	}
+	roles := map[string]bool{}
+	for _, rf := range v.RoleFeatures {
+		if rf.Role == "" {
+			return fmt.Errorf("wasm validation: empty role")
+		}
+		if roles[rf.Role] {
+			return fmt.Errorf("wasm validation: duplicate role %s", rf.Role)
+		}
+		roles[rf.Role] = true
+		if err := validateWasmFeatures(rf.Features); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateWasmFeatures(features []string) error {
+	for _, feature := range features {
+		if !determinism.IsFeature(feature) {
+			return fmt.Errorf("wasm validation: unknown feature %s", feature)
+		}
+	}
+	return nil
+}
+
+// HasRoleFeatures returns true if some roles are allowed extra features
+func (v WasmValidation) HasRoleFeatures() bool {
+	return len(v.RoleFeatures) > 0
+}
+
+// Policy returns the policy for code uploaded by a contract with the given role,
+// or by an account without a role if role is empty
+func (v WasmValidation) Policy(role string) determinism.Policy {
+	policy := determinism.Policy{
+		Features:        map[string]bool{},
+		MaxFunctions:    v.MaxFunctions,
+		MaxTables:       v.MaxTables,
+		MaxTableSize:    v.MaxTableSize,
+		MaxGlobals:      v.MaxGlobals,
+		MaxDataSegments: v.MaxDataSegments,
+	}
+	for _, feature := range v.Features {
+		policy.Features[feature] = true
+	}
+	if role == "" {
+		return policy
+	}
+	for _, rf := range v.RoleFeatures {
+		if rf.Role != role {
+			continue
+		}
+		for _, feature := range rf.Features {
+			policy.Features[feature] = true
+		}
+	}
+	return policy
+}
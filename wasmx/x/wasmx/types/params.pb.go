@@ -25,6 +25,16 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 // Params defines the parameters for the module.
 type Params struct {
+	// max number of 64KiB memory pages a contract instance can use
+	MaxMemoryPages uint32 `protobuf:"varint,1,opt,name=max_memory_pages,json=maxMemoryPages,proto3" json:"max_memory_pages,omitempty"`
+	// max nesting level of contract calls
+	MaxCallDepth uint32 `protobuf:"varint,2,opt,name=max_call_depth,json=maxCallDepth,proto3" json:"max_call_depth,omitempty"`
+	// gas costs of the host functions
+	GasSchedule GasSchedule `protobuf:"bytes,3,opt,name=gas_schedule,json=gasSchedule,proto3" json:"gas_schedule"`
+	// features and limits enforced on uploaded wasm modules
+	WasmValidation WasmValidation `protobuf:"bytes,4,opt,name=wasm_validation,json=wasmValidation,proto3" json:"wasm_validation"`
+	// max bytes of file content a contract can store in its WASI data directory
+	WasiFsQuota uint64 `protobuf:"varint,5,opt,name=wasi_fs_quota,json=wasiFsQuota,proto3" json:"wasi_fs_quota,omitempty"`
 }
 
 func (m *Params) Reset()      { *m = Params{} }
@@ -59,24 +69,369 @@ func (m *Params) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Params proto.InternalMessageInfo
 
+func (m *Params) GetMaxMemoryPages() uint32 {
+	if m != nil {
+		return m.MaxMemoryPages
+	}
+	return 0
+}
+
+func (m *Params) GetMaxCallDepth() uint32 {
+	if m != nil {
+		return m.MaxCallDepth
+	}
+	return 0
+}
+
+func (m *Params) GetGasSchedule() GasSchedule {
+	if m != nil {
+		return m.GasSchedule
+	}
+	return GasSchedule{}
+}
+
+func (m *Params) GetWasmValidation() WasmValidation {
+	if m != nil {
+		return m.WasmValidation
+	}
+	return WasmValidation{}
+}
+
+func (m *Params) GetWasiFsQuota() uint64 {
+	if m != nil {
+		return m.WasiFsQuota
+	}
+	return 0
+}
+
+// GasSchedule defines the gas costs of the host functions.
+// Host functions without an entry cost the default host function gas,
+// the same for all wasm runtimes.
+type GasSchedule struct {
+	// version must be increased with each schedule update
+	Version       uint64            `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	HostFunctions []HostFunctionGas `protobuf:"bytes,2,rep,name=host_functions,json=hostFunctions,proto3" json:"host_functions"`
+}
+
+func (m *GasSchedule) Reset()         { *m = GasSchedule{} }
+func (m *GasSchedule) String() string { return proto.CompactTextString(m) }
+func (*GasSchedule) ProtoMessage()    {}
+func (*GasSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e9026cea98ea2aa, []int{1}
+}
+func (m *GasSchedule) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GasSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GasSchedule.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GasSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GasSchedule.Merge(m, src)
+}
+func (m *GasSchedule) XXX_Size() int {
+	return m.Size()
+}
+func (m *GasSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_GasSchedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GasSchedule proto.InternalMessageInfo
+
+func (m *GasSchedule) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *GasSchedule) GetHostFunctions() []HostFunctionGas {
+	if m != nil {
+		return m.HostFunctions
+	}
+	return nil
+}
+
+// HostFunctionGas is the gas charged for a host function call:
+// base + per_byte * bytes copied between the contract memory and the host
+type HostFunctionGas struct {
+	// host module name, e.g. wasmx, env, wasi_snapshot_preview1
+	Module string `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	// host function name
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Base    uint64 `protobuf:"varint,3,opt,name=base,proto3" json:"base,omitempty"`
+	PerByte uint64 `protobuf:"varint,4,opt,name=per_byte,json=perByte,proto3" json:"per_byte,omitempty"`
+}
+
+func (m *HostFunctionGas) Reset()         { *m = HostFunctionGas{} }
+func (m *HostFunctionGas) String() string { return proto.CompactTextString(m) }
+func (*HostFunctionGas) ProtoMessage()    {}
+func (*HostFunctionGas) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e9026cea98ea2aa, []int{2}
+}
+func (m *HostFunctionGas) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HostFunctionGas) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HostFunctionGas.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *HostFunctionGas) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HostFunctionGas.Merge(m, src)
+}
+func (m *HostFunctionGas) XXX_Size() int {
+	return m.Size()
+}
+func (m *HostFunctionGas) XXX_DiscardUnknown() {
+	xxx_messageInfo_HostFunctionGas.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HostFunctionGas proto.InternalMessageInfo
+
+func (m *HostFunctionGas) GetModule() string {
+	if m != nil {
+		return m.Module
+	}
+	return ""
+}
+
+func (m *HostFunctionGas) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HostFunctionGas) GetBase() uint64 {
+	if m != nil {
+		return m.Base
+	}
+	return 0
+}
+
+func (m *HostFunctionGas) GetPerByte() uint64 {
+	if m != nil {
+		return m.PerByte
+	}
+	return 0
+}
+
+// WasmValidation defines the wasm features and module sizes accepted
+// at code upload. Limits set to 0 are not enforced.
+type WasmValidation struct {
+	// allowed features, beyond the wasm 1.0 instruction set
+	Features     []string `protobuf:"bytes,1,rep,name=features,proto3" json:"features,omitempty"`
+	MaxFunctions uint32   `protobuf:"varint,2,opt,name=max_functions,json=maxFunctions,proto3" json:"max_functions,omitempty"`
+	MaxTables    uint32   `protobuf:"varint,3,opt,name=max_tables,json=maxTables,proto3" json:"max_tables,omitempty"`
+	// max elements of a table
+	MaxTableSize    uint32 `protobuf:"varint,4,opt,name=max_table_size,json=maxTableSize,proto3" json:"max_table_size,omitempty"`
+	MaxGlobals      uint32 `protobuf:"varint,5,opt,name=max_globals,json=maxGlobals,proto3" json:"max_globals,omitempty"`
+	MaxDataSegments uint32 `protobuf:"varint,6,opt,name=max_data_segments,json=maxDataSegments,proto3" json:"max_data_segments,omitempty"`
+	// extra features allowed for code uploaded by system contracts with a role
+	RoleFeatures []RoleWasmFeatures `protobuf:"bytes,7,rep,name=role_features,json=roleFeatures,proto3" json:"role_features"`
+}
+
+func (m *WasmValidation) Reset()         { *m = WasmValidation{} }
+func (m *WasmValidation) String() string { return proto.CompactTextString(m) }
+func (*WasmValidation) ProtoMessage()    {}
+func (*WasmValidation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e9026cea98ea2aa, []int{3}
+}
+func (m *WasmValidation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WasmValidation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WasmValidation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WasmValidation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WasmValidation.Merge(m, src)
+}
+func (m *WasmValidation) XXX_Size() int {
+	return m.Size()
+}
+func (m *WasmValidation) XXX_DiscardUnknown() {
+	xxx_messageInfo_WasmValidation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WasmValidation proto.InternalMessageInfo
+
+func (m *WasmValidation) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *WasmValidation) GetMaxFunctions() uint32 {
+	if m != nil {
+		return m.MaxFunctions
+	}
+	return 0
+}
+
+func (m *WasmValidation) GetMaxTables() uint32 {
+	if m != nil {
+		return m.MaxTables
+	}
+	return 0
+}
+
+func (m *WasmValidation) GetMaxTableSize() uint32 {
+	if m != nil {
+		return m.MaxTableSize
+	}
+	return 0
+}
+
+func (m *WasmValidation) GetMaxGlobals() uint32 {
+	if m != nil {
+		return m.MaxGlobals
+	}
+	return 0
+}
+
+func (m *WasmValidation) GetMaxDataSegments() uint32 {
+	if m != nil {
+		return m.MaxDataSegments
+	}
+	return 0
+}
+
+func (m *WasmValidation) GetRoleFeatures() []RoleWasmFeatures {
+	if m != nil {
+		return m.RoleFeatures
+	}
+	return nil
+}
+
+// RoleWasmFeatures defines extra wasm features allowed for a role
+type RoleWasmFeatures struct {
+	Role     string   `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Features []string `protobuf:"bytes,2,rep,name=features,proto3" json:"features,omitempty"`
+}
+
+func (m *RoleWasmFeatures) Reset()         { *m = RoleWasmFeatures{} }
+func (m *RoleWasmFeatures) String() string { return proto.CompactTextString(m) }
+func (*RoleWasmFeatures) ProtoMessage()    {}
+func (*RoleWasmFeatures) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e9026cea98ea2aa, []int{4}
+}
+func (m *RoleWasmFeatures) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RoleWasmFeatures) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RoleWasmFeatures.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RoleWasmFeatures) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoleWasmFeatures.Merge(m, src)
+}
+func (m *RoleWasmFeatures) XXX_Size() int {
+	return m.Size()
+}
+func (m *RoleWasmFeatures) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoleWasmFeatures.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoleWasmFeatures proto.InternalMessageInfo
+
+func (m *RoleWasmFeatures) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *RoleWasmFeatures) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Params)(nil), "mythos.wasmx.v1.Params")
+	proto.RegisterType((*GasSchedule)(nil), "mythos.wasmx.v1.GasSchedule")
+	proto.RegisterType((*HostFunctionGas)(nil), "mythos.wasmx.v1.HostFunctionGas")
+	proto.RegisterType((*WasmValidation)(nil), "mythos.wasmx.v1.WasmValidation")
+	proto.RegisterType((*RoleWasmFeatures)(nil), "mythos.wasmx.v1.RoleWasmFeatures")
 }
 
 func init() { proto.RegisterFile("mythos/wasmx/v1/params.proto", fileDescriptor_9e9026cea98ea2aa) }
 
 var fileDescriptor_9e9026cea98ea2aa = []byte{
-	// 160 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xc9, 0xad, 0x2c, 0xc9,
-	0xc8, 0x2f, 0xd6, 0x2f, 0x4f, 0x2c, 0xce, 0xad, 0xd0, 0x2f, 0x33, 0xd4, 0x2f, 0x48, 0x2c, 0x4a,
-	0xcc, 0x2d, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x87, 0xc8, 0xea, 0x81, 0x65, 0xf5,
-	0xca, 0x0c, 0xa5, 0x44, 0xd2, 0xf3, 0xd3, 0xf3, 0xc1, 0x72, 0xfa, 0x20, 0x16, 0x44, 0x99, 0x12,
-	0x1f, 0x17, 0x5b, 0x00, 0x58, 0x9b, 0x15, 0xcb, 0x8c, 0x05, 0xf2, 0x0c, 0x4e, 0x1e, 0x27, 0x1e,
-	0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x84, 0xc7, 0x72, 0x0c, 0x17,
-	0x1e, 0xcb, 0x31, 0xdc, 0x78, 0x2c, 0xc7, 0x10, 0xa5, 0x97, 0x9e, 0x59, 0x92, 0x51, 0x9a, 0xa4,
-	0x97, 0x9c, 0x9f, 0xab, 0x9f, 0x93, 0x5f, 0x94, 0x9a, 0x92, 0x98, 0x97, 0x98, 0x9c, 0x59, 0x54,
-	0x5c, 0x92, 0x9a, 0x08, 0x75, 0x42, 0x05, 0x94, 0x2e, 0xa9, 0x2c, 0x48, 0x2d, 0x4e, 0x62, 0x03,
-	0x5b, 0x60, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff, 0xc2, 0xc4, 0x7f, 0x1f, 0xa7, 0x00, 0x00, 0x00,
+	// 607 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x93, 0x41, 0x6f, 0xd3, 0x30,
+	0x14, 0xc7, 0x9b, 0x2e, 0x74, 0xab, 0xbb, 0xb4, 0xc3, 0x42, 0x28, 0x4c, 0xa3, 0x2d, 0x85, 0x43,
+	0xc5, 0x21, 0xd5, 0xc6, 0x8d, 0x63, 0x19, 0xdb, 0x0e, 0x0c, 0x8d, 0x0c, 0x81, 0xc4, 0xc5, 0x7a,
+	0x6d, 0xbd, 0x34, 0x92, 0x5d, 0x87, 0x3c, 0xb7, 0x4b, 0xf7, 0x29, 0x38, 0xc2, 0x8d, 0xaf, 0xc0,
+	0xb7, 0xd8, 0x71, 0x47, 0x4e, 0x08, 0x6d, 0x5f, 0x04, 0xd9, 0x49, 0xcb, 0xba, 0x9d, 0xf2, 0xfc,
+	0x7f, 0x3f, 0xbf, 0x3c, 0xff, 0x9f, 0x4d, 0x76, 0xe4, 0x5c, 0x8f, 0x15, 0xf6, 0xce, 0x01, 0x65,
+	0xd6, 0x9b, 0xed, 0xf6, 0x12, 0x48, 0x41, 0x62, 0x90, 0xa4, 0x4a, 0x2b, 0xda, 0xc8, 0xb3, 0x81,
+	0xcd, 0x06, 0xb3, 0xdd, 0xed, 0x47, 0x91, 0x8a, 0x94, 0xcd, 0xf5, 0x4c, 0x94, 0x63, 0x9d, 0x1f,
+	0x65, 0x52, 0x39, 0xb1, 0xfb, 0x68, 0x97, 0x6c, 0x49, 0xc8, 0x98, 0xe4, 0x52, 0xa5, 0x73, 0x96,
+	0x40, 0xc4, 0xd1, 0x77, 0xda, 0x4e, 0xd7, 0x0b, 0xeb, 0x12, 0xb2, 0x63, 0x2b, 0x9f, 0x18, 0x95,
+	0xbe, 0x20, 0x46, 0x61, 0x43, 0x10, 0x82, 0x8d, 0x78, 0xa2, 0xc7, 0x7e, 0xd9, 0x72, 0x9b, 0x12,
+	0xb2, 0x37, 0x20, 0xc4, 0xbe, 0xd1, 0xe8, 0x5b, 0xb2, 0x19, 0x01, 0x32, 0x1c, 0x8e, 0xf9, 0x68,
+	0x2a, 0xb8, 0xbf, 0xd6, 0x76, 0xba, 0xb5, 0xbd, 0x9d, 0xe0, 0x4e, 0x63, 0xc1, 0x21, 0xe0, 0x69,
+	0xc1, 0xf4, 0xdd, 0xcb, 0x3f, 0xad, 0x52, 0x58, 0x8b, 0xfe, 0x4b, 0xf4, 0x3d, 0x69, 0x18, 0x94,
+	0xcd, 0x40, 0xc4, 0x23, 0xd0, 0xb1, 0x9a, 0xf8, 0xae, 0xad, 0xd4, 0xba, 0x57, 0xe9, 0x33, 0xa0,
+	0xfc, 0xb4, 0xc4, 0x8a, 0x62, 0xf5, 0xf3, 0x15, 0x95, 0x76, 0x88, 0x77, 0x0e, 0x18, 0xb3, 0x33,
+	0x64, 0x5f, 0xa7, 0x4a, 0x83, 0xff, 0xa0, 0xed, 0x74, 0xdd, 0xb0, 0x66, 0xc4, 0x03, 0xfc, 0x60,
+	0xa4, 0xd7, 0xee, 0xf7, 0x9f, 0xad, 0x52, 0x67, 0x46, 0x6a, 0xb7, 0x7a, 0xa3, 0x3e, 0x59, 0x9f,
+	0xf1, 0x14, 0x4d, 0x03, 0x8e, 0xdd, 0xb2, 0x58, 0xd2, 0x63, 0x52, 0x1f, 0x2b, 0xd4, 0xec, 0x6c,
+	0x3a, 0x19, 0x9a, 0x7f, 0xa0, 0x5f, 0x6e, 0xaf, 0x75, 0x6b, 0x7b, 0xed, 0x7b, 0x1d, 0x1e, 0x29,
+	0xd4, 0x07, 0x05, 0x75, 0x08, 0x58, 0xb4, 0xe8, 0x8d, 0x6f, 0xc9, 0xd8, 0x11, 0xa4, 0x71, 0x87,
+	0xa3, 0x8f, 0x49, 0x45, 0x2a, 0xeb, 0xa2, 0xf9, 0x75, 0x35, 0x2c, 0x56, 0x94, 0x12, 0x77, 0x02,
+	0x92, 0x5b, 0xff, 0xab, 0xa1, 0x8d, 0x8d, 0x36, 0x00, 0xcc, 0xfd, 0x76, 0x43, 0x1b, 0xd3, 0x27,
+	0x64, 0x23, 0xe1, 0x29, 0x1b, 0xcc, 0x35, 0xb7, 0xee, 0xb9, 0xe1, 0x7a, 0xc2, 0xd3, 0xfe, 0x5c,
+	0xf3, 0xce, 0xaf, 0x32, 0xa9, 0xaf, 0x1a, 0x47, 0xb7, 0xc9, 0xc6, 0x19, 0x07, 0x3d, 0x4d, 0xed,
+	0x0d, 0x58, 0xeb, 0x56, 0xc3, 0xe5, 0x9a, 0x3e, 0x27, 0x9e, 0x99, 0xfd, 0xed, 0xa3, 0x2e, 0x46,
+	0xbf, 0x3c, 0x01, 0x7d, 0x4a, 0x88, 0x81, 0x34, 0x0c, 0x04, 0x47, 0xdb, 0x88, 0x17, 0x56, 0x25,
+	0x64, 0x1f, 0xad, 0xb0, 0xb8, 0x3f, 0x36, 0xcd, 0x30, 0xbe, 0xc8, 0x7b, 0xca, 0x8b, 0x58, 0xe4,
+	0x34, 0xbe, 0xe0, 0xb4, 0x45, 0x6a, 0x86, 0x8a, 0x84, 0x1a, 0x80, 0x40, 0x3b, 0x26, 0x2f, 0x34,
+	0x75, 0x0f, 0x73, 0x85, 0xbe, 0x24, 0x0f, 0x0d, 0x30, 0x02, 0x0d, 0x0c, 0x79, 0x24, 0xf9, 0x44,
+	0xa3, 0x5f, 0xb1, 0x58, 0x43, 0x42, 0xb6, 0x0f, 0x1a, 0x4e, 0x0b, 0x99, 0xbe, 0x23, 0x5e, 0xaa,
+	0x04, 0x67, 0xcb, 0x73, 0xad, 0xdb, 0x09, 0x3d, 0xbb, 0x37, 0xa1, 0x50, 0x09, 0x6e, 0xec, 0x38,
+	0x28, 0xc0, 0x62, 0x44, 0x9b, 0x66, 0xf7, 0x42, 0xeb, 0xf4, 0xc9, 0xd6, 0x5d, 0xce, 0xd8, 0x9e,
+	0xaa, 0xe5, 0x80, 0x6c, 0xbc, 0x62, 0x64, 0x79, 0xd5, 0xc8, 0xfe, 0xd1, 0xe5, 0x75, 0xd3, 0xb9,
+	0xba, 0x6e, 0x3a, 0x7f, 0xaf, 0x9b, 0xce, 0xb7, 0x9b, 0x66, 0xe9, 0xea, 0xa6, 0x59, 0xfa, 0x7d,
+	0xd3, 0x2c, 0x7d, 0x09, 0xa2, 0x58, 0x8f, 0xa7, 0x83, 0x60, 0xa8, 0x64, 0x4f, 0xa8, 0x94, 0x8f,
+	0x60, 0x02, 0xc3, 0x38, 0x45, 0xcd, 0xa1, 0x78, 0xec, 0x59, 0xf1, 0xd5, 0xf3, 0x84, 0xe3, 0xa0,
+	0x62, 0x9f, 0xf2, 0xab, 0x7f, 0x03, 0x00, 0x80, 0xf3, 0x61, 0x37, 0x11, 0x04, 0x00, 0x00,
 }
 
 func (m *Params) Marshal() (dAtA []byte, err error) {
@@ -99,64 +454,1110 @@ func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.WasiFsQuota != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.WasiFsQuota))
+		i--
+		dAtA[i] = 0x28
+	}
+	{
+		size, err := m.WasmValidation.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintParams(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	{
+		size, err := m.GasSchedule.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintParams(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.MaxCallDepth != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxCallDepth))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.MaxMemoryPages != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxMemoryPages))
+		i--
+		dAtA[i] = 0x8
+	}
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintParams(dAtA []byte, offset int, v uint64) int {
-	offset -= sovParams(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *GasSchedule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *Params) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *GasSchedule) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GasSchedule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.HostFunctions) > 0 {
+		for iNdEx := len(m.HostFunctions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.HostFunctions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintParams(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Version != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HostFunctionGas) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *HostFunctionGas) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HostFunctionGas) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	return n
+	if m.PerByte != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.PerByte))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Base != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.Base))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Module) > 0 {
+		i -= len(m.Module)
+		copy(dAtA[i:], m.Module)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.Module)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func sovParams(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *WasmValidation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
-func sozParams(x uint64) (n int) {
-	return sovParams(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *WasmValidation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (m *Params) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowParams
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+
+func (m *WasmValidation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RoleFeatures) > 0 {
+		for iNdEx := len(m.RoleFeatures) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.RoleFeatures[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintParams(dAtA, i, uint64(size))
 			}
+			i--
+			dAtA[i] = 0x3a
 		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Params: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
+	}
+	if m.MaxDataSegments != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxDataSegments))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.MaxGlobals != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxGlobals))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.MaxTableSize != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxTableSize))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MaxTables != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxTables))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MaxFunctions != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxFunctions))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Features) > 0 {
+		for iNdEx := len(m.Features) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Features[iNdEx])
+			copy(dAtA[i:], m.Features[iNdEx])
+			i = encodeVarintParams(dAtA, i, uint64(len(m.Features[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RoleWasmFeatures) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RoleWasmFeatures) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RoleWasmFeatures) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Features) > 0 {
+		for iNdEx := len(m.Features) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Features[iNdEx])
+			copy(dAtA[i:], m.Features[iNdEx])
+			i = encodeVarintParams(dAtA, i, uint64(len(m.Features[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Role) > 0 {
+		i -= len(m.Role)
+		copy(dAtA[i:], m.Role)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.Role)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintParams(dAtA []byte, offset int, v uint64) int {
+	offset -= sovParams(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxMemoryPages != 0 {
+		n += 1 + sovParams(uint64(m.MaxMemoryPages))
+	}
+	if m.MaxCallDepth != 0 {
+		n += 1 + sovParams(uint64(m.MaxCallDepth))
+	}
+	l = m.GasSchedule.Size()
+	n += 1 + l + sovParams(uint64(l))
+	l = m.WasmValidation.Size()
+	n += 1 + l + sovParams(uint64(l))
+	if m.WasiFsQuota != 0 {
+		n += 1 + sovParams(uint64(m.WasiFsQuota))
+	}
+	return n
+}
+
+func (m *GasSchedule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovParams(uint64(m.Version))
+	}
+	if len(m.HostFunctions) > 0 {
+		for _, e := range m.HostFunctions {
+			l = e.Size()
+			n += 1 + l + sovParams(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HostFunctionGas) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Module)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
+	if m.Base != 0 {
+		n += 1 + sovParams(uint64(m.Base))
+	}
+	if m.PerByte != 0 {
+		n += 1 + sovParams(uint64(m.PerByte))
+	}
+	return n
+}
+
+func (m *WasmValidation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			l = len(s)
+			n += 1 + l + sovParams(uint64(l))
+		}
+	}
+	if m.MaxFunctions != 0 {
+		n += 1 + sovParams(uint64(m.MaxFunctions))
+	}
+	if m.MaxTables != 0 {
+		n += 1 + sovParams(uint64(m.MaxTables))
+	}
+	if m.MaxTableSize != 0 {
+		n += 1 + sovParams(uint64(m.MaxTableSize))
+	}
+	if m.MaxGlobals != 0 {
+		n += 1 + sovParams(uint64(m.MaxGlobals))
+	}
+	if m.MaxDataSegments != 0 {
+		n += 1 + sovParams(uint64(m.MaxDataSegments))
+	}
+	if len(m.RoleFeatures) > 0 {
+		for _, e := range m.RoleFeatures {
+			l = e.Size()
+			n += 1 + l + sovParams(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RoleWasmFeatures) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Role)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			l = len(s)
+			n += 1 + l + sovParams(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovParams(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozParams(x uint64) (n int) {
+	return sovParams(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
 			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxMemoryPages", wireType)
+			}
+			m.MaxMemoryPages = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxMemoryPages |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCallDepth", wireType)
+			}
+			m.MaxCallDepth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxCallDepth |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasSchedule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GasSchedule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WasmValidation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.WasmValidation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WasiFsQuota", wireType)
+			}
+			m.WasiFsQuota = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WasiFsQuota |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GasSchedule) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GasSchedule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GasSchedule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HostFunctions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HostFunctions = append(m.HostFunctions, HostFunctionGas{})
+			if err := m.HostFunctions[len(m.HostFunctions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HostFunctionGas) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HostFunctionGas: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HostFunctionGas: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Module", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Module = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Base", wireType)
+			}
+			m.Base = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Base |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PerByte", wireType)
+			}
+			m.PerByte = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PerByte |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WasmValidation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WasmValidation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WasmValidation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxFunctions", wireType)
+			}
+			m.MaxFunctions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxFunctions |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxTables", wireType)
+			}
+			m.MaxTables = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxTables |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxTableSize", wireType)
+			}
+			m.MaxTableSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxTableSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxGlobals", wireType)
+			}
+			m.MaxGlobals = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxGlobals |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxDataSegments", wireType)
+			}
+			m.MaxDataSegments = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxDataSegments |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RoleFeatures", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RoleFeatures = append(m.RoleFeatures, RoleWasmFeatures{})
+			if err := m.RoleFeatures[len(m.RoleFeatures)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RoleWasmFeatures) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RoleWasmFeatures: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RoleWasmFeatures: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Role = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
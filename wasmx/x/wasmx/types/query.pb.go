@@ -1021,6 +1021,84 @@ func (m *QueryContractsByCreatorResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_QueryContractsByCreatorResponse proto.InternalMessageInfo
 
+// QueryGasScheduleRequest is the request type for the Query/GasSchedule RPC method.
+type QueryGasScheduleRequest struct {
+}
+
+func (m *QueryGasScheduleRequest) Reset()         { *m = QueryGasScheduleRequest{} }
+func (m *QueryGasScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryGasScheduleRequest) ProtoMessage()    {}
+func (*QueryGasScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_defcf25d86c29c21, []int{24}
+}
+func (m *QueryGasScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryGasScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryGasScheduleRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryGasScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryGasScheduleRequest.Merge(m, src)
+}
+func (m *QueryGasScheduleRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryGasScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryGasScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryGasScheduleRequest proto.InternalMessageInfo
+
+// QueryGasScheduleResponse is the response type for the Query/GasSchedule RPC method.
+type QueryGasScheduleResponse struct {
+	// version of the gas schedule params
+	Version uint64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// costs of all host functions; the ones without a schedule entry have the default cost
+	HostFunctions []HostFunctionGas `protobuf:"bytes,2,rep,name=host_functions,json=hostFunctions,proto3" json:"host_functions"`
+}
+
+func (m *QueryGasScheduleResponse) Reset()         { *m = QueryGasScheduleResponse{} }
+func (m *QueryGasScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryGasScheduleResponse) ProtoMessage()    {}
+func (*QueryGasScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_defcf25d86c29c21, []int{25}
+}
+func (m *QueryGasScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryGasScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryGasScheduleResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryGasScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryGasScheduleResponse.Merge(m, src)
+}
+func (m *QueryGasScheduleResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryGasScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryGasScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryGasScheduleResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*QueryContractInfoRequest)(nil), "mythos.wasmx.v1.QueryContractInfoRequest")
 	proto.RegisterType((*QueryContractInfoResponse)(nil), "mythos.wasmx.v1.QueryContractInfoResponse")
@@ -1046,97 +1124,104 @@ func init() {
 	proto.RegisterType((*QueryParamsResponse)(nil), "mythos.wasmx.v1.QueryParamsResponse")
 	proto.RegisterType((*QueryContractsByCreatorRequest)(nil), "mythos.wasmx.v1.QueryContractsByCreatorRequest")
 	proto.RegisterType((*QueryContractsByCreatorResponse)(nil), "mythos.wasmx.v1.QueryContractsByCreatorResponse")
+	proto.RegisterType((*QueryGasScheduleRequest)(nil), "mythos.wasmx.v1.QueryGasScheduleRequest")
+	proto.RegisterType((*QueryGasScheduleResponse)(nil), "mythos.wasmx.v1.QueryGasScheduleResponse")
 }
 
 func init() { proto.RegisterFile("mythos/wasmx/v1/query.proto", fileDescriptor_defcf25d86c29c21) }
 
 var fileDescriptor_defcf25d86c29c21 = []byte{
-	// 1345 bytes of a gzipped FileDescriptorProto
+	// 1437 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x98, 0xcf, 0x6f, 0x1b, 0x45,
-	0x1b, 0xc7, 0x3d, 0xa9, 0xf3, 0xeb, 0x69, 0xda, 0x24, 0xf3, 0xf6, 0x4d, 0x5d, 0x37, 0x5d, 0x97,
-	0x6d, 0xda, 0x86, 0x40, 0x76, 0x9b, 0x96, 0x82, 0xc4, 0x01, 0x51, 0xa7, 0x80, 0x2a, 0x15, 0x29,
-	0x6c, 0x84, 0x90, 0x40, 0xc8, 0x9a, 0xec, 0x4e, 0x1d, 0x0b, 0x7b, 0xc7, 0xdd, 0x59, 0xb7, 0x8d,
-	0xa2, 0xa0, 0x0a, 0xa9, 0x27, 0x24, 0x0a, 0xe2, 0x44, 0x25, 0x04, 0xc7, 0x0a, 0x01, 0x7f, 0x47,
-	0x8f, 0x95, 0xb8, 0x70, 0x0a, 0x90, 0x70, 0x40, 0xbd, 0x71, 0xe5, 0x84, 0x76, 0xf6, 0xd9, 0x78,
-	0xd7, 0xce, 0xd8, 0x69, 0xf1, 0x0d, 0x4e, 0xc9, 0xce, 0x3c, 0xcf, 0x3c, 0x9f, 0xf9, 0xee, 0xce,
-	0x3c, 0xdf, 0x04, 0x4e, 0x36, 0x36, 0xc2, 0x75, 0x21, 0xed, 0xdb, 0x4c, 0x36, 0xee, 0xd8, 0xb7,
-	0x96, 0xec, 0x9b, 0x2d, 0x1e, 0x6c, 0x58, 0xcd, 0x40, 0x84, 0x82, 0x4e, 0xc6, 0x93, 0x96, 0x9a,
-	0xb4, 0x6e, 0x2d, 0x15, 0x8f, 0x55, 0x45, 0x55, 0xa8, 0x39, 0x3b, 0xfa, 0x2d, 0x0e, 0x2b, 0xce,
-	0x56, 0x85, 0xa8, 0xd6, 0xb9, 0xcd, 0x9a, 0x35, 0x9b, 0xf9, 0xbe, 0x08, 0x59, 0x58, 0x13, 0xbe,
-	0xc4, 0x59, 0xc3, 0x15, 0xb2, 0x21, 0xa4, 0xbd, 0xc6, 0x24, 0xb7, 0x6f, 0x2d, 0xad, 0xf1, 0x90,
-	0x2d, 0xd9, 0xae, 0xa8, 0xf9, 0x38, 0xbf, 0x90, 0x9e, 0x57, 0xd5, 0xf7, 0xa2, 0x9a, 0xac, 0x5a,
-	0xf3, 0xd5, 0x62, 0x49, 0xa5, 0x4e, 0xda, 0x26, 0x0b, 0x58, 0x63, 0xaf, 0x52, 0xe7, 0xac, 0x2b,
-	0xfc, 0x30, 0x60, 0x6e, 0x18, 0xcf, 0x9b, 0x2f, 0x41, 0xe1, 0x9d, 0x68, 0xfd, 0x65, 0x1c, 0xbe,
-	0xe6, 0xdf, 0x10, 0x0e, 0xbf, 0xd9, 0xe2, 0x32, 0xa4, 0x05, 0x18, 0x65, 0x9e, 0x17, 0x70, 0x29,
-	0x0b, 0xe4, 0x34, 0x99, 0x1f, 0x77, 0x92, 0x47, 0xf3, 0x3e, 0x81, 0x13, 0xfb, 0xa4, 0xc9, 0xa6,
-	0xf0, 0x25, 0xd7, 0xe7, 0x51, 0x07, 0x8e, 0x24, 0xf5, 0x2b, 0x35, 0xff, 0x86, 0x28, 0x0c, 0x9d,
-	0x26, 0xf3, 0x87, 0x2f, 0x96, 0xac, 0x0e, 0x51, 0xad, 0xf4, 0xba, 0x2b, 0xe5, 0xf2, 0xc4, 0xa3,
-	0xed, 0x52, 0xee, 0xf1, 0x76, 0x89, 0x3c, 0xd9, 0x2e, 0xe5, 0x9c, 0x09, 0x37, 0x35, 0xfb, 0x6a,
-	0xfe, 0x8f, 0x6f, 0x4b, 0xc4, 0xfc, 0x18, 0x4e, 0x66, 0x80, 0x64, 0x79, 0x63, 0x59, 0x78, 0x3c,
-	0xd9, 0xca, 0x71, 0x18, 0x75, 0x85, 0xc7, 0x2b, 0x35, 0x4f, 0x21, 0xe5, 0x9d, 0x91, 0xe8, 0xf1,
-	0x9a, 0x47, 0xdf, 0x04, 0x68, 0x2b, 0x8a, 0x38, 0xe7, 0xac, 0x58, 0x7e, 0x2b, 0x92, 0xdf, 0x8a,
-	0x5f, 0x3e, 0xca, 0x6f, 0xad, 0xb0, 0x6a, 0xb2, 0xa8, 0x93, 0xca, 0x34, 0xef, 0x11, 0x98, 0xdd,
-	0x1f, 0x00, 0x45, 0x99, 0x85, 0xf1, 0x04, 0x3b, 0x92, 0xe5, 0xd0, 0xfc, 0xb8, 0xd3, 0x1e, 0xa0,
-	0x6f, 0xed, 0x83, 0x71, 0xbe, 0x2f, 0x46, 0xbc, 0x74, 0x86, 0xe3, 0x6e, 0xc2, 0x71, 0xa5, 0x5e,
-	0x4f, 0x50, 0x56, 0x43, 0x16, 0xf2, 0xbe, 0x2f, 0x75, 0x60, 0x52, 0x3c, 0x24, 0x70, 0x4a, 0x83,
-	0x80, 0x5a, 0xbc, 0x06, 0xc3, 0xb5, 0x90, 0x37, 0x62, 0x1d, 0x0e, 0x5f, 0x34, 0xb5, 0xaf, 0x7f,
-	0x35, 0x14, 0x01, 0xab, 0xf2, 0x95, 0x72, 0x39, 0x1f, 0x7d, 0x01, 0x4e, 0x9c, 0x36, 0x38, 0xb5,
-	0xde, 0x43, 0xb1, 0x1c, 0x76, 0xfb, 0x29, 0xc5, 0x3a, 0x05, 0xa0, 0x6a, 0x54, 0x3c, 0x16, 0x32,
-	0x85, 0x30, 0xe1, 0x8c, 0xab, 0x91, 0xab, 0x2c, 0x64, 0xe6, 0x25, 0x94, 0xa0, 0x7b, 0x61, 0x94,
-	0x80, 0x42, 0x5e, 0x65, 0x12, 0x95, 0xa9, 0x7e, 0x37, 0xef, 0x0f, 0x61, 0xd6, 0x6a, 0x83, 0x05,
-	0x61, 0x92, 0xb7, 0xcc, 0xea, 0xf5, 0x84, 0x67, 0x06, 0x46, 0x24, 0xf7, 0x3d, 0x1e, 0x20, 0x0e,
-	0x3e, 0xa5, 0x39, 0x87, 0xb2, 0x9c, 0x97, 0x33, 0x9c, 0x87, 0xa2, 0x6a, 0xe5, 0x99, 0xbf, 0xb6,
-	0x4b, 0x34, 0x45, 0xf6, 0x36, 0x97, 0x32, 0x52, 0xa8, 0xcd, 0x4f, 0x19, 0x0c, 0xdf, 0x68, 0xf9,
-	0x9e, 0x2c, 0xe4, 0xd5, 0x1b, 0x3a, 0x91, 0x11, 0x37, 0x91, 0x75, 0x59, 0xd4, 0xfc, 0xf2, 0x85,
-	0xe8, 0xc5, 0x7c, 0xf7, 0x4b, 0x69, 0xbe, 0x5a, 0x0b, 0xd7, 0x5b, 0x6b, 0x96, 0x2b, 0x1a, 0x36,
-	0xde, 0x5e, 0xf1, 0x8f, 0x45, 0xe9, 0x7d, 0x64, 0x87, 0x1b, 0x4d, 0x2e, 0x55, 0x82, 0x74, 0xe2,
-	0x95, 0xa9, 0x09, 0x13, 0x1e, 0x6f, 0x46, 0xfc, 0xbe, 0x5b, 0xe3, 0xb2, 0x30, 0xac, 0xce, 0x44,
-	0x66, 0xcc, 0xbc, 0x0e, 0x86, 0x4e, 0x10, 0xd4, 0x71, 0x21, 0xad, 0xa3, 0x76, 0x67, 0xb1, 0xbe,
-	0xf7, 0x86, 0xe0, 0x7f, 0xf1, 0x19, 0x65, 0xf5, 0xfa, 0x1b, 0xe1, 0xfa, 0xbf, 0x55, 0xd5, 0x0f,
-	0xe1, 0x58, 0x56, 0x86, 0xa7, 0xd7, 0x92, 0x9e, 0x80, 0xb1, 0x2a, 0x93, 0x95, 0x96, 0xe4, 0x9e,
-	0x12, 0x27, 0xef, 0x8c, 0x56, 0x99, 0x7c, 0x57, 0x72, 0xaf, 0xfd, 0x19, 0x5f, 0xe5, 0x6b, 0xad,
-	0xea, 0x7f, 0x9f, 0xb1, 0x34, 0x1f, 0x10, 0xfc, 0x8e, 0xf7, 0x51, 0xe4, 0x19, 0xb4, 0x3f, 0x0f,
-	0x93, 0x0d, 0xde, 0x10, 0xc1, 0x46, 0x45, 0xfa, 0xac, 0x29, 0xd7, 0x45, 0x88, 0x17, 0xd0, 0xd1,
-	0x78, 0x78, 0x15, 0x47, 0xe9, 0x19, 0x38, 0xc2, 0x83, 0x40, 0x04, 0x95, 0x46, 0x9c, 0xaf, 0x84,
-	0x1b, 0x77, 0x26, 0xd4, 0x20, 0xae, 0x69, 0xbe, 0x00, 0x53, 0xd8, 0xb8, 0xfa, 0xb7, 0x4b, 0x73,
-	0x13, 0xa6, 0x53, 0xc1, 0xc8, 0x5e, 0x8e, 0x5a, 0x5b, 0x14, 0x1d, 0x75, 0x74, 0xa2, 0x6e, 0xe3,
-	0x93, 0xfb, 0x5c, 0xe9, 0x1e, 0xc7, 0x6e, 0x3e, 0xb6, 0xd7, 0xc9, 0xc7, 0x5c, 0x1c, 0xa5, 0xb3,
-	0xb8, 0x7f, 0xb5, 0x91, 0xf2, 0xd8, 0x93, 0xed, 0x92, 0x7a, 0x8e, 0x77, 0x8c, 0x3d, 0xde, 0x4e,
-	0xbe, 0x5b, 0x4c, 0xea, 0x4b, 0xcb, 0xe0, 0xff, 0x1d, 0x09, 0x83, 0x23, 0x46, 0xa6, 0x0f, 0x52,
-	0x82, 0xc8, 0x04, 0x28, 0xdb, 0x49, 0xc9, 0x33, 0x77, 0xd2, 0x6f, 0x08, 0xd0, 0xf4, 0xea, 0x48,
-	0xff, 0x3a, 0xc0, 0x1e, 0x7d, 0xd2, 0x43, 0x7b, 0xe2, 0xc7, 0xcd, 0x73, 0x3c, 0x41, 0x1f, 0x60,
-	0x03, 0x3d, 0x86, 0x80, 0x2b, 0xca, 0x73, 0xe2, 0x1e, 0xcc, 0xeb, 0x78, 0xcf, 0x26, 0xa3, 0xc8,
-	0x7d, 0x19, 0x46, 0x62, 0x6f, 0x8a, 0x92, 0x1c, 0xef, 0x62, 0x8e, 0x13, 0x90, 0x17, 0x83, 0xcd,
-	0x2f, 0x92, 0xd3, 0x93, 0xb6, 0x56, 0x01, 0x67, 0xa1, 0x08, 0x12, 0xc1, 0xcf, 0xc3, 0xa4, 0x1b,
-	0x8f, 0x54, 0xb2, 0xfd, 0xfa, 0x28, 0x0e, 0x5f, 0x19, 0xb0, 0xc7, 0xf9, 0x8a, 0x40, 0x49, 0xcb,
-	0x84, 0xdb, 0x5d, 0x04, 0xba, 0x67, 0x76, 0x91, 0x8a, 0x27, 0xd6, 0x6f, 0x3a, 0x99, 0xb9, 0x92,
-	0x4c, 0x0c, 0xec, 0x9d, 0x5c, 0xfc, 0xf3, 0x08, 0x0c, 0x2b, 0x36, 0xfa, 0x29, 0x81, 0x89, 0xb4,
-	0x93, 0xa6, 0xcf, 0x77, 0x29, 0xae, 0x33, 0xff, 0xc5, 0x85, 0x83, 0x84, 0xc6, 0xd5, 0xcd, 0xb9,
-	0x4f, 0x7e, 0xfa, 0xfd, 0xcb, 0x21, 0x83, 0xce, 0x76, 0xff, 0x99, 0x61, 0x6f, 0xe2, 0xd6, 0xb7,
-	0xe8, 0x03, 0x02, 0x93, 0x1d, 0xee, 0x98, 0xbe, 0xd8, 0xbb, 0x4a, 0xd6, 0xc5, 0x17, 0x17, 0x0f,
-	0x18, 0x8d, 0x58, 0x0b, 0x0a, 0x6b, 0x8e, 0x9a, 0x69, 0x2c, 0x8f, 0xdb, 0x9b, 0x78, 0x5b, 0x6c,
-	0xd9, 0x6d, 0x03, 0xfe, 0x35, 0x81, 0xa9, 0x4e, 0xbf, 0x4a, 0x35, 0xf5, 0x34, 0xd6, 0xba, 0x68,
-	0x1d, 0x34, 0xbc, 0x17, 0x5f, 0xa7, 0x6c, 0xb6, 0x54, 0x28, 0x0f, 0x09, 0x4c, 0x75, 0x9a, 0x49,
-	0x1d, 0x9f, 0xc6, 0xcd, 0xea, 0xf8, 0x74, 0x1e, 0xd5, 0xbc, 0xac, 0xf8, 0x6c, 0xba, 0xd8, 0x93,
-	0x2f, 0x60, 0xb7, 0xed, 0xcd, 0x76, 0x73, 0xde, 0xa2, 0xdf, 0x13, 0x98, 0xee, 0x32, 0x6c, 0x54,
-	0x53, 0x5c, 0x67, 0x75, 0x8b, 0xf6, 0x81, 0xe3, 0x91, 0xf6, 0x65, 0x45, 0x7b, 0x81, 0x5a, 0x3d,
-	0x69, 0x5d, 0x56, 0xaf, 0x67, 0x71, 0x3f, 0x23, 0x30, 0x8a, 0x4e, 0x88, 0xce, 0x69, 0x3e, 0xb0,
-	0x8c, 0x5f, 0x2c, 0x9e, 0xed, 0x13, 0xf5, 0x0f, 0x81, 0x7e, 0x20, 0x30, 0xdd, 0x65, 0x14, 0x74,
-	0xfa, 0xe9, 0x3c, 0x96, 0x4e, 0x3f, 0xad, 0x03, 0x31, 0x5f, 0x51, 0xb8, 0x4b, 0xd4, 0xee, 0x89,
-	0xeb, 0x45, 0xf9, 0x59, 0x5e, 0x01, 0x79, 0x75, 0x96, 0x9f, 0xd3, 0x9d, 0xce, 0xf6, 0x01, 0x36,
-	0x7b, 0x85, 0x20, 0xc7, 0x69, 0xc5, 0x51, 0xa4, 0x05, 0xdd, 0xa9, 0xa5, 0x77, 0x09, 0x8c, 0x25,
-	0xdd, 0x8d, 0x9e, 0xd5, 0x2f, 0x99, 0xbe, 0xce, 0xce, 0xf5, 0x0b, 0xc3, 0xea, 0x67, 0x55, 0xf5,
-	0x12, 0x3d, 0x95, 0xad, 0xae, 0x7a, 0x6d, 0x0a, 0x61, 0x1d, 0x86, 0x55, 0x4f, 0xa6, 0x3d, 0x76,
-	0x94, 0xb4, 0xc3, 0xe2, 0x99, 0x9e, 0x31, 0x58, 0x78, 0x46, 0x15, 0x9e, 0xa2, 0x47, 0xb3, 0x85,
-	0xa9, 0x84, 0x91, 0xb8, 0x2b, 0x52, 0xcd, 0x32, 0x99, 0xd6, 0x5b, 0x9c, 0xeb, 0x1d, 0x84, 0xc5,
-	0x0c, 0x55, 0xac, 0x40, 0x67, 0xb2, 0xc5, 0x24, 0xfe, 0xef, 0x88, 0xfe, 0x48, 0x80, 0x76, 0x77,
-	0x36, 0x6a, 0xf7, 0xbf, 0x7f, 0x33, 0x7d, 0xb9, 0x78, 0xe1, 0xe0, 0x09, 0xfd, 0xef, 0x1c, 0x69,
-	0x63, 0x33, 0xb7, 0x37, 0x3b, 0x9a, 0xfd, 0x56, 0x79, 0xe5, 0xd1, 0x6f, 0x46, 0xee, 0xe1, 0x8e,
-	0x91, 0x7b, 0xb4, 0x63, 0x90, 0xc7, 0x3b, 0x06, 0xf9, 0x75, 0xc7, 0x20, 0x9f, 0xef, 0x1a, 0xb9,
-	0xc7, 0xbb, 0x46, 0xee, 0xe7, 0x5d, 0x23, 0xf7, 0xbe, 0x95, 0x32, 0xf6, 0x75, 0x11, 0x70, 0x8f,
-	0xf9, 0xcc, 0xad, 0x05, 0x32, 0xe4, 0x0c, 0x4b, 0xdd, 0xc1, 0x9f, 0xca, 0xe4, 0xaf, 0x8d, 0xa8,
-	0xff, 0x8f, 0x5d, 0xfa, 0x3b, 0x00, 0x00, 0xff, 0xff, 0x2e, 0x50, 0x99, 0x2c, 0x0d, 0x14, 0x00,
-	0x00,
+	0x1b, 0xc7, 0x3d, 0xa9, 0xf3, 0xc3, 0x4f, 0xd3, 0xfc, 0x98, 0xf6, 0x4d, 0x9d, 0x6d, 0xb2, 0xce,
+	0xbb, 0x4d, 0xdb, 0x34, 0xef, 0x9b, 0xdd, 0xa6, 0x7d, 0xfb, 0x22, 0x71, 0x40, 0xd4, 0x29, 0x2d,
+	0x95, 0x5a, 0x29, 0x6c, 0x84, 0x90, 0x40, 0xc8, 0x9a, 0xec, 0x4e, 0x6c, 0x0b, 0x7b, 0xc7, 0xdd,
+	0x59, 0xa7, 0x8d, 0xa2, 0xa0, 0xaa, 0xa8, 0x27, 0x24, 0x0a, 0xe2, 0x44, 0x25, 0x04, 0xc7, 0x0a,
+	0x01, 0x7f, 0x47, 0x8f, 0x95, 0xb8, 0x70, 0x0a, 0x90, 0x72, 0x40, 0xfd, 0x03, 0x38, 0x70, 0x42,
+	0x3b, 0x3b, 0x13, 0xef, 0xda, 0x59, 0xdb, 0x2d, 0xb9, 0xc1, 0x29, 0xd9, 0x99, 0xe7, 0x99, 0xe7,
+	0x33, 0xdf, 0xf9, 0xf1, 0x3c, 0x63, 0x38, 0x55, 0xdf, 0x0a, 0x2a, 0x8c, 0x5b, 0x77, 0x08, 0xaf,
+	0xdf, 0xb5, 0x36, 0x97, 0xad, 0xdb, 0x4d, 0xea, 0x6f, 0x99, 0x0d, 0x9f, 0x05, 0x0c, 0x8f, 0x47,
+	0x9d, 0xa6, 0xe8, 0x34, 0x37, 0x97, 0xb5, 0x13, 0x65, 0x56, 0x66, 0xa2, 0xcf, 0x0a, 0xff, 0x8b,
+	0xcc, 0xb4, 0x99, 0x32, 0x63, 0xe5, 0x1a, 0xb5, 0x48, 0xa3, 0x6a, 0x11, 0xcf, 0x63, 0x01, 0x09,
+	0xaa, 0xcc, 0xe3, 0xb2, 0x57, 0x77, 0x18, 0xaf, 0x33, 0x6e, 0xad, 0x13, 0x4e, 0xad, 0xcd, 0xe5,
+	0x75, 0x1a, 0x90, 0x65, 0xcb, 0x61, 0x55, 0x4f, 0xf6, 0x2f, 0xc6, 0xfb, 0x45, 0xf4, 0x7d, 0xab,
+	0x06, 0x29, 0x57, 0x3d, 0x31, 0x98, 0x8a, 0xd4, 0x4e, 0xdb, 0x20, 0x3e, 0xa9, 0xef, 0x47, 0x6a,
+	0xef, 0x75, 0x98, 0x17, 0xf8, 0xc4, 0x09, 0xa2, 0x7e, 0xe3, 0x7f, 0x90, 0x7f, 0x2b, 0x1c, 0x7f,
+	0x45, 0x36, 0xdf, 0xf0, 0x36, 0x98, 0x4d, 0x6f, 0x37, 0x29, 0x0f, 0x70, 0x1e, 0x86, 0x89, 0xeb,
+	0xfa, 0x94, 0xf3, 0x3c, 0x9a, 0x43, 0x0b, 0x39, 0x5b, 0x7d, 0x1a, 0x0f, 0x11, 0x4c, 0x1f, 0xe0,
+	0xc6, 0x1b, 0xcc, 0xe3, 0x34, 0xdd, 0x0f, 0xdb, 0x70, 0x4c, 0xc5, 0x2f, 0x55, 0xbd, 0x0d, 0x96,
+	0x1f, 0x98, 0x43, 0x0b, 0x47, 0x2f, 0x16, 0xcc, 0x36, 0x51, 0xcd, 0xf8, 0xb8, 0xab, 0xc5, 0xe2,
+	0xe8, 0x93, 0xdd, 0x42, 0xe6, 0xe9, 0x6e, 0x01, 0x3d, 0xdf, 0x2d, 0x64, 0xec, 0x51, 0x27, 0xd6,
+	0xfb, 0x6a, 0xf6, 0xb7, 0xaf, 0x0b, 0xc8, 0xf8, 0x10, 0x4e, 0x25, 0x80, 0x78, 0x71, 0x6b, 0x85,
+	0xb9, 0x54, 0x4d, 0xe5, 0x24, 0x0c, 0x3b, 0xcc, 0xa5, 0xa5, 0xaa, 0x2b, 0x90, 0xb2, 0xf6, 0x50,
+	0xf8, 0x79, 0xc3, 0xc5, 0xd7, 0x00, 0x5a, 0x8a, 0x4a, 0x9c, 0xb3, 0x66, 0x24, 0xbf, 0x19, 0xca,
+	0x6f, 0x46, 0x8b, 0x2f, 0xe5, 0x37, 0x57, 0x49, 0x59, 0x0d, 0x6a, 0xc7, 0x3c, 0x8d, 0x07, 0x08,
+	0x66, 0x0e, 0x06, 0x90, 0xa2, 0xcc, 0x40, 0x4e, 0x61, 0x87, 0xb2, 0x1c, 0x59, 0xc8, 0xd9, 0xad,
+	0x06, 0x7c, 0xfd, 0x00, 0x8c, 0x73, 0x3d, 0x31, 0xa2, 0xa1, 0x13, 0x1c, 0xf7, 0x14, 0xc7, 0x95,
+	0x5a, 0x4d, 0xa1, 0xac, 0x05, 0x24, 0xa0, 0x3d, 0x17, 0xf5, 0xd0, 0xa4, 0x78, 0x8c, 0x60, 0x36,
+	0x05, 0x41, 0x6a, 0xf1, 0x1a, 0x0c, 0x56, 0x03, 0x5a, 0x8f, 0x74, 0x38, 0x7a, 0xd1, 0x48, 0x5d,
+	0xfe, 0xb5, 0x80, 0xf9, 0xa4, 0x4c, 0x57, 0x8b, 0xc5, 0x6c, 0xb8, 0x03, 0xec, 0xc8, 0xed, 0xf0,
+	0xd4, 0x7a, 0x47, 0x8a, 0x65, 0x93, 0x3b, 0x2f, 0x28, 0xd6, 0x2c, 0x80, 0x88, 0x51, 0x72, 0x49,
+	0x40, 0x04, 0xc2, 0xa8, 0x9d, 0x13, 0x2d, 0x57, 0x49, 0x40, 0x8c, 0x4b, 0x30, 0x9b, 0x32, 0xb0,
+	0x94, 0x00, 0x43, 0x56, 0x78, 0x22, 0xe1, 0x29, 0xfe, 0x37, 0x1e, 0x0e, 0x48, 0xaf, 0xb5, 0x3a,
+	0xf1, 0x03, 0xe5, 0xb7, 0x42, 0x6a, 0x35, 0xc5, 0x33, 0x05, 0x43, 0x9c, 0x7a, 0x2e, 0xf5, 0x25,
+	0x8e, 0xfc, 0x8a, 0x73, 0x0e, 0x24, 0x39, 0x2f, 0x27, 0x38, 0x8f, 0x84, 0xd1, 0x8a, 0x53, 0x7f,
+	0xec, 0x16, 0x70, 0x8c, 0xec, 0x16, 0xe5, 0x3c, 0x54, 0xa8, 0xc5, 0x8f, 0x09, 0x0c, 0x6e, 0x34,
+	0x3d, 0x97, 0xe7, 0xb3, 0x62, 0x85, 0xa6, 0x13, 0xe2, 0x2a, 0x59, 0x57, 0x58, 0xd5, 0x2b, 0x5e,
+	0x08, 0x17, 0xe6, 0x9b, 0x9f, 0x0a, 0x0b, 0xe5, 0x6a, 0x50, 0x69, 0xae, 0x9b, 0x0e, 0xab, 0x5b,
+	0xf2, 0xf6, 0x8a, 0xfe, 0x2c, 0x71, 0xf7, 0x03, 0x2b, 0xd8, 0x6a, 0x50, 0x2e, 0x1c, 0xb8, 0x1d,
+	0x8d, 0x8c, 0x0d, 0x18, 0x75, 0x69, 0x23, 0xe4, 0xf7, 0x9c, 0x2a, 0xe5, 0xf9, 0x41, 0x71, 0x26,
+	0x12, 0x6d, 0xc6, 0x4d, 0xd0, 0xd3, 0x04, 0x91, 0x3a, 0x2e, 0xc6, 0x75, 0x4c, 0x9d, 0x59, 0xa4,
+	0xef, 0x83, 0x01, 0x38, 0x1e, 0x9d, 0x51, 0x52, 0xab, 0xbd, 0x11, 0x54, 0xfe, 0xae, 0xaa, 0xbe,
+	0x0f, 0x27, 0x92, 0x32, 0xbc, 0xb8, 0x96, 0x78, 0x1a, 0x46, 0xca, 0x84, 0x97, 0x9a, 0x9c, 0xba,
+	0x42, 0x9c, 0xac, 0x3d, 0x5c, 0x26, 0xfc, 0x6d, 0x4e, 0xdd, 0xd6, 0x36, 0xbe, 0x4a, 0xd7, 0x9b,
+	0xe5, 0x7f, 0xb6, 0x31, 0x37, 0x1e, 0x21, 0xd0, 0xd3, 0x14, 0x79, 0x09, 0xed, 0xcf, 0xc1, 0x78,
+	0x9d, 0xd6, 0x99, 0xbf, 0x55, 0xe2, 0x1e, 0x69, 0xf0, 0x0a, 0x0b, 0xe4, 0x05, 0x34, 0x16, 0x35,
+	0xaf, 0xc9, 0x56, 0x7c, 0x1a, 0x8e, 0x51, 0xdf, 0x67, 0x7e, 0xa9, 0x1e, 0xf9, 0x0b, 0xe1, 0x72,
+	0xf6, 0xa8, 0x68, 0x94, 0x63, 0x1a, 0xff, 0x81, 0x09, 0x99, 0xb8, 0x7a, 0xa7, 0x4b, 0x63, 0x1b,
+	0x26, 0x63, 0xc6, 0x92, 0xbd, 0x08, 0xb9, 0xc8, 0x3a, 0xcc, 0xe8, 0x48, 0xdc, 0xc6, 0xa7, 0x0e,
+	0xb8, 0xd2, 0x5d, 0x2a, 0xb3, 0xf9, 0xc8, 0x7e, 0x26, 0x1f, 0x71, 0x64, 0x2b, 0x9e, 0x91, 0xf3,
+	0x17, 0x13, 0x29, 0x8e, 0x3c, 0xdf, 0x2d, 0x88, 0xef, 0x68, 0xc6, 0x32, 0xc7, 0x5b, 0x6a, 0xdf,
+	0x4a, 0xa7, 0x9e, 0xb4, 0x04, 0xfe, 0xd5, 0xe6, 0x70, 0x78, 0xc4, 0x92, 0xe9, 0xbd, 0x98, 0x20,
+	0x5c, 0x01, 0x25, 0x33, 0x29, 0x7a, 0xe9, 0x4c, 0xfa, 0x15, 0x02, 0x1c, 0x1f, 0x5d, 0xd2, 0xbf,
+	0x0e, 0xb0, 0x4f, 0xaf, 0x72, 0x68, 0x57, 0xfc, 0x28, 0x79, 0xe6, 0x14, 0xfa, 0x21, 0x26, 0xd0,
+	0x13, 0x12, 0x70, 0x55, 0xd4, 0x9c, 0x72, 0x0e, 0xc6, 0x4d, 0x38, 0x9e, 0x68, 0x95, 0xdc, 0x97,
+	0x61, 0x28, 0xaa, 0x4d, 0xa5, 0x24, 0x27, 0x3b, 0x98, 0x23, 0x07, 0xc9, 0x2b, 0x8d, 0x8d, 0xcf,
+	0xd4, 0xe9, 0x89, 0x97, 0x56, 0x3e, 0x25, 0x01, 0xf3, 0x95, 0xe0, 0xe7, 0x60, 0xdc, 0x89, 0x5a,
+	0x4a, 0xc9, 0x7c, 0x3d, 0x26, 0x9b, 0xaf, 0x1c, 0x72, 0x8d, 0xf3, 0x05, 0x82, 0x42, 0x2a, 0x93,
+	0x9c, 0xee, 0x12, 0xe0, 0xfd, 0x62, 0x57, 0x52, 0x51, 0x55, 0xfa, 0x4d, 0xaa, 0x9e, 0x2b, 0xaa,
+	0xe3, 0xf0, 0xd6, 0x64, 0x1a, 0x4e, 0x0a, 0xb4, 0xeb, 0x84, 0xaf, 0x39, 0x15, 0xea, 0x36, 0x6b,
+	0x6a, 0x0a, 0xc6, 0x47, 0x08, 0xf2, 0x9d, 0x7d, 0xad, 0xb2, 0x7d, 0x93, 0xfa, 0x5c, 0x6d, 0xd9,
+	0xac, 0xad, 0x3e, 0xf1, 0x2d, 0x18, 0xab, 0x30, 0x1e, 0x94, 0x36, 0x9a, 0x9e, 0x13, 0x86, 0x08,
+	0xaf, 0xe7, 0x70, 0xd3, 0xcd, 0x75, 0x2c, 0xe0, 0x9b, 0x8c, 0x07, 0xd7, 0xa4, 0xd5, 0x75, 0xa2,
+	0x56, 0xf2, 0x58, 0x25, 0xd6, 0xcc, 0x2f, 0xfe, 0x3e, 0x06, 0x83, 0x82, 0x02, 0x7f, 0x8c, 0x60,
+	0x34, 0x5e, 0xea, 0xe3, 0xf3, 0x1d, 0x23, 0xa6, 0xbd, 0x4e, 0xb4, 0xc5, 0x7e, 0x4c, 0xa3, 0xa9,
+	0x19, 0xf3, 0xf7, 0x7f, 0xf8, 0xf5, 0xf3, 0x01, 0x1d, 0xcf, 0x74, 0xbe, 0x83, 0xac, 0x6d, 0xb9,
+	0x36, 0x3b, 0xf8, 0x11, 0x82, 0xf1, 0xb6, 0xf2, 0x1d, 0xff, 0xb7, 0x7b, 0x94, 0xe4, 0x33, 0x43,
+	0x5b, 0xea, 0xd3, 0x5a, 0x62, 0x2d, 0x0a, 0xac, 0x79, 0x6c, 0xc4, 0xb1, 0x5c, 0x6a, 0x6d, 0xcb,
+	0xeb, 0x6c, 0xc7, 0x6a, 0xbd, 0x10, 0xbe, 0x44, 0x30, 0xd1, 0x5e, 0x50, 0xe3, 0x94, 0x78, 0x29,
+	0xb5, 0xbf, 0x66, 0xf6, 0x6b, 0xde, 0x8d, 0xaf, 0x5d, 0x36, 0x8b, 0x0b, 0x94, 0xc7, 0x08, 0x26,
+	0xda, 0xab, 0xdd, 0x34, 0xbe, 0x94, 0x72, 0x5b, 0x33, 0xfb, 0x35, 0x97, 0x7c, 0x97, 0x05, 0x9f,
+	0x85, 0x97, 0xba, 0xf2, 0xf9, 0xe4, 0x8e, 0xb5, 0xdd, 0xaa, 0x1e, 0x76, 0xf0, 0xb7, 0x08, 0x26,
+	0x3b, 0x2a, 0x4a, 0x9c, 0x12, 0x3c, 0xad, 0x16, 0xd7, 0xac, 0xbe, 0xed, 0x25, 0xed, 0xff, 0x05,
+	0xed, 0x05, 0x6c, 0x76, 0xa5, 0x75, 0x48, 0xad, 0x96, 0xc4, 0xfd, 0x04, 0xc1, 0xb0, 0x2c, 0xd5,
+	0xf0, 0x7c, 0xca, 0x06, 0x4b, 0x14, 0xb4, 0xda, 0x99, 0x1e, 0x56, 0x7f, 0x11, 0xe8, 0x3b, 0x04,
+	0x93, 0x1d, 0x95, 0x4c, 0x9a, 0x7e, 0x69, 0x45, 0xa0, 0x66, 0xf5, 0x6d, 0x2f, 0x71, 0x5f, 0x11,
+	0xb8, 0xcb, 0xd8, 0xea, 0x8a, 0xeb, 0x86, 0xfe, 0x49, 0x5e, 0x06, 0x59, 0x71, 0x96, 0xff, 0x9d,
+	0x76, 0x3a, 0x5b, 0x07, 0xd8, 0xe8, 0x66, 0x22, 0x39, 0xe6, 0x04, 0x87, 0x86, 0xf3, 0x69, 0xa7,
+	0x16, 0xdf, 0x43, 0x30, 0xa2, 0xd2, 0x2f, 0x3e, 0x93, 0x3e, 0x64, 0xfc, 0x3a, 0x3b, 0xdb, 0xcb,
+	0x4c, 0x46, 0x3f, 0x23, 0xa2, 0x17, 0xf0, 0x6c, 0x32, 0xba, 0x28, 0x06, 0x62, 0x08, 0x15, 0x18,
+	0x0c, 0x5d, 0x39, 0xee, 0x32, 0x23, 0x95, 0xaf, 0xb5, 0xd3, 0x5d, 0x6d, 0x64, 0xe0, 0x29, 0x11,
+	0x78, 0x02, 0x8f, 0x25, 0x03, 0x63, 0x0e, 0x43, 0x51, 0xda, 0xc6, 0x29, 0xc3, 0x24, 0x6a, 0x03,
+	0x6d, 0xbe, 0xbb, 0x91, 0x0c, 0xa6, 0x8b, 0x60, 0x79, 0x3c, 0x95, 0x0c, 0xc6, 0xe5, 0x8f, 0x5b,
+	0xf8, 0x7b, 0x04, 0xb8, 0x33, 0xf5, 0x62, 0xab, 0xf7, 0xfd, 0x9b, 0x28, 0x1c, 0xb4, 0x0b, 0xfd,
+	0x3b, 0xf4, 0xbe, 0x73, 0xb8, 0x25, 0xab, 0x0d, 0x6b, 0xbb, 0xad, 0x1a, 0xd9, 0xc1, 0xf7, 0x11,
+	0x1c, 0x8d, 0x25, 0x5d, 0xbc, 0x70, 0x70, 0xe0, 0xce, 0x9c, 0xad, 0x9d, 0xef, 0xc3, 0x32, 0x5d,
+	0xb5, 0xf0, 0x91, 0xc6, 0xa5, 0x5d, 0x71, 0xf5, 0xc9, 0x2f, 0x7a, 0xe6, 0xf1, 0x9e, 0x9e, 0x79,
+	0xb2, 0xa7, 0xa3, 0xa7, 0x7b, 0x3a, 0xfa, 0x79, 0x4f, 0x47, 0x9f, 0x3e, 0xd3, 0x33, 0x4f, 0x9f,
+	0xe9, 0x99, 0x1f, 0x9f, 0xe9, 0x99, 0x77, 0xcd, 0xd8, 0xf3, 0xa7, 0xc6, 0x7c, 0xea, 0x12, 0x8f,
+	0x38, 0x55, 0x9f, 0x07, 0x94, 0xc8, 0x31, 0xef, 0xca, 0xbf, 0xe2, 0x29, 0xb4, 0x3e, 0x24, 0x7e,
+	0x45, 0xbc, 0xf4, 0xe7, 0x00, 0x28, 0x6e, 0xc0, 0x4a, 0x33, 0x15, 0x00, 0x00,
 }
 
 func (this *QueryContractInfoResponse) Equal(that interface{}) bool {
@@ -1254,6 +1339,8 @@ type QueryClient interface {
 	Params(ctx context.Context, in *QueryParamsRequest, opts ...grpc.CallOption) (*QueryParamsResponse, error)
 	// ContractsByCreator gets the contracts by creator
 	ContractsByCreator(ctx context.Context, in *QueryContractsByCreatorRequest, opts ...grpc.CallOption) (*QueryContractsByCreatorResponse, error)
+	// GasSchedule gets the scheduled gas costs of all host functions
+	GasSchedule(ctx context.Context, in *QueryGasScheduleRequest, opts ...grpc.CallOption) (*QueryGasScheduleResponse, error)
 }
 
 type queryClient struct {
@@ -1372,6 +1459,15 @@ func (c *queryClient) ContractsByCreator(ctx context.Context, in *QueryContracts
 	return out, nil
 }
 
+func (c *queryClient) GasSchedule(ctx context.Context, in *QueryGasScheduleRequest, opts ...grpc.CallOption) (*QueryGasScheduleResponse, error) {
+	out := new(QueryGasScheduleResponse)
+	err := c.cc.Invoke(ctx, "/mythos.wasmx.v1.Query/GasSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QueryServer is the server API for Query service.
 type QueryServer interface {
 	// ContractInfo gets the contract meta data
@@ -1398,6 +1494,8 @@ type QueryServer interface {
 	Params(context.Context, *QueryParamsRequest) (*QueryParamsResponse, error)
 	// ContractsByCreator gets the contracts by creator
 	ContractsByCreator(context.Context, *QueryContractsByCreatorRequest) (*QueryContractsByCreatorResponse, error)
+	// GasSchedule gets the scheduled gas costs of all host functions
+	GasSchedule(context.Context, *QueryGasScheduleRequest) (*QueryGasScheduleResponse, error)
 }
 
 // UnimplementedQueryServer can be embedded to have forward compatible implementations.
@@ -1440,6 +1538,9 @@ func (*UnimplementedQueryServer) Params(ctx context.Context, req *QueryParamsReq
 func (*UnimplementedQueryServer) ContractsByCreator(ctx context.Context, req *QueryContractsByCreatorRequest) (*QueryContractsByCreatorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ContractsByCreator not implemented")
 }
+func (*UnimplementedQueryServer) GasSchedule(ctx context.Context, req *QueryGasScheduleRequest) (*QueryGasScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GasSchedule not implemented")
+}
 
 func RegisterQueryServer(s grpc1.Server, srv QueryServer) {
 	s.RegisterService(&_Query_serviceDesc, srv)
@@ -1661,6 +1762,24 @@ func _Query_ContractsByCreator_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Query_GasSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryGasScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).GasSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mythos.wasmx.v1.Query/GasSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).GasSchedule(ctx, req.(*QueryGasScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Query_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "mythos.wasmx.v1.Query",
 	HandlerType: (*QueryServer)(nil),
@@ -1713,6 +1832,10 @@ var _Query_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ContractsByCreator",
 			Handler:    _Query_ContractsByCreator_Handler,
 		},
+		{
+			MethodName: "GasSchedule",
+			Handler:    _Query_GasSchedule_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "mythos/wasmx/v1/query.proto",
@@ -2699,6 +2822,71 @@ func (m *QueryContractsByCreatorResponse) MarshalToSizedBuffer(dAtA []byte) (int
 	return len(dAtA) - i, nil
 }
 
+func (m *QueryGasScheduleRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryGasScheduleRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryGasScheduleRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryGasScheduleResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryGasScheduleResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryGasScheduleResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.HostFunctions) > 0 {
+		for iNdEx := len(m.HostFunctions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.HostFunctions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Version != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
 	offset -= sovQuery(v)
 	base := offset
@@ -3130,6 +3318,33 @@ func (m *QueryContractsByCreatorResponse) Size() (n int) {
 	return n
 }
 
+func (m *QueryGasScheduleRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *QueryGasScheduleResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovQuery(uint64(m.Version))
+	}
+	if len(m.HostFunctions) > 0 {
+		for _, e := range m.HostFunctions {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovQuery(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -5892,6 +6107,159 @@ func (m *QueryContractsByCreatorResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *QueryGasScheduleRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryGasScheduleRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryGasScheduleRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryGasScheduleResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryGasScheduleResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryGasScheduleResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HostFunctions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HostFunctions = append(m.HostFunctions, HostFunctionGas{})
+			if err := m.HostFunctions[len(m.HostFunctions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipQuery(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
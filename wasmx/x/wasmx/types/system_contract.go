@@ -1013,6 +1013,9 @@ func (p SystemContract) Validate() error {
 	if p.InitMessage == nil {
 		return fmt.Errorf("initialization message cannot be nil")
 	}
+	if err := validateMaxMemoryPages(p.MaxMemoryPages); err != nil {
+		return err
+	}
 	if p.Address != "" {
 		return ValidateNonZeroAddress(p.Address)
 	}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const TracerContextKey ContextKey = "tracer-context"
+
+// call types, as named by the EVM debug tracers
+const (
+	CALL_TYPE_CALL         = "CALL"
+	CALL_TYPE_STATICCALL   = "STATICCALL"
+	CALL_TYPE_DELEGATECALL = "DELEGATECALL"
+	CALL_TYPE_CREATE       = "CREATE"
+)
+
+// Tracer receives the contract execution steps when debug tracing a transaction or call.
+// Calls are nested: each CaptureEnter is followed by its sub-calls and a CaptureExit.
+type Tracer interface {
+	// CaptureEnter is called when a contract call starts
+	CaptureEnter(typ string, from sdk.AccAddress, to sdk.AccAddress, input []byte, gas uint64, value *big.Int)
+	// CaptureExit is called when the last entered contract call ends
+	CaptureExit(output []byte, gasUsed uint64, err error)
+	// CaptureStorageRead is called when a contract reads a key from its storage
+	CaptureStorageRead(contract sdk.AccAddress, key []byte, value []byte)
+	// CaptureStorageWrite is called when a contract writes a key to its storage; value is nil on delete
+	CaptureStorageWrite(contract sdk.AccAddress, key []byte, value []byte)
+	// CaptureLog is called when a contract emits a log
+	CaptureLog(contract sdk.AccAddress, topics [][32]byte, data []byte)
+}
+
+// TraceConfig holds the debug_trace* tracer options
+type TraceConfig struct {
+	// Tracer is the tracer name: callTracer or prestateTracer
+	Tracer string `json:"tracer"`
+	// TracerConfig holds the tracer specific options
+	TracerConfig json.RawMessage `json:"tracerConfig,omitempty"`
+	// Timeout overrides the default trace timeout, as a duration string
+	Timeout *string `json:"timeout,omitempty"`
+}
+
+// WithTracer returns a context that records contract executions with the tracer
+func WithTracer(ctx sdk.Context, tracer Tracer) sdk.Context {
+	return ctx.WithValue(TracerContextKey, tracer)
+}
+
+// GetTracer returns the tracer of the context or nil if the execution is not traced
+func GetTracer(ctx sdk.Context) Tracer {
+	tracer, ok := ctx.Value(TracerContextKey).(Tracer)
+	if !ok {
+		return nil
+	}
+	return tracer
+}
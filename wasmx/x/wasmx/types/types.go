@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"math/big"
+	"time"
 
 	mcodec "github.com/loredanacirstea/wasmx/codec"
 
@@ -25,6 +26,8 @@ type WasmConfig struct {
 	SimulationGasLimit *uint64
 	// SimulationGasLimit is the max gas to be used in a smart query contract call
 	SmartQueryGasLimit uint64
+	// SmartQueryTimeout is the wall-clock deadline of a smart query contract call (0 disables it)
+	SmartQueryTimeout time.Duration
 	// MemoryCacheSize in MiB not bytes
 	MemoryCacheSize uint32
 	// ContractDebugMode log what contract print
@@ -182,5 +185,10 @@ func (c *ContractInfo) ValidateWithAddress(addressCodec address.Codec) error {
 			return sdkerr.Wrap(err, "provenance")
 		}
 	}
+	if c.Admin != "" {
+		if _, err := addressCodec.StringToBytes(c.Admin); err != nil {
+			return sdkerr.Wrap(err, "admin")
+		}
+	}
 	return nil
 }
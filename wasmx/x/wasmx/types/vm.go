@@ -52,6 +52,7 @@ var (
 	ENTRY_POINT_REPLY       = "reply"
 	ENTRY_POINT_TIMED       = "eventual"
 	ENTRY_POINT_P2P_MSG     = "p2pmsg"
+	ENTRY_POINT_MIGRATE     = "migrate"
 
 	// wasi
 	ENTRY_POINT_WASI_COMMAND = "_start"
@@ -64,6 +65,7 @@ func init() {
 	AdditionalEntryPointMap[ENTRY_POINT_REPLY] = true
 	AdditionalEntryPointMap[ENTRY_POINT_TIMED] = true
 	AdditionalEntryPointMap[ENTRY_POINT_P2P_MSG] = true
+	AdditionalEntryPointMap[ENTRY_POINT_MIGRATE] = true
 }
 
 func SetEntryPoint(key string) {
@@ -0,0 +1,438 @@
+package determinism
+
+import (
+	"fmt"
+)
+
+const (
+	opBlock     = 0x02
+	opLoop      = 0x03
+	opIf        = 0x04
+	opEnd       = 0x0b
+	opLocalGet  = 0x20
+	opLocalTee  = 0x22
+	opF32Const  = 0x43
+	opF64Const  = 0x44
+	opF32Eq     = 0x5b
+	opF64Eq     = 0x61
+	opSelect    = 0x1b
+	prefixMisc  = 0xfc
+	prefixSIMD  = 0xfd
+	prefixAtoms = 0xfe
+)
+
+// the canonical NaNs, as produced by most hardware
+var (
+	canonicalNaN32 = []byte{0x00, 0x00, 0xc0, 0x7f}
+	canonicalNaN64 = []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf8, 0x7f}
+)
+
+// nanOp is the position after an instruction whose result can be a NaN
+type nanOp struct {
+	pos int
+	f64 bool
+}
+
+type funcChecker struct {
+	c        *checker
+	r        *reader
+	location string
+	start    int
+	op       int
+	nanOps   []nanOp
+}
+
+func (fc *funcChecker) use(feature string) {
+	if u, found := fc.c.used[feature]; found {
+		u.count++
+		return
+	}
+	fc.c.use(feature, fmt.Sprintf("%s at offset %d", fc.location, fc.op-fc.start))
+}
+
+func (c *checker) codeSection(r *reader) ([]byte, error) {
+	count, err := r.readU32()
+	if err != nil {
+		return nil, err
+	}
+	if count != c.report.Functions {
+		return nil, fmt.Errorf("function and code section have inconsistent lengths: %d, %d", c.report.Functions, count)
+	}
+	out := appendU32(nil, count)
+	for i := uint32(0); i < count; i++ {
+		size, err := r.readU32()
+		if err != nil {
+			return nil, err
+		}
+		body, err := r.readBytes(size)
+		if err != nil {
+			return nil, err
+		}
+		body, err = c.function(c.importedFuncs+i, body)
+		if err != nil {
+			return nil, err
+		}
+		out = appendU32(out, uint32(len(body)))
+		out = append(out, body...)
+	}
+	if !c.canonicalize || c.report.CanonicalizedOps == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
+
+// function checks a function body and returns it with canonicalized NaNs
+func (c *checker) function(funcidx uint32, body []byte) ([]byte, error) {
+	location := fmt.Sprintf("function %d", funcidx)
+	if int(funcidx) >= len(c.funcTypes) || int(c.funcTypes[funcidx]) >= len(c.types) {
+		return nil, fmt.Errorf("invalid type for %s", location)
+	}
+	r := newReader(body)
+	declCount, err := r.readU32()
+	if err != nil {
+		return nil, err
+	}
+	declStart := r.pos
+	numLocals := uint64(len(c.types[c.funcTypes[funcidx]].params))
+	for i := uint32(0); i < declCount; i++ {
+		n, err := r.readU32()
+		if err != nil {
+			return nil, err
+		}
+		t, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if err := c.valType(t, location); err != nil {
+			return nil, err
+		}
+		numLocals += uint64(n)
+	}
+	codeStart := r.pos
+
+	fc := &funcChecker{c: c, r: r, location: location, start: codeStart}
+	var op uint32
+	for !r.done() {
+		op, err = fc.instruction()
+		if err != nil {
+			return nil, fmt.Errorf("%s at offset %d: %w", location, fc.op-codeStart, err)
+		}
+	}
+	if op != opEnd {
+		return nil, fmt.Errorf("%s: missing end", location)
+	}
+	if !c.canonicalize || len(fc.nanOps) == 0 {
+		return body, nil
+	}
+	c.report.CanonicalizedOps += uint32(len(fc.nanOps))
+	return canonicalizeNaNs(body, declCount, declStart, codeStart, numLocals, fc.nanOps), nil
+}
+
+// canonicalizeNaNs replaces a NaN result with the canonical NaN, after each nanOp:
+// (select (local.tee $t) (f32.const nan) (f32.eq (local.get $t) (local.get $t)))
+func canonicalizeNaNs(body []byte, declCount uint32, declStart int, codeStart int, numLocals uint64, ops []nanOp) []byte {
+	has32, has64 := false, false
+	for _, op := range ops {
+		has32 = has32 || !op.f64
+		has64 = has64 || op.f64
+	}
+	// temporary locals, added after the existing ones
+	local32, local64 := uint32(numLocals), uint32(numLocals)
+	if has32 {
+		local64++
+	}
+	newDecls := uint32(0)
+	locals := []byte{}
+	if has32 {
+		newDecls++
+		locals = append(locals, 0x01, valF32)
+	}
+	if has64 {
+		newDecls++
+		locals = append(locals, 0x01, valF64)
+	}
+
+	out := appendU32(make([]byte, 0, len(body)+len(ops)*20), declCount+newDecls)
+	out = append(out, body[declStart:codeStart]...)
+	out = append(out, locals...)
+	last := codeStart
+	for _, op := range ops {
+		out = append(out, body[last:op.pos]...)
+		last = op.pos
+		local, nan, eq := local32, canonicalNaN32, byte(opF32Eq)
+		constOp := byte(opF32Const)
+		if op.f64 {
+			local, nan, eq = local64, canonicalNaN64, opF64Eq
+			constOp = opF64Const
+		}
+		out = appendU32(append(out, opLocalTee), local)
+		out = append(append(out, constOp), nan...)
+		out = appendU32(append(out, opLocalGet), local)
+		out = appendU32(append(out, opLocalGet), local)
+		out = append(out, eq, opSelect)
+	}
+	return append(out, body[last:]...)
+}
+
+// float instructions which can produce a NaN with a runtime dependent bit pattern:
+// arithmetic, rounding, min/max, sqrt, promotion and demotion
+func nanResult(op byte) (bool, bool) {
+	switch {
+	case op >= 0x8d && op <= 0x97:
+		return true, false
+	case op >= 0x9b && op <= 0xa5:
+		return true, true
+	case op == 0xb6:
+		return true, false
+	case op == 0xbb:
+		return true, true
+	}
+	return false, false
+}
+
+func isFloatOp(op byte) bool {
+	switch {
+	case op == 0x2a, op == 0x2b, op == 0x38, op == 0x39, op == opF32Const, op == opF64Const:
+		return true
+	case op >= 0x5b && op <= 0x66:
+		return true
+	case op >= 0x8b && op <= 0xa6:
+		return true
+	case op >= 0xa8 && op <= 0xab:
+		return true
+	case op >= 0xae && op <= 0xbf:
+		return true
+	}
+	return false
+}
+
+func (fc *funcChecker) memarg() error {
+	align, err := fc.r.readU32()
+	if err != nil {
+		return err
+	}
+	// multi-memory encodes the memory index after the alignment
+	if align&0x40 != 0 {
+		fc.use(FeatureMultiMemory)
+		if _, err := fc.r.readU32(); err != nil {
+			return err
+		}
+	}
+	_, err = fc.r.readUleb(64)
+	return err
+}
+
+func (fc *funcChecker) blockType() error {
+	b, err := fc.r.readByte()
+	if err != nil {
+		return err
+	}
+	if b == 0x40 {
+		return nil
+	}
+	if b&0x80 == 0 && b&0x40 != 0 {
+		// a value type
+		return fc.c.valType(b, fc.location)
+	}
+	// a type index
+	fc.r.pos--
+	if _, err := fc.r.readSleb(33); err != nil {
+		return err
+	}
+	fc.use(FeatureMultiValue)
+	return nil
+}
+
+// instruction reads one instruction and returns its opcode,
+// with the sub-opcode of prefixed instructions in the lower bits
+func (fc *funcChecker) instruction() (uint32, error) {
+	r := fc.r
+	fc.op = r.pos
+	op, err := r.readByte()
+	if err != nil {
+		return 0, err
+	}
+	if isFloatOp(op) {
+		fc.use(FeatureFloats)
+	}
+	var err2 error
+	switch {
+	case op == 0x00, op == 0x01, op == 0x05, op == opEnd, op == 0x0f, op == 0x1a, op == opSelect:
+	case op == opBlock, op == opLoop, op == opIf:
+		err2 = fc.blockType()
+	case op == 0x06: // try
+		fc.use(FeatureExceptions)
+		err2 = fc.blockType()
+	case op == 0x07, op == 0x08, op == 0x09, op == 0x18: // catch, throw, rethrow, delegate
+		fc.use(FeatureExceptions)
+		_, err2 = r.readU32()
+	case op == 0x19: // catch_all
+		fc.use(FeatureExceptions)
+	case op == 0x0c, op == 0x0d, op == 0x10: // br, br_if, call
+		_, err2 = r.readU32()
+	case op == 0x0e: // br_table
+		var n uint32
+		n, err2 = r.readU32()
+		for i := uint32(0); i <= n && err2 == nil; i++ {
+			_, err2 = r.readU32()
+		}
+	case op == 0x11: // call_indirect
+		if _, err2 = r.readU32(); err2 == nil {
+			var tableidx uint32
+			tableidx, err2 = r.readU32()
+			if tableidx != 0 {
+				fc.use(FeatureReferenceTypes)
+			}
+		}
+	case op == 0x12: // return_call
+		fc.use(FeatureTailCall)
+		_, err2 = r.readU32()
+	case op == 0x13: // return_call_indirect
+		fc.use(FeatureTailCall)
+		if _, err2 = r.readU32(); err2 == nil {
+			_, err2 = r.readU32()
+		}
+	case op == 0x1c: // select t*
+		fc.use(FeatureReferenceTypes)
+		var n uint32
+		n, err2 = r.readU32()
+		for i := uint32(0); i < n && err2 == nil; i++ {
+			var t byte
+			if t, err2 = r.readByte(); err2 == nil {
+				err2 = fc.c.valType(t, fc.location)
+			}
+		}
+	case op >= 0x20 && op <= 0x24: // local.*, global.*
+		_, err2 = r.readU32()
+	case op == 0x25, op == 0x26: // table.get, table.set
+		fc.use(FeatureReferenceTypes)
+		_, err2 = r.readU32()
+	case op >= 0x28 && op <= 0x3e: // loads and stores
+		err2 = fc.memarg()
+	case op == 0x3f, op == 0x40: // memory.size, memory.grow
+		var memidx uint32
+		memidx, err2 = r.readU32()
+		if memidx != 0 {
+			fc.use(FeatureMultiMemory)
+		}
+	case op == 0x41:
+		_, err2 = r.readSleb(32)
+	case op == 0x42:
+		_, err2 = r.readSleb(64)
+	case op == opF32Const:
+		err2 = r.skip(4)
+	case op == opF64Const:
+		err2 = r.skip(8)
+	case op >= 0x45 && op <= 0xbf:
+		if isNaN, f64 := nanResult(op); isNaN {
+			fc.nanOps = append(fc.nanOps, nanOp{pos: r.pos, f64: f64})
+		}
+	case op >= 0xc0 && op <= 0xc4:
+		fc.use(FeatureSignExtension)
+	case op == 0xd0: // ref.null
+		fc.use(FeatureReferenceTypes)
+		_, err2 = r.readByte()
+	case op == 0xd1: // ref.is_null
+		fc.use(FeatureReferenceTypes)
+	case op == 0xd2: // ref.func
+		fc.use(FeatureReferenceTypes)
+		_, err2 = r.readU32()
+	case op == prefixMisc:
+		var sub uint32
+		sub, err2 = r.readU32()
+		if err2 == nil {
+			err2 = fc.miscInstruction(sub)
+		}
+		return uint32(op)<<24 | sub, err2
+	case op == prefixSIMD:
+		var sub uint32
+		sub, err2 = r.readU32()
+		if err2 == nil {
+			err2 = fc.simdInstruction(sub)
+		}
+		return uint32(op)<<24 | sub, err2
+	case op == prefixAtoms:
+		var sub uint32
+		sub, err2 = r.readU32()
+		if err2 == nil {
+			err2 = fc.atomicInstruction(sub)
+		}
+		return uint32(op)<<24 | sub, err2
+	default:
+		return uint32(op), fmt.Errorf("unknown opcode 0x%x", op)
+	}
+	return uint32(op), err2
+}
+
+func (fc *funcChecker) miscInstruction(sub uint32) error {
+	r := fc.r
+	switch {
+	case sub <= 7: // trunc_sat
+		fc.use(FeatureFloats)
+		fc.use(FeatureNonTrappingFloatToInt)
+		return nil
+	case sub == 8: // memory.init
+		fc.use(FeatureBulkMemory)
+		if _, err := r.readU32(); err != nil {
+			return err
+		}
+		_, err := r.readU32()
+		return err
+	case sub == 9, sub == 13: // data.drop, elem.drop
+		fc.use(FeatureBulkMemory)
+		_, err := r.readU32()
+		return err
+	case sub == 10, sub == 12, sub == 14: // memory.copy, table.init, table.copy
+		fc.use(FeatureBulkMemory)
+		if _, err := r.readU32(); err != nil {
+			return err
+		}
+		_, err := r.readU32()
+		return err
+	case sub == 11: // memory.fill
+		fc.use(FeatureBulkMemory)
+		_, err := r.readU32()
+		return err
+	case sub >= 15 && sub <= 17: // table.grow, table.size, table.fill
+		fc.use(FeatureReferenceTypes)
+		_, err := r.readU32()
+		return err
+	}
+	return fmt.Errorf("unknown opcode 0xfc %d", sub)
+}
+
+func (fc *funcChecker) simdInstruction(sub uint32) error {
+	fc.use(FeatureSIMD)
+	r := fc.r
+	switch {
+	case sub <= 11, sub == 92, sub == 93: // loads, store, load_zero
+		return fc.memarg()
+	case sub == 12, sub == 13: // v128.const, i8x16.shuffle
+		return r.skip(16)
+	case sub >= 21 && sub <= 34: // extract_lane, replace_lane
+		_, err := r.readByte()
+		return err
+	case sub >= 84 && sub <= 91: // load_lane, store_lane
+		if err := fc.memarg(); err != nil {
+			return err
+		}
+		_, err := r.readByte()
+		return err
+	case sub <= 0x113:
+		// no immediates
+		return nil
+	}
+	return fmt.Errorf("unknown opcode 0xfd %d", sub)
+}
+
+func (fc *funcChecker) atomicInstruction(sub uint32) error {
+	fc.use(FeatureThreads)
+	switch {
+	case sub == 0x03: // atomic.fence
+		_, err := fc.r.readByte()
+		return err
+	case sub <= 0x02, sub >= 0x10 && sub <= 0x4e:
+		return fc.memarg()
+	}
+	return fmt.Errorf("unknown opcode 0xfe %d", sub)
+}
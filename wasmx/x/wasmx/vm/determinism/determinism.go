@@ -0,0 +1,691 @@
+// Package determinism checks that uploaded wasm modules only use features which
+// execute the same way in all supported wasm runtimes (wazero interpreter and compiler, WasmEdge),
+// and injects gas metering, so the runtimes also charge the same gas.
+package determinism
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// wasm proposals a module can use, beyond the wasm 1.0 (MVP) instructions
+const (
+	// f32 and f64 values and instructions. NaN-producing instructions are canonicalized.
+	FeatureFloats = "floats"
+	// keep the NaN bit patterns produced by the runtime, which can differ between runtimes
+	FeatureFloatNaNs             = "float_nans"
+	FeatureNonTrappingFloatToInt = "nontrapping_float_to_int"
+	FeatureSignExtension         = "sign_extension"
+	FeatureMultiValue            = "multi_value"
+	FeatureMutableGlobals        = "mutable_globals"
+	FeatureBulkMemory            = "bulk_memory"
+	FeatureReferenceTypes        = "reference_types"
+	FeatureSIMD                  = "simd"
+	FeatureThreads               = "threads"
+	FeatureTailCall              = "tail_call"
+	FeatureExceptions            = "exceptions"
+	FeatureMultiMemory           = "multi_memory"
+	FeatureMemory64              = "memory64"
+)
+
+// Features lists all features known by the checker
+var Features = []string{
+	FeatureFloats,
+	FeatureFloatNaNs,
+	FeatureNonTrappingFloatToInt,
+	FeatureSignExtension,
+	FeatureMultiValue,
+	FeatureMutableGlobals,
+	FeatureBulkMemory,
+	FeatureReferenceTypes,
+	FeatureSIMD,
+	FeatureThreads,
+	FeatureTailCall,
+	FeatureExceptions,
+	FeatureMultiMemory,
+	FeatureMemory64,
+}
+
+// IsFeature returns true for the features known by the checker
+func IsFeature(name string) bool {
+	for _, f := range Features {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Policy is the set of features and limits a module must respect. 0 means no limit.
+type Policy struct {
+	Features map[string]bool
+	// max functions defined by the module
+	MaxFunctions uint32
+	// max tables, including imported tables
+	MaxTables uint32
+	// max elements of a table; tables must declare a max size if they can grow
+	MaxTableSize uint32
+	// max globals, including imported globals
+	MaxGlobals uint32
+	// max data segments
+	MaxDataSegments uint32
+}
+
+func (p Policy) Allowed(feature string) bool {
+	return p.Features[feature]
+}
+
+// Report describes what a module uses
+type Report struct {
+	// features used by the module, sorted
+	Features     []string
+	Functions    uint32
+	Tables       uint32
+	Globals      uint32
+	DataSegments uint32
+	// number of NaN-producing float instructions which were canonicalized
+	CanonicalizedOps uint32
+	// policy violations, empty if the module is accepted
+	Violations []string
+}
+
+func (r Report) Error() error {
+	if len(r.Violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(r.Violations, "; "))
+}
+
+type featureUse struct {
+	count uint32
+	first string
+}
+
+type funcType struct {
+	params  []byte
+	results []byte
+}
+
+type checker struct {
+	policy        Policy
+	report        Report
+	used          map[string]*featureUse
+	types         []funcType
+	funcTypes     []uint32
+	importedFuncs uint32
+	memories      uint32
+	canonicalize  bool
+}
+
+// Check validates a wasm module against the policy. If float NaNs are canonicalized,
+// the returned code is the rewritten module, otherwise it is the original one.
+// A module which violates the policy returns a report with violations and no error;
+// malformed modules return an error.
+func Check(wasm []byte, policy Policy) (Report, []byte, error) {
+	c := &checker{
+		policy:       policy,
+		used:         map[string]*featureUse{},
+		canonicalize: policy.Allowed(FeatureFloats) && !policy.Allowed(FeatureFloatNaNs),
+	}
+	out, err := c.module(wasm)
+	if err != nil {
+		return c.report, nil, err
+	}
+	c.finish()
+	if len(c.report.Violations) > 0 || c.report.CanonicalizedOps == 0 {
+		out = wasm
+	}
+	return c.report, out, nil
+}
+
+func (c *checker) use(feature string, location string) {
+	u, found := c.used[feature]
+	if !found {
+		u = &featureUse{first: location}
+		c.used[feature] = u
+	}
+	u.count++
+}
+
+func (c *checker) violation(format string, args ...interface{}) {
+	c.report.Violations = append(c.report.Violations, fmt.Sprintf(format, args...))
+}
+
+func (c *checker) finish() {
+	features := make([]string, 0, len(c.used))
+	for feature := range c.used {
+		features = append(features, feature)
+	}
+	sort.Strings(features)
+	c.report.Features = features
+
+	violations := c.report.Violations
+	c.report.Violations = nil
+	for _, feature := range features {
+		if !c.policy.Allowed(feature) {
+			u := c.used[feature]
+			c.violation("%s not allowed: used %d times, first in %s", feature, u.count, u.first)
+		}
+	}
+	c.report.Violations = append(c.report.Violations, violations...)
+
+	limit := func(name string, count uint32, max uint32) {
+		if max > 0 && count > max {
+			c.violation("too many %s: %d, max %d", name, count, max)
+		}
+	}
+	limit("functions", c.report.Functions, c.policy.MaxFunctions)
+	limit("tables", c.report.Tables, c.policy.MaxTables)
+	limit("globals", c.report.Globals, c.policy.MaxGlobals)
+	limit("data segments", c.report.DataSegments, c.policy.MaxDataSegments)
+}
+
+var wasmHeader = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+const (
+	sectionCustom    = 0
+	sectionType      = 1
+	sectionImport    = 2
+	sectionFunction  = 3
+	sectionTable     = 4
+	sectionMemory    = 5
+	sectionGlobal    = 6
+	sectionExport    = 7
+	sectionStart     = 8
+	sectionElement   = 9
+	sectionCode      = 10
+	sectionData      = 11
+	sectionDataCount = 12
+	sectionTag       = 13
+)
+
+func (c *checker) module(wasm []byte) ([]byte, error) {
+	if len(wasm) < len(wasmHeader) || !bytes.Equal(wasm[:len(wasmHeader)], wasmHeader) {
+		return nil, fmt.Errorf("invalid wasm header")
+	}
+	r := newReader(wasm)
+	r.pos = len(wasmHeader)
+	out := append([]byte{}, wasmHeader...)
+	for !r.done() {
+		id, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		size, err := r.readU32()
+		if err != nil {
+			return nil, err
+		}
+		start := r.pos
+		content, err := r.readBytes(size)
+		if err != nil {
+			return nil, err
+		}
+		sr := newReader(content)
+		var rewritten []byte
+		switch id {
+		case sectionCustom:
+		case sectionType:
+			err = c.typeSection(sr)
+		case sectionImport:
+			err = c.importSection(sr)
+		case sectionFunction:
+			err = c.functionSection(sr)
+		case sectionTable:
+			err = c.tableSection(sr)
+		case sectionMemory:
+			err = c.memorySection(sr)
+		case sectionGlobal:
+			err = c.globalSection(sr)
+		case sectionExport:
+			err = c.exportSection(sr)
+		case sectionStart:
+		case sectionElement:
+			err = c.elementSection(sr)
+		case sectionCode:
+			rewritten, err = c.codeSection(sr)
+		case sectionData:
+			err = c.dataSection(sr)
+		case sectionDataCount:
+			c.use(FeatureBulkMemory, "data count section")
+		case sectionTag:
+			c.use(FeatureExceptions, "tag section")
+		default:
+			return nil, fmt.Errorf("unknown section id %d at offset %d", id, start)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("section %d at offset %d: %w", id, start, err)
+		}
+		if rewritten != nil {
+			content = rewritten
+		}
+		out = append(out, id)
+		out = appendU32(out, uint32(len(content)))
+		out = append(out, content...)
+	}
+	return out, nil
+}
+
+const (
+	valI32       = 0x7f
+	valI64       = 0x7e
+	valF32       = 0x7d
+	valF64       = 0x7c
+	valV128      = 0x7b
+	valFuncref   = 0x70
+	valExternref = 0x6f
+)
+
+func (c *checker) valType(t byte, location string) error {
+	switch t {
+	case valI32, valI64:
+	case valF32, valF64:
+		c.use(FeatureFloats, location)
+	case valV128:
+		c.use(FeatureSIMD, location)
+	case valFuncref, valExternref:
+		c.use(FeatureReferenceTypes, location)
+	default:
+		return fmt.Errorf("invalid value type 0x%x in %s", t, location)
+	}
+	return nil
+}
+
+func (c *checker) valTypes(r *reader, location string) ([]byte, error) {
+	count, err := r.readU32()
+	if err != nil {
+		return nil, err
+	}
+	types, err := r.readBytes(count)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range types {
+		if err := c.valType(t, location); err != nil {
+			return nil, err
+		}
+	}
+	return types, nil
+}
+
+func (c *checker) typeSection(r *reader) error {
+	count, err := r.readU32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		location := fmt.Sprintf("type %d", i)
+		form, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		if form != 0x60 {
+			return fmt.Errorf("invalid function type form 0x%x in %s", form, location)
+		}
+		params, err := c.valTypes(r, location)
+		if err != nil {
+			return err
+		}
+		results, err := c.valTypes(r, location)
+		if err != nil {
+			return err
+		}
+		if len(results) > 1 {
+			c.use(FeatureMultiValue, location)
+		}
+		c.types = append(c.types, funcType{params: params, results: results})
+	}
+	return nil
+}
+
+func (c *checker) importSection(r *reader) error {
+	count, err := r.readU32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		module, err := r.readName()
+		if err != nil {
+			return err
+		}
+		name, err := r.readName()
+		if err != nil {
+			return err
+		}
+		location := fmt.Sprintf("import %s.%s", module, name)
+		kind, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		switch kind {
+		case 0x00:
+			typeidx, err := r.readU32()
+			if err != nil {
+				return err
+			}
+			c.funcTypes = append(c.funcTypes, typeidx)
+			c.importedFuncs++
+		case 0x01:
+			err = c.tableType(r, location)
+		case 0x02:
+			err = c.memoryType(r, location)
+		case 0x03:
+			var mutable bool
+			mutable, err = c.globalType(r, location)
+			if mutable {
+				c.use(FeatureMutableGlobals, location)
+			}
+		case 0x04:
+			c.use(FeatureExceptions, location)
+			_, err = r.readByte()
+			if err == nil {
+				_, err = r.readU32()
+			}
+		default:
+			return fmt.Errorf("invalid import kind 0x%x in %s", kind, location)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *checker) functionSection(r *reader) error {
+	count, err := r.readU32()
+	if err != nil {
+		return err
+	}
+	c.report.Functions = count
+	for i := uint32(0); i < count; i++ {
+		typeidx, err := r.readU32()
+		if err != nil {
+			return err
+		}
+		c.funcTypes = append(c.funcTypes, typeidx)
+	}
+	return nil
+}
+
+type limits struct {
+	min    uint64
+	max    uint64
+	hasMax bool
+}
+
+func (c *checker) limits(r *reader, location string) (limits, bool, error) {
+	var l limits
+	flags, err := r.readByte()
+	if err != nil {
+		return l, false, err
+	}
+	if flags > 0x07 {
+		return l, false, fmt.Errorf("invalid limits flags 0x%x in %s", flags, location)
+	}
+	bits := uint(32)
+	if flags&0x04 != 0 {
+		c.use(FeatureMemory64, location)
+		bits = 64
+	}
+	l.min, err = r.readUleb(bits)
+	if err != nil {
+		return l, false, err
+	}
+	if flags&0x01 != 0 {
+		l.hasMax = true
+		l.max, err = r.readUleb(bits)
+		if err != nil {
+			return l, false, err
+		}
+	}
+	shared := flags&0x02 != 0
+	return l, shared, nil
+}
+
+func (c *checker) tableType(r *reader, location string) error {
+	reftype, err := r.readByte()
+	if err != nil {
+		return err
+	}
+	switch reftype {
+	case valFuncref:
+	case valExternref:
+		c.use(FeatureReferenceTypes, location)
+	default:
+		return fmt.Errorf("invalid table type 0x%x in %s", reftype, location)
+	}
+	l, _, err := c.limits(r, location)
+	if err != nil {
+		return err
+	}
+	c.report.Tables++
+	if c.report.Tables > 1 {
+		c.use(FeatureReferenceTypes, location)
+	}
+	if max := uint64(c.policy.MaxTableSize); max > 0 {
+		if l.min > max || (l.hasMax && l.max > max) {
+			c.violation("table too large in %s: max %d elements", location, max)
+		} else if !l.hasMax && c.policy.Allowed(FeatureReferenceTypes) {
+			// table.grow can only be bounded by the declared max
+			c.violation("unbounded table in %s: a max size is required", location)
+		}
+	}
+	return nil
+}
+
+func (c *checker) memoryType(r *reader, location string) error {
+	_, shared, err := c.limits(r, location)
+	if err != nil {
+		return err
+	}
+	if shared {
+		c.use(FeatureThreads, location)
+	}
+	c.memories++
+	if c.memories > 1 {
+		c.use(FeatureMultiMemory, location)
+	}
+	return nil
+}
+
+func (c *checker) globalType(r *reader, location string) (bool, error) {
+	t, err := r.readByte()
+	if err != nil {
+		return false, err
+	}
+	if err := c.valType(t, location); err != nil {
+		return false, err
+	}
+	mutable, err := r.readByte()
+	if err != nil {
+		return false, err
+	}
+	if mutable > 1 {
+		return false, fmt.Errorf("invalid global mutability 0x%x in %s", mutable, location)
+	}
+	c.report.Globals++
+	return mutable == 1, nil
+}
+
+func (c *checker) tableSection(r *reader) error {
+	count, err := r.readU32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		if err := c.tableType(r, fmt.Sprintf("table %d", i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *checker) memorySection(r *reader) error {
+	count, err := r.readU32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		if err := c.memoryType(r, fmt.Sprintf("memory %d", i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *checker) globalSection(r *reader) error {
+	count, err := r.readU32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		location := fmt.Sprintf("global %d", i)
+		if _, err := c.globalType(r, location); err != nil {
+			return err
+		}
+		if err := c.constExpr(r, location); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *checker) exportSection(r *reader) error {
+	count, err := r.readU32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		if _, err := r.readName(); err != nil {
+			return err
+		}
+		if _, err := r.readByte(); err != nil {
+			return err
+		}
+		if _, err := r.readU32(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *checker) elementSection(r *reader) error {
+	count, err := r.readU32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		location := fmt.Sprintf("element segment %d", i)
+		flags, err := r.readU32()
+		if err != nil {
+			return err
+		}
+		if flags > 7 {
+			return fmt.Errorf("invalid flags %d in %s", flags, location)
+		}
+		// 1: passive, 3: declarative
+		if flags&0x01 != 0 {
+			c.use(FeatureBulkMemory, location)
+		}
+		// explicit table index
+		if flags == 2 || flags == 6 {
+			tableidx, err := r.readU32()
+			if err != nil {
+				return err
+			}
+			if tableidx != 0 {
+				c.use(FeatureReferenceTypes, location)
+			}
+		}
+		// active segments have an offset
+		if flags&0x01 == 0 {
+			if err := c.constExpr(r, location); err != nil {
+				return err
+			}
+		}
+		exprs := flags&0x04 != 0
+		if flags&0x03 != 0 {
+			// element kind or reference type
+			kind, err := r.readByte()
+			if err != nil {
+				return err
+			}
+			if kind == valExternref {
+				c.use(FeatureReferenceTypes, location)
+			}
+		}
+		n, err := r.readU32()
+		if err != nil {
+			return err
+		}
+		for j := uint32(0); j < n; j++ {
+			if exprs {
+				err = c.constExpr(r, location)
+			} else {
+				_, err = r.readU32()
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *checker) dataSection(r *reader) error {
+	count, err := r.readU32()
+	if err != nil {
+		return err
+	}
+	c.report.DataSegments = count
+	for i := uint32(0); i < count; i++ {
+		location := fmt.Sprintf("data segment %d", i)
+		flags, err := r.readU32()
+		if err != nil {
+			return err
+		}
+		switch flags {
+		case 0:
+			err = c.constExpr(r, location)
+		case 1:
+			c.use(FeatureBulkMemory, location)
+		case 2:
+			var memidx uint32
+			memidx, err = r.readU32()
+			if err == nil && memidx != 0 {
+				c.use(FeatureMultiMemory, location)
+			}
+			if err == nil {
+				err = c.constExpr(r, location)
+			}
+		default:
+			return fmt.Errorf("invalid flags %d in %s", flags, location)
+		}
+		if err != nil {
+			return err
+		}
+		size, err := r.readU32()
+		if err != nil {
+			return err
+		}
+		if err := r.skip(size); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// constExpr checks an initializer expression, up to and including its end
+func (c *checker) constExpr(r *reader, location string) error {
+	fc := &funcChecker{c: c, r: r, location: location}
+	for {
+		op, err := fc.instruction()
+		if err != nil {
+			return err
+		}
+		if op == opEnd {
+			return nil
+		}
+	}
+}
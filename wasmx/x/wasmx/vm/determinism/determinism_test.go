@@ -0,0 +1,97 @@
+package determinism_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/vm/determinism"
+	"github.com/loredanacirstea/wasmx/x/wasmx/vm/determinism/testutils"
+)
+
+func policy(features ...string) determinism.Policy {
+	p := determinism.Policy{Features: map[string]bool{}}
+	for _, f := range features {
+		p.Features[f] = true
+	}
+	return p
+}
+
+func TestRejectFeatures(t *testing.T) {
+	report, code, err := determinism.Check(testutils.SimdConst, policy(determinism.FeatureFloats))
+	require.NoError(t, err)
+	require.Equal(t, []string{determinism.FeatureSIMD}, report.Features)
+	require.Equal(t, testutils.SimdConst, code)
+	require.EqualError(t, report.Error(), "simd not allowed: used 1 times, first in function 0 at offset 0")
+
+	report, _, err = determinism.Check(testutils.SimdConst, policy(determinism.FeatureSIMD))
+	require.NoError(t, err)
+	require.NoError(t, report.Error())
+
+	report, _, err = determinism.Check(testutils.F32Add, policy())
+	require.NoError(t, err)
+	require.Equal(t, []string{determinism.FeatureFloats}, report.Features)
+	require.Error(t, report.Error())
+}
+
+func TestLimits(t *testing.T) {
+	p := policy()
+	p.MaxFunctions = 1
+	report, _, err := determinism.Check(testutils.TwoFuncs, p)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), report.Functions)
+	require.EqualError(t, report.Error(), "too many functions: 2, max 1")
+
+	p.MaxFunctions = 2
+	report, _, err = determinism.Check(testutils.TwoFuncs, p)
+	require.NoError(t, err)
+	require.NoError(t, report.Error())
+}
+
+func TestCanonicalizeNaNs(t *testing.T) {
+	report, code, err := determinism.Check(testutils.F32Add, policy(determinism.FeatureFloats))
+	require.NoError(t, err)
+	require.NoError(t, report.Error())
+	require.Equal(t, uint32(1), report.CanonicalizedOps)
+	require.NotEqual(t, testutils.F32Add, code)
+
+	// the rewritten module is valid and only canonicalizes its new instructions
+	report2, code2, err := determinism.Check(code, policy(determinism.FeatureFloats, determinism.FeatureFloatNaNs))
+	require.NoError(t, err)
+	require.NoError(t, report2.Error())
+	require.Equal(t, uint32(0), report2.CanonicalizedOps)
+	require.Equal(t, code, code2)
+
+	// runtime NaNs are kept if allowed
+	report, code, err = determinism.Check(testutils.F32Add, policy(determinism.FeatureFloats, determinism.FeatureFloatNaNs))
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), report.CanonicalizedOps)
+	require.Equal(t, testutils.F32Add, code)
+}
+
+func TestMalformed(t *testing.T) {
+	_, _, err := determinism.Check(testutils.F32Add[:len(testutils.F32Add)-3], policy(determinism.FeatureFloats))
+	require.Error(t, err)
+	_, _, err = determinism.Check([]byte("not wasm"), policy())
+	require.Error(t, err)
+}
+
+func TestInjectGasMetering(t *testing.T) {
+	for _, code := range [][]byte{testutils.Loop, testutils.F32Add, testutils.TwoFuncs} {
+		metered, err := determinism.InjectGasMetering(code)
+		require.NoError(t, err)
+		require.NotEqual(t, code, metered)
+
+		// the metered module is valid and exports a mutable global
+		report, _, err := determinism.Check(metered, policy(determinism.FeatureFloats, determinism.FeatureFloatNaNs))
+		require.NoError(t, err)
+		require.NoError(t, report.Error())
+		require.Equal(t, uint32(1), report.Globals)
+
+		// the gas global name is reserved, so modules are not metered twice
+		_, err = determinism.InjectGasMetering(metered)
+		require.ErrorContains(t, err, "export name wasmx_gas_left is reserved")
+	}
+	_, err := determinism.InjectGasMetering([]byte("not wasm"))
+	require.Error(t, err)
+}
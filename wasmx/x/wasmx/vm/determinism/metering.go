@@ -0,0 +1,257 @@
+package determinism
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
+)
+
+// GasCostInstruction is the gas charged for each wasm instruction of a metered module
+const GasCostInstruction = uint64(1)
+
+const (
+	opUnreachable = 0x00
+	opGlobalGet   = 0x23
+	opGlobalSet   = 0x24
+	opI64Const    = 0x42
+	opI64LtS      = 0x53
+	opI64Sub      = 0x7d
+	blockEmpty    = 0x40
+)
+
+// sections must be in this order, custom sections can be anywhere
+var sectionOrder = map[byte]int{
+	sectionType:      1,
+	sectionImport:    2,
+	sectionFunction:  3,
+	sectionTable:     4,
+	sectionMemory:    5,
+	sectionTag:       6,
+	sectionGlobal:    7,
+	sectionExport:    8,
+	sectionStart:     9,
+	sectionElement:   10,
+	sectionDataCount: 11,
+	sectionCode:      12,
+	sectionData:      13,
+}
+
+// InjectGasMetering returns the module with gas metering instructions, so all runtimes
+// charge the same gas for the same execution. The module exports a mutable i64 global,
+// memc.GasGlobalExport, with the gas left. Each sequence of instructions which runs
+// without branching subtracts its cost from the global before it starts and traps
+// when the gas left becomes negative. The module must be valid, as checked by Check.
+func InjectGasMetering(wasm []byte) ([]byte, error) {
+	if len(wasm) < len(wasmHeader) || !bytes.Equal(wasm[:len(wasmHeader)], wasmHeader) {
+		return nil, fmt.Errorf("invalid wasm header")
+	}
+	type section struct {
+		id      byte
+		content []byte
+	}
+	c := &checker{used: map[string]*featureUse{}}
+	sections := []section{}
+	r := newReader(wasm)
+	r.pos = len(wasmHeader)
+	for !r.done() {
+		id, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		size, err := r.readU32()
+		if err != nil {
+			return nil, err
+		}
+		content, err := r.readBytes(size)
+		if err != nil {
+			return nil, err
+		}
+		switch id {
+		case sectionImport:
+			err = c.importSection(newReader(content))
+		case sectionGlobal:
+			err = c.globalSection(newReader(content))
+		case sectionExport:
+			err = checkReservedExport(newReader(content))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("section %d: %w", id, err)
+		}
+		sections = append(sections, section{id: id, content: content})
+	}
+	gasGlobal := c.report.Globals
+
+	// the gas global starts with the maximum, so calls without a gas meter do not run out of gas
+	global := []byte{valI64, 0x01, opI64Const}
+	global = appendS64(global, math.MaxInt64)
+	global = append(global, opEnd)
+	export := appendU32(nil, uint32(len(memc.GasGlobalExport)))
+	export = append(export, memc.GasGlobalExport...)
+	export = appendU32(append(export, 0x03), gasGlobal)
+
+	out := append([]byte{}, wasmHeader...)
+	appendSection := func(id byte, content []byte) {
+		out = append(out, id)
+		out = appendU32(out, uint32(len(content)))
+		out = append(out, content...)
+	}
+	added := map[byte]bool{}
+	addMissing := func(before int) {
+		if !added[sectionGlobal] && sectionOrder[sectionGlobal] < before {
+			appendSection(sectionGlobal, append(appendU32(nil, 1), global...))
+			added[sectionGlobal] = true
+		}
+		if !added[sectionExport] && sectionOrder[sectionExport] < before {
+			appendSection(sectionExport, append(appendU32(nil, 1), export...))
+			added[sectionExport] = true
+		}
+	}
+	for _, s := range sections {
+		content := s.content
+		if s.id != sectionCustom {
+			addMissing(sectionOrder[s.id])
+		}
+		var err error
+		switch s.id {
+		case sectionGlobal:
+			content, err = appendVecEntry(content, global)
+			added[sectionGlobal] = true
+		case sectionExport:
+			content, err = appendVecEntry(content, export)
+			added[sectionExport] = true
+		case sectionCode:
+			content, err = meterCodeSection(c, newReader(content), gasGlobal)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("section %d: %w", s.id, err)
+		}
+		appendSection(s.id, content)
+	}
+	addMissing(math.MaxInt)
+	return out, nil
+}
+
+func checkReservedExport(r *reader) error {
+	count, err := r.readU32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		name, err := r.readName()
+		if err != nil {
+			return err
+		}
+		if name == memc.GasGlobalExport {
+			return fmt.Errorf("export name %s is reserved", name)
+		}
+		if _, err := r.readByte(); err != nil {
+			return err
+		}
+		if _, err := r.readU32(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendVecEntry adds an entry to the vector of a section
+func appendVecEntry(content []byte, entry []byte) ([]byte, error) {
+	r := newReader(content)
+	count, err := r.readU32()
+	if err != nil {
+		return nil, err
+	}
+	out := appendU32(nil, count+1)
+	out = append(out, content[r.pos:]...)
+	return append(out, entry...), nil
+}
+
+func meterCodeSection(c *checker, r *reader, gasGlobal uint32) ([]byte, error) {
+	count, err := r.readU32()
+	if err != nil {
+		return nil, err
+	}
+	out := appendU32(nil, count)
+	for i := uint32(0); i < count; i++ {
+		size, err := r.readU32()
+		if err != nil {
+			return nil, err
+		}
+		body, err := r.readBytes(size)
+		if err != nil {
+			return nil, err
+		}
+		body, err = meterFunction(c, body, gasGlobal)
+		if err != nil {
+			return nil, fmt.Errorf("function %d: %w", c.importedFuncs+i, err)
+		}
+		out = appendU32(out, uint32(len(body)))
+		out = append(out, body...)
+	}
+	return out, nil
+}
+
+// meterFunction charges each instruction sequence at its start. A sequence ends after
+// an instruction which starts or ends a block or can branch.
+func meterFunction(c *checker, body []byte, gasGlobal uint32) ([]byte, error) {
+	r := newReader(body)
+	declCount, err := r.readU32()
+	if err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < declCount; i++ {
+		if _, err := r.readU32(); err != nil {
+			return nil, err
+		}
+		if _, err := r.readByte(); err != nil {
+			return nil, err
+		}
+	}
+	codeStart := r.pos
+	out := append(make([]byte, 0, len(body)*2), body[:codeStart]...)
+	fc := &funcChecker{c: c, r: r, start: codeStart}
+	start, cost := codeStart, uint64(0)
+	for !r.done() {
+		op, err := fc.instruction()
+		if err != nil {
+			return nil, fmt.Errorf("offset %d: %w", fc.op-codeStart, err)
+		}
+		cost += GasCostInstruction
+		if endsSequence(op) {
+			out = appendGasCharge(out, gasGlobal, cost)
+			out = append(out, body[start:r.pos]...)
+			start, cost = r.pos, 0
+		}
+	}
+	if cost > 0 {
+		out = appendGasCharge(out, gasGlobal, cost)
+		out = append(out, body[start:]...)
+	}
+	return out, nil
+}
+
+func endsSequence(op uint32) bool {
+	switch op {
+	case opUnreachable, opBlock, opLoop, opIf, 0x05, // else
+		0x06, 0x07, 0x08, 0x09, // try, catch, throw, rethrow
+		opEnd, 0x0c, 0x0d, 0x0e, 0x0f, // br, br_if, br_table, return
+		0x12, 0x13, // return_call, return_call_indirect
+		0x18, 0x19: // delegate, catch_all
+		return true
+	}
+	return false
+}
+
+// appendGasCharge subtracts the cost from the gas global and traps if the gas left is negative:
+// (global.set $gas (i64.sub (global.get $gas) (i64.const cost)))
+// (if (i64.lt_s (global.get $gas) (i64.const 0)) (then unreachable))
+func appendGasCharge(out []byte, gasGlobal uint32, cost uint64) []byte {
+	out = appendU32(append(out, opGlobalGet), gasGlobal)
+	out = appendS64(append(out, opI64Const), int64(cost))
+	out = append(out, opI64Sub)
+	out = appendU32(append(out, opGlobalSet), gasGlobal)
+	out = appendU32(append(out, opGlobalGet), gasGlobal)
+	return append(out, opI64Const, 0x00, opI64LtS, opIf, blockEmpty, opUnreachable, opEnd)
+}
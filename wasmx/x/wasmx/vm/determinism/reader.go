@@ -0,0 +1,124 @@
+package determinism
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errUnexpectedEnd = errors.New("unexpected end of wasm binary")
+
+// reader decodes the wasm binary format
+type reader struct {
+	data []byte
+	pos  int
+}
+
+func newReader(data []byte) *reader {
+	return &reader{data: data}
+}
+
+func (r *reader) done() bool {
+	return r.pos >= len(r.data)
+}
+
+func (r *reader) readByte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, errUnexpectedEnd
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *reader) readBytes(n uint32) ([]byte, error) {
+	if uint64(r.pos)+uint64(n) > uint64(len(r.data)) {
+		return nil, errUnexpectedEnd
+	}
+	bz := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return bz, nil
+}
+
+func (r *reader) skip(n uint32) error {
+	_, err := r.readBytes(n)
+	return err
+}
+
+func (r *reader) readU32() (uint32, error) {
+	v, err := r.readUleb(32)
+	return uint32(v), err
+}
+
+func (r *reader) readUleb(bits uint) (uint64, error) {
+	var result uint64
+	var shift uint
+	for {
+		b, err := r.readByte()
+		if err != nil {
+			return 0, err
+		}
+		if shift >= bits {
+			return 0, fmt.Errorf("integer too large at offset %d", r.pos-1)
+		}
+		result |= uint64(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			return result, nil
+		}
+	}
+}
+
+// readSleb reads a signed integer; only the encoding is checked, not the value
+func (r *reader) readSleb(bits uint) (int64, error) {
+	var result int64
+	var shift uint
+	for {
+		b, err := r.readByte()
+		if err != nil {
+			return 0, err
+		}
+		if shift >= bits {
+			return 0, fmt.Errorf("integer too large at offset %d", r.pos-1)
+		}
+		result |= int64(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			if shift < 64 && b&0x40 != 0 {
+				result |= -1 << shift
+			}
+			return result, nil
+		}
+	}
+}
+
+func (r *reader) readName() (string, error) {
+	n, err := r.readU32()
+	if err != nil {
+		return "", err
+	}
+	bz, err := r.readBytes(n)
+	return string(bz), err
+}
+
+func appendU32(bz []byte, v uint32) []byte {
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			bz = append(bz, b|0x80)
+			continue
+		}
+		return append(bz, b)
+	}
+}
+
+func appendS64(bz []byte, v int64) []byte {
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			return append(bz, b)
+		}
+		bz = append(bz, b|0x80)
+	}
+}
@@ -0,0 +1,61 @@
+// Package testutils has the wasm modules used to test the determinism checker and the runtimes
+package testutils
+
+// F32Add adds 1 to a float, so a NaN argument returns a NaN with a runtime dependent payload
+//
+// (module
+//
+//	(func (export "run") (param i32) (result i32)
+//	  (i32.reinterpret_f32 (f32.add (f32.reinterpret_i32 (local.get 0)) (f32.const 1)))))
+var F32Add = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x06, 0x01, 0x60, 0x01, 0x7f, 0x01, 0x7f,
+	0x03, 0x02, 0x01, 0x00,
+	0x07, 0x07, 0x01, 0x03, 0x72, 0x75, 0x6e, 0x00, 0x00,
+	0x0a, 0x0e, 0x01, 0x0c, 0x00, 0x20, 0x00, 0xbe, 0x43, 0x00, 0x00, 0x80, 0x3f, 0x92, 0xbc, 0x0b,
+}
+
+// SimdConst uses a SIMD instruction
+//
+// (module (func (drop (v128.const i64x2 0 0))))
+var SimdConst = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x04, 0x01, 0x60, 0x00, 0x00,
+	0x03, 0x02, 0x01, 0x00,
+	0x0a, 0x17, 0x01, 0x15, 0x00, 0xfd, 0x0c,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x1a, 0x0b,
+}
+
+// TwoFuncs has two empty functions
+//
+// (module (func) (func))
+var TwoFuncs = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x04, 0x01, 0x60, 0x00, 0x00,
+	0x03, 0x03, 0x02, 0x00, 0x00,
+	0x0a, 0x07, 0x02, 0x02, 0x00, 0x0b, 0x02, 0x00, 0x0b,
+}
+
+// Loop counts its argument down to 0. With injected gas metering, a call costs 6 + 8*n gas.
+//
+// (module
+//
+//	(func (export "run") (param i32)
+//	  (block (loop
+//	    (br_if 1 (i32.eqz (local.get 0)))
+//	    (local.set 0 (i32.sub (local.get 0) (i32.const 1)))
+//	    (br 0)))))
+var Loop = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x05, 0x01, 0x60, 0x01, 0x7f, 0x00,
+	0x03, 0x02, 0x01, 0x00,
+	0x07, 0x07, 0x01, 0x03, 0x72, 0x75, 0x6e, 0x00, 0x00,
+	0x0a, 0x18, 0x01, 0x16, 0x00, 0x02, 0x40, 0x03, 0x40, 0x20, 0x00, 0x45, 0x0d, 0x01,
+	0x20, 0x00, 0x41, 0x01, 0x6b, 0x21, 0x00, 0x0c, 0x00, 0x0b, 0x0b, 0x0b,
+}
+
+// LoopGas is the gas used by Loop with injected gas metering
+func LoopGas(n uint64) uint64 {
+	return 6 + 8*n
+}
@@ -0,0 +1,333 @@
+package vm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/exp/slices"
+
+	sdkerr "cosmossdk.io/errors"
+	log "cosmossdk.io/log"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+	membase "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/base"
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
+)
+
+// HostFnSignature is the signature of a host function, with value types
+// named as in the wasm text format (i32, i64, f32, f64)
+type HostFnSignature struct {
+	InputTypes  []string
+	OutputTypes []string
+}
+
+func (s HostFnSignature) Equal(inputTypes []string, outputTypes []string) bool {
+	return slices.Equal(s.InputTypes, inputTypes) && slices.Equal(s.OutputTypes, outputTypes)
+}
+
+func (s HostFnSignature) String() string {
+	return fmt.Sprintf("(%s) -> (%s)", strings.Join(s.InputTypes, ","), strings.Join(s.OutputTypes, ","))
+}
+
+// HostModules maps the module names registered by a host interface
+// to the signatures of their functions
+type HostModules map[string]map[string]HostFnSignature
+
+var hostSignatures = map[string]HostModules{}
+var hostSignaturesMtx sync.Mutex
+
+// GetHostInterfaceSignatures returns the host modules registered by a host interface
+// (e.g. wasmx_env_i32_2). They are built by the same SystemDepHandler used at runtime,
+// so they cannot drift from what a contract can actually import.
+func GetHostInterfaceSignatures(version string) (HostModules, bool, error) {
+	handler, found := SystemDepHandler[version]
+	if !found {
+		return nil, false, nil
+	}
+	hostSignaturesMtx.Lock()
+	defer hostSignaturesMtx.Unlock()
+	if modules, found := hostSignatures[version]; found {
+		return modules, true, nil
+	}
+
+	vm := &signatureVm{}
+	// some builders read the logger or chain id from the context
+	context := &Context{Ctx: sdk.Context{}.WithLogger(log.NewNopLogger()), ContractRouter: ContractRouter{}}
+	err := handler(context, membase.NewRuntimeHandlerBase(vm), &types.SystemDep{Role: version, Label: version})
+	if err != nil {
+		return nil, true, err
+	}
+	modules := HostModules{}
+	for _, mod := range vm.modules {
+		if _, found := modules[mod.name]; !found {
+			modules[mod.name] = map[string]HostFnSignature{}
+		}
+		for _, fn := range mod.fndefs {
+			modules[mod.name][fn.name] = HostFnSignature{InputTypes: fn.inputTypes, OutputTypes: fn.outputTypes}
+		}
+	}
+	hostSignatures[version] = modules
+	return modules, true, nil
+}
+
+// hostInterfacesForModule returns the host interfaces which register the given module name
+func hostInterfacesForModule(modname string) ([]string, error) {
+	versions := make([]string, 0)
+	for version := range SystemDepHandler {
+		modules, _, err := GetHostInterfaceSignatures(version)
+		if err != nil {
+			return nil, err
+		}
+		if _, found := modules[modname]; found {
+			versions = append(versions, version)
+		}
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
+
+// EffectiveGasSchedule returns the gas costs of all host functions, sorted by module and name.
+// Functions without a schedule entry cost memc.DefaultHostFunctionGas.
+func EffectiveGasSchedule(schedule memc.GasSchedule) ([]types.HostFunctionGas, error) {
+	versions := make([]string, 0, len(SystemDepHandler))
+	for version := range SystemDepHandler {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	seen := map[string]bool{}
+	costs := make([]types.HostFunctionGas, 0)
+	for _, version := range versions {
+		modules, _, err := GetHostInterfaceSignatures(version)
+		if err != nil {
+			return nil, sdkerr.Wrapf(err, "cannot build host interface %s", version)
+		}
+		for modname, fns := range modules {
+			for name := range fns {
+				key := memc.HostFunctionKey(modname, name)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				cost := schedule.Cost(modname, name)
+				costs = append(costs, types.HostFunctionGas{Module: modname, Name: name, Base: cost.Base, PerByte: cost.PerByte})
+			}
+		}
+	}
+	sort.Slice(costs, func(i, j int) bool {
+		if costs[i].Module != costs[j].Module {
+			return costs[i].Module < costs[j].Module
+		}
+		return costs[i].Name < costs[j].Name
+	})
+	return costs, nil
+}
+
+// VerifyEnv checks that all function imports are provided by the declared host interfaces,
+// with matching signatures, and reports each import that is not.
+// Imports from host interfaces that are not declared are rejected.
+func VerifyEnv(deps []string, imports []memc.WasmImport) error {
+	if len(deps) == 0 {
+		deps = []string{types.DEFAULT_SYS_DEP}
+	}
+	declared := HostModules{}
+	for _, dep := range deps {
+		// deps that are not host interfaces (e.g. interpreters, libraries) provide no imports
+		modules, found, err := GetHostInterfaceSignatures(dep)
+		if err != nil {
+			return sdkerr.Wrapf(err, "cannot build host interface %s", dep)
+		}
+		if !found {
+			continue
+		}
+		for modname, fns := range modules {
+			if _, found := declared[modname]; !found {
+				declared[modname] = map[string]HostFnSignature{}
+			}
+			for name, sig := range fns {
+				declared[modname][name] = sig
+			}
+		}
+	}
+
+	invalid := make([]string, 0)
+	for _, mimport := range imports {
+		modname := mimport.ModuleName()
+		name := fmt.Sprintf("%s.%s", modname, mimport.Name())
+		sig := HostFnSignature{InputTypes: mimport.InputTypes(), OutputTypes: mimport.OutputTypes()}
+
+		if fns, found := declared[modname]; found {
+			expected, found := fns[mimport.Name()]
+			if !found {
+				invalid = append(invalid, fmt.Sprintf("%s: unknown function", name))
+			} else if !expected.Equal(sig.InputTypes, sig.OutputTypes) {
+				invalid = append(invalid, fmt.Sprintf("%s: wrong type %s, expected %s", name, sig.String(), expected.String()))
+			}
+			continue
+		}
+
+		versions, err := hostInterfacesForModule(modname)
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			invalid = append(invalid, fmt.Sprintf("%s: unknown module", name))
+			continue
+		}
+		invalid = append(invalid, fmt.Sprintf("%s: host API not declared, provided by %s", name, strings.Join(versions, ", ")))
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("%s", strings.Join(invalid, "; "))
+	}
+	return nil
+}
+
+type signatureFn struct {
+	name        string
+	inputTypes  []string
+	outputTypes []string
+}
+
+func (f signatureFn) Name() string {
+	return f.name
+}
+
+func (f signatureFn) InputTypes() []interface{} {
+	return fromStringSlice(f.inputTypes)
+}
+
+func (f signatureFn) OutputTypes() []interface{} {
+	return fromStringSlice(f.outputTypes)
+}
+
+func (f signatureFn) Fn(_ interface{}, _ memc.RuntimeHandler, _ []interface{}) ([]interface{}, error) {
+	return nil, fmt.Errorf("host function signature %s cannot be executed", f.name)
+}
+
+func (f signatureFn) Cost() int32 {
+	return 0
+}
+
+type signatureModule struct {
+	name   string
+	fndefs []signatureFn
+}
+
+// signatureVm only records the host modules registered on it
+type signatureVm struct {
+	modules []*signatureModule
+}
+
+var _ memc.IVm = (*signatureVm)(nil)
+
+func (vm *signatureVm) Call(name string, _ []interface{}, _ memc.GasMeter) ([]interface{}, error) {
+	return nil, fmt.Errorf("signature vm: cannot call %s", name)
+}
+
+func (vm *signatureVm) GetMemory() (memc.IMemory, error) {
+	return nil, fmt.Errorf("signature vm: no memory")
+}
+
+func (vm *signatureVm) New(_ sdk.Context, _ bool) memc.IVm {
+	return &signatureVm{}
+}
+
+func (vm *signatureVm) Cleanup() {}
+
+func (vm *signatureVm) InstantiateWasm(_ string, _ string, _ []byte) error {
+	return nil
+}
+
+func (vm *signatureVm) RegisterModule(mod interface{}) error {
+	smod, ok := mod.(*signatureModule)
+	if !ok {
+		return fmt.Errorf("signature vm: unexpected module type %T", mod)
+	}
+	vm.modules = append(vm.modules, smod)
+	return nil
+}
+
+func (vm *signatureVm) BuildModule(_ memc.RuntimeHandler, modname string, _ interface{}, fndefs []memc.IFn) (interface{}, error) {
+	mod := &signatureModule{name: modname}
+	for _, fndef := range fndefs {
+		fn, ok := fndef.(signatureFn)
+		if !ok {
+			return nil, fmt.Errorf("signature vm: unexpected function type %T", fndef)
+		}
+		mod.fndefs = append(mod.fndefs, fn)
+	}
+	return mod, nil
+}
+
+func (vm *signatureVm) BuildFn(fnname string, _ memc.IFnVal, inputTypes []interface{}, outputTypes []interface{}, _ int32) memc.IFn {
+	return signatureFn{name: fnname, inputTypes: toStringSlice(inputTypes), outputTypes: toStringSlice(outputTypes)}
+}
+
+func (vm *signatureVm) ValType_I32() interface{} {
+	return "i32"
+}
+
+func (vm *signatureVm) ValType_I64() interface{} {
+	return "i64"
+}
+
+func (vm *signatureVm) ValType_F32() interface{} {
+	return "f32"
+}
+
+func (vm *signatureVm) ValType_F64() interface{} {
+	return "f64"
+}
+
+func (vm *signatureVm) GetFunctionList() []string {
+	return nil
+}
+
+func (vm *signatureVm) FindGlobal(_ string) interface{} {
+	return nil
+}
+
+func (vm *signatureVm) ListRegisteredModule() []string {
+	names := make([]string, len(vm.modules))
+	for i, mod := range vm.modules {
+		names[i] = mod.name
+	}
+	return names
+}
+
+func (vm *signatureVm) InstantiateWasi(_ []string, _ []string, _ []string, _ map[string][]byte) {}
+
+func (vm *signatureVm) WasiArgs() []string {
+	return nil
+}
+
+func (vm *signatureVm) WasiEnvs() []string {
+	return nil
+}
+
+func (vm *signatureVm) WasiPreopens() []string {
+	return nil
+}
+
+func (vm *signatureVm) WasiFileMap() map[string][]byte {
+	return nil
+}
+
+func toStringSlice(values []interface{}) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = fmt.Sprintf("%v", v)
+	}
+	return result
+}
+
+func fromStringSlice(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
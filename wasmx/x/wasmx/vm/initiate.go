@@ -236,7 +236,7 @@ var SystemDepHandler = map[string]func(context *Context, rnh memc.RuntimeHandler
 
 var SystemDepHandlerMock = map[string]func(context *Context, rnh memc.RuntimeHandler, dep *types.SystemDep) error{}
 
-type ExecuteFunctionInterface func(context *Context, vm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]int32, error)
+type ExecuteFunctionInterface func(context *Context, vm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]interface{}, error)
 
 type ExecuteFunctionHandlerInterface func(systemDeps []types.SystemDep) ExecuteFunctionInterface
 
@@ -361,21 +361,21 @@ func GetExecuteFunctionHandlerForLabels(systemDeps []types.SystemDep) ExecuteFun
 	return ExecuteDefaultMain
 }
 
-func ExecuteDefault(context *Context, contractVm memc.IVm, funcName string, interpreted bool) ([]int32, error) {
+func ExecuteDefault(context *Context, contractVm memc.IVm, funcName string, interpreted bool) ([]interface{}, error) {
 	return contractVm.Call(funcName, []interface{}{}, context.GasMeter)
 }
 
-func ExecuteDefaultContract(context *Context, contractVm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]int32, error) {
+func ExecuteDefaultContract(context *Context, contractVm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]interface{}, error) {
 	if funcName == types.ENTRY_POINT_EXECUTE || funcName == types.ENTRY_POINT_QUERY {
 		funcName = "main"
 	}
 	if interpreted && funcName == types.ENTRY_POINT_INSTANTIATE {
 		funcName = "main"
 	}
-	return contractVm.Call(funcName, []interface{}{}, context.GasMeter)
+	return contractVm.Call(funcName, args, context.GasMeter)
 }
 
-func ExecuteDefaultMain(context *Context, contractVm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]int32, error) {
+func ExecuteDefaultMain(context *Context, contractVm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]interface{}, error) {
 	return contractVm.Call("main", []interface{}{}, context.GasMeter)
 }
 
@@ -387,7 +387,7 @@ func ExecuteFunctionWrapperNoop(fn ExecuteFunctionInterface) ExecuteFunctionHand
 
 func ExecuteDefaultInterpreter(systemDeps []types.SystemDep) ExecuteFunctionInterface {
 	handler := GetExecuteFunctionHandlerForLabels(systemDeps)
-	return func(context *Context, contractVm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]int32, error) {
+	return func(context *Context, contractVm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]interface{}, error) {
 		return handler(context, contractVm, funcName, args, true)
 	}
 }
@@ -101,7 +101,7 @@ func AllocateMemVm(vm memc.IVm, mem memc.IMemory, size int32) (int32, error) {
 	if err != nil {
 		return 0, err
 	}
-	return result[0], nil
+	return memc.ResultI32(result, 0)
 }
 
 func AllocateWriteMem(vm memc.IVm, mem memc.IMemory, data []byte) (interface{}, error) {
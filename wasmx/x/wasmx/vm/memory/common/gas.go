@@ -0,0 +1,117 @@
+package common
+
+import (
+	"math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// HostFunctionGas is the gas charged for one host function call:
+// a base cost and a cost per byte read from or written to the wasm memory
+type HostFunctionGas struct {
+	Base    uint64
+	PerByte uint64
+}
+
+// GasSchedule maps host functions (module.name) to their gas costs
+type GasSchedule map[string]HostFunctionGas
+
+// HostFunctionKey is the GasSchedule key of a host function
+func HostFunctionKey(module string, name string) string {
+	return module + "." + name
+}
+
+// DefaultHostFunctionGas is charged by all runtimes for host functions missing from the schedule
+var DefaultHostFunctionGas = HostFunctionGas{Base: 100, PerByte: 3}
+
+// Cost returns the scheduled cost of a host function,
+// or DefaultHostFunctionGas if the function is missing from the schedule
+func (s GasSchedule) Cost(module string, name string) HostFunctionGas {
+	cost, found := s[HostFunctionKey(module, name)]
+	if !found {
+		return DefaultHostFunctionGas
+	}
+	return cost
+}
+
+// Gas returns the cost of a call which moved the given number of bytes
+func (c HostFunctionGas) Gas(bytes uint64) uint64 {
+	if c.PerByte > 0 && bytes > (math.MaxUint64-c.Base)/c.PerByte {
+		return math.MaxUint64
+	}
+	return c.Base + c.PerByte*bytes
+}
+
+// GasGlobalExport is the mutable i64 global exported by modules with injected gas metering.
+// It holds the gas left for wasm instructions: the runtime sets it before a call
+// and charges the difference after the call.
+const GasGlobalExport = "wasmx_gas_left"
+
+// GasGlobalStart returns the value of the gas global before a call
+func GasGlobalStart(gasMeter GasMeter) int64 {
+	remaining := gasMeter.GasRemaining()
+	if remaining > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(remaining)
+}
+
+// GasGlobalUsed returns the gas used by the wasm instructions of a call. A negative gas left
+// means the module ran out of gas, so more than the gas available at the start is used.
+func GasGlobalUsed(start int64, left int64) uint64 {
+	if left < 0 {
+		return uint64(start) + 1
+	}
+	return uint64(start - left)
+}
+
+const gasScheduleContextKey limitsContextKey = "gas-schedule"
+
+// WithGasSchedule returns a context for creating wasm vms
+// which charge host function calls according to the schedule
+func WithGasSchedule(ctx sdk.Context, schedule GasSchedule) sdk.Context {
+	return ctx.WithValue(gasScheduleContextKey, schedule)
+}
+
+// GetGasSchedule returns the gas schedule set on the context, or nil
+func GetGasSchedule(ctx sdk.Context) GasSchedule {
+	schedule, ok := ctx.Value(gasScheduleContextKey).(GasSchedule)
+	if !ok {
+		return nil
+	}
+	return schedule
+}
+
+// CountingMemory counts the bytes host functions read from and write to the wasm memory
+type CountingMemory struct {
+	IMemory
+	counter *uint64
+}
+
+var _ IMemory = (*CountingMemory)(nil)
+
+func NewCountingMemory(mem IMemory, counter *uint64) IMemory {
+	return CountingMemory{IMemory: mem, counter: counter}
+}
+
+func (m CountingMemory) ReadRaw(ptr interface{}, size interface{}) ([]byte, error) {
+	data, err := m.IMemory.ReadRaw(ptr, size)
+	*m.counter += uint64(len(data))
+	return data, err
+}
+
+func (m CountingMemory) WriteRaw(ptr interface{}, data []byte) error {
+	*m.counter += uint64(len(data))
+	return m.IMemory.WriteRaw(ptr, data)
+}
+
+func (m CountingMemory) Read(ptr int32, size int32) ([]byte, error) {
+	data, err := m.IMemory.Read(ptr, size)
+	*m.counter += uint64(len(data))
+	return data, err
+}
+
+func (m CountingMemory) Write(ptr int32, data []byte) error {
+	*m.counter += uint64(len(data))
+	return m.IMemory.Write(ptr, data)
+}
@@ -0,0 +1,38 @@
+package common
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// WASM_PAGE_SIZE is the size of a wasm memory page (64KiB)
+const WASM_PAGE_SIZE = 65536
+
+// WASM_MAX_PAGES is the wasm32 memory limit of 4GiB
+const WASM_MAX_PAGES = uint32(65536)
+
+type limitsContextKey string
+
+const memoryLimitContextKey limitsContextKey = "memory-limit-pages"
+
+// WithMemoryLimitPages returns a context for creating wasm vms
+// with a memory limit of the given number of pages. 0 means no limit.
+func WithMemoryLimitPages(ctx sdk.Context, pages uint32) sdk.Context {
+	return ctx.WithValue(memoryLimitContextKey, pages)
+}
+
+// GetMemoryLimitPages returns the memory limit set on the context, or 0 if there is no limit
+func GetMemoryLimitPages(ctx sdk.Context) uint32 {
+	pages, ok := ctx.Value(memoryLimitContextKey).(uint32)
+	if !ok {
+		return 0
+	}
+	return pages
+}
+
+// MemoryLimitReached returns true if the vm memory has grown up to the limit
+func MemoryLimitReached(mem IMemory, pages uint32) bool {
+	if mem == nil || pages == 0 {
+		return false
+	}
+	return uint64(mem.Size()) >= uint64(pages)*WASM_PAGE_SIZE
+}
@@ -20,11 +20,19 @@ type GasMeter interface {
 	ConsumeGas(gas uint64, descriptor string)
 }
 
+// WasmRuntimeConfig configures the caches of a wasm runtime
+type WasmRuntimeConfig struct {
+	// ModuleCacheSize is the max number of compiled modules kept in memory (0 disables the cache)
+	ModuleCacheSize int
+	// CompilationCacheDir is where compiled code is persisted across restarts (empty keeps it in memory)
+	CompilationCacheDir string
+}
+
 type IFnVal = func(context interface{}, mod RuntimeHandler, params []interface{}) ([]interface{}, error)
 
 type IWasmVmMeta interface {
 	LibVersion() string
-	InitWasmRuntime(parentCtx context.Context)
+	InitWasmRuntime(parentCtx context.Context, cfg WasmRuntimeConfig)
 	NewWasmVm(ctx sdk.Context, aot bool) IVm
 	AnalyzeWasm(ctx sdk.Context, wasmbuffer []byte) (WasmMeta, error)
 	AotCompile(ctx sdk.Context, inPath string, outPath string, meteringOff bool) error
@@ -47,8 +55,9 @@ type WasmExport interface {
 
 type WasmImport interface {
 	Name() string
-	// InputTypes() []interface{}
-	// OutputTypes() []interface{}
+	// value types named as in the wasm text format (i32, i64, f32, f64)
+	InputTypes() []string
+	OutputTypes() []string
 	Fn() interface{}
 	ModuleName() string
 }
@@ -69,7 +78,9 @@ type IMemory interface {
 type NewIVmFn = func(ctx sdk.Context, aot bool) IVm
 
 type IVm interface {
-	Call(name string, args []interface{}, gasMeter GasMeter) ([]int32, error)
+	// Call executes an exported function. Arguments and results are typed Go values
+	// matching the wasm types of the function: int32 (i32), int64 (i64), float32 (f32), float64 (f64).
+	Call(name string, args []interface{}, gasMeter GasMeter) ([]interface{}, error)
 	GetMemory() (IMemory, error)
 	New(ctx sdk.Context, aot bool) IVm
 	Cleanup()
@@ -79,6 +90,7 @@ type IVm interface {
 	BuildFn(fnname string, fnval IFnVal, inputTypes []interface{}, outputTypes []interface{}, cost int32) IFn
 	ValType_I32() interface{}
 	ValType_I64() interface{}
+	ValType_F32() interface{}
 	ValType_F64() interface{}
 	GetFunctionList() []string
 	FindGlobal(name string) interface{}
@@ -232,12 +244,36 @@ func FromInt32Slice(input []int32) []interface{} {
 	return result
 }
 
+// ResultI32 returns the i32 result at index
+func ResultI32(results []interface{}, index int) (int32, error) {
+	if index >= len(results) {
+		return 0, fmt.Errorf("missing result at index %d", index)
+	}
+	val, ok := results[index].(int32)
+	if !ok {
+		return 0, fmt.Errorf("result at index %d is not an i32: %T", index, results[index])
+	}
+	return val, nil
+}
+
+// ResultI64 returns the i64 result at index
+func ResultI64(results []interface{}, index int) (int64, error) {
+	if index >= len(results) {
+		return 0, fmt.Errorf("missing result at index %d", index)
+	}
+	val, ok := results[index].(int64)
+	if !ok {
+		return 0, fmt.Errorf("result at index %d is not an i64: %T", index, results[index])
+	}
+	return val, nil
+}
+
 func AllocateMemory(vm IVm, allocMemName string, size int32) (int32, error) {
 	result, err := vm.Call(allocMemName, []interface{}{size}, nil)
 	if err != nil {
 		return 0, err
 	}
-	return result[0], nil
+	return ResultI32(result, 0)
 }
 
 func FreeMemory(vm IVm, freeMemName string, ptr int32) error {
@@ -275,6 +311,6 @@ func (WasmRuntimeMockVmMeta) AotCompile(_ sdk.Context, inPath string, outPath st
 	return fmt.Errorf("runtime mock: AotCompile not implemented")
 }
 
-func (WasmRuntimeMockVmMeta) InitWasmRuntime(_ context.Context) {
-	panic(fmt.Errorf("runtime mock: InitWasmRuntime not implemented"))
+func (WasmRuntimeMockVmMeta) InitWasmRuntime(_ context.Context, _ WasmRuntimeConfig) {
+	// nothing to initialize
 }
@@ -128,7 +128,7 @@ func AllocateMemory(vm memc.IVm, allocMemName string, size int32) (int32, error)
 	if err != nil {
 		return 0, err
 	}
-	return result[0], nil
+	return memc.ResultI32(result, 0)
 }
 
 func FreeMemory(vm memc.IVm, freeMemName string, ptr int32) error {
@@ -35,7 +35,7 @@ func AllocateMemDefaultMalloc(vm memc.IVm, size int32) (int32, error) {
 	if err != nil {
 		return 0, err
 	}
-	return result[0], nil
+	return memc.ResultI32(result, 0)
 }
 
 func BuildPtrI64(ptr int32, datalen int32) int64 {
@@ -185,8 +185,11 @@ func ShamirRecover(vm memc.IVm, mem memc.IMemory, input []byte) (*ResultSecret,
 	return data, nil
 }
 
-func getResult(mem memc.IMemory, result []int32) ([]byte, error) {
-	outputPointer := result[0]
+func getResult(mem memc.IMemory, result []interface{}) ([]byte, error) {
+	outputPointer, err := memc.ResultI32(result, 0)
+	if err != nil {
+		return nil, err
+	}
 	memData, err := mem.Read(outputPointer, LENGTH_SIZE)
 	if err != nil {
 		return nil, err
@@ -211,7 +214,10 @@ func allocateInput(vm memc.IVm, mem memc.IMemory, input []byte) (int32, error) {
 	if err != nil {
 		return 0, err
 	}
-	inputPointer := allocateResult[0]
+	inputPointer, err := memc.ResultI32(allocateResult, 0)
+	if err != nil {
+		return 0, err
+	}
 
 	// Write the subject into the memory.
 	memData, err := mem.Read(inputPointer, int32(inputLen)+1)
@@ -109,11 +109,19 @@ func WasmxCall(ctx *Context, req vmtypes.CallRequestCommon) (int32, []byte) {
 	if types.IsSystemAddress(req.To.Bytes()) && !ctx.CosmosHandler.CanCallSystemContract(ctx.Ctx, req.From) {
 		return int32(1), []byte(`wasmxcall: cannot call system contract`)
 	}
+	if ctx.ContractInfo != nil && ctx.ContractInfo.MaxCallDepth > 0 && ctx.CurrentSubCallLevel >= ctx.ContractInfo.MaxCallDepth {
+		return int32(1), []byte(types.ErrCallDepth.Wrapf("wasmxcall: depth %d", ctx.CurrentSubCallLevel+1).Error())
+	}
+	callType := types.CALL_TYPE_CALL
+	if req.IsQuery {
+		callType = types.CALL_TYPE_STATICCALL
+	}
 	depContractInfo := GetContractDependency(ctx, req.To)
 	// ! we return success here in case the contract does not exist
 	// an empty transaction to any account should succeed (evm way)
 	// even with value 0 & no calldata
 	if depContractInfo == nil {
+		traceCall(ctx.Ctx, ctx.GasMeter, callType, req.From.Bytes(), req.To.Bytes(), req.Calldata, req.GasLimit, req.Value)(nil, nil)
 		return int32(0), []byte(`wasmxcall: cannot get contract context`)
 	}
 
@@ -165,6 +173,7 @@ func WasmxCall(ctx *Context, req vmtypes.CallRequestCommon) (int32, []byte) {
 	if depContractInfo.Role == types.ROLE_LIBRARY {
 		// use the sender contract if the call is to a library
 		to = req.From
+		callType = types.CALL_TYPE_DELEGATECALL
 		tostr2 = fromstr
 		// TODO
 		// newrouter[tostr2].ContractInfo.
@@ -198,20 +207,23 @@ func WasmxCall(ctx *Context, req vmtypes.CallRequestCommon) (int32, []byte) {
 		}
 		ci := newrouter[routerAddress].ContractInfo
 		newrouter[routerAddress].ContractInfo = &types.ContractDependency{
-			Address:       ci.Address,
-			Role:          ci.Role,
-			RoleLabel:     ci.RoleLabel,
-			Label:         ci.Label,
-			StoreKey:      ci.StoreKey,
-			CodeFilePath:  ci.CodeFilePath,
-			AotFilePath:   ci.AotFilePath,
-			Bytecode:      ci.Bytecode,
-			CodeHash:      ci.CodeHash,
-			CodeId:        ci.CodeId,
-			StorageType:   ci.StorageType,
-			Pinned:        ci.Pinned,
-			SystemDepsRaw: systemDeps,
-			SystemDeps:    sysdeps,
+			Address:        ci.Address,
+			Role:           ci.Role,
+			RoleLabel:      ci.RoleLabel,
+			Label:          ci.Label,
+			StoreKey:       ci.StoreKey,
+			CodeFilePath:   ci.CodeFilePath,
+			AotFilePath:    ci.AotFilePath,
+			Bytecode:       ci.Bytecode,
+			CodeHash:       ci.CodeHash,
+			CodeId:         ci.CodeId,
+			StorageType:    ci.StorageType,
+			Pinned:         ci.Pinned,
+			MaxMemoryPages: ci.MaxMemoryPages,
+			MaxCallDepth:   ci.MaxCallDepth,
+			GasSchedule:    ci.GasSchedule,
+			SystemDepsRaw:  systemDeps,
+			SystemDeps:     sysdeps,
 		}
 		newrouter[routerAddress].ContractInfo.SystemDepsRaw = systemDeps
 		newrouter[routerAddress].ContractInfo.SystemDeps = sysdeps
@@ -224,7 +236,9 @@ func WasmxCall(ctx *Context, req vmtypes.CallRequestCommon) (int32, []byte) {
 	newCosmosHandler := ctx.CosmosHandler.WithNewAddress(to)
 	sysDeps := newrouter[routerAddress].ContractInfo.SystemDeps
 	pinned := newrouter[routerAddress].ContractInfo.Pinned
-	rnh := getRuntimeHandler(ctx.newIVmFn, tempCtx, sysDeps, pinned)
+	maxMemoryPages := newrouter[routerAddress].ContractInfo.MaxMemoryPages
+	gasSchedule := newrouter[routerAddress].ContractInfo.GasSchedule
+	rnh := getRuntimeHandler(ctx.newIVmFn, tempCtx, sysDeps, pinned, maxMemoryPages, gasSchedule)
 	// increase current call count at this level
 	ctx.CurrentSubCallLevelCount += 1
 
@@ -268,9 +282,11 @@ func WasmxCall(ctx *Context, req vmtypes.CallRequestCommon) (int32, []byte) {
 			return int32(1), []byte(errmsg)
 		}
 	}
+	traceExit := traceCall(newctx.Ctx, ctx.GasMeter, callType, req.From.Bytes(), req.To.Bytes(), req.Calldata, req.GasLimit, req.Value)
 	_, err := newctx.Execute()
 	var success int32
 	returnData := newctx.ReturnData
+	traceExit(returnData, err)
 	// Returns 0 on success, 1 on failure and 2 on revert
 	if err != nil {
 		success = int32(2)
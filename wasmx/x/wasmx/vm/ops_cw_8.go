@@ -89,7 +89,7 @@ func cw_8_db_read(_context interface{}, rnh memc.RuntimeHandler, params []interf
 		return nil, err
 	}
 	// TODO MAX_LENGTH_DB_KEY
-	data := ctx.ContractStore.Get(key)
+	data := ctx.storageGet(key)
 	if len(data) == 0 {
 		returns[0] = int32(0)
 		return returns, nil
@@ -115,7 +115,9 @@ func cw_8_db_write(_context interface{}, rnh memc.RuntimeHandler, params []inter
 	}
 	ctx := _context.(*Context)
 	ctx.GasMeter.ConsumeGas(uint64(SSTORE_GAS_WASMX), "cw_8_db_write")
-	ctx.ContractStore.Set(key, data)
+	if err := ctx.contractStorageSet(key, data); err != nil {
+		return nil, err
+	}
 	returns := make([]interface{}, 0)
 	return returns, nil
 }
@@ -127,7 +129,9 @@ func cw_8_db_remove(_context interface{}, rnh memc.RuntimeHandler, params []inte
 	if err != nil {
 		return nil, err
 	}
-	ctx.ContractStore.Delete(key)
+	if err := ctx.contractStorageDelete(key); err != nil {
+		return nil, err
+	}
 	returns := make([]interface{}, 0)
 	return returns, nil
 }
@@ -151,12 +155,7 @@ func cw_8_db_scan(_context interface{}, rnh memc.RuntimeHandler, params []interf
 	}
 	order := params[2].(int32)
 
-	var iter types.Iterator
-	if order == int32(OrderAscending) {
-		iter = ctx.ContractStore.Iterator(startKey, endKey)
-	} else {
-		iter = ctx.ContractStore.ReverseIterator(startKey, endKey)
-	}
+	iter := ctx.contractStorageIterator(startKey, endKey, order != int32(OrderAscending))
 	count := len(ctx.dbIterators)
 	ctx.dbIterators[int32(count)] = iter
 	returns := make([]interface{}, 1)
@@ -176,7 +175,7 @@ func cw_8_db_next(_context interface{}, rnh memc.RuntimeHandler, params []interf
 	values := make([][]byte, 2)
 	if iterator.Valid() {
 		values[0] = iterator.Key()
-		values[1] = ctx.ContractStore.Get(values[0])
+		values[1] = ctx.storageGet(values[0])
 		iterator.Next()
 	} else {
 		iterator.Close()
@@ -568,7 +567,7 @@ func BuildArgsCw(context *Context) ([]byte, []byte, []byte, error) {
 }
 
 // instantiate(env_ptr: u32, info_ptr: u32, msg_ptr: u32)
-func ExecuteCw8Execute(context *Context, vm memc.IVm, funcName string) ([]int32, error) {
+func ExecuteCw8Execute(context *Context, vm memc.IVm, funcName string) ([]interface{}, error) {
 	envBz, infoBz, msgBz, err := BuildArgsCw(context)
 	if err != nil {
 		return nil, err
@@ -625,7 +624,17 @@ func ExecuteCw8Execute(context *Context, vm memc.IVm, funcName string) ([]int32,
 }
 
 // reply(env_ptr: u32, msg_ptr: u32)
-func ExecuteCw8Reply(context *Context, vm memc.IVm, funcName string) ([]int32, error) {
+func ExecuteCw8Reply(context *Context, vm memc.IVm, funcName string) ([]interface{}, error) {
+	return executeCw8EnvMsg(context, vm, funcName, cw8types.ERROR_FLAG_REPLY)
+}
+
+// migrate(env_ptr: u32, msg_ptr: u32)
+func ExecuteCw8Migrate(context *Context, vm memc.IVm, funcName string) ([]interface{}, error) {
+	return executeCw8EnvMsg(context, vm, funcName, cw8types.ERROR_FLAG_MIGRATE)
+}
+
+// executeCw8EnvMsg calls entry points that receive env and msg and return a Response
+func executeCw8EnvMsg(context *Context, vm memc.IVm, funcName string, errorFlag string) ([]interface{}, error) {
 	envBz, _, msgBz, err := BuildArgsCw(context)
 	if err != nil {
 		return nil, err
@@ -669,14 +678,14 @@ func ExecuteCw8Reply(context *Context, vm memc.IVm, funcName string) ([]int32, e
 		return nil, nil
 	}
 
-	err = sdkerr.Wrapf(sdkerr.Error{}, "%s %s", cw8types.ERROR_FLAG_REPLY, result.Err)
+	err = sdkerr.Wrapf(sdkerr.Error{}, "%s %s", errorFlag, result.Err)
 	if execErr != nil {
 		return nil, sdkerr.Wrapf(err, execErr.Error())
 	}
 	return nil, err
 }
 
-func ExecuteCw8Query(context *Context, vm memc.IVm, funcName string) ([]int32, error) {
+func ExecuteCw8Query(context *Context, vm memc.IVm, funcName string) ([]interface{}, error) {
 	envBz, _, msgBz, err := BuildArgsCw(context)
 	if err != nil {
 		return nil, err
@@ -723,12 +732,14 @@ func ExecuteCw8Query(context *Context, vm memc.IVm, funcName string) ([]int32, e
 }
 
 // instantiate/execute(env_ptr: u32, info_ptr: u32, msg_ptr: u32)
-func ExecuteCw8(context *Context, vm memc.IVm, funcName string, args []interface{}, _ bool) ([]int32, error) {
+func ExecuteCw8(context *Context, vm memc.IVm, funcName string, args []interface{}, _ bool) ([]interface{}, error) {
 	switch funcName {
 	case types.ENTRY_POINT_QUERY:
 		return ExecuteCw8Query(context, vm, funcName)
 	case types.ENTRY_POINT_REPLY:
 		return ExecuteCw8Reply(context, vm, funcName)
+	case types.ENTRY_POINT_MIGRATE:
+		return ExecuteCw8Migrate(context, vm, funcName)
 	}
 	// instantiate, execute
 	return ExecuteCw8Execute(context, vm, funcName)
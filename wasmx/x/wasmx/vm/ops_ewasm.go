@@ -50,7 +50,7 @@ func storageLoad(_context interface{}, rnh memc.RuntimeHandler, params []interfa
 	if err != nil {
 		return nil, err
 	}
-	data := ctx.ContractStore.Get(keybz)
+	data := ctx.storageGet(keybz)
 	if len(data) == 0 {
 		data = types.EMPTY_BYTES32
 	}
@@ -77,7 +77,9 @@ func storageStore(_context interface{}, rnh memc.RuntimeHandler, params []interf
 		return nil, err
 	}
 	ctx.GasMeter.ConsumeGas(uint64(SSTORE_GAS_EWASM), "ewasm_storageStore")
-	ctx.ContractStore.Set(keybz, valuebz)
+	if err := ctx.contractStorageSet(keybz, valuebz); err != nil {
+		return nil, err
+	}
 	returns := make([]interface{}, 0)
 	return returns, nil
 }
@@ -811,7 +813,7 @@ func ewasmLog(_context interface{}, rnh memc.RuntimeHandler, params []interface{
 		copy(topic_[:], topic)
 		log.Topics = append(log.Topics, topic_)
 	}
-	ctx.Logs = append(ctx.Logs, log)
+	ctx.addLog(log)
 	returns := make([]interface{}, 0)
 	return returns, nil
 }
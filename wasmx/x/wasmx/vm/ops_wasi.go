@@ -1,10 +1,19 @@
 package vm
 
 import (
+	cryptorand "crypto/rand"
+	gosha256 "crypto/sha256"
+	"encoding/binary"
 	"fmt"
+	"io"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
 	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
 	wasimem "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/wasi"
 )
@@ -57,11 +66,19 @@ import (
 // sock_send(fd: fd, si_data: ciovec_array, si_flags: siflags) -> (errno, size)
 // sock_shutdown(fd: fd, how: sdflags) -> errno
 
+var __WASI_CLOCK_REALTIME = int32(0)
+var __WASI_CLOCK_MONOTONIC = int32(1)
+var __WASI_CLOCK_PROCESS_CPUTIME_ID = int32(2)
+var __WASI_CLOCK_THREAD_CPUTIME_ID = int32(3)
+
+// start of the non-deterministic monotonic clock
+var wasiMonotonicStart = time.Now()
+
 var __WASI_O_CREAT = int32(1)
 var __WASI_O_DIRECTORY = int32(2)
 
 // var __WASI_O_EXCL = int32(4)
-// var __WASI_O_TRUNC = int32(8)
+var __WASI_O_TRUNC = int32(8)
 
 func wasi_stubUnimplemented(_ interface{}, _ memc.RuntimeHandler, _ []interface{}) ([]interface{}, error) {
 	// Return ENOSYS = 52
@@ -235,14 +252,51 @@ func wasi_environSizesGet(_context interface{}, rnh memc.RuntimeHandler, params
 func wasi_clockResGet(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	ctx := _context.(*WasiContext)
 	LoggerExtended(ctx.c).Debug("wasi_clockResGet", "params", params)
-	return wasi_stubUnimplemented(_context, rnh, params)
+	clockId := params[0].(int32)
+	resultPtr := params[1].(int32)
+	returns := make([]interface{}, 1)
+	if clockId < __WASI_CLOCK_REALTIME || clockId > __WASI_CLOCK_THREAD_CPUTIME_ID {
+		returns[0] = int32(28) // EINVAL
+		return returns, nil
+	}
+	mem, err := rnh.GetMemory()
+	if err != nil {
+		return nil, err
+	}
+	// timestamps are in nanoseconds
+	err = wasimem.WriteUint64Le(mem, resultPtr, uint64(1))
+	if err != nil {
+		return nil, err
+	}
+	returns[0] = int32(0)
+	return returns, nil
 }
 
 // 6) clock_time_get(id: clockid, precision: timestamp) -> (errno, timestamp)
 func wasi_clockTimeGet(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	ctx := _context.(*WasiContext)
 	LoggerExtended(ctx.c).Debug("wasi_clockTimeGet", "params", params)
-	return wasi_stubUnimplemented(_context, rnh, params)
+	clockId := params[0].(int32)
+	resultPtr := params[2].(int32)
+	returns := make([]interface{}, 1)
+	timestamp, ok, err := ctx.ClockTime(clockId)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		returns[0] = int32(28) // EINVAL
+		return returns, nil
+	}
+	mem, err := rnh.GetMemory()
+	if err != nil {
+		return nil, err
+	}
+	err = wasimem.WriteUint64Le(mem, resultPtr, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	returns[0] = int32(0)
+	return returns, nil
 }
 
 // 7) fd_advise(fd: fd, offset: filesize, len: filesize, advice: advice) -> errno
@@ -285,7 +339,14 @@ func wasi_fdClose(_context interface{}, rnh memc.RuntimeHandler, params []interf
 func wasi_fdDatasync(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	ctx := _context.(*WasiContext)
 	LoggerExtended(ctx.c).Debug("wasi_fdDatasync", "params", params)
-	return wasi_stubUnimplemented(_context, rnh, params)
+	returns := make([]interface{}, 1)
+	// persisted files are written through on each change
+	if _, ok := ctx.GetOpenFiles(rnh.GetVm())[params[0].(int32)]; !ok {
+		returns[0] = int32(8) // EBADF
+		return returns, nil
+	}
+	returns[0] = int32(0)
+	return returns, nil
 }
 
 // 11) fd_fdstat_get(fd: fd) -> (errno, fdstat)
@@ -328,6 +389,9 @@ func wasi_fdFdstatGet(_context interface{}, rnh memc.RuntimeHandler, params []in
 	// fs_rights_base = 0 for minimal (or ~0 for "all rights")
 	// fs_rights_inheriting = 0 // or 0xffffffff
 	fsFiletype := uint8(4) // regular file
+	if openF.isdir || ctx.dirMapping[openF.path] {
+		fsFiletype = uint8(3) // directory
+	}
 	fsFlags := uint16(0)
 	pad := uint8(0)
 	fsRightsBase := uint64(0)
@@ -386,14 +450,70 @@ func wasi_fdFdstatSetRights(_context interface{}, rnh memc.RuntimeHandler, param
 func wasi_fdFilestatGet(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	ctx := _context.(*WasiContext)
 	LoggerExtended(ctx.c).Debug("wasi_fdFilestatGet", "params", params)
-	return wasi_stubUnimplemented(_context, rnh, params)
+	fd := params[0].(int32)
+	resultPtr := params[1].(int32)
+	returns := make([]interface{}, 1)
+	openF, ok := ctx.GetOpenFiles(rnh.GetVm())[fd]
+	if !ok {
+		returns[0] = int32(8) // EBADF
+		return returns, nil
+	}
+	content, ok := ctx.fileMapping[openF.path]
+	if !ok {
+		returns[0] = int32(44) // ENOENT
+		return returns, nil
+	}
+	mem, err := rnh.GetMemory()
+	if err != nil {
+		return nil, err
+	}
+	err = writeFilestat(mem, resultPtr, openF.isdir || ctx.dirMapping[openF.path], uint64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+	returns[0] = int32(0)
+	return returns, nil
 }
 
 // 15) fd_filestat_set_size(fd: fd, size: filesize) -> errno
 func wasi_fdFilestatSetSize(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	ctx := _context.(*WasiContext)
 	LoggerExtended(ctx.c).Debug("wasi_fdFilestatSetSize", "params", params)
-	return wasi_stubUnimplemented(_context, rnh, params)
+	fd := params[0].(int32)
+	size := params[1].(int64)
+	returns := make([]interface{}, 1)
+	openF, ok := ctx.GetOpenFiles(rnh.GetVm())[fd]
+	if !ok {
+		returns[0] = int32(8) // EBADF
+		return returns, nil
+	}
+	if openF.isdir {
+		returns[0] = int32(31) // EISDIR
+		return returns, nil
+	}
+	content, ok := ctx.fileMapping[openF.path]
+	if !ok {
+		returns[0] = int32(44) // ENOENT
+		return returns, nil
+	}
+	if size < 0 {
+		returns[0] = int32(28) // EINVAL
+		return returns, nil
+	}
+	if !ctx.fsCanResize(openF.path, size) {
+		returns[0] = int32(19) // EDQUOT
+		return returns, nil
+	}
+	oldSize := int64(len(content))
+	if size < oldSize {
+		content = content[:size]
+	} else {
+		content = append(content, make([]byte, size-oldSize)...)
+	}
+	ctx.fileMapping[openF.path] = content
+	ctx.fsSync(openF.path, oldSize, size)
+	returns[0] = int32(0)
+	return returns, nil
 }
 
 // 16) fd_filestat_set_times(fd: fd, atim: timestamp, mtim: timestamp, fst_flags: fstflags) -> errno
@@ -407,7 +527,39 @@ func wasi_fdFilestatSetTimes(_context interface{}, rnh memc.RuntimeHandler, para
 func wasi_fdPread(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	ctx := _context.(*WasiContext)
 	LoggerExtended(ctx.c).Debug("wasi_fdPread", "params", params)
-	return wasi_stubUnimplemented(_context, rnh, params)
+	fd := params[0].(int32)
+	iovsPtr := params[1].(int32)
+	iovsLen := params[2].(int32)
+	offset := params[3].(int64)
+	outNread := params[4].(int32)
+	returns := make([]interface{}, 1)
+	openF, ok := ctx.GetOpenFiles(rnh.GetVm())[fd]
+	if !ok {
+		returns[0] = int32(8) // EBADF
+		return returns, nil
+	}
+	content, ok := ctx.fileMapping[openF.path]
+	if !ok {
+		returns[0] = int32(44) // ENOENT
+		return returns, nil
+	}
+	if offset < 0 {
+		returns[0] = int32(28) // EINVAL
+		return returns, nil
+	}
+	mem, err := rnh.GetMemory()
+	if err != nil {
+		return nil, err
+	}
+	nread, err := readIovs(mem, iovsPtr, iovsLen, content, offset)
+	if err != nil {
+		return nil, err
+	}
+	if err := wasimem.WriteUint32Le(mem, outNread, uint32(nread)); err != nil {
+		return nil, err
+	}
+	returns[0] = int32(0)
+	return returns, nil
 }
 
 // 18) fd_prestat_get(fd: fd) -> (errno, prestat)
@@ -506,7 +658,38 @@ func wasi_fdPrestatDirName(_context interface{}, rnh memc.RuntimeHandler, params
 func wasi_fdPwrite(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	ctx := _context.(*WasiContext)
 	LoggerExtended(ctx.c).Debug("wasi_fdPwrite", "params", params)
-	return wasi_stubUnimplemented(_context, rnh, params)
+	fd := params[0].(int32)
+	iovsPtr := params[1].(int32)
+	iovsLen := params[2].(int32)
+	offset := params[3].(int64)
+	nwrittenPtr := params[4].(int32)
+	returns := make([]interface{}, 1)
+	openF, ok := ctx.GetOpenFiles(rnh.GetVm())[fd]
+	if !ok {
+		returns[0] = int32(8) // EBADF
+		return returns, nil
+	}
+	if offset < 0 {
+		returns[0] = int32(28) // EINVAL
+		return returns, nil
+	}
+	mem, err := rnh.GetMemory()
+	if err != nil {
+		return nil, err
+	}
+	nwritten, errno, err := ctx.writeIovs(mem, openF.path, iovsPtr, iovsLen, offset)
+	if err != nil {
+		return nil, err
+	}
+	if errno != 0 {
+		returns[0] = errno
+		return returns, nil
+	}
+	if err := wasimem.WriteUint32Le(mem, nwrittenPtr, uint32(nwritten)); err != nil {
+		return nil, err
+	}
+	returns[0] = int32(0)
+	return returns, nil
 }
 
 // 21) fd_read(fd: fd, iovs: iovec_array) -> (errno, size)
@@ -543,48 +726,12 @@ func wasi_fdRead(_context interface{}, rnh memc.RuntimeHandler, params []interfa
 		return returns, nil
 	}
 
-	contentBytes := []byte(content)
-	var totalRead int64
-
-	// 6) Iterate over each iovec
-	iovOffset := iovsPtr
-	for i := int32(0); i < iovsLen; i++ {
-		// Each iovec is (bufPtr: u32, bufLen: u32), 8 bytes total in 32-bit
-		bufPtr, err1 := wasimem.ReadUint32Le(mem, iovOffset)
-		if err1 != nil {
-			return nil, err1
-		}
-		bufLen, err2 := wasimem.ReadUint32Le(mem, iovOffset+4)
-		if err2 != nil {
-			return nil, err2
-		}
-		iovOffset += 8
-
-		// If we've already reached or passed EOF, read 0 bytes into this iovec
-		if openF.offset >= int64(len(contentBytes)) {
-			// Nothing more to read
-			break
-		}
-
-		// Calculate how many bytes we can read
-		bytesAvailable := int64(len(contentBytes)) - openF.offset
-		toRead := int64(bufLen)
-		if toRead > bytesAvailable {
-			toRead = bytesAvailable
-		}
-
-		// Slice out [offset : offset+toRead]
-		chunk := contentBytes[openF.offset : openF.offset+toRead]
-
-		// Write that chunk into wasm memory at bufPtr
-		if err3 := mem.Write(int32(bufPtr), chunk); err3 != nil {
-			return nil, err3
-		}
-
-		// Update offset and totalRead
-		openF.offset += toRead
-		totalRead += toRead
+	// 6) Read into each iovec from the current offset
+	totalRead, err := readIovs(mem, iovsPtr, iovsLen, content, openF.offset)
+	if err != nil {
+		return nil, err
 	}
+	openF.offset += totalRead
 
 	// 7) Write the total number of bytes read into out_nread
 	//    Make sure to clamp it to a 32-bit if needed
@@ -813,14 +960,35 @@ func wasi_fdSeek(_context interface{}, rnh memc.RuntimeHandler, params []interfa
 func wasi_fdSync(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	ctx := _context.(*WasiContext)
 	LoggerExtended(ctx.c).Debug("wasi_fdSync", "params", params)
-	return wasi_stubUnimplemented(_context, rnh, params)
+	returns := make([]interface{}, 1)
+	// persisted files are written through on each change
+	if _, ok := ctx.GetOpenFiles(rnh.GetVm())[params[0].(int32)]; !ok {
+		returns[0] = int32(8) // EBADF
+		return returns, nil
+	}
+	returns[0] = int32(0)
+	return returns, nil
 }
 
 // 26) fd_tell(fd: fd) -> (errno, filesize)
 func wasi_fdTell(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	ctx := _context.(*WasiContext)
 	LoggerExtended(ctx.c).Debug("wasi_fdTell", "params", params)
-	return wasi_stubUnimplemented(_context, rnh, params)
+	returns := make([]interface{}, 1)
+	openF, ok := ctx.GetOpenFiles(rnh.GetVm())[params[0].(int32)]
+	if !ok {
+		returns[0] = int32(8) // EBADF
+		return returns, nil
+	}
+	mem, err := rnh.GetMemory()
+	if err != nil {
+		return nil, err
+	}
+	if err := wasimem.WriteUint64Le(mem, params[1].(int32), uint64(openF.offset)); err != nil {
+		return nil, err
+	}
+	returns[0] = int32(0)
+	return returns, nil
 }
 
 // 27) fd_write(fd: fd, iovs: ciovec_array) -> (errno, size)
@@ -849,65 +1017,20 @@ func wasi_fdWrite(_context interface{}, rnh memc.RuntimeHandler, params []interf
 		return returns, nil
 	}
 
-	// 4) Find the file’s content in our in-memory mapping
-	content, ok := fileMap[openF.path]
-	if !ok {
-		// ENOENT => 44 (No such file or directory)
-		returns[0] = int32(44)
-		return returns, nil
+	// 4) Write the iovecs into our in-memory file at the current offset
+	totalWritten, errno, err := ctx.writeIovs(mem, openF.path, iovsPtr, iovsLen, openF.offset)
+	if err != nil {
+		return nil, err
 	}
-
-	var totalWritten int64 = 0
-
-	// 5) Each WASI `__wasi_ciovec_t` is (buf_ptr: u32, buf_len: u32) in a 32-bit environment
-	//    so each element is 8 bytes. We'll iterate iovsLen times, reading from memory.
-	iovOffset := iovsPtr
-
-	for i := int32(0); i < iovsLen; i++ {
-		// read buf_ptr
-		bufPtr, err1 := wasimem.ReadUint32Le(mem, iovOffset)
-		if err1 != nil {
-			return nil, err1
-		}
-		// read buf_len
-		bufLen, err2 := wasimem.ReadUint32Le(mem, iovOffset+4)
-		if err2 != nil {
-			return nil, err2
-		}
-		iovOffset += 8
-
-		// 6) Read the actual data from the Wasm memory
-		data, err3 := mem.Read(int32(bufPtr), int32(bufLen))
-		if err3 != nil {
-			return nil, err3
-		}
-
-		// 7) Write into our in-memory file at the current offset
-		start := openF.offset
-		end := start + int64(bufLen)
-
-		// If end extends beyond the current content, expand it
-		if end > int64(len(content)) {
-			extra := int(end) - len(content)
-			content = append(content, make([]byte, extra)...) // Extend with zero bytes
-		}
-
-		// Replace that segment
-		contentBytes := []byte(content)
-		copy(contentBytes[start:end], data)
-		content = contentBytes
-
-		// Advance file offset
-		openF.offset = end
-
-		// Tally how many bytes we wrote this iteration
-		totalWritten += int64(bufLen)
+	if errno != 0 {
+		returns[0] = errno
+		return returns, nil
 	}
+	// Advance file offset
+	openF.offset += totalWritten
+	content := fileMap[openF.path]
 
-	// 8) Update the in-memory file content
-	ctx.SetFileMap(vm, openF.path, content)
-
-	// 9) Write totalWritten into `nwrittenPtr`
+	// 5) Write totalWritten into `nwrittenPtr`
 	if err := wasimem.WriteUint32Le(mem, nwrittenPtr, uint32(totalWritten)); err != nil {
 		return nil, err
 	}
@@ -933,7 +1056,28 @@ func wasi_fdWrite(_context interface{}, rnh memc.RuntimeHandler, params []interf
 func wasi_pathCreateDirectory(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	ctx := _context.(*WasiContext)
 	LoggerExtended(ctx.c).Debug("wasi_pathCreateDirectory", "params", params)
-	return wasi_stubUnimplemented(_context, rnh, params)
+	returns := make([]interface{}, 1)
+	fullPath, errno, err := ctx.resolvePath(rnh, params[0].(int32), params[1].(int32), params[2].(int32))
+	if err != nil {
+		return nil, err
+	}
+	if errno != 0 {
+		returns[0] = errno
+		return returns, nil
+	}
+	if _, ok := ctx.fileMapping[fullPath]; ok {
+		returns[0] = int32(20) // EEXIST
+		return returns, nil
+	}
+	if !ctx.dirMapping[parentPath(fullPath)] {
+		returns[0] = int32(44) // ENOENT
+		return returns, nil
+	}
+	ctx.dirMapping[fullPath] = true
+	ctx.fileMapping[fullPath] = []byte{}
+	ctx.fsCreateDir(fullPath)
+	returns[0] = int32(0)
+	return returns, nil
 }
 
 // 29) path_filestat_get(fd: fd, flags: lookupflags, path: string) -> (errno, filestat)
@@ -980,6 +1124,7 @@ func wasi_pathFilestatGet(_context interface{}, rnh memc.RuntimeHandler, params
 	if guestPath != "." {
 		fullPath = preopen.path + "/" + guestPath
 	}
+	ctx.fsLoad(fullPath)
 
 	// fullPath = filepath.Clean(fullPath) ?
 
@@ -1002,94 +1147,15 @@ func wasi_pathFilestatGet(_context interface{}, rnh memc.RuntimeHandler, params
 		return returns, nil
 	}
 
-	// 8) Prepare filestat fields
-	// We'll do a minimal approach:
-	//   dev=0, ino=0, filetype=__WASI_FILETYPE_REGULAR_FILE=4, size=len(fileContent)
-	//   times=0, nlink=1, etc.
-
-	var dev uint64 = 0
-	var ino uint64 = 0
-	var nlink uint64 = 1
-	size := uint64(len(fileContent))
-	var atim uint64 = 0
-	var mtim uint64 = 0
-	var ctim uint64 = 0
-
-	// __WASI_FILETYPE_REGULAR_FILE=4, directory=3, etc.
-	var filetype uint8 = 4
-	if isDir {
-		filetype = uint8(3)
-	}
-
-	// 9) Write the struct into memory.
-	//    The layout (in bytes) typically is:
-	//    0..7   dev (u64)
-	//    8..15  ino (u64)
-	//    16     filetype (u8)
-	//    17..23 padding
-	//    24..31 nlink (u64)
-	//    32..39 size (u64)
-	//    40..47 atim (u64)
-	//    48..55 mtim (u64)
-	//    56..63 ctim (u64) - total 64 bytes (some WASI docs say 56, but alignment might push it to 64).
-	//    Check your specific environment if it’s 56 or 64 total.
-
-	// Let's define offsets as constants for clarity:
-	const (
-		offsetDev      = 0
-		offsetIno      = 8
-		offsetFiletype = 16
-		offsetNlink    = 24
-		offsetSize     = 32
-		offsetAtim     = 40
-		offsetMtim     = 48
-		offsetCtim     = 56
-	)
-
-	// Write dev (u64)
-	if err := wasimem.WriteUint64Le(mem, resultPtr+offsetDev, dev); err != nil {
+	// 8) Write the filestat struct into memory
+	if err := writeFilestat(mem, resultPtr, isDir, uint64(len(fileContent))); err != nil {
 		return nil, err
 	}
 
-	// Write ino (u64)
-	if err := wasimem.WriteUint64Le(mem, resultPtr+offsetIno, ino); err != nil {
-		return nil, err
-	}
-
-	// Write filetype (u8)
-	if err := mem.Write(resultPtr+offsetFiletype, []byte{filetype}); err != nil {
-		return nil, err
-	}
-
-	// nlink (u64)
-	if err := wasimem.WriteUint64Le(mem, resultPtr+offsetNlink, nlink); err != nil {
-		return nil, err
-	}
-
-	// size (u64)
-	if err := wasimem.WriteUint64Le(mem, resultPtr+offsetSize, size); err != nil {
-		return nil, err
-	}
-
-	// atim (u64)
-	if err := wasimem.WriteUint64Le(mem, resultPtr+offsetAtim, atim); err != nil {
-		return nil, err
-	}
-
-	// mtim (u64)
-	if err := wasimem.WriteUint64Le(mem, resultPtr+offsetMtim, mtim); err != nil {
-		return nil, err
-	}
-
-	// ctim (u64)
-	if err := wasimem.WriteUint64Le(mem, resultPtr+offsetCtim, ctim); err != nil {
-		return nil, err
-	}
-
-	// 10) Return errno=0 for success
-	returns[0] = int32(0)
-	return returns, nil
-}
+	// 9) Return errno=0 for success
+	returns[0] = int32(0)
+	return returns, nil
+}
 
 //  30. path_filestat_set_times(fd: fd, flags: lookupflags, path: string,
 //     atim: timestamp, mtim: timestamp, fst_flags: fstflags) -> errno
@@ -1165,13 +1231,12 @@ func wasi_pathOpen(_context interface{}, rnh memc.RuntimeHandler, params []inter
 
 	// 5) Combine baseDirPath + guestPath to find the real path
 	//    or the key used in your in-memory FS.
-	fullPath := baseDirPath.path
-	if guestPath != "" && guestPath != "." {
-		if fullPath != "" && !endsWithSlash(fullPath) {
-			fullPath += "/"
-		}
-		fullPath += guestPath
+	fullPath := joinWasiPath(baseDirPath.path, guestPath)
+	if IsPersistedPath(fullPath) && !validPersistedPath(fullPath) {
+		returns[0] = int32(76) // ENOTCAPABLE
+		return returns, nil
 	}
+	ctx.fsLoad(fullPath)
 
 	// 6) Check if the guest wants to open a directory (__WASI_O_DIRECTORY)
 	wantsDir := (oflags & __WASI_O_DIRECTORY) != 0
@@ -1187,8 +1252,13 @@ func wasi_pathOpen(_context interface{}, rnh memc.RuntimeHandler, params []inter
 	} else {
 		// Doesn't exist
 		if (oflags&__WASI_O_CREAT) != 0 && !wantsDir {
+			if !ctx.dirMapping[parentPath(fullPath)] && IsPersistedPath(fullPath) {
+				returns[0] = int32(44) // ENOENT
+				return returns, nil
+			}
 			// create an empty file
 			ctx.fileMapping[fullPath] = []byte{}
+			ctx.fsSync(fullPath, 0, 0)
 		} else {
 			// ENOENT => 44
 			returns[0] = int32(44)
@@ -1207,6 +1277,10 @@ func wasi_pathOpen(_context interface{}, rnh memc.RuntimeHandler, params []inter
 		returns[0] = int32(54)
 		return returns, nil
 	}
+	if !isDir && (oflags&__WASI_O_TRUNC) != 0 && len(fileMap[fullPath]) > 0 {
+		ctx.fileMapping[fullPath] = []byte{}
+		ctx.fsSync(fullPath, 0, 0)
+	}
 
 	// 8) Create a new FD for the opened file/dir
 	newFd := ctx.nextfd
@@ -1244,14 +1318,146 @@ func wasi_pathReadlink(_context interface{}, rnh memc.RuntimeHandler, params []i
 func wasi_pathRemoveDirectory(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	ctx := _context.(*WasiContext)
 	LoggerExtended(ctx.c).Debug("wasi_pathRemoveDirectory", "params", params)
-	return wasi_stubUnimplemented(_context, rnh, params)
+	returns := make([]interface{}, 1)
+	fullPath, errno, err := ctx.resolvePath(rnh, params[0].(int32), params[1].(int32), params[2].(int32))
+	if err != nil {
+		return nil, err
+	}
+	if errno != 0 {
+		returns[0] = errno
+		return returns, nil
+	}
+	if _, ok := ctx.fileMapping[fullPath]; !ok {
+		returns[0] = int32(44) // ENOENT
+		return returns, nil
+	}
+	if !ctx.dirMapping[fullPath] {
+		returns[0] = int32(54) // ENOTDIR
+		return returns, nil
+	}
+	if ctx.isPreopened(fullPath) {
+		returns[0] = int32(63) // EPERM
+		return returns, nil
+	}
+	// only '.' and '..'
+	if len(listDirectoryEntries(ctx, fullPath)) > 2 {
+		returns[0] = int32(55) // ENOTEMPTY
+		return returns, nil
+	}
+	delete(ctx.dirMapping, fullPath)
+	delete(ctx.fileMapping, fullPath)
+	ctx.fsRemove(fullPath)
+	returns[0] = int32(0)
+	return returns, nil
 }
 
 // 35) path_rename(fd: fd, old_path: string, new_fd: fd, new_path: string) -> errno
 func wasi_pathRename(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	ctx := _context.(*WasiContext)
 	LoggerExtended(ctx.c).Debug("wasi_pathRename", "params", params)
-	return wasi_stubUnimplemented(_context, rnh, params)
+	returns := make([]interface{}, 1)
+	oldPath, errno, err := ctx.resolvePath(rnh, params[0].(int32), params[1].(int32), params[2].(int32))
+	if err != nil {
+		return nil, err
+	}
+	if errno != 0 {
+		returns[0] = errno
+		return returns, nil
+	}
+	newPath, errno, err := ctx.resolvePath(rnh, params[3].(int32), params[4].(int32), params[5].(int32))
+	if err != nil {
+		return nil, err
+	}
+	if errno != 0 {
+		returns[0] = errno
+		return returns, nil
+	}
+	if _, ok := ctx.fileMapping[oldPath]; !ok {
+		returns[0] = int32(44) // ENOENT
+		return returns, nil
+	}
+	if !ctx.dirMapping[parentPath(newPath)] {
+		returns[0] = int32(44) // ENOENT
+		return returns, nil
+	}
+	if oldPath == newPath {
+		returns[0] = int32(0)
+		return returns, nil
+	}
+	if ctx.isPreopened(oldPath) || strings.HasPrefix(newPath, oldPath+"/") {
+		returns[0] = int32(28) // EINVAL
+		return returns, nil
+	}
+	isDir := ctx.dirMapping[oldPath]
+	if _, ok := ctx.fileMapping[newPath]; ok {
+		if ctx.dirMapping[newPath] != isDir {
+			if isDir {
+				returns[0] = int32(54) // ENOTDIR
+			} else {
+				returns[0] = int32(31) // EISDIR
+			}
+			return returns, nil
+		}
+		if isDir && len(listDirectoryEntries(ctx, newPath)) > 2 {
+			returns[0] = int32(55) // ENOTEMPTY
+			return returns, nil
+		}
+	}
+
+	// the renamed path and, for directories, all paths under it
+	moved := []string{oldPath}
+	if isDir {
+		for p := range ctx.fileMapping {
+			if strings.HasPrefix(p, oldPath+"/") {
+				moved = append(moved, p)
+			}
+		}
+		sort.Strings(moved)
+	}
+	added := int64(0)
+	for _, p := range moved {
+		if !ctx.dirMapping[p] && IsPersistedPath(newPath) {
+			ctx.fsLoad(p)
+			added += int64(len(ctx.fileMapping[p]))
+		}
+	}
+	if IsPersistedPath(newPath) && ctx.fsEnabled() {
+		if removed, ok := ctx.persisted[newPath]; ok {
+			added -= removed
+		}
+		if !IsPersistedPath(oldPath) && ctx.fsUsage()+added > ctx.fsQuota() {
+			returns[0] = int32(19) // EDQUOT
+			return returns, nil
+		}
+	}
+	if _, ok := ctx.fileMapping[newPath]; ok {
+		delete(ctx.dirMapping, newPath)
+		delete(ctx.fileMapping, newPath)
+		ctx.fsRemove(newPath)
+	}
+	for _, p := range moved {
+		target := newPath + strings.TrimPrefix(p, oldPath)
+		ctx.fsLoad(p)
+		content := ctx.fileMapping[p]
+		dir := ctx.dirMapping[p]
+		delete(ctx.fileMapping, p)
+		delete(ctx.dirMapping, p)
+		ctx.fsRemove(p)
+		ctx.fileMapping[target] = content
+		if dir {
+			ctx.dirMapping[target] = true
+			ctx.fsCreateDir(target)
+		} else {
+			ctx.fsSync(target, 0, int64(len(content)))
+		}
+		for _, openF := range ctx.openFiles {
+			if openF.path == p {
+				openF.path = target
+			}
+		}
+	}
+	returns[0] = int32(0)
+	return returns, nil
 }
 
 // 36) path_symlink(old_path: string, fd: fd, new_path: string) -> errno
@@ -1265,7 +1471,27 @@ func wasi_pathSymlink(_context interface{}, rnh memc.RuntimeHandler, params []in
 func wasi_pathUnlinkFile(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	ctx := _context.(*WasiContext)
 	LoggerExtended(ctx.c).Debug("wasi_pathUnlinkFile", "params", params)
-	return wasi_stubUnimplemented(_context, rnh, params)
+	returns := make([]interface{}, 1)
+	fullPath, errno, err := ctx.resolvePath(rnh, params[0].(int32), params[1].(int32), params[2].(int32))
+	if err != nil {
+		return nil, err
+	}
+	if errno != 0 {
+		returns[0] = errno
+		return returns, nil
+	}
+	if _, ok := ctx.fileMapping[fullPath]; !ok {
+		returns[0] = int32(44) // ENOENT
+		return returns, nil
+	}
+	if ctx.dirMapping[fullPath] {
+		returns[0] = int32(31) // EISDIR
+		return returns, nil
+	}
+	delete(ctx.fileMapping, fullPath)
+	ctx.fsRemove(fullPath)
+	returns[0] = int32(0)
+	return returns, nil
 }
 
 // 38) poll_oneoff(in: ConstPointer<subscription>, out: Pointer<event>, nsubscriptions: size) -> (errno, size)
@@ -1301,11 +1527,43 @@ func wasi_schedYield(_context interface{}, rnh memc.RuntimeHandler, params []int
 	return returns, nil
 }
 
+// gas for each random byte, on top of the host function cost
+const WASI_RANDOM_GET_GAS_PER_BYTE = 3
+
 // 42) random_get(buf: Pointer<u8>, buf_len: size) -> errno
 func wasi_randomGet(_context interface{}, rnh memc.RuntimeHandler, params []interface{}) ([]interface{}, error) {
 	ctx := _context.(*WasiContext)
 	LoggerExtended(ctx.c).Debug("wasi_randomGet", "params", params)
+	bufPtr := params[0].(int32)
+	bufLen := params[1].(int32)
 	returns := make([]interface{}, 1)
+	mem, err := rnh.GetMemory()
+	if err != nil {
+		return nil, err
+	}
+	if bufLen < 0 {
+		returns[0] = int32(28) // EINVAL
+		return returns, nil
+	}
+	// check the buffer before allocating, so the contract cannot make us allocate more than its memory
+	if bufPtr < 0 || int64(bufPtr)+int64(bufLen) > int64(mem.Size()) {
+		returns[0] = int32(21) // EFAULT
+		return returns, nil
+	}
+	ctx.c.GasMeter.ConsumeGas(uint64(WASI_RANDOM_GET_GAS_PER_BYTE*int64(bufLen)), "wasi_random_get")
+	source, err := ctx.Rand()
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, bufLen)
+	_, err = io.ReadFull(source, buf)
+	if err != nil {
+		return nil, err
+	}
+	err = mem.WriteRaw(bufPtr, buf)
+	if err != nil {
+		return nil, err
+	}
 	returns[0] = int32(0)
 	return returns, nil
 }
@@ -1360,6 +1618,124 @@ type WasiContext struct {
 	// filepath => content
 	fileMapping map[string][]byte // contains dir paths with empty content
 	dirMapping  map[string]bool
+	// source for random_get, created on first use
+	rand io.Reader
+	// preopened directories cannot be removed or renamed
+	preopens map[string]bool
+	// persisted file path => size in storage
+	persisted map[string]int64
+	// persisted files whose content is loaded in fileMapping
+	loaded map[string]bool
+}
+
+// IsDeterministic is false only for contracts running with the non-deterministic
+// sys_env_1 host interface. Otherwise, clocks are derived from the block time and
+// random bytes from a seeded PRNG, so all nodes agree.
+func (wc *WasiContext) IsDeterministic() bool {
+	if wc.c.Env == nil {
+		return true
+	}
+	return !slices.Contains(wc.c.Env.Contract.SystemDeps, types.SYS_ENV_1)
+}
+
+// checkNonDeterministic rejects non-deterministic sources during consensus execution,
+// even if the contract declares sys_env_1; they can only be used in queries and simulations
+func (wc *WasiContext) checkNonDeterministic(source string) error {
+	if wc.c.Ctx.ExecMode() == sdk.ExecModeFinalize {
+		return fmt.Errorf("non-deterministic %s cannot be used in consensus execution", source)
+	}
+	return nil
+}
+
+// ClockTime returns the time in nanoseconds for a WASI clock id
+func (wc *WasiContext) ClockTime(clockId int32) (uint64, bool, error) {
+	switch clockId {
+	case __WASI_CLOCK_REALTIME, __WASI_CLOCK_MONOTONIC:
+		if !wc.IsDeterministic() {
+			if err := wc.checkNonDeterministic("clock"); err != nil {
+				return 0, false, err
+			}
+			if clockId == __WASI_CLOCK_MONOTONIC {
+				return uint64(time.Since(wasiMonotonicStart).Nanoseconds()), true, nil
+			}
+			return uint64(time.Now().UnixNano()), true, nil
+		}
+		// the block time never decreases, so it is also a valid monotonic clock
+		if wc.c.Env == nil {
+			return 0, true, nil
+		}
+		return wc.c.Env.Block.Timestamp, true, nil
+	case __WASI_CLOCK_PROCESS_CPUTIME_ID, __WASI_CLOCK_THREAD_CPUTIME_ID:
+		// cpu time is not observable by contracts
+		return 0, true, nil
+	}
+	return 0, false, nil
+}
+
+// Rand returns the source of random bytes for this execution
+func (wc *WasiContext) Rand() (io.Reader, error) {
+	if !wc.IsDeterministic() {
+		if err := wc.checkNonDeterministic("randomness"); err != nil {
+			return nil, err
+		}
+	}
+	if wc.rand != nil {
+		return wc.rand, nil
+	}
+	if !wc.IsDeterministic() {
+		wc.rand = cryptorand.Reader
+		return wc.rand, nil
+	}
+	wc.rand = NewDeterministicRand(wc.randSeed())
+	return wc.rand, nil
+}
+
+// the seed is unique for each contract call in a transaction
+func (wc *WasiContext) randSeed() []byte {
+	seed := []byte{}
+	if wc.c.Env != nil {
+		seed = append(seed, []byte(wc.c.Env.Chain.ChainIdFull)...)
+		seed = append(seed, wc.c.Env.Block.Hash...)
+	}
+	txhash := gosha256.Sum256(wc.c.Ctx.TxBytes())
+	seed = append(seed, txhash[:]...)
+	callId := make([]byte, 8)
+	binary.BigEndian.PutUint32(callId[0:4], wc.c.CurrentSubCallLevel)
+	binary.BigEndian.PutUint32(callId[4:8], wc.c.CurrentSubCallId)
+	seed = append(seed, callId...)
+	if wc.c.Env != nil {
+		seed = append(seed, wc.c.Env.Contract.Address.Bytes()...)
+	}
+	return seed
+}
+
+// DeterministicRand is a PRNG producing sha256(seed || counter) blocks
+type DeterministicRand struct {
+	seed    [32]byte
+	counter uint64
+	buf     []byte
+}
+
+func NewDeterministicRand(seed []byte) *DeterministicRand {
+	return &DeterministicRand{seed: gosha256.Sum256(seed)}
+}
+
+func (r *DeterministicRand) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.buf) == 0 {
+			block := make([]byte, 40)
+			copy(block, r.seed[:])
+			binary.BigEndian.PutUint64(block[32:], r.counter)
+			r.counter += 1
+			hash := gosha256.Sum256(block)
+			r.buf = hash[:]
+		}
+		copied := copy(p[n:], r.buf)
+		r.buf = r.buf[copied:]
+		n += copied
+	}
+	return n, nil
 }
 
 func (wc *WasiContext) InitContext(vm memc.IVm) {
@@ -1379,6 +1755,7 @@ func (wc *WasiContext) InitContext(vm memc.IVm) {
 		parts := strings.Split(pair, ":")
 		externalToInner[parts[1]] = parts[0]
 		wc.openFiles[int32(3+i)] = &openFile{path: parts[0], isdir: true}
+		wc.preopens[parts[0]] = true
 		wc.fileMapping[parts[0]] = []byte{}
 		wc.dirMapping[parts[0]] = true
 	}
@@ -1399,6 +1776,12 @@ func (wc *WasiContext) InitContext(vm memc.IVm) {
 	}
 
 	wc.nextfd = int32(3 + len(preopens))
+	if wc.fsEnabled() {
+		wc.openFiles[wc.nextfd] = &openFile{path: WASI_FS_DATA_DIR, isdir: true}
+		wc.preopens[WASI_FS_DATA_DIR] = true
+		wc.nextfd += 1
+		wc.fsInit()
+	}
 	wc.inited = true
 }
 
@@ -1430,12 +1813,84 @@ func (wc *WasiContext) SetOpenFile(vm memc.IVm, fd int32, f *openFile) {
 	wc.openFiles[fd] = f
 }
 
+func (wc *WasiContext) isPreopened(path string) bool {
+	return wc.preopens[path]
+}
+
+// resolvePath reads a guest path relative to a directory fd
+func (wc *WasiContext) resolvePath(rnh memc.RuntimeHandler, dirFd int32, pathPtr int32, pathLen int32) (string, int32, error) {
+	mem, err := rnh.GetMemory()
+	if err != nil {
+		return "", 0, err
+	}
+	pathBytes, err := mem.Read(pathPtr, pathLen)
+	if err != nil {
+		return "", int32(21), nil // EFAULT
+	}
+	dir, ok := wc.GetOpenFiles(rnh.GetVm())[dirFd]
+	if !ok || !dir.isdir {
+		return "", int32(8), nil // EBADF
+	}
+	fullPath := joinWasiPath(dir.path, string(pathBytes))
+	if IsPersistedPath(fullPath) && !validPersistedPath(fullPath) {
+		return "", int32(76), nil // ENOTCAPABLE
+	}
+	return fullPath, 0, nil
+}
+
+// writeIovs writes the ciovec array into a file at the given offset,
+// extending the file if needed. Persisted files are saved to storage.
+func (wc *WasiContext) writeIovs(mem memc.IMemory, path string, iovsPtr int32, iovsLen int32, offset int64) (int64, int32, error) {
+	content, ok := wc.fileMapping[path]
+	if !ok {
+		return 0, int32(44), nil // ENOENT
+	}
+	if wc.dirMapping[path] {
+		return 0, int32(31), nil // EISDIR
+	}
+	// Each WASI `__wasi_ciovec_t` is (buf_ptr: u32, buf_len: u32), 8 bytes in a 32-bit environment
+	data := []byte{}
+	iovOffset := iovsPtr
+	for i := int32(0); i < iovsLen; i++ {
+		bufPtr, err := wasimem.ReadUint32Le(mem, iovOffset)
+		if err != nil {
+			return 0, 0, err
+		}
+		bufLen, err := wasimem.ReadUint32Le(mem, iovOffset+4)
+		if err != nil {
+			return 0, 0, err
+		}
+		iovOffset += 8
+		buf, err := mem.Read(int32(bufPtr), int32(bufLen))
+		if err != nil {
+			return 0, 0, err
+		}
+		data = append(data, buf...)
+	}
+
+	end := offset + int64(len(data))
+	if !wc.fsCanResize(path, max(end, int64(len(content)))) {
+		return 0, int32(19), nil // EDQUOT
+	}
+	// If end extends beyond the current content, expand it with zero bytes
+	if end > int64(len(content)) {
+		content = append(content, make([]byte, end-int64(len(content)))...)
+	}
+	copy(content[offset:end], data)
+	wc.fileMapping[path] = content
+	wc.fsSync(path, offset, end)
+	return int64(len(data)), 0, nil
+}
+
 func BuildWasiEnv(_context *Context, rnh memc.RuntimeHandler) (interface{}, error) {
 	context := &WasiContext{
 		c:           _context,
 		openFiles:   map[int32]*openFile{},
 		fileMapping: map[string][]byte{},
 		dirMapping:  map[string]bool{},
+		preopens:    map[string]bool{},
+		persisted:   map[string]int64{},
+		loaded:      map[string]bool{},
 	}
 	vm := rnh.GetVm()
 	fndefs := []memc.IFn{
@@ -1867,6 +2322,145 @@ func BuildWasiEnv(_context *Context, rnh memc.RuntimeHandler) (interface{}, erro
 	return vm.BuildModule(rnh, "wasi_snapshot_preview1", context, fndefs)
 }
 
+// writeFilestat writes a __wasi_filestat_t at resultPtr
+func writeFilestat(mem memc.IMemory, resultPtr int32, isDir bool, size uint64) error {
+	// We'll do a minimal approach:
+	//   dev=0, ino=0, filetype=__WASI_FILETYPE_REGULAR_FILE=4, size=len(fileContent)
+	//   times=0, nlink=1, etc.
+
+	var dev uint64 = 0
+	var ino uint64 = 0
+	var nlink uint64 = 1
+	var atim uint64 = 0
+	var mtim uint64 = 0
+	var ctim uint64 = 0
+
+	// __WASI_FILETYPE_REGULAR_FILE=4, directory=3, etc.
+	var filetype uint8 = 4
+	if isDir {
+		filetype = uint8(3)
+	}
+
+	// Write the struct into memory.
+	//    The layout (in bytes) typically is:
+	//    0..7   dev (u64)
+	//    8..15  ino (u64)
+	//    16     filetype (u8)
+	//    17..23 padding
+	//    24..31 nlink (u64)
+	//    32..39 size (u64)
+	//    40..47 atim (u64)
+	//    48..55 mtim (u64)
+	//    56..63 ctim (u64) - total 64 bytes (some WASI docs say 56, but alignment might push it to 64).
+	//    Check your specific environment if it’s 56 or 64 total.
+
+	// Let's define offsets as constants for clarity:
+	const (
+		offsetDev      = 0
+		offsetIno      = 8
+		offsetFiletype = 16
+		offsetNlink    = 24
+		offsetSize     = 32
+		offsetAtim     = 40
+		offsetMtim     = 48
+		offsetCtim     = 56
+	)
+
+	// Write dev (u64)
+	if err := wasimem.WriteUint64Le(mem, resultPtr+offsetDev, dev); err != nil {
+		return err
+	}
+
+	// Write ino (u64)
+	if err := wasimem.WriteUint64Le(mem, resultPtr+offsetIno, ino); err != nil {
+		return err
+	}
+
+	// Write filetype (u8)
+	if err := mem.Write(resultPtr+offsetFiletype, []byte{filetype}); err != nil {
+		return err
+	}
+
+	// nlink (u64)
+	if err := wasimem.WriteUint64Le(mem, resultPtr+offsetNlink, nlink); err != nil {
+		return err
+	}
+
+	// size (u64)
+	if err := wasimem.WriteUint64Le(mem, resultPtr+offsetSize, size); err != nil {
+		return err
+	}
+
+	// atim (u64)
+	if err := wasimem.WriteUint64Le(mem, resultPtr+offsetAtim, atim); err != nil {
+		return err
+	}
+
+	// mtim (u64)
+	if err := wasimem.WriteUint64Le(mem, resultPtr+offsetMtim, mtim); err != nil {
+		return err
+	}
+
+	// ctim (u64)
+	if err := wasimem.WriteUint64Le(mem, resultPtr+offsetCtim, ctim); err != nil {
+		return err
+	}
+	return nil
+}
+
+// readIovs reads file content from offset into the iovec array
+func readIovs(mem memc.IMemory, iovsPtr int32, iovsLen int32, content []byte, offset int64) (int64, error) {
+	var totalRead int64
+	iovOffset := iovsPtr
+	for i := int32(0); i < iovsLen; i++ {
+		// Each iovec is (bufPtr: u32, bufLen: u32), 8 bytes total in 32-bit
+		bufPtr, err := wasimem.ReadUint32Le(mem, iovOffset)
+		if err != nil {
+			return 0, err
+		}
+		bufLen, err := wasimem.ReadUint32Le(mem, iovOffset+4)
+		if err != nil {
+			return 0, err
+		}
+		iovOffset += 8
+
+		// If we've already reached or passed EOF, read 0 bytes into this iovec
+		if offset >= int64(len(content)) {
+			break
+		}
+		toRead := min(int64(bufLen), int64(len(content))-offset)
+		if err := mem.Write(int32(bufPtr), content[offset:offset+toRead]); err != nil {
+			return 0, err
+		}
+		offset += toRead
+		totalRead += toRead
+	}
+	return totalRead, nil
+}
+
+// joinWasiPath joins a path to its directory, as path_open resolves it
+func joinWasiPath(dir string, guestPath string) string {
+	fullPath := dir
+	if guestPath != "" && guestPath != "." {
+		if fullPath != "" && !endsWithSlash(fullPath) {
+			fullPath += "/"
+		}
+		fullPath += guestPath
+	}
+	return fullPath
+}
+
+func parentPath(p string) string {
+	idx := strings.LastIndex(p, "/")
+	if idx < 0 {
+		return "."
+	}
+	if idx == 0 {
+		return "/"
+	}
+	return p[:idx]
+}
+
 func endsWithSlash(s string) bool {
 	return len(s) > 0 && s[len(s)-1] == '/'
 }
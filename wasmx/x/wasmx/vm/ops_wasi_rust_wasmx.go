@@ -51,7 +51,9 @@ func wasiStorageStore(_context interface{}, rnh memc.RuntimeHandler, params []in
 		return nil, err
 	}
 	ctx.GasMeter.ConsumeGas(uint64(SSTORE_GAS_EWASM), "wasiStorageStore")
-	ctx.ContractStore.Set(keybz, valuebz)
+	if err := ctx.contractStorageSet(keybz, valuebz); err != nil {
+		return nil, err
+	}
 
 	returns := make([]interface{}, 0)
 	return returns, nil
@@ -68,7 +70,7 @@ func wasiStorageLoad(_context interface{}, rnh memc.RuntimeHandler, params []int
 	if err != nil {
 		return nil, err
 	}
-	data := ctx.ContractStore.Get(keybz)
+	data := ctx.storageGet(keybz)
 	if len(data) == 0 {
 		data = types.EMPTY_BYTES32
 	}
@@ -612,7 +614,7 @@ func wasiLog(_context interface{}, rnh memc.RuntimeHandler, params []interface{}
 		Data:             wlog.Data,
 		Topics:           wlog.Topics,
 	}
-	ctx.Logs = append(ctx.Logs, log)
+	ctx.addLog(log)
 
 	returns := make([]interface{}, 0)
 	return returns, nil
@@ -671,8 +673,8 @@ func BuildWasmxEnvRusti64(context *Context, rnh memc.RuntimeHandler) (interface{
 	return vm.BuildModule(rnh, "wasmx", context, fndefs)
 }
 
-func ExecuteWasiCommand(context *Context, contractVm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]int32, error) {
-	var res []int32
+func ExecuteWasiCommand(context *Context, contractVm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]interface{}, error) {
+	var res []interface{}
 	var err error
 
 	// wasi command style has only one entry point `_start`,
@@ -691,8 +693,8 @@ func ExecuteWasiCommand(context *Context, contractVm memc.IVm, funcName string,
 	return res, nil
 }
 
-func ExecuteWasiReactor(context *Context, contractVm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]int32, error) {
-	var res []int32
+func ExecuteWasiReactor(context *Context, contractVm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]interface{}, error) {
+	var res []interface{}
 	var err error
 
 	// wasi reactor module needs to be `_initialize` first and only one time per module instantiation!
@@ -712,7 +714,7 @@ func ExecuteWasiReactor(context *Context, contractVm memc.IVm, funcName string,
 	return res, nil
 }
 
-func ExecuteWasi(context *Context, contractVm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]int32, error) {
+func ExecuteWasi(context *Context, contractVm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]interface{}, error) {
 	isCommand := false
 	isReactor := false
 	hasFunc := false
@@ -745,7 +747,7 @@ func ExecuteWasi(context *Context, contractVm memc.IVm, funcName string, args []
 
 // TODO py/js interpreters should not have a custom way to be called, use ExecuteWasi
 // and respect command/reactor compilation style
-func ExecutePythonInterpreter(context *Context, contractVm memc.IVm, funcName string, args []interface{}, _ bool) ([]int32, error) {
+func ExecutePythonInterpreter(context *Context, contractVm memc.IVm, funcName string, args []interface{}, _ bool) ([]interface{}, error) {
 	if funcName == "execute" || funcName == "query" {
 		funcName = "main"
 	}
@@ -800,7 +802,7 @@ set_returndata(res or b'')
 	return ExecuteWasi(context, contractVm, types.ENTRY_POINT_EXECUTE, make([]interface{}, 0), false)
 }
 
-func ExecuteJsInterpreter(context *Context, contractVm memc.IVm, funcName string, args []interface{}, _ bool) ([]int32, error) {
+func ExecuteJsInterpreter(context *Context, contractVm memc.IVm, funcName string, args []interface{}, _ bool) ([]interface{}, error) {
 	if funcName == "execute" || funcName == "query" {
 		funcName = "main"
 	}
@@ -851,7 +853,7 @@ wasmx.setReturnData(res || new ArrayBuffer(0));
 	return ExecuteWasi(context, contractVm, types.ENTRY_POINT_EXECUTE, make([]interface{}, 0), false)
 }
 
-func ExecuteWasiWrap(context *Context, contractVm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]int32, error) {
+func ExecuteWasiWrap(context *Context, contractVm memc.IVm, funcName string, args []interface{}, interpreted bool) ([]interface{}, error) {
 	contractVm.InstantiateWasi(
 		[]string{
 			``,
@@ -58,7 +58,9 @@ func wasmxStorageStore(_context interface{}, rnh memc.RuntimeHandler, params []i
 		return nil, err
 	}
 	ctx.GasMeter.ConsumeGas(uint64(SSTORE_GAS_WASMX), "wasmxStorageStore")
-	ctx.ContractStore.Set(key, data)
+	if err := ctx.contractStorageSet(key, data); err != nil {
+		return nil, err
+	}
 	returns := make([]interface{}, 0)
 	return returns, nil
 }
@@ -71,7 +73,7 @@ func wasmxStorageLoad(_context interface{}, rnh memc.RuntimeHandler, params []in
 	if err != nil {
 		return nil, err
 	}
-	data := ctx.ContractStore.Get(keybz)
+	data := ctx.storageGet(keybz)
 	// if len(data) == 0 {
 	// 	data = make([]byte, 32)
 	// }
@@ -87,7 +89,9 @@ func wasmxStorageDelete(_context interface{}, rnh memc.RuntimeHandler, params []
 		return nil, err
 	}
 	// refund some gas?
-	ctx.ContractStore.Delete(key)
+	if err := ctx.contractStorageDelete(key); err != nil {
+		return nil, err
+	}
 	returns := make([]interface{}, 0)
 	return returns, nil
 }
@@ -113,10 +117,10 @@ func wasmxStorageDeleteRange(_context interface{}, rnh memc.RuntimeHandler, para
 		endKey = nil
 	}
 
-	iter := ctx.ContractStore.Iterator(startKey, endKey)
+	iter := ctx.contractStorageIterator(startKey, endKey, false)
 	defer iter.Close()
 	for ; iter.Valid(); iter.Next() {
-		ctx.ContractStore.Delete(iter.Key())
+		ctx.storageDelete(iter.Key())
 	}
 	returns := make([]interface{}, 0)
 	return returns, nil
@@ -144,12 +148,7 @@ func wasmxStorageLoadRange(_context interface{}, rnh memc.RuntimeHandler, params
 		endKey = nil
 	}
 
-	var iter types.Iterator
-	if req.Reverse {
-		iter = ctx.ContractStore.ReverseIterator(startKey, endKey)
-	} else {
-		iter = ctx.ContractStore.Iterator(startKey, endKey)
-	}
+	iter := ctx.contractStorageIterator(startKey, endKey, req.Reverse)
 	defer iter.Close()
 	for ; iter.Valid(); iter.Next() {
 		values = append(values, iter.Value())
@@ -184,12 +183,7 @@ func wasmxStorageLoadRangePairs(_context interface{}, rnh memc.RuntimeHandler, p
 		endKey = nil
 	}
 
-	var iter types.Iterator
-	if req.Reverse {
-		iter = ctx.ContractStore.ReverseIterator(startKey, endKey)
-	} else {
-		iter = ctx.ContractStore.Iterator(startKey, endKey)
-	}
+	iter := ctx.contractStorageIterator(startKey, endKey, req.Reverse)
 	defer iter.Close()
 	for ; iter.Valid(); iter.Next() {
 		pair := StoragePair{Key: iter.Key(), Value: iter.Value()}
@@ -232,7 +226,7 @@ func wasmxLog(_context interface{}, rnh memc.RuntimeHandler, params []interface{
 		Data:             wlog.Data,
 		Topics:           wlog.Topics,
 	}
-	ctx.Logs = append(ctx.Logs, log)
+	ctx.addLog(log)
 	returns := make([]interface{}, 0)
 	return returns, nil
 }
@@ -0,0 +1,70 @@
+package vm
+
+import (
+	"bytes"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+)
+
+// SQL_MIGRATIONS_STORAGE_PREFIX is the contract storage prefix reserved for the sql migrations registered by the contract
+var SQL_MIGRATIONS_STORAGE_PREFIX = []byte("\x00sql_migrations")
+
+// contract storage prefixes reserved for host modules. Contracts cannot write,
+// delete or iterate these keys through the storage host functions.
+var reservedStoragePrefixes = [][]byte{WASI_FS_STORAGE_PREFIX, SQL_MIGRATIONS_STORAGE_PREFIX}
+
+// IsReservedStorageKey returns true for contract storage keys reserved for host modules
+func IsReservedStorageKey(key []byte) bool {
+	for _, prefix := range reservedStoragePrefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// contractStorageSet writes a key for the contract storage host functions
+func (c *Context) contractStorageSet(key []byte, value []byte) error {
+	if IsReservedStorageKey(key) {
+		return types.ErrReservedStorageKey.Wrapf("%x", key)
+	}
+	c.storageSet(key, value)
+	return nil
+}
+
+// contractStorageDelete removes a key for the contract storage host functions
+func (c *Context) contractStorageDelete(key []byte) error {
+	if IsReservedStorageKey(key) {
+		return types.ErrReservedStorageKey.Wrapf("%x", key)
+	}
+	c.storageDelete(key)
+	return nil
+}
+
+// contractStorageIterator iterates the contract storage without the reserved keys
+func (c *Context) contractStorageIterator(start, end []byte, reverse bool) types.Iterator {
+	var iter types.Iterator
+	if reverse {
+		iter = c.ContractStore.ReverseIterator(start, end)
+	} else {
+		iter = c.ContractStore.Iterator(start, end)
+	}
+	filtered := &reservedFilterIterator{Iterator: iter}
+	filtered.skipReserved()
+	return filtered
+}
+
+type reservedFilterIterator struct {
+	types.Iterator
+}
+
+func (it *reservedFilterIterator) Next() {
+	it.Iterator.Next()
+	it.skipReserved()
+}
+
+func (it *reservedFilterIterator) skipReserved() {
+	for it.Iterator.Valid() && IsReservedStorageKey(it.Iterator.Key()) {
+		it.Iterator.Next()
+	}
+}
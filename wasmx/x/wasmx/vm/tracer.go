@@ -0,0 +1,76 @@
+package vm
+
+import (
+	"encoding/json"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+)
+
+// traceCall reports the start of a contract call to the context tracer, if any,
+// and returns the function that reports the end of the call
+func traceCall(ctx sdk.Context, gasMeter types.GasMeter, typ string, from sdk.AccAddress, to sdk.AccAddress, input []byte, gasLimit *big.Int, value *big.Int) func(output []byte, err error) {
+	tracer := types.GetTracer(ctx)
+	if tracer == nil {
+		return func([]byte, error) {}
+	}
+	gas := uint64(0)
+	if gasLimit != nil && gasLimit.IsUint64() {
+		gas = gasLimit.Uint64()
+	}
+	gasStart := gasMeter.GasConsumed()
+	tracer.CaptureEnter(typ, from, to, input, gas, value)
+	return func(output []byte, err error) {
+		tracer.CaptureExit(output, gasMeter.GasConsumed()-gasStart, err)
+	}
+}
+
+// traceExecution traces a top level contract execution entry point
+func traceExecution(ctx sdk.Context, gasMeter types.GasMeter, funcName string, env types.Env, msg []byte) func(output []byte, err error) {
+	if types.GetTracer(ctx) == nil {
+		return func([]byte, error) {}
+	}
+	var ethMsg types.WasmxExecutionMessage
+	// decoding errors are returned by the execution itself
+	_ = json.Unmarshal(msg, &ethMsg)
+	typ := types.CALL_TYPE_CALL
+	if funcName == types.ENTRY_POINT_INSTANTIATE {
+		typ = types.CALL_TYPE_CREATE
+	}
+	return traceCall(ctx, gasMeter, typ, env.CurrentCall.Sender.Bytes(), env.Contract.Address.Bytes(), ethMsg.Data, env.CurrentCall.GasLimit, env.CurrentCall.Funds)
+}
+
+// storageGet reads a key from the contract storage
+func (c *Context) storageGet(key []byte) []byte {
+	value := c.ContractStore.Get(key)
+	if tracer := types.GetTracer(c.Ctx); tracer != nil {
+		tracer.CaptureStorageRead(c.Env.Contract.Address.Bytes(), key, value)
+	}
+	return value
+}
+
+// storageSet writes a key to the contract storage
+func (c *Context) storageSet(key []byte, value []byte) {
+	c.ContractStore.Set(key, value)
+	if tracer := types.GetTracer(c.Ctx); tracer != nil {
+		tracer.CaptureStorageWrite(c.Env.Contract.Address.Bytes(), key, value)
+	}
+}
+
+// storageDelete removes a key from the contract storage
+func (c *Context) storageDelete(key []byte) {
+	c.ContractStore.Delete(key)
+	if tracer := types.GetTracer(c.Ctx); tracer != nil {
+		tracer.CaptureStorageWrite(c.Env.Contract.Address.Bytes(), key, nil)
+	}
+}
+
+// addLog adds a log emitted by the contract to the execution logs
+func (c *Context) addLog(log WasmxLog) {
+	c.Logs = append(c.Logs, log)
+	if tracer := types.GetTracer(c.Ctx); tracer != nil {
+		tracer.CaptureLog(log.ContractAddress.Bytes(), log.Topics, log.Data)
+	}
+}
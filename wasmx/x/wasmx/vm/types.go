@@ -97,7 +97,7 @@ func (c *Context) Execute() ([]byte, error) {
 	if types.HasUtf8SystemDep(c.ContractInfo.SystemDeps) {
 		filepath = ""
 	}
-	rnh := getRuntimeHandler(c.newIVmFn, c.Ctx, c.ContractInfo.SystemDeps, c.ContractInfo.Pinned)
+	rnh := getRuntimeHandler(c.newIVmFn, c.Ctx, c.ContractInfo.SystemDeps, c.ContractInfo.Pinned, c.ContractInfo.MaxMemoryPages, c.ContractInfo.GasSchedule)
 	defer func() {
 		rnh.GetVm().Cleanup()
 	}()
@@ -0,0 +1,22 @@
+package utils
+
+import "context"
+
+// RunWithContext runs a blocking call that does not accept a context,
+// returning early with the context error if the context is done first.
+// The call itself keeps running in the background until it finishes.
+func RunWithContext(ctx context.Context, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
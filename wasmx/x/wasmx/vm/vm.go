@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -84,7 +85,7 @@ func getFirstKeyFromMapping[T any](vmap map[string]T, keys []string) (T, bool) {
 	return zero, false
 }
 
-func getRuntimeHandler(newIVmFn memc.NewIVmFn, ctx sdk.Context, systemDeps []types.SystemDep, pinned bool) memc.RuntimeHandler {
+func getRuntimeHandler(newIVmFn memc.NewIVmFn, ctx sdk.Context, systemDeps []types.SystemDep, pinned bool, maxMemoryPages uint32, gasSchedule memc.GasSchedule) memc.RuntimeHandler {
 	if !pinned {
 		// also check system deps
 		for _, dep := range systemDeps {
@@ -94,7 +95,9 @@ func getRuntimeHandler(newIVmFn memc.NewIVmFn, ctx sdk.Context, systemDeps []typ
 			}
 		}
 	}
-	vm := newIVmFn(ctx, pinned)
+	vmctx := memc.WithMemoryLimitPages(ctx, maxMemoryPages)
+	vmctx = memc.WithGasSchedule(vmctx, gasSchedule)
+	vm := newIVmFn(vmctx, pinned)
 	handler := getRuntimeHandlerFromDeps(vm, systemDeps)
 	if handler != nil {
 		return handler
@@ -124,7 +127,7 @@ func getRuntimeHandlerFromDeps(vm memc.IVm, systemDeps []types.SystemDep) memc.R
 	return nil
 }
 
-func AnalyzeWasm(ctx sdk.Context, vmMeta memc.IWasmVmMeta, wasmbuffer []byte) (memc.AnalysisReport, error) {
+func AnalyzeWasm(ctx sdk.Context, vmMeta memc.IWasmVmMeta, wasmbuffer []byte, deps []string) (memc.AnalysisReport, error) {
 	report := memc.AnalysisReport{}
 	meta, err := vmMeta.AnalyzeWasm(ctx, wasmbuffer)
 	if err != nil {
@@ -152,10 +155,6 @@ func AnalyzeWasm(ctx sdk.Context, vmMeta memc.IWasmVmMeta, wasmbuffer []byte) (m
 			dep = parseDependencyOrHexAddr(fname, types.CW_VM_EXPORT)
 		}
 		if dep != "" {
-			err := VerifyEnv(dep, imports)
-			if err != nil {
-				return report, sdkerr.Wrapf(types.ErrCreateFailed, "wasm module requires imports not supported by the %s version: %s", fname, err.Error())
-			}
 			if _, found := uniqueDeps[dep]; !found {
 				report.Dependencies = append(report.Dependencies, dep)
 				uniqueDeps[dep] = true
@@ -172,10 +171,6 @@ func AnalyzeWasm(ctx sdk.Context, vmMeta memc.IWasmVmMeta, wasmbuffer []byte) (m
 		}
 
 		if dep != "" {
-			err := VerifyEnv(dep, imports)
-			if err != nil {
-				return report, sdkerr.Wrapf(types.ErrCreateFailed, "wasm module requires imports not supported by the %s version: %s", fname, err.Error())
-			}
 			if _, found := uniqueDeps[dep]; !found {
 				report.Dependencies = append(report.Dependencies, dep)
 				uniqueDeps[dep] = true
@@ -186,16 +181,15 @@ func AnalyzeWasm(ctx sdk.Context, vmMeta memc.IWasmVmMeta, wasmbuffer []byte) (m
 	// make deterministic: order alphabetically
 	sort.Strings(report.Dependencies)
 
-	return report, nil
-}
-
-func VerifyEnv(version string, imports []memc.WasmImport) error {
-	// TODO check that all imports are supported by the given version
+	// imports must be provided by the host interfaces the contract declares,
+	// either through its exports or through the deps given at upload
+	allDeps := append(slices.Clone(report.Dependencies), deps...)
+	err = VerifyEnv(allDeps, imports)
+	if err != nil {
+		return report, sdkerr.Wrapf(types.ErrCreateFailed, "wasm module requires imports not supported by its host interfaces %v: %s", allDeps, err.Error())
+	}
 
-	// for _, mimport := range imports {
-	// 	fmt.Println("Import:", mimport.GetModuleName(), mimport.GetExternalName())
-	// }
-	return nil
+	return report, nil
 }
 
 // Returns the hex address of the interpreter if exists or the version string
@@ -256,8 +250,12 @@ func ExecuteWasmInterpreted(
 	inBackground bool,
 	app types.Application,
 	newIVmFn memc.NewIVmFn,
-) (types.ContractResponse, error) {
-	var err error
+) (response types.ContractResponse, err error) {
+	traceExit := traceExecution(ctx, gasMeter, funcName, env, msg)
+	defer func() {
+		traceExit(response.Data, err)
+	}()
+
 	var ethMsg types.WasmxExecutionMessage
 	err = json.Unmarshal(msg, &ethMsg)
 	if err != nil {
@@ -265,7 +263,7 @@ func ExecuteWasmInterpreted(
 	}
 
 	var contractRouter ContractRouter = make(map[string]*Context)
-	rnh := getRuntimeHandler(newIVmFn, ctx, contractInfo.SystemDeps, contractInfo.Pinned)
+	rnh := getRuntimeHandler(newIVmFn, ctx, contractInfo.SystemDeps, contractInfo.Pinned, contractInfo.MaxMemoryPages, contractInfo.GasSchedule)
 	defer func() {
 		rnh.GetVm().Cleanup()
 	}()
@@ -327,6 +325,8 @@ func ExecuteWasmInterpreted(
 	_, err = executeHandler(context, rnh.GetVm(), funcName, make([]interface{}, 0), true)
 	// sp, err2 := contractVm.Execute("get_sp")
 	if err != nil {
+		err = wrapMemoryLimitError(rnh.GetVm(), contractInfo.MaxMemoryPages, err)
+		err = wrapTimeoutError(context.Ctx, err)
 		wrapErr := sdkerr.Wrapf(
 			err,
 			"chain_id: %s; contract: %s; entry point: %s; revert: %s",
@@ -346,7 +346,7 @@ func ExecuteWasmInterpreted(
 		// return types.ContractResponse{}, err
 	}
 
-	response := handleContractResponse(context, rnh.GetVm(), isdebug)
+	response = handleContractResponse(context, rnh.GetVm(), isdebug)
 	return response, nil
 }
 
@@ -367,8 +367,12 @@ func ExecuteWasm(
 	inBackground bool,
 	app types.Application,
 	newIVmFn memc.NewIVmFn,
-) (types.ContractResponse, error) {
-	var err error
+) (response types.ContractResponse, err error) {
+	traceExit := traceExecution(ctx, gasMeter, funcName, env, msg)
+	defer func() {
+		traceExit(response.Data, err)
+	}()
+
 	var ethMsg types.WasmxExecutionMessage
 	err = json.Unmarshal(msg, &ethMsg)
 	if err != nil {
@@ -376,7 +380,7 @@ func ExecuteWasm(
 	}
 
 	var contractRouter ContractRouter = make(map[string]*Context)
-	rnh := getRuntimeHandler(newIVmFn, ctx, contractInfo.SystemDeps, contractInfo.Pinned)
+	rnh := getRuntimeHandler(newIVmFn, ctx, contractInfo.SystemDeps, contractInfo.Pinned, contractInfo.MaxMemoryPages, contractInfo.GasSchedule)
 	defer func() {
 		rnh.GetVm().Cleanup()
 	}()
@@ -444,6 +448,8 @@ func ExecuteWasm(
 	}
 	_, err = executeHandler(context, rnh.GetVm(), funcName, make([]interface{}, 0), false)
 	if err != nil {
+		err = wrapMemoryLimitError(rnh.GetVm(), contractInfo.MaxMemoryPages, err)
+		err = wrapTimeoutError(context.Ctx, err)
 		wrapErr := sdkerr.Wrapf(
 			err,
 			"chain_id: %s; contract: %s; entry point: %s; revert: %s",
@@ -460,7 +466,7 @@ func ExecuteWasm(
 		// runCleanups(cleanups)
 		// return types.ContractResponse{}, err
 	}
-	response := handleContractResponse(context, rnh.GetVm(), isdebug)
+	response = handleContractResponse(context, rnh.GetVm(), isdebug)
 	return response, nil
 }
 
@@ -505,9 +511,18 @@ func setExecutionBytecode(context *Context, rnh memc.RuntimeHandler, funcName st
 			return
 		}
 
-		memoffset := retvalues[0]
-		constructorLength := retvalues[1]
-		runtimeLength := retvalues[2]
+		memoffset, err := memc.ResultI32(retvalues, 0)
+		if err != nil {
+			return
+		}
+		constructorLength, err := memc.ResultI32(retvalues, 1)
+		if err != nil {
+			return
+		}
+		runtimeLength, err := memc.ResultI32(retvalues, 2)
+		if err != nil {
+			return
+		}
 		executionBytecode, err := activeMemory.Read(memoffset+constructorLength, runtimeLength)
 		if err != nil {
 			return
@@ -599,6 +614,25 @@ func handleContractErrorResponse(vm memc.IVm, data []byte, isdebug bool, err err
 	}
 }
 
+// wrapMemoryLimitError marks the error of an execution that has grown its memory up to the limit.
+// memory.grow does not trap, it returns -1 and the contract usually aborts soon after.
+func wrapMemoryLimitError(vm memc.IVm, maxMemoryPages uint32, err error) error {
+	mem, memErr := vm.GetMemory()
+	if memErr != nil || !memc.MemoryLimitReached(mem, maxMemoryPages) {
+		return err
+	}
+	return sdkerr.Wrapf(types.ErrMemoryLimit, "%d pages: %s", maxMemoryPages, err.Error())
+}
+
+// wrapTimeoutError marks the error of an execution interrupted by the wall-clock deadline of its context.
+// wazero closes the module when the context is done, host APIs return the context error.
+func wrapTimeoutError(ctx sdk.Context, err error) error {
+	if !errors.Is(ctx.Context().Err(), context.DeadlineExceeded) || errors.Is(err, types.ErrExecutionTimeout) {
+		return err
+	}
+	return sdkerr.Wrap(types.ErrExecutionTimeout, err.Error())
+}
+
 func getMemory(vm memc.IVm) []byte {
 	activeMemory, err := vm.GetMemory()
 	if err != nil {
@@ -0,0 +1,221 @@
+package vm
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+)
+
+// WASI_FS_DATA_DIR is preopened for every WASI contract. Files written under it
+// are persisted in the contract storage and are visible in the next calls.
+const WASI_FS_DATA_DIR = "/data"
+
+// WASI_FS_STORAGE_PREFIX is the contract storage prefix reserved for the WASI filesystem
+var WASI_FS_STORAGE_PREFIX = []byte("\x00wasi_fs")
+
+// files are stored in chunks, so writes only touch the changed parts of a file
+const WASI_FS_CHUNK_SIZE = int64(4096)
+
+// same as the default cosmos-sdk KV gas config
+const (
+	WASI_FS_READ_GAS           = 1000
+	WASI_FS_READ_GAS_PER_BYTE  = 3
+	WASI_FS_WRITE_GAS          = 2000
+	WASI_FS_WRITE_GAS_PER_BYTE = 30
+)
+
+const (
+	wasiFsMetaDir  = byte('d')
+	wasiFsMetaFile = byte('f')
+)
+
+// metadata key => 'd' for directories, 'f' + size for files
+func wasiFsMetaKey(path string) []byte {
+	return append(wasiFsKey('m'), []byte(path)...)
+}
+
+func wasiFsChunkKey(path string, index int64) []byte {
+	key := append(wasiFsKey('c'), []byte(path)...)
+	key = append(key, 0)
+	return binary.BigEndian.AppendUint32(key, uint32(index))
+}
+
+// total bytes stored, checked against the quota
+func wasiFsUsageKey() []byte {
+	return wasiFsKey('q')
+}
+
+func wasiFsKey(kind byte) []byte {
+	key := make([]byte, len(WASI_FS_STORAGE_PREFIX), len(WASI_FS_STORAGE_PREFIX)+1)
+	copy(key, WASI_FS_STORAGE_PREFIX)
+	return append(key, kind)
+}
+
+// IsPersistedPath returns true for paths under the persisted data directory
+func IsPersistedPath(path string) bool {
+	return path == WASI_FS_DATA_DIR || strings.HasPrefix(path, WASI_FS_DATA_DIR+"/")
+}
+
+// a persisted path must not escape the data directory
+func validPersistedPath(path string) bool {
+	for _, part := range strings.Split(path, "/") {
+		if part == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+func (wc *WasiContext) fsEnabled() bool {
+	return wc.c != nil && wc.c.Env != nil
+}
+
+// fsInit loads the persisted directories and file sizes.
+// File contents are loaded when the file is first used.
+func (wc *WasiContext) fsInit() {
+	wc.fileMapping[WASI_FS_DATA_DIR] = []byte{}
+	wc.dirMapping[WASI_FS_DATA_DIR] = true
+	prefix := wasiFsKey('m')
+	iter := storetypes.KVStorePrefixIterator(wc.c.ContractStore, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		path := string(iter.Key()[len(prefix):])
+		value := iter.Value()
+		wc.c.GasMeter.ConsumeGas(uint64(WASI_FS_READ_GAS+WASI_FS_READ_GAS_PER_BYTE*(len(iter.Key())+len(value))), "wasi_fs_read")
+		if len(value) > 0 && value[0] == wasiFsMetaDir {
+			wc.dirMapping[path] = true
+			wc.fileMapping[path] = []byte{}
+			continue
+		}
+		size := int64(0)
+		if len(value) == 9 {
+			size = int64(binary.BigEndian.Uint64(value[1:]))
+		}
+		wc.persisted[path] = size
+		wc.fileMapping[path] = []byte{}
+	}
+}
+
+// fsLoad loads the content of a persisted file, if not already loaded
+func (wc *WasiContext) fsLoad(path string) {
+	size, found := wc.persisted[path]
+	if !found || wc.loaded[path] {
+		return
+	}
+	content := make([]byte, 0, size)
+	chunks := (size + WASI_FS_CHUNK_SIZE - 1) / WASI_FS_CHUNK_SIZE
+	for i := int64(0); i < chunks; i++ {
+		chunk := wc.c.storageGet(wasiFsChunkKey(path, i))
+		wc.c.GasMeter.ConsumeGas(uint64(WASI_FS_READ_GAS+WASI_FS_READ_GAS_PER_BYTE*len(chunk)), "wasi_fs_read")
+		content = append(content, chunk...)
+	}
+	wc.fileMapping[path] = content
+	wc.loaded[path] = true
+}
+
+// fsQuota returns the maximum bytes of file content the contract can store in its data directory
+func (wc *WasiContext) fsQuota() int64 {
+	quota := types.DefaultWasiFsQuota
+	if wc.c.ContractInfo != nil && wc.c.ContractInfo.WasiFsQuota > 0 {
+		quota = wc.c.ContractInfo.WasiFsQuota
+	}
+	return int64(min(quota, math.MaxInt64))
+}
+
+func (wc *WasiContext) fsUsage() int64 {
+	value := wc.c.storageGet(wasiFsUsageKey())
+	if len(value) != 8 {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(value))
+}
+
+func (wc *WasiContext) fsSetUsage(usage int64) {
+	wc.c.storageSet(wasiFsUsageKey(), binary.BigEndian.AppendUint64(nil, uint64(usage)))
+}
+
+// fsCanResize checks the quota before a persisted file grows to newSize
+func (wc *WasiContext) fsCanResize(path string, newSize int64) bool {
+	if !wc.fsEnabled() || !IsPersistedPath(path) {
+		return true
+	}
+	return wc.fsUsage()-wc.persisted[path]+newSize <= wc.fsQuota()
+}
+
+// fsSync persists the [from, to) range of a file and its new size.
+// Chunks beyond the new size are removed.
+func (wc *WasiContext) fsSync(path string, from int64, to int64) {
+	if !wc.fsEnabled() || !IsPersistedPath(path) {
+		return
+	}
+	content := wc.fileMapping[path]
+	newSize := int64(len(content))
+	oldSize := wc.persisted[path]
+	writeChunk := func(i int64) {
+		start := i * WASI_FS_CHUNK_SIZE
+		end := min(start+WASI_FS_CHUNK_SIZE, newSize)
+		chunk := content[start:end]
+		wc.c.GasMeter.ConsumeGas(uint64(WASI_FS_WRITE_GAS+WASI_FS_WRITE_GAS_PER_BYTE*len(chunk)), "wasi_fs_write")
+		wc.c.storageSet(wasiFsChunkKey(path, i), chunk)
+	}
+	to = min(to, newSize)
+	lastWritten := int64(-1)
+	if from < to {
+		for i := from / WASI_FS_CHUNK_SIZE; i <= (to-1)/WASI_FS_CHUNK_SIZE; i++ {
+			writeChunk(i)
+			lastWritten = i
+		}
+	}
+	// a truncated file also rewrites its last, partial chunk
+	if newSize < oldSize && newSize%WASI_FS_CHUNK_SIZE != 0 && newSize/WASI_FS_CHUNK_SIZE != lastWritten {
+		writeChunk(newSize / WASI_FS_CHUNK_SIZE)
+	}
+	newChunks := (newSize + WASI_FS_CHUNK_SIZE - 1) / WASI_FS_CHUNK_SIZE
+	oldChunks := (oldSize + WASI_FS_CHUNK_SIZE - 1) / WASI_FS_CHUNK_SIZE
+	for i := newChunks; i < oldChunks; i++ {
+		wc.c.storageDelete(wasiFsChunkKey(path, i))
+	}
+	wc.fsSetMeta(path, newSize)
+	wc.fsSetUsage(wc.fsUsage() - oldSize + newSize)
+	wc.persisted[path] = newSize
+	wc.loaded[path] = true
+}
+
+func (wc *WasiContext) fsSetMeta(path string, size int64) {
+	value := binary.BigEndian.AppendUint64([]byte{wasiFsMetaFile}, uint64(size))
+	wc.c.GasMeter.ConsumeGas(uint64(WASI_FS_WRITE_GAS+WASI_FS_WRITE_GAS_PER_BYTE*len(value)), "wasi_fs_write")
+	wc.c.storageSet(wasiFsMetaKey(path), value)
+}
+
+// fsCreateDir persists a new directory
+func (wc *WasiContext) fsCreateDir(path string) {
+	if !wc.fsEnabled() || !IsPersistedPath(path) {
+		return
+	}
+	wc.c.GasMeter.ConsumeGas(uint64(WASI_FS_WRITE_GAS+WASI_FS_WRITE_GAS_PER_BYTE), "wasi_fs_write")
+	wc.c.storageSet(wasiFsMetaKey(path), []byte{wasiFsMetaDir})
+}
+
+// fsRemove removes a persisted file or directory
+func (wc *WasiContext) fsRemove(path string) {
+	if !wc.fsEnabled() || !IsPersistedPath(path) {
+		return
+	}
+	wc.c.GasMeter.ConsumeGas(uint64(WASI_FS_WRITE_GAS), "wasi_fs_write")
+	wc.c.storageDelete(wasiFsMetaKey(path))
+	size, found := wc.persisted[path]
+	if !found {
+		return
+	}
+	chunks := (size + WASI_FS_CHUNK_SIZE - 1) / WASI_FS_CHUNK_SIZE
+	for i := int64(0); i < chunks; i++ {
+		wc.c.storageDelete(wasiFsChunkKey(path, i))
+	}
+	wc.fsSetUsage(wc.fsUsage() - size)
+	delete(wc.persisted, path)
+	delete(wc.loaded, path)
+}
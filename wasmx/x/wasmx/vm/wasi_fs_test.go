@@ -0,0 +1,75 @@
+package vm
+
+import (
+	"context"
+	"testing"
+
+	"cosmossdk.io/store/dbadapter"
+	"cosmossdk.io/store/prefix"
+	dbm "github.com/cosmos/cosmos-db"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+)
+
+func newWasiFsTestContext(quota uint64) *Context {
+	store := prefix.NewStore(&dbadapter.Store{DB: dbm.NewMemDB()}, []byte("contract"))
+	return &Context{
+		Ctx:           sdk.Context{}.WithContext(context.Background()),
+		ContractStore: store,
+		ContractInfo:  &types.ContractDependency{WasiFsQuota: quota},
+	}
+}
+
+func TestWasiFsReservedKeys(t *testing.T) {
+	ctx := newWasiFsTestContext(0)
+	wc := &WasiContext{c: ctx}
+	wc.fsSetUsage(100)
+	fsKey := wasiFsUsageKey()
+
+	err := ctx.contractStorageSet(fsKey, []byte{1})
+	require.ErrorIs(t, err, types.ErrReservedStorageKey)
+	err = ctx.contractStorageDelete(fsKey)
+	require.ErrorIs(t, err, types.ErrReservedStorageKey)
+	require.Equal(t, int64(100), wc.fsUsage())
+	err = ctx.contractStorageSet([]byte(string(SQL_MIGRATIONS_STORAGE_PREFIX)+"x"), []byte{1})
+	require.ErrorIs(t, err, types.ErrReservedStorageKey)
+
+	// keys around the reserved prefix are not hidden
+	require.NoError(t, ctx.contractStorageSet([]byte{0}, []byte{1}))
+	require.NoError(t, ctx.contractStorageSet([]byte("\x00wasi_fr"), []byte{2}))
+	require.NoError(t, ctx.contractStorageSet([]byte("key"), []byte{3}))
+
+	for _, reverse := range []bool{false, true} {
+		iter := ctx.contractStorageIterator(nil, nil, reverse)
+		values := []byte{}
+		for ; iter.Valid(); iter.Next() {
+			values = append(values, iter.Value()...)
+		}
+		require.NoError(t, iter.Close())
+		if reverse {
+			require.Equal(t, []byte{3, 2, 1}, values)
+		} else {
+			require.Equal(t, []byte{1, 2, 3}, values)
+		}
+	}
+
+	// an iterator over the reserved prefix is empty
+	iter := ctx.contractStorageIterator(WASI_FS_STORAGE_PREFIX, nil, false)
+	require.True(t, iter.Valid())
+	require.Equal(t, []byte("key"), iter.Key())
+	iter.Next()
+	require.False(t, iter.Valid())
+	require.NoError(t, iter.Close())
+}
+
+func TestWasiFsQuota(t *testing.T) {
+	wc := &WasiContext{c: newWasiFsTestContext(0)}
+	require.Equal(t, int64(types.DefaultWasiFsQuota), wc.fsQuota())
+
+	wc = &WasiContext{c: newWasiFsTestContext(10), persisted: map[string]int64{}}
+	wc.c.Env = &types.Env{}
+	require.True(t, wc.fsCanResize(WASI_FS_DATA_DIR+"/file", 10))
+	require.False(t, wc.fsCanResize(WASI_FS_DATA_DIR+"/file", 11))
+}
@@ -0,0 +1,99 @@
+package vm
+
+import (
+	"context"
+	"testing"
+
+	"cosmossdk.io/log"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	mcodec "github.com/loredanacirstea/wasmx/codec"
+	"github.com/loredanacirstea/wasmx/x/wasmx/types"
+	memc "github.com/loredanacirstea/wasmx/x/wasmx/vm/memory/common"
+)
+
+type testMemory struct {
+	data []byte
+}
+
+func (m *testMemory) Size() uint32 { return uint32(len(m.data)) }
+func (m *testMemory) ReadRaw(ptr interface{}, size interface{}) ([]byte, error) {
+	return m.Read(ptr.(int32), size.(int32))
+}
+func (m *testMemory) WriteRaw(ptr interface{}, data []byte) error {
+	return m.Write(ptr.(int32), data)
+}
+func (m *testMemory) Read(ptr int32, size int32) ([]byte, error) {
+	return m.data[ptr : ptr+size], nil
+}
+func (m *testMemory) Write(ptr int32, data []byte) error {
+	copy(m.data[ptr:], data)
+	return nil
+}
+
+type testGasMeter struct {
+	consumed uint64
+}
+
+func (g *testGasMeter) GasConsumed() types.Gas          { return g.consumed }
+func (g *testGasMeter) GasLimit() types.Gas             { return 1_000_000 }
+func (g *testGasMeter) GasRemaining() types.Gas         { return g.GasLimit() - g.consumed }
+func (g *testGasMeter) ConsumeGas(gas uint64, _ string) { g.consumed += gas }
+
+type testRuntimeHandler struct {
+	memc.RuntimeHandler
+	mem *testMemory
+}
+
+func (r testRuntimeHandler) GetMemory() (memc.IMemory, error) { return r.mem, nil }
+
+func newWasiRandomTestContext(mode sdk.ExecMode, deps []string) *WasiContext {
+	ctx := sdk.Context{}.WithContext(context.Background()).WithExecMode(mode)
+	return &WasiContext{c: &Context{
+		Ctx:      ctx,
+		GasMeter: &testGasMeter{},
+		Logger:   func(ctx sdk.Context) log.Logger { return log.NewNopLogger() },
+		Env: &types.Env{Contract: types.EnvContractInfo{
+			Address:    mcodec.NewAccAddressPrefixed([]byte{1, 2, 3}, "mythos"),
+			SystemDeps: deps,
+		}},
+	}}
+}
+
+func TestWasiRandomGetGas(t *testing.T) {
+	wc := newWasiRandomTestContext(sdk.ExecModeFinalize, nil)
+	rnh := testRuntimeHandler{mem: &testMemory{data: make([]byte, 100)}}
+
+	returns, err := wasi_randomGet(wc, rnh, []interface{}{int32(10), int32(50)})
+	require.NoError(t, err)
+	require.Equal(t, int32(0), returns[0])
+	require.Equal(t, uint64(WASI_RANDOM_GET_GAS_PER_BYTE*50), wc.c.GasMeter.GasConsumed())
+	require.NotEqual(t, make([]byte, 50), rnh.mem.data[10:60])
+
+	// out of bounds buffers are rejected without charging gas
+	returns, err = wasi_randomGet(wc, rnh, []interface{}{int32(60), int32(50)})
+	require.NoError(t, err)
+	require.Equal(t, int32(21), returns[0])
+	require.Equal(t, uint64(WASI_RANDOM_GET_GAS_PER_BYTE*50), wc.c.GasMeter.GasConsumed())
+}
+
+func TestWasiNonDeterministicSources(t *testing.T) {
+	deps := []string{types.SYS_ENV_1}
+	rnh := testRuntimeHandler{mem: &testMemory{data: make([]byte, 100)}}
+
+	wc := newWasiRandomTestContext(sdk.ExecModeFinalize, deps)
+	_, err := wasi_randomGet(wc, rnh, []interface{}{int32(0), int32(32)})
+	require.ErrorContains(t, err, "non-deterministic randomness cannot be used in consensus execution")
+	_, _, err = wc.ClockTime(__WASI_CLOCK_REALTIME)
+	require.ErrorContains(t, err, "non-deterministic clock cannot be used in consensus execution")
+
+	// queries run in check mode
+	wc = newWasiRandomTestContext(sdk.ExecModeCheck, deps)
+	returns, err := wasi_randomGet(wc, rnh, []interface{}{int32(0), int32(32)})
+	require.NoError(t, err)
+	require.Equal(t, int32(0), returns[0])
+	_, ok, err := wc.ClockTime(__WASI_CLOCK_REALTIME)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
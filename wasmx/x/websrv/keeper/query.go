@@ -114,6 +114,8 @@ func (k *Keeper) HttpGet(c context.Context, req *types.QueryHttpRequestGet) (*ty
 }
 
 func (k *Keeper) HttpGetInternal(ctx sdk.Context, req types.HttpRequest) (*types.HttpResponse, error) {
+	ctx, cancel := k.wasmx.WithQueryDeadline(ctx)
+	defer cancel()
 	headerMap := k.headersToMap(req)
 	path := headerMap[types.Path_Info]
 	contractAddress := k.GetMostSpecificRouteToContract(ctx, path)
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"context"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	mcodec "github.com/loredanacirstea/wasmx/codec"
@@ -9,6 +11,7 @@ import (
 
 type WasmxKeeper interface {
 	Query(ctx sdk.Context, contractAddr mcodec.AccAddressPrefixed, senderAddr mcodec.AccAddressPrefixed, msg types.RawContractMessage, funds sdk.Coins, deps []string) ([]byte, error)
+	WithQueryDeadline(ctx sdk.Context) (sdk.Context, context.CancelFunc)
 
 	AccBech32Codec() mcodec.AccBech32Codec
 }